@@ -0,0 +1,368 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/dogechain-lab/fastrlp"
+	"github.com/klauspost/compress/snappy"
+)
+
+// The era format stores the blocks as e2store entries, each made of a header with its type
+// and data length, then the data. A file starts with a version entry, then holds per block
+// its snappy framed header, body, receipts and total difficulty, and ends with the index of
+// the offsets of the block headers. The accumulator of the ethereum history is left out
+const (
+	eraTypeVersion            uint16 = 0x3265
+	eraTypeCompressedHeader   uint16 = 0x03
+	eraTypeCompressedBody     uint16 = 0x04
+	eraTypeCompressedReceipts uint16 = 0x05
+	eraTypeTotalDifficulty    uint16 = 0x06
+	eraTypeBlockIndex         uint16 = 0x3266
+
+	eraHeaderSize = 8
+)
+
+var (
+	// eraMagic is the version entry starting the era files
+	eraMagic = []byte{0x65, 0x32, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+
+	errEraTruncatedBlock = errors.New("era block without its header or body")
+	errEraNoIndex        = errors.New("era file without block index")
+)
+
+// eraWriter writes the blocks of an era file
+type eraWriter struct {
+	writer  io.Writer
+	written int64
+	start   uint64
+	offsets []int64
+}
+
+func newEraWriter(writer io.Writer) (*eraWriter, error) {
+	w := &eraWriter{writer: writer}
+
+	if err := w.writeEntry(eraTypeVersion, nil); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *eraWriter) writeEntry(typ uint16, data []byte) error {
+	header := make([]byte, eraHeaderSize)
+	binary.LittleEndian.PutUint16(header[0:2], typ)
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(data)))
+
+	if _, err := w.writer.Write(header); err != nil {
+		return err
+	}
+
+	if _, err := w.writer.Write(data); err != nil {
+		return err
+	}
+
+	w.written += int64(eraHeaderSize + len(data))
+
+	return nil
+}
+
+func (w *eraWriter) writeCompressed(typ uint16, data []byte) error {
+	var buf bytes.Buffer
+
+	snappyWriter := snappy.NewBufferedWriter(&buf)
+
+	if _, err := snappyWriter.Write(data); err != nil {
+		return err
+	}
+
+	if err := snappyWriter.Close(); err != nil {
+		return err
+	}
+
+	return w.writeEntry(typ, buf.Bytes())
+}
+
+// add writes the block, its receipts and the total difficulty of the chain at it
+func (w *eraWriter) add(block *types.Block, receipts types.Receipts, td *big.Int) error {
+	if len(w.offsets) == 0 {
+		w.start = block.Number()
+	}
+
+	w.offsets = append(w.offsets, w.written)
+
+	if err := w.writeCompressed(eraTypeCompressedHeader, block.Header.MarshalRLP()); err != nil {
+		return err
+	}
+
+	body := &eraBody{Transactions: block.Transactions, Uncles: block.Uncles}
+	if err := w.writeCompressed(eraTypeCompressedBody, types.MarshalRLPTo(body.MarshalRLPWith, nil)); err != nil {
+		return err
+	}
+
+	if err := w.writeCompressed(eraTypeCompressedReceipts, receipts.MarshalRLPTo(nil)); err != nil {
+		return err
+	}
+
+	// little endian, as the ssz integers
+	tdBytes := make([]byte, 32)
+
+	for i, b := range td.FillBytes(make([]byte, 32)) {
+		tdBytes[31-i] = b
+	}
+
+	return w.writeEntry(eraTypeTotalDifficulty, tdBytes)
+}
+
+// finish writes the index of the blocks, their offsets being relative to the index entry
+func (w *eraWriter) finish() error {
+	index := make([]byte, 8*(len(w.offsets)+2))
+
+	binary.LittleEndian.PutUint64(index[0:8], w.start)
+
+	for i, offset := range w.offsets {
+		binary.LittleEndian.PutUint64(index[8*(i+1):], uint64(offset-w.written))
+	}
+
+	binary.LittleEndian.PutUint64(index[len(index)-8:], uint64(len(w.offsets)))
+
+	return w.writeEntry(eraTypeBlockIndex, index)
+}
+
+// eraBody is the body of a block, encoded as in the block
+type eraBody types.Body
+
+func (b *eraBody) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+
+	if len(b.Transactions) == 0 {
+		vv.Set(ar.NewNullArray())
+	} else {
+		v0 := ar.NewArray()
+		for _, tx := range b.Transactions {
+			v0.Set(tx.MarshalRLPWith(ar))
+		}
+		vv.Set(v0)
+	}
+
+	if len(b.Uncles) == 0 {
+		vv.Set(ar.NewNullArray())
+	} else {
+		v1 := ar.NewArray()
+		for _, uncle := range b.Uncles {
+			v1.Set(uncle.MarshalRLPWith(ar))
+		}
+		vv.Set(v1)
+	}
+
+	return vv
+}
+
+func (b *eraBody) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 2 {
+		return fmt.Errorf("incorrect number of elements to decode body, expected at least 2 but found %d",
+			len(elems))
+	}
+
+	txns, err := elems[0].GetElems()
+	if err != nil {
+		return err
+	}
+
+	for _, txn := range txns {
+		bTxn := &types.Transaction{}
+		if err := bTxn.UnmarshalRLPFrom(p, txn); err != nil {
+			return err
+		}
+
+		b.Transactions = append(b.Transactions, bTxn)
+	}
+
+	uncles, err := elems[1].GetElems()
+	if err != nil {
+		return err
+	}
+
+	for _, uncle := range uncles {
+		bUncle := &types.Header{}
+		if err := bUncle.UnmarshalRLPFrom(p, uncle); err != nil {
+			return err
+		}
+
+		b.Uncles = append(b.Uncles, bUncle)
+	}
+
+	return nil
+}
+
+// eraReader reads the blocks of an era file
+type eraReader struct {
+	file   *os.File
+	reader *bufio.Reader
+}
+
+func newEraReader(file *os.File, reader *bufio.Reader) *eraReader {
+	return &eraReader{file: file, reader: reader}
+}
+
+// readEntry reads the next entry, returning io.EOF at the end of the file
+func (r *eraReader) readEntry() (uint16, []byte, error) {
+	header := make([]byte, eraHeaderSize)
+	if _, err := io.ReadFull(r.reader, header); err != nil {
+		return 0, nil, err
+	}
+
+	data := make([]byte, binary.LittleEndian.Uint32(header[2:6]))
+	if _, err := io.ReadFull(r.reader, data); err != nil {
+		return 0, nil, err
+	}
+
+	return binary.LittleEndian.Uint16(header[0:2]), data, nil
+}
+
+func decompressEra(data []byte) ([]byte, error) {
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
+
+// getMetadata returns the number and hash of the last block of the file, read from the index
+func (r *eraReader) getMetadata() (*Metadata, error) {
+	info, err := r.file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	// the count ends the index, which ends the file
+	if info.Size() < eraHeaderSize+16 {
+		return nil, errEraNoIndex
+	}
+
+	buf := make([]byte, 8)
+	if _, err := r.file.ReadAt(buf, info.Size()-8); err != nil {
+		return nil, err
+	}
+
+	count := binary.LittleEndian.Uint64(buf)
+	if count == 0 {
+		return nil, nil
+	}
+
+	indexStart := info.Size() - int64(8*(count+2)) - eraHeaderSize
+	if indexStart < 0 {
+		return nil, errEraNoIndex
+	}
+
+	index := make([]byte, eraHeaderSize+8*(count+2))
+	if _, err := r.file.ReadAt(index, indexStart); err != nil {
+		return nil, err
+	}
+
+	if binary.LittleEndian.Uint16(index[0:2]) != eraTypeBlockIndex {
+		return nil, errEraNoIndex
+	}
+
+	start := binary.LittleEndian.Uint64(index[eraHeaderSize:])
+	offset := int64(binary.LittleEndian.Uint64(index[eraHeaderSize+8*count:]))
+
+	// the header entry of the last block
+	entryHeader := make([]byte, eraHeaderSize)
+	if _, err := r.file.ReadAt(entryHeader, indexStart+offset); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, binary.LittleEndian.Uint32(entryHeader[2:6]))
+	if _, err := r.file.ReadAt(data, indexStart+offset+eraHeaderSize); err != nil {
+		return nil, err
+	}
+
+	raw, err := decompressEra(data)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &types.Header{}
+	if err := header.UnmarshalRLP(raw); err != nil {
+		return nil, err
+	}
+
+	return &Metadata{
+		Latest:     start + count - 1,
+		LatestHash: header.Hash,
+	}, nil
+}
+
+// nextBlock reads the next block, nil at the end of the blocks
+func (r *eraReader) nextBlock() (*types.Block, error) {
+	var block *types.Block
+
+	for {
+		typ, data, err := r.readEntry()
+		if errors.Is(err, io.EOF) {
+			if block != nil {
+				return nil, errEraTruncatedBlock
+			}
+
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch typ {
+		case eraTypeCompressedHeader:
+			raw, err := decompressEra(data)
+			if err != nil {
+				return nil, err
+			}
+
+			block = &types.Block{Header: &types.Header{}}
+			if err := block.Header.UnmarshalRLP(raw); err != nil {
+				return nil, err
+			}
+
+		case eraTypeCompressedBody:
+			if block == nil {
+				return nil, errEraTruncatedBlock
+			}
+
+			raw, err := decompressEra(data)
+			if err != nil {
+				return nil, err
+			}
+
+			body := &eraBody{}
+			if err := types.UnmarshalRlp(body.UnmarshalRLPFrom, raw); err != nil {
+				return nil, err
+			}
+
+			block.Transactions = body.Transactions
+			block.Uncles = body.Uncles
+
+		case eraTypeTotalDifficulty:
+			// the total difficulty closes the entries of the block,
+			// whose receipts are computed again when it is imported
+			if block == nil {
+				return nil, errEraTruncatedBlock
+			}
+
+			return block, nil
+
+		case eraTypeBlockIndex:
+			if block != nil {
+				return nil, errEraTruncatedBlock
+			}
+
+			return nil, nil
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package archive
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Formats of the exported chains
+const (
+	FormatRLP = "rlp"
+	FormatEra = "era"
+)
+
+// exportLogBlocks is the number of blocks exported between the progress logs
+const exportLogBlocks = 10000
+
+var (
+	ErrExportRange        = errors.New("invalid export range")
+	ErrExportBlockMissing = errors.New("block to export not found")
+)
+
+type exportChainInterface interface {
+	GetBlockByNumber(uint64, bool) (*types.Block, bool)
+	GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
+	GetTD(types.Hash) (*big.Int, bool)
+}
+
+// ExportResult is the outcome of a chain export
+type ExportResult struct {
+	Format string `json:"format"`
+	From   uint64 `json:"from"`
+	To     uint64 `json:"to"`
+	Blocks uint64 `json:"blocks"`
+}
+
+// FormatOf returns the format of the chain file, era for the .era and .era1 files, rlp otherwise
+func FormatOf(path string) string {
+	switch filepath.Ext(path) {
+	case ".era", ".era1":
+		return FormatEra
+	default:
+		return FormatRLP
+	}
+}
+
+// ExportChain writes the blocks of the range to a new file, in the format of its extension.
+// The RLP files start with the metadata of the last block, as the backups,
+// and the era files also hold the receipts and total difficulty of the blocks
+func ExportChain(
+	chain exportChainInterface,
+	logger hclog.Logger,
+	from, to uint64,
+	path string,
+) (result *ExportResult, err error) {
+	if from > to {
+		return nil, fmt.Errorf("%w: from %d above to %d", ErrExportRange, from, to)
+	}
+
+	last, ok := chain.GetBlockByNumber(to, false)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrExportBlockMissing, to)
+	}
+
+	fp, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if closeErr := fp.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+
+		// the partial file is not a valid chain file
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+
+	fbuf := bufio.NewWriterSize(fp, 1*1024*1024)
+
+	result = &ExportResult{
+		Format: FormatOf(path),
+		From:   from,
+		To:     to,
+	}
+
+	var (
+		write  func(block *types.Block) error
+		finish = func() error { return nil }
+	)
+
+	switch result.Format {
+	case FormatEra:
+		era, err := newEraWriter(fbuf)
+		if err != nil {
+			return nil, err
+		}
+
+		write = func(block *types.Block) error {
+			return writeEraBlock(chain, era, block)
+		}
+		finish = era.finish
+	default:
+		if err := writeMetadata(fbuf, logger, to, last.Hash()); err != nil {
+			return nil, err
+		}
+
+		write = func(block *types.Block) error {
+			_, err := fbuf.Write(block.MarshalRLP())
+
+			return err
+		}
+	}
+
+	for n := from; n <= to; n++ {
+		block, ok := chain.GetBlockByNumber(n, true)
+		if !ok {
+			return result, fmt.Errorf("%w: %d", ErrExportBlockMissing, n)
+		}
+
+		if err := write(block); err != nil {
+			return result, err
+		}
+
+		result.Blocks++
+
+		if result.Blocks%exportLogBlocks == 0 {
+			logger.Info("exporting blocks", "block", n, "to", to)
+		}
+	}
+
+	if err := finish(); err != nil {
+		return result, err
+	}
+
+	return result, fbuf.Flush()
+}
+
+func writeEraBlock(chain exportChainInterface, era *eraWriter, block *types.Block) error {
+	receipts, err := chain.GetReceiptsByHash(block.Hash())
+	// the genesis has no receipts stored
+	if errors.Is(err, storage.ErrNotFound) && len(block.Transactions) == 0 {
+		receipts, err = nil, nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read receipts of block %d, %w", block.Number(), err)
+	}
+
+	td, ok := chain.GetTD(block.Hash())
+	if !ok {
+		return fmt.Errorf("%w: total difficulty of %d", ErrExportBlockMissing, block.Number())
+	}
+
+	return era.add(block, receipts, td)
+}
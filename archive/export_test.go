@@ -0,0 +1,119 @@
+package archive
+
+import (
+	"bufio"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/helper/progress"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockExportChain struct {
+	mockChain
+}
+
+func (m *mockExportChain) GetReceiptsByHash(types.Hash) ([]*types.Receipt, error) {
+	return []*types.Receipt{{CumulativeGasUsed: 21000, TxHash: types.StringToHash("tx")}}, nil
+}
+
+func (m *mockExportChain) GetTD(hash types.Hash) (*big.Int, bool) {
+	for _, b := range m.blocks {
+		if b.Hash() == hash {
+			return new(big.Int).SetUint64(b.Number() + 1), true
+		}
+	}
+
+	return nil, false
+}
+
+func newMockExportChain() *mockExportChain {
+	return &mockExportChain{
+		mockChain: mockChain{
+			genesis: genesis,
+			blocks:  []*types.Block{genesis, blocks[0], blocks[1], blocks[2]},
+		},
+	}
+}
+
+func TestExportChain(t *testing.T) {
+	for _, name := range []string{"chain.rlp", "chain.era"} {
+		name := name
+
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+
+			result, err := ExportChain(newMockExportChain(), hclog.NewNullLogger(), 0, 3, path)
+			assert.NoError(t, err)
+			assert.Equal(t, &ExportResult{Format: FormatOf(name), From: 0, To: 3, Blocks: 4}, result)
+
+			chain := &mockChain{genesis: genesis, blocks: []*types.Block{}}
+
+			assert.NoError(t, RestoreChain(chain, path, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+
+			if assert.Len(t, chain.blocks, 3) {
+				for i, block := range chain.blocks {
+					assert.Equal(t, blocks[i].Hash(), block.Hash())
+				}
+			}
+		})
+	}
+}
+
+func TestExportChain_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := ExportChain(newMockExportChain(), hclog.NewNullLogger(), 2, 1, filepath.Join(dir, "range.rlp"))
+	assert.ErrorIs(t, err, ErrExportRange)
+
+	_, err = ExportChain(newMockExportChain(), hclog.NewNullLogger(), 0, 4, filepath.Join(dir, "missing.rlp"))
+	assert.ErrorIs(t, err, ErrExportBlockMissing)
+
+	// the partial file is removed
+	chain := newMockExportChain()
+	chain.blocks = []*types.Block{genesis, blocks[0], blocks[2]}
+
+	_, err = ExportChain(chain, hclog.NewNullLogger(), 0, 3, filepath.Join(dir, "partial.rlp"))
+	assert.ErrorIs(t, err, ErrExportBlockMissing)
+	assert.NoFileExists(t, filepath.Join(dir, "partial.rlp"))
+
+	existing := filepath.Join(dir, "existing.rlp")
+	assert.NoError(t, os.WriteFile(existing, nil, 0600))
+
+	_, err = ExportChain(newMockExportChain(), hclog.NewNullLogger(), 0, 3, existing)
+	assert.True(t, errors.Is(err, os.ErrExist))
+}
+
+func TestEraReader_getMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chain.era1")
+
+	_, err := ExportChain(newMockExportChain(), hclog.NewNullLogger(), 1, 2, path)
+	assert.NoError(t, err)
+
+	fp, err := os.Open(path)
+	assert.NoError(t, err)
+
+	defer fp.Close()
+
+	reader := newEraReader(fp, bufio.NewReader(fp))
+
+	metadata, err := reader.getMetadata()
+	assert.NoError(t, err)
+	assert.Equal(t, &Metadata{Latest: 2, LatestHash: blocks[1].Hash()}, metadata)
+
+	// the version entry is skipped by the reads of the blocks
+	for _, expected := range blocks[:2] {
+		block, err := reader.nextBlock()
+		assert.NoError(t, err)
+		assert.Equal(t, expected.Hash(), block.Hash())
+	}
+
+	block, err := reader.nextBlock()
+	assert.NoError(t, err)
+	assert.Nil(t, block)
+}
@@ -27,7 +27,13 @@ type blockchainInterface interface {
 	VerifyFinalizedBlock(*types.Block) error
 }
 
-// RestoreChain reads blocks from the archive and write to the chain
+// blockSource is a stream of blocks, preceded by the metadata of the last one
+type blockSource interface {
+	getMetadata() (*Metadata, error)
+	nextBlock() (*types.Block, error)
+}
+
+// RestoreChain reads blocks from the archive, in the RLP or era format, and write to the chain
 func RestoreChain(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
 	fp, err := os.OpenFile(filePath, os.O_RDONLY, 0)
 	if err != nil {
@@ -37,6 +43,10 @@ func RestoreChain(chain blockchainInterface, filePath string, progression *progr
 
 	fbuf := bufio.NewReaderSize(fp, 1*1024*1024) // 1MB buffer
 
+	if eraHeader, err := fbuf.Peek(len(eraMagic)); err == nil && bytes.Equal(eraHeader, eraMagic) {
+		return importBlocks(chain, newEraReader(fp, fbuf), progression)
+	}
+
 	// check whether the file is compressed
 	fileMagic, err := fbuf.Peek(len(zstdMagic))
 	if err != nil {
@@ -63,7 +73,7 @@ func RestoreChain(chain blockchainInterface, filePath string, progression *progr
 }
 
 // import blocks scans all blocks from stream and write them to chain
-func importBlocks(chain blockchainInterface, blockStream *blockStream, progression *progress.ProgressionWrapper) error {
+func importBlocks(chain blockchainInterface, blockStream blockSource, progression *progress.ProgressionWrapper) error {
 	shutdownCh := common.GetTerminationSignalCh()
 
 	metadata, err := blockStream.getMetadata()
@@ -135,7 +145,7 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 // returns the first block to be written into chain
 func consumeCommonBlocks(
 	chain blockchainInterface,
-	blockStream *blockStream,
+	blockStream blockSource,
 	shutdownCh <-chan os.Signal,
 ) (*types.Block, error) {
 	for {
@@ -289,7 +299,8 @@ func (b *blockStream) loadPayload(offset uint64, size uint64) error {
 	b.reserveCap(offset + size)
 	buf := b.buffer[offset : offset+size]
 
-	if _, err := b.input.Read(buf); err != nil {
+	// a single read stops at the end of the buffered data
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return err
 	}
 
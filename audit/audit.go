@@ -0,0 +1,245 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/exporter"
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultRetryInterval is the delay before auditing a block again once the sink failed
+	DefaultRetryInterval = 5 * time.Second
+	// pollInterval is the delay before checking the head again, in case an event is missed
+	pollInterval = 2 * time.Second
+)
+
+var ErrBlockNotFound = errors.New("block not found")
+
+// store is the blockchain the imported blocks are read from
+type store interface {
+	Header() *types.Header
+	GetBlockByNumber(number uint64, full bool) (*types.Block, bool)
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+	SubscribeEvents() blockchain.Subscription
+}
+
+// Match is a transaction satisfying an audit rule, published to the sink
+type Match struct {
+	Rule string `json:"rule"`
+
+	BlockNumber uint64     `json:"blockNumber"`
+	BlockHash   types.Hash `json:"blockHash"`
+	Timestamp   uint64     `json:"timestamp"`
+
+	TxHash  types.Hash     `json:"transactionHash"`
+	TxIndex uint64         `json:"transactionIndex"`
+	From    types.Address  `json:"from"`
+	To      *types.Address `json:"to"`
+	Value   string         `json:"value"`
+	// Status is the receipt status of the transaction, 1 if it succeeded
+	Status uint64 `json:"status"`
+}
+
+func newMatch(
+	rule string,
+	header *types.Header,
+	txIndex int,
+	tx *types.Transaction,
+	receipt *types.Receipt,
+) *Match {
+	match := &Match{
+		Rule:        rule,
+		BlockNumber: header.Number,
+		BlockHash:   header.Hash,
+		Timestamp:   header.Timestamp,
+		TxHash:      tx.Hash,
+		TxIndex:     uint64(txIndex),
+		From:        tx.From,
+		To:          tx.To,
+		Value:       "0x0",
+	}
+
+	if tx.Value != nil {
+		match.Value = hex.EncodeBig(tx.Value)
+	}
+
+	if receipt != nil && receipt.Status != nil {
+		match.Status = uint64(*receipt.Status)
+	}
+
+	return match
+}
+
+// Config is the configuration of the auditor
+type Config struct {
+	// CheckpointPath is the file holding the number of the last block audited
+	CheckpointPath string
+	// RetryInterval is the delay before auditing a block again once the sink failed
+	RetryInterval time.Duration
+}
+
+// Auditor evaluates the rules on every imported block, in order, and publishes
+// the matches to the sink. Without a checkpoint, it starts from the blocks imported
+// after the head, then resumes from the checkpoint, so that no block is missed.
+// The delivery is at least once, as the exporter.
+type Auditor struct {
+	logger hclog.Logger
+	store  store
+	sink   exporter.Sink
+	rules  *Rules
+	config *Config
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+// NewAuditor creates an auditor of the blocks of the store
+func NewAuditor(logger hclog.Logger, store store, sink exporter.Sink, rules *Rules, config *Config) *Auditor {
+	if config.RetryInterval == 0 {
+		config.RetryInterval = DefaultRetryInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Auditor{
+		logger: logger.Named("audit"),
+		store:  store,
+		sink:   sink,
+		rules:  rules,
+		config: config,
+		ctx:    ctx,
+		cancel: cancel,
+		doneCh: make(chan struct{}),
+	}
+}
+
+// Start starts auditing from the block following the checkpoint
+func (a *Auditor) Start() error {
+	next, err := a.resumeFrom()
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("auditing blocks", "from", next, "rules", len(a.rules.Rules))
+
+	go a.run(next)
+
+	return nil
+}
+
+// Close stops the auditor and closes the sink
+func (a *Auditor) Close() error {
+	a.cancel()
+	<-a.doneCh
+
+	return a.sink.Close()
+}
+
+// resumeFrom returns the first block to audit
+func (a *Auditor) resumeFrom() (uint64, error) {
+	last, ok, err := exporter.ReadCheckpoint(a.config.CheckpointPath)
+	if err != nil {
+		return 0, err
+	}
+
+	if ok {
+		return last + 1, nil
+	}
+
+	if header := a.store.Header(); header != nil {
+		return header.Number + 1, nil
+	}
+
+	return 1, nil
+}
+
+func (a *Auditor) run(next uint64) {
+	defer close(a.doneCh)
+
+	sub := a.store.SubscribeEvents()
+	defer sub.Close()
+
+	eventCh := sub.GetEventCh()
+
+	for {
+		for header := a.store.Header(); header != nil && next <= header.Number; next++ {
+			if !a.auditWithRetry(next) {
+				return
+			}
+		}
+
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-eventCh:
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// auditWithRetry audits the block until the sink acknowledges its matches,
+// it returns false if the auditor is closed meanwhile
+func (a *Auditor) auditWithRetry(number uint64) bool {
+	for {
+		err := a.audit(number)
+		if err == nil {
+			return true
+		}
+
+		if a.ctx.Err() != nil {
+			return false
+		}
+
+		a.logger.Warn("failed to audit block, retrying", "number", number, "err", err)
+
+		select {
+		case <-a.ctx.Done():
+			return false
+		case <-time.After(a.config.RetryInterval):
+		}
+	}
+}
+
+// audit publishes the matches of the block, then saves the checkpoint
+func (a *Auditor) audit(number uint64) error {
+	block, ok := a.store.GetBlockByNumber(number, true)
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrBlockNotFound, number)
+	}
+
+	var receipts []*types.Receipt
+
+	if len(block.Transactions) > 0 {
+		var err error
+
+		if receipts, err = a.store.GetReceiptsByHash(block.Hash()); err != nil {
+			return fmt.Errorf("unable to get receipts, %w", err)
+		}
+	}
+
+	matches := a.rules.Match(block, receipts)
+
+	for _, match := range matches {
+		if err := a.sink.Publish(a.ctx, match); err != nil {
+			return fmt.Errorf("unable to publish, %w", err)
+		}
+	}
+
+	if err := exporter.WriteCheckpoint(a.config.CheckpointPath, number); err != nil {
+		return fmt.Errorf("unable to save checkpoint, %w", err)
+	}
+
+	if len(matches) > 0 {
+		a.logger.Info("audit rules matched", "number", number, "matches", len(matches))
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/exporter"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var errSinkDown = errors.New("sink down")
+
+type mockStore struct {
+	lock   sync.Mutex
+	blocks []*types.Block
+}
+
+// newMockStore creates a chain up to the head, every block
+// having a transaction of the watched account
+func newMockStore(head uint64) *mockStore {
+	s := &mockStore{}
+
+	for i := uint64(0); i <= head; i++ {
+		s.addBlock()
+	}
+
+	return s
+}
+
+func (s *mockStore) addBlock() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	header := &types.Header{Number: uint64(len(s.blocks))}
+	if len(s.blocks) > 0 {
+		header.ParentHash = s.blocks[len(s.blocks)-1].Hash()
+	}
+
+	header.ComputeHash()
+
+	s.blocks = append(s.blocks, &types.Block{
+		Header:       header,
+		Transactions: []*types.Transaction{newTestTx(watched, &other, int64(header.Number))},
+	})
+}
+
+func (s *mockStore) Header() *types.Header {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.blocks[len(s.blocks)-1].Header
+}
+
+func (s *mockStore) GetBlockByNumber(number uint64, _ bool) (*types.Block, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if number >= uint64(len(s.blocks)) {
+		return nil, false
+	}
+
+	return s.blocks[number], true
+}
+
+func (s *mockStore) GetReceiptsByHash(types.Hash) ([]*types.Receipt, error) {
+	status := types.ReceiptSuccess
+
+	return []*types.Receipt{{Status: &status}}, nil
+}
+
+func (s *mockStore) SubscribeEvents() blockchain.Subscription {
+	return blockchain.NewMockSubscription()
+}
+
+type mockSink struct {
+	lock      sync.Mutex
+	failures  int
+	published []uint64
+}
+
+func (s *mockSink) Publish(_ context.Context, record interface{}) error {
+	match, ok := record.(*Match)
+	if !ok {
+		return errors.New("not a match")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+
+		return errSinkDown
+	}
+
+	s.published = append(s.published, match.BlockNumber)
+
+	return nil
+}
+
+func (s *mockSink) Close() error {
+	return nil
+}
+
+func (s *mockSink) Published() []uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]uint64{}, s.published...)
+}
+
+func newTestAuditor(t *testing.T, store store, sink exporter.Sink, checkpoint string) *Auditor {
+	t.Helper()
+
+	rules, err := ParseRules([]byte(`{"rules": [{"name": "watchlist", "addresses": ["` + watched.String() + `"]}]}`))
+	assert.NoError(t, err)
+
+	a := NewAuditor(hclog.NewNullLogger(), store, sink, rules, &Config{
+		CheckpointPath: checkpoint,
+		RetryInterval:  10 * time.Millisecond,
+	})
+
+	assert.NoError(t, a.Start())
+
+	return a
+}
+
+func waitForPublished(t *testing.T, sink *mockSink, count int) {
+	t.Helper()
+
+	assert.Eventually(t, func() bool {
+		return len(sink.Published()) >= count
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAuditor_AuditsImportedBlocks(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore(3)
+	sink := &mockSink{}
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+
+	a := newTestAuditor(t, store, sink, checkpoint)
+
+	// the blocks before the start are not audited
+	store.addBlock()
+	store.addBlock()
+	waitForPublished(t, sink, 2)
+
+	assert.NoError(t, a.Close())
+	assert.Equal(t, []uint64{4, 5}, sink.Published())
+
+	last, ok, err := exporter.ReadCheckpoint(checkpoint)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), last)
+}
+
+func TestAuditor_RetriesOnSinkFailure(t *testing.T) {
+	t.Parallel()
+
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	assert.NoError(t, exporter.WriteCheckpoint(checkpoint, 0))
+
+	sink := &mockSink{failures: 3}
+
+	a := newTestAuditor(t, newMockStore(2), sink, checkpoint)
+
+	waitForPublished(t, sink, 2)
+	assert.NoError(t, a.Close())
+
+	// no block is skipped nor published twice
+	assert.Equal(t, []uint64{1, 2}, sink.Published())
+}
+
+func TestAuditor_ResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	assert.NoError(t, exporter.WriteCheckpoint(checkpoint, 2))
+
+	sink := &mockSink{}
+
+	a := newTestAuditor(t, newMockStore(4), sink, checkpoint)
+
+	waitForPublished(t, sink, 2)
+	assert.NoError(t, a.Close())
+
+	assert.Equal(t, []uint64{3, 4}, sink.Published())
+}
@@ -0,0 +1,158 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+var (
+	ErrNoRules           = errors.New("no audit rules")
+	ErrUnnamedRule       = errors.New("audit rule without name")
+	ErrDuplicateRule     = errors.New("duplicate audit rule")
+	ErrRuleWithoutFilter = errors.New("audit rule without any condition")
+	ErrInvalidMinValue   = errors.New("invalid audit rule min value")
+)
+
+// Rules is the set of declarative rules evaluated on the transactions of every imported block
+type Rules struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Rule matches the transactions satisfying all of its conditions
+type Rule struct {
+	// Name identifies the rule in the matches
+	Name string `json:"name"`
+
+	// Addresses is the watchlist of the senders and recipients
+	Addresses []types.Address `json:"addresses,omitempty"`
+
+	// MinValue is the lowest value transferred in wei, decimal or 0x prefixed hex
+	MinValue string `json:"minValue,omitempty"`
+
+	watchlist map[types.Address]struct{}
+	minValue  *big.Int
+}
+
+// LoadRules reads the rules of the JSON file
+func LoadRules(path string) (*Rules, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read audit rules, %w", err)
+	}
+
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates the JSON encoded rules
+func ParseRules(data []byte) (*Rules, error) {
+	rules := &Rules{}
+	if err := json.Unmarshal(data, rules); err != nil {
+		return nil, fmt.Errorf("unable to parse audit rules, %w", err)
+	}
+
+	if len(rules.Rules) == 0 {
+		return nil, ErrNoRules
+	}
+
+	names := make(map[string]struct{}, len(rules.Rules))
+
+	for _, rule := range rules.Rules {
+		if rule.Name == "" {
+			return nil, ErrUnnamedRule
+		}
+
+		if _, ok := names[rule.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateRule, rule.Name)
+		}
+
+		names[rule.Name] = struct{}{}
+
+		if err := rule.init(); err != nil {
+			return nil, err
+		}
+	}
+
+	return rules, nil
+}
+
+// init builds the lookup structures of the conditions
+func (r *Rule) init() error {
+	if len(r.Addresses) == 0 && r.MinValue == "" {
+		return fmt.Errorf("%w: %s", ErrRuleWithoutFilter, r.Name)
+	}
+
+	if len(r.Addresses) > 0 {
+		r.watchlist = make(map[types.Address]struct{}, len(r.Addresses))
+
+		for _, addr := range r.Addresses {
+			r.watchlist[addr] = struct{}{}
+		}
+	}
+
+	if r.MinValue != "" {
+		minValue, err := types.ParseUint256orHex(&r.MinValue)
+		if err != nil || minValue.Sign() < 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidMinValue, r.Name)
+		}
+
+		r.minValue = minValue
+	}
+
+	return nil
+}
+
+// Match returns whether the transaction satisfies all the conditions of the rule
+func (r *Rule) Match(tx *types.Transaction) bool {
+	if r.watchlist != nil && !r.watches(tx) {
+		return false
+	}
+
+	if r.minValue != nil && (tx.Value == nil || tx.Value.Cmp(r.minValue) < 0) {
+		return false
+	}
+
+	return true
+}
+
+// watches returns whether the sender or the recipient of the transaction is in the watchlist
+func (r *Rule) watches(tx *types.Transaction) bool {
+	if _, ok := r.watchlist[tx.From]; ok {
+		return true
+	}
+
+	if tx.To != nil {
+		if _, ok := r.watchlist[*tx.To]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match evaluates the rules on the transactions of the block,
+// it returns a match for every rule satisfied by a transaction
+func (rs *Rules) Match(block *types.Block, receipts []*types.Receipt) []*Match {
+	matches := []*Match{}
+
+	for txIndex, tx := range block.Transactions {
+		for _, rule := range rs.Rules {
+			if !rule.Match(tx) {
+				continue
+			}
+
+			var receipt *types.Receipt
+			if txIndex < len(receipts) {
+				receipt = receipts[txIndex]
+			}
+
+			matches = append(matches, newMatch(rule.Name, block.Header, txIndex, tx, receipt))
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,123 @@
+package audit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	watched = types.StringToAddress("1")
+	other   = types.StringToAddress("2")
+)
+
+func newTestTx(from types.Address, to *types.Address, value int64) *types.Transaction {
+	tx := &types.Transaction{
+		From:  from,
+		To:    to,
+		Value: big.NewInt(value),
+	}
+
+	tx.Hash = types.BytesToHash(big.NewInt(value).Bytes())
+
+	return tx
+}
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"no rules", `{"rules": []}`, ErrNoRules},
+		{"unnamed", `{"rules": [{"minValue": "1"}]}`, ErrUnnamedRule},
+		{"duplicate", `{"rules": [{"name": "a", "minValue": "1"}, {"name": "a", "minValue": "2"}]}`, ErrDuplicateRule},
+		{"no condition", `{"rules": [{"name": "a"}]}`, ErrRuleWithoutFilter},
+		{"invalid value", `{"rules": [{"name": "a", "minValue": "1eth"}]}`, ErrInvalidMinValue},
+		{"valid", `{"rules": [{"name": "a", "minValue": "0x10"}, {"name": "b", "addresses": ["` +
+			watched.String() + `"]}]}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(tt.data))
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRule_Match(t *testing.T) {
+	rules, err := ParseRules([]byte(`{"rules": [
+		{"name": "watchlist", "addresses": ["` + watched.String() + `"]},
+		{"name": "large", "minValue": "1000"},
+		{"name": "watched large", "addresses": ["` + watched.String() + `"], "minValue": "1000"}
+	]}`))
+	assert.NoError(t, err)
+
+	watchlist, large, watchedLarge := rules.Rules[0], rules.Rules[1], rules.Rules[2]
+
+	tests := []struct {
+		name     string
+		tx       *types.Transaction
+		expected []bool
+	}{
+		{"watched sender", newTestTx(watched, &other, 1), []bool{true, false, false}},
+		{"watched recipient", newTestTx(other, &watched, 1000), []bool{true, true, true}},
+		{"large transfer", newTestTx(other, &other, 1001), []bool{false, true, false}},
+		{"contract creation", newTestTx(other, nil, 999), []bool{false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, []bool{
+				watchlist.Match(tt.tx),
+				large.Match(tt.tx),
+				watchedLarge.Match(tt.tx),
+			})
+		})
+	}
+}
+
+func TestRules_Match(t *testing.T) {
+	rules, err := ParseRules([]byte(`{"rules": [
+		{"name": "watchlist", "addresses": ["` + watched.String() + `"]},
+		{"name": "large", "minValue": "1000"}
+	]}`))
+	assert.NoError(t, err)
+
+	header := &types.Header{Number: 5, Timestamp: 10}
+	header.ComputeHash()
+
+	txs := []*types.Transaction{
+		newTestTx(other, &other, 1),
+		newTestTx(watched, &other, 2000),
+	}
+
+	failed := types.ReceiptFailed
+	receipts := []*types.Receipt{{}, {Status: &failed}}
+
+	matches := rules.Match(&types.Block{Header: header, Transactions: txs}, receipts)
+
+	assert.Len(t, matches, 2)
+
+	for i, rule := range []string{"watchlist", "large"} {
+		assert.Equal(t, &Match{
+			Rule:        rule,
+			BlockNumber: 5,
+			BlockHash:   header.Hash,
+			Timestamp:   10,
+			TxHash:      txs[1].Hash,
+			TxIndex:     1,
+			From:        watched,
+			To:          &other,
+			Value:       "0x7d0",
+			Status:      uint64(types.ReceiptFailed),
+		}, matches[i])
+	}
+}
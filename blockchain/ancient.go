@@ -0,0 +1,58 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+)
+
+// ancientCheckpointBlocks is the number of blocks frozen between the progress checkpoints
+const ancientCheckpointBlocks = 1024
+
+// FreezeAncients moves the bodies and receipts of the canonical blocks older than the latest
+// keep ones to the ancient store of the storage, resuming from the last checkpoint.
+// The blocks of the forks are left in the hot store. It stops once the context is canceled,
+// recording the progress, and returns the number of blocks moved
+func (b *Blockchain) FreezeAncients(ctx context.Context, keep uint64) (uint64, error) {
+	head := b.Header()
+	if head == nil || head.Number < keep {
+		return 0, nil
+	}
+
+	// the blocks up to the limit, excluded, are frozen
+	limit := head.Number - keep + 1
+
+	from, _ := b.db.ReadAncientProgress()
+	frozen := uint64(0)
+
+	for n := from; n < limit; n++ {
+		if ctx.Err() != nil || b.isStopped() {
+			return frozen, b.db.WriteAncientProgress(n)
+		}
+
+		hash, ok := b.db.ReadCanonicalHash(n)
+		if !ok {
+			return frozen, fmt.Errorf("failed to read canonical hash of block %d", n)
+		}
+
+		moved, err := b.db.FreezeBlock(hash)
+		if err != nil {
+			return frozen, fmt.Errorf("failed to freeze block %d, %w", n, err)
+		}
+
+		if moved {
+			frozen++
+		}
+
+		if (n+1)%ancientCheckpointBlocks == 0 {
+			if err := b.db.WriteAncientProgress(n + 1); err != nil {
+				return frozen, err
+			}
+		}
+	}
+
+	if from >= limit {
+		return 0, nil
+	}
+
+	return frozen, b.db.WriteAncientProgress(limit)
+}
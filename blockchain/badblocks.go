@@ -0,0 +1,110 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// maxBadBlocks is the number of the most recent bad blocks kept
+const maxBadBlocks = 128
+
+var ErrKnownBadBlock = errors.New("known bad block")
+
+// loadBadBlocks loads the bad blocks recorded in the storage
+func (b *Blockchain) loadBadBlocks() error {
+	blocks, err := b.db.ReadBadBlocks()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	b.badBlocksLock.Lock()
+	defer b.badBlocksLock.Unlock()
+
+	b.badBlocks = blocks
+
+	return nil
+}
+
+// markBadBlock records the block rejected by the error, so that it is refused from then on.
+// The errors not caused by the block itself are not recorded
+func (b *Blockchain) markBadBlock(block *types.Block, err error) {
+	if err == nil || block == nil || block.Header == nil ||
+		errors.Is(err, ErrParentNotFound) || errors.Is(err, ErrKnownBadBlock) || errors.Is(err, ErrClosed) {
+		return
+	}
+
+	b.badBlocksLock.Lock()
+	defer b.badBlocksLock.Unlock()
+
+	hash := block.Hash()
+
+	for _, bad := range b.badBlocks {
+		if bad.Hash == hash {
+			return
+		}
+	}
+
+	b.badBlocks = append(b.badBlocks, &storage.BadBlock{
+		Hash:      hash,
+		Number:    block.Number(),
+		Reason:    err.Error(),
+		Timestamp: uint64(time.Now().Unix()),
+	})
+
+	if len(b.badBlocks) > maxBadBlocks {
+		b.badBlocks = b.badBlocks[len(b.badBlocks)-maxBadBlocks:]
+	}
+
+	b.logger.Warn("bad block recorded", "number", block.Number(), "hash", hash, "err", err)
+
+	if err := b.db.WriteBadBlocks(b.badBlocks); err != nil {
+		b.logger.Error("failed to write the bad blocks", "err", err)
+	}
+}
+
+// checkBadBlock returns an error if the block is a known bad block
+func (b *Blockchain) checkBadBlock(block *types.Block) error {
+	if bad, ok := b.GetBadBlock(block.Hash()); ok {
+		return fmt.Errorf("%w %d (%s): %s", ErrKnownBadBlock, bad.Number, bad.Hash, bad.Reason)
+	}
+
+	return nil
+}
+
+// GetBadBlock returns the bad block of the hash, if known
+func (b *Blockchain) GetBadBlock(hash types.Hash) (*storage.BadBlock, bool) {
+	b.badBlocksLock.RLock()
+	defer b.badBlocksLock.RUnlock()
+
+	for _, bad := range b.badBlocks {
+		if bad.Hash == hash {
+			return bad, true
+		}
+	}
+
+	return nil, false
+}
+
+// IsBadBlock returns whether the hash is the one of a known bad block
+func (b *Blockchain) IsBadBlock(hash types.Hash) bool {
+	_, ok := b.GetBadBlock(hash)
+
+	return ok
+}
+
+// BadBlocks returns the recent bad blocks, the most recent first
+func (b *Blockchain) BadBlocks() []*storage.BadBlock {
+	b.badBlocksLock.RLock()
+	defer b.badBlocksLock.RUnlock()
+
+	blocks := make([]*storage.BadBlock, len(b.badBlocks))
+	for i, bad := range b.badBlocks {
+		blocks[len(blocks)-1-i] = bad
+	}
+
+	return blocks
+}
@@ -0,0 +1,122 @@
+package blockchain
+
+import (
+	"math/big"
+
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// blockBatch collects the writes of the insertion of a header or a block, committed
+// to the storage at once. The caches and the current header are only updated with
+// them once committed, so that they never reflect a write which did not happen
+type blockBatch struct {
+	storage.Batch
+
+	headers   []*types.Header
+	canonical map[uint64]types.Hash
+	head      *types.Header
+	headTD    *big.Int
+}
+
+// newBatch creates a batch of writes on the storage
+func (b *Blockchain) newBatch() *blockBatch {
+	return &blockBatch{
+		Batch:     b.db.NewBatch(),
+		canonical: map[uint64]types.Hash{},
+	}
+}
+
+// writeHeader writes the header
+func (bb *blockBatch) writeHeader(h *types.Header) error {
+	if err := bb.WriteHeader(h); err != nil {
+		return err
+	}
+
+	bb.headers = append(bb.headers, h)
+
+	return nil
+}
+
+// writeCanonicalHash makes the hash the canonical block of the number
+func (bb *blockBatch) writeCanonicalHash(n uint64, hash types.Hash) error {
+	if err := bb.WriteCanonicalHash(n, hash); err != nil {
+		return err
+	}
+
+	bb.canonical[n] = hash
+
+	return nil
+}
+
+// writeCanonicalHeader writes the header as the head of the chain
+func (bb *blockBatch) writeCanonicalHeader(h *types.Header, diff *big.Int) error {
+	if err := bb.WriteCanonicalHeader(h, diff); err != nil {
+		return err
+	}
+
+	bb.headers = append(bb.headers, h)
+	bb.canonical[h.Number] = h.Hash
+	bb.setHead(h, diff)
+
+	return nil
+}
+
+// setHead sets the header the chain is at once the batch is committed
+func (bb *blockBatch) setHead(h *types.Header, diff *big.Int) {
+	bb.head = h
+	bb.headTD = diff
+}
+
+// commitBatch writes the batch, then updates the caches and the current header with it
+func (b *Blockchain) commitBatch(bb *blockBatch) error {
+	if err := bb.Write(); err != nil {
+		return err
+	}
+
+	for _, h := range bb.headers {
+		b.headersCache.Add(h.Hash, h)
+	}
+
+	for n, hash := range bb.canonical {
+		b.canonicalCache.Add(n, hash)
+	}
+
+	if bb.head != nil {
+		b.setCurrentHeader(bb.head, bb.headTD)
+	}
+
+	return nil
+}
+
+// clearInsertMarker clears the marker of the block which failed to be inserted
+func (b *Blockchain) clearInsertMarker() error {
+	batch := b.db.NewBatch()
+
+	if err := batch.DeleteInsertMarker(); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+// recoverInsertion clears the marker left by a block insertion interrupted by a stop
+// of the node. The writes of the block are committed at once, so that the chain is left
+// at its parent, and the block is written again once synced
+func (b *Blockchain) recoverInsertion() error {
+	marker, ok := b.db.ReadInsertMarker()
+	if !ok {
+		return nil
+	}
+
+	headNumber, _ := b.db.ReadHeadNumber()
+
+	b.logger.Warn(
+		"recovering from an interrupted block insertion",
+		"number", marker.Number,
+		"hash", marker.Hash,
+		"head", headNumber,
+	)
+
+	return b.clearInsertMarker()
+}
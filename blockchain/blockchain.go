@@ -10,7 +10,10 @@ import (
 
 	"github.com/dogechain-lab/dogechain/blockchain/storage"
 	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/contracts/bridge"
+	"github.com/dogechain-lab/dogechain/contracts/governance"
 	"github.com/dogechain-lab/dogechain/contracts/upgrader"
+	"github.com/dogechain-lab/dogechain/crypto"
 	"github.com/dogechain-lab/dogechain/helper/common"
 	"github.com/dogechain-lab/dogechain/state"
 	"github.com/dogechain-lab/dogechain/types"
@@ -22,6 +25,18 @@ import (
 const (
 	BlockGasTargetDivisor uint64 = 1024 // The bound divisor of the gas limit, used in update calculations
 	defaultCacheSize      int    = 10   // The default size for Blockchain LRU cache structures
+	headerCacheSize       int    = 512  // The size of the headers LRU cache
+	canonicalCacheSize    int    = 2048 // The size of the canonical hashes LRU cache
+	bodyCacheSize         int    = 256  // The size of the bodies LRU cache
+	receiptsWarmBlocks    uint64 = 64   // The number of the latest blocks whose receipts are warmed
+)
+
+// Names of the caches in the metrics
+const (
+	cacheHeaders    = "headers"
+	cacheCanonical  = "canonical"
+	cacheBodies     = "bodies"
+	cacheDifficulty = "difficulty"
 )
 
 var (
@@ -39,6 +54,8 @@ var (
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
 	ErrNilStorageBuilder    = errors.New("nil storage builder")
 	ErrClosed               = errors.New("blockchain is closed")
+	ErrBlockBodyTooLarge    = errors.New("block body exceeds size limit")
+	ErrInvalidBaseFee       = errors.New("invalid block base fee")
 )
 
 // Blockchain is a blockchain reference
@@ -54,6 +71,8 @@ type Blockchain struct {
 	genesis types.Hash   // The hash of the genesis block
 
 	headersCache    *lru.Cache // LRU cache for the headers
+	canonicalCache  *lru.Cache // LRU cache for the canonical hashes, by number
+	bodiesCache     *lru.Cache // LRU cache for the bodies
 	difficultyCache *lru.Cache // LRU cache for the difficulty
 
 	// We need to keep track of block receipts between the verification phase
@@ -67,6 +86,10 @@ type Blockchain struct {
 	// any new fields from being added
 	receiptsCache *lru.Cache // LRU cache for the block receipts
 
+	// The state changes of the latest blocks executed, kept in the same way as the receipts
+	// for the exporter. Nil unless enabled, since it is not needed otherwise
+	stateDiffCache *lru.Cache
+
 	currentHeader     atomic.Value // The current header
 	currentDifficulty atomic.Value // The current difficulty of the chain (total difficulty)
 
@@ -75,6 +98,9 @@ type Blockchain struct {
 	gpAverage *gasPriceAverage // A reference to the average gas price
 
 	metrics *Metrics
+
+	badBlocksLock sync.RWMutex
+	badBlocks     []*storage.BadBlock // The recent blocks rejected, the oldest first
 }
 
 // gasPriceAverage keeps track of the average gas price (rolling average)
@@ -94,6 +120,7 @@ type Verifier interface {
 
 type Executor interface {
 	ProcessBlock(parentRoot types.Hash, block *types.Block, blockCreator types.Address) (*state.Transition, error)
+	State() state.State
 	Stop()
 }
 
@@ -101,6 +128,7 @@ type BlockResult struct {
 	Root     types.Hash
 	Receipts []*types.Receipt
 	TotalGas uint64
+	Objects  []*state.Object
 }
 
 // updateGasPriceAvg updates the rolling average value of the gas price
@@ -222,21 +250,35 @@ func NewBlockchain(
 		return nil, err
 	}
 
+	if err := b.loadBadBlocks(); err != nil {
+		return nil, err
+	}
+
 	// Push the initial event to the stream
 	b.stream.push(&Event{})
 
 	return b, nil
 }
 
-// initCaches initializes the blockchain caches with the specified size
+// initCaches initializes the blockchain caches, the difficulty and receipts ones with the specified size
 func (b *Blockchain) initCaches(size int) error {
 	var err error
 
-	b.headersCache, err = lru.New(size)
+	b.headersCache, err = lru.New(headerCacheSize)
 	if err != nil {
 		return fmt.Errorf("unable to create headers cache, %w", err)
 	}
 
+	b.canonicalCache, err = lru.New(canonicalCacheSize)
+	if err != nil {
+		return fmt.Errorf("unable to create canonical hashes cache, %w", err)
+	}
+
+	b.bodiesCache, err = lru.New(bodyCacheSize)
+	if err != nil {
+		return fmt.Errorf("unable to create bodies cache, %w", err)
+	}
+
 	b.difficultyCache, err = lru.New(size)
 	if err != nil {
 		return fmt.Errorf("unable to create difficulty cache, %w", err)
@@ -250,6 +292,18 @@ func (b *Blockchain) initCaches(size int) error {
 	return nil
 }
 
+// getCached looks the key up in the cache, counting the hit or miss in the metrics of the cache
+func (b *Blockchain) getCached(cache *lru.Cache, name string, key interface{}) (interface{}, bool) {
+	value, ok := cache.Get(key)
+	if ok {
+		b.metrics.CacheHits.With("cache", name).Add(1)
+	} else {
+		b.metrics.CacheMisses.With("cache", name).Add(1)
+	}
+
+	return value, ok
+}
+
 // ComputeGenesis computes the genesis hash, and updates the blockchain reference
 func (b *Blockchain) ComputeGenesis() error {
 	// try to write the genesis block
@@ -257,6 +311,10 @@ func (b *Blockchain) ComputeGenesis() error {
 
 	if ok {
 		// initialized storage
+		if err := b.recoverInsertion(); err != nil {
+			return fmt.Errorf("failed to recover the interrupted block insertion: %w", err)
+		}
+
 		b.genesis, ok = b.db.ReadCanonicalHash(0)
 		if !ok {
 			return fmt.Errorf("failed to load genesis hash")
@@ -370,14 +428,62 @@ func (b *Blockchain) CalculateGasLimit(number uint64) (uint64, error) {
 		return 0, fmt.Errorf("parent of block %d not found", number)
 	}
 
-	return b.calculateGasLimit(parent.GasLimit), nil
+	params, err := b.GovernanceParams(parent)
+	if err != nil {
+		return 0, err
+	}
+
+	return b.calculateGasLimit(parent.GasLimit, params), nil
+}
+
+// CalculateBaseFee returns the base fee of the next block after parent,
+// which is zero before the london fork
+func (b *Blockchain) CalculateBaseFee(parent *types.Header) uint64 {
+	return b.config.Params.CalculateBaseFee(parent)
+}
+
+// GovernanceParams returns the governed parameters enacted in the state of the block.
+// All of them are zero before the governance fork.
+func (b *Blockchain) GovernanceParams(header *types.Header) (*governance.Params, error) {
+	forks := b.Config().Forks
+	if forks == nil || !forks.IsGovernance(header.Number) {
+		return &governance.Params{}, nil
+	}
+
+	st := b.executor.State()
+
+	snap, err := st.NewSnapshotAt(header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot of block %d, %w", header.Number, err)
+	}
+
+	return governance.ReadParams(state.NewTxn(st, snap)), nil
 }
 
-// calculateGasLimit calculates gas limit in reference to the block gas target
-func (b *Blockchain) calculateGasLimit(parentGasLimit uint64) uint64 {
+// BridgeSigners returns the addresses allowed to mint and burn bridged coins in the state of the header
+func (b *Blockchain) BridgeSigners(header *types.Header) ([]types.Address, error) {
+	st := b.executor.State()
+
+	snap, err := st.NewSnapshotAt(header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot of block %d, %w", header.Number, err)
+	}
+
+	return bridge.Signers(state.NewTxn(st, snap)), nil
+}
+
+// calculateGasLimit calculates gas limit in reference to the block gas target,
+// bounded by the governed gas target bounds
+func (b *Blockchain) calculateGasLimit(parentGasLimit uint64, params *governance.Params) uint64 {
 	// The gas limit cannot move more than 1/1024 * parentGasLimit
 	// in either direction per block
 	blockGasTarget := b.Config().BlockGasTarget
+	if blockGasTarget == 0 {
+		// Keep the parent gas limit if it is within the governed bounds
+		blockGasTarget = params.ClampGasTarget(parentGasLimit)
+	} else {
+		blockGasTarget = params.ClampGasTarget(blockGasTarget)
+	}
 
 	// Check if the gas limit target has been set
 	if blockGasTarget == 0 {
@@ -418,19 +524,25 @@ func (b *Blockchain) writeGenesis(genesis *chain.Genesis) error {
 
 // writeGenesisImpl writes the genesis file to the DB + blockchain reference
 func (b *Blockchain) writeGenesisImpl(header *types.Header) error {
-	// Update the reference
-	b.genesis = header.Hash
+	batch := b.newBatch()
 
 	// Update the DB
-	if err := b.db.WriteHeader(header); err != nil {
+	if err := batch.writeHeader(header); err != nil {
 		return err
 	}
 
 	// Advance the head
-	if _, err := b.advanceHead(header); err != nil {
+	if _, err := b.advanceHead(batch, header); err != nil {
+		return err
+	}
+
+	if err := b.commitBatch(batch); err != nil {
 		return err
 	}
 
+	// Update the reference
+	b.genesis = header.Hash
+
 	// Create an event and send it to the stream
 	event := &Event{}
 	event.AddNewHeader(header)
@@ -459,14 +571,14 @@ func (b *Blockchain) GetTD(hash types.Hash) (*big.Int, bool) {
 }
 
 // writeCanonicalHeader writes the new header
-func (b *Blockchain) writeCanonicalHeader(event *Event, h *types.Header) error {
+func (b *Blockchain) writeCanonicalHeader(batch *blockBatch, event *Event, h *types.Header) error {
 	parentTD, ok := b.readTotalDifficulty(h.ParentHash)
 	if !ok {
 		return fmt.Errorf("parent difficulty not found")
 	}
 
 	newTD := big.NewInt(0).Add(parentTD, new(big.Int).SetUint64(h.Difficulty))
-	if err := b.db.WriteCanonicalHeader(h, newTD); err != nil {
+	if err := batch.writeCanonicalHeader(h, newTD); err != nil {
 		return err
 	}
 
@@ -474,25 +586,23 @@ func (b *Blockchain) writeCanonicalHeader(event *Event, h *types.Header) error {
 	event.AddNewHeader(h)
 	event.SetDifficulty(newTD)
 
-	b.setCurrentHeader(h, newTD)
-
 	return nil
 }
 
 // advanceHead Sets the passed in header as the new head of the chain
-func (b *Blockchain) advanceHead(newHeader *types.Header) (*big.Int, error) {
+func (b *Blockchain) advanceHead(batch *blockBatch, newHeader *types.Header) (*big.Int, error) {
 	// Write the current head hash into storage
-	if err := b.db.WriteHeadHash(newHeader.Hash); err != nil {
+	if err := batch.WriteHeadHash(newHeader.Hash); err != nil {
 		return nil, err
 	}
 
 	// Write the current head number into storage
-	if err := b.db.WriteHeadNumber(newHeader.Number); err != nil {
+	if err := batch.WriteHeadNumber(newHeader.Number); err != nil {
 		return nil, err
 	}
 
 	// Matches the current head number with the current hash
-	if err := b.db.WriteCanonicalHash(newHeader.Number, newHeader.Hash); err != nil {
+	if err := batch.writeCanonicalHash(newHeader.Number, newHeader.Hash); err != nil {
 		return nil, err
 	}
 
@@ -510,12 +620,12 @@ func (b *Blockchain) advanceHead(newHeader *types.Header) (*big.Int, error) {
 
 	// Calculate the new total difficulty
 	newTD := big.NewInt(0).Add(parentTD, big.NewInt(0).SetUint64(newHeader.Difficulty))
-	if err := b.db.WriteTotalDifficulty(newHeader.Hash, newTD); err != nil {
+	if err := batch.WriteTotalDifficulty(newHeader.Hash, newTD); err != nil {
 		return nil, err
 	}
 
-	// Update the blockchain reference
-	b.setCurrentHeader(newHeader, newTD)
+	// Update the blockchain reference once committed
+	batch.setHead(newHeader, newTD)
 
 	return newTD, nil
 }
@@ -525,6 +635,12 @@ func (b *Blockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error
 	return b.db.ReadReceipts(hash)
 }
 
+// GetCompressedReceiptsByHash returns the snappy compressed receipts of the block in the
+// store format, as they are stored, so that they are served without decoding them
+func (b *Blockchain) GetCompressedReceiptsByHash(hash types.Hash) ([]byte, error) {
+	return b.db.ReadCompressedReceipts(hash)
+}
+
 // GetBodyByHash returns the body by their hash
 func (b *Blockchain) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
 	return b.readBody(hash)
@@ -538,7 +654,7 @@ func (b *Blockchain) GetHeaderByHash(hash types.Hash) (*types.Header, bool) {
 // readHeader Returns the header using the hash
 func (b *Blockchain) readHeader(hash types.Hash) (*types.Header, bool) {
 	// Try to find a hit in the headers cache
-	h, ok := b.headersCache.Get(hash)
+	h, ok := b.getCached(b.headersCache, cacheHeaders, hash)
 	if ok {
 		// Hit, return the3 header
 		header, ok := h.(*types.Header)
@@ -564,6 +680,12 @@ func (b *Blockchain) readHeader(hash types.Hash) (*types.Header, bool) {
 
 // readBody reads the block's body, using the block hash
 func (b *Blockchain) readBody(hash types.Hash) (*types.Body, bool) {
+	if cached, ok := b.getCached(b.bodiesCache, cacheBodies, hash); ok {
+		body, ok := cached.(*types.Body)
+
+		return body, ok
+	}
+
 	bb, err := b.db.ReadBody(hash)
 	if err != nil {
 		b.logger.Error("failed to read body", "err", err)
@@ -571,13 +693,33 @@ func (b *Blockchain) readBody(hash types.Hash) (*types.Body, bool) {
 		return nil, false
 	}
 
+	b.bodiesCache.Add(hash, bb)
+
 	return bb, true
 }
 
+// readCanonicalHash reads the hash of the canonical block of the number
+func (b *Blockchain) readCanonicalHash(n uint64) (types.Hash, bool) {
+	if cached, ok := b.getCached(b.canonicalCache, cacheCanonical, n); ok {
+		hash, ok := cached.(types.Hash)
+
+		return hash, ok
+	}
+
+	hash, ok := b.db.ReadCanonicalHash(n)
+	if !ok {
+		return types.Hash{}, false
+	}
+
+	b.canonicalCache.Add(n, hash)
+
+	return hash, true
+}
+
 // readTotalDifficulty reads the total difficulty associated with the hash
 func (b *Blockchain) readTotalDifficulty(headerHash types.Hash) (*big.Int, bool) {
 	// Try to find the difficulty in the cache
-	foundDifficulty, ok := b.difficultyCache.Get(headerHash)
+	foundDifficulty, ok := b.getCached(b.difficultyCache, cacheDifficulty, headerHash)
 	if ok {
 		// Hit, return the difficulty
 		fd, ok := foundDifficulty.(*big.Int)
@@ -602,7 +744,7 @@ func (b *Blockchain) readTotalDifficulty(headerHash types.Hash) (*big.Int, bool)
 
 // GetHeaderByNumber returns the header using the block number
 func (b *Blockchain) GetHeaderByNumber(n uint64) (*types.Header, bool) {
-	hash, ok := b.db.ReadCanonicalHash(n)
+	hash, ok := b.readCanonicalHash(n)
 	if !ok {
 		return nil, false
 	}
@@ -647,8 +789,14 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 
 	// Write the actual headers
 	for _, h := range headers {
+		batch := b.newBatch()
+
 		event := &Event{}
-		if err := b.writeHeaderImpl(event, h); err != nil {
+		if err := b.writeHeaderImpl(batch, event, h); err != nil {
+			return err
+		}
+
+		if err := b.commitBatch(batch); err != nil {
 			return err
 		}
 
@@ -659,12 +807,27 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 	return nil
 }
 
-// VerifyPotentialBlock does the minimal block verification without consulting the
+// VerifyPotentialBlock does the block verification without consulting the
 // consensus layer. Should only be used if consensus checks are done
-// outside the method call
+// outside the method call.
+//
+// The verification fully executes the block transactions, and compares the
+// resulting state root, gas used and receipts root with the proposed ones,
+// so that an invalid state transition is rejected before the block is voted for
 func (b *Blockchain) VerifyPotentialBlock(block *types.Block) error {
-	// Do just the initial block verification
-	return b.verifyBlock(block)
+	if block == nil {
+		return ErrNoBlock
+	}
+
+	if err := b.checkBadBlock(block); err != nil {
+		return err
+	}
+
+	// Do the block verification, executing the transactions
+	err := b.verifyBlock(block)
+	b.markBadBlock(block, err)
+
+	return err
 }
 
 // VerifyFinalizedBlock verifies that the block is valid by performing a series of checks.
@@ -678,19 +841,49 @@ func (b *Blockchain) VerifyFinalizedBlock(block *types.Block) error {
 		return ErrNoBlockHeader
 	}
 
+	if err := b.checkBadBlock(block); err != nil {
+		return err
+	}
+
 	// Make sure the consensus layer verifies this block header
 	if err := b.consensus.VerifyHeader(block.Header); err != nil {
-		return fmt.Errorf("failed to verify the header: %w", err)
+		err = fmt.Errorf("failed to verify the header: %w", err)
+		b.markBadBlock(block, err)
+
+		return err
 	}
 
 	// Do the initial block verification
 	if err := b.verifyBlock(block); err != nil {
+		b.markBadBlock(block, err)
+
 		return err
 	}
 
 	return nil
 }
 
+// VerifyTrustedBlock verifies a block of a chain trusted by its link to a checkpoint,
+// without the verification of its header and seals by the consensus
+func (b *Blockchain) VerifyTrustedBlock(block *types.Block) error {
+	if block == nil {
+		return ErrNoBlock
+	}
+
+	if block.Header == nil {
+		return ErrNoBlockHeader
+	}
+
+	if err := b.checkBadBlock(block); err != nil {
+		return err
+	}
+
+	err := b.verifyBlock(block)
+	b.markBadBlock(block, err)
+
+	return err
+}
+
 // verifyBlock does the base (common) block verification steps by
 // verifying the block body as well as the parent information
 func (b *Blockchain) verifyBlock(block *types.Block) error {
@@ -760,6 +953,11 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 		return fmt.Errorf("invalid gas limit, %w", gasLimitErr)
 	}
 
+	// Make sure the base fee follows the parent
+	if expected := b.CalculateBaseFee(parent); childBlock.Header.BaseFee != expected {
+		return fmt.Errorf("%w, expected %d, found %d", ErrInvalidBaseFee, expected, childBlock.Header.BaseFee)
+	}
+
 	return nil
 }
 
@@ -768,6 +966,34 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 // - The receipts match up
 // - The execution result matches up
 func (b *Blockchain) verifyBlockBody(block *types.Block) error {
+	if err := b.verifyBodyContent(block); err != nil {
+		return err
+	}
+
+	// Execute the transactions in the block and grab the result
+	blockResult, executeErr := b.executeBlockTransactions(block)
+	if executeErr != nil {
+		return fmt.Errorf("unable to execute block transactions, %w", executeErr)
+	}
+
+	// Verify the local execution result with the proposed block data
+	if err := blockResult.verifyBlockResult(block); err != nil {
+		return fmt.Errorf("unable to verify block execution result, %w", err)
+	}
+
+	return nil
+}
+
+// verifyBodyContent verifies the body against the header, without executing it:
+// the body size, the uncles root and the transactions root
+func (b *Blockchain) verifyBodyContent(block *types.Block) error {
+	// Make sure the block body fits in the size limit
+	if limit := b.config.Params.BlockBodySizeLimitAt(block.Number()); limit > 0 {
+		if size := block.BodySize(); size > limit {
+			return fmt.Errorf("%w, limit = %d, size = %d", ErrBlockBodyTooLarge, limit, size)
+		}
+	}
+
 	// Make sure the Uncles root matches up
 	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
 		b.logger.Error(fmt.Sprintf(
@@ -790,17 +1016,6 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) error {
 		return ErrInvalidTxRoot
 	}
 
-	// Execute the transactions in the block and grab the result
-	blockResult, executeErr := b.executeBlockTransactions(block)
-	if executeErr != nil {
-		return fmt.Errorf("unable to execute block transactions, %w", executeErr)
-	}
-
-	// Verify the local execution result with the proposed block data
-	if err := blockResult.verifyBlockResult(block); err != nil {
-		return fmt.Errorf("unable to verify block execution result, %w", err)
-	}
-
 	return nil
 }
 
@@ -869,20 +1084,71 @@ func (b *Blockchain) executeBlockTransactions(block *types.Block) (*BlockResult,
 		return nil, ErrClosed
 	}
 
-	_, root := txn.Commit()
+	_, root, objs := txn.CommitWithObjects()
 
 	// Append the receipts to the receipts cache
 	b.receiptsCache.Add(header.Hash, txn.Receipts())
 
+	if b.stateDiffCache != nil {
+		b.stateDiffCache.Add(header.Hash, objs)
+	}
+
 	return &BlockResult{
 		Root:     root,
 		Receipts: txn.Receipts(),
 		TotalGas: txn.TotalGas(),
+		Objects:  objs,
 	}, nil
 }
 
-// WriteBlock writes a single block
+// EnableStateDiffs keeps the state changes of the latest blocks executed
+func (b *Blockchain) EnableStateDiffs(size int) error {
+	cache, err := lru.New(size)
+	if err != nil {
+		return fmt.Errorf("unable to create state diff cache, %w", err)
+	}
+
+	b.stateDiffCache = cache
+
+	return nil
+}
+
+// GetStateDiff returns the state changes of the block. They are taken from the cache
+// if the block is recently executed, otherwise the block is executed again on top of its parent
+func (b *Blockchain) GetStateDiff(block *types.Block) ([]*state.Object, error) {
+	if b.stateDiffCache != nil {
+		if cached, ok := b.stateDiffCache.Get(block.Hash()); ok {
+			objs, ok := cached.([]*state.Object)
+			if !ok {
+				return nil, errors.New("invalid type assertion for state diff")
+			}
+
+			return objs, nil
+		}
+	}
+
+	result, err := b.executeBlockTransactions(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Root != block.Header.StateRoot {
+		return nil, ErrInvalidStateRoot
+	}
+
+	return result.Objects, nil
+}
+
+// WriteBlock writes a single block, recording it as a bad block if it is rejected
 func (b *Blockchain) WriteBlock(block *types.Block) error {
+	err := b.writeBlock(block)
+	b.markBadBlock(block, err)
+
+	return err
+}
+
+// writeBlock writes the verified block to the chain
+func (b *Blockchain) writeBlock(block *types.Block) error {
 	// Log the information
 	b.logger.Info(
 		"write block",
@@ -895,31 +1161,20 @@ func (b *Blockchain) WriteBlock(block *types.Block) error {
 	// nil checked by verify functions
 	header := block.Header
 
-	if err := b.writeBody(block); err != nil {
+	// Mark the block as being inserted, until the batch writing it is committed
+	if err := b.db.WriteInsertMarker(&storage.InsertMarker{
+		Number: header.Number,
+		Hash:   header.Hash,
+	}); err != nil {
 		return err
 	}
 
-	// Fetch the block receipts
-	blockReceipts, receiptsErr := b.extractBlockReceipts(block)
-	if receiptsErr != nil {
-		return receiptsErr
-	}
-
-	// write the receipts, do it only after the header has been written.
-	// Otherwise, a client might ask for a header once the receipt is valid,
-	// but before it is written into the storage
-	if err := b.db.WriteReceipts(block.Hash(), blockReceipts); err != nil {
-		return err
-	}
-
-	//	update snapshot
-	if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
-		return err
-	}
-
-	// Write the header to the chain
 	evnt := &Event{}
-	if err := b.writeHeaderImpl(evnt, header); err != nil {
+	if err := b.writeBlockBatch(block, evnt); err != nil {
+		if clearErr := b.clearInsertMarker(); clearErr != nil {
+			b.logger.Error("failed to clear the insert marker", "err", clearErr)
+		}
+
 		return err
 	}
 
@@ -958,6 +1213,120 @@ func (b *Blockchain) WriteBlock(block *types.Block) error {
 	return nil
 }
 
+// writeBlockBatch writes the body, the receipts, the transaction lookups and the header
+// of the block in a single batch, along with the canonical hashes and the head updates
+func (b *Blockchain) writeBlockBatch(block *types.Block, evnt *Event) error {
+	header := block.Header
+	batch := b.newBatch()
+
+	if err := writeBody(batch, block); err != nil {
+		return err
+	}
+
+	// Fetch the block receipts
+	blockReceipts, receiptsErr := b.extractBlockReceipts(block)
+	if receiptsErr != nil {
+		return receiptsErr
+	}
+
+	// the receipts are committed along with the header, so that a client
+	// never finds the header of the block without its receipts
+	if err := batch.WriteReceipts(block.Hash(), blockReceipts); err != nil {
+		return err
+	}
+
+	//	update snapshot
+	if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
+		return err
+	}
+
+	// Write the header to the chain
+	if err := b.writeHeaderImpl(batch, evnt, header); err != nil {
+		return err
+	}
+
+	if err := batch.DeleteInsertMarker(); err != nil {
+		return err
+	}
+
+	startT := time.Now()
+	err := b.commitBatch(batch)
+	b.metrics.BlockWrittenSeconds.Observe(time.Since(startT).Seconds())
+
+	return err
+}
+
+// WriteBlockWithReceipts verifies the block and the receipts retrieved along with it,
+// without executing its transactions, and writes them. It is used by the fast sync,
+// which writes the blocks preceding the state it downloads, so that the state of
+// the written block may not be available
+func (b *Blockchain) WriteBlockWithReceipts(block *types.Block, receipts []*types.Receipt) error {
+	if block == nil {
+		return ErrNoBlock
+	}
+
+	if block.Header == nil {
+		return ErrNoBlockHeader
+	}
+
+	header := block.Header
+
+	// Make sure the consensus layer verifies this block header
+	if err := b.consensus.VerifyHeader(header); err != nil {
+		return fmt.Errorf("failed to verify the header: %w", err)
+	}
+
+	if err := b.verifyBlockParent(block); err != nil {
+		return err
+	}
+
+	if err := b.verifyBodyContent(block); err != nil {
+		return err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return ErrInvalidReceiptsSize
+	}
+
+	if root := buildroot.CalculateReceiptsRoot(receipts); root != header.ReceiptsRoot {
+		return ErrInvalidReceiptsRoot
+	}
+
+	// fill the context fields, not retrieved along with the consensus ones
+	signer := crypto.NewSigner(b.config.Params.Forks.At(header.Number), uint64(b.config.Params.ChainID))
+	cumulativeGas := uint64(0)
+
+	for i, receipt := range receipts {
+		txn := block.Transactions[i]
+
+		if receipt.CumulativeGasUsed < cumulativeGas {
+			return ErrInvalidGasUsed
+		}
+
+		receipt.GasUsed = receipt.CumulativeGasUsed - cumulativeGas
+		receipt.TxHash = txn.Hash
+		cumulativeGas = receipt.CumulativeGasUsed
+
+		if txn.To == nil {
+			from, err := signer.Sender(txn)
+			if err != nil {
+				return fmt.Errorf("failed to recover the sender of %s, %w", txn.Hash, err)
+			}
+
+			receipt.SetContractAddress(crypto.CreateAddress(from, txn.Nonce))
+		}
+	}
+
+	if cumulativeGas != header.GasUsed {
+		return ErrInvalidGasUsed
+	}
+
+	// the receipts are picked from the cache, instead of executing the block
+	b.receiptsCache.Add(header.Hash, receipts)
+
+	return b.WriteBlock(block)
+}
+
 // extractBlockReceipts extracts the receipts from the passed in block
 func (b *Blockchain) extractBlockReceipts(block *types.Block) ([]*types.Receipt, error) {
 	// Check the cache for the block receipts
@@ -992,7 +1361,7 @@ func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
 
 	gasPrices := make([]*big.Int, len(block.Transactions))
 	for i, transaction := range block.Transactions {
-		gasPrices[i] = transaction.GasPrice
+		gasPrices[i] = transaction.EffectiveGasPrice(block.Header.BaseFee)
 	}
 
 	b.updateGasPriceAvg(gasPrices)
@@ -1000,23 +1369,28 @@ func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
 
 // writeBody writes the block body to the DB.
 // Additionally, it also updates the txn lookup, for txnHash -> block lookups
-func (b *Blockchain) writeBody(block *types.Block) error {
-	startT := time.Now()
-	defer func() {
-		endT := time.Now()
-		b.metrics.BlockWrittenSeconds.Observe(endT.Sub(startT).Seconds())
-	}()
-
+func writeBody(db storage.BlockWriter, block *types.Block) error {
 	body := block.Body()
 
 	// Write the full body (txns + receipts)
-	if err := b.db.WriteBody(block.Header.Hash, body); err != nil {
+	if err := db.WriteBody(block.Header.Hash, body); err != nil {
 		return err
 	}
 
-	// Write txn lookups (txHash -> block)
-	for _, txn := range block.Transactions {
-		if err := b.db.WriteTxLookup(txn.Hash, block.Hash()); err != nil {
+	// Write txn lookups (txHash -> block, index)
+	return writeTxLookups(db, block)
+}
+
+// writeTxLookups maps the transactions of the block to the block and their index in it
+func writeTxLookups(db storage.BlockWriter, block *types.Block) error {
+	for idx, txn := range block.Transactions {
+		entry := &storage.TxLookupEntry{
+			BlockHash:   block.Hash(),
+			BlockNumber: block.Number(),
+			Index:       uint64(idx),
+		}
+
+		if err := db.WriteTxLookup(txn.Hash, entry); err != nil {
 			return err
 		}
 	}
@@ -1031,6 +1405,36 @@ func (b *Blockchain) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return v, ok
 }
 
+// ReadTxLookupEntry returns the block and the index of the transaction using its hash.
+// The lookups written before the index was kept are resolved by scanning their block
+func (b *Blockchain) ReadTxLookupEntry(hash types.Hash) (*storage.TxLookupEntry, bool) {
+	if entry, ok := b.db.ReadTxLookupEntry(hash); ok {
+		return entry, true
+	}
+
+	blockHash, ok := b.db.ReadTxLookup(hash)
+	if !ok {
+		return nil, false
+	}
+
+	block, ok := b.GetBlockByHash(blockHash, true)
+	if !ok {
+		return nil, false
+	}
+
+	for idx, txn := range block.Transactions {
+		if txn.Hash == hash {
+			return &storage.TxLookupEntry{
+				BlockHash:   blockHash,
+				BlockNumber: block.Number(),
+				Index:       uint64(idx),
+			}, true
+		}
+	}
+
+	return nil, false
+}
+
 // verifyGasLimit is a helper function for validating a gas limit in a header
 func (b *Blockchain) verifyGasLimit(header, parentHeader *types.Header) error {
 	if header.GasUsed > header.GasLimit {
@@ -1108,8 +1512,8 @@ func (b *Blockchain) dispatchEvent(evnt *Event) {
 	b.stream.push(evnt)
 }
 
-// writeHeaderImpl writes a block and the data, assumes the genesis is already set
-func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
+// writeHeaderImpl writes a block and the data into the batch, assumes the genesis is already set
+func (b *Blockchain) writeHeaderImpl(batch *blockBatch, evnt *Event, header *types.Header) error {
 	currentHeader := b.Header()
 
 	currentTD, ok := b.readTotalDifficulty(currentHeader.Hash)
@@ -1128,7 +1532,7 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 	}
 
 	// Write the difficulty
-	if err := b.db.WriteTotalDifficulty(
+	if err := batch.WriteTotalDifficulty(
 		header.Hash,
 		big.NewInt(0).Add(
 			parentTD,
@@ -1139,23 +1543,20 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 	}
 
 	// Write header
-	if err := b.db.WriteHeader(header); err != nil {
+	if err := batch.writeHeader(header); err != nil {
 		return err
 	}
 
 	// Write canonical header
 	if header.ParentHash == currentHeader.Hash {
 		// Fast path to save the new canonical header
-		return b.writeCanonicalHeader(evnt, header)
+		return b.writeCanonicalHeader(batch, evnt, header)
 	}
 
-	// Update the headers cache
-	b.headersCache.Add(header.Hash, header)
-
 	incomingTD := big.NewInt(0).Add(parentTD, big.NewInt(0).SetUint64(header.Difficulty))
 	if incomingTD.Cmp(currentTD) > 0 {
 		// new block has higher difficulty, reorg the chain
-		if err := b.handleReorg(evnt, currentHeader, header); err != nil {
+		if err := b.handleReorg(batch, evnt, currentHeader, header); err != nil {
 			return err
 		}
 	} else {
@@ -1163,7 +1564,7 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 		evnt.AddOldHeader(header)
 		evnt.Type = EventFork
 
-		if err := b.writeFork(header); err != nil {
+		if err := b.writeFork(batch, header); err != nil {
 			return err
 		}
 	}
@@ -1172,7 +1573,7 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 }
 
 // writeFork writes the new header forks to the DB
-func (b *Blockchain) writeFork(header *types.Header) error {
+func (b *Blockchain) writeFork(batch *blockBatch, header *types.Header) error {
 	forks, err := b.db.ReadForks()
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
@@ -1191,15 +1592,17 @@ func (b *Blockchain) writeFork(header *types.Header) error {
 	}
 
 	newForks = append(newForks, header.Hash)
-	if err := b.db.WriteForks(newForks); err != nil {
+	if err := batch.WriteForks(newForks); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// handleReorg handles a reorganization event
+// handleReorg handles a reorganization event. The event lists the headers leaving
+// the canonical chain and the ones joining it, both from their head down to the common ancestor
 func (b *Blockchain) handleReorg(
+	batch *blockBatch,
 	evnt *Event,
 	oldHeader *types.Header,
 	newHeader *types.Header,
@@ -1214,61 +1617,63 @@ func (b *Blockchain) handleReorg(
 
 	// Fill up the old headers array
 	for oldHeader.Number > newHeader.Number {
+		oldChain = append(oldChain, oldHeader)
+
 		oldHeader, ok = b.readHeader(oldHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", oldHeader.ParentHash.String())
+			return fmt.Errorf("header '%s' not found", oldChain[len(oldChain)-1].ParentHash.String())
 		}
-
-		oldChain = append(oldChain, oldHeader)
 	}
 
 	// Fill up the new headers array
 	for newHeader.Number > oldHeader.Number {
+		newChain = append(newChain, newHeader)
+
 		newHeader, ok = b.readHeader(newHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", newHeader.ParentHash.String())
+			return fmt.Errorf("header '%s' not found", newChain[len(newChain)-1].ParentHash.String())
 		}
-
-		newChain = append(newChain, newHeader)
 	}
 
+	// Both sides are at the same height, walk them down to the common ancestor
 	for oldHeader.Hash != newHeader.Hash {
+		oldChain = append(oldChain, oldHeader)
+		newChain = append(newChain, newHeader)
+
 		oldHeader, ok = b.readHeader(oldHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", oldHeader.ParentHash.String())
+			return fmt.Errorf("header '%s' not found", oldChain[len(oldChain)-1].ParentHash.String())
 		}
 
 		newHeader, ok = b.readHeader(newHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", newHeader.ParentHash.String())
+			return fmt.Errorf("header '%s' not found", newChain[len(newChain)-1].ParentHash.String())
 		}
-
-		oldChain = append(oldChain, oldHeader)
 	}
 
-	for _, b := range oldChain[:len(oldChain)-1] {
-		evnt.AddOldHeader(b)
+	for _, h := range oldChain {
+		evnt.AddOldHeader(h)
 	}
 
-	evnt.AddOldHeader(oldChainHead)
-	evnt.AddNewHeader(newChainHead)
-
-	for _, b := range newChain {
-		evnt.AddNewHeader(b)
+	for _, h := range newChain {
+		evnt.AddNewHeader(h)
 	}
 
-	if err := b.writeFork(oldChainHead); err != nil {
-		return fmt.Errorf("failed to write the old header as fork: %w", err)
+	// the old head is kept as a fork, unless the new chain extends it
+	if len(oldChain) > 0 {
+		if err := b.writeFork(batch, oldChainHead); err != nil {
+			return fmt.Errorf("failed to write the old header as fork: %w", err)
+		}
 	}
 
-	// Update canonical chain numbers
-	for _, h := range newChain {
-		if err := b.db.WriteCanonicalHash(h.Number, h.Hash); err != nil {
+	// Update canonical chain numbers, the head being written when advanced
+	for _, h := range newChain[1:] {
+		if err := batch.writeCanonicalHash(h.Number, h.Hash); err != nil {
 			return err
 		}
 	}
 
-	diff, err := b.advanceHead(newChainHead)
+	diff, err := b.advanceHead(batch, newChainHead)
 	if err != nil {
 		return err
 	}
@@ -1285,6 +1690,33 @@ func (b *Blockchain) GetForks() ([]types.Hash, error) {
 	return b.db.ReadForks()
 }
 
+// ReadSyncProgress returns the progression of the interrupted bulk sync, if any
+func (b *Blockchain) ReadSyncProgress() (*storage.SyncProgress, bool) {
+	progress, err := b.db.ReadSyncProgress()
+	if err != nil || len(progress.Slots) == 0 {
+		return nil, false
+	}
+
+	return progress, true
+}
+
+// WriteSyncProgress writes the progression of the bulk sync, along with the headers
+// and the bodies of the blocks it downloaded ahead of the chain head. The blocks
+// are not written to the chain, only stored to be written once the sync is resumed
+func (b *Blockchain) WriteSyncProgress(progress *storage.SyncProgress, blocks []*types.Block) error {
+	for _, block := range blocks {
+		if err := b.db.WriteHeader(block.Header); err != nil {
+			return err
+		}
+
+		if err := b.db.WriteBody(block.Hash(), block.Body()); err != nil {
+			return err
+		}
+	}
+
+	return b.db.WriteSyncProgress(progress)
+}
+
 // GetBlockByHash returns the block using the block hash
 func (b *Blockchain) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
 	header, ok := b.readHeader(hash)
@@ -1315,7 +1747,7 @@ func (b *Blockchain) GetBlockByHash(hash types.Hash, full bool) (*types.Block, b
 
 // GetBlockByNumber returns the block using the block number
 func (b *Blockchain) GetBlockByNumber(blockNumber uint64, full bool) (*types.Block, bool) {
-	blockHash, ok := b.db.ReadCanonicalHash(blockNumber)
+	blockHash, ok := b.readCanonicalHash(blockNumber)
 	if !ok {
 		return nil, false
 	}
@@ -1328,6 +1760,96 @@ func (b *Blockchain) GetBlockByNumber(blockNumber uint64, full bool) (*types.Blo
 	return b.GetBlockByHash(blockHash, full)
 }
 
+// WarmCaches reads the headers and bodies of the latest blocks into the caches,
+// from the oldest to the head. The window is bounded by the bodies cache size,
+// the older blocks being evicted anyway. The receipts of the most recent ones
+// are read as well, for the storage to serve them from its own cache.
+// It returns the number of blocks read
+func (b *Blockchain) WarmCaches(blocks uint64) uint64 {
+	head := b.Header()
+	if head == nil || blocks == 0 {
+		return 0
+	}
+
+	if blocks > uint64(bodyCacheSize) {
+		blocks = uint64(bodyCacheSize)
+	}
+
+	from := uint64(0)
+	if head.Number >= blocks {
+		from = head.Number - blocks + 1
+	}
+
+	warmed := uint64(0)
+
+	for n := from; n <= head.Number; n++ {
+		if b.isStopped() {
+			break
+		}
+
+		block, ok := b.GetBlockByNumber(n, true)
+		if !ok {
+			b.logger.Warn("failed to warm block", "number", n)
+
+			continue
+		}
+
+		if head.Number-n < receiptsWarmBlocks && len(block.Transactions) > 0 {
+			if _, err := b.GetReceiptsByHash(block.Hash()); err != nil {
+				b.logger.Warn("failed to warm receipts", "number", n, "err", err)
+			}
+		}
+
+		warmed++
+	}
+
+	return warmed
+}
+
+// reencodeCheckpointBlocks is the number of blocks re-encoded between the progress checkpoints
+const reencodeCheckpointBlocks = 1024
+
+// ReencodeStorage rewrites the bodies and receipts of the canonical chain stored in the legacy
+// codec with the current one, resuming from the last checkpoint. The blocks written from now on
+// already use the current codec, so it stops at the head. It returns the number of blocks rewritten
+func (b *Blockchain) ReencodeStorage() (uint64, error) {
+	head := b.Header()
+	if head == nil {
+		return 0, nil
+	}
+
+	from, _ := b.db.ReadCodecProgress()
+	reencoded := uint64(0)
+
+	for n := from; n <= head.Number; n++ {
+		if b.isStopped() {
+			return reencoded, nil
+		}
+
+		hash, ok := b.db.ReadCanonicalHash(n)
+		if !ok {
+			return reencoded, fmt.Errorf("failed to read canonical hash of block %d", n)
+		}
+
+		rewritten, err := b.db.ReencodeBlock(hash)
+		if err != nil {
+			return reencoded, fmt.Errorf("failed to re-encode block %d, %w", n, err)
+		}
+
+		if rewritten {
+			reencoded++
+		}
+
+		if (n+1)%reencodeCheckpointBlocks == 0 {
+			if err := b.db.WriteCodecProgress(n + 1); err != nil {
+				return reencoded, err
+			}
+		}
+	}
+
+	return reencoded, b.db.WriteCodecProgress(head.Number + 1)
+}
+
 // Close closes the DB connection
 func (b *Blockchain) Close() error {
 	b.executor.Stop()
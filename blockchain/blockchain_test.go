@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -10,8 +11,10 @@ import (
 	"github.com/dogechain-lab/dogechain/blockchain/storage"
 	"github.com/dogechain-lab/dogechain/blockchain/storage/kvstorage"
 	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
 	"github.com/dogechain-lab/dogechain/state"
 	"github.com/dogechain-lab/dogechain/types"
+	"github.com/dogechain-lab/dogechain/types/buildroot"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 )
@@ -23,8 +26,7 @@ func TestGenesis(t *testing.T) {
 	genesis := &types.Header{Difficulty: 1, Number: 0}
 	genesis.ComputeHash()
 
-	_, err := b.advanceHead(genesis)
-	assert.NoError(t, err)
+	assert.NoError(t, b.writeGenesisImpl(genesis))
 
 	header := b.Header()
 	assert.Equal(t, header.Hash, genesis.Hash)
@@ -247,10 +249,10 @@ func TestInsertHeaders(t *testing.T) {
 						NewChain: []*header{
 							mock(0x4).Parent(0x1).Diff(10).Number(2),
 						},
-						// remove block 2 and 3
+						// remove block 3 and 2
 						OldChain: []*header{
-							mock(0x2),
 							mock(0x3),
+							mock(0x2),
 						},
 						Diff: big.NewInt(1 + 10),
 					},
@@ -391,8 +393,8 @@ func TestInsertHeaders(t *testing.T) {
 							mock(0x3).Parent(0x0).Diff(5),
 						},
 						OldChain: []*header{
-							mock(0x1),
 							mock(0x2),
+							mock(0x1),
 						},
 						Diff: big.NewInt(0 + 5),
 					},
@@ -426,6 +428,77 @@ func TestInsertHeaders(t *testing.T) {
 			},
 			TD: 0 + 1 + 2 + 10,
 		},
+		{
+			Name: "Reorg to a fork as long",
+			History: []*headerEvnt{
+				{
+					header: mock(0x0),
+				},
+				{
+					header: mock(0x1),
+					event: &evnt{
+						NewChain: []*header{
+							mock(0x1),
+						},
+						Diff: big.NewInt(1),
+					},
+				},
+				{
+					header: mock(0x2),
+					event: &evnt{
+						NewChain: []*header{
+							mock(0x2),
+						},
+						Diff: big.NewInt(1 + 2),
+					},
+				},
+				{
+					header: mock(0x3),
+					event: &evnt{
+						NewChain: []*header{
+							mock(0x3),
+						},
+						Diff: big.NewInt(1 + 2 + 3),
+					},
+				},
+				{
+					// fork 1. 0x1 -> 0x4
+					header: mock(0x4).Parent(0x1),
+					event: &evnt{
+						OldChain: []*header{
+							mock(0x4).Parent(0x1),
+						},
+					},
+				},
+				{
+					// 0x1 -> 0x4 -> 0x5, as long as the canonical chain
+					header: mock(0x5).Parent(0x4).Number(3).Diff(10),
+					event: &evnt{
+						NewChain: []*header{
+							mock(0x5).Parent(0x4).Number(3).Diff(10),
+							mock(0x4).Parent(0x1),
+						},
+						OldChain: []*header{
+							mock(0x3),
+							mock(0x2),
+						},
+						Diff: big.NewInt(1 + 4 + 10),
+					},
+				},
+			},
+			Head: mock(0x5).Parent(0x4).Number(3).Diff(10),
+			Forks: []*header{
+				mock(0x4),
+				mock(0x3),
+			},
+			Chain: []*header{
+				mock(0x0),
+				mock(0x1),
+				mock(0x4).Parent(0x1),
+				mock(0x5).Parent(0x4).Number(3).Diff(10),
+			},
+			TD: 0 + 1 + 4 + 10,
+		},
 	}
 
 	for _, cc := range cases {
@@ -531,8 +604,7 @@ func TestForkUnknownParents(t *testing.T) {
 	h1 := AppendNewTestHeaders(h0[:5], 10)
 
 	// Write genesis
-	_, err := b.advanceHead(h0[0])
-	assert.NoError(t, err)
+	assert.NoError(t, b.writeGenesisImpl(h0[0]))
 
 	// Write 10 headers
 	assert.NoError(t, b.WriteHeaders(h0[1:]))
@@ -561,9 +633,65 @@ func TestBlockchainWriteBody(t *testing.T) {
 	}
 	block.Header.ComputeHash()
 
-	if err := b.writeBody(block); err != nil {
+	if err := writeBody(b.db, block); err != nil {
 		t.Fatal(err)
 	}
+
+	entry, ok := storage.ReadTxLookupEntry(block.Transactions[0].Hash)
+	assert.True(t, ok)
+	assert.Equal(t, block.Hash(), entry.BlockHash)
+	assert.Equal(t, uint64(0), entry.Index)
+}
+
+func TestRebuildTxIndex(t *testing.T) {
+	db, err := kvstorage.NewMemoryStorageBuilder(hclog.NewNullLogger()).Build()
+	assert.NoError(t, err)
+
+	headers := NewTestHeaders(4)
+	blocks := make([]*types.Block, len(headers))
+
+	for i, header := range headers {
+		blocks[i] = &types.Block{Header: header}
+
+		if i > 0 {
+			for j := 0; j < i; j++ {
+				tx := &types.Transaction{
+					Nonce: uint64(j),
+					Value: big.NewInt(int64(i)),
+					V:     big.NewInt(1),
+				}
+				tx.ComputeHash()
+
+				blocks[i].Transactions = append(blocks[i].Transactions, tx)
+			}
+		}
+
+		assert.NoError(t, db.WriteHeader(header))
+		assert.NoError(t, db.WriteCanonicalHash(header.Number, header.Hash))
+		assert.NoError(t, db.WriteBody(header.Hash, blocks[i].Body()))
+	}
+
+	assert.NoError(t, db.WriteHeadNumber(3))
+
+	// a stale lookup of a reorganized block
+	stale := blocks[3].Transactions[2]
+	assert.NoError(t, db.WriteTxLookup(stale.Hash, &storage.TxLookupEntry{BlockHash: types.StringToHash("1")}))
+
+	result, err := RebuildTxIndex(db, hclog.NewNullLogger())
+	assert.NoError(t, err)
+	assert.Equal(t, &TxIndexResult{Blocks: 4, Transactions: 6}, result)
+
+	for _, block := range blocks {
+		for idx, tx := range block.Transactions {
+			entry, ok := db.ReadTxLookupEntry(tx.Hash)
+			assert.True(t, ok)
+			assert.Equal(t, &storage.TxLookupEntry{
+				BlockHash:   block.Hash(),
+				BlockNumber: block.Number(),
+				Index:       uint64(idx),
+			}, entry)
+		}
+	}
 }
 
 func TestCalculateGasLimit(t *testing.T) {
@@ -856,6 +984,35 @@ func TestBlockchain_VerifyBlockBody(t *testing.T) {
 		ParentHash: types.ZeroHash,
 	}
 
+	t.Run("Block body too large", func(t *testing.T) {
+		t.Parallel()
+
+		chainCallback := func(c *chain.Chain) {
+			c.Params.BlockBodySizeLimits = []*chain.BlockBodySizeLimit{
+				{Block: 0, Limit: 1024},
+			}
+		}
+
+		blockchain, err := NewMockBlockchain(map[TestCallbackType]interface{}{
+			ChainCallback: chainCallback,
+		})
+		if err != nil {
+			t.Fatalf("unable to instantiate new blockchain, %v", err)
+		}
+
+		block := &types.Block{
+			Header: &types.Header{
+				Number:     1,
+				Sha3Uncles: types.EmptyUncleHash,
+			},
+			Transactions: []*types.Transaction{
+				{Input: make([]byte, 1024)},
+			},
+		}
+
+		assert.ErrorIs(t, blockchain.verifyBlockBody(block), ErrBlockBodyTooLarge)
+	})
+
 	t.Run("Invalid SHA3 Uncles root", func(t *testing.T) {
 		t.Parallel()
 
@@ -998,3 +1155,732 @@ func TestBlockchain_VerifyBlockBody(t *testing.T) {
 		assert.ErrorIs(t, blockchain.verifyBlockBody(block), errUnableToExecute)
 	})
 }
+
+func TestBlockchain_WarmCaches(t *testing.T) {
+	headers := NewTestHeaders(20)
+	b := NewTestBlockchain(t, headers)
+
+	for _, h := range headers[1:] {
+		assert.NoError(t, writeBody(b.db, &types.Block{Header: h}))
+	}
+
+	tests := []struct {
+		name     string
+		blocks   uint64
+		expected uint64
+	}{
+		{"disabled", 0, 0},
+		{"latest blocks", 5, 5},
+		// the test chain only has the head hash of the genesis, not its header
+		{"more than the chain", 100, 19},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b.headersCache.Purge()
+			b.bodiesCache.Purge()
+
+			assert.Equal(t, tt.expected, b.WarmCaches(tt.blocks))
+			assert.Equal(t, int(tt.expected), b.bodiesCache.Len())
+
+			if tt.expected > 0 {
+				// the head is always kept in the cache
+				assert.True(t, b.headersCache.Contains(b.Header().Hash))
+			}
+		})
+	}
+}
+
+// receiptsReadsStorage counts the reads of the receipts
+type receiptsReadsStorage struct {
+	storage.Storage
+	reads uint64
+}
+
+func (s *receiptsReadsStorage) ReadReceipts(hash types.Hash) ([]*types.Receipt, error) {
+	s.reads++
+
+	return s.Storage.ReadReceipts(hash)
+}
+
+func TestBlockchain_WarmCaches_Receipts(t *testing.T) {
+	headers := NewTestHeaders(100)
+	b := NewTestBlockchain(t, headers)
+
+	for _, h := range headers[1:] {
+		tx := &types.Transaction{Nonce: h.Number, Value: big.NewInt(1), V: big.NewInt(1)}
+		tx.ComputeHash()
+
+		assert.NoError(t, writeBody(b.db, &types.Block{Header: h, Transactions: []*types.Transaction{tx}}))
+		assert.NoError(t, b.db.WriteReceipts(h.Hash, []*types.Receipt{{CumulativeGasUsed: 21000, TxHash: tx.Hash}}))
+	}
+
+	db := &receiptsReadsStorage{Storage: b.db}
+	b.db = db
+
+	assert.Equal(t, uint64(99), b.WarmCaches(100))
+
+	// only the receipts of the latest blocks are read
+	assert.Equal(t, receiptsWarmBlocks, db.reads)
+}
+
+func TestBlockchain_Caches(t *testing.T) {
+	headers := NewTestHeaders(10)
+	b := NewTestBlockchain(t, headers)
+
+	for _, h := range headers[1:] {
+		assert.NoError(t, writeBody(b.db, &types.Block{Header: h}))
+	}
+
+	b.canonicalCache.Purge()
+	b.bodiesCache.Purge()
+
+	block, ok := b.GetBlockByNumber(5, true)
+	assert.True(t, ok)
+	assert.Equal(t, headers[5].Hash, block.Hash())
+
+	assert.True(t, b.canonicalCache.Contains(uint64(5)))
+	assert.True(t, b.bodiesCache.Contains(headers[5].Hash))
+
+	// a longer fork from the block 4 replaces the cached canonical hashes
+	fork := AppendNewTestheadersWithSeed(headers[:5], 8, 1)
+	assert.NoError(t, b.WriteHeaders(fork[5:]))
+
+	for n := uint64(5); n < uint64(len(fork)); n++ {
+		header, ok := b.GetHeaderByNumber(n)
+		assert.True(t, ok)
+		assert.Equal(t, fork[n].Hash, header.Hash)
+	}
+}
+
+func TestBlockchain_ReencodeStorage(t *testing.T) {
+	headers := NewTestHeaders(20)
+	b := NewTestBlockchain(t, headers)
+
+	var (
+		visited  []uint64
+		progress []uint64
+	)
+
+	numbers := make(map[types.Hash]uint64, len(headers))
+	for _, h := range headers {
+		numbers[h.Hash] = h.Number
+	}
+
+	db := storage.NewMockStorage()
+	db.HookReadCanonicalHash(func(n uint64) (types.Hash, bool) {
+		return headers[n].Hash, true
+	})
+	db.HookReencodeBlock(func(hash types.Hash) (bool, error) {
+		visited = append(visited, numbers[hash])
+
+		// only the odd blocks are stored in the legacy codec
+		return numbers[hash]%2 == 1, nil
+	})
+	db.HookWriteCodecProgress(func(n uint64) error {
+		progress = append(progress, n)
+
+		return nil
+	})
+
+	b.db = db
+
+	// starts from the genesis
+	reencoded, err := b.ReencodeStorage()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), reencoded)
+	assert.Len(t, visited, 20)
+	assert.Equal(t, []uint64{20}, progress)
+
+	// resumes from the checkpoint
+	visited, progress = nil, nil
+
+	db.HookReadCodecProgress(func() (uint64, bool) {
+		return 15, true
+	})
+
+	reencoded, err = b.ReencodeStorage()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), reencoded)
+	assert.Equal(t, []uint64{15, 16, 17, 18, 19}, visited)
+	assert.Equal(t, []uint64{20}, progress)
+
+	// fails on a missing block
+	db.HookReadCanonicalHash(func(n uint64) (types.Hash, bool) {
+		return types.Hash{}, false
+	})
+
+	_, err = b.ReencodeStorage()
+	assert.Error(t, err)
+}
+
+func TestBlockchain_FreezeAncients(t *testing.T) {
+	headers := NewTestHeaders(20)
+	b := NewTestBlockchain(t, headers)
+
+	var (
+		frozen   []uint64
+		progress []uint64
+	)
+
+	numbers := make(map[types.Hash]uint64, len(headers))
+	for _, h := range headers {
+		numbers[h.Hash] = h.Number
+	}
+
+	db := storage.NewMockStorage()
+	db.HookReadCanonicalHash(func(n uint64) (types.Hash, bool) {
+		return headers[n].Hash, true
+	})
+	db.HookFreezeBlock(func(hash types.Hash) (bool, error) {
+		frozen = append(frozen, numbers[hash])
+
+		return true, nil
+	})
+	db.HookWriteAncientProgress(func(n uint64) error {
+		progress = append(progress, n)
+
+		return nil
+	})
+
+	b.db = db
+
+	// keeps the latest blocks of the head 19
+	moved, err := b.FreezeAncients(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(15), moved)
+	assert.Len(t, frozen, 15)
+	assert.Equal(t, []uint64{15}, progress)
+
+	// resumes from the checkpoint
+	frozen, progress = nil, nil
+
+	db.HookReadAncientProgress(func() (uint64, bool) {
+		return 15, true
+	})
+
+	moved, err = b.FreezeAncients(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), moved)
+	assert.Equal(t, []uint64{15, 16, 17}, frozen)
+	assert.Equal(t, []uint64{18}, progress)
+
+	// nothing to freeze while the chain is shorter than the kept blocks
+	frozen, progress = nil, nil
+
+	moved, err = b.FreezeAncients(context.Background(), 30)
+	assert.NoError(t, err)
+	assert.Zero(t, moved)
+	assert.Empty(t, frozen)
+	assert.Empty(t, progress)
+
+	// records the progress once canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	moved, err = b.FreezeAncients(ctx, 2)
+	assert.NoError(t, err)
+	assert.Zero(t, moved)
+	assert.Equal(t, []uint64{15}, progress)
+}
+
+func TestBlockchain_IndexBloomBits(t *testing.T) {
+	addr := types.StringToAddress("1")
+	topic := types.StringToHash("2")
+
+	receipts := func(logs ...*types.Log) []*types.Receipt {
+		return []*types.Receipt{{Logs: logs}}
+	}
+
+	// blooms of the blocks logging
+	blooms := map[uint64]types.Bloom{
+		5:    types.CreateBloom(receipts(&types.Log{Address: addr})),
+		100:  types.CreateBloom(receipts(&types.Log{Address: addr, Topics: []types.Hash{topic}})),
+		4095: types.CreateBloom(receipts(&types.Log{Address: types.StringToAddress("3"), Topics: []types.Hash{topic}})),
+		4097: types.CreateBloom(receipts(&types.Log{Address: addr})),
+	}
+
+	headers := NewTestHeaders(BloomSectionSize + 10)
+	for i, header := range headers {
+		header.LogsBloom = blooms[header.Number]
+
+		if i > 0 {
+			header.ParentHash = headers[i-1].Hash
+		}
+
+		header.ComputeHash()
+	}
+
+	b := NewTestBlockchain(t, headers)
+
+	// the test chain does not store its genesis header
+	assert.NoError(t, b.db.WriteHeader(headers[0]))
+
+	// nothing matches before the index
+	assert.Equal(t, uint64(0), b.BloomIndexedBlocks())
+
+	matches, err := b.MatchBloomBits(0, BloomSectionSize, [][][]byte{{addr.Bytes()}})
+	assert.NoError(t, err)
+	assert.Empty(t, matches)
+
+	// only the complete section is indexed
+	indexed, err := b.IndexBloomBits()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), indexed)
+	assert.Equal(t, uint64(BloomSectionSize), b.BloomIndexedBlocks())
+
+	indexed, err = b.IndexBloomBits()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), indexed)
+
+	tests := []struct {
+		name    string
+		from    uint64
+		to      uint64
+		filters [][][]byte
+		matches []uint64
+	}{
+		{
+			"address",
+			0, BloomSectionSize + 9,
+			[][][]byte{{addr.Bytes()}},
+			[]uint64{5, 100},
+		},
+		{
+			"address and topic",
+			0, BloomSectionSize - 1,
+			[][][]byte{{addr.Bytes()}, {topic.Bytes()}},
+			[]uint64{100},
+		},
+		{
+			"any address and topic",
+			0, BloomSectionSize - 1,
+			[][][]byte{{addr.Bytes(), types.StringToAddress("3").Bytes()}, {topic.Bytes()}},
+			[]uint64{100, 4095},
+		},
+		{
+			"range",
+			6, BloomSectionSize - 1,
+			[][][]byte{{addr.Bytes()}},
+			[]uint64{100},
+		},
+		{
+			"no match",
+			0, BloomSectionSize - 1,
+			[][][]byte{{types.StringToHash("4").Bytes()}},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := b.MatchBloomBits(tt.from, tt.to, tt.filters)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.matches, matches)
+		})
+	}
+}
+
+func TestBlockchain_WriteBlockWithReceipts(t *testing.T) {
+	b := NewTestBlockchain(t, nil)
+	parent := b.Header()
+
+	to := types.StringToAddress("1")
+	txs := []*types.Transaction{
+		{Nonce: 0, To: &to, GasPrice: big.NewInt(1), Value: big.NewInt(1), V: big.NewInt(1)},
+		{Nonce: 1, To: &to, GasPrice: big.NewInt(1), Value: big.NewInt(2), V: big.NewInt(1)},
+	}
+
+	for _, tx := range txs {
+		tx.ComputeHash()
+	}
+
+	receipts := []*types.Receipt{
+		{CumulativeGasUsed: 21000},
+		{CumulativeGasUsed: 50000},
+	}
+
+	for _, receipt := range receipts {
+		receipt.SetStatus(types.ReceiptSuccess)
+	}
+
+	newBlock := func() *types.Block {
+		header := &types.Header{
+			ParentHash:   parent.Hash,
+			Number:       parent.Number + 1,
+			GasLimit:     parent.GasLimit,
+			GasUsed:      50000,
+			BaseFee:      b.CalculateBaseFee(parent),
+			Sha3Uncles:   types.EmptyUncleHash,
+			TxRoot:       buildroot.CalculateTransactionsRoot(txs),
+			ReceiptsRoot: buildroot.CalculateReceiptsRoot(receipts),
+			Difficulty:   1,
+		}
+		header.ComputeHash()
+
+		return &types.Block{Header: header, Transactions: txs}
+	}
+
+	t.Run("receipts mismatch", func(t *testing.T) {
+		block := newBlock()
+
+		assert.ErrorIs(t, b.WriteBlockWithReceipts(block, receipts[:1]), ErrInvalidReceiptsSize)
+
+		block.Header.ReceiptsRoot = types.ZeroHash
+		assert.ErrorIs(t, b.WriteBlockWithReceipts(block, receipts), ErrInvalidReceiptsRoot)
+
+		assert.Equal(t, parent.Number, b.Header().Number)
+	})
+
+	t.Run("written without execution", func(t *testing.T) {
+		block := newBlock()
+
+		assert.NoError(t, b.WriteBlockWithReceipts(block, receipts))
+		assert.Equal(t, block.Hash(), b.Header().Hash)
+
+		written, err := b.GetReceiptsByHash(block.Hash())
+		assert.NoError(t, err)
+		assert.Len(t, written, 2)
+		assert.Equal(t, txs[1].Hash, written[1].TxHash)
+		assert.Equal(t, uint64(29000), written[1].GasUsed)
+	})
+}
+
+func TestBlockchain_BadBlocks(t *testing.T) {
+	b := TestBlockchain(t, nil)
+	genesis := b.Header()
+
+	verifier, ok := b.consensus.(*MockVerifier)
+	assert.True(t, ok)
+
+	verified := 0
+	errInvalidSeal := errors.New("invalid seal")
+
+	verifier.HookVerifyHeader(func(*types.Header) error {
+		verified++
+
+		return errInvalidSeal
+	})
+
+	newBlock := func(parent types.Hash, number uint64) *types.Block {
+		header := &types.Header{ParentHash: parent, Number: number}
+		header.ComputeHash()
+
+		return &types.Block{Header: header}
+	}
+
+	t.Run("rejected block quarantined", func(t *testing.T) {
+		block := newBlock(genesis.Hash, 1)
+
+		assert.ErrorIs(t, b.VerifyFinalizedBlock(block), errInvalidSeal)
+		assert.True(t, b.IsBadBlock(block.Hash()))
+
+		// refused without another verification
+		assert.ErrorIs(t, b.VerifyFinalizedBlock(block), ErrKnownBadBlock)
+		assert.ErrorIs(t, b.VerifyPotentialBlock(block), ErrKnownBadBlock)
+		assert.Equal(t, 1, verified)
+
+		bad, ok := b.GetBadBlock(block.Hash())
+		assert.True(t, ok)
+		assert.Equal(t, uint64(1), bad.Number)
+		assert.Contains(t, bad.Reason, errInvalidSeal.Error())
+	})
+
+	t.Run("missing parent not recorded", func(t *testing.T) {
+		block := newBlock(types.StringToHash("unknown"), 5)
+
+		assert.ErrorIs(t, b.VerifyPotentialBlock(block), ErrParentNotFound)
+		assert.False(t, b.IsBadBlock(block.Hash()))
+	})
+
+	t.Run("persisted and bounded", func(t *testing.T) {
+		for i := uint64(0); i < maxBadBlocks+2; i++ {
+			b.markBadBlock(newBlock(genesis.Hash, 10+i), errInvalidSeal)
+		}
+
+		b.badBlocks = nil
+		assert.NoError(t, b.loadBadBlocks())
+
+		blocks := b.BadBlocks()
+		assert.Len(t, blocks, maxBadBlocks)
+		assert.Equal(t, uint64(10+maxBadBlocks+1), blocks[0].Number)
+		assert.Equal(t, uint64(12), blocks[maxBadBlocks-1].Number)
+	})
+}
+
+func TestBlockchain_SyncProgress(t *testing.T) {
+	b := TestBlockchain(t, nil)
+	genesis := b.Header()
+
+	_, ok := b.ReadSyncProgress()
+	assert.False(t, ok)
+
+	header := &types.Header{ParentHash: genesis.Hash, Number: 1}
+	header.ComputeHash()
+
+	block := &types.Block{Header: header}
+	progress := &storage.SyncProgress{
+		Number: 1,
+		Hash:   header.Hash,
+		Slots:  []*storage.SyncSlot{{From: 1, Hashes: []types.Hash{header.Hash}}},
+	}
+
+	assert.NoError(t, b.WriteSyncProgress(progress, []*types.Block{block}))
+
+	found, ok := b.ReadSyncProgress()
+	assert.True(t, ok)
+	assert.Equal(t, progress, found)
+
+	// the blocks are stored, not written to the chain
+	_, ok = b.GetHeaderByHash(header.Hash)
+	assert.True(t, ok)
+
+	_, ok = b.GetBodyByHash(header.Hash)
+	assert.True(t, ok)
+
+	assert.Equal(t, genesis.Hash, b.Header().Hash)
+
+	_, ok = b.GetHeaderByNumber(1)
+	assert.False(t, ok)
+
+	// an empty progression is no progression
+	assert.NoError(t, b.WriteSyncProgress(&storage.SyncProgress{}, nil))
+
+	_, ok = b.ReadSyncProgress()
+	assert.False(t, ok)
+}
+
+var errBatchWrite = errors.New("batch write failed")
+
+// failingBatchStorage fails to commit its batches
+type failingBatchStorage struct {
+	storage.Storage
+}
+
+func (s *failingBatchStorage) NewBatch() storage.Batch {
+	return &failingBatch{s.Storage.NewBatch()}
+}
+
+type failingBatch struct {
+	storage.Batch
+}
+
+func (b *failingBatch) Write() error {
+	return errBatchWrite
+}
+
+func TestBlockchain_WriteBlockAtomic(t *testing.T) {
+	b := TestBlockchain(t, nil)
+	genesis := b.Header()
+
+	tx := &types.Transaction{Nonce: 0, GasPrice: big.NewInt(1), Value: big.NewInt(1), V: big.NewInt(1)}
+	tx.ComputeHash()
+
+	header := &types.Header{ParentHash: genesis.Hash, Number: 1, Difficulty: 1}
+	header.ComputeHash()
+
+	block := &types.Block{Header: header, Transactions: []*types.Transaction{tx}}
+
+	// the receipts are taken from the cache instead of executing the block
+	b.receiptsCache.Add(header.Hash, []*types.Receipt{{CumulativeGasUsed: 21000}})
+
+	db := b.db
+	b.db = &failingBatchStorage{db}
+
+	assert.ErrorIs(t, b.WriteBlock(block), errBatchWrite)
+
+	// nothing of the block is written
+	assert.Equal(t, genesis.Hash, b.Header().Hash)
+
+	_, err := db.ReadHeader(header.Hash)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	_, err = db.ReadBody(header.Hash)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	_, err = db.ReadReceipts(header.Hash)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	_, ok := db.ReadTxLookup(tx.Hash)
+	assert.False(t, ok)
+
+	_, ok = b.GetHeaderByNumber(1)
+	assert.False(t, ok)
+
+	// the marker, failing to be cleared as well, is cleared once restarted
+	marker, ok := db.ReadInsertMarker()
+	assert.True(t, ok)
+	assert.Equal(t, &storage.InsertMarker{Number: 1, Hash: header.Hash}, marker)
+
+	b.db = db
+	assert.NoError(t, b.ComputeGenesis())
+	assert.Equal(t, genesis.Hash, b.Header().Hash)
+
+	_, ok = db.ReadInsertMarker()
+	assert.False(t, ok)
+
+	// written at once
+	assert.NoError(t, b.WriteBlock(block))
+	assert.Equal(t, header.Hash, b.Header().Hash)
+
+	found, ok := b.GetBlockByNumber(1, true)
+	assert.True(t, ok)
+	assert.Len(t, found.Transactions, 1)
+
+	receipts, err := b.GetReceiptsByHash(header.Hash)
+	assert.NoError(t, err)
+	assert.Len(t, receipts, 1)
+
+	entry, ok := b.ReadTxLookupEntry(tx.Hash)
+	assert.True(t, ok)
+	assert.Equal(t, header.Hash, entry.BlockHash)
+
+	_, ok = db.ReadInsertMarker()
+	assert.False(t, ok)
+}
+
+func TestBlockchain_SetHead(t *testing.T) {
+	headers := NewTestHeaders(10)
+
+	// the states of the blocks are empty
+	for i, h := range headers {
+		h.StateRoot = types.EmptyRootHash
+
+		if i > 0 {
+			h.ParentHash = headers[i-1].Hash
+		}
+
+		h.ComputeHash()
+	}
+
+	b := NewTestBlockchain(t, headers)
+
+	// forks from the blocks 6 and 2
+	fork := AppendNewTestheadersWithSeed(headers[:7], 2, 1)
+	assert.NoError(t, b.WriteHeaders(fork[7:]))
+
+	oldFork := AppendNewTestheadersWithSeed(headers[:3], 2, 2)
+	assert.NoError(t, b.WriteHeaders(oldFork[3:]))
+
+	forks, err := b.GetForks()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []types.Hash{fork[8].Hash, oldFork[4].Hash}, forks)
+
+	// a transaction of a block rewound
+	tx := &types.Transaction{Value: big.NewInt(1), V: big.NewInt(1)}
+	tx.ComputeHash()
+
+	assert.NoError(t, writeBody(b.db, &types.Block{Header: headers[8], Transactions: []*types.Transaction{tx}}))
+	assert.NoError(t, b.db.WriteAncientProgress(9))
+
+	result, err := b.SetHead(5)
+	assert.NoError(t, err)
+	assert.Equal(t, &SetHeadResult{
+		From:         9,
+		To:           5,
+		Hash:         headers[5].Hash,
+		Blocks:       4,
+		ForkBlocks:   2,
+		Transactions: 1,
+	}, result)
+
+	assert.Equal(t, headers[5].Hash, b.Header().Hash)
+
+	headNumber, ok := b.db.ReadHeadNumber()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), headNumber)
+
+	for _, h := range append(headers[6:], fork[7:]...) {
+		_, ok := b.GetHeaderByHash(h.Hash)
+		assert.False(t, ok, h.Number)
+
+		_, ok = b.GetHeaderByNumber(h.Number)
+		assert.False(t, ok, h.Number)
+	}
+
+	_, ok = b.ReadTxLookup(tx.Hash)
+	assert.False(t, ok)
+
+	forks, err = b.GetForks()
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{oldFork[4].Hash}, forks)
+
+	progress, ok := b.db.ReadAncientProgress()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(6), progress)
+
+	// the chain grows from the new head
+	grown := AppendNewTestheadersWithSeed(headers[:6], 3, 3)
+	assert.NoError(t, b.WriteHeaders(grown[6:]))
+	assert.Equal(t, grown[8].Hash, b.Header().Hash)
+
+	_, err = b.SetHead(8)
+	assert.ErrorIs(t, err, ErrSetHeadNotBelow)
+}
+
+func TestBlockchain_SetHead_MissingState(t *testing.T) {
+	headers := NewTestHeaders(5)
+	b := NewTestBlockchain(t, headers)
+
+	_, err := b.SetHead(2)
+	assert.ErrorIs(t, err, ErrSetHeadMissingState)
+	assert.Equal(t, headers[4].Hash, b.Header().Hash)
+}
+
+func TestBlockchain_SetHead_Frozen(t *testing.T) {
+	headers := NewTestHeaders(10)
+
+	// the states of the blocks are empty
+	for i, h := range headers {
+		h.StateRoot = types.EmptyRootHash
+
+		if i > 0 {
+			h.ParentHash = headers[i-1].Hash
+		}
+
+		h.ComputeHash()
+	}
+
+	b := newTestBlockchainWithStorage(t, headers, kvstorage.NewTieredStorageBuilder(
+		hclog.NewNullLogger(),
+		kvdb.NewMemoryBuilder(),
+		kvdb.NewMemoryBuilder(),
+	))
+
+	for _, h := range headers[1:] {
+		tx := &types.Transaction{Nonce: h.Number, Value: big.NewInt(1), V: big.NewInt(1)}
+		tx.ComputeHash()
+
+		assert.NoError(t, writeBody(b.db, &types.Block{Header: h, Transactions: []*types.Transaction{tx}}))
+		assert.NoError(t, b.db.WriteReceipts(h.Hash, []*types.Receipt{{CumulativeGasUsed: 21000, TxHash: tx.Hash}}))
+	}
+
+	// the blocks 1 to 7 are moved to the ancient store, the genesis has no body
+	moved, err := b.FreezeAncients(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), moved)
+
+	_, ok := b.GetBodyByHash(headers[6].Hash)
+	assert.True(t, ok)
+
+	_, err = b.SetHead(5)
+	assert.NoError(t, err)
+
+	// the frozen blocks rewound are deleted from the ancient store as well
+	for _, h := range headers[6:] {
+		_, ok := b.GetBodyByHash(h.Hash)
+		assert.False(t, ok, h.Number)
+
+		receipts, err := b.GetReceiptsByHash(h.Hash)
+		assert.Empty(t, receipts, h.Number)
+		assert.ErrorIs(t, err, storage.ErrNotFound, h.Number)
+	}
+
+	// the frozen blocks kept are still read back
+	_, ok = b.GetBodyByHash(headers[5].Hash)
+	assert.True(t, ok)
+
+	receipts, err := b.GetReceiptsByHash(headers[5].Hash)
+	assert.NoError(t, err)
+	assert.Len(t, receipts, 1)
+}
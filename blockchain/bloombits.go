@@ -0,0 +1,210 @@
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+const (
+	// BloomSectionSize is the number of blocks of a section of the bloom bits index
+	BloomSectionSize = 4096
+
+	// bloomBitLength is the number of bits of a logs bloom
+	bloomBitLength = types.BloomByteLength * 8
+)
+
+// IndexBloomBits indexes the logs blooms of the canonical chain by sections of BloomSectionSize blocks,
+// resuming from the last indexed section. For every bit of the blooms, a section stores the vector of
+// the blocks setting it, so that a log query reads the vectors of its few bits instead of every header.
+// Only the complete sections are indexed. It returns the number of sections indexed
+func (b *Blockchain) IndexBloomBits() (uint64, error) {
+	head := b.Header()
+	if head == nil {
+		return 0, nil
+	}
+
+	from, _ := b.db.ReadBloomSections()
+	indexed := uint64(0)
+
+	for section := from; (section+1)*BloomSectionSize <= head.Number+1; section++ {
+		if b.isStopped() {
+			break
+		}
+
+		if err := b.indexBloomSection(section); err != nil {
+			return indexed, fmt.Errorf("failed to index bloom section %d, %w", section, err)
+		}
+
+		if err := b.db.WriteBloomSections(section + 1); err != nil {
+			return indexed, err
+		}
+
+		indexed++
+	}
+
+	return indexed, nil
+}
+
+// indexBloomSection writes the bit vectors of the logs blooms of the section
+func (b *Blockchain) indexBloomSection(section uint64) error {
+	vectors := make([][]byte, bloomBitLength)
+	for bit := range vectors {
+		vectors[bit] = make([]byte, BloomSectionSize/8)
+	}
+
+	for i := uint64(0); i < BloomSectionSize; i++ {
+		n := section*BloomSectionSize + i
+
+		header, ok := b.GetHeaderByNumber(n)
+		if !ok {
+			return fmt.Errorf("failed to read header %d", n)
+		}
+
+		for idx, v := range header.LogsBloom {
+			if v == 0 {
+				// most blooms are almost empty
+				continue
+			}
+
+			// the bloom bytes are stored from the highest bits
+			for k := uint(0); k < 8; k++ {
+				if v&(1<<k) != 0 {
+					vectors[uint(types.BloomByteLength-1-idx)*8+k][i/8] |= 1 << (7 - i%8)
+				}
+			}
+		}
+	}
+
+	for bit, vector := range vectors {
+		if err := b.db.WriteBloomBits(uint(bit), section, vector); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BloomIndexedBlocks returns the number of the first blocks of the chain covered by the bloom bits index
+func (b *Blockchain) BloomIndexedBlocks() uint64 {
+	sections, _ := b.db.ReadBloomSections()
+
+	return sections * BloomSectionSize
+}
+
+// MatchBloomBits returns the numbers of the indexed blocks in [from, to] whose logs bloom might match
+// the filters, in order. Each filter lists the alternative values (addresses or topics) a log matches,
+// all the filters having to match, and an empty filter matches anything. The blocks above the indexed
+// ones are never returned
+func (b *Blockchain) MatchBloomBits(from, to uint64, filters [][][]byte) ([]uint64, error) {
+	if indexed := b.BloomIndexedBlocks(); to >= indexed {
+		if indexed == 0 {
+			return nil, nil
+		}
+
+		to = indexed - 1
+	}
+
+	if from > to {
+		return nil, nil
+	}
+
+	// the bits each value sets in a bloom
+	locations := make([][][3]uint, len(filters))
+
+	for i, filter := range filters {
+		for _, value := range filter {
+			locations[i] = append(locations[i], types.BloomBitLocations(value))
+		}
+	}
+
+	var matches []uint64
+
+	for section := from / BloomSectionSize; section <= to/BloomSectionSize; section++ {
+		match, err := b.matchBloomSection(section, locations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match bloom section %d, %w", section, err)
+		}
+
+		first := section * BloomSectionSize
+
+		for i := uint64(0); i < BloomSectionSize; i++ {
+			if n := first + i; n >= from && n <= to && match[i/8]&(1<<(7-i%8)) != 0 {
+				matches = append(matches, n)
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// matchBloomSection returns the vector of the blocks of the section matching all the filters
+func (b *Blockchain) matchBloomSection(section uint64, filters [][][3]uint) ([]byte, error) {
+	vectors := make(map[uint][]byte)
+
+	readVector := func(bit uint) ([]byte, error) {
+		if vector, ok := vectors[bit]; ok {
+			return vector, nil
+		}
+
+		vector, err := b.db.ReadBloomBits(bit, section)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vector of bit %d, %w", bit, err)
+		}
+
+		if len(vector) != BloomSectionSize/8 {
+			return nil, fmt.Errorf("invalid vector of bit %d, %d bytes", bit, len(vector))
+		}
+
+		vectors[bit] = vector
+
+		return vector, nil
+	}
+
+	match := newBloomVector(0xff)
+
+	for _, filter := range filters {
+		if len(filter) == 0 {
+			continue
+		}
+
+		// any of the values
+		anyMatch := newBloomVector(0)
+
+		for _, bits := range filter {
+			// all the bits of the value
+			valueMatch := newBloomVector(0xff)
+
+			for _, bit := range bits {
+				vector, err := readVector(bit)
+				if err != nil {
+					return nil, err
+				}
+
+				for i := range valueMatch {
+					valueMatch[i] &= vector[i]
+				}
+			}
+
+			for i := range anyMatch {
+				anyMatch[i] |= valueMatch[i]
+			}
+		}
+
+		for i := range match {
+			match[i] &= anyMatch[i]
+		}
+	}
+
+	return match, nil
+}
+
+// newBloomVector returns a section vector filled with the byte
+func newBloomVector(fill byte) []byte {
+	vector := make([]byte, BloomSectionSize/8)
+	for i := range vector {
+		vector[i] = fill
+	}
+
+	return vector
+}
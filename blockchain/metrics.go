@@ -19,6 +19,10 @@ type Metrics struct {
 	BlockWrittenSeconds metrics.Histogram
 	// Transaction number
 	TransactionNum metrics.Histogram
+	// Cache hits, by cache
+	CacheHits metrics.Counter
+	// Cache misses, by cache
+	CacheMisses metrics.Counter
 }
 
 // GetPrometheusMetrics return the blockchain metrics instance
@@ -29,6 +33,9 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 		labels = append(labels, labelsWithValues[i])
 	}
 
+	// the caches are told apart by their label
+	cacheLabels := append(append([]string{}, labels...), "cache")
+
 	return &Metrics{
 		GasPriceAverage: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
 			Namespace: namespace,
@@ -60,6 +67,18 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 			Name:      "transaction_number",
 			Help:      "Transaction number",
 		}, labels).With(labelsWithValues...),
+		CacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "blockchain",
+			Name:      "cache_hits",
+			Help:      "Number of the reads served by the caches",
+		}, cacheLabels).With(labelsWithValues...),
+		CacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "blockchain",
+			Name:      "cache_misses",
+			Help:      "Number of the reads missing the caches",
+		}, cacheLabels).With(labelsWithValues...),
 	}
 }
 
@@ -71,6 +90,8 @@ func NilMetrics() *Metrics {
 		BlockHeight:         discard.NewGauge(),
 		BlockWrittenSeconds: discard.NewHistogram(),
 		TransactionNum:      discard.NewHistogram(),
+		CacheHits:           discard.NewCounter(),
+		CacheMisses:         discard.NewCounter(),
 	}
 }
 
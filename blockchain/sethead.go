@@ -0,0 +1,206 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+var (
+	ErrSetHeadNotBelow     = errors.New("the new head is not below the head")
+	ErrSetHeadMissingState = errors.New("state of the new head not found")
+)
+
+// SetHeadResult is the outcome of a rewind of the chain
+type SetHeadResult struct {
+	From         uint64     `json:"from"`
+	To           uint64     `json:"to"`
+	Hash         types.Hash `json:"hash"`
+	Blocks       uint64     `json:"blocks"`
+	ForkBlocks   uint64     `json:"forkBlocks"`
+	Transactions uint64     `json:"transactions"`
+}
+
+// SetHead rewinds the canonical chain to the block of the number, whose state must be found.
+// The canonical blocks above it are deleted along with their receipts and transaction lookups,
+// and so are the blocks of the forks above it. The deletions and the new head are written at once,
+// so that an interrupted rewind leaves the chain at its head. The bloom bits index and the ancient
+// progress are moved back to the new head afterwards. The chain should not be running
+func (b *Blockchain) SetHead(number uint64) (*SetHeadResult, error) {
+	head := b.Header()
+	if head == nil || number >= head.Number {
+		return nil, fmt.Errorf("%w: %d", ErrSetHeadNotBelow, number)
+	}
+
+	hash, ok := b.readCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("failed to read canonical hash of block %d", number)
+	}
+
+	header, ok := b.readHeader(hash)
+	if !ok {
+		return nil, fmt.Errorf("failed to read header of block %d", number)
+	}
+
+	// the canonical hash is the one of the consensus, which may hash the headers its own way
+	header = header.Copy()
+	header.Hash = hash
+
+	diff, ok := b.readTotalDifficulty(hash)
+	if !ok {
+		return nil, fmt.Errorf("failed to read total difficulty of block %d", number)
+	}
+
+	if _, err := b.executor.State().NewSnapshotAt(header.StateRoot); err != nil {
+		return nil, fmt.Errorf("%w: %s, %v", ErrSetHeadMissingState, header.StateRoot, err)
+	}
+
+	result := &SetHeadResult{
+		From: head.Number,
+		To:   number,
+		Hash: hash,
+	}
+
+	batch := b.db.NewBatch()
+
+	for n := head.Number; n > number; n-- {
+		blockHash, ok := b.readCanonicalHash(n)
+		if !ok {
+			return nil, fmt.Errorf("failed to read canonical hash of block %d", n)
+		}
+
+		txs, err := b.deleteBlock(batch, blockHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete block %d, %w", n, err)
+		}
+
+		if err := batch.DeleteCanonicalHash(n); err != nil {
+			return nil, err
+		}
+
+		result.Blocks++
+		result.Transactions += txs
+	}
+
+	forks, err := b.rewindForks(batch, number, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := batch.WriteForks(forks); err != nil {
+		return nil, err
+	}
+
+	if err := batch.WriteHeadHash(hash); err != nil {
+		return nil, err
+	}
+
+	if err := batch.WriteHeadNumber(number); err != nil {
+		return nil, err
+	}
+
+	if err := batch.DeleteInsertMarker(); err != nil {
+		return nil, err
+	}
+
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+
+	b.headersCache.Purge()
+	b.canonicalCache.Purge()
+	b.bodiesCache.Purge()
+	b.difficultyCache.Purge()
+	b.receiptsCache.Purge()
+	b.setCurrentHeader(header, diff)
+
+	// only the complete sections stay indexed, and the blocks above the head are frozen again
+	if sections, _ := b.db.ReadBloomSections(); sections > (number+1)/BloomSectionSize {
+		if err := b.db.WriteBloomSections((number + 1) / BloomSectionSize); err != nil {
+			return result, err
+		}
+	}
+
+	if progress, ok := b.db.ReadAncientProgress(); ok && progress > number+1 {
+		if err := b.db.WriteAncientProgress(number + 1); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// deleteBlock deletes the block, and the lookups of its transactions pointing to it.
+// The lookups are kept if its body is not found, as for the headers written by the bulk sync.
+// It returns the number of lookups deleted
+func (b *Blockchain) deleteBlock(batch storage.Batch, hash types.Hash) (uint64, error) {
+	deleted := uint64(0)
+
+	if body, err := b.db.ReadBody(hash); err == nil {
+		for _, tx := range body.Transactions {
+			if blockHash, ok := b.db.ReadTxLookup(tx.Hash); !ok || blockHash != hash {
+				continue
+			}
+
+			if err := batch.DeleteTxLookup(tx.Hash); err != nil {
+				return deleted, err
+			}
+
+			deleted++
+		}
+	}
+
+	return deleted, batch.DeleteBlock(hash)
+}
+
+// rewindForks deletes the blocks of the forks above the number, down to the number or to
+// a canonical block. It returns the forks left, the ancestors of the deleted ones included
+func (b *Blockchain) rewindForks(batch storage.Batch, number uint64, result *SetHeadResult) ([]types.Hash, error) {
+	forks, err := b.db.ReadForks()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+
+	var (
+		kept = []types.Hash{}
+		// the forks may share their ancestors
+		seen = map[types.Hash]bool{}
+	)
+
+	for _, fork := range forks {
+		hash := fork
+
+		for !seen[hash] {
+			seen[hash] = true
+
+			header, ok := b.readHeader(hash)
+			if !ok {
+				break
+			}
+
+			if header.Number <= number {
+				if canonical, ok := b.readCanonicalHash(header.Number); !ok || canonical != hash {
+					kept = append(kept, hash)
+				}
+
+				break
+			}
+
+			// the canonical blocks are deleted on their own
+			if canonical, ok := b.readCanonicalHash(header.Number); ok && canonical == hash {
+				break
+			}
+
+			if _, err := b.deleteBlock(batch, hash); err != nil {
+				return nil, fmt.Errorf("failed to delete fork block %d, %w", header.Number, err)
+			}
+
+			result.ForkBlocks++
+			hash = header.ParentHash
+		}
+	}
+
+	return kept, nil
+}
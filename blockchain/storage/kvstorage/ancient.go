@@ -0,0 +1,199 @@
+package kvstorage
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+var ErrNoAncientStore = errors.New("no ancient store")
+
+// ancientPrefixes are the prefixes of the data of the blocks moved to the ancient store,
+// only read back once frozen
+var ancientPrefixes = [][]byte{BODY, RECEIPTS}
+
+// isAncientKey returns whether the key may be stored in the ancient store
+func isAncientKey(key []byte) bool {
+	for _, p := range ancientPrefixes {
+		if bytes.HasPrefix(key, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tieredKV writes to the hot db, and reads the bodies and receipts missing from it in
+// the ancient one, where the old blocks are moved to by FreezeBlock
+type tieredKV struct {
+	hot     KV
+	ancient KV
+}
+
+func (t *tieredKV) Set(p []byte, v []byte) error {
+	return t.hot.Set(p, v)
+}
+
+func (t *tieredKV) Get(p []byte) ([]byte, bool, error) {
+	v, ok, err := t.hot.Get(p)
+	if err != nil || ok || !isAncientKey(p) {
+		return v, ok, err
+	}
+
+	return t.ancient.Get(p)
+}
+
+// Batch returns a batch writing to the hot db, whose deletions of the bodies and receipts
+// are applied to the ancient db as well, as the blocks deleted may be frozen
+func (t *tieredKV) Batch() kvdb.KVBatch {
+	return &tieredBatch{
+		hot:     t.hot.Batch(),
+		ancient: t.ancient.Batch(),
+	}
+}
+
+func (t *tieredKV) Close() error {
+	hotErr := t.hot.Close()
+
+	if err := t.ancient.Close(); err != nil {
+		return err
+	}
+
+	return hotErr
+}
+
+// tieredBatch is a batch of the hot and ancient dbs of a tiered kv
+type tieredBatch struct {
+	hot     kvdb.KVBatch
+	ancient kvdb.KVBatch
+}
+
+func (b *tieredBatch) Set(k, v []byte) {
+	b.hot.Set(k, v)
+}
+
+func (b *tieredBatch) Delete(k []byte) {
+	b.hot.Delete(k)
+
+	if isAncientKey(k) {
+		b.ancient.Delete(k)
+	}
+}
+
+// Write commits the hot db first, so that the frozen blocks are deleted from the ancient db
+// once they are not referenced by the hot one anymore
+func (b *tieredBatch) Write() error {
+	if err := b.hot.Write(); err != nil {
+		return err
+	}
+
+	return b.ancient.Write()
+}
+
+type tieredStorageBuilder struct {
+	logger         hclog.Logger
+	dbBuilder      kvdb.Builder
+	ancientBuilder kvdb.Builder
+}
+
+func (builder *tieredStorageBuilder) Build() (storage.Storage, error) {
+	hot, err := builder.dbBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	ancient, err := builder.ancientBuilder.Build()
+	if err != nil {
+		hot.Close()
+
+		return nil, err
+	}
+
+	return newKeyValueStorage(builder.logger.Named("database"), &tieredKV{hot: hot, ancient: ancient}), nil
+}
+
+// NewTieredStorageBuilder creates the new blockchain storage builder, on a hot database holding
+// the recent blocks and the chain metadata, and an ancient one holding the bodies and receipts
+// of the old blocks, so that they may be kept on different volumes
+func NewTieredStorageBuilder(
+	logger hclog.Logger,
+	dbBuilder kvdb.Builder,
+	ancientBuilder kvdb.Builder,
+) storage.StorageBuilder {
+	return &tieredStorageBuilder{
+		logger:         logger,
+		dbBuilder:      dbBuilder,
+		ancientBuilder: ancientBuilder,
+	}
+}
+
+// ANCIENT //
+
+// FreezeBlock moves the body and receipts of the block from the hot db to the ancient one,
+// re-encoding them with the current codec. They are written to the ancient db before being
+// deleted from the hot one, so that an interrupted move leaves them readable.
+// It returns whether any of them was moved
+func (s *KeyValueStorage) FreezeBlock(hash types.Hash) (bool, error) {
+	tiered, ok := s.db.(*tieredKV)
+	if !ok {
+		return false, ErrNoAncientStore
+	}
+
+	batch := tiered.hot.Batch()
+	moved := false
+
+	for _, p := range ancientPrefixes {
+		key := append(append([]byte{}, p...), hash.Bytes()...)
+
+		data, ok, err := tiered.hot.Get(key)
+		if err != nil {
+			return false, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		raw, legacy, err := decodeStoreData(data)
+		if err != nil {
+			return false, err
+		}
+
+		if legacy {
+			data = encodeStoreData(raw)
+		}
+
+		if err := tiered.ancient.Set(key, data); err != nil {
+			return false, err
+		}
+
+		batch.Delete(key)
+
+		moved = true
+	}
+
+	if !moved {
+		return false, nil
+	}
+
+	return true, batch.Write()
+}
+
+// ReadAncientProgress returns the number of the next canonical block to freeze
+func (s *KeyValueStorage) ReadAncientProgress() (uint64, bool) {
+	data, ok := s.get(ANCIENT, NUMBER)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+// WriteAncientProgress writes the number of the next canonical block to freeze
+func (s *KeyValueStorage) WriteAncientProgress(n uint64) error {
+	return s.set(ANCIENT, NUMBER, s.encodeUint(n))
+}
@@ -0,0 +1,134 @@
+package kvstorage
+
+import (
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTieredStorage(t *testing.T) {
+	t.Helper()
+
+	f := func(t *testing.T) (storage.Storage, func()) {
+		t.Helper()
+
+		s, err := NewTieredStorageBuilder(
+			hclog.NewNullLogger(),
+			kvdb.NewMemoryBuilder(),
+			kvdb.NewMemoryBuilder(),
+		).Build()
+		assert.NoError(t, err)
+
+		return s, func() {
+			s.Close()
+		}
+	}
+	storage.TestStorage(t, f)
+}
+
+func TestKeyValueStorage_FreezeBlock(t *testing.T) {
+	t.Parallel()
+
+	hot := &memoryKV{map[string][]byte{}}
+	ancient := &memoryKV{map[string][]byte{}}
+
+	s, ok := newKeyValueStorage(hclog.NewNullLogger(), &tieredKV{hot: hot, ancient: ancient}).(*KeyValueStorage)
+	assert.True(t, ok)
+
+	header := &types.Header{Number: 1, ExtraData: []byte{}}
+	header.ComputeHash()
+
+	hash := header.Hash
+	body := &types.Body{}
+	receipts := []*types.Receipt{{CumulativeGasUsed: 21000, TxHash: types.StringToHash("1")}}
+
+	assert.NoError(t, s.WriteHeader(header))
+	assert.NoError(t, s.WriteBody(hash, body))
+	// written by a previous version
+	rr := types.Receipts(receipts)
+	assert.NoError(t, s.writeRLP(RECEIPTS, hash.Bytes(), &rr))
+
+	moved, err := s.FreezeBlock(hash)
+	assert.NoError(t, err)
+	assert.True(t, moved)
+
+	// the body and receipts are moved, re-encoded, the header is kept
+	for _, p := range [][]byte{BODY, RECEIPTS} {
+		key := append(append([]byte{}, p...), hash.Bytes()...)
+
+		_, ok, _ := hot.Get(key)
+		assert.False(t, ok)
+
+		data, ok, _ := ancient.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, codecSnappy, data[0])
+	}
+
+	_, ok, _ = hot.Get(append(append([]byte{}, HEADER...), hash.Bytes()...))
+	assert.True(t, ok)
+
+	// still read through the storage
+	_, err = s.ReadBody(hash)
+	assert.NoError(t, err)
+
+	readReceipts, err := s.ReadReceipts(hash)
+	assert.NoError(t, err)
+	assert.Len(t, readReceipts, 1)
+	assert.Equal(t, receipts[0].TxHash, readReceipts[0].TxHash)
+
+	_, err = s.ReadCompressedReceipts(hash)
+	assert.NoError(t, err)
+
+	// nothing left to move
+	moved, err = s.FreezeBlock(hash)
+	assert.NoError(t, err)
+	assert.False(t, moved)
+
+	// the progress is kept in the hot store
+	assert.NoError(t, s.WriteAncientProgress(10))
+
+	n, ok := s.ReadAncientProgress()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10), n)
+
+	// a storage without an ancient store does not freeze
+	plain, ok := newKeyValueStorage(hclog.NewNullLogger(), &memoryKV{map[string][]byte{}}).(*KeyValueStorage)
+	assert.True(t, ok)
+
+	_, err = plain.FreezeBlock(hash)
+	assert.ErrorIs(t, err, ErrNoAncientStore)
+}
+
+func TestKeyValueStorage_DeleteFrozenBlock(t *testing.T) {
+	t.Parallel()
+
+	hot := &memoryKV{map[string][]byte{}}
+	ancient := &memoryKV{map[string][]byte{}}
+
+	s := newKeyValueStorage(hclog.NewNullLogger(), &tieredKV{hot: hot, ancient: ancient})
+
+	header := &types.Header{Number: 1, ExtraData: []byte{}}
+	header.ComputeHash()
+
+	hash := header.Hash
+
+	assert.NoError(t, s.WriteHeader(header))
+	assert.NoError(t, s.WriteBody(hash, &types.Body{}))
+	assert.NoError(t, s.WriteReceipts(hash, []*types.Receipt{{CumulativeGasUsed: 21000}}))
+
+	moved, err := s.FreezeBlock(hash)
+	assert.NoError(t, err)
+	assert.True(t, moved)
+
+	batch := s.NewBatch()
+	assert.NoError(t, batch.DeleteBlock(hash))
+	assert.NoError(t, batch.Write())
+
+	// the body and receipts are deleted from the ancient store as well
+	assert.Empty(t, hot.db)
+	assert.Empty(t, ancient.db)
+}
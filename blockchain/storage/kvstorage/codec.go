@@ -0,0 +1,54 @@
+package kvstorage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/klauspost/compress/snappy"
+)
+
+// Codecs of the stored bodies and receipts, prefixed to the encoded data.
+// The legacy entries are plain RLP lists without prefix, so the codec types
+// are all below the RLP list prefix 0xc0 to tell them apart.
+const (
+	// codecSnappy is the snappy compressed RLP
+	codecSnappy byte = 0x01
+
+	// rlpListPrefix is the lowest first byte of an RLP list
+	rlpListPrefix byte = 0xc0
+)
+
+var (
+	ErrEmptyStoreData   = errors.New("empty store data")
+	ErrUnknownStoreType = errors.New("unknown store data codec")
+)
+
+// encodeStoreData compresses the RLP data with the current codec
+func encodeStoreData(data []byte) []byte {
+	dst := make([]byte, 1+snappy.MaxEncodedLen(len(data)))
+	dst[0] = codecSnappy
+
+	return dst[:1+len(snappy.Encode(dst[1:], data))]
+}
+
+// decodeStoreData returns the RLP data of either the current or the legacy codec,
+// and whether the data is legacy
+func decodeStoreData(data []byte) ([]byte, bool, error) {
+	if len(data) == 0 {
+		return nil, false, ErrEmptyStoreData
+	}
+
+	switch codec := data[0]; {
+	case codec >= rlpListPrefix:
+		return data, true, nil
+	case codec == codecSnappy:
+		raw, err := snappy.Decode(nil, data[1:])
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to decompress store data, %w", err)
+		}
+
+		return raw, false, nil
+	default:
+		return nil, false, fmt.Errorf("%w: %d", ErrUnknownStoreType, codec)
+	}
+}
@@ -0,0 +1,110 @@
+package kvstorage
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreData_Codec(t *testing.T) {
+	t.Parallel()
+
+	raw := (&types.Receipts{}).MarshalRLPTo(nil)
+
+	// legacy data is the plain rlp
+	data, legacy, err := decodeStoreData(raw)
+	assert.NoError(t, err)
+	assert.True(t, legacy)
+	assert.Equal(t, raw, data)
+
+	encoded := encodeStoreData(raw)
+	assert.Equal(t, codecSnappy, encoded[0])
+
+	data, legacy, err = decodeStoreData(encoded)
+	assert.NoError(t, err)
+	assert.False(t, legacy)
+	assert.Equal(t, raw, data)
+
+	_, _, err = decodeStoreData(nil)
+	assert.ErrorIs(t, err, ErrEmptyStoreData)
+
+	_, _, err = decodeStoreData([]byte{0x7f})
+	assert.ErrorIs(t, err, ErrUnknownStoreType)
+}
+
+func TestKeyValueStorage_ReencodeBlock(t *testing.T) {
+	t.Parallel()
+
+	db := &memoryKV{map[string][]byte{}}
+	s, ok := newKeyValueStorage(hclog.NewNullLogger(), db).(*KeyValueStorage)
+	assert.True(t, ok)
+
+	to := types.StringToAddress("1")
+	tx := &types.Transaction{
+		Nonce:    1,
+		To:       &to,
+		Value:    big.NewInt(1),
+		Gas:      21000,
+		GasPrice: big.NewInt(1),
+		V:        big.NewInt(1),
+	}
+	tx.ComputeHash()
+
+	hash := types.StringToHash("1")
+	body := &types.Body{Transactions: []*types.Transaction{tx}}
+	receipts := types.Receipts{{CumulativeGasUsed: 21000, TxHash: tx.Hash}}
+
+	// written by a previous version
+	assert.NoError(t, s.writeRLP(BODY, hash.Bytes(), body))
+	assert.NoError(t, s.writeRLP(RECEIPTS, hash.Bytes(), &receipts))
+
+	assertBlock := func() {
+		readBody, err := s.ReadBody(hash)
+		assert.NoError(t, err)
+		assert.Len(t, readBody.Transactions, 1)
+		assert.Equal(t, tx.Hash, readBody.Transactions[0].Hash)
+
+		readReceipts, err := s.ReadReceipts(hash)
+		assert.NoError(t, err)
+		assert.Len(t, readReceipts, 1)
+		assert.Equal(t, tx.Hash, readReceipts[0].TxHash)
+	}
+
+	assertBlock()
+
+	reencoded, err := s.ReencodeBlock(hash)
+	assert.NoError(t, err)
+	assert.True(t, reencoded)
+
+	for _, p := range [][]byte{BODY, RECEIPTS} {
+		data, ok := s.get(p, hash.Bytes())
+		assert.True(t, ok)
+		assert.Equal(t, codecSnappy, data[0])
+	}
+
+	assertBlock()
+
+	// nothing left to re-encode
+	reencoded, err = s.ReencodeBlock(hash)
+	assert.NoError(t, err)
+	assert.False(t, reencoded)
+
+	// neither a missing block
+	reencoded, err = s.ReencodeBlock(types.StringToHash("2"))
+	assert.NoError(t, err)
+	assert.False(t, reencoded)
+}
+
+func TestKeyCategory(t *testing.T) {
+	t.Parallel()
+
+	hash := types.StringToHash("1").Bytes()
+
+	assert.Equal(t, "headers", KeyCategory(append([]byte("h"), hash...)))
+	assert.Equal(t, "head", KeyCategory([]byte("ohash")))
+	assert.Equal(t, "receipts", KeyCategory(append([]byte("r"), hash...)))
+	assert.Equal(t, "other", KeyCategory([]byte("z")))
+}
@@ -0,0 +1,29 @@
+package kvstorage
+
+import (
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/hashicorp/go-hclog"
+)
+
+type databaseStorageBuilder struct {
+	logger    hclog.Logger
+	dbBuilder kvdb.Builder
+}
+
+func (builder *databaseStorageBuilder) Build() (storage.Storage, error) {
+	db, err := builder.dbBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return newKeyValueStorage(builder.logger.Named("database"), db), nil
+}
+
+// NewDatabaseStorageBuilder creates the new blockchain storage builder, on the database of any backend
+func NewDatabaseStorageBuilder(logger hclog.Logger, dbBuilder kvdb.Builder) storage.StorageBuilder {
+	return &databaseStorageBuilder{
+		logger:    logger,
+		dbBuilder: dbBuilder,
+	}
+}
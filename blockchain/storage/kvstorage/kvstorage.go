@@ -2,13 +2,16 @@
 package kvstorage
 
 import (
+	"bytes"
 	"encoding/binary"
 	"math/big"
 
 	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/dogechain-lab/fastrlp"
 	"github.com/hashicorp/go-hclog"
+	"github.com/klauspost/compress/snappy"
 )
 
 // Prefixes for the key-value store
@@ -39,8 +42,63 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// CODEC is the prefix for the progress of the storage codec upgrade
+	CODEC = []byte("e")
+
+	// BLOOM_BITS is the prefix for the bit vectors of the bloom bits index
+	BLOOM_BITS = []byte("i")
+
+	// BLOOM_SECTIONS is the prefix for the progress of the bloom bits index
+	BLOOM_SECTIONS = []byte("j")
+
+	// BAD_BLOCKS is the entry to store the blocks rejected by the chain
+	BAD_BLOCKS = []byte("k")
+
+	// SYNC_PROGRESS is the entry to store the progression of the bulk sync
+	SYNC_PROGRESS = []byte("p")
+
+	// INSERT_MARKER is the entry to store the block being inserted
+	INSERT_MARKER = []byte("w")
+
+	// ANCIENT is the prefix for the progress of the move of the old blocks to the ancient store
+	ANCIENT = []byte("a")
 )
 
+// keyCategories names the data stored under the prefixes
+var keyCategories = []struct {
+	prefix []byte
+	name   string
+}{
+	{DIFFICULTY, "difficulties"},
+	{HEADER, "headers"},
+	{HEAD, "head"},
+	{FORK, "forks"},
+	{CANONICAL, "canonical hashes"},
+	{BODY, "bodies"},
+	{RECEIPTS, "receipts"},
+	{SNAPSHOTS, "snapshots"},
+	{TX_LOOKUP_PREFIX, "transaction lookups"},
+	{CODEC, "codec"},
+	{BLOOM_BITS, "bloom bits"},
+	{BLOOM_SECTIONS, "bloom sections"},
+	{BAD_BLOCKS, "bad blocks"},
+	{SYNC_PROGRESS, "sync progress"},
+	{INSERT_MARKER, "insert marker"},
+	{ANCIENT, "ancient progress"},
+}
+
+// KeyCategory returns the name of the data stored under the key, other if its prefix is unknown
+func KeyCategory(key []byte) string {
+	for _, category := range keyCategories {
+		if bytes.HasPrefix(key, category.prefix) {
+			return category.name
+		}
+	}
+
+	return "other"
+}
+
 // Sub-prefixes
 var (
 	HASH   = []byte("hash")
@@ -54,6 +112,8 @@ type KV interface {
 
 	Set(p []byte, v []byte) error
 	Get(p []byte) ([]byte, bool, error)
+
+	Batch() kvdb.KVBatch
 }
 
 // KeyValueStorage is a generic storage for kv databases
@@ -208,13 +268,13 @@ func (s *KeyValueStorage) WriteCanonicalHeader(h *types.Header, diff *big.Int) e
 
 // WriteBody writes the body
 func (s *KeyValueStorage) WriteBody(hash types.Hash, body *types.Body) error {
-	return s.writeRLP(BODY, hash.Bytes(), body)
+	return s.writeCompressedRLP(BODY, hash.Bytes(), body)
 }
 
 // ReadBody reads the body
 func (s *KeyValueStorage) ReadBody(hash types.Hash) (*types.Body, error) {
 	body := &types.Body{}
-	err := s.readRLP(BODY, hash.Bytes(), body)
+	err := s.readCompressedRLP(BODY, hash.Bytes(), body)
 
 	return body, err
 }
@@ -225,25 +285,274 @@ func (s *KeyValueStorage) ReadBody(hash types.Hash) (*types.Body, error) {
 func (s *KeyValueStorage) WriteReceipts(hash types.Hash, receipts []*types.Receipt) error {
 	rr := types.Receipts(receipts)
 
-	return s.writeRLP(RECEIPTS, hash.Bytes(), &rr)
+	return s.writeCompressedRLP(RECEIPTS, hash.Bytes(), &rr)
 }
 
 // ReadReceipts reads the receipts
 func (s *KeyValueStorage) ReadReceipts(hash types.Hash) ([]*types.Receipt, error) {
 	receipts := &types.Receipts{}
-	err := s.readRLP(RECEIPTS, hash.Bytes(), receipts)
+	err := s.readCompressedRLP(RECEIPTS, hash.Bytes(), receipts)
 
 	return *receipts, err
 }
 
+// ReadCompressedReceipts reads the snappy compressed RLP of the receipts in the store format,
+// without decoding them. The receipts stored in the current codec are returned as they are
+func (s *KeyValueStorage) ReadCompressedReceipts(hash types.Hash) ([]byte, error) {
+	data, ok, err := s.db.Get(append(append([]byte{}, RECEIPTS...), hash.Bytes()...))
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+
+	if len(data) > 0 && data[0] == codecSnappy {
+		return data[1:], nil
+	}
+
+	raw, _, err := decodeStoreData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return snappy.Encode(nil, raw), nil
+}
+
+// CODEC //
+
+// ReencodeBlock rewrites the body and receipts of the block stored in the legacy codec
+// with the current one. It returns whether any of them was rewritten
+func (s *KeyValueStorage) ReencodeBlock(hash types.Hash) (bool, error) {
+	reencoded := false
+
+	for _, p := range [][]byte{BODY, RECEIPTS} {
+		key := append(append([]byte{}, p...), hash.Bytes()...)
+
+		data, ok, err := s.db.Get(key)
+		if err != nil {
+			return reencoded, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		raw, legacy, err := decodeStoreData(data)
+		if err != nil {
+			return reencoded, err
+		}
+
+		if !legacy {
+			continue
+		}
+
+		if err := s.db.Set(key, encodeStoreData(raw)); err != nil {
+			return reencoded, err
+		}
+
+		reencoded = true
+	}
+
+	return reencoded, nil
+}
+
+// ReadCodecProgress returns the number of the next canonical block to re-encode
+func (s *KeyValueStorage) ReadCodecProgress() (uint64, bool) {
+	data, ok := s.get(CODEC, NUMBER)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+// WriteCodecProgress writes the number of the next canonical block to re-encode
+func (s *KeyValueStorage) WriteCodecProgress(n uint64) error {
+	return s.set(CODEC, NUMBER, s.encodeUint(n))
+}
+
+// BLOOM BITS //
+
+// WriteBloomBits writes the compressed vector of a bit of the logs blooms of a section of blocks
+func (s *KeyValueStorage) WriteBloomBits(bit uint, section uint64, bits []byte) error {
+	return s.set(BLOOM_BITS, s.encodeBloomBitsKey(bit, section), encodeStoreData(bits))
+}
+
+// ReadBloomBits reads the vector of a bit of the logs blooms of a section of blocks
+func (s *KeyValueStorage) ReadBloomBits(bit uint, section uint64) ([]byte, error) {
+	data, ok, err := s.db.Get(append(append([]byte{}, BLOOM_BITS...), s.encodeBloomBitsKey(bit, section)...))
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+
+	bits, _, err := decodeStoreData(data)
+
+	return bits, err
+}
+
+// ReadBloomSections returns the number of sections of blocks indexed in the bloom bits index
+func (s *KeyValueStorage) ReadBloomSections() (uint64, bool) {
+	data, ok := s.get(BLOOM_SECTIONS, NUMBER)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+// WriteBloomSections writes the number of sections of blocks indexed in the bloom bits index
+func (s *KeyValueStorage) WriteBloomSections(n uint64) error {
+	return s.set(BLOOM_SECTIONS, NUMBER, s.encodeUint(n))
+}
+
+// BAD BLOCKS //
+
+// WriteBadBlocks writes the blocks rejected by the chain
+func (s *KeyValueStorage) WriteBadBlocks(blocks []*storage.BadBlock) error {
+	bb := storage.BadBlocks(blocks)
+
+	return s.writeRLP(BAD_BLOCKS, EMPTY, &bb)
+}
+
+// ReadBadBlocks reads the blocks rejected by the chain
+func (s *KeyValueStorage) ReadBadBlocks() ([]*storage.BadBlock, error) {
+	blocks := &storage.BadBlocks{}
+	err := s.readRLP(BAD_BLOCKS, EMPTY, blocks)
+
+	return *blocks, err
+}
+
+// SYNC PROGRESS //
+
+// WriteSyncProgress writes the progression of the bulk sync
+func (s *KeyValueStorage) WriteSyncProgress(progress *storage.SyncProgress) error {
+	return s.writeRLP(SYNC_PROGRESS, EMPTY, progress)
+}
+
+// ReadSyncProgress reads the progression of the bulk sync
+func (s *KeyValueStorage) ReadSyncProgress() (*storage.SyncProgress, error) {
+	progress := &storage.SyncProgress{}
+	if err := s.readRLP(SYNC_PROGRESS, EMPTY, progress); err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+// INSERT MARKER //
+
+// WriteInsertMarker writes the marker of the block being inserted
+func (s *KeyValueStorage) WriteInsertMarker(marker *storage.InsertMarker) error {
+	return s.set(INSERT_MARKER, EMPTY, append(marker.Hash.Bytes(), s.encodeUint(marker.Number)...))
+}
+
+// ReadInsertMarker reads the marker of the block being inserted, false if there is none
+func (s *KeyValueStorage) ReadInsertMarker() (*storage.InsertMarker, bool) {
+	data, ok := s.get(INSERT_MARKER, EMPTY)
+	if !ok || len(data) != types.HashLength+8 {
+		return nil, false
+	}
+
+	return &storage.InsertMarker{
+		Hash:   types.BytesToHash(data[:types.HashLength]),
+		Number: s.decodeUint(data[types.HashLength:]),
+	}, true
+}
+
+// BATCH //
+
+// NewBatch creates a batch of writes, committed to the db at once
+func (s *KeyValueStorage) NewBatch() storage.Batch {
+	batch := s.db.Batch()
+
+	return &kvBatch{
+		KeyValueStorage: &KeyValueStorage{
+			logger: s.logger,
+			db:     &batchKV{KV: s.db, batch: batch},
+		},
+		batch: batch,
+	}
+}
+
+// batchKV sends the writes to the batch, the reads still being served by the db
+type batchKV struct {
+	KV
+	batch kvdb.KVBatch
+}
+
+func (b *batchKV) Set(p []byte, v []byte) error {
+	b.batch.Set(p, v)
+
+	return nil
+}
+
+// kvBatch writes with the storage encoding into a batch of the db
+type kvBatch struct {
+	*KeyValueStorage
+	batch kvdb.KVBatch
+}
+
+// DeleteCanonicalHash deletes the canonical hash of the number
+func (b *kvBatch) DeleteCanonicalHash(n uint64) error {
+	b.batch.Delete(append(append([]byte{}, CANONICAL...), b.encodeUint(n)...))
+
+	return nil
+}
+
+// DeleteBlock deletes the header, the total difficulty, the body and the receipts of the block
+func (b *kvBatch) DeleteBlock(hash types.Hash) error {
+	for _, p := range [][]byte{HEADER, DIFFICULTY, BODY, RECEIPTS} {
+		b.batch.Delete(append(append([]byte{}, p...), hash.Bytes()...))
+	}
+
+	return nil
+}
+
+// DeleteTxLookup deletes the lookup of the transaction
+func (b *kvBatch) DeleteTxLookup(hash types.Hash) error {
+	b.batch.Delete(append(append([]byte{}, TX_LOOKUP_PREFIX...), hash.Bytes()...))
+
+	return nil
+}
+
+// DeleteInsertMarker clears the marker of the block being inserted
+func (b *kvBatch) DeleteInsertMarker() error {
+	b.batch.Delete(append(append([]byte{}, INSERT_MARKER...), EMPTY...))
+
+	return nil
+}
+
+// Write commits the writes of the batch
+func (b *kvBatch) Write() error {
+	return b.batch.Write()
+}
+
+// encodeBloomBitsKey returns the key of a bit vector, by bit then section
+func (s *KeyValueStorage) encodeBloomBitsKey(bit uint, section uint64) []byte {
+	key := make([]byte, 10)
+	binary.BigEndian.PutUint16(key[:2], uint16(bit))
+	binary.BigEndian.PutUint64(key[2:], section)
+
+	return key
+}
+
 // TX LOOKUP //
 
-// WriteTxLookup maps the transaction hash to the block hash
-func (s *KeyValueStorage) WriteTxLookup(hash types.Hash, blockHash types.Hash) error {
+// WriteTxLookup maps the transaction hash to its block and its index in the block
+func (s *KeyValueStorage) WriteTxLookup(hash types.Hash, entry *storage.TxLookupEntry) error {
 	ar := &fastrlp.Arena{}
-	vr := ar.NewBytes(blockHash.Bytes())
+	vv := ar.NewArray()
+	vv.Set(ar.NewBytes(entry.BlockHash.Bytes()))
+	vv.Set(ar.NewUint(entry.BlockNumber))
+	vv.Set(ar.NewUint(entry.Index))
 
-	return s.write2(TX_LOOKUP_PREFIX, hash.Bytes(), vr)
+	return s.write2(TX_LOOKUP_PREFIX, hash.Bytes(), vv)
 }
 
 // ReadTxLookup reads the block hash using the transaction hash
@@ -255,6 +564,15 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 		return types.Hash{}, false
 	}
 
+	// the legacy lookups only hold the block hash
+	if v.Type() == fastrlp.TypeArray {
+		if v.Elems() == 0 {
+			return types.Hash{}, false
+		}
+
+		v = v.Get(0)
+	}
+
 	blockHash := []byte{}
 	blockHash, err := v.GetBytes(blockHash[:0], 32)
 
@@ -265,17 +583,45 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return types.BytesToHash(blockHash), true
 }
 
+// ReadTxLookupEntry reads the block and the index of the transaction using its hash.
+// The legacy lookups, holding only the block hash, are not found
+func (s *KeyValueStorage) ReadTxLookupEntry(hash types.Hash) (*storage.TxLookupEntry, bool) {
+	parser := &fastrlp.Parser{}
+
+	v := s.read2(TX_LOOKUP_PREFIX, hash.Bytes(), parser)
+	if v == nil || v.Type() != fastrlp.TypeArray || v.Elems() != 3 {
+		return nil, false
+	}
+
+	blockHash, err := v.Get(0).GetBytes(nil, 32)
+	if err != nil {
+		return nil, false
+	}
+
+	entry := &storage.TxLookupEntry{
+		BlockHash: types.BytesToHash(blockHash),
+	}
+
+	if entry.BlockNumber, err = v.Get(1).GetUint64(); err != nil {
+		return nil, false
+	}
+
+	if entry.Index, err = v.Get(2).GetUint64(); err != nil {
+		return nil, false
+	}
+
+	return entry, true
+}
+
 // WRITE OPERATIONS //
 
 func (s *KeyValueStorage) writeRLP(p, k []byte, raw types.RLPMarshaler) error {
-	var data []byte
-	if obj, ok := raw.(types.RLPStoreMarshaler); ok {
-		data = obj.MarshalStoreRLPTo(nil)
-	} else {
-		data = raw.MarshalRLPTo(nil)
-	}
+	return s.set(p, k, marshalStoreRLP(raw))
+}
 
-	return s.set(p, k, data)
+// writeCompressedRLP writes the object in the current storage codec
+func (s *KeyValueStorage) writeCompressedRLP(p, k []byte, raw types.RLPMarshaler) error {
+	return s.set(p, k, encodeStoreData(marshalStoreRLP(raw)))
 }
 
 func (s *KeyValueStorage) readRLP(p, k []byte, raw types.RLPUnmarshaler) error {
@@ -290,6 +636,38 @@ func (s *KeyValueStorage) readRLP(p, k []byte, raw types.RLPUnmarshaler) error {
 		return storage.ErrNotFound
 	}
 
+	return unmarshalStoreRLP(data, raw)
+}
+
+// readCompressedRLP reads the object in either the current or the legacy storage codec
+func (s *KeyValueStorage) readCompressedRLP(p, k []byte, raw types.RLPUnmarshaler) error {
+	p = append(p, k...)
+	data, ok, err := s.db.Get(p)
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return storage.ErrNotFound
+	}
+
+	if data, _, err = decodeStoreData(data); err != nil {
+		return err
+	}
+
+	return unmarshalStoreRLP(data, raw)
+}
+
+func marshalStoreRLP(raw types.RLPMarshaler) []byte {
+	if obj, ok := raw.(types.RLPStoreMarshaler); ok {
+		return obj.MarshalStoreRLPTo(nil)
+	}
+
+	return raw.MarshalRLPTo(nil)
+}
+
+func unmarshalStoreRLP(data []byte, raw types.RLPUnmarshaler) error {
 	if obj, ok := raw.(types.RLPStoreUnmarshaler); ok {
 		// decode in the store format
 		if err := obj.UnmarshalStoreRLP(data); err != nil {
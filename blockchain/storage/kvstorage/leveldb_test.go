@@ -19,7 +19,7 @@ func newLevelDBStorage(t *testing.T) (storage.Storage, func()) {
 
 	logger := hclog.NewNullLogger()
 
-	s, err := NewLevelDBStorageBuilder(
+	s, err := NewDatabaseStorageBuilder(
 		logger, kvdb.NewLevelDBBuilder(logger, path)).Build()
 	if err != nil {
 		t.Fatal(err)
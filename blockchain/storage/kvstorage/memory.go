@@ -3,6 +3,7 @@ package kvstorage
 import (
 	"github.com/dogechain-lab/dogechain/blockchain/storage"
 	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
 	"github.com/hashicorp/go-hclog"
 )
 
@@ -43,6 +44,42 @@ func (m *memoryKV) Get(p []byte) ([]byte, bool, error) {
 	return v, true, nil
 }
 
+func (m *memoryKV) Batch() kvdb.KVBatch {
+	return &memoryBatch{db: m}
+}
+
 func (m *memoryKV) Close() error {
 	return nil
 }
+
+// memoryBatch buffers the writes to the memory kv, applied in order on write
+type memoryBatch struct {
+	db  *memoryKV
+	ops []memoryOp
+}
+
+// memoryOp is a write of a batch, a deletion if the value is nil
+type memoryOp struct {
+	key   string
+	value []byte
+}
+
+func (b *memoryBatch) Set(k, v []byte) {
+	b.ops = append(b.ops, memoryOp{key: hex.EncodeToHex(k), value: append([]byte{}, v...)})
+}
+
+func (b *memoryBatch) Delete(k []byte) {
+	b.ops = append(b.ops, memoryOp{key: hex.EncodeToHex(k)})
+}
+
+func (b *memoryBatch) Write() error {
+	for _, op := range b.ops {
+		if op.value == nil {
+			delete(b.db.db, op.key)
+		} else {
+			b.db.db[op.key] = op.value
+		}
+	}
+
+	return nil
+}
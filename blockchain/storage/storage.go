@@ -37,12 +37,86 @@ type Storage interface {
 
 	WriteReceipts(hash types.Hash, receipts []*types.Receipt) error
 	ReadReceipts(hash types.Hash) ([]*types.Receipt, error)
+	ReadCompressedReceipts(hash types.Hash) ([]byte, error)
 
-	WriteTxLookup(hash types.Hash, blockHash types.Hash) error
+	WriteTxLookup(hash types.Hash, entry *TxLookupEntry) error
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
+	ReadTxLookupEntry(hash types.Hash) (*TxLookupEntry, bool)
+
+	ReencodeBlock(hash types.Hash) (bool, error)
+	ReadCodecProgress() (uint64, bool)
+	WriteCodecProgress(n uint64) error
+
+	WriteBloomBits(bit uint, section uint64, bits []byte) error
+	ReadBloomBits(bit uint, section uint64) ([]byte, error)
+	ReadBloomSections() (uint64, bool)
+	WriteBloomSections(n uint64) error
+
+	WriteBadBlocks(blocks []*BadBlock) error
+	ReadBadBlocks() ([]*BadBlock, error)
+
+	WriteSyncProgress(progress *SyncProgress) error
+	ReadSyncProgress() (*SyncProgress, error)
+
+	WriteInsertMarker(marker *InsertMarker) error
+	ReadInsertMarker() (*InsertMarker, bool)
+
+	NewBatch() Batch
+
+	FreezeBlock(hash types.Hash) (bool, error)
+	ReadAncientProgress() (uint64, bool)
+	WriteAncientProgress(n uint64) error
 
 	Close() error
 }
 
+// BlockWriter writes the data of the blocks inserted in the chain
+type BlockWriter interface {
+	WriteCanonicalHash(n uint64, hash types.Hash) error
+	WriteHeadHash(h types.Hash) error
+	WriteHeadNumber(uint64) error
+	WriteForks(forks []types.Hash) error
+	WriteTotalDifficulty(hash types.Hash, diff *big.Int) error
+	WriteHeader(h *types.Header) error
+	WriteCanonicalHeader(h *types.Header, diff *big.Int) error
+	WriteBody(hash types.Hash, body *types.Body) error
+	WriteReceipts(hash types.Hash, receipts []*types.Receipt) error
+	WriteTxLookup(hash types.Hash, entry *TxLookupEntry) error
+}
+
+// BlockDeleter deletes the data of the blocks rewound from the chain
+type BlockDeleter interface {
+	DeleteCanonicalHash(n uint64) error
+	// DeleteBlock deletes the header, the total difficulty, the body and the receipts of the block
+	DeleteBlock(hash types.Hash) error
+	DeleteTxLookup(hash types.Hash) error
+}
+
+// Batch collects the writes of the insertion of a block, committed all at once
+type Batch interface {
+	BlockWriter
+	BlockDeleter
+
+	// DeleteInsertMarker clears the marker of the insertion along with its writes
+	DeleteInsertMarker() error
+
+	// Write commits the writes atomically, the batch is not reusable afterwards
+	Write() error
+}
+
+// InsertMarker is written ahead of the insertion of a block, and cleared in the batch
+// committing it. Finding it at startup means the node stopped while inserting the block
+type InsertMarker struct {
+	Number uint64
+	Hash   types.Hash
+}
+
+// TxLookupEntry locates a transaction in the chain
+type TxLookupEntry struct {
+	BlockHash   types.Hash
+	BlockNumber uint64
+	Index       uint64
+}
+
 // Factory is a factory method to create a blockchain storage
 type Factory func(config map[string]interface{}, logger hclog.Logger) (Storage, error)
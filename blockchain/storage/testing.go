@@ -8,6 +8,7 @@ import (
 
 	"github.com/dogechain-lab/dogechain/helper/hex"
 	"github.com/dogechain-lab/dogechain/types"
+	"github.com/golang/snappy"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,6 +50,27 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("", func(t *testing.T) {
+		testBloomBits(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testTxLookup(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testBadBlocks(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testSyncProgress(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testInsertMarker(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testBatch(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testBatchDelete(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -393,6 +415,284 @@ func testReceipts(t *testing.T, m PlaceholderStorage) {
 	}
 
 	assert.True(t, reflect.DeepEqual(receipts, found))
+
+	// the compressed receipts are the ones in the store format
+	compressed, err := s.ReadCompressedReceipts(h.Hash)
+	assert.NoError(t, err)
+
+	raw, err := snappy.Decode(nil, compressed)
+	assert.NoError(t, err)
+
+	decoded := types.Receipts{}
+	assert.NoError(t, decoded.UnmarshalStoreRLP(raw))
+	assert.True(t, reflect.DeepEqual(types.Receipts(receipts), decoded))
+
+	_, err = s.ReadCompressedReceipts(hash1)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func testBloomBits(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadBloomSections()
+	assert.False(t, ok)
+
+	bits := make([]byte, 512)
+	bits[3] = 0x80
+
+	assert.NoError(t, s.WriteBloomBits(2047, 3, bits))
+	assert.NoError(t, s.WriteBloomSections(4))
+
+	found, err := s.ReadBloomBits(2047, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, bits, found)
+
+	// stored by bit and section
+	_, err = s.ReadBloomBits(2047, 2)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.ReadBloomBits(2046, 3)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	sections, ok := s.ReadBloomSections()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(4), sections)
+}
+
+func testBadBlocks(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, err := s.ReadBadBlocks()
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	blocks := []*BadBlock{
+		{Hash: hash1, Number: 10, Reason: "invalid state root", Timestamp: 1000},
+		{Hash: hash2, Number: 11, Reason: "", Timestamp: 1001},
+	}
+
+	assert.NoError(t, s.WriteBadBlocks(blocks))
+
+	found, err := s.ReadBadBlocks()
+	assert.NoError(t, err)
+	assert.Equal(t, blocks, found)
+}
+
+func testSyncProgress(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, err := s.ReadSyncProgress()
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	progress := &SyncProgress{
+		Number: 300,
+		Hash:   hash2,
+		Slots: []*SyncSlot{
+			{From: 101, Hashes: []types.Hash{hash1, hash2}},
+			{From: 299, Hashes: []types.Hash{hash2}},
+		},
+	}
+
+	assert.NoError(t, s.WriteSyncProgress(progress))
+
+	found, err := s.ReadSyncProgress()
+	assert.NoError(t, err)
+	assert.Equal(t, progress, found)
+
+	// the progression is cleared by an empty one
+	assert.NoError(t, s.WriteSyncProgress(&SyncProgress{}))
+
+	found, err = s.ReadSyncProgress()
+	assert.NoError(t, err)
+	assert.Empty(t, found.Slots)
+}
+
+func testInsertMarker(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadInsertMarker()
+	assert.False(t, ok)
+
+	marker := &InsertMarker{Number: 10, Hash: hash1}
+	assert.NoError(t, s.WriteInsertMarker(marker))
+
+	found, ok := s.ReadInsertMarker()
+	assert.True(t, ok)
+	assert.Equal(t, marker, found)
+
+	// the marker is cleared along with the writes of the batch
+	batch := s.NewBatch()
+	assert.NoError(t, batch.DeleteInsertMarker())
+	assert.NoError(t, batch.Write())
+
+	_, ok = s.ReadInsertMarker()
+	assert.False(t, ok)
+}
+
+func testBatch(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	h := &types.Header{
+		Number:    5,
+		ExtraData: []byte{0x1},
+	}
+	h.ComputeHash()
+
+	body := &types.Body{
+		Transactions: []*types.Transaction{
+			{Nonce: 1, To: &addr1, Value: big.NewInt(1), GasPrice: big.NewInt(1), V: big.NewInt(1)},
+		},
+	}
+	body.Transactions[0].ComputeHash()
+
+	receipts := []*types.Receipt{
+		{CumulativeGasUsed: 21000, TxHash: body.Transactions[0].Hash},
+	}
+	receipts[0].SetStatus(types.ReceiptSuccess)
+
+	entry := &TxLookupEntry{BlockHash: h.Hash, BlockNumber: h.Number}
+
+	batch := s.NewBatch()
+	assert.NoError(t, batch.WriteCanonicalHeader(h, big.NewInt(10)))
+	assert.NoError(t, batch.WriteBody(h.Hash, body))
+	assert.NoError(t, batch.WriteReceipts(h.Hash, receipts))
+	assert.NoError(t, batch.WriteTxLookup(body.Transactions[0].Hash, entry))
+
+	// nothing is written until the batch is
+	_, err := s.ReadHeader(h.Hash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, ok := s.ReadHeadHash()
+	assert.False(t, ok)
+
+	_, ok = s.ReadCanonicalHash(h.Number)
+	assert.False(t, ok)
+
+	assert.NoError(t, batch.Write())
+
+	found, err := s.ReadHeader(h.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, h, found)
+
+	headHash, ok := s.ReadHeadHash()
+	assert.True(t, ok)
+	assert.Equal(t, h.Hash, headHash)
+
+	canonical, ok := s.ReadCanonicalHash(h.Number)
+	assert.True(t, ok)
+	assert.Equal(t, h.Hash, canonical)
+
+	td, ok := s.ReadTotalDifficulty(h.Hash)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(10), td)
+
+	foundBody, err := s.ReadBody(h.Hash)
+	assert.NoError(t, err)
+	assert.Len(t, foundBody.Transactions, 1)
+	assert.Equal(t, body.Transactions[0].Hash, foundBody.Transactions[0].Hash)
+
+	foundReceipts, err := s.ReadReceipts(h.Hash)
+	assert.NoError(t, err)
+	assert.Len(t, foundReceipts, 1)
+
+	foundEntry, ok := s.ReadTxLookupEntry(body.Transactions[0].Hash)
+	assert.True(t, ok)
+	assert.Equal(t, entry, foundEntry)
+}
+
+func testBatchDelete(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	h := &types.Header{
+		Number:    5,
+		ExtraData: []byte{0x1},
+	}
+	h.ComputeHash()
+
+	body := &types.Body{
+		Transactions: []*types.Transaction{
+			{Nonce: 1, To: &addr1, Value: big.NewInt(1), GasPrice: big.NewInt(1), V: big.NewInt(1)},
+		},
+	}
+	body.Transactions[0].ComputeHash()
+
+	batch := s.NewBatch()
+	assert.NoError(t, batch.WriteCanonicalHeader(h, big.NewInt(10)))
+	assert.NoError(t, batch.WriteBody(h.Hash, body))
+	assert.NoError(t, batch.WriteReceipts(h.Hash, []*types.Receipt{{CumulativeGasUsed: 21000}}))
+	assert.NoError(t, batch.WriteTxLookup(body.Transactions[0].Hash, &TxLookupEntry{BlockHash: h.Hash}))
+	assert.NoError(t, batch.Write())
+
+	batch = s.NewBatch()
+	assert.NoError(t, batch.DeleteCanonicalHash(h.Number))
+	assert.NoError(t, batch.DeleteBlock(h.Hash))
+	assert.NoError(t, batch.DeleteTxLookup(body.Transactions[0].Hash))
+
+	// nothing is deleted until the batch is written
+	_, ok := s.ReadCanonicalHash(h.Number)
+	assert.True(t, ok)
+
+	assert.NoError(t, batch.Write())
+
+	_, ok = s.ReadCanonicalHash(h.Number)
+	assert.False(t, ok)
+
+	_, err := s.ReadHeader(h.Hash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, ok = s.ReadTotalDifficulty(h.Hash)
+	assert.False(t, ok)
+
+	_, err = s.ReadBody(h.Hash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.ReadReceipts(h.Hash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, ok = s.ReadTxLookup(body.Transactions[0].Hash)
+	assert.False(t, ok)
+}
+
+func testTxLookup(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadTxLookup(hash1)
+	assert.False(t, ok)
+
+	entry := &TxLookupEntry{
+		BlockHash:   hash2,
+		BlockNumber: 10,
+		Index:       3,
+	}
+	assert.NoError(t, s.WriteTxLookup(hash1, entry))
+
+	blockHash, ok := s.ReadTxLookup(hash1)
+	assert.True(t, ok)
+	assert.Equal(t, hash2, blockHash)
+
+	found, ok := s.ReadTxLookupEntry(hash1)
+	assert.True(t, ok)
+	assert.Equal(t, entry, found)
 }
 
 func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
@@ -472,31 +772,69 @@ type writeBodyDelegate func(types.Hash, *types.Body) error
 type readBodyDelegate func(types.Hash) (*types.Body, error)
 type writeReceiptsDelegate func(types.Hash, []*types.Receipt) error
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
-type writeTxLookupDelegate func(types.Hash, types.Hash) error
+type readCompressedReceiptsDelegate func(types.Hash) ([]byte, error)
+type writeTxLookupDelegate func(types.Hash, *TxLookupEntry) error
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
+type readTxLookupEntryDelegate func(types.Hash) (*TxLookupEntry, bool)
+type reencodeBlockDelegate func(types.Hash) (bool, error)
+type readCodecProgressDelegate func() (uint64, bool)
+type writeCodecProgressDelegate func(uint64) error
+type writeBloomBitsDelegate func(uint, uint64, []byte) error
+type readBloomBitsDelegate func(uint, uint64) ([]byte, error)
+type readBloomSectionsDelegate func() (uint64, bool)
+type writeBloomSectionsDelegate func(uint64) error
+type writeBadBlocksDelegate func([]*BadBlock) error
+type readBadBlocksDelegate func() ([]*BadBlock, error)
+type writeSyncProgressDelegate func(*SyncProgress) error
+type readSyncProgressDelegate func() (*SyncProgress, error)
+type writeInsertMarkerDelegate func(*InsertMarker) error
+type readInsertMarkerDelegate func() (*InsertMarker, bool)
+type newBatchDelegate func() Batch
+type freezeBlockDelegate func(types.Hash) (bool, error)
+type readAncientProgressDelegate func() (uint64, bool)
+type writeAncientProgressDelegate func(uint64) error
 type closeDelegate func() error
 
 type MockStorage struct {
-	readCanonicalHashFn    readCanonicalHashDelegate
-	writeCanonicalHashFn   writeCanonicalHashDelegate
-	readHeadHashFn         readHeadHashDelegate
-	readHeadNumberFn       readHeadNumberDelegate
-	writeHeadHashFn        writeHeadHashDelegate
-	writeHeadNumberFn      writeHeadNumberDelegate
-	writeForksFn           writeForksDelegate
-	readForksFn            readForksDelegate
-	writeTotalDifficultyFn writeTotalDifficultyDelegate
-	readTotalDifficultyFn  readTotalDifficultyDelegate
-	writeHeaderFn          writeHeaderDelegate
-	readHeaderFn           readHeaderDelegate
-	writeCanonicalHeaderFn writeCanonicalHeaderDelegate
-	writeBodyFn            writeBodyDelegate
-	readBodyFn             readBodyDelegate
-	writeReceiptsFn        writeReceiptsDelegate
-	readReceiptsFn         readReceiptsDelegate
-	writeTxLookupFn        writeTxLookupDelegate
-	readTxLookupFn         readTxLookupDelegate
-	closeFn                closeDelegate
+	readCanonicalHashFn      readCanonicalHashDelegate
+	writeCanonicalHashFn     writeCanonicalHashDelegate
+	readHeadHashFn           readHeadHashDelegate
+	readHeadNumberFn         readHeadNumberDelegate
+	writeHeadHashFn          writeHeadHashDelegate
+	writeHeadNumberFn        writeHeadNumberDelegate
+	writeForksFn             writeForksDelegate
+	readForksFn              readForksDelegate
+	writeTotalDifficultyFn   writeTotalDifficultyDelegate
+	readTotalDifficultyFn    readTotalDifficultyDelegate
+	writeHeaderFn            writeHeaderDelegate
+	readHeaderFn             readHeaderDelegate
+	writeCanonicalHeaderFn   writeCanonicalHeaderDelegate
+	writeBodyFn              writeBodyDelegate
+	readBodyFn               readBodyDelegate
+	writeReceiptsFn          writeReceiptsDelegate
+	readReceiptsFn           readReceiptsDelegate
+	readCompressedReceiptsFn readCompressedReceiptsDelegate
+	writeTxLookupFn          writeTxLookupDelegate
+	readTxLookupFn           readTxLookupDelegate
+	readTxLookupEntryFn      readTxLookupEntryDelegate
+	reencodeBlockFn          reencodeBlockDelegate
+	readCodecProgressFn      readCodecProgressDelegate
+	writeCodecProgressFn     writeCodecProgressDelegate
+	writeBloomBitsFn         writeBloomBitsDelegate
+	readBloomBitsFn          readBloomBitsDelegate
+	readBloomSectionsFn      readBloomSectionsDelegate
+	writeBloomSectionsFn     writeBloomSectionsDelegate
+	writeBadBlocksFn         writeBadBlocksDelegate
+	readBadBlocksFn          readBadBlocksDelegate
+	writeSyncProgressFn      writeSyncProgressDelegate
+	readSyncProgressFn       readSyncProgressDelegate
+	writeInsertMarkerFn      writeInsertMarkerDelegate
+	readInsertMarkerFn       readInsertMarkerDelegate
+	newBatchFn               newBatchDelegate
+	freezeBlockFn            freezeBlockDelegate
+	readAncientProgressFn    readAncientProgressDelegate
+	writeAncientProgressFn   writeAncientProgressDelegate
+	closeFn                  closeDelegate
 }
 
 func NewMockStorage() *MockStorage {
@@ -707,9 +1045,21 @@ func (m *MockStorage) HookReadReceipts(fn readReceiptsDelegate) {
 	m.readReceiptsFn = fn
 }
 
-func (m *MockStorage) WriteTxLookup(hash types.Hash, blockHash types.Hash) error {
+func (m *MockStorage) ReadCompressedReceipts(hash types.Hash) ([]byte, error) {
+	if m.readCompressedReceiptsFn != nil {
+		return m.readCompressedReceiptsFn(hash)
+	}
+
+	return nil, ErrNotFound
+}
+
+func (m *MockStorage) HookReadCompressedReceipts(fn readCompressedReceiptsDelegate) {
+	m.readCompressedReceiptsFn = fn
+}
+
+func (m *MockStorage) WriteTxLookup(hash types.Hash, entry *TxLookupEntry) error {
 	if m.writeTxLookupFn != nil {
-		return m.writeTxLookupFn(hash, blockHash)
+		return m.writeTxLookupFn(hash, entry)
 	}
 
 	return nil
@@ -731,6 +1081,223 @@ func (m *MockStorage) HookReadTxLookup(fn readTxLookupDelegate) {
 	m.readTxLookupFn = fn
 }
 
+func (m *MockStorage) ReadTxLookupEntry(hash types.Hash) (*TxLookupEntry, bool) {
+	if m.readTxLookupEntryFn != nil {
+		return m.readTxLookupEntryFn(hash)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadTxLookupEntry(fn readTxLookupEntryDelegate) {
+	m.readTxLookupEntryFn = fn
+}
+
+func (m *MockStorage) ReencodeBlock(hash types.Hash) (bool, error) {
+	if m.reencodeBlockFn != nil {
+		return m.reencodeBlockFn(hash)
+	}
+
+	return false, nil
+}
+
+func (m *MockStorage) HookReencodeBlock(fn reencodeBlockDelegate) {
+	m.reencodeBlockFn = fn
+}
+
+func (m *MockStorage) ReadCodecProgress() (uint64, bool) {
+	if m.readCodecProgressFn != nil {
+		return m.readCodecProgressFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadCodecProgress(fn readCodecProgressDelegate) {
+	m.readCodecProgressFn = fn
+}
+
+func (m *MockStorage) WriteCodecProgress(n uint64) error {
+	if m.writeCodecProgressFn != nil {
+		return m.writeCodecProgressFn(n)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteCodecProgress(fn writeCodecProgressDelegate) {
+	m.writeCodecProgressFn = fn
+}
+
+func (m *MockStorage) WriteBloomBits(bit uint, section uint64, bits []byte) error {
+	if m.writeBloomBitsFn != nil {
+		return m.writeBloomBitsFn(bit, section, bits)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteBloomBits(fn writeBloomBitsDelegate) {
+	m.writeBloomBitsFn = fn
+}
+
+func (m *MockStorage) ReadBloomBits(bit uint, section uint64) ([]byte, error) {
+	if m.readBloomBitsFn != nil {
+		return m.readBloomBitsFn(bit, section)
+	}
+
+	return nil, ErrNotFound
+}
+
+func (m *MockStorage) HookReadBloomBits(fn readBloomBitsDelegate) {
+	m.readBloomBitsFn = fn
+}
+
+func (m *MockStorage) ReadBloomSections() (uint64, bool) {
+	if m.readBloomSectionsFn != nil {
+		return m.readBloomSectionsFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadBloomSections(fn readBloomSectionsDelegate) {
+	m.readBloomSectionsFn = fn
+}
+
+func (m *MockStorage) WriteBloomSections(n uint64) error {
+	if m.writeBloomSectionsFn != nil {
+		return m.writeBloomSectionsFn(n)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteBloomSections(fn writeBloomSectionsDelegate) {
+	m.writeBloomSectionsFn = fn
+}
+
+func (m *MockStorage) WriteBadBlocks(blocks []*BadBlock) error {
+	if m.writeBadBlocksFn != nil {
+		return m.writeBadBlocksFn(blocks)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteBadBlocks(fn writeBadBlocksDelegate) {
+	m.writeBadBlocksFn = fn
+}
+
+func (m *MockStorage) ReadBadBlocks() ([]*BadBlock, error) {
+	if m.readBadBlocksFn != nil {
+		return m.readBadBlocksFn()
+	}
+
+	return nil, ErrNotFound
+}
+
+func (m *MockStorage) HookReadBadBlocks(fn readBadBlocksDelegate) {
+	m.readBadBlocksFn = fn
+}
+
+func (m *MockStorage) WriteSyncProgress(progress *SyncProgress) error {
+	if m.writeSyncProgressFn != nil {
+		return m.writeSyncProgressFn(progress)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteSyncProgress(fn writeSyncProgressDelegate) {
+	m.writeSyncProgressFn = fn
+}
+
+func (m *MockStorage) ReadSyncProgress() (*SyncProgress, error) {
+	if m.readSyncProgressFn != nil {
+		return m.readSyncProgressFn()
+	}
+
+	return nil, ErrNotFound
+}
+
+func (m *MockStorage) HookReadSyncProgress(fn readSyncProgressDelegate) {
+	m.readSyncProgressFn = fn
+}
+
+func (m *MockStorage) WriteInsertMarker(marker *InsertMarker) error {
+	if m.writeInsertMarkerFn != nil {
+		return m.writeInsertMarkerFn(marker)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteInsertMarker(fn writeInsertMarkerDelegate) {
+	m.writeInsertMarkerFn = fn
+}
+
+func (m *MockStorage) ReadInsertMarker() (*InsertMarker, bool) {
+	if m.readInsertMarkerFn != nil {
+		return m.readInsertMarkerFn()
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadInsertMarker(fn readInsertMarkerDelegate) {
+	m.readInsertMarkerFn = fn
+}
+
+// NewBatch returns a batch calling the writes of the mock directly, unless hooked
+func (m *MockStorage) NewBatch() Batch {
+	if m.newBatchFn != nil {
+		return m.newBatchFn()
+	}
+
+	return &mockBatch{BlockWriter: m}
+}
+
+func (m *MockStorage) HookNewBatch(fn newBatchDelegate) {
+	m.newBatchFn = fn
+}
+
+func (m *MockStorage) FreezeBlock(hash types.Hash) (bool, error) {
+	if m.freezeBlockFn != nil {
+		return m.freezeBlockFn(hash)
+	}
+
+	return false, nil
+}
+
+func (m *MockStorage) HookFreezeBlock(fn freezeBlockDelegate) {
+	m.freezeBlockFn = fn
+}
+
+func (m *MockStorage) ReadAncientProgress() (uint64, bool) {
+	if m.readAncientProgressFn != nil {
+		return m.readAncientProgressFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadAncientProgress(fn readAncientProgressDelegate) {
+	m.readAncientProgressFn = fn
+}
+
+func (m *MockStorage) WriteAncientProgress(n uint64) error {
+	if m.writeAncientProgressFn != nil {
+		return m.writeAncientProgressFn(n)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteAncientProgress(fn writeAncientProgressDelegate) {
+	m.writeAncientProgressFn = fn
+}
+
 func (m *MockStorage) Close() error {
 	if m.closeFn != nil {
 		return m.closeFn()
@@ -742,3 +1309,28 @@ func (m *MockStorage) Close() error {
 func (m *MockStorage) HookClose(fn closeDelegate) {
 	m.closeFn = fn
 }
+
+// mockBatch forwards the writes to the mock storage
+type mockBatch struct {
+	BlockWriter
+}
+
+func (b *mockBatch) DeleteCanonicalHash(n uint64) error {
+	return nil
+}
+
+func (b *mockBatch) DeleteBlock(hash types.Hash) error {
+	return nil
+}
+
+func (b *mockBatch) DeleteTxLookup(hash types.Hash) error {
+	return nil
+}
+
+func (b *mockBatch) DeleteInsertMarker() error {
+	return nil
+}
+
+func (b *mockBatch) Write() error {
+	return nil
+}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"fmt"
+
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/dogechain-lab/fastrlp"
 )
@@ -52,3 +54,214 @@ func (f *Forks) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	return nil
 }
+
+// BadBlock is a block rejected by the verification or the write to the chain
+type BadBlock struct {
+	Hash   types.Hash
+	Number uint64
+	// Reason is the error the block was rejected with
+	Reason string
+	// Timestamp is the unix time the block was rejected at
+	Timestamp uint64
+}
+
+type BadBlocks []*BadBlock
+
+// MarshalRLPTo is a wrapper function for calling the type marshal implementation
+func (b *BadBlocks) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(b.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith is the actual RLP marshal implementation for the type
+func (b *BadBlocks) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	if len(*b) == 0 {
+		return ar.NewNullArray()
+	}
+
+	vr := ar.NewArray()
+
+	for _, block := range *b {
+		vv := ar.NewArray()
+		vv.Set(ar.NewCopyBytes(block.Hash[:]))
+		vv.Set(ar.NewUint(block.Number))
+		vv.Set(ar.NewString(block.Reason))
+		vv.Set(ar.NewUint(block.Timestamp))
+
+		vr.Set(vv)
+	}
+
+	return vr
+}
+
+// UnmarshalRLP is a wrapper function for calling the type unmarshal implementation
+func (b *BadBlocks) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(b.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom is the actual RLP unmarshal implementation for the type
+func (b *BadBlocks) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	blocks := make([]*BadBlock, len(elems))
+
+	for indx, elem := range elems {
+		fields, err := elem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(fields) != 4 {
+			return fmt.Errorf("incorrect number of bad block fields, expected 4 but found %d", len(fields))
+		}
+
+		block := &BadBlock{}
+
+		if err := fields[0].GetHash(block.Hash[:]); err != nil {
+			return err
+		}
+
+		if block.Number, err = fields[1].GetUint64(); err != nil {
+			return err
+		}
+
+		if block.Reason, err = fields[2].GetString(); err != nil {
+			return err
+		}
+
+		if block.Timestamp, err = fields[3].GetUint64(); err != nil {
+			return err
+		}
+
+		blocks[indx] = block
+	}
+
+	*b = blocks
+
+	return nil
+}
+
+// SyncProgress is the progression of a bulk sync, so that it is resumed once interrupted
+type SyncProgress struct {
+	// Number and Hash are the ones of the highest header verified on the chain synced
+	Number uint64
+	Hash   types.Hash
+	// Slots are the blocks downloaded ahead of the chain head, not written to the chain yet
+	Slots []*SyncSlot
+}
+
+// SyncSlot is a range of consecutive blocks downloaded by the bulk sync
+type SyncSlot struct {
+	From   uint64
+	Hashes []types.Hash
+}
+
+// MarshalRLPTo is a wrapper function for calling the type marshal implementation
+func (p *SyncProgress) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(p.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith is the actual RLP marshal implementation for the type
+func (p *SyncProgress) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
+	vv := ar.NewArray()
+	vv.Set(ar.NewUint(p.Number))
+	vv.Set(ar.NewCopyBytes(p.Hash[:]))
+
+	if len(p.Slots) == 0 {
+		vv.Set(ar.NewNullArray())
+
+		return vv
+	}
+
+	slots := ar.NewArray()
+
+	for _, slot := range p.Slots {
+		vs := ar.NewArray()
+		vs.Set(ar.NewUint(slot.From))
+
+		if len(slot.Hashes) == 0 {
+			vs.Set(ar.NewNullArray())
+		} else {
+			hashes := ar.NewArray()
+			for _, hash := range slot.Hashes {
+				hashes.Set(ar.NewCopyBytes(hash[:]))
+			}
+
+			vs.Set(hashes)
+		}
+
+		slots.Set(vs)
+	}
+
+	vv.Set(slots)
+
+	return vv
+}
+
+// UnmarshalRLP is a wrapper function for calling the type unmarshal implementation
+func (p *SyncProgress) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(p.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom is the actual RLP unmarshal implementation for the type
+func (p *SyncProgress) UnmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 3 {
+		return fmt.Errorf("incorrect number of sync progress fields, expected 3 but found %d", len(elems))
+	}
+
+	if p.Number, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if err := elems[1].GetHash(p.Hash[:]); err != nil {
+		return err
+	}
+
+	slotElems, err := elems[2].GetElems()
+	if err != nil {
+		return err
+	}
+
+	p.Slots = make([]*SyncSlot, len(slotElems))
+
+	for indx, slotElem := range slotElems {
+		fields, err := slotElem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(fields) != 2 {
+			return fmt.Errorf("incorrect number of sync slot fields, expected 2 but found %d", len(fields))
+		}
+
+		slot := &SyncSlot{}
+
+		if slot.From, err = fields[0].GetUint64(); err != nil {
+			return err
+		}
+
+		hashElems, err := fields[1].GetElems()
+		if err != nil {
+			return err
+		}
+
+		slot.Hashes = make([]types.Hash, len(hashElems))
+
+		for i, hashElem := range hashElems {
+			if err := hashElem.GetHash(slot.Hashes[i][:]); err != nil {
+				return err
+			}
+		}
+
+		p.Slots[indx] = slot
+	}
+
+	return nil
+}
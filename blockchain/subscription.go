@@ -96,10 +96,11 @@ const (
 
 // Event is the blockchain event that gets passed to the listeners
 type Event struct {
-	// Old chain (removed headers) if there was a reorg
+	// Old chain (removed headers) if there was a reorg, from the old head down,
+	// or the header of a fork
 	OldChain []*types.Header
 
-	// New part of the chain (or a fork)
+	// New part of the chain, from the new head down
 	NewChain []*types.Header
 
 	// Difficulty is the new difficulty created with this event
@@ -115,7 +116,7 @@ type Event struct {
 
 // Header returns the latest block header for the event
 func (e *Event) Header() *types.Header {
-	return e.NewChain[len(e.NewChain)-1]
+	return e.NewChain[0]
 }
 
 // SetDifficulty sets the event difficulty
@@ -90,6 +90,17 @@ func HeadersToBlocks(headers []*types.Header) []*types.Block {
 func NewTestBlockchain(t *testing.T, headers []*types.Header) *Blockchain {
 	t.Helper()
 
+	return newTestBlockchainWithStorage(t, headers, kvstorage.NewMemoryStorageBuilder(hclog.NewNullLogger()))
+}
+
+// newTestBlockchainWithStorage creates a new dummy blockchain for testing on the storage
+func newTestBlockchainWithStorage(
+	t *testing.T,
+	headers []*types.Header,
+	builder storage.StorageBuilder,
+) *Blockchain {
+	t.Helper()
+
 	genesis := &chain.Genesis{
 		Number:   0,
 		GasLimit: 0,
@@ -106,14 +117,19 @@ func NewTestBlockchain(t *testing.T, headers []*types.Header) *Blockchain {
 	}
 
 	st := itrie.NewState(itrie.NewMemoryStorage())
-	b, err := newBlockChain(config, state.NewExecutor(config.Params, st, hclog.NewNullLogger()))
+	b, err := newBlockChainWithStorage(config, state.NewExecutor(config.Params, st, hclog.NewNullLogger()), builder)
 
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	if headers != nil {
-		if _, err := b.advanceHead(headers[0]); err != nil {
+		batch := b.newBatch()
+		if _, err := b.advanceHead(batch, headers[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := b.commitBatch(batch); err != nil {
 			t.Fatal(err)
 		}
 
@@ -300,6 +316,10 @@ func (m *mockExecutor) ProcessBlock(
 	return nil, nil
 }
 
+func (m *mockExecutor) State() state.State {
+	return nil
+}
+
 func (m *mockExecutor) Stop() {
 	// do nothing
 }
@@ -329,6 +349,14 @@ func TestBlockchain(t *testing.T, genesis *chain.Genesis) *Blockchain {
 }
 
 func newBlockChain(config *chain.Chain, executor Executor) (*Blockchain, error) {
+	return newBlockChainWithStorage(config, executor, kvstorage.NewMemoryStorageBuilder(hclog.NewNullLogger()))
+}
+
+func newBlockChainWithStorage(
+	config *chain.Chain,
+	executor Executor,
+	builder storage.StorageBuilder,
+) (*Blockchain, error) {
 	if executor == nil {
 		executor = &mockExecutor{}
 	}
@@ -336,7 +364,7 @@ func newBlockChain(config *chain.Chain, executor Executor) (*Blockchain, error)
 	b, err := NewBlockchain(
 		hclog.NewNullLogger(),
 		config,
-		kvstorage.NewMemoryStorageBuilder(hclog.NewNullLogger()),
+		builder,
 		&MockVerifier{},
 		executor,
 		NilMetrics(),
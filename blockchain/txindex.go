@@ -0,0 +1,72 @@
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// txIndexLogBlocks is the number of blocks indexed between the progress logs
+const txIndexLogBlocks = 100000
+
+// TxIndexResult is the outcome of a transaction index rebuild
+type TxIndexResult struct {
+	Blocks       uint64 `json:"blocks"`
+	Transactions uint64 `json:"transactions"`
+}
+
+// RebuildTxIndex rewrites the transaction lookups of the canonical chain of the storage,
+// mapping every transaction to its block and its index in it. The lookups written before
+// the index was kept only hold the block hash, and the ones of the reorganized blocks may
+// point to a side chain, so the whole canonical chain is indexed again
+func RebuildTxIndex(db storage.Storage, logger hclog.Logger) (*TxIndexResult, error) {
+	result := &TxIndexResult{}
+
+	headNumber, ok := db.ReadHeadNumber()
+	if !ok {
+		return result, nil
+	}
+
+	for n := uint64(0); n <= headNumber; n++ {
+		hash, ok := db.ReadCanonicalHash(n)
+		if !ok {
+			return result, fmt.Errorf("failed to read canonical hash of block %d", n)
+		}
+
+		header, err := db.ReadHeader(hash)
+		if err != nil {
+			return result, fmt.Errorf("failed to read header of block %d, %w", n, err)
+		}
+
+		// the canonical hash is the one of the consensus, which may hash the headers its own way
+		header.Hash = hash
+
+		if n > 0 {
+			body, err := db.ReadBody(hash)
+			if err != nil {
+				return result, fmt.Errorf("failed to read body of block %d, %w", n, err)
+			}
+
+			block := &types.Block{
+				Header:       header,
+				Transactions: body.Transactions,
+			}
+
+			if err := writeTxLookups(db, block); err != nil {
+				return result, fmt.Errorf("failed to index block %d, %w", n, err)
+			}
+
+			result.Transactions += uint64(len(body.Transactions))
+		}
+
+		result.Blocks++
+
+		if result.Blocks%txIndexLogBlocks == 0 {
+			logger.Info("indexing transactions", "block", n, "head", headNumber, "transactions", result.Transactions)
+		}
+	}
+
+	return result, nil
+}
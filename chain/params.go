@@ -2,6 +2,8 @@ package chain
 
 import (
 	"math/big"
+
+	"github.com/dogechain-lab/dogechain/types"
 )
 
 // Params are all the set of params for the chain
@@ -11,6 +13,335 @@ type Params struct {
 	Engine         map[string]interface{} `json:"engine"`
 	BlockGasTarget uint64                 `json:"blockGasTarget"`
 	BlackList      []string               `json:"blackList,omitempty"`
+
+	// BlockBodySizeLimits schedules the maximum encoded size of the block body
+	BlockBodySizeLimits []*BlockBodySizeLimit `json:"blockBodySizeLimits,omitempty"`
+
+	// PriceLimits schedules the minimum gas price of the transactions accepted by the pool
+	PriceLimits []*PriceLimit `json:"priceLimits,omitempty"`
+
+	// CodeSizeLimits schedules the maximum size of the contract code (EIP-170)
+	CodeSizeLimits []*CodeSizeLimit `json:"codeSizeLimits,omitempty"`
+
+	// GasCostOverrides schedules the gas costs of the selected operations
+	// overriding the ones of the forks
+	GasCostOverrides []*GasCostOverride `json:"gasCostOverrides,omitempty"`
+
+	// PriorityLane reserves a portion of the gas of every block to the whitelisted system callers
+	PriorityLane *PriorityLane `json:"priorityLane,omitempty"`
+
+	// InitialBaseFee is the base fee of the first block after the london fork,
+	// the default one is used if it is not set
+	InitialBaseFee uint64 `json:"initialBaseFee,omitempty"`
+}
+
+const (
+	// DefaultInitialBaseFee is the base fee of the first london block if none is configured
+	DefaultInitialBaseFee uint64 = 1000000000 // 1 GWei
+
+	BaseFeeChangeDenominator uint64 = 8 // The bound divisor of the base fee, used in update calculations
+	ElasticityMultiplier     uint64 = 2 // The bound multiplier of the gas limit over the gas target (EIP-1559)
+
+	// DefaultMaxCodeSize is the maximum size of the contract code if no limit is scheduled (EIP-170)
+	DefaultMaxCodeSize uint64 = 24576
+)
+
+// ForksInTime returns the forks active at the block, along with the code size
+// limit and the gas cost overrides scheduled at the block
+func (p *Params) ForksInTime(block uint64) ForksInTime {
+	forks := p.Forks.At(block)
+	forks.MaxCodeSize = p.CodeSizeLimitAt(block)
+	forks.GasCosts = p.GasCostsAt(block)
+
+	return forks
+}
+
+// GetInitialBaseFee returns the base fee of the first block after the london fork
+func (p *Params) GetInitialBaseFee() uint64 {
+	if p.InitialBaseFee == 0 {
+		return DefaultInitialBaseFee
+	}
+
+	return p.InitialBaseFee
+}
+
+// CalculateBaseFee returns the base fee of the next block after parent,
+// which is zero if the next block is before the london fork
+func (p *Params) CalculateBaseFee(parent *types.Header) uint64 {
+	if p.Forks == nil || !p.Forks.IsLondon(parent.Number+1) {
+		return 0
+	}
+
+	// the first london block starts from the initial base fee
+	if parent.BaseFee == 0 {
+		return p.GetInitialBaseFee()
+	}
+
+	parentGasTarget := parent.GasLimit / ElasticityMultiplier
+	if parentGasTarget == 0 || parent.GasUsed == parentGasTarget {
+		return parent.BaseFee
+	}
+
+	// The base fee moves at most 1/8 of the parent base fee per block,
+	// proportionally to how far the parent gas used is from the gas target
+	var (
+		parentBaseFee = new(big.Int).SetUint64(parent.BaseFee)
+		target        = new(big.Int).SetUint64(parentGasTarget)
+		denominator   = new(big.Int).SetUint64(BaseFeeChangeDenominator)
+		delta         = new(big.Int)
+	)
+
+	if parent.GasUsed > parentGasTarget {
+		delta.SetUint64(parent.GasUsed - parentGasTarget)
+		delta.Mul(delta, parentBaseFee).Div(delta, target).Div(delta, denominator)
+
+		// the base fee increases at least by 1 when the parent is above the target
+		if delta.Sign() == 0 {
+			return parent.BaseFee + 1
+		}
+
+		return parent.BaseFee + delta.Uint64()
+	}
+
+	delta.SetUint64(parentGasTarget - parent.GasUsed)
+	delta.Mul(delta, parentBaseFee).Div(delta, target).Div(delta, denominator)
+
+	return parent.BaseFee - delta.Uint64()
+}
+
+// BlockBodySizeLimit is the maximum encoded size in bytes of the block
+// transactions, activated from the given block. Zero means no limit
+type BlockBodySizeLimit struct {
+	Block Fork   `json:"block"`
+	Limit uint64 `json:"limit"`
+}
+
+// BlockBodySizeLimitAt returns the block body size limit active at the block,
+// zero if there is no limit
+func (p *Params) BlockBodySizeLimitAt(block uint64) uint64 {
+	var (
+		active *BlockBodySizeLimit
+		limit  uint64
+	)
+
+	for _, l := range p.BlockBodySizeLimits {
+		if l.Block.Active(block) && (active == nil || l.Block >= active.Block) {
+			active = l
+			limit = l.Limit
+		}
+	}
+
+	return limit
+}
+
+// CodeSizeLimit is the maximum size in bytes of the contract code, activated from the given block
+type CodeSizeLimit struct {
+	Block Fork   `json:"block"`
+	Limit uint64 `json:"limit"`
+}
+
+// CodeSizeLimitAt returns the code size limit active at the block,
+// the EIP-170 one if none is scheduled
+func (p *Params) CodeSizeLimitAt(block uint64) uint64 {
+	var (
+		active *CodeSizeLimit
+		limit  = DefaultMaxCodeSize
+	)
+
+	for _, l := range p.CodeSizeLimits {
+		if l.Block.Active(block) && (active == nil || l.Block >= active.Block) {
+			active = l
+			limit = l.Limit
+		}
+	}
+
+	return limit
+}
+
+// GasCosts are the gas costs of the selected operations. The zero costs
+// are not overridden, the ones of the active forks are charged
+type GasCosts struct {
+	// CodeDeposit is the cost per byte of the code stored by a contract creation
+	CodeDeposit uint64 `json:"codeDeposit,omitempty"`
+	// SstoreSet is the cost of setting a zero storage slot to a non-zero value
+	SstoreSet uint64 `json:"sstoreSet,omitempty"`
+	// ExpByte is the cost per byte of the exponent of EXP
+	ExpByte uint64 `json:"expByte,omitempty"`
+}
+
+// GasCostOverride overrides the gas costs from the given block
+type GasCostOverride struct {
+	Block Fork `json:"block"`
+	GasCosts
+}
+
+// GasCostsAt returns the gas cost overrides active at the block, nil if there are none
+func (p *Params) GasCostsAt(block uint64) *GasCosts {
+	var active *GasCostOverride
+
+	for _, o := range p.GasCostOverrides {
+		if o.Block.Active(block) && (active == nil || o.Block >= active.Block) {
+			active = o
+		}
+	}
+
+	if active == nil {
+		return nil
+	}
+
+	return &active.GasCosts
+}
+
+// PriceLimit is the minimum gas price of the transactions accepted by the pool,
+// activated from the given block
+type PriceLimit struct {
+	Block Fork   `json:"block"`
+	Limit uint64 `json:"limit"`
+}
+
+// PriceLimitAt returns the price limit of the schedule active at the block,
+// zero if there is none
+func PriceLimitAt(limits []*PriceLimit, block uint64) uint64 {
+	var (
+		active *PriceLimit
+		limit  uint64
+	)
+
+	for _, l := range limits {
+		if l.Block.Active(block) && (active == nil || l.Block >= active.Block) {
+			active = l
+			limit = l.Limit
+		}
+	}
+
+	return limit
+}
+
+// PriorityLane reserves a portion of the gas of every block to the transactions of the
+// system callers: the bridge signers of the state the block is built on, and the callers
+// configured, such as the validator set owner. Their transactions are packed first, up to
+// the reserved gas, so that the bridge relays cannot be crowded out during spam. The gas
+// they leave is used by the other transactions
+type PriorityLane struct {
+	// ReservedGasPercent is the percentage of the block gas limit reserved to the callers
+	ReservedGasPercent uint64 `json:"reservedGasPercent"`
+	// Callers are the senders of the transactions of the lane besides the bridge signers
+	Callers []types.Address `json:"callers,omitempty"`
+}
+
+// ReservedGas returns the gas of the block reserved to the lane, zero if there is no lane
+func (l *PriorityLane) ReservedGas(gasLimit uint64) uint64 {
+	if l == nil || len(l.Callers) == 0 {
+		return 0
+	}
+
+	if l.ReservedGasPercent >= 100 {
+		return gasLimit
+	}
+
+	return gasLimit / 100 * l.ReservedGasPercent
+}
+
+// IsCaller returns whether the address is a caller of the lane
+func (l *PriorityLane) IsCaller(addr types.Address) bool {
+	if l == nil {
+		return false
+	}
+
+	for _, caller := range l.Callers {
+		if caller == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rebase returns the params of a chain starting from the state of the block, the forks
+// and the schedules being moved by the number of the block. The engine config is kept as it is
+func (p *Params) Rebase(block uint64) *Params {
+	rebased := *p
+
+	if p.Forks != nil {
+		rebased.Forks = p.Forks.Rebase(block)
+	}
+
+	// the entries of the schedules superseded at the block are dropped,
+	// the one active at the block being active from the genesis
+	rebased.BlockBodySizeLimits = nil
+	rebased.PriceLimits = nil
+	rebased.CodeSizeLimits = nil
+	rebased.GasCostOverrides = nil
+
+	var activeBodyLimit *BlockBodySizeLimit
+
+	for _, l := range p.BlockBodySizeLimits {
+		if l.Block.Active(block) && (activeBodyLimit == nil || l.Block >= activeBodyLimit.Block) {
+			activeBodyLimit = l
+		}
+	}
+
+	for _, l := range p.BlockBodySizeLimits {
+		if l == activeBodyLimit || !l.Block.Active(block) {
+			rebased.BlockBodySizeLimits = append(rebased.BlockBodySizeLimits, &BlockBodySizeLimit{
+				Block: l.Block.Rebase(block),
+				Limit: l.Limit,
+			})
+		}
+	}
+
+	var activePriceLimit *PriceLimit
+
+	for _, l := range p.PriceLimits {
+		if l.Block.Active(block) && (activePriceLimit == nil || l.Block >= activePriceLimit.Block) {
+			activePriceLimit = l
+		}
+	}
+
+	for _, l := range p.PriceLimits {
+		if l == activePriceLimit || !l.Block.Active(block) {
+			rebased.PriceLimits = append(rebased.PriceLimits, &PriceLimit{
+				Block: l.Block.Rebase(block),
+				Limit: l.Limit,
+			})
+		}
+	}
+
+	var activeCodeSizeLimit *CodeSizeLimit
+
+	for _, l := range p.CodeSizeLimits {
+		if l.Block.Active(block) && (activeCodeSizeLimit == nil || l.Block >= activeCodeSizeLimit.Block) {
+			activeCodeSizeLimit = l
+		}
+	}
+
+	for _, l := range p.CodeSizeLimits {
+		if l == activeCodeSizeLimit || !l.Block.Active(block) {
+			rebased.CodeSizeLimits = append(rebased.CodeSizeLimits, &CodeSizeLimit{
+				Block: l.Block.Rebase(block),
+				Limit: l.Limit,
+			})
+		}
+	}
+
+	var activeGasCostOverride *GasCostOverride
+
+	for _, o := range p.GasCostOverrides {
+		if o.Block.Active(block) && (activeGasCostOverride == nil || o.Block >= activeGasCostOverride.Block) {
+			activeGasCostOverride = o
+		}
+	}
+
+	for _, o := range p.GasCostOverrides {
+		if o == activeGasCostOverride || !o.Block.Active(block) {
+			rebased.GasCostOverrides = append(rebased.GasCostOverrides, &GasCostOverride{
+				Block:    o.Block.Rebase(block),
+				GasCosts: o.GasCosts,
+			})
+		}
+	}
+
+	return &rebased
 }
 
 func (p *Params) GetEngine() string {
@@ -24,15 +355,20 @@ func (p *Params) GetEngine() string {
 
 // Forks specifies when each fork is activated
 type Forks struct {
-	Homestead      *Fork `json:"homestead,omitempty"`
-	Byzantium      *Fork `json:"byzantium,omitempty"`
-	Constantinople *Fork `json:"constantinople,omitempty"`
-	Petersburg     *Fork `json:"petersburg,omitempty"`
-	Istanbul       *Fork `json:"istanbul,omitempty"`
-	EIP150         *Fork `json:"EIP150,omitempty"`
-	EIP158         *Fork `json:"EIP158,omitempty"`
-	EIP155         *Fork `json:"EIP155,omitempty"`
-	Portland       *Fork `json:"portland,omitempty"`
+	Homestead       *Fork `json:"homestead,omitempty"`
+	Byzantium       *Fork `json:"byzantium,omitempty"`
+	Constantinople  *Fork `json:"constantinople,omitempty"`
+	Petersburg      *Fork `json:"petersburg,omitempty"`
+	Istanbul        *Fork `json:"istanbul,omitempty"`
+	EIP150          *Fork `json:"EIP150,omitempty"`
+	EIP158          *Fork `json:"EIP158,omitempty"`
+	EIP155          *Fork `json:"EIP155,omitempty"`
+	Portland        *Fork `json:"portland,omitempty"`
+	Governance      *Fork `json:"governance,omitempty"`
+	BridgeAllowlist *Fork `json:"bridgeallowlist,omitempty"`
+	Berlin          *Fork `json:"berlin,omitempty"`
+	London          *Fork `json:"london,omitempty"`
+	FeeRecipient    *Fork `json:"feerecipient,omitempty"`
 }
 
 func (f *Forks) on(ff *Fork, block uint64) bool {
@@ -83,17 +419,42 @@ func (f *Forks) IsPortland(block uint64) bool {
 	return f.active(f.Portland, block)
 }
 
+func (f *Forks) IsGovernance(block uint64) bool {
+	return f.active(f.Governance, block)
+}
+
+func (f *Forks) IsBridgeAllowlist(block uint64) bool {
+	return f.active(f.BridgeAllowlist, block)
+}
+
+func (f *Forks) IsBerlin(block uint64) bool {
+	return f.active(f.Berlin, block)
+}
+
+func (f *Forks) IsLondon(block uint64) bool {
+	return f.active(f.London, block)
+}
+
+func (f *Forks) IsFeeRecipient(block uint64) bool {
+	return f.active(f.FeeRecipient, block)
+}
+
 func (f *Forks) At(block uint64) ForksInTime {
 	return ForksInTime{
-		Homestead:      f.active(f.Homestead, block),
-		Byzantium:      f.active(f.Byzantium, block),
-		Constantinople: f.active(f.Constantinople, block),
-		Petersburg:     f.active(f.Petersburg, block),
-		Istanbul:       f.active(f.Istanbul, block),
-		EIP150:         f.active(f.EIP150, block),
-		EIP158:         f.active(f.EIP158, block),
-		EIP155:         f.active(f.EIP155, block),
-		Portland:       f.active(f.Portland, block),
+		Homestead:       f.active(f.Homestead, block),
+		Byzantium:       f.active(f.Byzantium, block),
+		Constantinople:  f.active(f.Constantinople, block),
+		Petersburg:      f.active(f.Petersburg, block),
+		Istanbul:        f.active(f.Istanbul, block),
+		EIP150:          f.active(f.EIP150, block),
+		EIP158:          f.active(f.EIP158, block),
+		EIP155:          f.active(f.EIP155, block),
+		Portland:        f.active(f.Portland, block),
+		Governance:      f.active(f.Governance, block),
+		BridgeAllowlist: f.active(f.BridgeAllowlist, block),
+		Berlin:          f.active(f.Berlin, block),
+		London:          f.active(f.London, block),
+		FeeRecipient:    f.active(f.FeeRecipient, block),
 	}
 }
 
@@ -101,6 +462,10 @@ func (f *Forks) IsOnPortland(block uint64) bool {
 	return f.on(f.Portland, block)
 }
 
+func (f *Forks) IsOnGovernance(block uint64) bool {
+	return f.on(f.Governance, block)
+}
+
 type Fork uint64
 
 func NewFork(n uint64) *Fork {
@@ -121,6 +486,45 @@ func (f Fork) Int() *big.Int {
 	return big.NewInt(int64(f))
 }
 
+// Rebase returns the fork of a chain starting from the state of the block,
+// active from its genesis if it is active at the block
+func (f Fork) Rebase(block uint64) Fork {
+	if f.Active(block) {
+		return 0
+	}
+
+	return f - Fork(block)
+}
+
+// rebaseFork rebases the fork if it is set
+func rebaseFork(f *Fork, block uint64) *Fork {
+	if f == nil {
+		return nil
+	}
+
+	return NewFork(uint64(f.Rebase(block)))
+}
+
+// Rebase returns the forks of a chain starting from the state of the block
+func (f *Forks) Rebase(block uint64) *Forks {
+	return &Forks{
+		Homestead:       rebaseFork(f.Homestead, block),
+		Byzantium:       rebaseFork(f.Byzantium, block),
+		Constantinople:  rebaseFork(f.Constantinople, block),
+		Petersburg:      rebaseFork(f.Petersburg, block),
+		Istanbul:        rebaseFork(f.Istanbul, block),
+		EIP150:          rebaseFork(f.EIP150, block),
+		EIP158:          rebaseFork(f.EIP158, block),
+		EIP155:          rebaseFork(f.EIP155, block),
+		Portland:        rebaseFork(f.Portland, block),
+		Governance:      rebaseFork(f.Governance, block),
+		BridgeAllowlist: rebaseFork(f.BridgeAllowlist, block),
+		Berlin:          rebaseFork(f.Berlin, block),
+		London:          rebaseFork(f.London, block),
+		FeeRecipient:    rebaseFork(f.FeeRecipient, block),
+	}
+}
+
 type ForksInTime struct {
 	Homestead,
 	Byzantium,
@@ -130,17 +534,30 @@ type ForksInTime struct {
 	EIP150,
 	EIP158,
 	EIP155,
-	Portland bool
+	Portland,
+	Governance,
+	BridgeAllowlist,
+	Berlin,
+	London,
+	FeeRecipient bool
+
+	// MaxCodeSize is the maximum size of the contract code, the EIP-170 one if zero
+	MaxCodeSize uint64
+	// GasCosts overrides the gas costs of the selected operations, nil if they are not
+	GasCosts *GasCosts
 }
 
 var AllForksEnabled = &Forks{
-	Homestead:      NewFork(0),
-	EIP150:         NewFork(0),
-	EIP155:         NewFork(0),
-	EIP158:         NewFork(0),
-	Byzantium:      NewFork(0),
-	Constantinople: NewFork(0),
-	Petersburg:     NewFork(0),
-	Istanbul:       NewFork(0),
-	Portland:       NewFork(10222),
+	Homestead:       NewFork(0),
+	EIP150:          NewFork(0),
+	EIP155:          NewFork(0),
+	EIP158:          NewFork(0),
+	Byzantium:       NewFork(0),
+	Constantinople:  NewFork(0),
+	Petersburg:      NewFork(0),
+	Istanbul:        NewFork(0),
+	Portland:        NewFork(10222),
+	Governance:      NewFork(0),
+	BridgeAllowlist: NewFork(0),
+	FeeRecipient:    NewFork(0),
 }
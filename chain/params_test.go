@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/dogechain-lab/dogechain/types"
 )
 
 func TestValidateChainID(t *testing.T) {
@@ -66,3 +68,284 @@ func TestParamsForksInTime(t *testing.T) {
 	expect("constantinople", ff.Constantinople, false)
 	expect("eip150", ff.EIP150, false)
 }
+
+func TestParamsBlockBodySizeLimitAt(t *testing.T) {
+	var params *Params
+	if err := json.Unmarshal([]byte(`{
+		"blockBodySizeLimits": [
+			{"block": 100, "limit": 2048},
+			{"block": 10, "limit": 1024},
+			{"block": 200, "limit": 0}
+		]
+	}`), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[uint64]uint64{
+		0:   0,
+		10:  1024,
+		99:  1024,
+		100: 2048,
+		200: 0,
+	}
+
+	for block, expected := range cases {
+		if limit := params.BlockBodySizeLimitAt(block); limit != expected {
+			t.Fatalf("block %d should be limited to %d but found %d", block, expected, limit)
+		}
+	}
+
+	if limit := (&Params{}).BlockBodySizeLimitAt(100); limit != 0 {
+		t.Fatalf("no limit expected but found %d", limit)
+	}
+}
+
+func TestPriorityLane(t *testing.T) {
+	var params *Params
+	if err := json.Unmarshal([]byte(`{
+		"priorityLane": {
+			"reservedGasPercent": 25,
+			"callers": ["0x0000000000000000000000000000000000000001"]
+		}
+	}`), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	lane := params.PriorityLane
+
+	if reserved := lane.ReservedGas(1000); reserved != 250 {
+		t.Fatalf("250 gas should be reserved but found %d", reserved)
+	}
+
+	if !lane.IsCaller(types.StringToAddress("0x1")) || lane.IsCaller(types.StringToAddress("0x2")) {
+		t.Fatal("only 0x1 should be a caller of the lane")
+	}
+
+	// no lane without callers
+	if reserved := (&PriorityLane{ReservedGasPercent: 25}).ReservedGas(1000); reserved != 0 {
+		t.Fatalf("no gas should be reserved but found %d", reserved)
+	}
+
+	if reserved := (&Params{}).PriorityLane.ReservedGas(1000); reserved != 0 {
+		t.Fatalf("no gas should be reserved but found %d", reserved)
+	}
+}
+
+func TestParamsCalculateBaseFee(t *testing.T) {
+	params := &Params{
+		Forks: &Forks{
+			London: NewFork(10),
+		},
+	}
+
+	cases := []struct {
+		name    string
+		parent  *types.Header
+		baseFee uint64
+	}{
+		{
+			"before london",
+			&types.Header{Number: 8},
+			0,
+		},
+		{
+			"first london block",
+			&types.Header{Number: 9, GasLimit: 20000000},
+			DefaultInitialBaseFee,
+		},
+		{
+			"parent at gas target",
+			&types.Header{Number: 10, GasLimit: 20000000, GasUsed: 10000000, BaseFee: 1000000000},
+			1000000000,
+		},
+		{
+			"parent full",
+			&types.Header{Number: 10, GasLimit: 20000000, GasUsed: 20000000, BaseFee: 1000000000},
+			1125000000,
+		},
+		{
+			"parent empty",
+			&types.Header{Number: 10, GasLimit: 20000000, BaseFee: 1000000000},
+			875000000,
+		},
+		{
+			"minimum increase",
+			&types.Header{Number: 10, GasLimit: 20000000, GasUsed: 10000001, BaseFee: 8},
+			9,
+		},
+	}
+
+	for _, c := range cases {
+		if baseFee := params.CalculateBaseFee(c.parent); baseFee != c.baseFee {
+			t.Fatalf("%s: base fee should be %d but found %d", c.name, c.baseFee, baseFee)
+		}
+	}
+
+	params.InitialBaseFee = 100
+
+	if baseFee := params.CalculateBaseFee(&types.Header{Number: 9}); baseFee != 100 {
+		t.Fatalf("configured initial base fee expected but found %d", baseFee)
+	}
+}
+
+func TestPriceLimitAt(t *testing.T) {
+	var params *Params
+	if err := json.Unmarshal([]byte(`{
+		"priceLimits": [
+			{"block": 100, "limit": 50},
+			{"block": 10, "limit": 20},
+			{"block": 200, "limit": 0}
+		]
+	}`), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[uint64]uint64{
+		0:   0,
+		10:  20,
+		99:  20,
+		100: 50,
+		200: 0,
+	}
+
+	for block, expected := range cases {
+		if limit := PriceLimitAt(params.PriceLimits, block); limit != expected {
+			t.Fatalf("block %d should be limited to %d but found %d", block, expected, limit)
+		}
+	}
+
+	if limit := PriceLimitAt(nil, 100); limit != 0 {
+		t.Fatalf("no limit expected but found %d", limit)
+	}
+}
+
+func TestCodeSizeLimitAt(t *testing.T) {
+	var params *Params
+	if err := json.Unmarshal([]byte(`{
+		"codeSizeLimits": [
+			{"block": 100, "limit": 49152},
+			{"block": 200, "limit": 65536}
+		]
+	}`), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[uint64]uint64{
+		0:   DefaultMaxCodeSize,
+		99:  DefaultMaxCodeSize,
+		100: 49152,
+		200: 65536,
+	}
+
+	for block, expected := range cases {
+		if limit := params.CodeSizeLimitAt(block); limit != expected {
+			t.Fatalf("block %d should be limited to %d but found %d", block, expected, limit)
+		}
+	}
+}
+
+func TestGasCostsAt(t *testing.T) {
+	var params *Params
+	if err := json.Unmarshal([]byte(`{
+		"forks": {"homestead": 0},
+		"gasCostOverrides": [
+			{"block": 100, "codeDeposit": 100, "sstoreSet": 10000},
+			{"block": 200, "expByte": 20}
+		]
+	}`), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	if costs := params.GasCostsAt(99); costs != nil {
+		t.Fatalf("no overrides expected but found %+v", costs)
+	}
+
+	// the latest override replaces the previous ones
+	cases := map[uint64]GasCosts{
+		100: {CodeDeposit: 100, SstoreSet: 10000},
+		200: {ExpByte: 20},
+	}
+
+	for block, expected := range cases {
+		if costs := params.GasCostsAt(block); costs == nil || *costs != expected {
+			t.Fatalf("block %d should override %+v but found %+v", block, expected, costs)
+		}
+	}
+
+	forks := params.ForksInTime(150)
+	if !forks.Homestead || forks.MaxCodeSize != DefaultMaxCodeSize || forks.GasCosts.CodeDeposit != 100 {
+		t.Fatalf("unexpected forks %+v", forks)
+	}
+}
+
+func TestParamsRebase(t *testing.T) {
+	params := &Params{
+		Forks: &Forks{
+			Homestead:  NewFork(0),
+			London:     NewFork(100),
+			Governance: NewFork(150),
+			Portland:   NewFork(300),
+		},
+		ChainID: 2000,
+		BlockBodySizeLimits: []*BlockBodySizeLimit{
+			{Block: 100, Limit: 2048},
+			{Block: 10, Limit: 1024},
+			{Block: 200, Limit: 0},
+		},
+		PriceLimits: []*PriceLimit{
+			{Block: 160, Limit: 1},
+		},
+		CodeSizeLimits: []*CodeSizeLimit{
+			{Block: 120, Limit: 49152},
+		},
+		GasCostOverrides: []*GasCostOverride{
+			{Block: 200, GasCosts: GasCosts{CodeDeposit: 100}},
+		},
+	}
+
+	rebased := params.Rebase(150)
+
+	// the forks active at the block are active from the genesis
+	expectedForks := &Forks{
+		Homestead:  NewFork(0),
+		London:     NewFork(0),
+		Governance: NewFork(0),
+		Portland:   NewFork(150),
+	}
+	if !reflect.DeepEqual(rebased.Forks, expectedForks) {
+		t.Fatalf("unexpected forks %+v", rebased.Forks)
+	}
+
+	if rebased.ChainID != 2000 {
+		t.Fatalf("unexpected chain id %d", rebased.ChainID)
+	}
+
+	// the superseded limits are dropped
+	expectedLimits := []*BlockBodySizeLimit{
+		{Block: 0, Limit: 2048},
+		{Block: 50, Limit: 0},
+	}
+	if !reflect.DeepEqual(rebased.BlockBodySizeLimits, expectedLimits) {
+		t.Fatalf("unexpected block body size limits %+v", rebased.BlockBodySizeLimits)
+	}
+
+	if !reflect.DeepEqual(rebased.PriceLimits, []*PriceLimit{{Block: 10, Limit: 1}}) {
+		t.Fatalf("unexpected price limits %+v", rebased.PriceLimits)
+	}
+
+	if !reflect.DeepEqual(rebased.CodeSizeLimits, []*CodeSizeLimit{{Block: 0, Limit: 49152}}) {
+		t.Fatalf("unexpected code size limits %+v", rebased.CodeSizeLimits)
+	}
+
+	expectedOverrides := []*GasCostOverride{
+		{Block: 50, GasCosts: GasCosts{CodeDeposit: 100}},
+	}
+	if !reflect.DeepEqual(rebased.GasCostOverrides, expectedOverrides) {
+		t.Fatalf("unexpected gas cost overrides %+v", rebased.GasCostOverrides)
+	}
+
+	// the params are left untouched
+	if *params.Forks.London != 100 || len(params.BlockBodySizeLimits) != 3 {
+		t.Fatal("params modified")
+	}
+}
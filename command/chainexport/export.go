@@ -0,0 +1,81 @@
+package chainexport
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use: "export [file]",
+		Short: "Writes the blocks of the chain to a new file, offline from the data directory. " +
+			"The .era and .era1 files are written in the era format, with the receipts of the blocks, " +
+			"the other files in RLP. The node of the data directory should be stopped",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(exportCmd)
+
+	return exportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"./dogechain-chain",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		"./genesis.json",
+		"the genesis file of the chain",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.from,
+		fromFlag,
+		0,
+		"the number of the first block to export",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.toRaw,
+		toFlag,
+		0,
+		"the number of the last block to export, the head of the chain if not set",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, args []string) error {
+	params.path = args[0]
+
+	if cmd.Flags().Changed(toFlag) {
+		params.to = &params.toRaw
+	}
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initChain(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.export(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,83 @@
+package chainexport
+
+import (
+	"github.com/dogechain-lab/dogechain/archive"
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/server"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	chainFlag   = "chain"
+	fromFlag    = "from"
+	toFlag      = "to"
+)
+
+var (
+	params = &exportParams{}
+)
+
+type exportParams struct {
+	dataDir     string
+	genesisPath string
+	from        uint64
+	toRaw       uint64
+	path        string
+
+	// to is nil to export up to the head
+	to *uint64
+
+	genesisConfig *chain.Chain
+
+	result *archive.ExportResult
+}
+
+func (p *exportParams) validateFlags() error {
+	if p.to != nil && *p.to < p.from {
+		return archive.ErrExportRange
+	}
+
+	return nil
+}
+
+func (p *exportParams) initChain() error {
+	var err error
+
+	p.genesisConfig, err = chain.Import(p.genesisPath)
+
+	return err
+}
+
+func (p *exportParams) generateConfig() *server.Config {
+	return &server.Config{
+		Chain:   p.genesisConfig,
+		DataDir: p.dataDir,
+		LeveldbOptions: &server.LeveldbOptions{
+			CacheSize:           kvdb.DefaultLevelDBCache,
+			Handles:             kvdb.DefaultLevelDBHandles,
+			BloomKeyBits:        kvdb.DefaultLevelDBBloomKeyBits,
+			CompactionTableSize: kvdb.DefaultLevelDBCompactionTableSize,
+			CompactionTotalSize: kvdb.DefaultLevelDBCompactionTotalSize,
+			NoSync:              kvdb.DefaultLevelDBNoSync,
+		},
+		LogLevel: hclog.Info,
+	}
+}
+
+func (p *exportParams) export() error {
+	var err error
+
+	p.result, err = server.ExportChain(p.generateConfig(), p.from, p.to, p.path)
+
+	return err
+}
+
+func (p *exportParams) getResult() command.CommandResult {
+	return &ExportResult{
+		ExportResult: p.result,
+		Path:         p.path,
+	}
+}
@@ -0,0 +1,31 @@
+package chainexport
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/archive"
+	"github.com/dogechain-lab/dogechain/command/helper"
+)
+
+type ExportResult struct {
+	*archive.ExportResult
+
+	Path string `json:"path"`
+}
+
+func (r *ExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[EXPORT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Path),
+		fmt.Sprintf("Format|%s", r.Format),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+		fmt.Sprintf("Blocks|%d", r.Blocks),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
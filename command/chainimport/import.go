@@ -0,0 +1,70 @@
+package chainimport
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	importCmd := &cobra.Command{
+		Use: "import [file]",
+		Short: "Verifies and writes the blocks of an exported chain file, in the RLP or era format, " +
+			"to the chain of the data directory, offline. The node of the data directory should be stopped",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(importCmd)
+
+	return importCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"./dogechain-chain",
+		"the data directory of the stopped node, created if missing",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		"./genesis.json",
+		"the genesis file of the chain",
+	)
+
+	cmd.Flags().StringVar(
+		&params.dbBackend,
+		dbBackendFlag,
+		"",
+		"the key-value backend of a new data directory (leveldb, pebble), "+
+			"the backend of an existing one being detected",
+	)
+}
+
+func runPreRun(_ *cobra.Command, args []string) error {
+	params.path = args[0]
+
+	return nil
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initChain(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.importChain(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
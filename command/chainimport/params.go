@@ -0,0 +1,70 @@
+package chainimport
+
+import (
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/server"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag   = "data-dir"
+	chainFlag     = "chain"
+	dbBackendFlag = "db-backend"
+)
+
+var (
+	params = &importParams{}
+)
+
+type importParams struct {
+	dataDir     string
+	genesisPath string
+	dbBackend   string
+	path        string
+
+	genesisConfig *chain.Chain
+
+	result *server.ImportResult
+}
+
+func (p *importParams) initChain() error {
+	var err error
+
+	p.genesisConfig, err = chain.Import(p.genesisPath)
+
+	return err
+}
+
+func (p *importParams) generateConfig() *server.Config {
+	return &server.Config{
+		Chain:     p.genesisConfig,
+		DataDir:   p.dataDir,
+		DBBackend: p.dbBackend,
+		LeveldbOptions: &server.LeveldbOptions{
+			CacheSize:           kvdb.DefaultLevelDBCache,
+			Handles:             kvdb.DefaultLevelDBHandles,
+			BloomKeyBits:        kvdb.DefaultLevelDBBloomKeyBits,
+			CompactionTableSize: kvdb.DefaultLevelDBCompactionTableSize,
+			CompactionTotalSize: kvdb.DefaultLevelDBCompactionTotalSize,
+			NoSync:              kvdb.DefaultLevelDBNoSync,
+		},
+		LogLevel: hclog.Info,
+	}
+}
+
+func (p *importParams) importChain() error {
+	var err error
+
+	p.result, err = server.ImportChain(p.generateConfig(), p.path)
+
+	return err
+}
+
+func (p *importParams) getResult() command.CommandResult {
+	return &ImportResult{
+		ImportResult: p.result,
+		Path:         p.path,
+	}
+}
@@ -0,0 +1,31 @@
+package chainimport
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/server"
+)
+
+type ImportResult struct {
+	*server.ImportResult
+
+	Path string `json:"path"`
+}
+
+func (r *ImportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[IMPORT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Path),
+		fmt.Sprintf("Format|%s", r.Format),
+		fmt.Sprintf("Previous head|%d", r.From),
+		fmt.Sprintf("Head|%d", r.To),
+		fmt.Sprintf("Imported blocks|%d", r.Blocks),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,27 @@
+package db
+
+import (
+	"github.com/dogechain-lab/dogechain/command/db/migrate"
+	"github.com/dogechain-lab/dogechain/command/db/stats"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Top level command for managing the databases of a stopped node. Only accepts subcommands.",
+	}
+
+	registerSubcommands(dbCmd)
+
+	return dbCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// db migrate
+		migrate.GetCommand(),
+		// db stats
+		stats.GetCommand(),
+	)
+}
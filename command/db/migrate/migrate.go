@@ -0,0 +1,51 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use: "migrate",
+		Short: "Converts the blockchain and state databases of the data directory to another backend. " +
+			"The node of the data directory should be stopped",
+		Run: runCommand,
+	}
+
+	setFlags(migrateCmd)
+
+	return migrateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"./dogechain-chain",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.to,
+		toFlag,
+		kvdb.BackendPebble,
+		fmt.Sprintf("the backend the databases are converted to, %s or %s", kvdb.BackendLevelDB, kvdb.BackendPebble),
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.migrate(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
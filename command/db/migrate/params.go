@@ -0,0 +1,53 @@
+package migrate
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/server"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	toFlag      = "to"
+)
+
+var (
+	params = &migrateParams{}
+)
+
+type migrateParams struct {
+	dataDir string
+	to      string
+
+	result *server.MigrateResult
+}
+
+func (p *migrateParams) generateConfig() *server.Config {
+	return &server.Config{
+		DataDir: p.dataDir,
+		LeveldbOptions: &server.LeveldbOptions{
+			CacheSize:           kvdb.DefaultLevelDBCache,
+			Handles:             kvdb.DefaultLevelDBHandles,
+			BloomKeyBits:        kvdb.DefaultLevelDBBloomKeyBits,
+			CompactionTableSize: kvdb.DefaultLevelDBCompactionTableSize,
+			CompactionTotalSize: kvdb.DefaultLevelDBCompactionTotalSize,
+			NoSync:              kvdb.DefaultLevelDBNoSync,
+		},
+		LogLevel: hclog.Info,
+	}
+}
+
+func (p *migrateParams) migrate() error {
+	var err error
+
+	p.result, err = server.MigrateDatabase(p.generateConfig(), p.to)
+
+	return err
+}
+
+func (p *migrateParams) getResult() command.CommandResult {
+	return &MigrateResult{
+		MigrateResult: p.result,
+	}
+}
@@ -0,0 +1,27 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/server"
+)
+
+type MigrateResult struct {
+	*server.MigrateResult
+}
+
+func (r *MigrateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DATABASE MIGRATION]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Backend|%s", r.To),
+		fmt.Sprintf("Blockchain pairs|%d", r.Blockchain),
+		fmt.Sprintf("State pairs|%d", r.State),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,54 @@
+package stats
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/server"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag      = "data-dir"
+	backendStatsFlag = "backend-stats"
+)
+
+var (
+	params = &statsParams{}
+)
+
+type statsParams struct {
+	dataDir      string
+	backendStats bool
+
+	result *server.DBStatsResult
+}
+
+func (p *statsParams) generateConfig() *server.Config {
+	return &server.Config{
+		DataDir: p.dataDir,
+		LeveldbOptions: &server.LeveldbOptions{
+			CacheSize:           kvdb.DefaultLevelDBCache,
+			Handles:             kvdb.DefaultLevelDBHandles,
+			BloomKeyBits:        kvdb.DefaultLevelDBBloomKeyBits,
+			CompactionTableSize: kvdb.DefaultLevelDBCompactionTableSize,
+			CompactionTotalSize: kvdb.DefaultLevelDBCompactionTotalSize,
+			NoSync:              kvdb.DefaultLevelDBNoSync,
+		},
+		LogLevel: hclog.Error,
+	}
+}
+
+func (p *statsParams) inspect() error {
+	var err error
+
+	p.result, err = server.DatabaseStats(p.generateConfig())
+
+	return err
+}
+
+func (p *statsParams) getResult() command.CommandResult {
+	return &StatsResult{
+		DBStatsResult: p.result,
+		backendStats:  p.backendStats,
+	}
+}
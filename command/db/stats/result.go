@@ -0,0 +1,78 @@
+package stats
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/server"
+)
+
+type StatsResult struct {
+	*server.DBStatsResult
+
+	backendStats bool
+}
+
+func (r *StatsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	r.writeDatabase(&buffer, "BLOCKCHAIN DATABASE", r.Blockchain)
+
+	if r.Ancient != nil {
+		r.writeDatabase(&buffer, "ANCIENT DATABASE", r.Ancient)
+	}
+
+	r.writeDatabase(&buffer, "STATE DATABASE", r.State)
+
+	return buffer.String()
+}
+
+func (r *StatsResult) writeDatabase(buffer *bytes.Buffer, title string, stats *server.DBStats) {
+	var keys, size uint64
+
+	rows := []string{"Category|Keys|Size"}
+
+	for _, category := range stats.Keys {
+		rows = append(rows, fmt.Sprintf("%s|%d|%s",
+			category.Category, category.Keys, formatSize(category.Size)))
+
+		keys += category.Keys
+		size += category.Size
+	}
+
+	rows = append(rows, fmt.Sprintf("Total|%d|%s", keys, formatSize(size)))
+
+	buffer.WriteString(fmt.Sprintf("\n[%s]\n", title))
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Path|%s", stats.Path),
+		fmt.Sprintf("Backend|%s", stats.Backend),
+		fmt.Sprintf("Disk size|%s", formatSize(stats.DiskSize)),
+	}))
+	buffer.WriteString("\n\n")
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	if r.backendStats && stats.BackendStats != "" {
+		buffer.WriteString("\n")
+		buffer.WriteString(stats.BackendStats)
+		buffer.WriteString("\n")
+	}
+}
+
+// formatSize formats the size in bytes with the largest binary unit below it
+func formatSize(size uint64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+
+	value, unit := float64(size), 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	return fmt.Sprintf("%.2f %s", value, units[unit])
+}
@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	statsCmd := &cobra.Command{
+		Use: "stats",
+		Short: "Counts the pairs and sizes of the blockchain and state databases of the data directory, " +
+			"per category of data. The node of the data directory should be stopped",
+		Run: runCommand,
+	}
+
+	setFlags(statsCmd)
+
+	return statsCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"./dogechain-chain",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.backendStats,
+		backendStatsFlag,
+		false,
+		"also print the statistics of the backend, as the sizes and compactions of its levels",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.inspect(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
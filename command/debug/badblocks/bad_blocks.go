@@ -0,0 +1,41 @@
+package badblocks
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	badBlocksCmd := &cobra.Command{
+		Use:   "badblocks",
+		Short: "Returns the recent blocks rejected by the node, and the reason they were rejected for",
+		Run:   runCommand,
+	}
+
+	setFlags(badBlocksCmd)
+
+	return badBlocksCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.limit,
+		limitFlag,
+		10,
+		"the maximum number of the bad blocks listed, the most recent first. All of them if 0",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initBadBlocks(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,46 @@
+package badblocks
+
+import (
+	"context"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/server/proto"
+)
+
+const (
+	limitFlag = "limit"
+)
+
+var (
+	params = &badBlocksParams{}
+)
+
+type badBlocksParams struct {
+	limit uint64
+
+	badBlocks *proto.BadBlocksResponse
+}
+
+func (p *badBlocksParams) initBadBlocks(grpcAddress string) error {
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	badBlocks, err := client.BadBlocks(
+		context.Background(),
+		&proto.BadBlocksRequest{Limit: p.limit},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.badBlocks = badBlocks
+
+	return nil
+}
+
+func (p *badBlocksParams) getResult() command.CommandResult {
+	return newBadBlocksResult(p.badBlocks)
+}
@@ -0,0 +1,71 @@
+package badblocks
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/server/proto"
+)
+
+type BadBlock struct {
+	Number    uint64 `json:"number"`
+	Hash      string `json:"hash"`
+	Reason    string `json:"reason"`
+	Timestamp uint64 `json:"timestamp"`
+}
+
+type BadBlocksResult struct {
+	Blocks []BadBlock `json:"blocks"`
+}
+
+func newBadBlocksResult(resp *proto.BadBlocksResponse) *BadBlocksResult {
+	res := &BadBlocksResult{
+		Blocks: make([]BadBlock, len(resp.Blocks)),
+	}
+
+	for i, b := range resp.Blocks {
+		res.Blocks[i] = BadBlock{
+			Number:    b.Number,
+			Hash:      b.Hash,
+			Reason:    b.Reason,
+			Timestamp: b.Timestamp,
+		}
+	}
+
+	return res
+}
+
+func (r *BadBlocksResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BAD BLOCKS]\n")
+
+	if len(r.Blocks) == 0 {
+		buffer.WriteString("No bad blocks found\n")
+
+		return buffer.String()
+	}
+
+	rows := make([]string, 0, len(r.Blocks)+1)
+	rows = append(rows, "Number|Hash|Rejected At|Reason")
+
+	for _, b := range r.Blocks {
+		rows = append(
+			rows,
+			fmt.Sprintf(
+				"%d|%s|%s|%s",
+				b.Number,
+				b.Hash,
+				time.Unix(int64(b.Timestamp), 0).UTC().Format(time.RFC3339),
+				b.Reason,
+			),
+		)
+	}
+
+	buffer.WriteString(helper.FormatKV(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,30 @@
+package debug
+
+import (
+	"github.com/dogechain-lab/dogechain/command/debug/badblocks"
+	"github.com/dogechain-lab/dogechain/command/debug/sethead"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	debugCmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Top level command for debugging the node. Only accepts subcommands.",
+	}
+
+	helper.RegisterGRPCAddressFlag(debugCmd)
+
+	registerSubcommands(debugCmd)
+
+	return debugCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// debug badblocks
+		badblocks.GetCommand(),
+		// debug set-head
+		sethead.GetCommand(),
+	)
+}
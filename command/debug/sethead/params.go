@@ -0,0 +1,80 @@
+package sethead
+
+import (
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/server"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	chainFlag   = "chain"
+)
+
+var (
+	params = &setHeadParams{}
+)
+
+type setHeadParams struct {
+	dataDir     string
+	genesisPath string
+	numberRaw   string
+
+	number uint64
+
+	genesisConfig *chain.Chain
+
+	result *blockchain.SetHeadResult
+}
+
+func (p *setHeadParams) validateArgs(args []string) error {
+	p.numberRaw = args[0]
+
+	var err error
+
+	p.number, err = types.ParseUint64orHex(&p.numberRaw)
+
+	return err
+}
+
+func (p *setHeadParams) initChain() error {
+	var err error
+
+	p.genesisConfig, err = chain.Import(p.genesisPath)
+
+	return err
+}
+
+func (p *setHeadParams) generateConfig() *server.Config {
+	return &server.Config{
+		Chain:   p.genesisConfig,
+		DataDir: p.dataDir,
+		LeveldbOptions: &server.LeveldbOptions{
+			CacheSize:           kvdb.DefaultLevelDBCache,
+			Handles:             kvdb.DefaultLevelDBHandles,
+			BloomKeyBits:        kvdb.DefaultLevelDBBloomKeyBits,
+			CompactionTableSize: kvdb.DefaultLevelDBCompactionTableSize,
+			CompactionTotalSize: kvdb.DefaultLevelDBCompactionTotalSize,
+			NoSync:              kvdb.DefaultLevelDBNoSync,
+		},
+		LogLevel: hclog.Info,
+	}
+}
+
+func (p *setHeadParams) setHead() error {
+	var err error
+
+	p.result, err = server.SetHead(p.generateConfig(), p.number)
+
+	return err
+}
+
+func (p *setHeadParams) getResult() command.CommandResult {
+	return &SetHeadResult{
+		SetHeadResult: p.result,
+	}
+}
@@ -0,0 +1,29 @@
+package sethead
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/command/helper"
+)
+
+type SetHeadResult struct {
+	*blockchain.SetHeadResult
+}
+
+func (r *SetHeadResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SET HEAD]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d (%s)", r.To, r.Hash),
+		fmt.Sprintf("Blocks deleted|%d", r.Blocks),
+		fmt.Sprintf("Fork blocks deleted|%d", r.ForkBlocks),
+		fmt.Sprintf("Transaction lookups deleted|%d", r.Transactions),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
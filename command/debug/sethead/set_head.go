@@ -0,0 +1,61 @@
+package sethead
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	setHeadCmd := &cobra.Command{
+		Use: "set-head [number]",
+		Short: "Rewinds the chain of the data directory to the block of the number, deleting the blocks above it " +
+			"along with their receipts and transaction lookups. The state of the block must be found. " +
+			"The node of the data directory should be stopped",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(setHeadCmd)
+
+	return setHeadCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"./dogechain-chain",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		"./genesis.json",
+		"the genesis file of the chain",
+	)
+}
+
+func runPreRun(_ *cobra.Command, args []string) error {
+	return params.validateArgs(args)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initChain(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.setHead(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,24 @@
+package devnet
+
+import (
+	"github.com/dogechain-lab/dogechain/command/devnet/up"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	devnetCmd := &cobra.Command{
+		Use:   "devnet",
+		Short: "Top level command for bootstrapping local development networks. Only accepts subcommands.",
+	}
+
+	registerSubcommands(devnetCmd)
+
+	return devnetCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// devnet up
+		up.GetCommand(),
+	)
+}
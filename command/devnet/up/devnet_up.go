@@ -0,0 +1,146 @@
+package up
+
+import (
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/consensus/ibft"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	devnetUpCmd := &cobra.Command{
+		Use: "up",
+		Short: "Bootstraps a local development network: generates the validator secrets, the genesis " +
+			"and the node configs to the directory if missing, then launches the nodes",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(devnetUpCmd)
+
+	return devnetUpCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dir,
+		dirFlag,
+		defaultDir,
+		"the directory of the development network, reused if it already holds a genesis",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.validators,
+		validatorsFlag,
+		defaultValidators,
+		"the number of validator nodes",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.isPos,
+		posFlag,
+		false,
+		"the flag indicating that the network uses Proof of Stake IBFT, Proof of Authority otherwise",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.premine,
+		premineFlag,
+		[]string{},
+		fmt.Sprintf(
+			"the premined accounts and balances (format: <address>:<balance>). Default premined balance: %s",
+			command.DefaultPremineBalance,
+		),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.chainID,
+		chainIDFlag,
+		command.DefaultChainID,
+		"the ID of the chain",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.epochSize,
+		epochSizeFlag,
+		ibft.DefaultEpochSize,
+		"the epoch size for the chain",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.blockGasLimit,
+		blockGasLimitFlag,
+		command.DefaultGenesisGasLimit,
+		"the maximum amount of gas used by all transactions in a block",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.basePort,
+		basePortFlag,
+		defaultBasePort,
+		fmt.Sprintf(
+			"the first port of the nodes, each node listening to %d ports (gRPC, libp2p, JSON-RPC) from its own base",
+			portsPerNode,
+		),
+	)
+
+	cmd.Flags().BoolVar(
+		&params.dockerCompose,
+		dockerComposeFlag,
+		false,
+		"the flag indicating that a docker-compose file is written to the directory instead of launching the nodes",
+	)
+
+	cmd.Flags().StringVar(
+		&params.image,
+		imageFlag,
+		defaultImage,
+		"the docker image of the nodes in the docker-compose file",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+
+	if err := params.setupNetwork(); err != nil {
+		outputter.SetError(err)
+		outputter.WriteOutput()
+
+		return
+	}
+
+	if params.dockerCompose {
+		if err := params.writeComposeFile(); err != nil {
+			outputter.SetError(err)
+		} else {
+			outputter.SetCommandResult(params.getResult())
+		}
+
+		outputter.WriteOutput()
+
+		return
+	}
+
+	if err := params.startNodes(); err != nil {
+		params.stopNodes()
+
+		outputter.SetError(err)
+		outputter.WriteOutput()
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+	outputter.WriteOutput()
+
+	if err := helper.HandleSignals(params.stopNodes, outputter); err != nil {
+		outputter.SetError(err)
+		outputter.WriteOutput()
+	}
+}
@@ -0,0 +1,381 @@
+package up
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/server"
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/network"
+	"github.com/dogechain-lab/dogechain/secrets"
+	"github.com/dogechain-lab/dogechain/secrets/helper"
+	"github.com/dogechain-lab/dogechain/secrets/local"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	dirFlag           = "dir"
+	validatorsFlag    = "validators"
+	posFlag           = "pos"
+	premineFlag       = "premine"
+	chainIDFlag       = "chain-id"
+	epochSizeFlag     = "epoch-size"
+	blockGasLimitFlag = "block-gas-limit"
+	basePortFlag      = "base-port"
+	dockerComposeFlag = "docker-compose"
+	imageFlag         = "image"
+)
+
+const (
+	defaultDir        = "./devnet"
+	defaultValidators = 4
+	defaultBasePort   = 10000
+	defaultImage      = "dogechain/dogechain:latest"
+
+	// portsPerNode is the number of ports reserved to each node, from its base port
+	portsPerNode = 10
+
+	nodeDirPrefix = "node"
+	configFile    = "config.json"
+	logFile       = "node.log"
+	composeFile   = "docker-compose.yml"
+
+	// composeWorkDir is the directory the network directory is mounted to in the containers
+	composeWorkDir = "/devnet"
+)
+
+var (
+	params = &upParams{}
+)
+
+var (
+	errNoValidators      = errors.New("at least one validator is required")
+	errInvalidEpochSize  = errors.New("epoch size must be greater than 1")
+	errPortsOutOfRange   = errors.New("the ports of the nodes exceed the port range")
+	errNodeSecretsMissed = errors.New("the secrets of a node of the existing network are missing")
+)
+
+type upParams struct {
+	dir           string
+	validators    uint64
+	isPos         bool
+	premine       []string
+	chainID       uint64
+	epochSize     uint64
+	blockGasLimit uint64
+	basePort      uint64
+	dockerCompose bool
+	image         string
+
+	nodes          []*devnetNode
+	genesisCreated bool
+}
+
+// devnetNode is a validator node of the development network
+type devnetNode struct {
+	name    string
+	address types.Address
+	nodeID  peer.ID
+
+	grpcPort    uint64
+	libp2pPort  uint64
+	jsonRPCPort uint64
+
+	cmd *exec.Cmd
+}
+
+func (n *devnetNode) bootnode() string {
+	return fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", n.libp2pPort, n.nodeID)
+}
+
+func (p *upParams) validateFlags() error {
+	if p.validators == 0 {
+		return errNoValidators
+	}
+
+	if p.epochSize < 2 {
+		return errInvalidEpochSize
+	}
+
+	if p.basePort == 0 || p.basePort+p.validators*portsPerNode > 65535 {
+		return errPortsOutOfRange
+	}
+
+	return nil
+}
+
+func (p *upParams) genesisPath() string {
+	return filepath.Join(p.dir, command.DefaultGenesisFileName)
+}
+
+// setupNetwork prepares the secrets, the genesis and the configs of the nodes.
+// An existing network is reused as is, only its node configs are rewritten
+func (p *upParams) setupNetwork() error {
+	if err := os.MkdirAll(p.dir, 0755); err != nil {
+		return err
+	}
+
+	_, err := os.Stat(p.genesisPath())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	isNew := os.IsNotExist(err)
+
+	if err := p.initNodes(isNew); err != nil {
+		return err
+	}
+
+	if isNew {
+		if err := p.generateGenesis(); err != nil {
+			return err
+		}
+
+		p.genesisCreated = true
+	}
+
+	return p.writeConfigs()
+}
+
+// initNodes reads the secrets of the nodes, generating the ones missing for a new network
+func (p *upParams) initNodes(isNew bool) error {
+	p.nodes = make([]*devnetNode, 0, p.validators)
+
+	for idx := uint64(0); idx < p.validators; idx++ {
+		base := p.basePort + idx*portsPerNode
+		node := &devnetNode{
+			name:        fmt.Sprintf("%s%d", nodeDirPrefix, idx+1),
+			grpcPort:    base,
+			libp2pPort:  base + 1,
+			jsonRPCPort: base + 2,
+		}
+
+		// the secrets of an existing node are read, so the local secrets manager is set up directly
+		secretsManager, err := local.SecretsManagerFactory(
+			nil,
+			&secrets.SecretsManagerParams{
+				Logger: hclog.NewNullLogger(),
+				Extra: map[string]interface{}{
+					secrets.Path: filepath.Join(p.dir, node.name),
+				},
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		hasSecrets := secretsManager.HasSecret(secrets.ValidatorKey) &&
+			secretsManager.HasSecret(secrets.NetworkKey)
+
+		if !hasSecrets && !isNew {
+			return fmt.Errorf("%w: %s", errNodeSecretsMissed, node.name)
+		}
+
+		if !hasSecrets {
+			if _, err := helper.InitValidatorKey(secretsManager); err != nil {
+				return err
+			}
+
+			if _, err := helper.InitNetworkingPrivateKey(secretsManager); err != nil {
+				return err
+			}
+		}
+
+		validatorKey, err := crypto.ReadConsensusKey(secretsManager)
+		if err != nil {
+			return err
+		}
+
+		networkKey, err := network.ReadLibp2pKey(secretsManager)
+		if err != nil {
+			return err
+		}
+
+		if node.nodeID, err = peer.IDFromPrivateKey(networkKey); err != nil {
+			return err
+		}
+
+		node.address = crypto.PubKeyToAddress(&validatorKey.PublicKey)
+
+		p.nodes = append(p.nodes, node)
+	}
+
+	return nil
+}
+
+// generateGenesis runs the genesis command of the binary, so the network gets
+// the genesis a manual setup would
+func (p *upParams) generateGenesis() error {
+	args := []string{
+		"genesis",
+		"--dir", p.genesisPath(),
+		"--consensus", "ibft",
+		"--chain-id", strconv.FormatUint(p.chainID, 10),
+		"--epoch-size", strconv.FormatUint(p.epochSize, 10),
+		"--block-gas-limit", strconv.FormatUint(p.blockGasLimit, 10),
+	}
+
+	if p.isPos {
+		args = append(args, "--pos")
+	}
+
+	for _, premine := range p.premine {
+		args = append(args, "--premine", premine)
+	}
+
+	for _, node := range p.nodes {
+		args = append(args,
+			"--ibft-validator", node.address.String(),
+			"--bootnode", node.bootnode(),
+		)
+	}
+
+	output, err := p.execBinary(args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("unable to generate the genesis: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// writeConfigs writes the server config of every node. The paths of the configs are
+// relative to the network directory, the working directory of the nodes
+func (p *upParams) writeConfigs() error {
+	for _, node := range p.nodes {
+		config := server.DefaultConfig()
+		config.GenesisPath = command.DefaultGenesisFileName
+		config.DataDir = node.name
+		config.ShouldSeal = true
+		config.GRPCAddr = fmt.Sprintf("127.0.0.1:%d", node.grpcPort)
+		config.JSONRPCAddr = fmt.Sprintf("127.0.0.1:%d", node.jsonRPCPort)
+		config.Network.Libp2pAddr = fmt.Sprintf("127.0.0.1:%d", node.libp2pPort)
+
+		// the peer range is derived from the maximum peers
+		config.Network.MaxInboundPeers = 0
+		config.Network.MaxOutboundPeers = 0
+
+		data, err := json.MarshalIndent(config, "", "    ")
+		if err != nil {
+			return err
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(p.dir, node.name, configFile), data, 0600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeComposeFile writes a docker-compose file running the nodes on the host network
+func (p *upParams) writeComposeFile() error {
+	var compose strings.Builder
+
+	compose.WriteString("version: \"3.8\"\n\nservices:\n")
+
+	for _, node := range p.nodes {
+		fmt.Fprintf(&compose, "  %s:\n", node.name)
+		fmt.Fprintf(&compose, "    image: %s\n", p.image)
+		fmt.Fprintf(&compose, "    command: [\"server\", \"--config\", \"%s/%s\"]\n", node.name, configFile)
+		fmt.Fprintf(&compose, "    working_dir: %s\n", composeWorkDir)
+		fmt.Fprintf(&compose, "    volumes:\n      - .:%s\n", composeWorkDir)
+		compose.WriteString("    network_mode: host\n")
+		compose.WriteString("    restart: unless-stopped\n")
+	}
+
+	return ioutil.WriteFile(filepath.Join(p.dir, composeFile), []byte(compose.String()), 0600)
+}
+
+// startNodes launches a server process per node, logging to the node directory
+func (p *upParams) startNodes() error {
+	for _, node := range p.nodes {
+		log, err := os.Create(filepath.Join(p.dir, node.name, logFile))
+		if err != nil {
+			return err
+		}
+
+		cmd := p.execBinary("server", "--config", filepath.Join(node.name, configFile))
+		cmd.Dir = p.dir
+		cmd.Stdout = log
+		cmd.Stderr = log
+
+		if err := cmd.Start(); err != nil {
+			log.Close()
+
+			return fmt.Errorf("unable to start %s: %w", node.name, err)
+		}
+
+		// the child process holds its own descriptor
+		log.Close()
+
+		node.cmd = cmd
+	}
+
+	return nil
+}
+
+// stopNodes interrupts the nodes started and waits for them to exit
+func (p *upParams) stopNodes() {
+	for _, node := range p.nodes {
+		if node.cmd == nil {
+			continue
+		}
+
+		if err := node.cmd.Process.Signal(os.Interrupt); err != nil {
+			_ = node.cmd.Process.Kill()
+		}
+	}
+
+	for _, node := range p.nodes {
+		if node.cmd == nil {
+			continue
+		}
+
+		_ = node.cmd.Wait()
+		node.cmd = nil
+	}
+}
+
+// execBinary returns the command running the binary itself
+func (p *upParams) execBinary(args ...string) *exec.Cmd {
+	binary, err := os.Executable()
+	if err != nil {
+		binary = os.Args[0]
+	}
+
+	return exec.Command(binary, args...)
+}
+
+func (p *upParams) getResult() command.CommandResult {
+	result := &DevnetUpResult{
+		Dir:            p.dir,
+		GenesisCreated: p.genesisCreated,
+		Nodes:          make([]*DevnetNode, 0, len(p.nodes)),
+	}
+
+	if p.dockerCompose {
+		result.ComposeFile = filepath.Join(p.dir, composeFile)
+	}
+
+	for _, node := range p.nodes {
+		result.Nodes = append(result.Nodes, &DevnetNode{
+			Name:        node.name,
+			Address:     node.address,
+			NodeID:      node.nodeID.String(),
+			GRPCAddr:    fmt.Sprintf("127.0.0.1:%d", node.grpcPort),
+			JSONRPCAddr: fmt.Sprintf("http://127.0.0.1:%d", node.jsonRPCPort),
+		})
+	}
+
+	return result
+}
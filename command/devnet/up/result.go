@@ -0,0 +1,65 @@
+package up
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+type DevnetNode struct {
+	Name        string        `json:"name"`
+	Address     types.Address `json:"address"`
+	NodeID      string        `json:"node_id"`
+	GRPCAddr    string        `json:"grpc_addr"`
+	JSONRPCAddr string        `json:"jsonrpc_addr"`
+}
+
+type DevnetUpResult struct {
+	Dir            string        `json:"dir"`
+	GenesisCreated bool          `json:"genesis_created"`
+	ComposeFile    string        `json:"compose_file,omitempty"`
+	Nodes          []*DevnetNode `json:"nodes"`
+}
+
+func (r *DevnetUpResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DEVNET UP]\n")
+
+	status := "reused"
+	if r.GenesisCreated {
+		status = "created"
+	}
+
+	kv := []string{
+		fmt.Sprintf("Directory|%s", r.Dir),
+		fmt.Sprintf("Genesis|%s", status),
+	}
+
+	if r.ComposeFile != "" {
+		kv = append(kv, fmt.Sprintf("Docker compose file|%s", r.ComposeFile))
+	}
+
+	buffer.WriteString(helper.FormatKV(kv))
+	buffer.WriteString("\n\n[NODES]\n")
+
+	nodes := make([]string, len(r.Nodes)+1)
+	nodes[0] = "Name|Address|Node ID|gRPC|JSON-RPC"
+
+	for i, node := range r.Nodes {
+		nodes[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s",
+			node.Name,
+			node.Address,
+			node.NodeID,
+			node.GRPCAddr,
+			node.JSONRPCAddr,
+		)
+	}
+
+	buffer.WriteString(helper.FormatList(nodes))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,79 @@
+package dumpgenesis
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dumpGenesisCmd := &cobra.Command{
+		Use: "dump-genesis",
+		Short: "Writes the genesis file of a new chain starting from the state of a block, " +
+			"offline from the data directory, to boot forks of the chain. " +
+			"The state is recovered from the preimages recorded by the node, " +
+			"so that a state written by a state sync cannot be dumped. The node of the data directory should be stopped",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(dumpGenesisCmd)
+
+	return dumpGenesisCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"./dogechain-chain",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		"./genesis.json",
+		"the genesis file of the chain",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.blockRaw,
+		blockFlag,
+		0,
+		"the number of the block whose state is dumped, the head of the chain if not set",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputPath,
+		outputFlag,
+		"./genesis-fork.json",
+		"the genesis file to write",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	if cmd.Flags().Changed(blockFlag) {
+		params.block = &params.blockRaw
+	}
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initChain(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.dump(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
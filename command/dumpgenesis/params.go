@@ -0,0 +1,94 @@
+package dumpgenesis
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/server"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	chainFlag   = "chain"
+	blockFlag   = "block"
+	outputFlag  = "output"
+)
+
+var (
+	params = &dumpGenesisParams{}
+)
+
+type dumpGenesisParams struct {
+	dataDir     string
+	genesisPath string
+	blockRaw    uint64
+	outputPath  string
+
+	// block is nil to dump the state of the head
+	block *uint64
+
+	genesisConfig *chain.Chain
+
+	result *server.DumpGenesisResult
+}
+
+func (p *dumpGenesisParams) validateFlags() error {
+	if _, err := os.Stat(p.outputPath); err == nil {
+		return fmt.Errorf("genesis file at path (%s) already exists", p.outputPath)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to stat (%s): %w", p.outputPath, err)
+	}
+
+	return nil
+}
+
+func (p *dumpGenesisParams) initChain() error {
+	var err error
+
+	p.genesisConfig, err = chain.Import(p.genesisPath)
+
+	return err
+}
+
+func (p *dumpGenesisParams) generateConfig() *server.Config {
+	return &server.Config{
+		Chain:   p.genesisConfig,
+		DataDir: p.dataDir,
+		LeveldbOptions: &server.LeveldbOptions{
+			CacheSize:           kvdb.DefaultLevelDBCache,
+			Handles:             kvdb.DefaultLevelDBHandles,
+			BloomKeyBits:        kvdb.DefaultLevelDBBloomKeyBits,
+			CompactionTableSize: kvdb.DefaultLevelDBCompactionTableSize,
+			CompactionTotalSize: kvdb.DefaultLevelDBCompactionTotalSize,
+			NoSync:              kvdb.DefaultLevelDBNoSync,
+		},
+		LogLevel: hclog.Info,
+	}
+}
+
+func (p *dumpGenesisParams) dump() error {
+	forked, result, err := server.DumpGenesis(p.generateConfig(), p.block)
+	if err != nil {
+		return err
+	}
+
+	if err := helper.WriteGenesisConfigToDisk(forked, p.outputPath); err != nil {
+		return err
+	}
+
+	p.result = result
+
+	return nil
+}
+
+func (p *dumpGenesisParams) getResult() command.CommandResult {
+	return &DumpGenesisResult{
+		DumpGenesisResult: p.result,
+		Path:              p.outputPath,
+	}
+}
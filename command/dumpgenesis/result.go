@@ -0,0 +1,32 @@
+package dumpgenesis
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/server"
+)
+
+type DumpGenesisResult struct {
+	*server.DumpGenesisResult
+
+	Path string `json:"path"`
+}
+
+func (r *DumpGenesisResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DUMP GENESIS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Path),
+		fmt.Sprintf("Block|%d", r.Number),
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("State root|%s", r.StateRoot),
+		fmt.Sprintf("Accounts|%d", r.Accounts),
+		fmt.Sprintf("Storage slots|%d", r.Slots),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -109,6 +109,28 @@ func setFlags(cmd *cobra.Command) {
 		"the epoch size for the chain",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.minValidatorCount,
+		minValidatorCountFlag,
+		ibft.DefaultMinValidatorCount,
+		"the minimum number of validators that removals cannot go below, 0 disables the check",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.noEmptyBlocks,
+		noEmptyBlocksFlag,
+		false,
+		"the flag indicating that proposers wait for transactions instead of sealing empty blocks. "+
+			"It is a proposal-time rule, the synced blocks are not checked against it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.maxIdleInterval,
+		maxIdleIntervalFlag,
+		ibft.DefaultMaxIdleInterval,
+		"the maximum interval in seconds without a block when empty blocks are suppressed",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.blockGasLimit,
 		blockGasLimitFlag,
@@ -143,6 +165,21 @@ func setFlags(cmd *cobra.Command) {
 		"",
 		"the system vault contract owner address",
 	)
+
+	cmd.Flags().Uint64Var(
+		&params.priorityLaneGasPercent,
+		priorityLaneGasFlag,
+		0,
+		"the percentage of the block gas limit reserved to the transactions of the bridge signers, "+
+			"the validator set owner and the priority lane callers. 0 disables the lane",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.priorityLaneCallersRaw,
+		priorityLaneCallerFlag,
+		[]string{},
+		"an additional caller of the priority lane. This flag can be used multiple times",
+	)
 }
 
 // setLegacyFlags sets the legacy flags to preserve backwards compatibility
@@ -8,6 +8,7 @@ import (
 	"github.com/dogechain-lab/dogechain/command"
 	"github.com/dogechain-lab/dogechain/command/helper"
 	"github.com/dogechain-lab/dogechain/consensus/ibft"
+	"github.com/dogechain-lab/dogechain/contracts/governance"
 	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
 	bridgeHelper "github.com/dogechain-lab/dogechain/helper/bridge"
 	validatorsetHelper "github.com/dogechain-lab/dogechain/helper/validatorset"
@@ -24,12 +25,17 @@ const (
 	ibftValidatorFlag       = "ibft-validator"
 	ibftValidatorPrefixFlag = "ibft-validators-prefix-path"
 	epochSizeFlag           = "epoch-size"
+	minValidatorCountFlag   = "min-validator-count"
+	noEmptyBlocksFlag       = "no-empty-blocks"
+	maxIdleIntervalFlag     = "max-idle-interval"
 	blockGasLimitFlag       = "block-gas-limit"
 	posFlag                 = "pos"
 	validatorsetOwner       = "validatorset-owner"
 	bridgeOwner             = "bridge-owner"
 	bridgeSigner            = "bridge-signer"
 	vaultOwner              = "vault-owner"
+	priorityLaneGasFlag     = "priority-lane-gas-percent"
+	priorityLaneCallerFlag  = "priority-lane-caller"
 )
 
 // Legacy flags that need to be preserved for running clients
@@ -45,6 +51,7 @@ var (
 	errValidatorsNotSpecified = errors.New("validator information not specified")
 	errUnsupportedConsensus   = errors.New("specified consensusRaw not supported")
 	errInvalidEpochSize       = errors.New("epoch size must be greater than 1")
+	errInvalidPriorityLaneGas = errors.New("priority lane gas percent must not exceed 100")
 )
 
 type genesisParams struct {
@@ -63,12 +70,19 @@ type genesisParams struct {
 	blockGasLimit uint64
 	isPos         bool
 
+	minValidatorCount uint64
+	noEmptyBlocks     bool
+	maxIdleInterval   uint64
+
 	validatorsetOwner string
 	bridgeOwner       string
 	bridgeSignersRaw  []string
 	bridgeSigners     []types.Address
 	vaultOwner        string
 
+	priorityLaneGasPercent uint64
+	priorityLaneCallersRaw []string
+
 	extraData []byte
 	consensus server.ConsensusType
 
@@ -103,6 +117,10 @@ func (p *genesisParams) validateFlags() error {
 		return errInvalidEpochSize
 	}
 
+	if p.priorityLaneGasPercent > 100 {
+		return errInvalidPriorityLaneGas
+	}
+
 	return nil
 }
 
@@ -215,8 +233,11 @@ func (p *genesisParams) initConsensusEngineConfig() {
 func (p *genesisParams) initIBFTEngineMap(mechanism ibft.MechanismType) {
 	p.consensusEngineConfig = map[string]interface{}{
 		string(server.IBFTConsensus): map[string]interface{}{
-			"type":      mechanism,
-			"epochSize": p.epochSize,
+			"type":              mechanism,
+			"epochSize":         p.epochSize,
+			"minValidatorCount": p.minValidatorCount,
+			"noEmptyBlocks":     p.noEmptyBlocks,
+			"maxIdleInterval":   p.maxIdleInterval,
 		},
 	}
 }
@@ -254,6 +275,8 @@ func (p *genesisParams) initGenesisConfig() error {
 		Bootnodes: p.bootnodes,
 	}
 
+	chainConfig.Params.PriorityLane = p.priorityLane()
+
 	// Predeploy ValidatorSet smart contract if needed
 	if p.shouldPredeployValidatorSetSC() {
 		account, err := p.predeployValidatorSetSC()
@@ -281,6 +304,9 @@ func (p *genesisParams) initGenesisConfig() error {
 		chainConfig.Genesis.Alloc[systemcontracts.AddrVaultContract] = vaultAccount
 	}
 
+	// Predeploy governance contract, run natively by the nodes
+	chainConfig.Genesis.Alloc[systemcontracts.AddrGovernanceContract] = governance.PredeployGovernanceSC()
+
 	// Premine accounts
 	if err := fillPremineMap(chainConfig.Genesis.Alloc, p.premine); err != nil {
 		return err
@@ -332,6 +358,37 @@ func (p *genesisParams) initBridgeSigners() {
 	}
 }
 
+// priorityLane returns the priority lane of the system callers, nil if no gas is reserved.
+// The bridge signers are read from the state of each block, so only the validator set owner
+// and the additional callers are kept
+func (p *genesisParams) priorityLane() *chain.PriorityLane {
+	if p.priorityLaneGasPercent == 0 {
+		return nil
+	}
+
+	lane := &chain.PriorityLane{
+		ReservedGasPercent: p.priorityLaneGasPercent,
+	}
+
+	callers := make([]types.Address, 0, len(p.priorityLaneCallersRaw)+1)
+
+	if p.validatorsetOwner != "" {
+		callers = append(callers, types.StringToAddress(p.validatorsetOwner))
+	}
+
+	for _, caller := range p.priorityLaneCallersRaw {
+		callers = append(callers, types.StringToAddress(caller))
+	}
+
+	for _, caller := range callers {
+		if caller != types.ZeroAddress && !lane.IsCaller(caller) {
+			lane.Callers = append(lane.Callers, caller)
+		}
+	}
+
+	return lane
+}
+
 func (p *genesisParams) predeployBridgeSC() (*chain.GenesisAccount, error) {
 	return bridgeHelper.PredeployBridgeSC(
 		bridgeHelper.PredeployParams{
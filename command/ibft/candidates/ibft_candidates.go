@@ -34,7 +34,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	)
 }
 
-func getIBFTCandidates(grpcAddress string) (*ibftOp.CandidatesResp, error) {
+func getIBFTCandidates(grpcAddress string) (*ibftOp.ListCandidatesResp, error) {
 	client, err := helper.GetIBFTOperatorClientConnection(
 		grpcAddress,
 	)
@@ -42,5 +42,5 @@ func getIBFTCandidates(grpcAddress string) (*ibftOp.CandidatesResp, error) {
 		return nil, err
 	}
 
-	return client.Candidates(context.Background(), &empty.Empty{})
+	return client.ListCandidates(context.Background(), &empty.Empty{})
 }
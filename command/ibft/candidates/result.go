@@ -3,6 +3,7 @@ package candidates
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/dogechain-lab/dogechain/command/helper"
 	ibftHelper "github.com/dogechain-lab/dogechain/command/ibft/helper"
@@ -12,13 +13,15 @@ import (
 type IBFTCandidate struct {
 	Address string          `json:"address"`
 	Vote    ibftHelper.Vote `json:"vote"`
+	Expiry  uint64          `json:"expiry"`
+	Voted   bool            `json:"voted"`
 }
 
 type IBFTCandidatesResult struct {
 	Candidates []IBFTCandidate `json:"candidates"`
 }
 
-func newIBFTCandidatesResult(resp *ibftOp.CandidatesResp) *IBFTCandidatesResult {
+func newIBFTCandidatesResult(resp *ibftOp.ListCandidatesResp) *IBFTCandidatesResult {
 	res := &IBFTCandidatesResult{
 		Candidates: make([]IBFTCandidate, len(resp.Candidates)),
 	}
@@ -26,6 +29,8 @@ func newIBFTCandidatesResult(resp *ibftOp.CandidatesResp) *IBFTCandidatesResult
 	for i, c := range resp.Candidates {
 		res.Candidates[i].Address = c.Address
 		res.Candidates[i].Vote = ibftHelper.BoolToVote(c.Auth)
+		res.Candidates[i].Expiry = c.Expiry
+		res.Candidates[i].Voted = c.Voted
 	}
 
 	return res
@@ -51,10 +56,21 @@ func (r *IBFTCandidatesResult) GetOutput() string {
 func formatCandidates(candidates []IBFTCandidate) string {
 	generatedCandidates := make([]string, 0, len(candidates)+1)
 
-	generatedCandidates = append(generatedCandidates, "Address|Vote")
+	generatedCandidates = append(generatedCandidates, "Address|Vote|Expiry|Voted")
 	for _, c := range candidates {
-		generatedCandidates = append(generatedCandidates, fmt.Sprintf("%s|%s", c.Address, c.Vote))
+		generatedCandidates = append(
+			generatedCandidates,
+			fmt.Sprintf("%s|%s|%s|%t", c.Address, c.Vote, formatExpiry(c.Expiry), c.Voted),
+		)
 	}
 
 	return helper.FormatKV(generatedCandidates)
 }
+
+func formatExpiry(expiry uint64) string {
+	if expiry == 0 {
+		return "never"
+	}
+
+	return time.Unix(int64(expiry), 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,59 @@
+package earnings
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	ibftEarningsCmd := &cobra.Command{
+		Use:   "earnings",
+		Short: "Returns the blocks proposed and the fees earned by a validator, per epoch",
+		Run:   runCommand,
+	}
+
+	setFlags(ibftEarningsCmd)
+	helper.SetRequiredFlags(ibftEarningsCmd, params.getRequiredFlags())
+
+	return ibftEarningsCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.address,
+		addressFlag,
+		"",
+		"the address of the validator",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.fromEpoch,
+		fromFlag,
+		0,
+		"the first epoch to report, the last one if not set",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.toEpoch,
+		toFlag,
+		0,
+		"the last epoch to report, the latest epoch indexed if not set",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	params.hasFrom = cmd.Flags().Changed(fromFlag)
+	params.hasTo = cmd.Flags().Changed(toFlag)
+
+	if err := params.initEarnings(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,64 @@
+package earnings
+
+import (
+	"context"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	ibftOp "github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+)
+
+const (
+	addressFlag = "address"
+	fromFlag    = "from"
+	toFlag      = "to"
+)
+
+var (
+	params = &earningsParams{}
+)
+
+type earningsParams struct {
+	address   string
+	fromEpoch uint64
+	hasFrom   bool
+	toEpoch   uint64
+	hasTo     bool
+
+	earnings *ibftOp.ValidatorEarningsResp
+}
+
+func (p *earningsParams) getRequiredFlags() []string {
+	return []string{
+		addressFlag,
+	}
+}
+
+func (p *earningsParams) initEarnings(grpcAddress string) error {
+	ibftClient, err := helper.GetIBFTOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	earnings, err := ibftClient.GetValidatorEarnings(
+		context.Background(),
+		&ibftOp.ValidatorEarningsReq{
+			Address:   p.address,
+			HasFrom:   p.hasFrom,
+			FromEpoch: p.fromEpoch,
+			HasTo:     p.hasTo,
+			ToEpoch:   p.toEpoch,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.earnings = earnings
+
+	return nil
+}
+
+func (p *earningsParams) getResult() command.CommandResult {
+	return newIBFTEarningsResult(p.earnings)
+}
@@ -0,0 +1,71 @@
+package earnings
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+	ibftOp "github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+)
+
+type IBFTEpochEarnings struct {
+	Epoch  uint64 `json:"epoch"`
+	Blocks uint64 `json:"blocks"`
+	Fees   string `json:"fees"`
+}
+
+type IBFTEarningsResult struct {
+	Address   string              `json:"address"`
+	FromEpoch uint64              `json:"fromEpoch"`
+	ToEpoch   uint64              `json:"toEpoch"`
+	Blocks    uint64              `json:"blocks"`
+	Fees      string              `json:"fees"`
+	Epochs    []IBFTEpochEarnings `json:"epochs"`
+}
+
+func newIBFTEarningsResult(resp *ibftOp.ValidatorEarningsResp) *IBFTEarningsResult {
+	res := &IBFTEarningsResult{
+		Address:   resp.Address,
+		FromEpoch: resp.FromEpoch,
+		ToEpoch:   resp.ToEpoch,
+		Blocks:    resp.Blocks,
+		Fees:      resp.Fees,
+		Epochs:    make([]IBFTEpochEarnings, len(resp.Epochs)),
+	}
+
+	for i, e := range resp.Epochs {
+		res.Epochs[i].Epoch = e.Epoch
+		res.Epochs[i].Blocks = e.Blocks
+		res.Epochs[i].Fees = e.Fees
+	}
+
+	return res
+}
+
+func (r *IBFTEarningsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[VALIDATOR EARNINGS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Address|%s", r.Address),
+		fmt.Sprintf("Epochs|%d - %d", r.FromEpoch, r.ToEpoch),
+		fmt.Sprintf("Blocks|%d", r.Blocks),
+		fmt.Sprintf("Fees|%s", r.Fees),
+	}))
+	buffer.WriteString("\n")
+
+	if len(r.Epochs) > 0 {
+		generatedEpochs := make([]string, 0, len(r.Epochs)+1)
+
+		generatedEpochs = append(generatedEpochs, "Epoch|Blocks|Fees")
+		for _, e := range r.Epochs {
+			generatedEpochs = append(generatedEpochs, fmt.Sprintf("%d|%d|%s", e.Epoch, e.Blocks, e.Fees))
+		}
+
+		buffer.WriteString("\n[EPOCHS]\n")
+		buffer.WriteString(helper.FormatKV(generatedEpochs))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
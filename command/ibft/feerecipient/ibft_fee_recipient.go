@@ -0,0 +1,52 @@
+package feerecipient
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	ibftFeeRecipientCmd := &cobra.Command{
+		Use: "fee-recipient",
+		Short: "Returns the address credited with the fees of the blocks the validator proposes, " +
+			"or changes it if the address is specified",
+		Run: runCommand,
+	}
+
+	setFlags(ibftFeeRecipientCmd)
+
+	return ibftFeeRecipientCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.address,
+		addressFlag,
+		"",
+		"the new fee recipient, either the validator itself or its payout address "+
+			"registered in the governance contract",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.reset,
+		resetFlag,
+		false,
+		"resets the fee recipient to the validator itself",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(addressFlag, resetFlag)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initFeeRecipient(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
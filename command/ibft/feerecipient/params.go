@@ -0,0 +1,55 @@
+package feerecipient
+
+import (
+	"context"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	ibftOp "github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	addressFlag = "address"
+	resetFlag   = "reset"
+)
+
+var (
+	params = &feeRecipientParams{}
+)
+
+type feeRecipientParams struct {
+	address string
+	reset   bool
+
+	feeRecipient *ibftOp.FeeRecipient
+}
+
+func (p *feeRecipientParams) isUpdate() bool {
+	return p.address != "" || p.reset
+}
+
+func (p *feeRecipientParams) initFeeRecipient(grpcAddress string) error {
+	ibftClient, err := helper.GetIBFTOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	if p.isUpdate() {
+		p.feeRecipient, err = ibftClient.SetFeeRecipient(
+			context.Background(),
+			&ibftOp.FeeRecipient{Address: p.address},
+		)
+	} else {
+		p.feeRecipient, err = ibftClient.GetFeeRecipient(context.Background(), &empty.Empty{})
+	}
+
+	return err
+}
+
+func (p *feeRecipientParams) getResult() command.CommandResult {
+	return &IBFTFeeRecipientResult{
+		Address: p.feeRecipient.Address,
+		Updated: p.isUpdate(),
+	}
+}
@@ -0,0 +1,26 @@
+package feerecipient
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+)
+
+type IBFTFeeRecipientResult struct {
+	Address string `json:"address"`
+	Updated bool   `json:"updated"`
+}
+
+func (r *IBFTFeeRecipientResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[FEE RECIPIENT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Address|%s", r.Address),
+		fmt.Sprintf("Updated|%t", r.Updated),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
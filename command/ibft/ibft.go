@@ -3,10 +3,15 @@ package ibft
 import (
 	"github.com/dogechain-lab/dogechain/command/helper"
 	"github.com/dogechain-lab/dogechain/command/ibft/candidates"
+	"github.com/dogechain-lab/dogechain/command/ibft/earnings"
+	"github.com/dogechain-lab/dogechain/command/ibft/feerecipient"
 	"github.com/dogechain-lab/dogechain/command/ibft/propose"
+	"github.com/dogechain-lab/dogechain/command/ibft/simulate"
 	"github.com/dogechain-lab/dogechain/command/ibft/snapshot"
 	"github.com/dogechain-lab/dogechain/command/ibft/status"
 	_switch "github.com/dogechain-lab/dogechain/command/ibft/switch"
+	"github.com/dogechain-lab/dogechain/command/ibft/syncpeers"
+	"github.com/dogechain-lab/dogechain/command/ibft/uptime"
 	"github.com/spf13/cobra"
 )
 
@@ -35,5 +40,15 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		candidates.GetCommand(),
 		// ibft switch
 		_switch.GetCommand(),
+		// ibft simulate
+		simulate.GetCommand(),
+		// ibft uptime
+		uptime.GetCommand(),
+		// ibft fee-recipient
+		feerecipient.GetCommand(),
+		// ibft earnings
+		earnings.GetCommand(),
+		// ibft sync-peers
+		syncpeers.GetCommand(),
 	)
 }
@@ -24,11 +24,11 @@ func GetCommand() *cobra.Command {
 }
 
 func setFlags(cmd *cobra.Command) {
-	cmd.Flags().StringVar(
-		&params.addressRaw,
+	cmd.Flags().StringArrayVar(
+		&params.addressesRaw,
 		addressFlag,
-		"",
-		"the address of the account to be voted for",
+		[]string{},
+		"the addresses of the accounts to be voted for",
 	)
 
 	cmd.Flags().StringVar(
@@ -41,6 +41,13 @@ func setFlags(cmd *cobra.Command) {
 			dropVote,
 		),
 	)
+
+	cmd.Flags().DurationVar(
+		&params.expiry,
+		expiryFlag,
+		0,
+		"the duration after which the proposals are dropped if not voted in, 0 means never",
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -55,7 +62,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	if err := params.proposeCandidate(helper.GetGRPCAddress(cmd)); err != nil {
+	if err := params.proposeCandidates(helper.GetGRPCAddress(cmd)); err != nil {
 		outputter.SetError(err)
 
 		return
@@ -3,6 +3,7 @@ package propose
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/dogechain-lab/dogechain/command"
 	"github.com/dogechain-lab/dogechain/command/helper"
@@ -13,6 +14,7 @@ import (
 const (
 	voteFlag    = "vote"
 	addressFlag = "addr"
+	expiryFlag  = "expiry"
 )
 
 const (
@@ -23,6 +25,8 @@ const (
 var (
 	errInvalidVoteType      = errors.New("invalid vote type")
 	errInvalidAddressFormat = errors.New("invalid address format")
+	errInvalidAddresses     = errors.New("at least 1 address is required")
+	errInvalidExpiry        = errors.New("expiry should not be negative")
 )
 
 var (
@@ -30,10 +34,14 @@ var (
 )
 
 type proposeParams struct {
-	addressRaw string
+	addressesRaw []string
 
-	vote    string
-	address types.Address
+	vote      string
+	expiry    time.Duration
+	addresses []types.Address
+
+	proposed []string
+	errors   []string
 }
 
 func (p *proposeParams) getRequiredFlags() []string {
@@ -48,13 +56,24 @@ func (p *proposeParams) validateFlags() error {
 		return errInvalidVoteType
 	}
 
+	if len(p.addressesRaw) < 1 {
+		return errInvalidAddresses
+	}
+
+	if p.expiry < 0 {
+		return errInvalidExpiry
+	}
+
 	return nil
 }
 
 func (p *proposeParams) initRawParams() error {
-	p.address = types.Address{}
-	if err := p.address.UnmarshalText([]byte(p.addressRaw)); err != nil {
-		return errInvalidAddressFormat
+	p.addresses = make([]types.Address, len(p.addressesRaw))
+
+	for i, raw := range p.addressesRaw {
+		if err := p.addresses[i].UnmarshalText([]byte(raw)); err != nil {
+			return errInvalidAddressFormat
+		}
 	}
 
 	return nil
@@ -64,28 +83,51 @@ func isValidVoteType(vote string) bool {
 	return vote == authVote || vote == dropVote
 }
 
-func (p *proposeParams) proposeCandidate(grpcAddress string) error {
+func (p *proposeParams) proposeCandidates(grpcAddress string) error {
 	ibftClient, err := helper.GetIBFTOperatorClientConnection(grpcAddress)
 	if err != nil {
 		return err
 	}
 
-	if _, err := ibftClient.Propose(
-		context.Background(),
-		&ibftOp.Candidate{
-			Address: p.address.String(),
+	var expiry uint64
+	if p.expiry > 0 {
+		expiry = uint64(time.Now().Add(p.expiry).Unix())
+	}
+
+	req := &ibftOp.ProposeBatchReq{
+		Candidates: make([]*ibftOp.Candidate, len(p.addresses)),
+	}
+
+	for i, addr := range p.addresses {
+		req.Candidates[i] = &ibftOp.Candidate{
+			Address: addr.String(),
 			Auth:    p.vote == authVote,
-		},
-	); err != nil {
+			Expiry:  expiry,
+		}
+	}
+
+	resp, err := ibftClient.ProposeBatch(context.Background(), req)
+	if err != nil {
 		return err
 	}
 
+	for _, result := range resp.Results {
+		if result.Error != "" {
+			p.errors = append(p.errors, result.Address+": "+result.Error)
+
+			continue
+		}
+
+		p.proposed = append(p.proposed, result.Address)
+	}
+
 	return nil
 }
 
 func (p *proposeParams) getResult() command.CommandResult {
 	return &IBFTProposeResult{
-		Address: p.address.String(),
-		Vote:    p.vote,
+		Addresses: p.proposed,
+		Vote:      p.vote,
+		Errors:    p.errors,
 	}
 }
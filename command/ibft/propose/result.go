@@ -6,34 +6,43 @@ import (
 )
 
 type IBFTProposeResult struct {
-	Address string `json:"-"`
-	Vote    string `json:"-"`
+	Addresses []string `json:"addresses"`
+	Vote      string   `json:"vote"`
+	Errors    []string `json:"errors"`
 }
 
 func (r *IBFTProposeResult) GetOutput() string {
 	var buffer bytes.Buffer
 
 	buffer.WriteString("\n[IBFT PROPOSE]\n")
-	buffer.WriteString(r.Message())
-	buffer.WriteString("\n")
+
+	for _, addr := range r.Addresses {
+		buffer.WriteString(r.message(addr))
+		buffer.WriteString("\n")
+	}
+
+	if len(r.Errors) > 0 {
+		buffer.WriteString("\n[ERRORS]\n")
+
+		for _, err := range r.Errors {
+			buffer.WriteString(err)
+			buffer.WriteString("\n")
+		}
+	}
 
 	return buffer.String()
 }
 
-func (r *IBFTProposeResult) Message() string {
+func (r *IBFTProposeResult) message(address string) string {
 	if r.Vote == authVote {
 		return fmt.Sprintf(
 			"Successfully voted for the addition of address [%s] to the validator set",
-			r.Address,
+			address,
 		)
 	}
 
 	return fmt.Sprintf(
 		"Successfully voted for the removal of validator at address [%s] from the validator set",
-		r.Address,
+		address,
 	)
 }
-
-func (r *IBFTProposeResult) MarshalJSON() ([]byte, error) {
-	return []byte(fmt.Sprintf(`{"message": "%s"}`, r.Message())), nil
-}
@@ -0,0 +1,118 @@
+package simulate
+
+import (
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/consensus/ibft/simulator"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	ibftSimulateCmd := &cobra.Command{
+		Use:     "simulate",
+		Short:   "Runs in-memory IBFT validators over a lossy network, some of them possibly byzantine",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(ibftSimulateCmd)
+
+	return ibftSimulateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	defaultConfig := simulator.DefaultConfig()
+
+	cmd.Flags().IntVar(
+		&params.nodes,
+		nodesFlag,
+		defaultConfig.Nodes,
+		"the number of validators",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.byzantineRaw,
+		byzantineFlag,
+		[]string{},
+		fmt.Sprintf(
+			"the behavior of a validator, in the <index>=<behavior> format. Possible behaviors: [%s, %s, %s, %s, %s, %s]",
+			simulator.Silent,
+			simulator.Equivocating,
+			simulator.InvalidProposing,
+			simulator.MalformedSeals,
+			simulator.StaleViews,
+			simulator.Withholding,
+		),
+	)
+
+	cmd.Flags().Float64Var(
+		&params.dropRate,
+		dropRateFlag,
+		0,
+		"the probability of a message not to be delivered to a validator",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.minDelay,
+		minDelayFlag,
+		0,
+		"the minimum delivery delay of a message",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.maxDelay,
+		maxDelayFlag,
+		0,
+		"the maximum delivery delay of a message",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.roundTimeout,
+		roundTimeoutFlag,
+		defaultConfig.RoundTimeout,
+		"the timeout of the first round, doubled on every new round",
+	)
+
+	cmd.Flags().Int64Var(
+		&params.seed,
+		seedFlag,
+		0,
+		"the seed of the network randomness, 0 means random",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.blocks,
+		blocksFlag,
+		defaultBlocks,
+		"the height the honest validators should reach",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.timeout,
+		timeoutFlag,
+		defaultTimeout,
+		"the maximum duration of the simulation",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.runSimulation(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
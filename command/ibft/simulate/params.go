@@ -0,0 +1,165 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/consensus/ibft/simulator"
+)
+
+const (
+	nodesFlag        = "nodes"
+	byzantineFlag    = "byzantine"
+	dropRateFlag     = "drop-rate"
+	minDelayFlag     = "min-delay"
+	maxDelayFlag     = "max-delay"
+	roundTimeoutFlag = "round-timeout"
+	seedFlag         = "seed"
+	blocksFlag       = "blocks"
+	timeoutFlag      = "timeout"
+)
+
+const (
+	defaultBlocks  = 10
+	defaultTimeout = 2 * time.Minute
+)
+
+var (
+	errInvalidBlocks          = errors.New("blocks should be positive")
+	errInvalidTimeout         = errors.New("timeout should be positive")
+	errInvalidByzantineFormat = errors.New("invalid byzantine format, expected <index>=<behavior>")
+)
+
+var (
+	params = &simulateParams{}
+)
+
+type simulateParams struct {
+	nodes        int
+	byzantineRaw []string
+	dropRate     float64
+	minDelay     time.Duration
+	maxDelay     time.Duration
+	roundTimeout time.Duration
+	seed         int64
+	blocks       uint64
+	timeout      time.Duration
+
+	config *simulator.Config
+
+	heights   []uint64
+	delivered uint64
+	dropped   uint64
+	safe      bool
+	errors    []string
+}
+
+func (p *simulateParams) validateFlags() error {
+	if p.blocks == 0 {
+		return errInvalidBlocks
+	}
+
+	if p.timeout <= 0 {
+		return errInvalidTimeout
+	}
+
+	return nil
+}
+
+func (p *simulateParams) initRawParams() error {
+	config := simulator.DefaultConfig()
+	config.Nodes = p.nodes
+	config.DropRate = p.dropRate
+	config.MinDelay = p.minDelay
+	config.MaxDelay = p.maxDelay
+	config.RoundTimeout = p.roundTimeout
+
+	if p.seed != 0 {
+		config.Seed = p.seed
+	}
+
+	for _, raw := range p.byzantineRaw {
+		parts := strings.SplitN(raw, "=", 2)
+		if len(parts) != 2 {
+			return errInvalidByzantineFormat
+		}
+
+		index, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return errInvalidByzantineFormat
+		}
+
+		behavior, err := simulator.ParseBehavior(parts[1])
+		if err != nil {
+			return err
+		}
+
+		config.Byzantine[index] = behavior
+	}
+
+	p.config = config
+
+	return nil
+}
+
+func (p *simulateParams) runSimulation() error {
+	sim, err := simulator.New(p.config)
+	if err != nil {
+		return err
+	}
+
+	defer sim.Stop()
+
+	if err := sim.Start(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	if err := sim.WaitForHeight(ctx, p.blocks); err != nil {
+		p.errors = append(p.errors, err.Error())
+	}
+
+	if err := sim.CheckSafety(); err != nil {
+		p.errors = append(p.errors, err.Error())
+	} else {
+		p.safe = true
+	}
+
+	p.heights = sim.Heights()
+	p.delivered, p.dropped = sim.Stats()
+
+	return nil
+}
+
+func (p *simulateParams) getResult() command.CommandResult {
+	result := &IBFTSimulateResult{
+		Seed:      p.config.Seed,
+		Nodes:     make([]SimulatedNode, len(p.heights)),
+		Delivered: p.delivered,
+		Dropped:   p.dropped,
+		Safe:      p.safe,
+		Errors:    p.errors,
+	}
+
+	for i, height := range p.heights {
+		behavior, ok := p.config.Byzantine[i]
+		if !ok {
+			behavior = simulator.Honest
+		}
+
+		result.Nodes[i] = SimulatedNode{
+			Name:     fmt.Sprintf("node-%d", i),
+			Behavior: string(behavior),
+			Height:   height,
+		}
+	}
+
+	return result
+}
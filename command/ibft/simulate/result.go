@@ -0,0 +1,59 @@
+package simulate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+)
+
+type SimulatedNode struct {
+	Name     string `json:"name"`
+	Behavior string `json:"behavior"`
+	Height   uint64 `json:"height"`
+}
+
+type IBFTSimulateResult struct {
+	Seed      int64           `json:"seed"`
+	Nodes     []SimulatedNode `json:"nodes"`
+	Delivered uint64          `json:"delivered"`
+	Dropped   uint64          `json:"dropped"`
+	Safe      bool            `json:"safe"`
+	Errors    []string        `json:"errors"`
+}
+
+func (r *IBFTSimulateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[IBFT SIMULATION]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Seed|%d", r.Seed),
+		fmt.Sprintf("Messages delivered|%d", r.Delivered),
+		fmt.Sprintf("Messages dropped|%d", r.Dropped),
+		fmt.Sprintf("Safe|%t", r.Safe),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[NODES]\n")
+
+	nodes := make([]string, len(r.Nodes)+1)
+	nodes[0] = "Name|Behavior|Height"
+
+	for i, n := range r.Nodes {
+		nodes[i+1] = fmt.Sprintf("%s|%s|%d", n.Name, n.Behavior, n.Height)
+	}
+
+	buffer.WriteString(helper.FormatList(nodes))
+	buffer.WriteString("\n")
+
+	if len(r.Errors) > 0 {
+		buffer.WriteString("\n[ERRORS]\n")
+
+		for _, err := range r.Errors {
+			buffer.WriteString(err)
+			buffer.WriteString("\n")
+		}
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,38 @@
+package syncpeers
+
+import (
+	"context"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	ibftOp "github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	params = &syncPeersParams{}
+)
+
+type syncPeersParams struct {
+	scores *ibftOp.SyncPeerScoresResp
+}
+
+func (p *syncPeersParams) initSyncPeers(grpcAddress string) error {
+	ibftClient, err := helper.GetIBFTOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	scores, err := ibftClient.GetSyncPeerScores(context.Background(), &empty.Empty{})
+	if err != nil {
+		return err
+	}
+
+	p.scores = scores
+
+	return nil
+}
+
+func (p *syncPeersParams) getResult() command.CommandResult {
+	return newIBFTSyncPeersResult(p.scores)
+}
@@ -0,0 +1,98 @@
+package syncpeers
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+	ibftOp "github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+)
+
+type IBFTSyncPeerScore struct {
+	ID            string  `json:"id"`
+	Score         int64   `json:"score"`
+	Requests      uint64  `json:"requests"`
+	Blocks        uint64  `json:"blocks"`
+	NilHeaders    uint64  `json:"nil_headers"`
+	InvalidBlocks uint64  `json:"invalid_blocks"`
+	Timeouts      uint64  `json:"timeouts"`
+	Failures      uint64  `json:"failures"`
+	Throughput    float64 `json:"throughput"`
+	BannedUntil   int64   `json:"banned_until,omitempty"`
+}
+
+type IBFTSyncPeersResult struct {
+	Peers []IBFTSyncPeerScore `json:"peers"`
+}
+
+func newIBFTSyncPeersResult(resp *ibftOp.SyncPeerScoresResp) *IBFTSyncPeersResult {
+	res := &IBFTSyncPeersResult{
+		Peers: make([]IBFTSyncPeerScore, len(resp.Peers)),
+	}
+
+	for i, p := range resp.Peers {
+		res.Peers[i] = IBFTSyncPeerScore{
+			ID:            p.Id,
+			Score:         p.Score,
+			Requests:      p.Requests,
+			Blocks:        p.Blocks,
+			NilHeaders:    p.NilHeaders,
+			InvalidBlocks: p.InvalidBlocks,
+			Timeouts:      p.Timeouts,
+			Failures:      p.Failures,
+			Throughput:    p.Throughput,
+			BannedUntil:   p.BannedUntil,
+		}
+	}
+
+	return res
+}
+
+func (r *IBFTSyncPeersResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SYNC PEERS]\n")
+
+	if len(r.Peers) == 0 {
+		buffer.WriteString("No sync peers found\n")
+
+		return buffer.String()
+	}
+
+	generatedPeers := make([]string, 0, len(r.Peers)+1)
+
+	generatedPeers = append(
+		generatedPeers,
+		"ID|Score|Requests|Blocks|Nil Headers|Invalid Blocks|Timeouts|Failures|Blocks/s|Banned Until",
+	)
+
+	for _, p := range r.Peers {
+		bannedUntil := "-"
+		if p.BannedUntil != 0 {
+			bannedUntil = time.Unix(p.BannedUntil, 0).UTC().Format(time.RFC3339)
+		}
+
+		generatedPeers = append(
+			generatedPeers,
+			fmt.Sprintf(
+				"%s|%d|%d|%d|%d|%d|%d|%d|%.2f|%s",
+				p.ID,
+				p.Score,
+				p.Requests,
+				p.Blocks,
+				p.NilHeaders,
+				p.InvalidBlocks,
+				p.Timeouts,
+				p.Failures,
+				p.Throughput,
+				bannedUntil,
+			),
+		)
+	}
+
+	buffer.WriteString(helper.FormatKV(generatedPeers))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
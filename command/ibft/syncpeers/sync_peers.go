@@ -0,0 +1,30 @@
+package syncpeers
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	ibftSyncPeersCmd := &cobra.Command{
+		Use:   "sync-peers",
+		Short: "Returns the scores of the peers the node syncs from, and the ones banned",
+		Run:   runCommand,
+	}
+
+	return ibftSyncPeersCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initSyncPeers(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
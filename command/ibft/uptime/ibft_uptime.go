@@ -0,0 +1,41 @@
+package uptime
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	ibftUptimeCmd := &cobra.Command{
+		Use:   "uptime",
+		Short: "Returns the share of the blocks each validator committed a seal to, over the latest epochs",
+		Run:   runCommand,
+	}
+
+	setFlags(ibftUptimeCmd)
+
+	return ibftUptimeCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.epochs,
+		epochsFlag,
+		1,
+		"the number of the latest epochs to report, tracked since the node started",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initUptime(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
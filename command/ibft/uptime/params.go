@@ -0,0 +1,46 @@
+package uptime
+
+import (
+	"context"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	ibftOp "github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+)
+
+const (
+	epochsFlag = "epochs"
+)
+
+var (
+	params = &uptimeParams{}
+)
+
+type uptimeParams struct {
+	epochs uint64
+
+	uptime *ibftOp.ValidatorUptimeResp
+}
+
+func (p *uptimeParams) initUptime(grpcAddress string) error {
+	ibftClient, err := helper.GetIBFTOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	uptime, err := ibftClient.GetValidatorUptime(
+		context.Background(),
+		&ibftOp.ValidatorUptimeReq{Epochs: p.epochs},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.uptime = uptime
+
+	return nil
+}
+
+func (p *uptimeParams) getResult() command.CommandResult {
+	return newIBFTUptimeResult(p.uptime)
+}
@@ -0,0 +1,61 @@
+package uptime
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+	ibftOp "github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+)
+
+type IBFTValidatorUptime struct {
+	Address    string  `json:"address"`
+	Signed     uint64  `json:"signed"`
+	Expected   uint64  `json:"expected"`
+	Percentage float64 `json:"percentage"`
+}
+
+type IBFTUptimeResult struct {
+	From       uint64                `json:"from"`
+	To         uint64                `json:"to"`
+	Validators []IBFTValidatorUptime `json:"validators"`
+}
+
+func newIBFTUptimeResult(resp *ibftOp.ValidatorUptimeResp) *IBFTUptimeResult {
+	res := &IBFTUptimeResult{
+		From:       resp.From,
+		To:         resp.To,
+		Validators: make([]IBFTValidatorUptime, len(resp.Validators)),
+	}
+
+	for i, v := range resp.Validators {
+		res.Validators[i].Address = v.Address
+		res.Validators[i].Signed = v.Signed
+		res.Validators[i].Expected = v.Expected
+		res.Validators[i].Percentage = v.Percentage
+	}
+
+	return res
+}
+
+func (r *IBFTUptimeResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[VALIDATOR UPTIME]\n")
+	buffer.WriteString(fmt.Sprintf("Blocks: %d - %d\n\n", r.From, r.To))
+
+	generatedUptimes := make([]string, 0, len(r.Validators)+1)
+
+	generatedUptimes = append(generatedUptimes, "Address|Signed|Expected|Uptime")
+	for _, v := range r.Validators {
+		generatedUptimes = append(
+			generatedUptimes,
+			fmt.Sprintf("%s|%d|%d|%.2f%%", v.Address, v.Signed, v.Expected, v.Percentage),
+		)
+	}
+
+	buffer.WriteString(helper.FormatKV(generatedUptimes))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,54 @@
+package prunestate
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/server"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	blocksFlag  = "blocks"
+)
+
+var (
+	params = &pruneStateParams{}
+)
+
+type pruneStateParams struct {
+	dataDir string
+	blocks  uint64
+
+	result *itrie.PruneResult
+}
+
+func (p *pruneStateParams) generateConfig() *server.Config {
+	return &server.Config{
+		DataDir: p.dataDir,
+		LeveldbOptions: &server.LeveldbOptions{
+			CacheSize:           kvdb.DefaultLevelDBCache,
+			Handles:             kvdb.DefaultLevelDBHandles,
+			BloomKeyBits:        kvdb.DefaultLevelDBBloomKeyBits,
+			CompactionTableSize: kvdb.DefaultLevelDBCompactionTableSize,
+			CompactionTotalSize: kvdb.DefaultLevelDBCompactionTotalSize,
+			NoSync:              kvdb.DefaultLevelDBNoSync,
+		},
+		LogLevel: hclog.Info,
+	}
+}
+
+func (p *pruneStateParams) prune() error {
+	var err error
+
+	p.result, err = server.PruneState(p.generateConfig(), p.blocks)
+
+	return err
+}
+
+func (p *pruneStateParams) getResult() command.CommandResult {
+	return &PruneStateResult{
+		PruneResult: p.result,
+	}
+}
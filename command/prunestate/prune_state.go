@@ -0,0 +1,51 @@
+package prunestate
+
+import (
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/server"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	pruneStateCmd := &cobra.Command{
+		Use: "prune-state",
+		Short: "Deletes the state trie nodes unreachable from the states of the latest blocks, " +
+			"offline from the data directory. The node of the data directory should be stopped",
+		Run: runCommand,
+	}
+
+	setFlags(pruneStateCmd)
+
+	return pruneStateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"./dogechain-chain",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.blocks,
+		blocksFlag,
+		server.MinPruneStateBlocks,
+		fmt.Sprintf("the number of latest blocks whose state is retained, at least %d", server.MinPruneStateBlocks),
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.prune(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
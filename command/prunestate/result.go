@@ -0,0 +1,27 @@
+package prunestate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+)
+
+type PruneStateResult struct {
+	*itrie.PruneResult
+}
+
+func (r *PruneStateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[PRUNE STATE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Retained states|%d", r.Roots),
+		fmt.Sprintf("Retained nodes|%d", r.Retained),
+		fmt.Sprintf("Pruned nodes|%d", r.Pruned),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
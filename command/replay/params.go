@@ -0,0 +1,144 @@
+package replay
+
+import (
+	"errors"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/server"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag      = "data-dir"
+	chainFlag        = "chain"
+	txFlag           = "tx"
+	rawFlag          = "raw"
+	blockFlag        = "block"
+	noStructLogsFlag = "no-struct-logs"
+)
+
+var (
+	params = &replayParams{}
+)
+
+var (
+	errNoTransaction      = errors.New("either a transaction hash or a raw transaction is required")
+	errBothTransactions   = errors.New("a transaction hash and a raw transaction are both set")
+	errInvalidTxHash      = errors.New("invalid transaction hash")
+	errRawTxBlockRequired = errors.New("the block of the raw transaction is required")
+	errBlockWithoutRawTx  = errors.New("the block is only used with a raw transaction")
+)
+
+type replayParams struct {
+	dataDir      string
+	genesisPath  string
+	txHashRaw    string
+	rawTxRaw     string
+	blockRaw     string
+	noStructLogs bool
+
+	txHash types.Hash
+	rawTx  []byte
+	block  uint64
+
+	genesisConfig *chain.Chain
+
+	result *server.ReplayResult
+}
+
+func (p *replayParams) validateFlags() error {
+	if p.txHashRaw == "" && p.rawTxRaw == "" {
+		return errNoTransaction
+	}
+
+	if p.txHashRaw != "" && p.rawTxRaw != "" {
+		return errBothTransactions
+	}
+
+	if p.txHashRaw != "" {
+		if p.blockRaw != "" {
+			return errBlockWithoutRawTx
+		}
+
+		if err := p.txHash.UnmarshalText([]byte(p.txHashRaw)); err != nil {
+			return errInvalidTxHash
+		}
+
+		return nil
+	}
+
+	if p.blockRaw == "" {
+		return errRawTxBlockRequired
+	}
+
+	var err error
+
+	if p.rawTx, err = hex.DecodeHex(p.rawTxRaw); err != nil {
+		return err
+	}
+
+	if p.block, err = types.ParseUint64orHex(&p.blockRaw); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *replayParams) initChain() error {
+	var err error
+
+	p.genesisConfig, err = chain.Import(p.genesisPath)
+
+	return err
+}
+
+func (p *replayParams) generateConfig() *server.Config {
+	return &server.Config{
+		Chain:   p.genesisConfig,
+		DataDir: p.dataDir,
+		LeveldbOptions: &server.LeveldbOptions{
+			CacheSize:           kvdb.DefaultLevelDBCache,
+			Handles:             kvdb.DefaultLevelDBHandles,
+			BloomKeyBits:        kvdb.DefaultLevelDBBloomKeyBits,
+			CompactionTableSize: kvdb.DefaultLevelDBCompactionTableSize,
+			CompactionTotalSize: kvdb.DefaultLevelDBCompactionTotalSize,
+			NoSync:              kvdb.DefaultLevelDBNoSync,
+		},
+		LogLevel: hclog.Error,
+	}
+}
+
+func (p *replayParams) replay() error {
+	replayer, err := server.NewReplayer(p.generateConfig())
+	if err != nil {
+		return err
+	}
+
+	defer replayer.Close()
+
+	if p.rawTx != nil {
+		p.result, err = replayer.ReplayRawTx(p.rawTx, p.block)
+	} else {
+		p.result, err = replayer.ReplayTx(p.txHash)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if p.noStructLogs {
+		p.result.StructLogs = nil
+	}
+
+	return nil
+}
+
+func (p *replayParams) getResult() command.CommandResult {
+	return &ReplayResult{
+		ReplayResult: p.result,
+	}
+}
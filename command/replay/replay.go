@@ -0,0 +1,90 @@
+package replay
+
+import (
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	replayCmd := &cobra.Command{
+		Use: "replay-tx",
+		Short: "Replays a transaction against the state of its block, offline from the data directory. " +
+			"The node of the data directory should be stopped",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(replayCmd)
+
+	return replayCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"./dogechain-chain",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		"./genesis.json",
+		"the genesis file of the chain",
+	)
+
+	cmd.Flags().StringVar(
+		&params.txHashRaw,
+		txFlag,
+		"",
+		"the hash of the sealed transaction to replay",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawTxRaw,
+		rawFlag,
+		"",
+		"the hex encoded signed transaction to replay, instead of a sealed one",
+	)
+
+	cmd.Flags().StringVar(
+		&params.blockRaw,
+		blockFlag,
+		"",
+		fmt.Sprintf("the block number the raw transaction is replayed in, as its first transaction. "+
+			"Only used with the --%s flag", rawFlag),
+	)
+
+	cmd.Flags().BoolVar(
+		&params.noStructLogs,
+		noStructLogsFlag,
+		false,
+		"omit the struct logs of the replay",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initChain(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.replay(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
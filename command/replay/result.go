@@ -0,0 +1,51 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/server"
+)
+
+type ReplayResult struct {
+	*server.ReplayResult
+}
+
+func (r *ReplayResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REPLAY]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block|%d (%s)", r.BlockNumber, r.BlockHash),
+		fmt.Sprintf("Transaction|%s", r.TxHash),
+		fmt.Sprintf("Transaction index|%d", r.TxIndex),
+		fmt.Sprintf("Gas used|%d", r.Gas),
+		fmt.Sprintf("Failed|%t", r.Failed),
+		fmt.Sprintf("Return value|0x%s", r.ReturnValue),
+	}))
+	buffer.WriteString("\n")
+
+	if r.StructLogs != nil {
+		writeJSONSection(&buffer, "STRUCT LOGS", r.StructLogs)
+	}
+
+	writeJSONSection(&buffer, "CALL TRACE", r.CallTrace)
+	writeJSONSection(&buffer, "STATE DIFF", r.StateDiff)
+
+	return buffer.String()
+}
+
+func writeJSONSection(buffer *bytes.Buffer, title string, v interface{}) {
+	buffer.WriteString(fmt.Sprintf("\n[%s]\n", title))
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		buffer.WriteString(err.Error())
+	} else {
+		buffer.Write(data)
+	}
+
+	buffer.WriteString("\n")
+}
@@ -5,6 +5,12 @@ import (
 	"os"
 
 	"github.com/dogechain-lab/dogechain/command/backup"
+	"github.com/dogechain-lab/dogechain/command/chainexport"
+	"github.com/dogechain-lab/dogechain/command/chainimport"
+	"github.com/dogechain-lab/dogechain/command/db"
+	"github.com/dogechain-lab/dogechain/command/debug"
+	"github.com/dogechain-lab/dogechain/command/devnet"
+	"github.com/dogechain-lab/dogechain/command/dumpgenesis"
 	"github.com/dogechain-lab/dogechain/command/genesis"
 	"github.com/dogechain-lab/dogechain/command/helper"
 	"github.com/dogechain-lab/dogechain/command/ibft"
@@ -12,9 +18,12 @@ import (
 	"github.com/dogechain-lab/dogechain/command/loadbot"
 	"github.com/dogechain-lab/dogechain/command/monitor"
 	"github.com/dogechain-lab/dogechain/command/peers"
+	"github.com/dogechain-lab/dogechain/command/prunestate"
+	"github.com/dogechain-lab/dogechain/command/replay"
 	"github.com/dogechain-lab/dogechain/command/secrets"
 	"github.com/dogechain-lab/dogechain/command/server"
 	"github.com/dogechain-lab/dogechain/command/status"
+	"github.com/dogechain-lab/dogechain/command/txindex"
 	"github.com/dogechain-lab/dogechain/command/txpool"
 	"github.com/dogechain-lab/dogechain/command/version"
 	"github.com/spf13/cobra"
@@ -52,6 +61,15 @@ func (rc *RootCommand) registerSubCommands() {
 		genesis.GetCommand(),
 		server.GetCommand(),
 		license.GetCommand(),
+		replay.GetCommand(),
+		txindex.GetCommand(),
+		prunestate.GetCommand(),
+		devnet.GetCommand(),
+		debug.GetCommand(),
+		db.GetCommand(),
+		chainexport.GetCommand(),
+		chainimport.GetCommand(),
+		dumpgenesis.GetCommand(),
 	)
 }
 
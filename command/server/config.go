@@ -6,8 +6,11 @@ import (
 	"io/ioutil"
 	"strings"
 
+	"github.com/dogechain-lab/dogechain/consensus"
+	"github.com/dogechain-lab/dogechain/gasprice"
 	"github.com/dogechain-lab/dogechain/jsonrpc"
 	"github.com/dogechain-lab/dogechain/network"
+	"github.com/dogechain-lab/dogechain/server"
 	"github.com/dogechain-lab/dogechain/txpool"
 	"github.com/hashicorp/hcl"
 )
@@ -17,6 +20,9 @@ type Config struct {
 	GenesisPath              string     `json:"chain_config"`
 	SecretsConfigPath        string     `json:"secrets_config"`
 	DataDir                  string     `json:"data_dir"`
+	DBBackend                string     `json:"db_backend"`
+	AncientDir               string     `json:"ancient_dir"`
+	AncientBlocks            uint64     `json:"ancient_blocks"`
 	BlockGasTarget           string     `json:"block_gas_target"`
 	GRPCAddr                 string     `json:"grpc_addr"`
 	JSONRPCAddr              string     `json:"jsonrpc_addr"`
@@ -27,19 +33,47 @@ type Config struct {
 	LogLevel                 string     `json:"log_level"`
 	RestoreFile              string     `json:"restore_file"`
 	BlockTime                uint64     `json:"block_time_s"`
+	TxOrdering               string     `json:"tx_ordering"`
+	MinerFeeRecipient        string     `json:"miner_fee_recipient"`
+	CacheWarmBlocks          uint64     `json:"cache_warm_blocks"`
+	HealState                bool       `json:"heal_state"`
+	PruneStateBlocks         uint64     `json:"prune_state_blocks"`
+	FastSync                 bool       `json:"fast_sync"`
+	ParallelExecution        bool       `json:"parallel_execution"`
+	PrefetchState            bool       `json:"prefetch_state"`
+	StateSnapshot            bool       `json:"state_snapshot"`
+	Checkpoint               string     `json:"checkpoint"`
+	SyncRequestTimeout       uint64     `json:"sync_request_timeout_s"`
 	Headers                  *Headers   `json:"headers"`
 	LogFilePath              string     `json:"log_to"`
 	EnableGraphQL            bool       `json:"enable_graphql"`
 	GraphQLAddr              string     `json:"graphql_addr"`
 	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
 	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONRPCLogLimit          uint64     `json:"json_rpc_log_limit" yaml:"json_rpc_log_limit"`
 	JSONNamespace            string     `json:"json_namespace" yaml:"json_namespace"`
+	JSONRPCRateLimit         uint64     `json:"json_rpc_rate_limit" yaml:"json_rpc_rate_limit"`
+	JSONRPCDisabledMethods   string     `json:"json_rpc_disabled_methods" yaml:"json_rpc_disabled_methods"`
+	JSONRPCTLSCert           string     `json:"json_rpc_tls_cert" yaml:"json_rpc_tls_cert"`
+	JSONRPCTLSKey            string     `json:"json_rpc_tls_key" yaml:"json_rpc_tls_key"`
+	JSONRPCTLSClientCA       string     `json:"json_rpc_tls_client_ca" yaml:"json_rpc_tls_client_ca"`
+	JSONRPCEnableAdmin       bool       `json:"json_rpc_enable_admin" yaml:"json_rpc_enable_admin"`
+	JSONRPCAdminToken        string     `json:"json_rpc_admin_token" yaml:"json_rpc_admin_token"`
 	EnableWS                 bool       `json:"enable_ws"`
+	Exporter                 *Exporter  `json:"exporter"`
+	Audit                    *Audit     `json:"audit"`
+
+	JSONRPCVirtualHosts     []*VirtualHost    `json:"json_rpc_virtual_hosts" yaml:"json_rpc_virtual_hosts"`
+	JSONRPCMethodRateLimits map[string]uint64 `json:"json_rpc_method_rate_limits" yaml:"json_rpc_method_rate_limits"`
+
+	GasPriceOracle *GasPriceOracle `json:"gas_price_oracle"`
 }
 
 // Telemetry holds the config details for metric services.
 type Telemetry struct {
-	PrometheusAddr string `json:"prometheus_addr"`
+	PrometheusAddr  string `json:"prometheus_addr"`
+	StatusAddr      string `json:"status_addr"`
+	StatusAuthToken string `json:"status_auth_token"`
 }
 
 // Network defines the network configuration params
@@ -57,8 +91,51 @@ type Network struct {
 type TxPool struct {
 	PriceLimit            uint64 `json:"price_limit"`
 	MaxSlots              uint64 `json:"max_slots"`
+	MaxAccountEnqueued    uint64 `json:"max_account_enqueued"`
+	MaxAccountSlots       uint64 `json:"max_account_slots"`
 	PruneTickSeconds      uint64 `json:"prune_tick_seconds"`
 	PromoteOutdateSeconds uint64 `json:"promote_outdate_seconds"`
+	TxLifetimeSeconds     uint64 `json:"tx_lifetime_seconds"`
+	PriceBump             uint64 `json:"price_bump"`
+	Journal               string `json:"journal"`
+	RebroadcastBlocks     uint64 `json:"rebroadcast_blocks"`
+	MaxTxSize             uint64 `json:"max_tx_size"`
+	MaxAccountFutureNonce uint64 `json:"max_account_future_nonce"`
+	NoLocals              bool   `json:"no_locals"`
+	// the addresses the transactions from or to are rejected
+	DenyList []string `json:"deny_list,omitempty"`
+	// the only senders accepted, any sender is accepted if empty
+	AllowList []string `json:"allow_list,omitempty"`
+}
+
+// Exporter defines the block execution result exporter configuration params
+type Exporter struct {
+	Sink string `json:"sink"`
+	From uint64 `json:"from"`
+}
+
+// Audit defines the block import audit configuration params
+type Audit struct {
+	Rules string `json:"rules"`
+	Sink  string `json:"sink"`
+}
+
+// GasPriceOracle defines the gas price oracle configuration params
+type GasPriceOracle struct {
+	Blocks     uint64 `json:"blocks"`
+	Percentile uint64 `json:"percentile"`
+}
+
+// VirtualHost defines a logical endpoint of the jsonrpc server, served at its path (and host),
+// with its own namespaces, limits and CORS policy
+type VirtualHost struct {
+	Name                      string   `json:"name"`
+	Path                      string   `json:"path"`
+	Host                      string   `json:"host"`
+	JSONNamespace             string   `json:"json_namespace"`
+	AccessControlAllowOrigins []string `json:"access_control_allow_origins"`
+	BatchRequestLimit         uint64   `json:"batch_request_limit"`
+	RateLimit                 uint64   `json:"rate_limit"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -69,6 +146,12 @@ type Headers struct {
 // minimum block generation time in seconds
 const defaultBlockTime uint64 = 2
 
+// number of the latest blocks read into the caches on startup
+const defaultCacheWarmBlocks uint64 = 128
+
+// deadline, in seconds, of a request to a sync peer
+const defaultSyncRequestTimeout uint64 = 10
+
 // DefaultConfig returns the default server configuration
 func DefaultConfig() *Config {
 	defaultNetworkConfig := network.DefaultConfig()
@@ -76,6 +159,7 @@ func DefaultConfig() *Config {
 	return &Config{
 		GenesisPath:    "./genesis.json",
 		DataDir:        "./dogechain-chain",
+		AncientBlocks:  server.DefaultAncientBlocks,
 		BlockGasTarget: "0x0", // Special value signaling the parent gas limit should be applied
 		Network: &Network{
 			NoDiscover:       defaultNetworkConfig.NoDiscover,
@@ -88,12 +172,23 @@ func DefaultConfig() *Config {
 		TxPool: &TxPool{
 			PriceLimit:            0,
 			MaxSlots:              txpool.DefaultMaxSlots,
+			MaxAccountEnqueued:    txpool.DefaultMaxAccountEnqueued,
+			MaxAccountSlots:       txpool.DefaultMaxAccountSlots,
 			PruneTickSeconds:      txpool.DefaultPruneTickSeconds,
 			PromoteOutdateSeconds: txpool.DefaultPromoteOutdateSeconds,
+			TxLifetimeSeconds:     txpool.DefaultTxLifetimeSeconds,
+			PriceBump:             txpool.DefaultPriceBump,
+			Journal:               txpool.DefaultJournal,
+			RebroadcastBlocks:     txpool.DefaultRebroadcastBlocks,
+			MaxTxSize:             txpool.DefaultMaxTxSize,
+			MaxAccountFutureNonce: txpool.DefaultMaxAccountFutureNonce,
 		},
-		LogLevel:    "INFO",
-		RestoreFile: "",
-		BlockTime:   defaultBlockTime,
+		LogLevel:           "INFO",
+		RestoreFile:        "",
+		BlockTime:          defaultBlockTime,
+		TxOrdering:         consensus.DefaultOrderingPolicy,
+		CacheWarmBlocks:    defaultCacheWarmBlocks,
+		SyncRequestTimeout: defaultSyncRequestTimeout,
 		Headers: &Headers{
 			AccessControlAllowOrigins: []string{"*"},
 		},
@@ -101,8 +196,15 @@ func DefaultConfig() *Config {
 		EnableGraphQL:            false,
 		JSONRPCBatchRequestLimit: jsonrpc.DefaultJSONRPCBatchRequestLimit,
 		JSONRPCBlockRangeLimit:   jsonrpc.DefaultJSONRPCBlockRangeLimit,
+		JSONRPCLogLimit:          jsonrpc.DefaultJSONRPCLogLimit,
 		JSONNamespace:            string(jsonrpc.NamespaceAll),
 		EnableWS:                 false,
+		Exporter:                 &Exporter{},
+		Audit:                    &Audit{},
+		GasPriceOracle: &GasPriceOracle{
+			Blocks:     gasprice.DefaultBlocks,
+			Percentile: gasprice.DefaultPercentile,
+		},
 	}
 }
 
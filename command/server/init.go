@@ -10,7 +10,10 @@ import (
 
 	"github.com/dogechain-lab/dogechain/chain"
 	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/consensus"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
 	"github.com/dogechain-lab/dogechain/network"
+	"github.com/dogechain-lab/dogechain/protocol"
 	"github.com/dogechain-lab/dogechain/secrets"
 	"github.com/dogechain-lab/dogechain/server"
 	"github.com/dogechain-lab/dogechain/types"
@@ -52,6 +55,34 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initTxOrdering(); err != nil {
+		return err
+	}
+
+	if err := p.initMinerFeeRecipient(); err != nil {
+		return err
+	}
+
+	if err := p.initCheckpoint(); err != nil {
+		return err
+	}
+
+	if err := p.initTxPoolAddressPolicy(); err != nil {
+		return err
+	}
+
+	if err := p.initPruneStateBlocks(); err != nil {
+		return err
+	}
+
+	if err := p.initDBBackend(); err != nil {
+		return err
+	}
+
+	if err := p.initAncientBlocks(); err != nil {
+		return err
+	}
+
 	if p.isDevMode {
 		p.initDevMode()
 	}
@@ -70,6 +101,92 @@ func (p *serverParams) initBlockTime() error {
 	return nil
 }
 
+func (p *serverParams) initPruneStateBlocks() error {
+	if blocks := p.rawConfig.PruneStateBlocks; blocks > 0 && blocks < server.MinPruneStateBlocks {
+		return fmt.Errorf("%w: %d, at least %d", server.ErrPruneStateBlocks, blocks, server.MinPruneStateBlocks)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initDBBackend() error {
+	switch p.rawConfig.DBBackend {
+	case "", kvdb.BackendLevelDB, kvdb.BackendPebble, kvdb.BackendMemory:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", kvdb.ErrUnknownBackend, p.rawConfig.DBBackend)
+	}
+}
+
+func (p *serverParams) initAncientBlocks() error {
+	if blocks := p.rawConfig.AncientBlocks; blocks < server.MinAncientBlocks {
+		return fmt.Errorf("%w: %d, at least %d", server.ErrAncientBlocks, blocks, server.MinAncientBlocks)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initTxOrdering() error {
+	if _, err := consensus.NewOrderingPolicy(p.rawConfig.TxOrdering); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *serverParams) initTxPoolAddressPolicy() error {
+	var err error
+
+	if p.txPoolDenyList, err = parseAddresses(p.rawConfig.TxPool.DenyList); err != nil {
+		return fmt.Errorf("invalid txpool denylist, %w", err)
+	}
+
+	if p.txPoolAllowList, err = parseAddresses(p.rawConfig.TxPool.AllowList); err != nil {
+		return fmt.Errorf("invalid txpool allowlist, %w", err)
+	}
+
+	return nil
+}
+
+func parseAddresses(raw []string) ([]types.Address, error) {
+	addrs := make([]types.Address, len(raw))
+
+	for i, s := range raw {
+		if err := addrs[i].UnmarshalText([]byte(s)); err != nil {
+			return nil, fmt.Errorf("%s: %w", s, err)
+		}
+	}
+
+	return addrs, nil
+}
+
+func (p *serverParams) initMinerFeeRecipient() error {
+	if p.rawConfig.MinerFeeRecipient == "" {
+		return nil
+	}
+
+	if err := p.minerFeeRecipient.UnmarshalText([]byte(p.rawConfig.MinerFeeRecipient)); err != nil {
+		return fmt.Errorf("invalid miner fee recipient, %w", err)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initCheckpoint() error {
+	if p.rawConfig.Checkpoint == "" {
+		return nil
+	}
+
+	checkpoint, err := protocol.ParseCheckpoint(p.rawConfig.Checkpoint)
+	if err != nil {
+		return err
+	}
+
+	p.checkpoint = checkpoint
+
+	return nil
+}
+
 func (p *serverParams) initDataDirLocation() error {
 	if p.rawConfig.DataDir == "" {
 		return errDataDirectoryUndefined
@@ -206,6 +323,10 @@ func (p *serverParams) initAddresses() error {
 		return err
 	}
 
+	if err := p.initStatusAddress(); err != nil {
+		return err
+	}
+
 	if err := p.initLibp2pAddress(); err != nil {
 		return err
 	}
@@ -230,6 +351,28 @@ func (p *serverParams) initAddresses() error {
 	return p.initGRPCAddress()
 }
 
+func (p *serverParams) initStatusAddress() error {
+	if !p.isStatusAddressSet() {
+		return nil
+	}
+
+	var parseErr error
+
+	if p.statusAddress, parseErr = helper.ResolveAddr(
+		p.rawConfig.Telemetry.StatusAddr,
+		helper.LocalHostBinding,
+	); parseErr != nil {
+		return parseErr
+	}
+
+	// the page is meant for the operator of the node only
+	if !p.statusAddress.IP.IsLoopback() {
+		return errNonLocalStatusAddress
+	}
+
+	return nil
+}
+
 func (p *serverParams) initPrometheusAddress() error {
 	if !p.isPrometheusAddressSet() {
 		return nil
@@ -4,13 +4,17 @@ import (
 	"errors"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/jsonrpc"
 	"github.com/dogechain-lab/dogechain/network"
+	"github.com/dogechain-lab/dogechain/protocol"
 	"github.com/dogechain-lab/dogechain/secrets"
 	"github.com/dogechain-lab/dogechain/server"
+	"github.com/dogechain-lab/dogechain/types"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -18,6 +22,9 @@ const (
 	configFlag                   = "config"
 	genesisPathFlag              = "chain"
 	dataDirFlag                  = "data-dir"
+	dbBackendFlag                = "db-backend"
+	ancientDirFlag               = "ancient-dir"
+	ancientBlocksFlag            = "ancient-blocks"
 	leveldbCacheFlag             = "leveldb.cache-size"
 	leveldbHandlesFlag           = "leveldb.handles"
 	leveldbBloomKeyBitsFlag      = "leveldb.bloom-bits"
@@ -26,6 +33,8 @@ const (
 	leveldbNoSyncFlag            = "leveldb.nosync"
 	libp2pAddressFlag            = "libp2p"
 	prometheusAddressFlag        = "prometheus"
+	statusAddressFlag            = "status"
+	statusAuthTokenFlag          = "status-auth-token"
 	natFlag                      = "nat"
 	dnsFlag                      = "dns"
 	sealFlag                     = "seal"
@@ -34,12 +43,34 @@ const (
 	maxOutboundPeersFlag         = "max-outbound-peers"
 	priceLimitFlag               = "price-limit"
 	maxSlotsFlag                 = "max-slots"
+	maxAccountEnqueuedFlag       = "max-account-enqueued"
+	maxAccountSlotsFlag          = "max-account-slots"
 	pruneTickSecondsFlag         = "prune-tick-seconds"
 	promoteOutdateSecondsFlag    = "promote-outdate-seconds"
+	txLifetimeSecondsFlag        = "tx-lifetime-seconds"
+	priceBumpFlag                = "price-bump"
+	txPoolJournalFlag            = "txpool-journal"
+	rebroadcastBlocksFlag        = "rebroadcast-blocks"
+	maxTxSizeFlag                = "max-tx-size"
+	maxAccountFutureNonceFlag    = "txpool-max-account-future-nonce"
+	noLocalsFlag                 = "txpool-nolocals"
+	txPoolDenyListFlag           = "txpool-denylist"
+	txPoolAllowListFlag          = "txpool-allowlist"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
 	blockTimeFlag                = "block-time"
+	txOrderingFlag               = "tx-ordering"
+	minerFeeRecipientFlag        = "miner-fee-recipient"
+	cacheWarmBlocksFlag          = "cache-warm-blocks"
+	healStateFlag                = "heal-state"
+	pruneStateBlocksFlag         = "prune-state-blocks"
+	fastSyncFlag                 = "fast-sync"
+	parallelExecutionFlag        = "experimental-parallel-execution"
+	prefetchStateFlag            = "prefetch-state"
+	stateSnapshotFlag            = "state-snapshot"
+	checkpointFlag               = "checkpoint"
+	syncRequestTimeoutFlag       = "sync-request-timeout"
 	devIntervalFlag              = "dev-interval"
 	devFlag                      = "dev"
 	corsOriginFlag               = "access-control-allow-origins"
@@ -48,8 +79,22 @@ const (
 	enableGraphQLFlag            = "enable-graphql"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
+	jsonRPCLogLimitFlag          = "json-rpc-log-limit"
 	jsonrpcNamespaceFlag         = "json-rpc-namespace"
+	jsonRPCRateLimitFlag         = "json-rpc-rate-limit"
+	jsonRPCDisabledMethodsFlag   = "json-rpc-disabled-methods"
+	jsonRPCTLSCertFlag           = "json-rpc-tls-cert"
+	jsonRPCTLSKeyFlag            = "json-rpc-tls-key"
+	jsonRPCTLSClientCAFlag       = "json-rpc-tls-client-ca"
+	jsonRPCEnableAdminFlag       = "json-rpc-enable-admin"
+	jsonRPCAdminTokenFlag        = "json-rpc-admin-token"
 	enableWSFlag                 = "enable-ws"
+	exporterSinkFlag             = "exporter-sink"
+	exporterFromFlag             = "exporter-from"
+	auditRulesFlag               = "audit-rules"
+	auditSinkFlag                = "audit-sink"
+	gpoBlocksFlag                = "gpo-blocks"
+	gpoPercentileFlag            = "gpo-percentile"
 )
 
 const (
@@ -59,16 +104,20 @@ const (
 var (
 	params = &serverParams{
 		rawConfig: &Config{
-			Telemetry: &Telemetry{},
-			Network:   &Network{},
-			TxPool:    &TxPool{},
+			Telemetry:      &Telemetry{},
+			Network:        &Network{},
+			TxPool:         &TxPool{},
+			Exporter:       &Exporter{},
+			Audit:          &Audit{},
+			GasPriceOracle: &GasPriceOracle{},
 		},
 	}
 )
 
 var (
-	errInvalidPeerParams = errors.New("both max-peers and max-inbound/outbound flags are set")
-	errInvalidNATAddress = errors.New("could not parse NAT address (ip:port)")
+	errInvalidPeerParams     = errors.New("both max-peers and max-inbound/outbound flags are set")
+	errInvalidNATAddress     = errors.New("could not parse NAT address (ip:port)")
+	errNonLocalStatusAddress = errors.New("the status page could only bind to a loopback address")
 )
 
 type serverParams struct {
@@ -84,17 +133,22 @@ type serverParams struct {
 
 	libp2pAddress     *net.TCPAddr
 	prometheusAddress *net.TCPAddr
+	statusAddress     *net.TCPAddr
 	natAddress        *net.TCPAddr
 	dnsAddress        multiaddr.Multiaddr
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
 	graphqlAddress    *net.TCPAddr
 
-	blockGasTarget uint64
-	devInterval    uint64
-	isDevMode      bool
-	isDaemon       bool
-	validatorKey   string
+	blockGasTarget    uint64
+	minerFeeRecipient types.Address
+	checkpoint        *protocol.Checkpoint
+	txPoolDenyList    []types.Address
+	txPoolAllowList   []types.Address
+	devInterval       uint64
+	isDevMode         bool
+	isDaemon          bool
+	validatorKey      string
 
 	corsAllowedOrigins []string
 
@@ -134,6 +188,10 @@ func (p *serverParams) isPrometheusAddressSet() bool {
 	return p.rawConfig.Telemetry.PrometheusAddr != ""
 }
 
+func (p *serverParams) isStatusAddressSet() bool {
+	return p.rawConfig.Telemetry.StatusAddr != ""
+}
+
 func (p *serverParams) isNATAddressSet() bool {
 	return p.rawConfig.Network.NatAddr != ""
 }
@@ -166,6 +224,31 @@ func (p *serverParams) setRawGraphQLAddress(graphqlAddress string) {
 	p.rawConfig.GraphQLAddr = graphqlAddress
 }
 
+func (p *serverParams) getVirtualHosts() []*jsonrpc.VirtualHost {
+	vhosts := make([]*jsonrpc.VirtualHost, len(p.rawConfig.JSONRPCVirtualHosts))
+
+	for i, raw := range p.rawConfig.JSONRPCVirtualHosts {
+		vhost := &jsonrpc.VirtualHost{
+			Name:                     raw.Name,
+			Path:                     raw.Path,
+			Host:                     raw.Host,
+			AccessControlAllowOrigin: raw.AccessControlAllowOrigins,
+			BatchLengthLimit:         raw.BatchRequestLimit,
+			RateLimit:                raw.RateLimit,
+		}
+
+		for _, ns := range strings.Split(raw.JSONNamespace, ",") {
+			if ns = strings.TrimSpace(ns); ns != "" {
+				vhost.Namespaces = append(vhost.Namespaces, jsonrpc.Namespace(ns))
+			}
+		}
+
+		vhosts[i] = vhost
+	}
+
+	return vhosts
+}
+
 func (p *serverParams) generateConfig() *server.Config {
 	chainCfg := p.genesisConfig
 
@@ -176,6 +259,14 @@ func (p *serverParams) generateConfig() *server.Config {
 
 	ns := strings.Split(p.rawConfig.JSONNamespace, ",")
 
+	var disabledMethods []string
+
+	for _, method := range strings.Split(p.rawConfig.JSONRPCDisabledMethods, ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			disabledMethods = append(disabledMethods, method)
+		}
+	}
+
 	return &server.Config{
 		Chain: chainCfg,
 		JSONRPC: &server.JSONRPC{
@@ -183,8 +274,18 @@ func (p *serverParams) generateConfig() *server.Config {
 			AccessControlAllowOrigin: p.corsAllowedOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			LogLimit:                 p.rawConfig.JSONRPCLogLimit,
 			JSONNamespace:            ns,
 			EnableWS:                 p.rawConfig.EnableWS,
+			VirtualHosts:             p.getVirtualHosts(),
+			RateLimit:                p.rawConfig.JSONRPCRateLimit,
+			MethodRateLimits:         p.rawConfig.JSONRPCMethodRateLimits,
+			DisabledMethods:          disabledMethods,
+			TLSCertFile:              p.rawConfig.JSONRPCTLSCert,
+			TLSKeyFile:               p.rawConfig.JSONRPCTLSKey,
+			TLSClientCAFile:          p.rawConfig.JSONRPCTLSClientCA,
+			EnableAdmin:              p.rawConfig.JSONRPCEnableAdmin,
+			AdminToken:               p.rawConfig.JSONRPCAdminToken,
 		},
 		EnableGraphQL: p.rawConfig.EnableGraphQL,
 		GraphQL: &server.GraphQL{
@@ -195,7 +296,9 @@ func (p *serverParams) generateConfig() *server.Config {
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
 		Telemetry: &server.Telemetry{
-			PrometheusAddr: p.prometheusAddress,
+			PrometheusAddr:  p.prometheusAddress,
+			StatusAddr:      p.statusAddress,
+			StatusAuthToken: p.rawConfig.Telemetry.StatusAuthToken,
 		},
 		Network: &network.Config{
 			NoDiscover:       p.rawConfig.Network.NoDiscover,
@@ -212,10 +315,24 @@ func (p *serverParams) generateConfig() *server.Config {
 		Seal:                  p.rawConfig.ShouldSeal,
 		PriceLimit:            p.rawConfig.TxPool.PriceLimit,
 		MaxSlots:              p.rawConfig.TxPool.MaxSlots,
+		MaxAccountEnqueued:    p.rawConfig.TxPool.MaxAccountEnqueued,
+		MaxAccountSlots:       p.rawConfig.TxPool.MaxAccountSlots,
 		PruneTickSeconds:      p.rawConfig.TxPool.PruneTickSeconds,
 		PromoteOutdateSeconds: p.rawConfig.TxPool.PromoteOutdateSeconds,
+		TxLifetimeSeconds:     p.rawConfig.TxPool.TxLifetimeSeconds,
+		PriceBump:             p.rawConfig.TxPool.PriceBump,
+		TxPoolJournal:         p.rawConfig.TxPool.Journal,
+		RebroadcastBlocks:     p.rawConfig.TxPool.RebroadcastBlocks,
+		MaxTxSize:             p.rawConfig.TxPool.MaxTxSize,
+		MaxAccountFutureNonce: p.rawConfig.TxPool.MaxAccountFutureNonce,
+		NoLocals:              p.rawConfig.TxPool.NoLocals,
+		TxPoolDenyList:        p.txPoolDenyList,
+		TxPoolAllowList:       p.txPoolAllowList,
 		SecretsManager:        p.secretsConfig,
 		RestoreFile:           p.getRestoreFilePath(),
+		DBBackend:             p.rawConfig.DBBackend,
+		AncientDir:            p.rawConfig.AncientDir,
+		AncientBlocks:         p.rawConfig.AncientBlocks,
 		LeveldbOptions: &server.LeveldbOptions{
 			CacheSize:           p.leveldbCacheSize,
 			Handles:             p.leveldbHandles,
@@ -224,10 +341,33 @@ func (p *serverParams) generateConfig() *server.Config {
 			CompactionTotalSize: p.leveldbTotalTableSize,
 			NoSync:              p.leveldbNoSync,
 		},
-		BlockTime:    p.rawConfig.BlockTime,
-		LogLevel:     hclog.LevelFromString(p.rawConfig.LogLevel),
-		LogFilePath:  p.logFileLocation,
-		Daemon:       p.isDaemon,
-		ValidatorKey: p.validatorKey,
+		BlockTime:          p.rawConfig.BlockTime,
+		TxOrdering:         p.rawConfig.TxOrdering,
+		MinerFeeRecipient:  p.minerFeeRecipient,
+		CacheWarmBlocks:    p.rawConfig.CacheWarmBlocks,
+		HealState:          p.rawConfig.HealState,
+		PruneStateBlocks:   p.rawConfig.PruneStateBlocks,
+		FastSync:           p.rawConfig.FastSync,
+		ParallelExecution:  p.rawConfig.ParallelExecution,
+		PrefetchState:      p.rawConfig.PrefetchState,
+		StateSnapshot:      p.rawConfig.StateSnapshot,
+		Checkpoint:         p.checkpoint,
+		SyncRequestTimeout: time.Duration(p.rawConfig.SyncRequestTimeout) * time.Second,
+		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
+		LogFilePath:        p.logFileLocation,
+		Daemon:             p.isDaemon,
+		ValidatorKey:       p.validatorKey,
+		Exporter: &server.Exporter{
+			Sink: p.rawConfig.Exporter.Sink,
+			From: p.rawConfig.Exporter.From,
+		},
+		Audit: &server.Audit{
+			Rules: p.rawConfig.Audit.Rules,
+			Sink:  p.rawConfig.Audit.Sink,
+		},
+		GasPriceOracle: &server.GasPriceOracle{
+			Blocks:     p.rawConfig.GasPriceOracle.Blocks,
+			Percentile: p.rawConfig.GasPriceOracle.Percentile,
+		},
 	}
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/dogechain-lab/dogechain/command"
 	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/consensus"
 	"github.com/dogechain-lab/dogechain/crypto"
 	"github.com/dogechain-lab/dogechain/helper/daemon"
 	"github.com/dogechain-lab/dogechain/helper/kvdb"
@@ -81,6 +82,103 @@ func setFlags(cmd *cobra.Command) {
 			defaultConfig.BlockTime,
 			"minimum block time in seconds (at least 1s)",
 		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.TxOrdering,
+			txOrderingFlag,
+			defaultConfig.TxOrdering,
+			fmt.Sprintf(
+				"the policy ordering transactions in a new block (%s, %s or %s)",
+				consensus.PriceTimeOrdering,
+				consensus.FIFOOrdering,
+				consensus.RoundRobinOrdering,
+			),
+		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.MinerFeeRecipient,
+			minerFeeRecipientFlag,
+			"",
+			"the address credited with the fees of the blocks the validator proposes, "+
+				"either the validator itself (default) or its payout address registered in the governance contract",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.CacheWarmBlocks,
+			cacheWarmBlocksFlag,
+			defaultConfig.CacheWarmBlocks,
+			"the number of latest blocks read into the caches on startup, at most the bodies cache size, "+
+				"with the receipts of the most recent ones and the head state, "+
+				"JSON-RPC requests are rejected until it is done (0 to disable)",
+		)
+
+		cmd.Flags().BoolVar(
+			&params.rawConfig.HealState,
+			healStateFlag,
+			false,
+			"the flag indicating that the node retrieves from its peers "+
+				"the trie nodes and contract codes missing from its head state",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.PruneStateBlocks,
+			pruneStateBlocksFlag,
+			0,
+			fmt.Sprintf(
+				"the number of latest blocks whose state is retained, the older states being pruned "+
+					"in the background (0 to keep all the states, otherwise at least %d)",
+				server.MinPruneStateBlocks,
+			),
+		)
+
+		cmd.Flags().BoolVar(
+			&params.rawConfig.FastSync,
+			fastSyncFlag,
+			false,
+			"the flag indicating that a node without state downloads the state of a recent block "+
+				"from its peers, instead of executing all the blocks preceding it. "+
+				"On a PoS chain, the block is at most the next epoch end, whose state the validator set is read from",
+		)
+
+		cmd.Flags().BoolVar(
+			&params.rawConfig.ParallelExecution,
+			parallelExecutionFlag,
+			false,
+			"the experimental flag indicating that the transactions of a block are executed concurrently, "+
+				"the transactions conflicting with the previous ones being executed again in order",
+		)
+
+		cmd.Flags().BoolVar(
+			&params.rawConfig.PrefetchState,
+			prefetchStateFlag,
+			false,
+			"the flag indicating that the accounts and storage slots a block is expected to access, "+
+				"the ones accessed by the previous block, are read in the background while it is executed",
+		)
+
+		cmd.Flags().BoolVar(
+			&params.rawConfig.StateSnapshot,
+			stateSnapshotFlag,
+			false,
+			"the flag indicating that a flat snapshot of the accounts and storage slots is maintained "+
+				"alongside the state trie, generated in the background, to read the latest states from",
+		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.Checkpoint,
+			checkpointFlag,
+			"",
+			"the trusted block, as <number>:<hash>, the synced chain must contain. "+
+				"The seals of the blocks proven to be its ancestors, by the headers walked backward from it, are not verified",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.SyncRequestTimeout,
+			syncRequestTimeoutFlag,
+			defaultConfig.SyncRequestTimeout,
+			"the deadline in seconds of a request to a sync peer, "+
+				"the request being retried on another peer once it is reached",
+		)
 	}
 
 	// endpoint flags
@@ -100,6 +198,14 @@ func setFlags(cmd *cobra.Command) {
 				"that consider fromBlock/toBlock values (e.g. eth_getLogs)",
 		)
 
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.JSONRPCLogLimit,
+			jsonRPCLogLimitFlag,
+			defaultConfig.JSONRPCLogLimit,
+			"the max number of logs returned by eth_getLogs, the queries paged by a cursor "+
+				"returning the following logs on the next page (0 for unlimited)",
+		)
+
 		cmd.Flags().BoolVar(
 			&params.rawConfig.EnableWS,
 			enableWSFlag,
@@ -119,12 +225,94 @@ func setFlags(cmd *cobra.Command) {
 			jsonrpcNamespaceFlag,
 			defaultConfig.JSONNamespace,
 			"the jsonrpc endpoint namespaces should be enabled "+
-				"(eth, net, web3, txpool, debug. concatenate with commas or * for all)",
+				"(eth, net, web3, txpool, debug, dc, trace. concatenate with commas or * for all)",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.JSONRPCRateLimit,
+			jsonRPCRateLimitFlag,
+			defaultConfig.JSONRPCRateLimit,
+			"the number of json-rpc requests per second allowed to a client IP (0 for unlimited), "+
+				"the per method limits being set in the config file",
+		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.JSONRPCDisabledMethods,
+			jsonRPCDisabledMethodsFlag,
+			defaultConfig.JSONRPCDisabledMethods,
+			"the jsonrpc methods not served, though their namespace is enabled (concatenate with commas)",
+		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.JSONRPCTLSCert,
+			jsonRPCTLSCertFlag,
+			"",
+			"the PEM certificate file the json-rpc server serves https and wss with, plain http if not set",
+		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.JSONRPCTLSKey,
+			jsonRPCTLSKeyFlag,
+			"",
+			"the PEM private key file of the json-rpc tls certificate",
+		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.JSONRPCTLSClientCA,
+			jsonRPCTLSClientCAFlag,
+			"",
+			"the PEM certificate authority file the json-rpc clients must present a certificate signed by, "+
+				"no client authentication if not set",
+		)
+
+		cmd.Flags().BoolVar(
+			&params.rawConfig.JSONRPCEnableAdmin,
+			jsonRPCEnableAdminFlag,
+			false,
+			"the flag indicating that the admin namespace (peers, node info) is served at the /admin json-rpc path",
+		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.JSONRPCAdminToken,
+			jsonRPCAdminTokenFlag,
+			"",
+			"the bearer token the json-rpc admin clients must present, only loopback clients are served if not set",
 		)
 	}
 
-	// leveldb flags
+	// database flags
 	{
+		cmd.Flags().StringVar(
+			&params.rawConfig.DBBackend,
+			dbBackendFlag,
+			"",
+			fmt.Sprintf(
+				"the database backend of the storages, %s, %s or %s "+
+					"(default the one of the data directory, %s for a new one)",
+				kvdb.BackendLevelDB, kvdb.BackendPebble, kvdb.BackendMemory, kvdb.BackendLevelDB,
+			),
+		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.AncientDir,
+			ancientDirFlag,
+			"",
+			"the directory of the ancient store, holding the bodies and receipts of the blocks older "+
+				"than the latest ones, so that it may be kept on another volume than the data directory "+
+				"(default the one recorded in the data directory, none for a new one)",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.AncientBlocks,
+			ancientBlocksFlag,
+			defaultConfig.AncientBlocks,
+			fmt.Sprintf(
+				"the number of latest blocks kept in the data directory once there is an ancient store, "+
+					"at least %d",
+				server.MinAncientBlocks,
+			),
+		)
+
 		cmd.Flags().IntVar(
 			&params.leveldbCacheSize,
 			leveldbCacheFlag,
@@ -291,6 +479,76 @@ func setFlags(cmd *cobra.Command) {
 			"the address and port for the prometheus instrumentation service (address:port). "+
 				"If only port is defined (:port) it will bind to 0.0.0.0:port",
 		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.Telemetry.StatusAddr,
+			statusAddressFlag,
+			"",
+			"the loopback address and port for the consensus status page (address:port). "+
+				"If only port is defined (:port) it will bind to 127.0.0.1:port",
+		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.Telemetry.StatusAuthToken,
+			statusAuthTokenFlag,
+			"",
+			"the password required by the consensus status page (basic auth), no auth if not set",
+		)
+	}
+
+	// exporter flags
+	{
+		cmd.Flags().StringVar(
+			&params.rawConfig.Exporter.Sink,
+			exporterSinkFlag,
+			"",
+			"the sink the execution result of every block is published to (<scheme>://<target>), "+
+				"such as file:///path/to/artifacts or http://host:port/topic. Disabled if not set",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.Exporter.From,
+			exporterFromFlag,
+			0,
+			"the first block to export when there is no exporter checkpoint yet",
+		)
+	}
+
+	// gas price oracle flags
+	{
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.GasPriceOracle.Blocks,
+			gpoBlocksFlag,
+			defaultConfig.GasPriceOracle.Blocks,
+			"the number of the latest blocks whose transactions are sampled by the gas price oracle "+
+				"(eth_gasPrice, eth_maxPriorityFeePerGas)",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.GasPriceOracle.Percentile,
+			gpoPercentileFlag,
+			defaultConfig.GasPriceOracle.Percentile,
+			"the percentile of the sampled tips suggested by the gas price oracle",
+		)
+	}
+
+	// audit flags
+	{
+		cmd.Flags().StringVar(
+			&params.rawConfig.Audit.Rules,
+			auditRulesFlag,
+			"",
+			"the JSON file of the rules (address watchlists, value thresholds) evaluated "+
+				"on every imported block. Disabled if not set",
+		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.Audit.Sink,
+			auditSinkFlag,
+			"",
+			"the sink the audit rule matches are published to (<scheme>://<target>), "+
+				"the audit.log file of the data directory if not set",
+		)
 	}
 
 	// txpool flags
@@ -312,6 +570,80 @@ func setFlags(cmd *cobra.Command) {
 			"maximum slots in the pool",
 		)
 
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.TxPool.MaxAccountEnqueued,
+			maxAccountEnqueuedFlag,
+			txpool.DefaultMaxAccountEnqueued,
+			"maximum enqueued (nonce gapped) transactions of a single account in the pool",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.TxPool.MaxAccountSlots,
+			maxAccountSlotsFlag,
+			txpool.DefaultMaxAccountSlots,
+			"maximum slots the transactions of a single account may take in the pool",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.TxPool.MaxAccountFutureNonce,
+			maxAccountFutureNonceFlag,
+			txpool.DefaultMaxAccountFutureNonce,
+			"how far ahead of the account nonce the nonce of a transaction may be, "+
+				"further ones are rejected (0 accepts any nonce)",
+		)
+
+		cmd.Flags().BoolVar(
+			&params.rawConfig.TxPool.NoLocals,
+			noLocalsFlag,
+			false,
+			"disable the price limit and eviction exemptions of the local transactions, "+
+				"submitted through this node's JSON-RPC or operator",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.TxPool.MaxTxSize,
+			maxTxSizeFlag,
+			txpool.DefaultMaxTxSize,
+			"maximum encoded size in bytes of a transaction accepted by the pool",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.TxPool.PriceBump,
+			priceBumpFlag,
+			txpool.DefaultPriceBump,
+			"minimum gas price bump (percentage) to replace a pending transaction of the same nonce",
+		)
+
+		cmd.Flags().StringVar(
+			&params.rawConfig.TxPool.Journal,
+			txPoolJournalFlag,
+			txpool.DefaultJournal,
+			"the file the local transactions are journaled to, to survive node restarts, "+
+				"relative to the data directory (empty disables the journal)",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.rawConfig.TxPool.RebroadcastBlocks,
+			rebroadcastBlocksFlag,
+			txpool.DefaultRebroadcastBlocks,
+			"the blocks a local pending transaction stays unmined before it is gossiped again, "+
+				"doubling for each rebroadcast (0 disables the rebroadcast)",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.rawConfig.TxPool.DenyList,
+			txPoolDenyListFlag,
+			nil,
+			"the addresses the pool rejects the transactions from or to",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.rawConfig.TxPool.AllowList,
+			txPoolAllowListFlag,
+			nil,
+			"the only senders the pool accepts the transactions of (any sender if not set)",
+		)
+
 		// pruning outdated account flags
 		{
 			cmd.Flags().Uint64Var(
@@ -327,6 +659,13 @@ func setFlags(cmd *cobra.Command) {
 				txpool.DefaultPromoteOutdateSeconds,
 				"account in the pool not promoted for a long time would be pruned",
 			)
+
+			cmd.Flags().Uint64Var(
+				&params.rawConfig.TxPool.TxLifetimeSeconds,
+				txLifetimeSecondsFlag,
+				txpool.DefaultTxLifetimeSeconds,
+				"seconds a transaction may sit in the pool before it expires",
+			)
 		}
 	}
 
@@ -0,0 +1,52 @@
+package txindex
+
+import (
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/server"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag = "data-dir"
+)
+
+var (
+	params = &txIndexParams{}
+)
+
+type txIndexParams struct {
+	dataDir string
+
+	result *blockchain.TxIndexResult
+}
+
+func (p *txIndexParams) generateConfig() *server.Config {
+	return &server.Config{
+		DataDir: p.dataDir,
+		LeveldbOptions: &server.LeveldbOptions{
+			CacheSize:           kvdb.DefaultLevelDBCache,
+			Handles:             kvdb.DefaultLevelDBHandles,
+			BloomKeyBits:        kvdb.DefaultLevelDBBloomKeyBits,
+			CompactionTableSize: kvdb.DefaultLevelDBCompactionTableSize,
+			CompactionTotalSize: kvdb.DefaultLevelDBCompactionTotalSize,
+			NoSync:              kvdb.DefaultLevelDBNoSync,
+		},
+		LogLevel: hclog.Info,
+	}
+}
+
+func (p *txIndexParams) rebuild() error {
+	var err error
+
+	p.result, err = server.RebuildTxIndex(p.generateConfig())
+
+	return err
+}
+
+func (p *txIndexParams) getResult() command.CommandResult {
+	return &TxIndexResult{
+		TxIndexResult: p.result,
+	}
+}
@@ -0,0 +1,26 @@
+package txindex
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/command/helper"
+)
+
+type TxIndexResult struct {
+	*blockchain.TxIndexResult
+}
+
+func (r *TxIndexResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TRANSACTION INDEX]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Blocks|%d", r.Blocks),
+		fmt.Sprintf("Transactions|%d", r.Transactions),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
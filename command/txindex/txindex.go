@@ -0,0 +1,41 @@
+package txindex
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	txIndexCmd := &cobra.Command{
+		Use: "rebuild-txindex",
+		Short: "Rebuilds the index of the transactions by hash of the canonical chain, offline from the data directory. " +
+			"The node of the data directory should be stopped",
+		Run: runCommand,
+	}
+
+	setFlags(txIndexCmd)
+
+	return txIndexCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"./dogechain-chain",
+		"the data directory of the stopped node",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.rebuild(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,60 @@
+package account
+
+import (
+	"context"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	txpoolOp "github.com/dogechain-lab/dogechain/txpool/proto"
+)
+
+var (
+	params = &accountParams{}
+)
+
+type accountParams struct {
+	address string
+
+	txpoolClient txpoolOp.TxnPoolOperatorClient
+
+	resp *txpoolOp.AccountQueueResp
+}
+
+func (p *accountParams) initTxPoolClient(grpcAddress string) error {
+	txpoolClient, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.txpoolClient = txpoolClient
+
+	return nil
+}
+
+func (p *accountParams) getAccountQueue() error {
+	var err error
+
+	p.resp, err = p.txpoolClient.GetAccountQueue(context.Background(), &txpoolOp.AccountQueueReq{
+		Address: p.address,
+	})
+
+	return err
+}
+
+func (p *accountParams) getResult() command.CommandResult {
+	res := &TxPoolAccountResult{
+		Address:        p.resp.Address,
+		NextNonce:      p.resp.NextNonce,
+		PendingNonces:  p.resp.PendingNonces,
+		EnqueuedNonces: p.resp.EnqueuedNonces,
+	}
+
+	if p.resp.Gap != nil {
+		res.Gap = &NonceGap{
+			From: p.resp.Gap.From,
+			To:   p.resp.Gap.To,
+		}
+	}
+
+	return res
+}
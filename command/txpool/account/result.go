@@ -0,0 +1,57 @@
+package account
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+)
+
+type NonceGap struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+type TxPoolAccountResult struct {
+	Address        string    `json:"address"`
+	NextNonce      uint64    `json:"next_nonce"`
+	PendingNonces  []uint64  `json:"pending_nonces"`
+	EnqueuedNonces []uint64  `json:"enqueued_nonces"`
+	Gap            *NonceGap `json:"gap,omitempty"`
+}
+
+func (r *TxPoolAccountResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	gap := "none"
+	if r.Gap != nil {
+		gap = fmt.Sprintf("%d - %d, the enqueued transactions wait for these nonces", r.Gap.From, r.Gap.To)
+	}
+
+	buffer.WriteString("\n[TXPOOL ACCOUNT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Address|%s", r.Address),
+		fmt.Sprintf("Next nonce|%d", r.NextNonce),
+		fmt.Sprintf("Pending nonces|%s", formatNonces(r.PendingNonces)),
+		fmt.Sprintf("Enqueued nonces|%s", formatNonces(r.EnqueuedNonces)),
+		fmt.Sprintf("Missing nonces|%s", gap),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+func formatNonces(nonces []uint64) string {
+	if len(nonces) == 0 {
+		return "none"
+	}
+
+	formatted := make([]string, len(nonces))
+	for i, nonce := range nonces {
+		formatted[i] = strconv.FormatUint(nonce, 10)
+	}
+
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,40 @@
+package account
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	accountCmd := &cobra.Command{
+		Use: "account <address>",
+		Short: "Returns the nonces of the transactions of the account in the transaction pool, " +
+			"and the missing nonces blocking the promotion of its enqueued transactions",
+		Args: cobra.ExactArgs(1),
+		Run:  runCommand,
+	}
+
+	return accountCmd
+}
+
+func runCommand(cmd *cobra.Command, args []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	params.address = args[0]
+
+	if err := params.initTxPoolClient(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.getAccountQueue(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
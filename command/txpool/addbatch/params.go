@@ -0,0 +1,121 @@
+package addbatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/txpool"
+	txpoolOp "github.com/dogechain-lab/dogechain/txpool/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	params = &addBatchParams{}
+)
+
+var (
+	errNoTransactions = errors.New("no transactions in the file")
+)
+
+const (
+	fileFlag = "file"
+)
+
+type addBatchParams struct {
+	file string
+
+	txpoolClient txpoolOp.TxnPoolOperatorClient
+
+	raws    [][]byte
+	results []*txpoolOp.AddTxnResult
+}
+
+func (p *addBatchParams) getRequiredFlags() []string {
+	return []string{
+		fileFlag,
+	}
+}
+
+// readTransactions reads the RLP encoded transactions of the file
+func (p *addBatchParams) readTransactions() error {
+	data, err := os.ReadFile(p.file)
+	if err != nil {
+		return fmt.Errorf("unable to read transactions, %w", err)
+	}
+
+	if p.raws, err = txpool.SplitTransactions(data); err != nil {
+		return err
+	}
+
+	if len(p.raws) == 0 {
+		return errNoTransactions
+	}
+
+	return nil
+}
+
+func (p *addBatchParams) initTxPoolClient(grpcAddress string) error {
+	txpoolClient, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.txpoolClient = txpoolClient
+
+	return nil
+}
+
+// addTransactions submits the transactions in batches of the maximum size
+func (p *addBatchParams) addTransactions() error {
+	for start := 0; start < len(p.raws); start += txpool.MaxAddTxnsBatch {
+		end := start + txpool.MaxAddTxnsBatch
+		if end > len(p.raws) {
+			end = len(p.raws)
+		}
+
+		req := &txpoolOp.AddTxnsReq{
+			Txns: make([]*txpoolOp.AddTxnReq, 0, end-start),
+		}
+
+		for _, raw := range p.raws[start:end] {
+			req.Txns = append(req.Txns, &txpoolOp.AddTxnReq{
+				Raw: &anypb.Any{Value: raw},
+			})
+		}
+
+		resp, err := p.txpoolClient.AddTxns(context.Background(), req)
+		if err != nil {
+			return err
+		}
+
+		p.results = append(p.results, resp.Results...)
+	}
+
+	return nil
+}
+
+func (p *addBatchParams) getResult() command.CommandResult {
+	result := &TxPoolAddBatchResult{
+		NumSubmitted: len(p.raws),
+		Transactions: make([]TransactionResult, len(p.results)),
+	}
+
+	for i, r := range p.results {
+		if r.Error == "" {
+			result.NumAdded++
+		}
+
+		result.Transactions[i] = TransactionResult{
+			Hash:         r.TxHash,
+			ReplacedHash: r.ReplacedTxHash,
+			Error:        r.Error,
+			ErrorReason:  r.ErrorReason,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,59 @@
+package addbatch
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+)
+
+type TxPoolAddBatchResult struct {
+	NumSubmitted int                 `json:"num_submitted"`
+	NumAdded     int                 `json:"num_added"`
+	Transactions []TransactionResult `json:"transactions"`
+}
+
+type TransactionResult struct {
+	Hash         string `json:"hash,omitempty"`
+	ReplacedHash string `json:"replaced_hash,omitempty"`
+	Error        string `json:"error,omitempty"`
+	ErrorReason  string `json:"error_reason,omitempty"`
+}
+
+func (r *TxPoolAddBatchResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL ADD BATCH]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Transactions submitted|%d", r.NumSubmitted),
+		fmt.Sprintf("Transactions added|%d", r.NumAdded),
+	}))
+
+	added := make([]string, 0, r.NumAdded)
+	errors := make([]string, 0, len(r.Transactions)-r.NumAdded)
+
+	for i, tx := range r.Transactions {
+		switch {
+		case tx.Error != "":
+			errors = append(errors, fmt.Sprintf("[%d] %s", i, tx.Error))
+		case tx.ReplacedHash != "":
+			added = append(added, fmt.Sprintf("[%d] %s (replaced %s)", i, tx.Hash, tx.ReplacedHash))
+		default:
+			added = append(added, fmt.Sprintf("[%d] %s", i, tx.Hash))
+		}
+	}
+
+	if len(added) > 0 {
+		buffer.WriteString("\n\n[ADDED TRANSACTIONS]\n")
+		buffer.WriteString(helper.FormatList(added))
+	}
+
+	if len(errors) > 0 {
+		buffer.WriteString("\n\n[ERRORS]\n")
+		buffer.WriteString(helper.FormatList(errors))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,53 @@
+package addbatch
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	addBatchCmd := &cobra.Command{
+		Use:     "addbatch",
+		Short:   "Adds a batch of signed transactions to the transaction pool in one round trip",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(addBatchCmd)
+	helper.SetRequiredFlags(addBatchCmd, params.getRequiredFlags())
+
+	return addBatchCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.file,
+		fileFlag,
+		"",
+		"the file of the signed transactions, RLP encoded one after another",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.readTransactions()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initTxPoolClient(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.addTransactions(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,103 @@
+package dump
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	txpoolOp "github.com/dogechain-lab/dogechain/txpool/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	params = &dumpParams{}
+)
+
+const (
+	fileFlag = "file"
+)
+
+type dumpParams struct {
+	file string
+
+	txpoolClient txpoolOp.TxnPoolOperatorClient
+
+	numDumped int
+}
+
+func (p *dumpParams) getRequiredFlags() []string {
+	return []string{
+		fileFlag,
+	}
+}
+
+func (p *dumpParams) initTxPoolClient(grpcAddress string) error {
+	txpoolClient, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.txpoolClient = txpoolClient
+
+	return nil
+}
+
+// dumpPool streams the transactions of the pool to a temporary file,
+// renamed to the file once the dump is complete
+func (p *dumpParams) dumpPool() error {
+	stream, err := p.txpoolClient.DumpPool(context.Background(), &empty.Empty{})
+	if err != nil {
+		return err
+	}
+
+	tmpFile := p.file + ".tmp"
+
+	out, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err := p.writeChunks(stream, out); err != nil {
+		out.Close()
+		os.Remove(tmpFile)
+
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		os.Remove(tmpFile)
+
+		return err
+	}
+
+	return os.Rename(tmpFile, p.file)
+}
+
+func (p *dumpParams) writeChunks(stream txpoolOp.TxnPoolOperator_DumpPoolClient, out io.Writer) error {
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		for _, raw := range chunk.Txns {
+			if _, err := out.Write(raw); err != nil {
+				return err
+			}
+		}
+
+		p.numDumped += len(chunk.Txns)
+	}
+}
+
+func (p *dumpParams) getResult() command.CommandResult {
+	return &TxPoolDumpResult{
+		File:      p.file,
+		NumDumped: p.numDumped,
+	}
+}
@@ -0,0 +1,26 @@
+package dump
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+)
+
+type TxPoolDumpResult struct {
+	File      string `json:"file"`
+	NumDumped int    `json:"num_dumped"`
+}
+
+func (r *TxPoolDumpResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL DUMP]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.File),
+		fmt.Sprintf("Transactions dumped|%d", r.NumDumped),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
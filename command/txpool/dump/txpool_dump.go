@@ -0,0 +1,49 @@
+package dump
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dumpCmd := &cobra.Command{
+		Use: "dump",
+		Short: "Writes the pending and enqueued transactions of the transaction pool to a file, " +
+			"RLP encoded one after another, to be restored later",
+		Run: runCommand,
+	}
+
+	setFlags(dumpCmd)
+	helper.SetRequiredFlags(dumpCmd, params.getRequiredFlags())
+
+	return dumpCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.file,
+		fileFlag,
+		"",
+		"the file the transactions are written to",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initTxPoolClient(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.dumpPool(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
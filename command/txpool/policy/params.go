@@ -0,0 +1,74 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	txpoolOp "github.com/dogechain-lab/dogechain/txpool/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	params = &policyParams{}
+)
+
+const (
+	denyFlag    = "deny"
+	undenyFlag  = "undeny"
+	allowFlag   = "allow"
+	unallowFlag = "unallow"
+)
+
+type policyParams struct {
+	deny    []string
+	undeny  []string
+	allow   []string
+	unallow []string
+
+	txpoolClient txpoolOp.TxnPoolOperatorClient
+
+	resp *txpoolOp.AddressPolicyResp
+}
+
+func (p *policyParams) initTxPoolClient(grpcAddress string) error {
+	txpoolClient, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.txpoolClient = txpoolClient
+
+	return nil
+}
+
+func (p *policyParams) hasUpdates() bool {
+	return len(p.deny)+len(p.undeny)+len(p.allow)+len(p.unallow) > 0
+}
+
+// updatePolicy updates the policy with the addresses of the flags, if any, and fetches it
+func (p *policyParams) updatePolicy() error {
+	var err error
+
+	if !p.hasUpdates() {
+		p.resp, err = p.txpoolClient.GetAddressPolicy(context.Background(), &empty.Empty{})
+
+		return err
+	}
+
+	p.resp, err = p.txpoolClient.UpdateAddressPolicy(context.Background(), &txpoolOp.UpdateAddressPolicyReq{
+		Deny:    p.deny,
+		Undeny:  p.undeny,
+		Allow:   p.allow,
+		Unallow: p.unallow,
+	})
+
+	return err
+}
+
+func (p *policyParams) getResult() command.CommandResult {
+	return &TxPoolPolicyResult{
+		DenyList:  p.resp.DenyList,
+		AllowList: p.resp.AllowList,
+	}
+}
@@ -0,0 +1,34 @@
+package policy
+
+import (
+	"bytes"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+)
+
+type TxPoolPolicyResult struct {
+	DenyList  []string `json:"deny_list"`
+	AllowList []string `json:"allow_list"`
+}
+
+func (r *TxPoolPolicyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL DENYLIST]\n")
+	writeAddresses(&buffer, r.DenyList, "No denied addresses")
+
+	buffer.WriteString("\n[TXPOOL ALLOWLIST]\n")
+	writeAddresses(&buffer, r.AllowList, "No allowlist, any sender is allowed")
+
+	return buffer.String()
+}
+
+func writeAddresses(buffer *bytes.Buffer, addrs []string, empty string) {
+	if len(addrs) == 0 {
+		buffer.WriteString(empty)
+	} else {
+		buffer.WriteString(helper.FormatList(addrs))
+	}
+
+	buffer.WriteString("\n")
+}
@@ -0,0 +1,69 @@
+package policy
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	policyCmd := &cobra.Command{
+		Use: "policy",
+		Short: "Returns the addresses denied and allowed by the transaction pool, " +
+			"after adding and removing the ones of the flags. The updates are lost on restart",
+		Run: runCommand,
+	}
+
+	setFlags(policyCmd)
+
+	return policyCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(
+		&params.deny,
+		denyFlag,
+		nil,
+		"the address to add to the denylist, the pending transactions of the sender are evicted",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.undeny,
+		undenyFlag,
+		nil,
+		"the address to remove from the denylist",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.allow,
+		allowFlag,
+		nil,
+		"the address to add to the allowlist",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.unallow,
+		unallowFlag,
+		nil,
+		"the address to remove from the allowlist",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initTxPoolClient(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.updatePolicy(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,69 @@
+package pricelimit
+
+import (
+	"context"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	txpoolOp "github.com/dogechain-lab/dogechain/txpool/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	params = &priceLimitParams{}
+)
+
+const (
+	setFlag   = "set"
+	clearFlag = "clear"
+)
+
+type priceLimitParams struct {
+	priceLimit uint64
+	isSet      bool
+	clear      bool
+
+	txpoolClient txpoolOp.TxnPoolOperatorClient
+
+	resp *txpoolOp.PriceLimitResp
+}
+
+func (p *priceLimitParams) initTxPoolClient(grpcAddress string) error {
+	txpoolClient, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.txpoolClient = txpoolClient
+
+	return nil
+}
+
+// updatePriceLimit sets or clears the override if requested, and fetches the price limit
+func (p *priceLimitParams) updatePriceLimit() error {
+	var err error
+
+	if !p.isSet && !p.clear {
+		p.resp, err = p.txpoolClient.GetPriceLimit(context.Background(), &empty.Empty{})
+
+		return err
+	}
+
+	p.resp, err = p.txpoolClient.SetPriceLimit(context.Background(), &txpoolOp.SetPriceLimitReq{
+		PriceLimit: p.priceLimit,
+		Clear:      p.clear,
+	})
+
+	return err
+}
+
+func (p *priceLimitParams) getResult() command.CommandResult {
+	return &TxPoolPriceLimitResult{
+		PriceLimit:  p.resp.PriceLimit,
+		Configured:  p.resp.Configured,
+		Scheduled:   p.resp.Scheduled,
+		Governed:    p.resp.Governed,
+		Override:    p.resp.Override,
+		HasOverride: p.resp.HasOverride,
+	}
+}
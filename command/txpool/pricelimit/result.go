@@ -0,0 +1,38 @@
+package pricelimit
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+)
+
+type TxPoolPriceLimitResult struct {
+	PriceLimit  uint64 `json:"price_limit"`
+	Configured  uint64 `json:"configured"`
+	Scheduled   uint64 `json:"scheduled"`
+	Governed    uint64 `json:"governed"`
+	Override    uint64 `json:"override"`
+	HasOverride bool   `json:"has_override"`
+}
+
+func (r *TxPoolPriceLimitResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	override := "none"
+	if r.HasOverride {
+		override = fmt.Sprintf("%d", r.Override)
+	}
+
+	buffer.WriteString("\n[TXPOOL PRICE LIMIT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Price limit|%d", r.PriceLimit),
+		fmt.Sprintf("Configured|%d", r.Configured),
+		fmt.Sprintf("Scheduled|%d", r.Scheduled),
+		fmt.Sprintf("Governed|%d", r.Governed),
+		fmt.Sprintf("Override|%s", override),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
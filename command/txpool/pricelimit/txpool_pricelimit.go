@@ -0,0 +1,65 @@
+package pricelimit
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	priceLimitCmd := &cobra.Command{
+		Use: "price-limit",
+		Short: "Returns the price limit of the transaction pool, after overriding the configured " +
+			"and scheduled ones if requested. The override is lost on restart",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(priceLimitCmd)
+
+	return priceLimitCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.priceLimit,
+		setFlag,
+		0,
+		"the price limit overriding the configured and scheduled ones, "+
+			"never below the minimum gas price enacted by the governance",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.clear,
+		clearFlag,
+		false,
+		"clears the override, restoring the configured and scheduled price limits",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(setFlag, clearFlag)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.isSet = cmd.Flags().Changed(setFlag)
+
+	return nil
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initTxPoolClient(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.updatePriceLimit(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,85 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/txpool"
+	txpoolOp "github.com/dogechain-lab/dogechain/txpool/proto"
+)
+
+var (
+	params = &restoreParams{}
+)
+
+const (
+	fileFlag = "file"
+)
+
+type restoreParams struct {
+	file string
+
+	txpoolClient txpoolOp.TxnPoolOperatorClient
+
+	raws        [][]byte
+	numRestored uint64
+	numDropped  uint64
+}
+
+func (p *restoreParams) getRequiredFlags() []string {
+	return []string{
+		fileFlag,
+	}
+}
+
+// readTransactions reads the RLP encoded transactions of the dump
+func (p *restoreParams) readTransactions() error {
+	data, err := os.ReadFile(p.file)
+	if err != nil {
+		return fmt.Errorf("unable to read the dump, %w", err)
+	}
+
+	p.raws, err = txpool.SplitTransactions(data)
+
+	return err
+}
+
+func (p *restoreParams) initTxPoolClient(grpcAddress string) error {
+	txpoolClient, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.txpoolClient = txpoolClient
+
+	return nil
+}
+
+// restorePool submits the transactions in chunks, in the order of the dump
+func (p *restoreParams) restorePool() error {
+	for _, chunk := range txpool.ChunkTransactions(p.raws) {
+		resp, err := p.txpoolClient.RestorePool(context.Background(), &txpoolOp.PoolChunk{
+			Txns: chunk,
+		})
+		if err != nil {
+			return err
+		}
+
+		p.numRestored += resp.Restored
+		p.numDropped += resp.Dropped
+	}
+
+	return nil
+}
+
+func (p *restoreParams) getResult() command.CommandResult {
+	return &TxPoolRestoreResult{
+		File:        p.file,
+		NumRead:     len(p.raws),
+		NumRestored: p.numRestored,
+		NumDropped:  p.numDropped,
+	}
+}
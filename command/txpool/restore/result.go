@@ -0,0 +1,30 @@
+package restore
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/command/helper"
+)
+
+type TxPoolRestoreResult struct {
+	File        string `json:"file"`
+	NumRead     int    `json:"num_read"`
+	NumRestored uint64 `json:"num_restored"`
+	NumDropped  uint64 `json:"num_dropped"`
+}
+
+func (r *TxPoolRestoreResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL RESTORE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.File),
+		fmt.Sprintf("Transactions read|%d", r.NumRead),
+		fmt.Sprintf("Transactions restored|%d", r.NumRestored),
+		fmt.Sprintf("Transactions dropped|%d", r.NumDropped),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
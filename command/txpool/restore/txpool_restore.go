@@ -0,0 +1,54 @@
+package restore
+
+import (
+	"github.com/dogechain-lab/dogechain/command"
+	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	restoreCmd := &cobra.Command{
+		Use: "restore",
+		Short: "Adds the transactions of a transaction pool dump to the transaction pool as local transactions. " +
+			"The ones mined or replaced since dumped are dropped",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(restoreCmd)
+	helper.SetRequiredFlags(restoreCmd, params.getRequiredFlags())
+
+	return restoreCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.file,
+		fileFlag,
+		"",
+		"the file of the transaction pool dump",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.readTransactions()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initTxPoolClient(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.restorePool(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
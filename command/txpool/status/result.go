@@ -5,13 +5,46 @@ import (
 	"fmt"
 
 	"github.com/dogechain-lab/dogechain/command/helper"
+	txpoolOp "github.com/dogechain-lab/dogechain/txpool/proto"
 )
 
 type TxPoolStatusResult struct {
+	PendingTransactions  uint64          `json:"pendingTransactions"`
+	EnqueuedTransactions uint64          `json:"enqueuedTransactions"`
+	MaxSlots             uint64          `json:"maxSlots"`
+	CurrentSlots         uint64          `json:"currentSlots"`
+	MaxAccountEnqueued   uint64          `json:"maxAccountEnqueued"`
+	MaxAccountSlots      uint64          `json:"maxAccountSlots"`
+	Accounts             []AccountStatus `json:"accounts"`
+}
+
+type AccountStatus struct {
+	Address              string `json:"address"`
 	PendingTransactions  uint64 `json:"pendingTransactions"`
 	EnqueuedTransactions uint64 `json:"enqueuedTransactions"`
-	MaxSlots             uint64 `json:"maxSlots"`
-	CurrentSlots         uint64 `json:"currentSlots"`
+	Slots                uint64 `json:"slots"`
+}
+
+func newTxPoolStatusResult(resp *txpoolOp.TxnPoolStatusResp) *TxPoolStatusResult {
+	accounts := make([]AccountStatus, len(resp.Accounts))
+	for i, account := range resp.Accounts {
+		accounts[i] = AccountStatus{
+			Address:              account.Address,
+			PendingTransactions:  account.PendingLength,
+			EnqueuedTransactions: account.EnqueuedLength,
+			Slots:                account.Slots,
+		}
+	}
+
+	return &TxPoolStatusResult{
+		PendingTransactions:  resp.PendingLength,
+		EnqueuedTransactions: resp.EnqueuedLength,
+		MaxSlots:             resp.MaxSlots,
+		CurrentSlots:         resp.CurrentSlots,
+		MaxAccountEnqueued:   resp.MaxAccountEnqueued,
+		MaxAccountSlots:      resp.MaxAccountSlots,
+		Accounts:             accounts,
+	}
 }
 
 func (r *TxPoolStatusResult) GetOutput() string {
@@ -23,8 +56,30 @@ func (r *TxPoolStatusResult) GetOutput() string {
 		fmt.Sprintf("Enqueued transactions|%d", r.EnqueuedTransactions),
 		fmt.Sprintf("Max slots|%d", r.MaxSlots),
 		fmt.Sprintf("Current slots|%d", r.CurrentSlots),
+		fmt.Sprintf("Max account enqueued|%d", r.MaxAccountEnqueued),
+		fmt.Sprintf("Max account slots|%d", r.MaxAccountSlots),
 	}))
 	buffer.WriteString("\n")
 
+	if len(r.Accounts) > 0 {
+		buffer.WriteString("\n[ACCOUNTS]\n")
+
+		rows := make([]string, len(r.Accounts)+1)
+		rows[0] = "Address|Pending|Enqueued|Slots"
+
+		for i, account := range r.Accounts {
+			rows[i+1] = fmt.Sprintf(
+				"%s|%d|%d|%d",
+				account.Address,
+				account.PendingTransactions,
+				account.EnqueuedTransactions,
+				account.Slots,
+			)
+		}
+
+		buffer.WriteString(helper.FormatList(rows))
+		buffer.WriteString("\n")
+	}
+
 	return buffer.String()
 }
@@ -30,12 +30,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		return
 	}
 
-	outputter.SetCommandResult(&TxPoolStatusResult{
-		PendingTransactions:  statusResponse.PendingLength,
-		EnqueuedTransactions: statusResponse.EnqueuedLength,
-		MaxSlots:             statusResponse.MaxSlots,
-		CurrentSlots:         statusResponse.CurrentSlots,
-	})
+	outputter.SetCommandResult(newTxPoolStatusResult(statusResponse))
 }
 
 func getTxPoolStatus(grpcAddress string) (*txpoolOp.TxnPoolStatusResp, error) {
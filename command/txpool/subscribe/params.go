@@ -15,6 +15,7 @@ var (
 	prunedPromotedFlag = "pruned-promoted"
 	prunedEnqueuedFlag = "pruned-enqueued"
 	replacedFlag       = "replaced"
+	expiredFlag        = "expired"
 )
 
 type subscribeParams struct {
@@ -33,6 +34,7 @@ func (sp *subscribeParams) initEventMap() {
 		proto.EventType_PRUNED_PROMOTED: &falseRaw,
 		proto.EventType_PRUNED_ENQUEUED: &falseRaw,
 		proto.EventType_REPLACED:        &falseRaw,
+		proto.EventType_EXPIRED:         &falseRaw,
 	}
 }
 
@@ -68,5 +70,6 @@ func (sp *subscribeParams) setAllEvents() {
 		proto.EventType_PRUNED_PROMOTED,
 		proto.EventType_PRUNED_ENQUEUED,
 		proto.EventType_REPLACED,
+		proto.EventType_EXPIRED,
 	}
 }
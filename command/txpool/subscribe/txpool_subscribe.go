@@ -76,6 +76,12 @@ func setFlags(cmd *cobra.Command) {
 		false,
 		"should subscribe to replaced tx events in the TxPool",
 	)
+	cmd.Flags().BoolVar(
+		params.eventSubscriptionMap[txpoolProto.EventType_EXPIRED],
+		expiredFlag,
+		false,
+		"should subscribe to expired tx events in the TxPool",
+	)
 }
 
 func runCommand(cmd *cobra.Command, _ []string) {
@@ -162,6 +168,7 @@ func runSubscribeLoop(
 			outputter.SetCommandResult(&TxPoolEventResult{
 				EventType: streamEvent.Type,
 				TxHash:    streamEvent.TxHash,
+				Reason:    streamEvent.Reason,
 			})
 			flushOutput()
 		}
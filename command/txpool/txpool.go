@@ -2,6 +2,12 @@ package txpool
 
 import (
 	"github.com/dogechain-lab/dogechain/command/helper"
+	"github.com/dogechain-lab/dogechain/command/txpool/account"
+	"github.com/dogechain-lab/dogechain/command/txpool/addbatch"
+	"github.com/dogechain-lab/dogechain/command/txpool/dump"
+	"github.com/dogechain-lab/dogechain/command/txpool/policy"
+	"github.com/dogechain-lab/dogechain/command/txpool/pricelimit"
+	"github.com/dogechain-lab/dogechain/command/txpool/restore"
 	"github.com/dogechain-lab/dogechain/command/txpool/status"
 	"github.com/dogechain-lab/dogechain/command/txpool/subscribe"
 	"github.com/spf13/cobra"
@@ -26,5 +32,17 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		status.GetCommand(),
 		// txpool subscribe
 		subscribe.GetCommand(),
+		// txpool addbatch
+		addbatch.GetCommand(),
+		// txpool policy
+		policy.GetCommand(),
+		// txpool account
+		account.GetCommand(),
+		// txpool price-limit
+		pricelimit.GetCommand(),
+		// txpool dump
+		dump.GetCommand(),
+		// txpool restore
+		restore.GetCommand(),
 	)
 }
@@ -3,13 +3,18 @@ package consensus
 import (
 	"context"
 	"log"
+	"math/big"
+	"net/http"
+	"time"
 
 	"github.com/dogechain-lab/dogechain/blockchain"
 	"github.com/dogechain-lab/dogechain/chain"
 	"github.com/dogechain-lab/dogechain/helper/progress"
 	"github.com/dogechain-lab/dogechain/network"
+	"github.com/dogechain-lab/dogechain/protocol"
 	"github.com/dogechain-lab/dogechain/secrets"
 	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
 	"github.com/dogechain-lab/dogechain/txpool"
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/hashicorp/go-hclog"
@@ -44,6 +49,46 @@ type Consensus interface {
 	Close() error
 }
 
+// StatusProvider is implemented by the consensus mechanisms serving a live status page
+type StatusProvider interface {
+	// StatusHandler returns the handler rendering the status page
+	StatusHandler() http.Handler
+}
+
+// FinalityProvider is implemented by the consensus mechanisms finalizing
+// the blocks with the committed seals of the validators
+type FinalityProvider interface {
+	// CommittedSeals returns the number of committed seals finalizing the header
+	CommittedSeals(header *types.Header) (int, error)
+}
+
+// EarningsProvider is implemented by the consensus mechanisms indexing the income of the validators
+type EarningsProvider interface {
+	// ValidatorEarnings returns the income of the validator over the epochs of the range, inclusive.
+	// The latest epoch indexed is the default of the range bounds not set.
+	ValidatorEarnings(validator types.Address, fromEpoch, toEpoch *uint64) (*EarningsReport, error)
+}
+
+// EarningsReport is the income of a validator over a range of epochs
+type EarningsReport struct {
+	Validator types.Address
+	FromEpoch uint64
+	ToEpoch   uint64
+	// Blocks is the number of blocks the validator proposed
+	Blocks uint64
+	// Fees is the fees credited to the fee recipients of the blocks the validator proposed
+	Fees *big.Int
+	// Epochs is the income per epoch, of the epochs the validator proposed blocks in
+	Epochs []*EpochEarnings
+}
+
+// EpochEarnings is the income of a validator over an epoch
+type EpochEarnings struct {
+	Epoch  uint64
+	Blocks uint64
+	Fees   *big.Int
+}
+
 // Config is the configuration for the consensus
 type Config struct {
 	// Logger to be used by the backend
@@ -57,6 +102,23 @@ type Config struct {
 
 	// Path is the directory path for the consensus protocol tos tore information
 	Path string
+
+	// TxOrdering is the name of the policy ordering transactions in a new block
+	TxOrdering string
+
+	// FeeRecipient is the address credited with the fees of the proposed blocks,
+	// the validator itself if zero
+	FeeRecipient types.Address
+
+	// FastSync makes a node without state download the state of a recent block
+	// from its peers, instead of executing all the blocks preceding it
+	FastSync bool
+
+	// Checkpoint is the block the synced chain must contain, if any
+	Checkpoint *protocol.Checkpoint
+
+	// SyncRequestTimeout is the deadline of a request to a sync peer, the default one if zero
+	SyncRequestTimeout time.Duration
 }
 
 type ConsensusParams struct {
@@ -67,6 +129,7 @@ type ConsensusParams struct {
 	Network        *network.Server
 	Blockchain     *blockchain.Blockchain
 	Executor       *state.Executor
+	StateStorage   itrie.Storage
 	Grpc           *grpc.Server
 	Logger         hclog.Logger
 	Metrics        *Metrics
@@ -2,6 +2,7 @@ package dev
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -27,6 +28,10 @@ type Dev struct {
 
 	blockchain *blockchain.Blockchain
 	executor   *state.Executor
+
+	txOrdering consensus.OrderingPolicy
+
+	feeRecipient types.Address // Address credited with the fees of the sealed blocks
 }
 
 // Factory implements the base factory method
@@ -35,13 +40,20 @@ func Factory(
 ) (consensus.Consensus, error) {
 	logger := params.Logger.Named("dev")
 
+	txOrdering, err := consensus.NewOrderingPolicy(params.Config.TxOrdering)
+	if err != nil {
+		return nil, err
+	}
+
 	d := &Dev{
-		logger:     logger,
-		notifyCh:   make(chan struct{}),
-		closeCh:    make(chan struct{}),
-		blockchain: params.Blockchain,
-		executor:   params.Executor,
-		txpool:     params.Txpool,
+		logger:       logger,
+		notifyCh:     make(chan struct{}),
+		closeCh:      make(chan struct{}),
+		blockchain:   params.Blockchain,
+		executor:     params.Executor,
+		txpool:       params.Txpool,
+		txOrdering:   txOrdering,
+		feeRecipient: params.Config.FeeRecipient,
 	}
 
 	rawInterval, ok := params.Config.Config["interval"]
@@ -107,13 +119,29 @@ type transitionInterface interface {
 	Write(txn *types.Transaction) error
 }
 
-func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface) []*types.Transaction {
-	var includedTxs []*types.Transaction
+func (d *Dev) orderingPolicy() consensus.OrderingPolicy {
+	if d.txOrdering == nil {
+		d.txOrdering, _ = consensus.NewOrderingPolicy(consensus.DefaultOrderingPolicy)
+	}
+
+	return d.txOrdering
+}
+
+func (d *Dev) writeTransactions(
+	gasLimit uint64,
+	sizeLimit uint64,
+	baseFee uint64,
+	transition transitionInterface,
+) []*types.Transaction {
+	var (
+		includedTxs []*types.Transaction
+		bodySize    uint64 // encoded size of the included transactions
+	)
 
 	// get all pending transactions once and for all
 	pendingTxs := d.txpool.Pending()
-	// get highest price transaction queue
-	priceTxs := types.NewTransactionsByPriceAndNonce(pendingTxs)
+	// get transaction queue ordered by the configured policy
+	priceTxs := d.orderingPolicy().Order(pendingTxs, baseFee)
 
 	for {
 		tx := priceTxs.Peek()
@@ -123,6 +151,14 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 			break
 		}
 
+		if sizeLimit > 0 && bodySize+tx.Size() > sizeLimit {
+			// Ignore transaction when the block body has no room for it
+			d.logger.Debug("Size limit exceeded for current block", "from", tx.From, "size", tx.Size())
+			priceTxs.Pop()
+
+			continue
+		}
+
 		if tx.ExceedsBlockGasLimit(gasLimit) {
 			// The address is punished. For current loop, it would not include its transactions any more.
 			d.txpool.Drop(tx)
@@ -155,6 +191,10 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 					tx.Hash, "from", tx.From, "nonce", tx.Nonce)
 				d.txpool.DemoteAllPromoted(tx, nonceErr.CorrectNonce)
 				priceTxs.Pop()
+			} else if errors.Is(err, state.ErrFeeCapTooLow) {
+				// the fee cap is below the base fee, kept in the pool until the base fee falls
+				d.logger.Debug("Fee cap too low for current block", "hash", tx.Hash, "from", tx.From)
+				priceTxs.Pop()
 			} else {
 				// no matter what kind of failure, drop is reasonable for not executed it yet
 				d.logger.Debug("write not executed transaction failed",
@@ -171,6 +211,7 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 		priceTxs.Shift()
 
 		includedTxs = append(includedTxs, tx)
+		bodySize += tx.Size()
 	}
 
 	d.logger.Info("picked out txns from pool", "num", len(includedTxs))
@@ -188,6 +229,7 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 		Number:     num + 1,
 		GasLimit:   parent.GasLimit, // Inherit from parent for now, will need to adjust dynamically later.
 		Timestamp:  uint64(time.Now().Unix()),
+		Miner:      d.feeRecipient,
 	}
 
 	// calculate gas limit based on parent header
@@ -197,6 +239,7 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 	}
 
 	header.GasLimit = gasLimit
+	header.BaseFee = d.blockchain.Config().CalculateBaseFee(parent)
 
 	miner, err := d.GetBlockCreator(header)
 	if err != nil {
@@ -209,7 +252,12 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 		return err
 	}
 
-	txns := d.writeTransactions(gasLimit, transition)
+	txns := d.writeTransactions(
+		gasLimit,
+		d.blockchain.Config().BlockBodySizeLimitAt(header.Number),
+		header.BaseFee,
+		transition,
+	)
 
 	// upgrade system if needed
 	upgrader.UpgradeSystem(
@@ -0,0 +1,332 @@
+package ibft
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/consensus"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// maxEarningsEpochs is the maximum number of epochs of an earnings report
+const maxEarningsEpochs = 1024
+
+// earningsFile is the file of the consensus directory the earnings index is saved to
+const earningsFile = "earnings"
+
+var (
+	errNoEarnings           = errors.New("no block indexed yet")
+	errInvalidEarningsRange = errors.New("invalid epoch range")
+)
+
+// validatorEarnings is the income of a validator over an epoch
+type validatorEarnings struct {
+	Blocks uint64   `json:"blocks"`
+	Fees   *big.Int `json:"fees"`
+}
+
+// earningsData is the persisted earnings index
+type earningsData struct {
+	Last   uint64                                          `json:"last"`
+	Epochs map[uint64]map[types.Address]*validatorEarnings `json:"epochs"`
+}
+
+// earningsIndex indexes the blocks proposed and the fees earned by the validators, per epoch.
+// The fees are the tips credited to the coinbase, the fee recipient of the block if set.
+// The index starts from the epoch of the head the first time the node runs with it
+type earningsIndex struct {
+	sync.RWMutex
+
+	data earningsData
+}
+
+func newEarningsIndex() *earningsIndex {
+	return &earningsIndex{
+		data: earningsData{
+			Epochs: make(map[uint64]map[types.Address]*validatorEarnings),
+		},
+	}
+}
+
+// last returns the last block indexed
+func (e *earningsIndex) last() uint64 {
+	e.RLock()
+	defer e.RUnlock()
+
+	return e.data.Last
+}
+
+// record indexes the block proposed by the validator, crediting the fees
+func (e *earningsIndex) record(number, epoch uint64, validator types.Address, fees *big.Int) {
+	e.Lock()
+	defer e.Unlock()
+
+	// a block is never indexed twice
+	if number <= e.data.Last {
+		return
+	}
+
+	e.data.Last = number
+
+	validators, ok := e.data.Epochs[epoch]
+	if !ok {
+		validators = make(map[types.Address]*validatorEarnings)
+		e.data.Epochs[epoch] = validators
+	}
+
+	earnings, ok := validators[validator]
+	if !ok {
+		earnings = &validatorEarnings{Fees: new(big.Int)}
+		validators[validator] = earnings
+	}
+
+	earnings.Blocks++
+	earnings.Fees.Add(earnings.Fees, fees)
+}
+
+// rewind drops the epochs from the epoch, the last block indexed being the one before it
+func (e *earningsIndex) rewind(epoch, last uint64) {
+	e.Lock()
+	defer e.Unlock()
+
+	for ep := range e.data.Epochs {
+		if ep >= epoch {
+			delete(e.data.Epochs, ep)
+		}
+	}
+
+	e.data.Last = last
+}
+
+// report returns the income of the validator over the epochs, the latest epoch indexed by default
+func (e *earningsIndex) report(
+	validator types.Address,
+	lastEpoch uint64,
+	fromEpoch, toEpoch *uint64,
+) (*consensus.EarningsReport, error) {
+	e.RLock()
+	defer e.RUnlock()
+
+	if len(e.data.Epochs) == 0 {
+		return nil, errNoEarnings
+	}
+
+	report := &consensus.EarningsReport{
+		Validator: validator,
+		FromEpoch: lastEpoch,
+		ToEpoch:   lastEpoch,
+		Fees:      new(big.Int),
+		Epochs:    []*consensus.EpochEarnings{},
+	}
+
+	if toEpoch != nil {
+		report.ToEpoch = *toEpoch
+		report.FromEpoch = *toEpoch
+	}
+
+	if fromEpoch != nil {
+		report.FromEpoch = *fromEpoch
+	}
+
+	if report.FromEpoch > report.ToEpoch {
+		return nil, fmt.Errorf("%w: from %d is after to %d", errInvalidEarningsRange, report.FromEpoch, report.ToEpoch)
+	}
+
+	if report.ToEpoch-report.FromEpoch >= maxEarningsEpochs {
+		return nil, fmt.Errorf("%w: more than %d epochs", errInvalidEarningsRange, maxEarningsEpochs)
+	}
+
+	for epoch, validators := range e.data.Epochs {
+		if epoch < report.FromEpoch || epoch > report.ToEpoch {
+			continue
+		}
+
+		earnings, ok := validators[validator]
+		if !ok {
+			continue
+		}
+
+		report.Blocks += earnings.Blocks
+		report.Fees.Add(report.Fees, earnings.Fees)
+		report.Epochs = append(report.Epochs, &consensus.EpochEarnings{
+			Epoch:  epoch,
+			Blocks: earnings.Blocks,
+			Fees:   new(big.Int).Set(earnings.Fees),
+		})
+	}
+
+	sort.Slice(report.Epochs, func(i, j int) bool {
+		return report.Epochs[i].Epoch < report.Epochs[j].Epoch
+	})
+
+	return report, nil
+}
+
+// loadFromPath reads the index saved to the directory, if any
+func (e *earningsIndex) loadFromPath(path string) error {
+	data := earningsData{}
+	if err := readDataStore(filepath.Join(path, earningsFile), &data); err != nil {
+		return err
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	e.data.Last = data.Last
+
+	if data.Epochs != nil {
+		e.data.Epochs = data.Epochs
+	}
+
+	return nil
+}
+
+// saveToPath writes the index to the directory
+func (e *earningsIndex) saveToPath(path string) error {
+	e.RLock()
+	defer e.RUnlock()
+
+	return writeDataStore(filepath.Join(path, earningsFile), &e.data)
+}
+
+// setupEarnings loads the earnings index, which starts from the beginning
+// of the epoch of the head if new
+func (i *Ibft) setupEarnings() error {
+	i.earnings = newEarningsIndex()
+	i.earningsCh = make(chan struct{}, 1)
+
+	if i.config.Path != "" {
+		if err := i.earnings.loadFromPath(i.config.Path); err != nil {
+			i.logger.Error("could not read the earnings index, rebuilding it", "err", err)
+		}
+	}
+
+	// the chain was rewound, the epoch of the head is indexed again
+	if head := i.blockchain.Header().Number; i.earnings.last() > head {
+		i.earnings.rewind(i.GetEpoch(head), i.previousEpochEnd(head))
+	}
+
+	if i.earnings.last() == 0 {
+		i.earnings.data.Last = i.previousEpochEnd(i.blockchain.Header().Number)
+	}
+
+	return nil
+}
+
+// previousEpochEnd returns the last block of the epoch before the one of the block,
+// the genesis for the blocks of the first epoch and the genesis itself
+func (i *Ibft) previousEpochEnd(number uint64) uint64 {
+	if number == 0 {
+		return 0
+	}
+
+	return (i.GetEpoch(number) - 1) * i.epochSize
+}
+
+// runEarningsIndexer indexes the blocks inserted, until the consensus is closed
+func (i *Ibft) runEarningsIndexer() {
+	for {
+		i.indexEarnings()
+
+		select {
+		case <-i.closeCh:
+			return
+		case <-i.earningsCh:
+		}
+	}
+}
+
+// notifyEarnings signals the indexer there are blocks to index
+func (i *Ibft) notifyEarnings() {
+	select {
+	case i.earningsCh <- struct{}{}:
+	default:
+	}
+}
+
+// indexEarnings indexes the blocks from the last indexed to the head
+func (i *Ibft) indexEarnings() {
+	head := i.blockchain.Header().Number
+
+	for number := i.earnings.last() + 1; number <= head; number++ {
+		select {
+		case <-i.closeCh:
+			return
+		default:
+		}
+
+		header, ok := i.blockchain.GetHeaderByNumber(number)
+		if !ok {
+			i.logger.Debug("unable to index earnings, no header", "number", number)
+
+			return
+		}
+
+		validator, fees, err := i.blockEarnings(header)
+		if err != nil {
+			i.logger.Error("unable to index earnings", "number", number, "err", err)
+
+			return
+		}
+
+		i.earnings.record(number, i.GetEpoch(number), validator, fees)
+	}
+}
+
+// blockEarnings returns the proposer of the block, and the fees credited to its coinbase
+func (i *Ibft) blockEarnings(header *types.Header) (types.Address, *big.Int, error) {
+	proposer, err := ecrecoverFromHeader(header)
+	if err != nil {
+		return types.ZeroAddress, nil, err
+	}
+
+	fees := new(big.Int)
+
+	// empty blocks have no fees
+	if header.TxRoot == types.EmptyRootHash {
+		return proposer, fees, nil
+	}
+
+	body, ok := i.blockchain.GetBodyByHash(header.Hash)
+	if !ok {
+		return types.ZeroAddress, nil, fmt.Errorf("body of block %d not found", header.Number)
+	}
+
+	receipts, err := i.blockchain.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return types.ZeroAddress, nil, err
+	}
+
+	if len(receipts) != len(body.Transactions) {
+		return types.ZeroAddress, nil, fmt.Errorf("block %d has %d receipts for %d transactions",
+			header.Number, len(receipts), len(body.Transactions))
+	}
+
+	for idx, tx := range body.Transactions {
+		fee := new(big.Int).SetUint64(receipts[idx].GasUsed)
+		fees.Add(fees, fee.Mul(fee, tx.EffectiveTip(header.BaseFee)))
+	}
+
+	return proposer, fees, nil
+}
+
+// ValidatorEarnings implements the consensus.EarningsProvider interface
+func (i *Ibft) ValidatorEarnings(
+	validator types.Address,
+	fromEpoch, toEpoch *uint64,
+) (*consensus.EarningsReport, error) {
+	if i.earnings == nil {
+		return nil, errNoEarnings
+	}
+
+	last := i.earnings.last()
+	if last == 0 {
+		return nil, errNoEarnings
+	}
+
+	return i.earnings.report(validator, i.GetEpoch(last), fromEpoch, toEpoch)
+}
@@ -0,0 +1,215 @@
+package ibft
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/consensus"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEarningsIndex_Report(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B")
+
+	var (
+		a, b  = pool.get("A").Address(), pool.get("B").Address()
+		index = newEarningsIndex()
+	)
+
+	_, err := index.report(a, 1, nil, nil)
+	assert.ErrorIs(t, err, errNoEarnings)
+
+	// A proposes the blocks of the first two epochs, B the ones of the third one
+	index.record(1, 1, a, big.NewInt(10))
+	index.record(2, 1, a, big.NewInt(20))
+	index.record(3, 2, a, big.NewInt(5))
+	index.record(4, 3, b, big.NewInt(7))
+
+	// blocks already indexed are ignored
+	index.record(4, 3, a, big.NewInt(100))
+
+	uint64Ptr := func(n uint64) *uint64 {
+		return &n
+	}
+
+	// the latest epoch by default
+	report, err := index.report(a, 3, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), report.FromEpoch)
+	assert.Equal(t, uint64(3), report.ToEpoch)
+	assert.Equal(t, uint64(0), report.Blocks)
+	assert.Equal(t, big.NewInt(0), report.Fees)
+	assert.Empty(t, report.Epochs)
+
+	report, err = index.report(a, 3, uint64Ptr(1), uint64Ptr(3))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), report.Blocks)
+	assert.Equal(t, big.NewInt(35), report.Fees)
+	assert.Len(t, report.Epochs, 2)
+	assert.Equal(t, uint64(1), report.Epochs[0].Epoch)
+	assert.Equal(t, uint64(2), report.Epochs[0].Blocks)
+	assert.Equal(t, big.NewInt(30), report.Epochs[0].Fees)
+	assert.Equal(t, uint64(2), report.Epochs[1].Epoch)
+
+	// only the end of the range reports the one epoch
+	report, err = index.report(b, 3, nil, uint64Ptr(3))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), report.Blocks)
+	assert.Equal(t, big.NewInt(7), report.Fees)
+
+	_, err = index.report(a, 3, uint64Ptr(3), uint64Ptr(1))
+	assert.ErrorIs(t, err, errInvalidEarningsRange)
+
+	_, err = index.report(a, 3, uint64Ptr(1), uint64Ptr(maxEarningsEpochs+1))
+	assert.ErrorIs(t, err, errInvalidEarningsRange)
+}
+
+func TestEarningsIndex_SaveAndLoad(t *testing.T) {
+	var (
+		addr  = types.StringToAddress("1")
+		index = newEarningsIndex()
+		path  = t.TempDir()
+	)
+
+	index.record(1, 1, addr, big.NewInt(42))
+	assert.NoError(t, index.saveToPath(path))
+
+	loaded := newEarningsIndex()
+	assert.NoError(t, loaded.loadFromPath(path))
+	assert.Equal(t, uint64(1), loaded.last())
+
+	report, err := loaded.report(addr, 1, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), report.Blocks)
+	assert.Equal(t, big.NewInt(42), report.Fees)
+}
+
+func TestEarningsIndex_Rewind(t *testing.T) {
+	var (
+		addr  = types.StringToAddress("1")
+		index = newEarningsIndex()
+	)
+
+	index.record(1, 1, addr, big.NewInt(1))
+	index.record(2, 2, addr, big.NewInt(2))
+	index.record(3, 3, addr, big.NewInt(3))
+
+	index.rewind(2, 1)
+	assert.Equal(t, uint64(1), index.last())
+
+	report, err := index.report(addr, 3, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, report.Epochs)
+
+	// the blocks of the dropped epochs are indexed again
+	index.record(2, 2, addr, big.NewInt(5))
+
+	report, err = index.report(addr, 2, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(5), report.Fees)
+}
+
+func TestIbft_IndexEarnings(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B")
+
+	blockchain := NewMockBlockchain(t)
+	genesis := blockchain.SetGenesis(pool.ValidatorSet())
+
+	// A proposes the blocks of the first epoch, B the block of the second one
+	var (
+		parent   = genesis
+		feeBlock types.Hash
+	)
+
+	for number := uint64(1); number <= 3; number++ {
+		proposer := pool.get("A")
+		if number == 3 {
+			proposer = pool.get("B")
+		}
+
+		block := blockchain.MockBlock(number, parent.Hash(), proposer.priv, pool.ValidatorSet())
+
+		if number == 2 {
+			block.Transactions = []*types.Transaction{
+				{GasPrice: big.NewInt(10), Gas: 21000},
+				{GasPrice: big.NewInt(3), Gas: 50000},
+			}
+			feeBlock = block.Hash()
+		}
+
+		assert.NoError(t, blockchain.WriteBlock(block))
+
+		parent = block
+	}
+
+	blockchain.GetReceiptsByHashHandler = func(hash types.Hash) ([]*types.Receipt, error) {
+		if hash != feeBlock {
+			return []*types.Receipt{}, nil
+		}
+
+		return []*types.Receipt{{GasUsed: 21000}, {GasUsed: 30000}}, nil
+	}
+
+	ibft := &Ibft{
+		logger:     hclog.NewNullLogger(),
+		blockchain: blockchain,
+		epochSize:  2,
+		closeCh:    make(chan struct{}),
+		earnings:   newEarningsIndex(),
+	}
+
+	ibft.indexEarnings()
+	assert.Equal(t, uint64(3), ibft.earnings.last())
+
+	report, err := ibft.ValidatorEarnings(pool.get("A").Address(), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), report.FromEpoch)
+	assert.Equal(t, uint64(0), report.Blocks)
+
+	from := uint64(1)
+
+	report, err = ibft.ValidatorEarnings(pool.get("A").Address(), &from, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), report.Blocks)
+	assert.Equal(t, big.NewInt(21000*10+30000*3), report.Fees)
+
+	report, err = ibft.ValidatorEarnings(pool.get("B").Address(), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), report.Blocks)
+}
+
+func TestIbft_SetupEarnings_RewoundToGenesis(t *testing.T) {
+	var (
+		pool = newTesterAccountPool()
+		addr = types.StringToAddress("1")
+	)
+
+	pool.add("A")
+
+	blockchain := NewMockBlockchain(t)
+	blockchain.SetGenesis(pool.ValidatorSet())
+
+	// the index was saved before the chain was set back to the genesis
+	dir := t.TempDir()
+	index := newEarningsIndex()
+	index.record(1, 1, addr, big.NewInt(1))
+	index.record(3, 2, addr, big.NewInt(3))
+	assert.NoError(t, index.saveToPath(dir))
+
+	ibft := &Ibft{
+		logger:     hclog.NewNullLogger(),
+		blockchain: blockchain,
+		config:     &consensus.Config{Path: dir},
+		epochSize:  2,
+	}
+
+	assert.NoError(t, ibft.setupEarnings())
+	assert.Equal(t, uint64(0), ibft.earnings.last())
+
+	_, err := ibft.earnings.report(addr, 2, nil, nil)
+	assert.ErrorIs(t, err, errNoEarnings)
+}
@@ -47,3 +47,26 @@ func TestExtraEncoding(t *testing.T) {
 		}
 	}
 }
+
+func TestIbft_CommittedSeals(t *testing.T) {
+	seal := types.StringToHash("1").Bytes()
+
+	header := &types.Header{}
+	if err := PutIbftExtra(header, &IstanbulExtra{
+		Validators:    []types.Address{},
+		CommittedSeal: [][]byte{seal, seal, seal},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	i := &Ibft{}
+
+	seals, err := i.CommittedSeals(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if seals != 3 {
+		t.Fatalf("expected 3 committed seals, got %d", seals)
+	}
+}
@@ -0,0 +1,106 @@
+package ibft
+
+import (
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/contracts/governance"
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// isFeeRecipientBlock returns whether the fees of the block are credited to the
+// fee recipient set in the header miner field, instead of the block signer.
+// It applies to the PoS blocks since the fee recipient fork, as the PoA blocks
+// use the miner field for candidate voting.
+func (i *Ibft) isFeeRecipientBlock(height uint64) bool {
+	if i.config.Params == nil || i.config.Params.Forks == nil ||
+		!i.config.Params.Forks.IsFeeRecipient(height) {
+		return false
+	}
+
+	for _, mechanism := range i.mechanisms {
+		if pos, ok := mechanism.(*PoSMechanism); ok && pos.IsInRange(height) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FeeRecipient returns the fee recipient of the blocks the validator proposes
+func (i *Ibft) FeeRecipient() types.Address {
+	i.feeRecipientLock.RLock()
+	defer i.feeRecipientLock.RUnlock()
+
+	if i.feeRecipient == types.ZeroAddress {
+		return i.validatorKeyAddr
+	}
+
+	return i.feeRecipient
+}
+
+// SetFeeRecipient sets the fee recipient of the blocks the validator proposes,
+// zero resetting it to the validator itself. The recipient must be allowed
+// by the state of the latest block.
+func (i *Ibft) SetFeeRecipient(recipient types.Address) error {
+	if recipient != types.ZeroAddress && recipient != i.validatorKeyAddr {
+		header := i.blockchain.Header()
+
+		if !i.isFeeRecipientBlock(header.Number + 1) {
+			return fmt.Errorf("fee recipient is not supported at block %d", header.Number+1)
+		}
+
+		if err := i.verifyFeeRecipient(header, i.validatorKeyAddr, recipient); err != nil {
+			return err
+		}
+	}
+
+	i.feeRecipientLock.Lock()
+	defer i.feeRecipientLock.Unlock()
+
+	i.feeRecipient = recipient
+
+	return nil
+}
+
+// proposalFeeRecipient returns the fee recipient of the block built on top of the parent,
+// falling back to the validator if the configured one is no longer allowed
+func (i *Ibft) proposalFeeRecipient(parent *types.Header) types.Address {
+	recipient := i.FeeRecipient()
+
+	if recipient == i.validatorKeyAddr || !i.isFeeRecipientBlock(parent.Number+1) {
+		return i.validatorKeyAddr
+	}
+
+	if err := i.verifyFeeRecipient(parent, i.validatorKeyAddr, recipient); err != nil {
+		i.logger.Warn("fee recipient refused, crediting the validator",
+			"recipient", recipient, "err", err)
+
+		return i.validatorKeyAddr
+	}
+
+	return recipient
+}
+
+// verifyFeeRecipient checks that the recipient is either the validator itself, or
+// the payout address the validator registered in the state of the parent block
+func (i *Ibft) verifyFeeRecipient(parent *types.Header, validator, recipient types.Address) error {
+	if recipient == validator {
+		return nil
+	}
+
+	st := i.executor.State()
+
+	snap, err := st.NewSnapshotAt(parent.StateRoot)
+	if err != nil {
+		return fmt.Errorf("unable to get snapshot of block %d, %w", parent.Number, err)
+	}
+
+	payout := governance.ReadPayout(state.NewTxn(st, snap), validator)
+	if payout == types.ZeroAddress || payout != recipient {
+		return fmt.Errorf("%w: validator=%s, recipient=%s, payout=%s",
+			ErrInvalidFeeRecipient, validator, recipient, payout)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package ibft
+
+import (
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/consensus"
+	"github.com/dogechain-lab/dogechain/contracts/governance"
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
+	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFeeRecipientIbft returns a PoS ibft whose latest block registers
+// the payout address of the validator in the governance contract
+func newFeeRecipientIbft(t *testing.T, validator, payout types.Address) *Ibft {
+	t.Helper()
+
+	params := &chain.Params{
+		ChainID: 100,
+		Forks: &chain.Forks{
+			Governance:   chain.NewFork(0),
+			FeeRecipient: chain.NewFork(0),
+		},
+	}
+
+	executor := state.NewExecutor(params, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger())
+
+	contract := governance.PredeployGovernanceSC()
+	contract.Storage = map[types.Hash]types.Hash{
+		governance.PayoutSlot(validator): types.BytesToHash(payout.Bytes()),
+	}
+
+	root := executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		systemcontracts.AddrGovernanceContract: contract,
+	})
+
+	m := NewMockBlockchain(t)
+	m.HeaderHandler = func() *types.Header {
+		return &types.Header{Number: 0, StateRoot: root}
+	}
+
+	ibft := &Ibft{
+		logger:           hclog.NewNullLogger(),
+		config:           &consensus.Config{Params: params},
+		blockchain:       m,
+		executor:         executor,
+		validatorKeyAddr: validator,
+	}
+
+	initIbftMechanism(PoS, ibft)
+
+	return ibft
+}
+
+func TestIbft_SetFeeRecipient(t *testing.T) {
+	var (
+		validator = types.StringToAddress("1")
+		payout    = types.StringToAddress("2")
+		other     = types.StringToAddress("3")
+	)
+
+	ibft := newFeeRecipientIbft(t, validator, payout)
+	parent := ibft.blockchain.Header()
+
+	// defaults to the validator
+	assert.Equal(t, validator, ibft.FeeRecipient())
+	assert.Equal(t, validator, ibft.proposalFeeRecipient(parent))
+
+	// the registered payout address is allowed
+	assert.NoError(t, ibft.SetFeeRecipient(payout))
+	assert.Equal(t, payout, ibft.FeeRecipient())
+	assert.Equal(t, payout, ibft.proposalFeeRecipient(parent))
+
+	// any other address is refused
+	assert.ErrorIs(t, ibft.SetFeeRecipient(other), ErrInvalidFeeRecipient)
+	assert.Equal(t, payout, ibft.FeeRecipient())
+
+	// zero resets to the validator
+	assert.NoError(t, ibft.SetFeeRecipient(types.ZeroAddress))
+	assert.Equal(t, validator, ibft.FeeRecipient())
+}
+
+func TestIbft_SetFeeRecipient_NotSupported(t *testing.T) {
+	var (
+		validator = types.StringToAddress("1")
+		payout    = types.StringToAddress("2")
+	)
+
+	ibft := newFeeRecipientIbft(t, validator, payout)
+
+	// PoA blocks use the miner field for voting
+	initIbftMechanism(PoA, ibft)
+
+	assert.Error(t, ibft.SetFeeRecipient(payout))
+	assert.NoError(t, ibft.SetFeeRecipient(validator))
+}
+
+func TestIbft_VerifyFeeRecipient(t *testing.T) {
+	var (
+		validator = types.StringToAddress("1")
+		payout    = types.StringToAddress("2")
+		other     = types.StringToAddress("3")
+	)
+
+	ibft := newFeeRecipientIbft(t, validator, payout)
+	parent := ibft.blockchain.Header()
+
+	assert.NoError(t, ibft.verifyFeeRecipient(parent, validator, validator))
+	assert.NoError(t, ibft.verifyFeeRecipient(parent, validator, payout))
+	assert.ErrorIs(t, ibft.verifyFeeRecipient(parent, validator, other), ErrInvalidFeeRecipient)
+
+	// a validator without a registered payout address is only allowed itself
+	assert.NoError(t, ibft.verifyFeeRecipient(parent, other, other))
+	assert.ErrorIs(t, ibft.verifyFeeRecipient(parent, other, types.ZeroAddress), ErrInvalidFeeRecipient)
+
+	// the fees go to the miner field of the PoS blocks
+	creator, err := ibft.GetBlockCreator(&types.Header{Number: 1, Miner: payout})
+	assert.NoError(t, err)
+	assert.Equal(t, payout, creator)
+}
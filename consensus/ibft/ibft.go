@@ -5,13 +5,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"sync"
 	"time"
 
 	"go.uber.org/atomic"
 
+	"github.com/dogechain-lab/dogechain/chain"
 	"github.com/dogechain-lab/dogechain/consensus"
 	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	"github.com/dogechain-lab/dogechain/contracts/bridge"
+	"github.com/dogechain-lab/dogechain/contracts/governance"
 	"github.com/dogechain-lab/dogechain/contracts/upgrader"
 	"github.com/dogechain-lab/dogechain/crypto"
 	"github.com/dogechain-lab/dogechain/helper/common"
@@ -21,6 +26,7 @@ import (
 	"github.com/dogechain-lab/dogechain/protocol"
 	"github.com/dogechain-lab/dogechain/secrets"
 	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/txpool"
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/hashicorp/go-hclog"
 	"google.golang.org/grpc"
@@ -28,13 +34,20 @@ import (
 )
 
 const (
-	DefaultEpochSize = 100000
+	DefaultEpochSize         = 100000
+	DefaultMinValidatorCount = 4  // written to the new genesis files, the check is disabled when unset
+	DefaultMaxIdleInterval   = 60 // in seconds
 )
 
+// emptyBlockPollInterval is how often a proposer suppressing empty blocks checks the txpool
+const emptyBlockPollInterval = 100 * time.Millisecond
+
 var (
 	ErrInvalidHookParam     = errors.New("invalid IBFT hook param passed in")
 	ErrInvalidMechanismType = errors.New("invalid consensus mechanism type in params")
 	ErrMissingMechanismType = errors.New("missing consensus mechanism type in params")
+	ErrMinValidatorCount    = errors.New("validator set would drop below the minimum validator count")
+	ErrInvalidFeeRecipient  = errors.New("fee recipient is neither the validator nor its registered payout address")
 )
 
 type blockchainInterface interface {
@@ -43,6 +56,8 @@ type blockchainInterface interface {
 	WriteBlock(block *types.Block) error
 	VerifyPotentialBlock(block *types.Block) error
 	CalculateGasLimit(number uint64) (uint64, error)
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+	GetBodyByHash(hash types.Hash) (*types.Body, bool)
 }
 
 type txPoolInterface interface {
@@ -50,6 +65,8 @@ type txPoolInterface interface {
 	DemoteAllPromoted(tx *types.Transaction, correctNonce uint64)
 	ResetWithHeaders(headers ...*types.Header)
 	Pending() map[types.Address][]*types.Transaction
+	ExecutionHint(hash types.Hash) (*txpool.ExecutionHint, bool)
+	Length() uint64
 }
 
 type syncerInterface interface {
@@ -59,6 +76,7 @@ type syncerInterface interface {
 	WatchSyncWithPeer(p *protocol.SyncPeer, newBlockHandler func(b *types.Block) bool, blockTimeout time.Duration)
 	GetSyncProgression() *progress.Progression
 	Broadcast(b *types.Block)
+	PeerScores() []*protocol.PeerScore
 }
 
 // Ibft represents the IBFT consensus mechanism object
@@ -78,11 +96,19 @@ type Ibft struct {
 	validatorKey     *ecdsa.PrivateKey // Private key for the validator
 	validatorKeyAddr types.Address
 
+	feeRecipientLock sync.RWMutex
+	feeRecipient     types.Address // Address credited with the fees of the proposed PoS blocks, the validator if zero
+
 	txpool txPoolInterface // Reference to the transaction pool
 
 	store     *snapshotStore // Snapshot store that keeps track of all snapshots
 	epochSize uint64
 
+	minValidatorCount uint64 // Minimum number of validators a set change may leave, 0 disables the check
+
+	noEmptyBlocks   bool          // Whether proposers wait for transactions instead of sealing empty blocks
+	maxIdleInterval time.Duration // Maximum interval without a block when empty blocks are suppressed
+
 	msgQueue *msgQueue     // Structure containing different message queues
 	updateCh chan struct{} // Update channel
 
@@ -103,6 +129,22 @@ type Ibft struct {
 	mechanisms []ConsensusMechanism // IBFT ConsensusMechanism used (PoA / PoS)
 
 	blockTime time.Duration // Minimum block generation time in seconds
+
+	roundTimeout func(round uint64) time.Duration // Timeout of a round, the exponential timeout if nil
+
+	txOrdering consensus.OrderingPolicy // Policy ordering transactions in a new block
+
+	status statusTracker // Copy of the consensus state for the status page
+
+	uptime uptimeTracker // Participation of the validators in the committed seals
+
+	earnings   *earningsIndex // Blocks proposed and fees earned by the validators, per epoch
+	earningsCh chan struct{}  // Wakes the earnings indexer up
+
+	discovery *validatorDiscovery // Connects the validators to each other in advance
+
+	savedRound *roundData  // Round saved before the restart, rejoined in the next sequence
+	rounds     roundWriter // Round state written to the consensus directory
 }
 
 // runHook runs a specified hook if it is present in the hook map
@@ -131,6 +173,21 @@ func (i *Ibft) runHook(hookName HookType, height uint64, hookParam interface{})
 	return nil
 }
 
+// checkValidatorCount returns an error if shrinking the validator set from current
+// to next validators would leave fewer validators than the configured minimum
+func (i *Ibft) checkValidatorCount(current, next int) error {
+	if next >= current || uint64(next) >= i.minValidatorCount {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: %d validators would be left, at least %d required",
+		ErrMinValidatorCount,
+		next,
+		i.minValidatorCount,
+	)
+}
+
 // Factory implements the base consensus Factory method
 func Factory(
 	params *consensus.ConsensusParams,
@@ -154,22 +211,64 @@ func Factory(
 		}
 	}
 
+	// No minimum defined disables the check, so that the chains
+	// created before it replay their history under the same rules
+	var minValidatorCount uint64
+	if definedCount, ok := params.Config.Config["minValidatorCount"]; ok {
+		readCount, ok := definedCount.(float64)
+		if !ok {
+			return nil, errors.New("invalid type assertion")
+		}
+
+		minValidatorCount = uint64(readCount)
+	}
+
+	var noEmptyBlocks bool
+	if definedNoEmpty, ok := params.Config.Config["noEmptyBlocks"]; ok {
+		if noEmptyBlocks, ok = definedNoEmpty.(bool); !ok {
+			return nil, errors.New("invalid type assertion")
+		}
+	}
+
+	maxIdleInterval := uint64(DefaultMaxIdleInterval)
+	if definedInterval, ok := params.Config.Config["maxIdleInterval"]; ok {
+		readInterval, ok := definedInterval.(float64)
+		if !ok {
+			return nil, errors.New("invalid type assertion")
+		}
+
+		if readInterval > 0 {
+			maxIdleInterval = uint64(readInterval)
+		}
+	}
+
+	txOrdering, err := consensus.NewOrderingPolicy(params.Config.TxOrdering)
+	if err != nil {
+		return nil, err
+	}
+
 	p := &Ibft{
-		logger:         params.Logger.Named("ibft"),
-		config:         params.Config,
-		Grpc:           params.Grpc,
-		blockchain:     params.Blockchain,
-		executor:       params.Executor,
-		closeCh:        make(chan struct{}),
-		isClosed:       atomic.NewBool(false),
-		txpool:         params.Txpool,
-		state:          &currentState{},
-		network:        params.Network,
-		epochSize:      epochSize,
-		sealing:        params.Seal,
-		metrics:        params.Metrics,
-		secretsManager: params.SecretsManager,
-		blockTime:      time.Duration(params.BlockTime) * time.Second,
+		logger:            params.Logger.Named("ibft"),
+		config:            params.Config,
+		Grpc:              params.Grpc,
+		blockchain:        params.Blockchain,
+		executor:          params.Executor,
+		closeCh:           make(chan struct{}),
+		isClosed:          atomic.NewBool(false),
+		txpool:            params.Txpool,
+		rounds:            roundWriter{wakeCh: make(chan struct{}, 1)},
+		state:             &currentState{},
+		network:           params.Network,
+		epochSize:         epochSize,
+		minValidatorCount: minValidatorCount,
+		noEmptyBlocks:     noEmptyBlocks,
+		maxIdleInterval:   time.Duration(maxIdleInterval) * time.Second,
+		sealing:           params.Seal,
+		metrics:           params.Metrics,
+		secretsManager:    params.SecretsManager,
+		blockTime:         time.Duration(params.BlockTime) * time.Second,
+		txOrdering:        txOrdering,
+		feeRecipient:      params.Config.FeeRecipient,
 	}
 
 	// Initialize the mechanism
@@ -180,7 +279,20 @@ func Factory(
 	// Istanbul requires a different header hash function
 	types.HeaderHash = istanbulHeaderHash
 
-	p.syncer = protocol.NewSyncer(params.Logger, params.Network, params.Blockchain)
+	syncer := protocol.NewSyncer(params.Logger, params.Network, params.Blockchain)
+	if params.Config.FastSync {
+		syncer.EnableFastSync(params.StateStorage, p.fastSyncLimit)
+	}
+
+	if params.Config.Checkpoint != nil {
+		syncer.SetCheckpoint(params.Config.Checkpoint)
+	}
+
+	if params.Config.SyncRequestTimeout > 0 {
+		syncer.SetRequestTimeout(params.Config.SyncRequestTimeout)
+	}
+
+	p.syncer = syncer
 
 	return p, nil
 }
@@ -192,6 +304,20 @@ func (i *Ibft) Initialize() error {
 		return err
 	}
 
+	// Set up the earnings index
+	if err := i.setupEarnings(); err != nil {
+		return err
+	}
+
+	// The local chain must contain the checkpoint
+	if checkpoint := i.config.Checkpoint; checkpoint != nil {
+		if header, ok := i.blockchain.GetHeaderByNumber(checkpoint.Number); ok {
+			if err := checkpoint.Check(header); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -215,6 +341,17 @@ func (i *Ibft) Start() error {
 		return err
 	}
 
+	// start announcing and discovering the validators
+	if err := i.setupValidatorDiscovery(); err != nil {
+		return err
+	}
+
+	go i.discovery.run(i.closeCh)
+
+	go i.runEarningsIndexer()
+
+	go i.runRoundWriter()
+
 	// Start the syncer
 	i.syncer.Start()
 
@@ -311,6 +448,56 @@ func (i *Ibft) setupMechanism() error {
 	return nil
 }
 
+// getPoSMechanism returns the PoS mechanism covering the latest block, or the last one defined
+func (i *Ibft) getPoSMechanism() *PoSMechanism {
+	var (
+		height uint64
+		found  *PoSMechanism
+	)
+
+	if header := i.blockchain.Header(); header != nil {
+		height = header.Number
+	}
+
+	for _, mechanism := range i.mechanisms {
+		pos, ok := mechanism.(*PoSMechanism)
+		if !ok {
+			continue
+		}
+
+		found = pos
+
+		if pos.IsInRange(height) {
+			break
+		}
+	}
+
+	return found
+}
+
+// fastSyncLimit returns the highest pivot of a fast sync from the block, the first block
+// following it whose state the PoS reads when inserted, so that the validator set of
+// an epoch is never read from a block written without its state
+func (i *Ibft) fastSyncLimit(number uint64) (uint64, bool) {
+	var (
+		limit uint64
+		found bool
+	)
+
+	for _, mechanism := range i.mechanisms {
+		pos, ok := mechanism.(*PoSMechanism)
+		if !ok {
+			continue
+		}
+
+		if boundary, ok := pos.stateBoundary(number); ok && (!found || boundary < limit) {
+			limit, found = boundary, true
+		}
+	}
+
+	return limit, found
+}
+
 // setupTransport sets up the gossip transport protocol
 func (i *Ibft) setupTransport() error {
 	// Define a new topic
@@ -328,26 +515,7 @@ func (i *Ibft) setupTransport() error {
 			return
 		}
 
-		if !i.isSealing() {
-			// if we are not sealing we do not care about the messages
-			// but we need to subscribe to propagate the messages
-			return
-		}
-
-		// decode sender
-		if err := validateMsg(msg); err != nil {
-			i.logger.Error("failed to validate msg", "err", err)
-
-			return
-		}
-
-		if msg.From == i.validatorKeyAddr.String() {
-			// we are the sender, skip this message since we already
-			// relay our own messages internally.
-			return
-		}
-
-		i.pushMessage(msg)
+		i.HandleMessage(msg)
 	})
 
 	if err != nil {
@@ -359,6 +527,33 @@ func (i *Ibft) setupTransport() error {
 	return nil
 }
 
+// HandleMessage validates the message received from the transport and
+// queues it for the state machine
+func (i *Ibft) HandleMessage(msg *proto.MessageReq) {
+	if !i.isSealing() {
+		// if we are not sealing we do not care about the messages
+		// but we need to subscribe to propagate the messages
+		return
+	}
+
+	// decode sender
+	if err := validateMsg(msg); err != nil {
+		i.logger.Error("failed to validate msg", "err", err)
+
+		return
+	}
+
+	if msg.From == i.validatorKeyAddr.String() {
+		// we are the sender, skip this message since we already
+		// relay our own messages internally.
+		return
+	}
+
+	i.metrics.MessagesReceived.With("type", msg.Type.String()).Add(1)
+
+	i.pushMessage(msg)
+}
+
 // createKey sets the validator's private key from the secrets manager
 func (i *Ibft) createKey() error {
 	i.msgQueue = newMsgQueue()
@@ -426,13 +621,21 @@ func (i *Ibft) start() {
 
 // runCycle represents the IBFT state machine loop
 func (i *Ibft) runCycle() {
+	// Publish the state for the status page
+	i.status.observe(i.state)
+
 	// Log to the console
 	if i.state.view != nil {
 		i.logger.Debug("cycle", "state", i.getState(), "sequence", i.state.view.Sequence, "round", i.state.view.Round+1)
 	}
 
+	state, start := i.getState(), time.Now()
+	defer func() {
+		i.metrics.StateDuration.With("state", state.String()).Observe(time.Since(start).Seconds())
+	}()
+
 	// Based on the current state, execute the corresponding section
-	switch i.getState() {
+	switch state {
 	case AcceptState:
 		i.runAcceptState()
 
@@ -510,6 +713,8 @@ func (i *Ibft) runSyncState() {
 
 		if err := i.syncer.BulkSyncWithPeer(p, func(newBlock *types.Block) {
 			callInsertBlockHook(newBlock.Number())
+			i.recordUptime(newBlock.Header)
+			i.notifyEarnings()
 			i.txpool.ResetWithHeaders(newBlock.Header)
 		}); err != nil {
 			i.logger.Error("failed to bulk sync", "err", err)
@@ -533,6 +738,8 @@ func (i *Ibft) runSyncState() {
 			// After each written block, update the snapshot store for PoS.
 			// The snapshot store is currently updated for PoA inside the ProcessHeadersHook
 			callInsertBlockHook(newBlock.Number())
+			i.recordUptime(newBlock.Header)
+			i.notifyEarnings()
 
 			i.syncer.Broadcast(newBlock)
 			i.txpool.ResetWithHeaders(newBlock.Header)
@@ -575,8 +782,70 @@ func (i *Ibft) shouldWriteTransactions(height uint64) bool {
 	return false
 }
 
+// suppressesEmptyBlocks returns whether empty blocks are suppressed at the height,
+// blocks not including transactions by design are always sealed
+func (i *Ibft) suppressesEmptyBlocks(height uint64) bool {
+	return i.noEmptyBlocks && i.shouldWriteTransactions(height)
+}
+
+// idleDeadline returns the time a block has to be sealed after the parent,
+// even without any transaction
+func (i *Ibft) idleDeadline(parent *types.Header) time.Time {
+	return time.Unix(int64(parent.Timestamp), 0).Add(i.maxIdleInterval)
+}
+
+// isEarlyEmptyBlock returns whether the block is an empty block sealed before the idle deadline,
+// while empty blocks are suppressed.
+// It is a proposal-time rule only: the validators refuse to vote for such a block, but it is not
+// part of the header verification, so that the blocks synced or sealed before the option was
+// enabled in the genesis are still valid. A block sealed by a quorum is never checked against it
+func (i *Ibft) isEarlyEmptyBlock(parent *types.Header, block *types.Block) bool {
+	return i.suppressesEmptyBlocks(block.Number()) &&
+		len(block.Transactions) == 0 &&
+		block.Header.Timestamp < uint64(i.idleDeadline(parent).Unix())
+}
+
+// waitForTransactions waits until the txpool has pending transactions, or the idle deadline passes.
+// It returns false if the node is closing
+func (i *Ibft) waitForTransactions(parent *types.Header) bool {
+	deadline := time.NewTimer(time.Until(i.idleDeadline(parent)))
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(emptyBlockPollInterval)
+	defer ticker.Stop()
+
+	for i.txpool.Length() == 0 {
+		select {
+		case <-deadline.C:
+			return true
+		case <-ticker.C:
+		case <-i.closeCh:
+			return false
+		}
+	}
+
+	return true
+}
+
+// waitUntil waits until the time. It returns false if the node is closing
+func (i *Ibft) waitUntil(t time.Time) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-i.closeCh:
+		return false
+	}
+}
+
 // buildBlock builds the block, based on the passed in snapshot and parent header
 func (i *Ibft) buildBlock(snap *Snapshot, parent *types.Header) (*types.Block, error) {
+	defer func(start time.Time) {
+		i.metrics.ProposalBuildTime.Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	header := &types.Header{
 		ParentHash: parent.Hash,
 		Number:     parent.Number + 1,
@@ -597,6 +866,7 @@ func (i *Ibft) buildBlock(snap *Snapshot, parent *types.Header) (*types.Block, e
 	}
 
 	header.GasLimit = gasLimit
+	header.BaseFee = i.config.Params.CalculateBaseFee(parent)
 
 	if hookErr := i.runHook(CandidateVoteHook, header.Number, &candidateVoteHookParams{
 		header: header,
@@ -618,7 +888,14 @@ func (i *Ibft) buildBlock(snap *Snapshot, parent *types.Header) (*types.Block, e
 	// we need to include in the extra field the current set of validators
 	putIbftExtraValidators(header, snap.Set)
 
-	transition, err := i.executor.BeginTxn(parent.StateRoot, header, i.validatorKeyAddr)
+	coinbase := i.validatorKeyAddr
+
+	if i.isFeeRecipientBlock(header.Number) {
+		coinbase = i.proposalFeeRecipient(parent)
+		header.Miner = coinbase
+	}
+
+	transition, err := i.executor.BeginTxn(parent.StateRoot, header, coinbase)
 	if err != nil {
 		return nil, err
 	}
@@ -631,7 +908,13 @@ func (i *Ibft) buildBlock(snap *Snapshot, parent *types.Header) (*types.Block, e
 	)
 
 	if i.shouldWriteTransactions(header.Number) {
-		txs, dropTxs, resetTxs = i.writeTransactions(gasLimit, transition)
+		txs, dropTxs, resetTxs = i.writeTransactions(
+			gasLimit,
+			i.config.Params.BlockBodySizeLimitAt(header.Number),
+			header.BaseFee,
+			i.priorityLane(transition.Txn()),
+			transition,
+		)
 	}
 
 	if err := i.PreStateCommit(header, transition); err != nil {
@@ -690,9 +973,33 @@ func (i *Ibft) buildBlock(snap *Snapshot, parent *types.Header) (*types.Block, e
 	return block, nil
 }
 
+// priorityLane returns the priority lane of the block built on the state, whose callers are
+// the bridge signers of the state and the callers of the chain params, nil if there is none
+func (i *Ibft) priorityLane(state bridge.State) *chain.PriorityLane {
+	lane := i.config.Params.PriorityLane
+	if lane == nil || lane.ReservedGasPercent == 0 {
+		return nil
+	}
+
+	blockLane := &chain.PriorityLane{
+		ReservedGasPercent: lane.ReservedGasPercent,
+		Callers:            bridge.Signers(state),
+	}
+
+	for _, caller := range lane.Callers {
+		if !blockLane.IsCaller(caller) {
+			blockLane.Callers = append(blockLane.Callers, caller)
+		}
+	}
+
+	return blockLane
+}
+
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
 	WriteFailedReceipt(txn *types.Transaction) error
+	TotalGas() uint64
+	Prewarm(addrs []types.Address)
 }
 
 type demoteTransaction struct {
@@ -700,10 +1007,24 @@ type demoteTransaction struct {
 	CorrectNonce uint64
 }
 
+// orderingPolicy returns the configured transaction ordering policy, or the default one
+func (i *Ibft) orderingPolicy() consensus.OrderingPolicy {
+	if i.txOrdering == nil {
+		i.txOrdering, _ = consensus.NewOrderingPolicy(consensus.DefaultOrderingPolicy)
+	}
+
+	return i.txOrdering
+}
+
 // writeTransactions writes transactions from the txpool to the transition object
-// and returns transactions that were included in the transition (new block)
+// and returns transactions that were included in the transition (new block).
+// The transactions of the priority lane callers are written first, up to the gas reserved
+// to the lane, the rest of them competing with the other transactions for the remaining gas
 func (i *Ibft) writeTransactions(
 	gasLimit uint64,
+	sizeLimit uint64,
+	baseFee uint64,
+	lane *chain.PriorityLane,
 	transition transitionInterface,
 ) (
 	includedTransactions []*types.Transaction,
@@ -712,61 +1033,165 @@ func (i *Ibft) writeTransactions(
 ) {
 	// get all pending transactions once and for all
 	pendingTxs := i.txpool.Pending()
-	// get highest price transaction queue
-	priceTxs := types.NewTransactionsByPriceAndNonce(pendingTxs)
+	// reuse what the txpool learnt about them, before the ordering takes them over
+	minGas := i.prewarmTransactions(pendingTxs, transition)
+
+	writer := &txsWriter{
+		logger:     i.logger,
+		transition: transition,
+		gasLimit:   gasLimit,
+		sizeLimit:  sizeLimit,
+		minGas:     minGas,
+	}
 
+	if reserved := lane.ReservedGas(gasLimit); reserved > 0 {
+		priorityTxs := make(map[types.Address][]*types.Transaction)
+
+		for addr, txs := range pendingTxs {
+			if lane.IsCaller(addr) {
+				priorityTxs[addr] = txs
+				delete(pendingTxs, addr)
+			}
+		}
+
+		if len(priorityTxs) > 0 {
+			writer.write(i.orderingPolicy().Order(copyPendingTxs(priorityTxs), baseFee), reserved)
+
+			// the transactions left out of the lane join the other ones
+			for addr, txs := range writer.leftovers(priorityTxs) {
+				pendingTxs[addr] = txs
+			}
+
+			i.logger.Debug("priority lane written",
+				"reserved", reserved,
+				"gasUsed", transition.TotalGas(),
+				"txs", len(writer.included),
+			)
+		}
+	}
+
+	// get transaction queue ordered by the configured policy
+	writer.write(i.orderingPolicy().Order(pendingTxs, baseFee), gasLimit)
+
+	i.logger.Info("executed txns",
+		"successful", len(writer.included),
+		"shouldDropTxs", len(writer.dropped),
+		"shouldDemoteTxs", len(writer.demoted),
+	)
+
+	return writer.included, writer.dropped, writer.demoted
+}
+
+// copyPendingTxs copies the transaction lists of the accounts, which the ordering takes over
+func copyPendingTxs(pendingTxs map[types.Address][]*types.Transaction) map[types.Address][]*types.Transaction {
+	copied := make(map[types.Address][]*types.Transaction, len(pendingTxs))
+
+	for addr, txs := range pendingTxs {
+		copied[addr] = append([]*types.Transaction{}, txs...)
+	}
+
+	return copied
+}
+
+// txsWriter writes the transactions of the ordered queues to the transition, and keeps
+// track of the ones included, and of the ones whose accounts should be dropped or demoted
+type txsWriter struct {
+	logger     hclog.Logger
+	transition transitionInterface
+	gasLimit   uint64
+	sizeLimit  uint64
+	minGas     uint64
+
+	// encoded size of the included transactions
+	bodySize uint64
+
+	included []*types.Transaction
+	dropped  []*types.Transaction
+	demoted  []*demoteTransaction
+}
+
+// write writes the transactions of the queue while the gas used by the transition stays within the limit,
+// the block gas limit but for the priority lane
+func (w *txsWriter) write(priceTxs consensus.TxIterator, limit uint64) {
 	for {
 		tx := priceTxs.Peek()
 		if tx == nil {
-			i.logger.Debug("no more transactions")
+			w.logger.Debug("no more transactions")
+
+			break
+		}
+
+		if limit-w.transition.TotalGas() < w.minGas {
+			// none of the pending transactions could fit in the remaining gas
+			w.logger.Debug("Not enough gas for any pending transaction", "minGas", w.minGas)
 
 			break
 		}
 
-		if tx.ExceedsBlockGasLimit(gasLimit) {
+		if w.sizeLimit > 0 && w.bodySize+tx.Size() > w.sizeLimit {
+			// Ignore transaction when the block body has no room for it
+			w.logger.Debug("Size limit exceeded for current block", "from", tx.From, "size", tx.Size())
+			priceTxs.Pop()
+
+			continue
+		}
+
+		if tx.ExceedsBlockGasLimit(w.gasLimit) {
 			// the account transactions should be dropped
-			shouldDropTxs = append(shouldDropTxs, tx)
+			w.dropped = append(w.dropped, tx)
 			// The address is punished. For current loop, it would not include its transactions any more.
 			priceTxs.Pop()
 			// write failed receipts
-			if err := transition.WriteFailedReceipt(tx); err != nil {
-				i.logger.Error("write receipt failed", "err", err)
+			if err := w.transition.WriteFailedReceipt(tx); err != nil {
+				w.logger.Error("write receipt failed", "err", err)
 			}
 
 			continue
 		}
 
-		if err := transition.Write(tx); err != nil {
+		if limit < w.gasLimit && w.transition.TotalGas()+tx.Gas > limit {
+			// Ignore transaction when the lane has no room for it, it competes for the rest of the block
+			w.logger.Debug("Gas limit exceeded for priority lane", "from", tx.From)
+			priceTxs.Pop()
+
+			continue
+		}
+
+		if err := w.transition.Write(tx); err != nil {
 			//nolint:errorlint
 			if _, ok := err.(*state.AllGasUsedError); ok {
 				// no more transaction could be packed
-				i.logger.Debug("Not enough gas for further transactions")
+				w.logger.Debug("Not enough gas for further transactions")
 
 				break
 			} else if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok {
 				// Ignore transaction when the free gas not enough
-				i.logger.Debug("Gas limit exceeded for current block", "from", tx.From)
+				w.logger.Debug("Gas limit exceeded for current block", "from", tx.From)
 				priceTxs.Pop()
 			} else if nonceErr, ok := err.(*state.NonceTooLowError); ok {
 				// low nonce tx, should reset accounts once done
-				i.logger.Warn("write transaction nonce too low",
+				w.logger.Warn("write transaction nonce too low",
 					"hash", tx.Hash, "from", tx.From, "nonce", tx.Nonce)
 				// skip the address, whose txs should be reset first.
-				shouldDemoteTxs = append(shouldDemoteTxs, &demoteTransaction{tx, nonceErr.CorrectNonce})
+				w.demoted = append(w.demoted, &demoteTransaction{tx, nonceErr.CorrectNonce})
 				// priceTxs.Shift()
 				priceTxs.Pop()
 			} else if nonceErr, ok := err.(*state.NonceTooHighError); ok {
 				// high nonce tx, should reset accounts once done
-				i.logger.Error("write miss some transactions with higher nonce",
+				w.logger.Error("write miss some transactions with higher nonce",
 					tx.Hash, "from", tx.From, "nonce", tx.Nonce)
-				shouldDemoteTxs = append(shouldDemoteTxs, &demoteTransaction{tx, nonceErr.CorrectNonce})
+				w.demoted = append(w.demoted, &demoteTransaction{tx, nonceErr.CorrectNonce})
+				priceTxs.Pop()
+			} else if errors.Is(err, state.ErrFeeCapTooLow) {
+				// the fee cap is below the base fee, kept in the pool until the base fee falls
+				w.logger.Debug("Fee cap too low for current block", "hash", tx.Hash, "from", tx.From)
 				priceTxs.Pop()
 			} else {
 				// no matter what kind of failure, drop is reasonable for not executed it yet
-				i.logger.Debug("write not executed transaction failed",
+				w.logger.Debug("write not executed transaction failed",
 					"hash", tx.Hash, "from", tx.From,
 					"nonce", tx.Nonce, "err", err)
-				shouldDropTxs = append(shouldDropTxs, tx)
+				w.dropped = append(w.dropped, tx)
 				priceTxs.Pop()
 			}
 
@@ -776,16 +1201,80 @@ func (i *Ibft) writeTransactions(
 		// no errors, go on
 		priceTxs.Shift()
 
-		includedTransactions = append(includedTransactions, tx)
+		w.included = append(w.included, tx)
+		w.bodySize += tx.Size()
 	}
+}
 
-	i.logger.Info("executed txns",
-		"successful", len(includedTransactions),
-		"shouldDropTxs", len(shouldDropTxs),
-		"shouldDemoteTxs", len(shouldDemoteTxs),
+// leftovers returns the transactions of the accounts not written yet,
+// but the ones of the accounts to drop or demote
+func (w *txsWriter) leftovers(pendingTxs map[types.Address][]*types.Transaction) map[types.Address][]*types.Transaction {
+	written := make(map[types.Address]int)
+	for _, tx := range w.included {
+		written[tx.From]++
+	}
+
+	skipped := make(map[types.Address]struct{})
+	for _, tx := range w.dropped {
+		skipped[tx.From] = struct{}{}
+	}
+
+	for _, tx := range w.demoted {
+		skipped[tx.Tx.From] = struct{}{}
+	}
+
+	leftovers := make(map[types.Address][]*types.Transaction)
+
+	for addr, txs := range pendingTxs {
+		if _, ok := skipped[addr]; ok || written[addr] >= len(txs) {
+			continue
+		}
+
+		leftovers[addr] = txs[written[addr]:]
+	}
+
+	return leftovers
+}
+
+// prewarmTransactions loads the accounts the pending transactions are known to touch
+// into the transition, and returns the minimum gas any of them uses, according to
+// the execution hints cached by the txpool. It is 0 if any of them has no hint.
+func (i *Ibft) prewarmTransactions(
+	pendingTxs map[types.Address][]*types.Transaction,
+	transition transitionInterface,
+) uint64 {
+	var (
+		minGas   uint64 = math.MaxUint64
+		accounts        = make([]types.Address, 0, len(pendingTxs))
+		seen            = make(map[types.Address]struct{}, len(pendingTxs))
 	)
 
-	return
+	for _, txs := range pendingTxs {
+		for _, tx := range txs {
+			hint, ok := i.txpool.ExecutionHint(tx.Hash)
+			if !ok {
+				// nothing is known about it, leave it to the executor
+				minGas = 0
+
+				continue
+			}
+
+			if hint.Gas < minGas {
+				minGas = hint.Gas
+			}
+
+			for _, addr := range hint.Accounts {
+				if _, ok := seen[addr]; !ok {
+					seen[addr] = struct{}{}
+					accounts = append(accounts, addr)
+				}
+			}
+		}
+	}
+
+	transition.Prewarm(accounts)
+
+	return minGas
 }
 
 // runAcceptState runs the Accept state loop
@@ -854,8 +1343,23 @@ func (i *Ibft) runAcceptState() { // start new round
 		logger.Info("we are the proposer", "block", number)
 
 		if !i.state.locked {
+			if i.suppressesEmptyBlocks(number) && !i.waitForTransactions(parent) {
+				// closing
+				return
+			}
+
 			// since the state is not locked, we need to build a new block
 			i.state.block, err = i.buildBlock(snap, parent)
+			if err == nil && i.isEarlyEmptyBlock(parent, i.state.block) {
+				// none of the pending transactions made it into the block,
+				// so that only a heartbeat block could be proposed
+				if !i.waitUntil(i.idleDeadline(parent)) {
+					return
+				}
+
+				i.state.block, err = i.buildBlock(snap, parent)
+			}
+
 			if err != nil {
 				i.logger.Error("failed to build block", "err", err)
 				i.setState(RoundChangeState)
@@ -892,7 +1396,13 @@ func (i *Ibft) runAcceptState() { // start new round
 	// we are NOT a proposer for the block. Then, we have to wait
 	// for a pre-prepare message from the proposer
 
-	timeout := exponentialTimeout(i.state.view.Round)
+	timeout := i.getRoundTimeout()
+	if i.suppressesEmptyBlocks(number) {
+		// the proposer may wait for transactions until the idle deadline
+		if idle := time.Until(i.idleDeadline(parent)); idle > 0 {
+			timeout += idle
+		}
+	}
 	for i.getState() == AcceptState {
 		msg, ok := i.getNextMessage(timeout)
 		if !ok {
@@ -953,7 +1463,15 @@ func (i *Ibft) runAcceptState() { // start new round
 				continue
 			}
 
-			// Verify other block params
+			// proposal-time rule only, not part of the header verification
+			if i.isEarlyEmptyBlock(parent, block) {
+				i.logger.Error("empty block proposed before the idle deadline", "timestamp", block.Header.Timestamp)
+				i.handleStateErr(errBlockVerificationFailed)
+
+				continue
+			}
+
+			// Verify other block params, executing the block to compare the state and receipts roots
 			if err := i.blockchain.VerifyPotentialBlock(block); err != nil {
 				i.logger.Error("block verification failed", "err", err)
 				i.handleStateErr(errBlockVerificationFailed)
@@ -999,7 +1517,7 @@ func (i *Ibft) runValidateState() {
 		}
 	}
 
-	timeout := exponentialTimeout(i.state.view.Round)
+	timeout := i.getRoundTimeout()
 	for i.getState() == ValidateState {
 		msg, ok := i.getNextMessage(timeout)
 		if !ok {
@@ -1038,6 +1556,13 @@ func (i *Ibft) runValidateState() {
 			i.state.addPrepared(msg)
 
 		case proto.MessageReq_Commit:
+			// a single bad seal would fail the insertion of the block
+			if err := verifyCommittedSeal(msg, i.state.block.Header); err != nil {
+				i.logger.Error("invalid committed seal", "from", msg.From, "err", err)
+
+				continue
+			}
+
 			i.state.addCommitted(msg)
 
 		default:
@@ -1144,6 +1669,9 @@ func (i *Ibft) insertBlock(block *types.Block) error {
 		return hookErr
 	}
 
+	i.recordUptime(header)
+	i.notifyEarnings()
+
 	i.logger.Info(
 		"block committed",
 		"sequence", i.state.view.Sequence,
@@ -1153,6 +1681,8 @@ func (i *Ibft) insertBlock(block *types.Block) error {
 		"committed", i.state.numCommitted(),
 	)
 
+	i.metrics.CommitRound.Observe(float64(i.state.view.Round))
+
 	// broadcast the new block
 	i.syncer.Broadcast(block)
 
@@ -1185,6 +1715,8 @@ func (i *Ibft) runRoundChangeState() {
 		i.state.cleanRound(round)
 		// send the round change message
 		i.sendRoundChange()
+		// persist the new round, so that it is rejoined after a restart
+		i.saveRound()
 	}
 	sendNextRoundChange := func() {
 		sendRoundChange(i.state.view.Round + 1)
@@ -1229,7 +1761,7 @@ func (i *Ibft) runRoundChangeState() {
 	}
 
 	// create a timer for the round change
-	timeout := exponentialTimeout(i.state.view.Round)
+	timeout := i.getRoundTimeout()
 	for i.getState() == RoundChangeState {
 		msg, ok := i.getNextMessage(timeout)
 		if !ok {
@@ -1241,7 +1773,7 @@ func (i *Ibft) runRoundChangeState() {
 			i.logger.Debug("round change timeout")
 			checkTimeout()
 			// update the timeout duration
-			timeout = exponentialTimeout(i.state.view.Round)
+			timeout = i.getRoundTimeout()
 
 			continue
 		}
@@ -1256,15 +1788,20 @@ func (i *Ibft) runRoundChangeState() {
 		// we only expect RoundChange messages right now
 		num := i.state.AddRoundMessage(msg)
 
+		if msg.View.Round == i.state.view.Round {
+			i.saveRound()
+		}
+
 		if num == i.state.NumValid() {
 			// start a new round immediately
 			i.startNewRound(msg.View.Round)
+			i.saveRound()
 			i.setState(AcceptState)
 		} else if num == i.state.validators.MaxFaultyNodes()+1 {
 			// weak certificate, try to catch up if our round number is smaller
 			if i.state.view.Round < msg.View.Round {
 				// update timer
-				timeout = exponentialTimeout(i.state.view.Round)
+				timeout = i.getRoundTimeout()
 				sendRoundChange(msg.View.Round)
 			}
 		}
@@ -1331,7 +1868,11 @@ func (i *Ibft) gossip(typ proto.MessageReq_Type) {
 
 	if err := i.transport.Gossip(msg); err != nil {
 		i.logger.Error("failed to gossip", "err", err)
+
+		return
 	}
+
+	i.metrics.MessagesSent.With("type", typ.String()).Add(1)
 }
 
 // getState returns the current IBFT state
@@ -1348,6 +1889,7 @@ func (i *Ibft) isState(s IbftState) bool {
 func (i *Ibft) setState(s IbftState) {
 	i.logger.Info("state change", "new", s)
 	i.state.setState(s)
+	i.status.observe(i.state)
 }
 
 // forceTimeout sets the forceTimeoutCh flag to true
@@ -1355,6 +1897,15 @@ func (i *Ibft) forceTimeout() {
 	i.forceTimeoutCh = true
 }
 
+// getRoundTimeout returns the timeout of the current round
+func (i *Ibft) getRoundTimeout() time.Duration {
+	if i.roundTimeout != nil {
+		return i.roundTimeout(i.state.view.Round)
+	}
+
+	return exponentialTimeout(i.state.view.Round)
+}
+
 // isSealing checks if the current node is sealing blocks
 func (i *Ibft) isSealing() bool {
 	return i.sealing
@@ -1394,6 +1945,18 @@ func (i *Ibft) verifyHeaderImpl(snap *Snapshot, parent, header *types.Header) er
 		return err
 	}
 
+	// verify the fee recipient
+	if i.isFeeRecipientBlock(header.Number) {
+		signer, err := ecrecoverFromHeader(header)
+		if err != nil {
+			return err
+		}
+
+		if err := i.verifyFeeRecipient(parent, signer, header.Miner); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1429,11 +1992,27 @@ func (i *Ibft) ProcessHeaders(headers []*types.Header) error {
 	return i.processHeaders(headers)
 }
 
-// GetBlockCreator retrieves the block signer from the extra data field
+// GetBlockCreator retrieves the block signer from the extra data field,
+// or the fee recipient in the miner field if the block has one
 func (i *Ibft) GetBlockCreator(header *types.Header) (types.Address, error) {
+	if i.isFeeRecipientBlock(header.Number) && header.Miner != types.ZeroAddress {
+		return header.Miner, nil
+	}
+
 	return ecrecoverFromHeader(header)
 }
 
+// CommittedSeals returns the number of committed seals in the extra data field,
+// which have been verified before the block was written
+func (i *Ibft) CommittedSeals(header *types.Header) (int, error) {
+	extra, err := getIbftExtra(header)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(extra.CommittedSeal), nil
+}
+
 // PreStateCommit a hook to be called before finalizing state transition on inserting block
 func (i *Ibft) PreStateCommit(header *types.Header, txn *state.Transition) error {
 	params := &preStateCommitHookParams{
@@ -1444,6 +2023,37 @@ func (i *Ibft) PreStateCommit(header *types.Header, txn *state.Transition) error
 		return hookErr
 	}
 
+	if forks := i.config.Params.Forks; forks != nil && forks.IsGovernance(header.Number) {
+		if err := i.tallyGovernance(header, txn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tallyGovernance enacts the governed parameters voted for by a quorum of the
+// validators of the parent block, so that every node picks them up at the same block
+func (i *Ibft) tallyGovernance(header *types.Header, txn *state.Transition) error {
+	snap, err := i.getSnapshot(header.Number - 1)
+	if err != nil {
+		return err
+	}
+
+	if snap == nil {
+		return fmt.Errorf("cannot find snapshot at %d", header.Number-1)
+	}
+
+	quorum := 2*snap.Set.MaxFaultyNodes() + 1
+
+	for _, change := range governance.Tally(txn.Txn(), snap.Set, quorum) {
+		i.logger.Info("governance parameter enacted",
+			"block", header.Number,
+			"param", change.Param.String(),
+			"value", change.Value,
+		)
+	}
+
 	return nil
 }
 
@@ -1479,10 +2089,22 @@ func (i *Ibft) Close() error {
 		if err != nil {
 			return err
 		}
+
+		if i.earnings != nil {
+			if err := i.earnings.saveToPath(i.config.Path); err != nil {
+				return err
+			}
+		}
+
+		i.flushRound()
 	}
 
 	i.transport.Close()
 
+	if i.discovery != nil {
+		i.discovery.topic.Close()
+	}
+
 	return nil
 }
 
@@ -1518,6 +2140,8 @@ func (i *Ibft) getNextMessage(timeout time.Duration) (*proto.MessageReq, bool) {
 
 // pushMessage pushes a new message to the message queue
 func (i *Ibft) pushMessage(msg *proto.MessageReq) {
+	i.status.recordMessage(msg)
+
 	task := &msgTask{
 		view: msg.View,
 		msg:  protoTypeToMsg(msg.Type),
@@ -1539,6 +2163,8 @@ func (i *Ibft) startNewSequence() {
 		Sequence: header.Number + 1,
 		Round:    0,
 	}
+
+	i.restoreRound()
 }
 
 // startNewRound changes the round in the view of state
@@ -4,18 +4,25 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/chain"
 	"github.com/dogechain-lab/dogechain/consensus"
 	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
+	bridgeHelper "github.com/dogechain-lab/dogechain/helper/bridge"
 	"github.com/dogechain-lab/dogechain/helper/common"
 	"github.com/dogechain-lab/dogechain/helper/hex"
 	"github.com/dogechain-lab/dogechain/helper/progress"
 	"github.com/dogechain-lab/dogechain/protocol"
 	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/txpool"
 	"github.com/dogechain-lab/dogechain/types"
+	"github.com/go-kit/kit/metrics"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
@@ -39,6 +46,8 @@ type MockBlockchain struct {
 	WriteBlockHandler           func(*types.Block) error
 	VerifyPotentialBlockHandler func(block *types.Block) error
 	CalculateGasLimitHandler    func(number uint64) (uint64, error)
+	GetReceiptsByHashHandler    func(hash types.Hash) ([]*types.Receipt, error)
+	GetBodyByHashHandler        func(hash types.Hash) (*types.Body, bool)
 }
 
 func (m *MockBlockchain) Header() *types.Header {
@@ -91,6 +100,26 @@ func (m *MockBlockchain) CalculateGasLimit(number uint64) (uint64, error) {
 	return m.CalculateGasLimitHandler(number)
 }
 
+func (m *MockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	m.t.Helper()
+
+	if m.GetReceiptsByHashHandler == nil {
+		m.errorByUndefinedMethod("GetReceiptsByHash")
+	}
+
+	return m.GetReceiptsByHashHandler(hash)
+}
+
+func (m *MockBlockchain) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
+	m.t.Helper()
+
+	if m.GetBodyByHashHandler == nil {
+		m.errorByUndefinedMethod("GetBodyByHash")
+	}
+
+	return m.GetBodyByHashHandler(hash)
+}
+
 // helper method
 func (m *MockBlockchain) SetGenesis(validators []types.Address) *types.Block {
 	m.t.Helper()
@@ -197,6 +226,20 @@ func (m *MockBlockchain) calculateGasLimit(number uint64) (uint64, error) {
 	return defaultBlockGasLimit, nil
 }
 
+func (m *MockBlockchain) getReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return []*types.Receipt{}, nil
+}
+
+func (m *MockBlockchain) getBodyByHash(hash types.Hash) (*types.Body, bool) {
+	for _, block := range m.blocks {
+		if block.Hash() == hash {
+			return block.Body(), true
+		}
+	}
+
+	return nil, false
+}
+
 // interface check
 var _ blockchainInterface = (*MockBlockchain)(nil)
 
@@ -215,6 +258,8 @@ func NewMockBlockchain(t *testing.T) *MockBlockchain {
 	m.WriteBlockHandler = m.writeBlock
 	m.VerifyPotentialBlockHandler = m.verifyPotentialBlock
 	m.CalculateGasLimitHandler = m.calculateGasLimit
+	m.GetReceiptsByHashHandler = m.getReceiptsByHash
+	m.GetBodyByHashHandler = m.getBodyByHash
 
 	return m
 }
@@ -620,6 +665,62 @@ func TestTransition_RoundChangeState_Timeout(t *testing.T) {
 	})
 }
 
+func TestTransition_RoundChangeState_Metrics(t *testing.T) {
+	m := newMockIbft(t, []string{"A", "B", "C", "D"}, "A")
+
+	sent := newMockCounter()
+	stateDuration := newMockHistogram()
+	m.metrics.MessagesSent = sent
+	m.metrics.StateDuration = stateDuration
+
+	m.forceTimeout()
+	m.setState(RoundChangeState)
+	m.Close()
+
+	m.runCycle()
+
+	// both round change messages are counted
+	assert.Equal(t, map[string]float64{"type=RoundChange": 2}, sent.values)
+	// the cycle is observed once
+	assert.Equal(t, map[string]int{"state=RoundChangeState": 1}, stateDuration.observations)
+}
+
+// mockCounter records the additions by label values
+type mockCounter struct {
+	values map[string]float64
+	labels string
+}
+
+func newMockCounter() *mockCounter {
+	return &mockCounter{values: map[string]float64{}}
+}
+
+func (c *mockCounter) With(labelValues ...string) metrics.Counter {
+	return &mockCounter{values: c.values, labels: c.labels + strings.Join(labelValues, "=")}
+}
+
+func (c *mockCounter) Add(delta float64) {
+	c.values[c.labels] += delta
+}
+
+// mockHistogram records the number of observations by label values
+type mockHistogram struct {
+	observations map[string]int
+	labels       string
+}
+
+func newMockHistogram() *mockHistogram {
+	return &mockHistogram{observations: map[string]int{}}
+}
+
+func (h *mockHistogram) With(labelValues ...string) metrics.Histogram {
+	return &mockHistogram{observations: h.observations, labels: h.labels + strings.Join(labelValues, "=")}
+}
+
+func (h *mockHistogram) Observe(float64) {
+	h.observations[h.labels]++
+}
+
 func TestTransition_RoundChangeState_WeakCertificate(t *testing.T) {
 	m := newMockIbft(t, []string{"A", "B", "C", "D", "E", "F", "G"}, "A")
 	m.setState(RoundChangeState)
@@ -723,6 +824,9 @@ func TestIBFT_WriteTransactions(t *testing.T) {
 		failedTxnsIndexes           []int
 		notExecutableTxnsIndexes    int
 		gasLimitReachedTxnIndex     int
+		feeCapTooLowTxnsIndexes     []int
+		sizeLimit                   uint64
+		hintGas                     uint64
 		expectedIncludedTxnsCount   int
 		expectedFailReceiptsWritten int
 		expectedDropTxnsCount       int
@@ -735,7 +839,9 @@ func TestIBFT_WriteTransactions(t *testing.T) {
 	}
 
 	setupMockTransition := func(test testCase, mockTxPool *mockTxPool) *mockTransition {
-		mockTransition := &mockTransition{}
+		mockTransition := &mockTransition{
+			gasPerTxn: test.params.hintGas,
+		}
 		for _, i := range test.params.failedTxnsIndexes {
 			mockTransition.failReceiptsWritten = append(
 				mockTransition.failReceiptsWritten,
@@ -754,6 +860,13 @@ func TestIBFT_WriteTransactions(t *testing.T) {
 			mockTransition.gasLimitReachedTransaction = mockTxPool.transactions[test.params.gasLimitReachedTxnIndex]
 		}
 
+		for _, i := range test.params.feeCapTooLowTxnsIndexes {
+			mockTransition.feeCapTooLowTransactions = append(
+				mockTransition.feeCapTooLowTransactions,
+				mockTxPool.transactions[i],
+			)
+		}
+
 		return mockTransition
 	}
 
@@ -824,25 +937,203 @@ func TestIBFT_WriteTransactions(t *testing.T) {
 				expectedDemoteTxnsCount:     0,
 			},
 		},
+		{
+			"transaction whose fee cap is below the base fee is skipped, not dropped",
+			testParams{
+				txns: []*types.Transaction{
+					{Nonce: 1},
+					{Nonce: 2}, // fee cap too low, kept in the pool
+					{Nonce: 3},
+				},
+				notExecutableTxnsIndexes:    -1,
+				gasLimitReachedTxnIndex:     -1,
+				feeCapTooLowTxnsIndexes:     []int{1},
+				expectedIncludedTxnsCount:   1, // nonce 1
+				expectedFailReceiptsWritten: 0,
+				expectedDropTxnsCount:       0,
+				expectedDemoteTxnsCount:     0,
+			},
+		},
+		{
+			"transaction exceeding block body size limit is not included",
+			testParams{
+				txns: []*types.Transaction{
+					{Nonce: 1, Input: make([]byte, 100)},
+					{Nonce: 2, Input: make([]byte, 100)}, // no room left, ignored
+					{Nonce: 3},
+				},
+				notExecutableTxnsIndexes:    -1,
+				gasLimitReachedTxnIndex:     -1,
+				sizeLimit:                   150,
+				expectedIncludedTxnsCount:   1, // nonce 1
+				expectedFailReceiptsWritten: 0,
+				expectedDropTxnsCount:       0,
+				expectedDemoteTxnsCount:     0,
+			},
+		},
+		{
+			"packing stops once the hinted gas could not fit anymore",
+			testParams{
+				txns: []*types.Transaction{
+					{Nonce: 1},
+					{Nonce: 2},
+					{Nonce: 3}, // 200 gas left, not even executed
+				},
+				notExecutableTxnsIndexes:    -1,
+				gasLimitReachedTxnIndex:     -1,
+				hintGas:                     400,
+				expectedIncludedTxnsCount:   2, // nonce 1, 2
+				expectedFailReceiptsWritten: 0,
+				expectedDropTxnsCount:       0,
+				expectedDemoteTxnsCount:     0,
+			},
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
 			m := newMockIbft(t, []string{"A", "B", "C"}, "A")
 			mockTxPool := newMockTxPool(test.params.txns)
+			if test.params.hintGas > 0 {
+				mockTxPool.hints = make(map[types.Hash]*txpool.ExecutionHint)
+				for _, tx := range test.params.txns {
+					mockTxPool.hints[tx.Hash] = &txpool.ExecutionHint{
+						Gas:      test.params.hintGas,
+						Accounts: []types.Address{tx.From},
+					}
+				}
+			}
+
 			m.txpool = mockTxPool
 			mockTransition := setupMockTransition(test, mockTxPool)
 
-			included, shouldDropTxs, shouldDemoteTxs := m.writeTransactions(1000, mockTransition)
+			included, shouldDropTxs, shouldDemoteTxs := m.writeTransactions(1000, test.params.sizeLimit, 0, nil, mockTransition)
 
 			assert.Equal(t, test.params.expectedIncludedTxnsCount, len(included))
 			assert.Equal(t, test.params.expectedFailReceiptsWritten, len(mockTransition.failReceiptsWritten))
 			assert.Equal(t, test.params.expectedDropTxnsCount, len(shouldDropTxs))
 			assert.Equal(t, test.params.expectedDemoteTxnsCount, len(shouldDemoteTxs))
+
+			if test.params.hintGas > 0 {
+				// the sender of the hinted transactions is warmed once
+				assert.Len(t, mockTransition.prewarmed, 1)
+			}
 		})
 	}
 }
 
+func TestIBFT_WriteTransactions_PriorityLane(t *testing.T) {
+	var (
+		caller  = types.StringToAddress("0x1")
+		spammer = types.StringToAddress("0x2")
+		txs     []*types.Transaction
+	)
+
+	// the spammer outbids the caller for the whole block
+	for nonce := uint64(0); nonce < 10; nonce++ {
+		txs = append(txs, &types.Transaction{From: spammer, Nonce: nonce, Gas: 100, GasPrice: big.NewInt(10)})
+	}
+
+	for nonce := uint64(0); nonce < 5; nonce++ {
+		txs = append(txs, &types.Transaction{From: caller, Nonce: nonce, Gas: 100, GasPrice: big.NewInt(1)})
+	}
+
+	lane := &chain.PriorityLane{
+		ReservedGasPercent: 30,
+		Callers:            []types.Address{caller},
+	}
+
+	countFrom := func(txs []*types.Transaction, from types.Address) int {
+		count := 0
+
+		for _, tx := range txs {
+			if tx.From == from {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	writeTransactions := func(lane *chain.PriorityLane) []*types.Transaction {
+		m := newMockIbft(t, []string{"A", "B", "C"}, "A")
+		mockTxPool := newMockTxPool(txs)
+		// the block holds 10 transactions
+		mockTxPool.hints = map[types.Hash]*txpool.ExecutionHint{
+			types.ZeroHash: {Gas: 100},
+		}
+		m.txpool = mockTxPool
+
+		included, _, _ := m.writeTransactions(1000, 0, 0, lane, &mockTransition{gasPerTxn: 100})
+
+		return included
+	}
+
+	// crowded out without a lane
+	included := writeTransactions(nil)
+	assert.Len(t, included, 10)
+	assert.Equal(t, 0, countFrom(included, caller))
+
+	// the lane is written first, up to the reserved gas
+	included = writeTransactions(lane)
+	assert.Len(t, included, 10)
+	assert.Equal(t, 3, countFrom(included, caller))
+	assert.Equal(t, 3, countFrom(included[:3], caller))
+
+	// the gas the lane leaves is used by the other transactions
+	lane.Callers = []types.Address{types.StringToAddress("0x3")}
+	included = writeTransactions(lane)
+	assert.Len(t, included, 10)
+	assert.Equal(t, 10, countFrom(included, spammer))
+}
+
+// bridgeState is the storage of the bridge contract
+type bridgeState map[types.Hash]types.Hash
+
+func (s bridgeState) GetState(addr types.Address, key types.Hash) types.Hash {
+	if addr != systemcontracts.AddrBridgeContract {
+		return types.ZeroHash
+	}
+
+	return s[key]
+}
+
+func TestIBFT_PriorityLane(t *testing.T) {
+	var (
+		signer = types.StringToAddress("0x1")
+		owner  = types.StringToAddress("0x2")
+	)
+
+	account, err := bridgeHelper.PredeployBridgeSC(bridgeHelper.PredeployParams{
+		Owner:   types.StringToAddress("0x100"),
+		Signers: []types.Address{signer},
+	})
+	assert.NoError(t, err)
+
+	m := newMockIbft(t, []string{"A", "B", "C"}, "A")
+
+	// no lane configured
+	m.config.Params = &chain.Params{}
+	assert.Nil(t, m.priorityLane(bridgeState(account.Storage)))
+
+	m.config.Params = &chain.Params{
+		PriorityLane: &chain.PriorityLane{
+			ReservedGasPercent: 30,
+			Callers:            []types.Address{owner},
+		},
+	}
+
+	// the signers are read from the state the block is built on
+	lane := m.priorityLane(bridgeState(account.Storage))
+	assert.Equal(t, []types.Address{signer, owner}, lane.Callers)
+	assert.Equal(t, uint64(300), lane.ReservedGas(1000))
+
+	// a signer removed from the bridge leaves the lane
+	lane = m.priorityLane(bridgeState{})
+	assert.Equal(t, []types.Address{owner}, lane.Callers)
+	assert.False(t, lane.IsCaller(types.StringToAddress("0x3")))
+}
+
 func TestRunSyncState_NewHeadReceivedFromPeer_CallsTxPoolResetWithHeaders(t *testing.T) {
 	m := newMockIbft(t, []string{"A", "B", "C"}, "A")
 	m.setState(SyncState)
@@ -948,6 +1239,7 @@ type mockSyncer struct {
 	broadcastedBlock        *types.Block
 	broadcastCalled         bool
 	blockchain              blockchainInterface
+	peerScores              []*protocol.PeerScore
 }
 
 func newMockSyncer(
@@ -1011,12 +1303,17 @@ func (s *mockSyncer) Broadcast(b *types.Block) {
 	s.broadcastedBlock = b
 }
 
+func (s *mockSyncer) PeerScores() []*protocol.PeerScore {
+	return s.peerScores
+}
+
 type mockTxPool struct {
 	transactions          []*types.Transaction
 	demoted               []*types.Transaction
 	nonceDecreased        map[*types.Transaction]bool
 	resetWithHeaderCalled bool
 	resetWithHeadersParam []*types.Header
+	hints                 map[types.Hash]*txpool.ExecutionHint
 }
 
 func newMockTxPool(txs []*types.Transaction) *mockTxPool {
@@ -1061,11 +1358,33 @@ func (p *mockTxPool) Pending() map[types.Address][]*types.Transaction {
 	return txs
 }
 
+func (p *mockTxPool) ExecutionHint(hash types.Hash) (*txpool.ExecutionHint, bool) {
+	hint, ok := p.hints[hash]
+
+	return hint, ok
+}
+
+func (p *mockTxPool) Length() uint64 {
+	return uint64(len(p.transactions))
+}
+
 type mockTransition struct {
 	failReceiptsWritten        []*types.Transaction
 	shouldDroppedTransactions  []*types.Transaction
 	successReceiptsWritten     []*types.Transaction
 	gasLimitReachedTransaction *types.Transaction
+	feeCapTooLowTransactions   []*types.Transaction
+	gasPerTxn                  uint64
+	totalGas                   uint64
+	prewarmed                  []types.Address
+}
+
+func (t *mockTransition) TotalGas() uint64 {
+	return t.totalGas
+}
+
+func (t *mockTransition) Prewarm(addrs []types.Address) {
+	t.prewarmed = append(t.prewarmed, addrs...)
 }
 
 func (t *mockTransition) WriteFailedReceipt(txn *types.Transaction) error {
@@ -1085,7 +1404,14 @@ func (t *mockTransition) Write(txn *types.Transaction) error {
 		}
 	}
 
+	for _, lowTx := range t.feeCapTooLowTransactions {
+		if txn == lowTx {
+			return state.NewTransitionApplicationError(fmt.Errorf("%w, mock", state.ErrFeeCapTooLow), false)
+		}
+	}
+
 	t.successReceiptsWritten = append(t.successReceiptsWritten, txn)
+	t.totalGas += t.gasPerTxn
 
 	return nil
 }
@@ -1138,6 +1464,14 @@ func (m *mockIbft) VerifyPotentialBlock(block *types.Block) error {
 	return nil
 }
 
+func (m *mockIbft) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.blockchain.GetReceiptsByHash(hash)
+}
+
+func (m *mockIbft) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
+	return m.blockchain.GetBodyByHash(hash)
+}
+
 func (m *mockIbft) emitMsg(msg *proto.MessageReq) {
 	// convert the address from the address pool
 	from := m.pool.get(msg.From).Address()
@@ -1660,3 +1994,27 @@ func TestGetIBFTForks(t *testing.T) {
 		})
 	}
 }
+
+func TestIBFT_CheckValidatorCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		min      uint64
+		current  int
+		next     int
+		expected error
+	}{
+		{"disabled", 0, 4, 1, nil},
+		{"above minimum", 4, 5, 4, nil},
+		{"below minimum", 4, 4, 3, ErrMinValidatorCount},
+		{"growing below minimum", 4, 1, 2, nil},
+		{"unchanged below minimum", 4, 3, 3, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := &Ibft{minValidatorCount: test.min}
+
+			assert.ErrorIs(t, i.checkValidatorCount(test.current, test.next), test.expected)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package ibfttest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/consensus/ibft/simulator"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+const (
+	// DefaultRoundTimeout is the round timeout of the cluster validators,
+	// short enough for the round changes to run in unit test time
+	DefaultRoundTimeout = 500 * time.Millisecond
+	// DefaultWaitTimeout bounds the waits of the cluster
+	DefaultWaitTimeout = time.Minute
+)
+
+// Option updates the simulation config of the cluster
+type Option func(config *simulator.Config)
+
+// WithEpochSize sets the epoch size of the chain
+func WithEpochSize(size uint64) Option {
+	return func(config *simulator.Config) {
+		config.EpochSize = size
+	}
+}
+
+// WithLatency sets the bounds of the random delivery delay of the messages
+func WithLatency(minDelay, maxDelay time.Duration) Option {
+	return func(config *simulator.Config) {
+		config.MinDelay = minDelay
+		config.MaxDelay = maxDelay
+	}
+}
+
+// WithDropRate sets the probability of a message not to be delivered
+func WithDropRate(rate float64) Option {
+	return func(config *simulator.Config) {
+		config.DropRate = rate
+	}
+}
+
+// WithByzantine sets the behavior of a validator
+func WithByzantine(index int, behavior simulator.Behavior) Option {
+	return func(config *simulator.Config) {
+		config.Byzantine[index] = behavior
+	}
+}
+
+// WithRoundTimeout sets the timeout of the first round
+func WithRoundTimeout(timeout time.Duration) Option {
+	return func(config *simulator.Config) {
+		config.RoundTimeout = timeout
+	}
+}
+
+// Cluster runs real IBFT validators with in-memory chains, connected by an in-memory
+// transport with controllable latency and partitions. It is stopped on test cleanup
+type Cluster struct {
+	t   *testing.T
+	sim *simulator.Simulator
+}
+
+// NewCluster starts a cluster of n validators
+func NewCluster(t *testing.T, n int, opts ...Option) *Cluster {
+	t.Helper()
+
+	config := simulator.DefaultConfig()
+	config.Nodes = n
+	config.RoundTimeout = DefaultRoundTimeout
+	config.Seed = 1
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	sim, err := simulator.New(config)
+	if err != nil {
+		t.Fatalf("unable to create the cluster, %v", err)
+	}
+
+	t.Cleanup(sim.Stop)
+
+	if err := sim.Start(); err != nil {
+		t.Fatalf("unable to start the cluster, %v", err)
+	}
+
+	return &Cluster{
+		t:   t,
+		sim: sim,
+	}
+}
+
+// Simulator returns the underlying simulator
+func (c *Cluster) Simulator() *simulator.Simulator {
+	return c.sim
+}
+
+// WaitForHeight waits until the validators of the indexes reach the height,
+// every honest validator if no index is given. The test fails on timeout
+func (c *Cluster) WaitForHeight(height uint64, indexes ...int) {
+	c.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultWaitTimeout)
+	defer cancel()
+
+	var err error
+	if len(indexes) == 0 {
+		err = c.sim.WaitForHeight(ctx, height)
+	} else {
+		err = c.sim.WaitForNodesHeight(ctx, height, indexes...)
+	}
+
+	if err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// Partition splits the validators by the indexes of the groups,
+// the validators not in any group being isolated
+func (c *Cluster) Partition(groups ...[]int) {
+	c.t.Helper()
+
+	if err := c.sim.Partition(groups...); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// Heal removes the partitions
+func (c *Cluster) Heal() {
+	c.sim.Heal()
+}
+
+// SetLatency changes the bounds of the random delivery delay of the messages
+func (c *Cluster) SetLatency(minDelay, maxDelay time.Duration) {
+	c.t.Helper()
+
+	if err := c.sim.SetDelays(minDelay, maxDelay); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// Header returns the latest header of the validator
+func (c *Cluster) Header(index int) *types.Header {
+	return c.sim.Header(index)
+}
+
+// Height returns the latest block number of the validator
+func (c *Cluster) Height(index int) uint64 {
+	return c.sim.Header(index).Number
+}
+
+// CheckSafety fails the test if the validators finalized different blocks at the same height
+func (c *Cluster) CheckSafety() {
+	c.t.Helper()
+
+	if err := c.sim.CheckSafety(); err != nil {
+		c.t.Fatal(err)
+	}
+}
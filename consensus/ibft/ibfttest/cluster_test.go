@@ -0,0 +1,66 @@
+package ibfttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCluster_CatchUpAfterPartition(t *testing.T) {
+	t.Parallel()
+
+	cluster := NewCluster(t, 4)
+
+	cluster.WaitForHeight(2)
+
+	// the majority keeps sealing blocks without the isolated validator
+	cluster.Partition([]int{0, 1, 2})
+
+	target := cluster.Height(0) + 3
+	cluster.WaitForHeight(target, 0, 1, 2)
+
+	// the isolated validator catches up once the partition heals
+	cluster.Heal()
+	cluster.WaitForHeight(target)
+	cluster.CheckSafety()
+}
+
+func TestCluster_NoQuorumInPartitions(t *testing.T) {
+	t.Parallel()
+
+	cluster := NewCluster(t, 4)
+
+	cluster.WaitForHeight(1)
+
+	// none of the halves has a quorum
+	cluster.Partition([]int{0, 1}, []int{2, 3})
+
+	// let the in-flight messages settle
+	time.Sleep(2 * DefaultRoundTimeout)
+
+	stalled := cluster.Height(0)
+
+	time.Sleep(4 * DefaultRoundTimeout)
+
+	for index := 0; index < 4; index++ {
+		assert.LessOrEqual(t, cluster.Height(index), stalled+1)
+	}
+
+	// the chain moves on once healed
+	cluster.Heal()
+	cluster.WaitForHeight(stalled + 2)
+	cluster.CheckSafety()
+}
+
+func TestCluster_EpochTransitions(t *testing.T) {
+	t.Parallel()
+
+	const epochSize = 3
+
+	cluster := NewCluster(t, 4, WithEpochSize(epochSize), WithLatency(0, 20*time.Millisecond))
+
+	// cross a few epoch boundaries
+	cluster.WaitForHeight(3*epochSize + 1)
+	cluster.CheckSafety()
+}
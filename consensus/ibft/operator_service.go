@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
 	"github.com/dogechain-lab/dogechain/types"
@@ -33,8 +34,10 @@ func (o *operator) getNextCandidate(snap *Snapshot) *proto.Candidate {
 	o.candidatesLock.Lock()
 	defer o.candidatesLock.Unlock()
 
+	now := time.Now()
+
 	// first, we need to remove any candidates that have already been
-	// selected as validators
+	// selected as validators or have expired
 	for i := 0; i < len(o.candidates); i++ {
 		addr := types.StringToAddress(o.candidates[i].Address)
 
@@ -44,6 +47,13 @@ func (o *operator) getNextCandidate(snap *Snapshot) *proto.Candidate {
 			i--
 		}
 
+		// Check if the candidate has expired
+		if isCandidateExpired(o.candidates[i], now) {
+			deleteFn()
+
+			continue
+		}
+
 		// Check if the candidate is already in the validator set, and wants to be added
 		if o.candidates[i].Auth && snap.Set.Includes(addr) {
 			deleteFn()
@@ -101,49 +111,118 @@ func (o *operator) GetSnapshot(ctx context.Context, req *proto.SnapshotReq) (*pr
 
 // Propose proposes a new candidate to be added / removed from the validator set
 func (o *operator) Propose(ctx context.Context, req *proto.Candidate) (*empty.Empty, error) {
-	var addr types.Address
-	if err := addr.UnmarshalText([]byte(req.Address)); err != nil {
+	snap, err := o.ibft.getLatestSnapshot()
+	if err != nil {
 		return nil, err
 	}
 
-	// check if the candidate is already there
 	o.candidatesLock.Lock()
 	defer o.candidatesLock.Unlock()
 
-	for _, c := range o.candidates {
-		if c.Address == req.Address {
-			return nil, fmt.Errorf("already a candidate")
-		}
+	if err := o.addCandidate(snap, req); err != nil {
+		return nil, err
 	}
 
+	return &empty.Empty{}, nil
+}
+
+// ProposeBatch proposes several candidates at once. Every candidate is checked
+// on its own, so a rejected one does not prevent the others from being added.
+func (o *operator) ProposeBatch(ctx context.Context, req *proto.ProposeBatchReq) (*proto.ProposeBatchResp, error) {
 	snap, err := o.ibft.getLatestSnapshot()
 	if err != nil {
 		return nil, err
 	}
+
+	o.candidatesLock.Lock()
+	defer o.candidatesLock.Unlock()
+
+	resp := &proto.ProposeBatchResp{
+		Results: make([]*proto.ProposeBatchResp_Result, 0, len(req.Candidates)),
+	}
+
+	for _, candidate := range req.Candidates {
+		result := &proto.ProposeBatchResp_Result{
+			Address: candidate.Address,
+		}
+
+		if err := o.addCandidate(snap, candidate); err != nil {
+			result.Error = err.Error()
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// addCandidate validates the candidate against the snapshot and appends it
+// to the candidates list. The caller should hold the candidates lock.
+func (o *operator) addCandidate(snap *Snapshot, req *proto.Candidate) error {
+	var addr types.Address
+	if err := addr.UnmarshalText([]byte(req.Address)); err != nil {
+		return err
+	}
+
+	if isCandidateExpired(req, time.Now()) {
+		return fmt.Errorf("the candidate has already expired")
+	}
+
+	// check if the candidate is already there
+	for _, c := range o.candidates {
+		if c.Address == req.Address {
+			return fmt.Errorf("already a candidate")
+		}
+	}
+
 	// safe checks
 	if req.Auth {
 		if snap.Set.Includes(addr) {
-			return nil, fmt.Errorf("the candidate is already a validator")
+			return fmt.Errorf("the candidate is already a validator")
 		}
 	}
 
 	if !req.Auth {
 		if !snap.Set.Includes(addr) {
-			return nil, fmt.Errorf("cannot remove a validator if they're not in the snapshot")
+			return fmt.Errorf("cannot remove a validator if they're not in the snapshot")
+		}
+
+		// the validators dropped by the pending candidates and this one
+		dropped := 1
+
+		for _, c := range o.candidates {
+			if !c.Auth {
+				dropped++
+			}
+		}
+
+		if err := o.ibft.checkValidatorCount(snap.Set.Len(), snap.Set.Len()-dropped); err != nil {
+			return err
 		}
 	}
 
 	// check if we have already voted for this candidate
+	if o.hasVoted(snap, addr) {
+		return fmt.Errorf("already voted for this address")
+	}
+
+	o.candidates = append(o.candidates, req)
+
+	return nil
+}
+
+// hasVoted checks whether the local validator has a pending vote for the address
+func (o *operator) hasVoted(snap *Snapshot, addr types.Address) bool {
 	count := snap.Count(func(v *Vote) bool {
 		return v.Address == addr && v.Validator == o.ibft.validatorKeyAddr
 	})
-	if count == 1 {
-		return nil, fmt.Errorf("already voted for this address")
-	}
 
-	o.candidates = append(o.candidates, req)
+	return count > 0
+}
 
-	return &empty.Empty{}, nil
+// isCandidateExpired checks whether the candidate expiry has passed
+func isCandidateExpired(c *proto.Candidate, now time.Time) bool {
+	return c.Expiry != 0 && c.Expiry <= uint64(now.Unix())
 }
 
 // Candidates returns the validator candidates list
@@ -159,3 +238,204 @@ func (o *operator) Candidates(ctx context.Context, req *empty.Empty) (*proto.Can
 
 	return resp, nil
 }
+
+// ListCandidates returns the validator candidates list with their expiry
+// and voting status, expired candidates are not listed
+func (o *operator) ListCandidates(ctx context.Context, req *empty.Empty) (*proto.ListCandidatesResp, error) {
+	snap, err := o.ibft.getLatestSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	o.candidatesLock.Lock()
+	defer o.candidatesLock.Unlock()
+
+	now := time.Now()
+
+	resp := &proto.ListCandidatesResp{
+		Candidates: []*proto.CandidateStatus{},
+	}
+
+	for _, c := range o.candidates {
+		if isCandidateExpired(c, now) {
+			continue
+		}
+
+		resp.Candidates = append(resp.Candidates, &proto.CandidateStatus{
+			Address: c.Address,
+			Auth:    c.Auth,
+			Expiry:  c.Expiry,
+			Voted:   o.hasVoted(snap, types.StringToAddress(c.Address)),
+		})
+	}
+
+	return resp, nil
+}
+
+// PendingValidators returns the validator set changes staged for the next epoch,
+// and the validator set once they are applied
+func (o *operator) PendingValidators(ctx context.Context, req *empty.Empty) (*proto.PendingValidatorsResp, error) {
+	pos := o.ibft.getPoSMechanism()
+	if pos == nil {
+		return nil, fmt.Errorf("PoS mechanism is not enabled")
+	}
+
+	pending, err := pos.pendingValidators()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.PendingValidatorsResp{
+		Epoch:      pending.epoch,
+		Deltas:     make([]*proto.ValidatorDelta, 0, len(pending.deltas)),
+		Validators: make([]string, 0, len(pending.validators)),
+	}
+
+	if pending.refused != nil {
+		resp.Refused = pending.refused.Error()
+	}
+
+	for _, delta := range pending.deltas {
+		resp.Deltas = append(resp.Deltas, &proto.ValidatorDelta{
+			Address: delta.Address.String(),
+			Added:   delta.Added,
+			Number:  delta.Number,
+		})
+	}
+
+	for _, addr := range pending.validators {
+		resp.Validators = append(resp.Validators, addr.String())
+	}
+
+	return resp, nil
+}
+
+// GetValidatorUptime returns the share of the blocks each validator committed a seal to,
+// over the latest epochs
+func (o *operator) GetValidatorUptime(
+	ctx context.Context,
+	req *proto.ValidatorUptimeReq,
+) (*proto.ValidatorUptimeResp, error) {
+	report, err := o.ibft.uptime.report(req.Epochs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ValidatorUptimeResp{
+		From:       report.From,
+		To:         report.To,
+		Validators: make([]*proto.ValidatorUptime, 0, len(report.Validators)),
+	}
+
+	for _, u := range report.Validators {
+		resp.Validators = append(resp.Validators, &proto.ValidatorUptime{
+			Address:    u.Address.String(),
+			Signed:     u.Signed,
+			Expected:   u.Expected,
+			Percentage: u.Percentage(),
+		})
+	}
+
+	return resp, nil
+}
+
+// GetFeeRecipient returns the address credited with the fees of the blocks the validator proposes
+func (o *operator) GetFeeRecipient(ctx context.Context, req *empty.Empty) (*proto.FeeRecipient, error) {
+	return &proto.FeeRecipient{
+		Address: o.ibft.FeeRecipient().String(),
+	}, nil
+}
+
+// SetFeeRecipient changes the address credited with the fees of the blocks the validator proposes.
+// The address must be the validator itself or its registered payout address.
+func (o *operator) SetFeeRecipient(ctx context.Context, req *proto.FeeRecipient) (*proto.FeeRecipient, error) {
+	var addr types.Address
+
+	if req.Address != "" {
+		if err := addr.UnmarshalText([]byte(req.Address)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := o.ibft.SetFeeRecipient(addr); err != nil {
+		return nil, err
+	}
+
+	return o.GetFeeRecipient(ctx, nil)
+}
+
+// GetValidatorEarnings returns the blocks proposed and the fees earned by the validator, per epoch
+func (o *operator) GetValidatorEarnings(
+	ctx context.Context,
+	req *proto.ValidatorEarningsReq,
+) (*proto.ValidatorEarningsResp, error) {
+	var addr types.Address
+	if err := addr.UnmarshalText([]byte(req.Address)); err != nil {
+		return nil, err
+	}
+
+	var fromEpoch, toEpoch *uint64
+
+	if req.HasFrom {
+		fromEpoch = &req.FromEpoch
+	}
+
+	if req.HasTo {
+		toEpoch = &req.ToEpoch
+	}
+
+	report, err := o.ibft.ValidatorEarnings(addr, fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ValidatorEarningsResp{
+		Address:   report.Validator.String(),
+		FromEpoch: report.FromEpoch,
+		ToEpoch:   report.ToEpoch,
+		Blocks:    report.Blocks,
+		Fees:      report.Fees.String(),
+		Epochs:    make([]*proto.EpochEarnings, 0, len(report.Epochs)),
+	}
+
+	for _, e := range report.Epochs {
+		resp.Epochs = append(resp.Epochs, &proto.EpochEarnings{
+			Epoch:  e.Epoch,
+			Blocks: e.Blocks,
+			Fees:   e.Fees.String(),
+		})
+	}
+
+	return resp, nil
+}
+
+// GetSyncPeerScores returns the scores of the sync peers, the highest first
+func (o *operator) GetSyncPeerScores(ctx context.Context, req *empty.Empty) (*proto.SyncPeerScoresResp, error) {
+	scores := o.ibft.syncer.PeerScores()
+
+	resp := &proto.SyncPeerScoresResp{
+		Peers: make([]*proto.SyncPeerScore, 0, len(scores)),
+	}
+
+	for _, score := range scores {
+		peerScore := &proto.SyncPeerScore{
+			Id:            score.ID.String(),
+			Score:         score.Score,
+			Requests:      score.Requests,
+			Blocks:        score.Blocks,
+			NilHeaders:    score.NilHeaders,
+			InvalidBlocks: score.InvalidBlocks,
+			Timeouts:      score.Timeouts,
+			Failures:      score.Failures,
+			Throughput:    score.Throughput,
+		}
+
+		if !score.BannedUntil.IsZero() {
+			peerScore.BannedUntil = score.BannedUntil.Unix()
+		}
+
+		resp.Peers = append(resp.Peers, peerScore)
+	}
+
+	return resp, nil
+}
@@ -3,11 +3,15 @@ package ibft
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/dogechain-lab/dogechain/blockchain"
 	"github.com/dogechain-lab/dogechain/consensus"
 	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	"github.com/dogechain-lab/dogechain/contracts/validatorset"
+	"github.com/dogechain-lab/dogechain/protocol"
 	"github.com/dogechain-lab/dogechain/types"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -125,3 +129,254 @@ func TestOperator_Propose(t *testing.T) {
 	})
 	assert.Error(t, err)
 }
+
+func TestOperator_Propose_MinValidatorCount(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B", "C", "D", "E")
+
+	ibft := &Ibft{
+		blockchain:        blockchain.TestBlockchain(t, pool.genesis()),
+		config:            &consensus.Config{},
+		epochSize:         DefaultEpochSize,
+		minValidatorCount: 4,
+	}
+	assert.NoError(t, ibft.setupSnapshot())
+
+	o := &operator{ibft: ibft}
+
+	// 4 validators are left
+	_, err := o.Propose(context.Background(), &proto.Candidate{
+		Address: pool.get("A").Address().String(),
+		Auth:    false,
+	})
+	assert.NoError(t, err)
+
+	// the pending removal is taken into account
+	_, err = o.Propose(context.Background(), &proto.Candidate{
+		Address: pool.get("B").Address().String(),
+		Auth:    false,
+	})
+	assert.ErrorIs(t, err, ErrMinValidatorCount)
+	assert.Len(t, o.candidates, 1)
+
+	// additions are not limited
+	pool.add("X")
+
+	_, err = o.Propose(context.Background(), &proto.Candidate{
+		Address: pool.get("X").Address().String(),
+		Auth:    true,
+	})
+	assert.NoError(t, err)
+}
+
+func TestOperator_ProposeBatch(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B", "C")
+
+	ibft := &Ibft{
+		blockchain: blockchain.TestBlockchain(t, pool.genesis()),
+		config:     &consensus.Config{},
+		epochSize:  DefaultEpochSize,
+	}
+	assert.NoError(t, ibft.setupSnapshot())
+
+	o := &operator{ibft: ibft}
+
+	pool.add("X", "Y")
+
+	resp, err := o.ProposeBatch(context.Background(), &proto.ProposeBatchReq{
+		Candidates: []*proto.Candidate{
+			// valid addition
+			{
+				Address: pool.get("X").Address().String(),
+				Auth:    true,
+			},
+			// cannot add a validator already in the set
+			{
+				Address: pool.get("A").Address().String(),
+				Auth:    true,
+			},
+			// already expired
+			{
+				Address: pool.get("Y").Address().String(),
+				Auth:    true,
+				Expiry:  uint64(time.Now().Add(-time.Minute).Unix()),
+			},
+			// valid removal with expiry
+			{
+				Address: pool.get("B").Address().String(),
+				Auth:    false,
+				Expiry:  uint64(time.Now().Add(time.Hour).Unix()),
+			},
+			// duplicated within the batch
+			{
+				Address: pool.get("X").Address().String(),
+				Auth:    true,
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Results, 5)
+
+	for i, accepted := range []bool{true, false, false, true, false} {
+		assert.Equal(t, accepted, resp.Results[i].Error == "", "result %d", i)
+	}
+
+	assert.Len(t, o.candidates, 2)
+}
+
+func TestOperator_ListCandidates(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B", "C")
+
+	ibft := &Ibft{
+		blockchain: blockchain.TestBlockchain(t, pool.genesis()),
+		config:     &consensus.Config{},
+		epochSize:  DefaultEpochSize,
+	}
+	assert.NoError(t, ibft.setupSnapshot())
+
+	pool.add("X", "Y")
+
+	expiry := uint64(time.Now().Add(time.Hour).Unix())
+
+	o := &operator{
+		ibft: ibft,
+		candidates: []*proto.Candidate{
+			{
+				Address: pool.get("X").Address().String(),
+				Auth:    true,
+				Expiry:  expiry,
+			},
+			{
+				Address: pool.get("Y").Address().String(),
+				Auth:    true,
+				Expiry:  uint64(time.Now().Add(-time.Minute).Unix()),
+			},
+		},
+	}
+
+	resp, err := o.ListCandidates(context.Background(), nil)
+	assert.NoError(t, err)
+
+	// the expired candidate is not listed
+	assert.Len(t, resp.Candidates, 1)
+	assert.Equal(t, pool.get("X").Address().String(), resp.Candidates[0].Address)
+	assert.Equal(t, expiry, resp.Candidates[0].Expiry)
+	assert.False(t, resp.Candidates[0].Voted)
+}
+
+func TestOperator_PendingValidators(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B", "C")
+
+	ibft := &Ibft{
+		blockchain: blockchain.TestBlockchain(t, pool.genesis()),
+		config:     &consensus.Config{},
+		epochSize:  DefaultEpochSize,
+	}
+	assert.NoError(t, ibft.setupSnapshot())
+
+	o := &operator{ibft: ibft}
+
+	// not available without PoS
+	_, err := o.PendingValidators(context.Background(), nil)
+	assert.Error(t, err)
+
+	pos := &PoSMechanism{
+		BaseConsensusMechanism: BaseConsensusMechanism{
+			mechanismType: PoS,
+			ibft:          ibft,
+		},
+	}
+	ibft.mechanisms = []ConsensusMechanism{pos}
+
+	pool.add("X")
+
+	assert.NoError(t, pos.watcher.watch(1, 1, []*types.Receipt{
+		newValidatorEventReceipt(types.ReceiptSuccess,
+			newValidatorEvent(validatorset.ValidatorAddedEventID, pool.get("X").Address()),
+			newValidatorEvent(validatorset.ValidatorDeletedEventID, pool.get("A").Address()),
+		),
+	}))
+
+	resp, err := o.PendingValidators(context.Background(), nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(1), resp.Epoch)
+	assert.Equal(t, []*proto.ValidatorDelta{
+		{Address: pool.get("X").Address().String(), Added: true, Number: 1},
+		{Address: pool.get("A").Address().String(), Added: false, Number: 1},
+	}, resp.Deltas)
+	assert.Equal(t, []string{
+		pool.get("B").Address().String(),
+		pool.get("C").Address().String(),
+		pool.get("X").Address().String(),
+	}, resp.Validators)
+	assert.Empty(t, resp.Refused)
+
+	// the removal would be refused with a higher minimum
+	ibft.minValidatorCount = 4
+
+	assert.NoError(t, pos.watcher.watch(2, 1, []*types.Receipt{
+		newValidatorEventReceipt(types.ReceiptSuccess,
+			newValidatorEvent(validatorset.ValidatorDeletedEventID, pool.get("B").Address()),
+		),
+	}))
+
+	resp, err = o.PendingValidators(context.Background(), nil)
+	assert.NoError(t, err)
+
+	assert.Len(t, resp.Deltas, 3)
+	assert.Equal(t, []string{
+		pool.get("A").Address().String(),
+		pool.get("B").Address().String(),
+		pool.get("C").Address().String(),
+	}, resp.Validators)
+	assert.Contains(t, resp.Refused, ErrMinValidatorCount.Error())
+}
+
+func TestOperator_FeeRecipient(t *testing.T) {
+	var (
+		validator = types.StringToAddress("1")
+		payout    = types.StringToAddress("2")
+	)
+
+	o := &operator{ibft: newFeeRecipientIbft(t, validator, payout)}
+
+	resp, err := o.GetFeeRecipient(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, validator.String(), resp.Address)
+
+	resp, err = o.SetFeeRecipient(context.Background(), &proto.FeeRecipient{Address: payout.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, payout.String(), resp.Address)
+
+	// the address must be valid
+	_, err = o.SetFeeRecipient(context.Background(), &proto.FeeRecipient{Address: "0x1"})
+	assert.Error(t, err)
+
+	// empty resets to the validator
+	resp, err = o.SetFeeRecipient(context.Background(), &proto.FeeRecipient{})
+	assert.NoError(t, err)
+	assert.Equal(t, validator.String(), resp.Address)
+}
+
+func TestOperator_GetSyncPeerScores(t *testing.T) {
+	bannedUntil := time.Unix(1_000_000, 0)
+
+	o := &operator{ibft: &Ibft{syncer: &mockSyncer{
+		peerScores: []*protocol.PeerScore{
+			{ID: "A", Score: 10, Requests: 10, Blocks: 200, Throughput: 50},
+			{ID: "B", Score: -50, InvalidBlocks: 2, BannedUntil: bannedUntil},
+		},
+	}}}
+
+	resp, err := o.GetSyncPeerScores(context.Background(), nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*proto.SyncPeerScore{
+		{Id: peer.ID("A").String(), Score: 10, Requests: 10, Blocks: 200, Throughput: 50},
+		{Id: peer.ID("B").String(), Score: -50, InvalidBlocks: 2, BannedUntil: bannedUntil.Unix()},
+	}, resp.Peers)
+}
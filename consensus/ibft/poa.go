@@ -184,6 +184,12 @@ func (poa *PoAMechanism) processHeadersHook(hookParam interface{}) error {
 		if authorize {
 			// add the candidate to the validators list
 			params.snap.Set.Add(params.header.Miner)
+		} else if err := poa.ibft.checkValidatorCount(
+			params.snap.Set.Len(),
+			params.snap.Set.Len()-1,
+		); err != nil {
+			// keep the validator, the chain would not be live anymore
+			poa.ibft.logger.Warn("validator removal refused", "validator", params.header.Miner, "err", err)
 		} else {
 			// remove the candidate from the validators list
 			params.snap.Set.Del(params.header.Miner)
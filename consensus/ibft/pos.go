@@ -16,6 +16,8 @@ type PoSMechanism struct {
 	BaseConsensusMechanism
 	// Params
 	ContractDeployment uint64 // The height when deploying ValidatorSet contract
+
+	watcher validatorWatcher // Stages the validator set changes of the current epoch
 }
 
 // PoSFactory initializes the required data
@@ -46,8 +48,9 @@ func (pos *PoSMechanism) IsAvailable(hookType HookType, height uint64) bool {
 		// deploy contract on ContractDeployment
 		return height == pos.ContractDeployment
 	case InsertBlockHook:
-		// update validators when the one before the beginning or the end of epoch
-		return height+1 == pos.From || pos.IsInRange(height) && pos.ibft.IsLastOfEpoch(height)
+		// watch validator events in range, and update validators
+		// when the one before the beginning or the end of epoch
+		return height+1 == pos.From || pos.IsInRange(height)
 	default:
 		return false
 	}
@@ -108,15 +111,46 @@ func (pos *PoSMechanism) acceptStateLogHook(snapParam interface{}) error {
 	return nil
 }
 
-// insertBlockHook checks if the block is the last block of the epoch,
-// in order to update the validator set
+// insertBlockHook stages the validator set changes of the block,
+// and updates the validator set if the block is the last block of the epoch
 func (pos *PoSMechanism) insertBlockHook(numberParam interface{}) error {
 	headerNumber, ok := numberParam.(uint64)
 	if !ok {
 		return ErrInvalidHookParam
 	}
 
-	return pos.updateValidators(headerNumber)
+	if pos.IsInRange(headerNumber) {
+		if err := pos.watchValidatorEvents(headerNumber); err != nil {
+			return err
+		}
+	}
+
+	if headerNumber+1 == pos.From || pos.ibft.IsLastOfEpoch(headerNumber) {
+		return pos.updateValidators(headerNumber)
+	}
+
+	return nil
+}
+
+// watchValidatorEvents stages the validator set changes emitted in the block
+func (pos *PoSMechanism) watchValidatorEvents(num uint64) error {
+	header, ok := pos.ibft.blockchain.GetHeaderByNumber(num)
+	if !ok {
+		return errors.New("header not found")
+	}
+
+	var receipts []*types.Receipt
+
+	// epoch blocks and empty blocks have no receipts
+	if header.TxRoot != types.EmptyRootHash {
+		var err error
+
+		if receipts, err = pos.ibft.blockchain.GetReceiptsByHash(header.Hash); err != nil {
+			return err
+		}
+	}
+
+	return pos.watcher.watch(num, pos.ibft.GetEpoch(num), receipts)
 }
 
 // verifyBlockHook checks if the block is an epoch block and if it has any transactions
@@ -190,6 +224,24 @@ func (pos *PoSMechanism) ShouldWriteTransactions(blockNumber uint64) bool {
 	return pos.IsInRange(blockNumber) && !pos.ibft.IsLastOfEpoch(blockNumber)
 }
 
+// stateBoundary returns the first block following the number whose state the insert hook
+// reads, the last block before the PoS or the last block of an epoch, false if there is none
+func (pos *PoSMechanism) stateBoundary(number uint64) (uint64, bool) {
+	next := number + 1
+
+	// the validators of the first epoch are read from the block preceding the PoS
+	if next < pos.From {
+		return pos.From - 1, true
+	}
+
+	end := pos.ibft.GetEpoch(next) * pos.ibft.epochSize
+	if !pos.IsInRange(end) {
+		return 0, false
+	}
+
+	return end, true
+}
+
 // getNextValidators is a helper function for fetching the validator set
 // from the ValidatorSet SC
 func (pos *PoSMechanism) getNextValidators(header *types.Header) (ValidatorSet, error) {
@@ -201,6 +253,59 @@ func (pos *PoSMechanism) getNextValidators(header *types.Header) (ValidatorSet,
 	return validatorset.QueryValidators(transition, pos.ibft.validatorKeyAddr)
 }
 
+// nextValidators returns the validator set of the next epoch, read from the ValidatorSet SC
+// in the contract order. The staged changes are only shown to the operator, the contract
+// being the source of truth of the set and its order
+func (pos *PoSMechanism) nextValidators(header *types.Header, current ValidatorSet) (ValidatorSet, error) {
+	defer pos.watcher.reset()
+
+	next, err := pos.getNextValidators(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pos.ibft.checkValidatorCount(current.Len(), next.Len()); err != nil {
+		// keep the current set, the chain would not be live anymore
+		pos.ibft.logger.Warn("validator set change refused", "number", header.Number, "err", err)
+
+		return current, nil
+	}
+
+	return next, nil
+}
+
+// pendingValidatorSet is the validator set change staged for the next epoch
+type pendingValidatorSet struct {
+	epoch      uint64            // the epoch being watched
+	deltas     []*validatorDelta // the staged validator set changes
+	validators ValidatorSet      // the validator set resulting from the changes
+	refused    error             // the reason why the changes would be refused, if any
+}
+
+// pendingValidators returns the validator set changes staged for the next epoch
+func (pos *PoSMechanism) pendingValidators() (*pendingValidatorSet, error) {
+	snap, err := pos.ibft.getLatestSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	epoch, deltas := pos.watcher.pending()
+
+	pending := &pendingValidatorSet{
+		epoch:      epoch,
+		deltas:     deltas,
+		validators: applyValidatorDeltas(snap.Set, deltas),
+	}
+
+	if err := pos.ibft.checkValidatorCount(snap.Set.Len(), pending.validators.Len()); err != nil {
+		// the current set would be kept
+		pending.validators = snap.Set
+		pending.refused = err
+	}
+
+	return pending, nil
+}
+
 // updateSnapshotValidators updates validators in snapshot at given height
 func (pos *PoSMechanism) updateValidators(num uint64) error {
 	header, ok := pos.ibft.blockchain.GetHeaderByNumber(num)
@@ -208,11 +313,6 @@ func (pos *PoSMechanism) updateValidators(num uint64) error {
 		return errors.New("header not found")
 	}
 
-	validators, err := pos.getNextValidators(header)
-	if err != nil {
-		return err
-	}
-
 	snap, err := pos.ibft.getSnapshot(header.Number)
 	if err != nil {
 		return err
@@ -222,6 +322,11 @@ func (pos *PoSMechanism) updateValidators(num uint64) error {
 		return fmt.Errorf("cannot find snapshot at %d", header.Number)
 	}
 
+	validators, err := pos.nextValidators(header, snap.Set)
+	if err != nil {
+		return err
+	}
+
 	if !snap.Set.Equal(&validators) {
 		newSnap := snap.Copy()
 		newSnap.Set = validators
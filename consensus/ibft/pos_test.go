@@ -143,3 +143,87 @@ func TestIsLastOfEpoch(t *testing.T) {
 		})
 	}
 }
+
+func TestFastSyncLimit(t *testing.T) {
+	to := uint64(25)
+
+	tests := []struct {
+		name       string
+		mechanisms func(ibft *Ibft) []ConsensusMechanism
+		number     uint64
+		limit      uint64
+		found      bool
+	}{
+		{
+			name: "no limit without PoS",
+			mechanisms: func(ibft *Ibft) []ConsensusMechanism {
+				return []ConsensusMechanism{&PoAMechanism{BaseConsensusMechanism{ibft: ibft, mechanismType: PoA}}}
+			},
+			number: 0,
+		},
+		{
+			name: "the first epoch end of the PoS from the genesis",
+			mechanisms: func(ibft *Ibft) []ConsensusMechanism {
+				return []ConsensusMechanism{newTestPoS(ibft, 0, nil)}
+			},
+			number: 0,
+			limit:  10,
+			found:  true,
+		},
+		{
+			name: "the epoch end itself",
+			mechanisms: func(ibft *Ibft) []ConsensusMechanism {
+				return []ConsensusMechanism{newTestPoS(ibft, 0, nil)}
+			},
+			number: 19,
+			limit:  20,
+			found:  true,
+		},
+		{
+			name: "the last block before the PoS",
+			mechanisms: func(ibft *Ibft) []ConsensusMechanism {
+				return []ConsensusMechanism{
+					&PoAMechanism{BaseConsensusMechanism{ibft: ibft, mechanismType: PoA}},
+					newTestPoS(ibft, 16, nil),
+				}
+			},
+			number: 3,
+			limit:  15,
+			found:  true,
+		},
+		{
+			name: "no limit after the PoS",
+			mechanisms: func(ibft *Ibft) []ConsensusMechanism {
+				return []ConsensusMechanism{newTestPoS(ibft, 0, &to)}
+			},
+			number: 20,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ibft := &Ibft{
+				epochSize: TestEpochSize,
+			}
+			ibft.mechanisms = tt.mechanisms(ibft)
+
+			limit, found := ibft.fastSyncLimit(tt.number)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.limit, limit)
+		})
+	}
+}
+
+// newTestPoS creates a PoS mechanism available in the range
+func newTestPoS(ibft *Ibft, from uint64, to *uint64) *PoSMechanism {
+	return &PoSMechanism{
+		BaseConsensusMechanism: BaseConsensusMechanism{
+			mechanismType: PoS,
+			ibft:          ibft,
+			From:          from,
+			To:            to,
+		},
+	}
+}
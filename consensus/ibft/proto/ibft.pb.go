@@ -1,17 +1,16 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.25.0
-// 	protoc        v3.12.0
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.21.4
 // source: consensus/ibft/proto/ibft.proto
 
 package proto
 
 import (
-	proto "github.com/golang/protobuf/proto"
-	any "github.com/golang/protobuf/ptypes/any"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -23,10 +22,6 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// This is a compile-time assertion that a sufficiently up-to-date version
-// of the legacy proto package is being used.
-const _ = proto.ProtoPackageIsVersion4
-
 type MessageReq_Type int32
 
 const (
@@ -144,7 +139,7 @@ type MessageReq struct {
 	// hash of the locked block
 	Digest string `protobuf:"bytes,6,opt,name=digest,proto3" json:"digest,omitempty"`
 	// proposal is the rlp encoded block in preprepare messages
-	Proposal *any.Any `protobuf:"bytes,7,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	Proposal *anypb.Any `protobuf:"bytes,7,opt,name=proposal,proto3" json:"proposal,omitempty"`
 }
 
 func (x *MessageReq) Reset() {
@@ -221,7 +216,7 @@ func (x *MessageReq) GetDigest() string {
 	return ""
 }
 
-func (x *MessageReq) GetProposal() *any.Any {
+func (x *MessageReq) GetProposal() *anypb.Any {
 	if x != nil {
 		return x.Proposal
 	}
@@ -283,6 +278,83 @@ func (x *View) GetSequence() uint64 {
 	return 0
 }
 
+// ValidatorAnnouncement advertises the libp2p address of a validator,
+// so that the validators joining the set connect to each other in advance
+type ValidatorAnnouncement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// validator is the address of the validator
+	Validator string `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	// peer is the dialable multiaddr of the node, including its peer ID
+	Peer string `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	// epoch is the epoch the announcement is made in
+	Epoch uint64 `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// signature is the signature of the announcement by the validator key
+	Signature string `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *ValidatorAnnouncement) Reset() {
+	*x = ValidatorAnnouncement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_ibft_proto_ibft_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorAnnouncement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorAnnouncement) ProtoMessage() {}
+
+func (x *ValidatorAnnouncement) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_ibft_proto_ibft_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorAnnouncement.ProtoReflect.Descriptor instead.
+func (*ValidatorAnnouncement) Descriptor() ([]byte, []int) {
+	return file_consensus_ibft_proto_ibft_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ValidatorAnnouncement) GetValidator() string {
+	if x != nil {
+		return x.Validator
+	}
+	return ""
+}
+
+func (x *ValidatorAnnouncement) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *ValidatorAnnouncement) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ValidatorAnnouncement) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
 var File_consensus_ibft_proto_ibft_proto protoreflect.FileDescriptor
 
 var file_consensus_ibft_proto_ibft_proto_rawDesc = []byte{
@@ -316,16 +388,24 @@ var file_consensus_ibft_proto_ibft_proto_rawDesc = []byte{
 	0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
 	0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
 	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
-	0x63, 0x65, 0x32, 0x71, 0x0a, 0x04, 0x49, 0x62, 0x66, 0x74, 0x12, 0x36, 0x0a, 0x09, 0x48, 0x61,
-	0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x11, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x12, 0x31, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x2e,
-	0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x17, 0x5a, 0x15, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e,
-	0x73, 0x75, 0x73, 0x2f, 0x69, 0x62, 0x66, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x65, 0x22, 0x7d, 0x0a, 0x15, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x41,
+	0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x32, 0x71, 0x0a, 0x04, 0x49, 0x62, 0x66, 0x74, 0x12, 0x36, 0x0a, 0x09, 0x48, 0x61, 0x6e,
+	0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11,
+	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x31, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x42, 0x17, 0x5a, 0x15, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73,
+	0x75, 0x73, 0x2f, 0x69, 0x62, 0x66, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -341,23 +421,24 @@ func file_consensus_ibft_proto_ibft_proto_rawDescGZIP() []byte {
 }
 
 var file_consensus_ibft_proto_ibft_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_consensus_ibft_proto_ibft_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_consensus_ibft_proto_ibft_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_consensus_ibft_proto_ibft_proto_goTypes = []interface{}{
-	(MessageReq_Type)(0),  // 0: v1.MessageReq.Type
-	(*HandshakeResp)(nil), // 1: v1.HandshakeResp
-	(*MessageReq)(nil),    // 2: v1.MessageReq
-	(*View)(nil),          // 3: v1.View
-	(*any.Any)(nil),       // 4: google.protobuf.Any
-	(*empty.Empty)(nil),   // 5: google.protobuf.Empty
+	(MessageReq_Type)(0),          // 0: v1.MessageReq.Type
+	(*HandshakeResp)(nil),         // 1: v1.HandshakeResp
+	(*MessageReq)(nil),            // 2: v1.MessageReq
+	(*View)(nil),                  // 3: v1.View
+	(*ValidatorAnnouncement)(nil), // 4: v1.ValidatorAnnouncement
+	(*anypb.Any)(nil),             // 5: google.protobuf.Any
+	(*emptypb.Empty)(nil),         // 6: google.protobuf.Empty
 }
 var file_consensus_ibft_proto_ibft_proto_depIdxs = []int32{
 	0, // 0: v1.MessageReq.type:type_name -> v1.MessageReq.Type
 	3, // 1: v1.MessageReq.view:type_name -> v1.View
-	4, // 2: v1.MessageReq.proposal:type_name -> google.protobuf.Any
-	5, // 3: v1.Ibft.Handshake:input_type -> google.protobuf.Empty
+	5, // 2: v1.MessageReq.proposal:type_name -> google.protobuf.Any
+	6, // 3: v1.Ibft.Handshake:input_type -> google.protobuf.Empty
 	2, // 4: v1.Ibft.Message:input_type -> v1.MessageReq
 	1, // 5: v1.Ibft.Handshake:output_type -> v1.HandshakeResp
-	5, // 6: v1.Ibft.Message:output_type -> google.protobuf.Empty
+	6, // 6: v1.Ibft.Message:output_type -> google.protobuf.Empty
 	5, // [5:7] is the sub-list for method output_type
 	3, // [3:5] is the sub-list for method input_type
 	3, // [3:3] is the sub-list for extension type_name
@@ -407,6 +488,18 @@ func file_consensus_ibft_proto_ibft_proto_init() {
 				return nil
 			}
 		}
+		file_consensus_ibft_proto_ibft_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorAnnouncement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -414,7 +507,7 @@ func file_consensus_ibft_proto_ibft_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_consensus_ibft_proto_ibft_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
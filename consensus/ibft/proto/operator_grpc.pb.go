@@ -4,10 +4,10 @@ package proto
 
 import (
 	context "context"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,9 +20,17 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type IbftOperatorClient interface {
 	GetSnapshot(ctx context.Context, in *SnapshotReq, opts ...grpc.CallOption) (*Snapshot, error)
-	Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*empty.Empty, error)
-	Candidates(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CandidatesResp, error)
-	Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error)
+	Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Candidates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CandidatesResp, error)
+	ProposeBatch(ctx context.Context, in *ProposeBatchReq, opts ...grpc.CallOption) (*ProposeBatchResp, error)
+	ListCandidates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCandidatesResp, error)
+	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error)
+	PendingValidators(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PendingValidatorsResp, error)
+	GetValidatorUptime(ctx context.Context, in *ValidatorUptimeReq, opts ...grpc.CallOption) (*ValidatorUptimeResp, error)
+	GetFeeRecipient(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FeeRecipient, error)
+	SetFeeRecipient(ctx context.Context, in *FeeRecipient, opts ...grpc.CallOption) (*FeeRecipient, error)
+	GetValidatorEarnings(ctx context.Context, in *ValidatorEarningsReq, opts ...grpc.CallOption) (*ValidatorEarningsResp, error)
+	GetSyncPeerScores(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncPeerScoresResp, error)
 }
 
 type ibftOperatorClient struct {
@@ -42,8 +50,8 @@ func (c *ibftOperatorClient) GetSnapshot(ctx context.Context, in *SnapshotReq, o
 	return out, nil
 }
 
-func (c *ibftOperatorClient) Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
+func (c *ibftOperatorClient) Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/v1.IbftOperator/Propose", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -51,7 +59,7 @@ func (c *ibftOperatorClient) Propose(ctx context.Context, in *Candidate, opts ..
 	return out, nil
 }
 
-func (c *ibftOperatorClient) Candidates(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CandidatesResp, error) {
+func (c *ibftOperatorClient) Candidates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CandidatesResp, error) {
 	out := new(CandidatesResp)
 	err := c.cc.Invoke(ctx, "/v1.IbftOperator/Candidates", in, out, opts...)
 	if err != nil {
@@ -60,7 +68,25 @@ func (c *ibftOperatorClient) Candidates(ctx context.Context, in *empty.Empty, op
 	return out, nil
 }
 
-func (c *ibftOperatorClient) Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error) {
+func (c *ibftOperatorClient) ProposeBatch(ctx context.Context, in *ProposeBatchReq, opts ...grpc.CallOption) (*ProposeBatchResp, error) {
+	out := new(ProposeBatchResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/ProposeBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) ListCandidates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCandidatesResp, error) {
+	out := new(ListCandidatesResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/ListCandidates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error) {
 	out := new(IbftStatusResp)
 	err := c.cc.Invoke(ctx, "/v1.IbftOperator/Status", in, out, opts...)
 	if err != nil {
@@ -69,14 +95,76 @@ func (c *ibftOperatorClient) Status(ctx context.Context, in *empty.Empty, opts .
 	return out, nil
 }
 
+func (c *ibftOperatorClient) PendingValidators(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PendingValidatorsResp, error) {
+	out := new(PendingValidatorsResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/PendingValidators", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) GetValidatorUptime(ctx context.Context, in *ValidatorUptimeReq, opts ...grpc.CallOption) (*ValidatorUptimeResp, error) {
+	out := new(ValidatorUptimeResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/GetValidatorUptime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) GetFeeRecipient(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FeeRecipient, error) {
+	out := new(FeeRecipient)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/GetFeeRecipient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) SetFeeRecipient(ctx context.Context, in *FeeRecipient, opts ...grpc.CallOption) (*FeeRecipient, error) {
+	out := new(FeeRecipient)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/SetFeeRecipient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) GetValidatorEarnings(ctx context.Context, in *ValidatorEarningsReq, opts ...grpc.CallOption) (*ValidatorEarningsResp, error) {
+	out := new(ValidatorEarningsResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/GetValidatorEarnings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) GetSyncPeerScores(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncPeerScoresResp, error) {
+	out := new(SyncPeerScoresResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/GetSyncPeerScores", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IbftOperatorServer is the server API for IbftOperator service.
 // All implementations must embed UnimplementedIbftOperatorServer
 // for forward compatibility
 type IbftOperatorServer interface {
 	GetSnapshot(context.Context, *SnapshotReq) (*Snapshot, error)
-	Propose(context.Context, *Candidate) (*empty.Empty, error)
-	Candidates(context.Context, *empty.Empty) (*CandidatesResp, error)
-	Status(context.Context, *empty.Empty) (*IbftStatusResp, error)
+	Propose(context.Context, *Candidate) (*emptypb.Empty, error)
+	Candidates(context.Context, *emptypb.Empty) (*CandidatesResp, error)
+	ProposeBatch(context.Context, *ProposeBatchReq) (*ProposeBatchResp, error)
+	ListCandidates(context.Context, *emptypb.Empty) (*ListCandidatesResp, error)
+	Status(context.Context, *emptypb.Empty) (*IbftStatusResp, error)
+	PendingValidators(context.Context, *emptypb.Empty) (*PendingValidatorsResp, error)
+	GetValidatorUptime(context.Context, *ValidatorUptimeReq) (*ValidatorUptimeResp, error)
+	GetFeeRecipient(context.Context, *emptypb.Empty) (*FeeRecipient, error)
+	SetFeeRecipient(context.Context, *FeeRecipient) (*FeeRecipient, error)
+	GetValidatorEarnings(context.Context, *ValidatorEarningsReq) (*ValidatorEarningsResp, error)
+	GetSyncPeerScores(context.Context, *emptypb.Empty) (*SyncPeerScoresResp, error)
 	mustEmbedUnimplementedIbftOperatorServer()
 }
 
@@ -87,15 +175,39 @@ type UnimplementedIbftOperatorServer struct {
 func (UnimplementedIbftOperatorServer) GetSnapshot(context.Context, *SnapshotReq) (*Snapshot, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshot not implemented")
 }
-func (UnimplementedIbftOperatorServer) Propose(context.Context, *Candidate) (*empty.Empty, error) {
+func (UnimplementedIbftOperatorServer) Propose(context.Context, *Candidate) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Propose not implemented")
 }
-func (UnimplementedIbftOperatorServer) Candidates(context.Context, *empty.Empty) (*CandidatesResp, error) {
+func (UnimplementedIbftOperatorServer) Candidates(context.Context, *emptypb.Empty) (*CandidatesResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Candidates not implemented")
 }
-func (UnimplementedIbftOperatorServer) Status(context.Context, *empty.Empty) (*IbftStatusResp, error) {
+func (UnimplementedIbftOperatorServer) ProposeBatch(context.Context, *ProposeBatchReq) (*ProposeBatchResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeBatch not implemented")
+}
+func (UnimplementedIbftOperatorServer) ListCandidates(context.Context, *emptypb.Empty) (*ListCandidatesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCandidates not implemented")
+}
+func (UnimplementedIbftOperatorServer) Status(context.Context, *emptypb.Empty) (*IbftStatusResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedIbftOperatorServer) PendingValidators(context.Context, *emptypb.Empty) (*PendingValidatorsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PendingValidators not implemented")
+}
+func (UnimplementedIbftOperatorServer) GetValidatorUptime(context.Context, *ValidatorUptimeReq) (*ValidatorUptimeResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorUptime not implemented")
+}
+func (UnimplementedIbftOperatorServer) GetFeeRecipient(context.Context, *emptypb.Empty) (*FeeRecipient, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeeRecipient not implemented")
+}
+func (UnimplementedIbftOperatorServer) SetFeeRecipient(context.Context, *FeeRecipient) (*FeeRecipient, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFeeRecipient not implemented")
+}
+func (UnimplementedIbftOperatorServer) GetValidatorEarnings(context.Context, *ValidatorEarningsReq) (*ValidatorEarningsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorEarnings not implemented")
+}
+func (UnimplementedIbftOperatorServer) GetSyncPeerScores(context.Context, *emptypb.Empty) (*SyncPeerScoresResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSyncPeerScores not implemented")
+}
 func (UnimplementedIbftOperatorServer) mustEmbedUnimplementedIbftOperatorServer() {}
 
 // UnsafeIbftOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -146,7 +258,7 @@ func _IbftOperator_Propose_Handler(srv interface{}, ctx context.Context, dec fun
 }
 
 func _IbftOperator_Candidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -158,13 +270,49 @@ func _IbftOperator_Candidates_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: "/v1.IbftOperator/Candidates",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IbftOperatorServer).Candidates(ctx, req.(*empty.Empty))
+		return srv.(IbftOperatorServer).Candidates(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_ProposeBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposeBatchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).ProposeBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/ProposeBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).ProposeBatch(ctx, req.(*ProposeBatchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_ListCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).ListCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/ListCandidates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).ListCandidates(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _IbftOperator_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -176,7 +324,115 @@ func _IbftOperator_Status_Handler(srv interface{}, ctx context.Context, dec func
 		FullMethod: "/v1.IbftOperator/Status",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IbftOperatorServer).Status(ctx, req.(*empty.Empty))
+		return srv.(IbftOperatorServer).Status(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_PendingValidators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).PendingValidators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/PendingValidators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).PendingValidators(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_GetValidatorUptime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorUptimeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).GetValidatorUptime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/GetValidatorUptime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).GetValidatorUptime(ctx, req.(*ValidatorUptimeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_GetFeeRecipient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).GetFeeRecipient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/GetFeeRecipient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).GetFeeRecipient(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_SetFeeRecipient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeeRecipient)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).SetFeeRecipient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/SetFeeRecipient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).SetFeeRecipient(ctx, req.(*FeeRecipient))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_GetValidatorEarnings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorEarningsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).GetValidatorEarnings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/GetValidatorEarnings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).GetValidatorEarnings(ctx, req.(*ValidatorEarningsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_GetSyncPeerScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).GetSyncPeerScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/GetSyncPeerScores",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).GetSyncPeerScores(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -200,10 +456,42 @@ var IbftOperator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Candidates",
 			Handler:    _IbftOperator_Candidates_Handler,
 		},
+		{
+			MethodName: "ProposeBatch",
+			Handler:    _IbftOperator_ProposeBatch_Handler,
+		},
+		{
+			MethodName: "ListCandidates",
+			Handler:    _IbftOperator_ListCandidates_Handler,
+		},
 		{
 			MethodName: "Status",
 			Handler:    _IbftOperator_Status_Handler,
 		},
+		{
+			MethodName: "PendingValidators",
+			Handler:    _IbftOperator_PendingValidators_Handler,
+		},
+		{
+			MethodName: "GetValidatorUptime",
+			Handler:    _IbftOperator_GetValidatorUptime_Handler,
+		},
+		{
+			MethodName: "GetFeeRecipient",
+			Handler:    _IbftOperator_GetFeeRecipient_Handler,
+		},
+		{
+			MethodName: "SetFeeRecipient",
+			Handler:    _IbftOperator_SetFeeRecipient_Handler,
+		},
+		{
+			MethodName: "GetValidatorEarnings",
+			Handler:    _IbftOperator_GetValidatorEarnings_Handler,
+		},
+		{
+			MethodName: "GetSyncPeerScores",
+			Handler:    _IbftOperator_GetSyncPeerScores_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "consensus/ibft/proto/operator.proto",
@@ -60,3 +60,12 @@ func (v *View) Copy() *View {
 
 	return viewClone
 }
+
+// PayloadNoSig returns the byte representation of the announcement, without the signature field
+func (a *ValidatorAnnouncement) PayloadNoSig() ([]byte, error) {
+	return proto.Marshal(&ValidatorAnnouncement{
+		Validator: a.Validator,
+		Peer:      a.Peer,
+		Epoch:     a.Epoch,
+	})
+}
@@ -0,0 +1,176 @@
+package ibft
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// roundFile is the file of the consensus directory the round state is saved to
+const roundFile = "round"
+
+// roundData is the persisted view of the validator, with the round change
+// messages received for its round
+type roundData struct {
+	Sequence     uint64   `json:"sequence"`
+	Round        uint64   `json:"round"`
+	RoundChanges [][]byte `json:"round_changes"`
+}
+
+// loadRound reads the round state saved to the consensus directory, kept only
+// if it is the round of the sequence following the head
+func (i *Ibft) loadRound() {
+	if i.config.Path == "" {
+		return
+	}
+
+	var data *roundData
+	if err := readDataStore(filepath.Join(i.config.Path, roundFile), &data); err != nil {
+		i.logger.Error("could not read the round state, starting from the first round", "err", err)
+
+		return
+	}
+
+	if data == nil || data.Sequence != i.blockchain.Header().Number+1 {
+		return
+	}
+
+	i.savedRound = data
+}
+
+// roundWriter holds the round state to write to the consensus directory. It is written
+// in the background, so that the round changes are not slowed down by the disk
+type roundWriter struct {
+	sync.Mutex
+
+	pending *roundData    // state not written yet
+	last    *roundData    // state last queued, the unchanged ones are not written again
+	wakeCh  chan struct{} // wakes the writer up
+
+	writeLock sync.Mutex // held while writing, so that a flush waits for the write in progress
+}
+
+// saveRound queues the current view and the round change messages of its round
+// to be written to the consensus directory, if they changed since the last time
+func (i *Ibft) saveRound() {
+	if i.config.Path == "" || i.state.view == nil {
+		return
+	}
+
+	var (
+		view     = i.state.view
+		messages = i.state.roundMessages[view.Round]
+	)
+
+	i.rounds.Lock()
+	last := i.rounds.last
+	i.rounds.Unlock()
+
+	// the messages of a round are only added, one per validator
+	if last != nil && last.Sequence == view.Sequence && last.Round == view.Round &&
+		len(last.RoundChanges) == len(messages) {
+		return
+	}
+
+	data := &roundData{
+		Sequence: view.Sequence,
+		Round:    view.Round,
+	}
+
+	for _, msg := range messages {
+		raw, err := protobuf.Marshal(msg)
+		if err != nil {
+			i.logger.Error("failed to encode round change message", "err", err)
+
+			return
+		}
+
+		data.RoundChanges = append(data.RoundChanges, raw)
+	}
+
+	i.rounds.Lock()
+	i.rounds.pending = data
+	i.rounds.last = data
+	i.rounds.Unlock()
+
+	select {
+	case i.rounds.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// runRoundWriter writes the round state queued, until the consensus is closed
+func (i *Ibft) runRoundWriter() {
+	for {
+		select {
+		case <-i.closeCh:
+			return
+		case <-i.rounds.wakeCh:
+			i.flushRound()
+		}
+	}
+}
+
+// flushRound writes the round state queued, if any, to the consensus directory
+func (i *Ibft) flushRound() {
+	i.rounds.writeLock.Lock()
+	defer i.rounds.writeLock.Unlock()
+
+	i.rounds.Lock()
+	data := i.rounds.pending
+	i.rounds.pending = nil
+	i.rounds.Unlock()
+
+	if data == nil {
+		return
+	}
+
+	if err := writeDataStore(filepath.Join(i.config.Path, roundFile), data); err != nil {
+		i.logger.Error("failed to save the round state", "err", err)
+	}
+}
+
+// restoreRound moves the view to the round saved before the restart, if it is
+// the round of the new sequence, and queues the round change messages received for it
+func (i *Ibft) restoreRound() {
+	data := i.savedRound
+	i.savedRound = nil
+
+	if data == nil || data.Sequence != i.state.view.Sequence {
+		return
+	}
+
+	i.logger.Info("rejoining the round saved before the restart",
+		"sequence", data.Sequence,
+		"round", data.Round,
+		"round changes", len(data.RoundChanges),
+	)
+
+	i.startNewRound(data.Round)
+
+	for _, raw := range data.RoundChanges {
+		msg := &proto.MessageReq{}
+		if err := protobuf.Unmarshal(raw, msg); err != nil {
+			i.logger.Error("failed to decode round change message", "err", err)
+
+			continue
+		}
+
+		if msg.Type != proto.MessageReq_RoundChange || msg.View == nil {
+			continue
+		}
+
+		// our own message is relayed internally without signature
+		if msg.From != i.validatorKeyAddr.String() {
+			if err := validateMsg(msg); err != nil {
+				i.logger.Error("failed to validate round change message", "err", err)
+
+				continue
+			}
+		}
+
+		i.pushMessage(msg)
+	}
+}
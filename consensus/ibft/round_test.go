@@ -0,0 +1,143 @@
+package ibft
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+// emitSignedMsg emits the message signed by the account
+func (m *mockIbft) emitSignedMsg(msg *proto.MessageReq) {
+	account := m.pool.get(msg.From)
+	msg.From = ""
+
+	assert.NoError(m.t, signMsg(account.priv, msg))
+	assert.NoError(m.t, validateMsg(msg))
+
+	m.Ibft.pushMessage(msg)
+}
+
+func TestRound_RejoinAfterRestart(t *testing.T) {
+	path := t.TempDir()
+
+	m := newMockIbft(t, []string{"A", "B", "C", "D"}, "A")
+	m.setState(RoundChangeState)
+
+	m.emitSignedMsg(&proto.MessageReq{
+		From: "B",
+		Type: proto.MessageReq_RoundChange,
+		View: proto.ViewMsg(1, 2),
+	})
+	m.emitSignedMsg(&proto.MessageReq{
+		From: "C",
+		Type: proto.MessageReq_RoundChange,
+		View: proto.ViewMsg(1, 2),
+	})
+	m.Close()
+
+	m.config.Path = path
+	m.runCycle()
+
+	m.expect(expectResult{
+		sequence: 1,
+		round:    2,
+		outgoing: 1,
+		state:    AcceptState,
+	})
+
+	// the writer is not running in the mock
+	m.flushRound()
+
+	// the restarted node rejoins the round with its round change certificate
+	restarted := newMockIbft(t, []string{"A", "B", "C", "D"}, "A")
+	restarted.config.Path = path
+	restarted.loadRound()
+	restarted.startNewSequence()
+
+	assert.Equal(t, uint64(1), restarted.state.view.Sequence)
+	assert.Equal(t, uint64(2), restarted.state.view.Round)
+	assert.Equal(t, 2, restarted.msgQueue.roundChangeStateQueue.Len())
+
+	// the round is only rejoined once
+	restarted.startNewSequence()
+	assert.Equal(t, uint64(0), restarted.state.view.Round)
+}
+
+func TestRound_IgnoreStaleSequence(t *testing.T) {
+	path := t.TempDir()
+
+	m := newMockIbft(t, []string{"A", "B", "C", "D"}, "A")
+	m.config.Path = path
+	m.state.view = proto.ViewMsg(5, 3)
+	m.saveRound()
+	m.flushRound()
+
+	// the head is the genesis, the round of another sequence is dropped
+	restarted := newMockIbft(t, []string{"A", "B", "C", "D"}, "A")
+	restarted.config.Path = path
+	restarted.loadRound()
+
+	assert.Nil(t, restarted.savedRound)
+
+	restarted.startNewSequence()
+	assert.Equal(t, uint64(0), restarted.state.view.Round)
+}
+
+func TestRound_SaveOnlyChanges(t *testing.T) {
+	m := newMockIbft(t, []string{"A", "B", "C", "D"}, "A")
+	m.config.Path = t.TempDir()
+	m.state.view = proto.ViewMsg(1, 2)
+
+	m.saveRound()
+	assert.NotNil(t, m.rounds.pending)
+
+	m.flushRound()
+	assert.Nil(t, m.rounds.pending)
+
+	// the same round without new messages is not written again
+	m.saveRound()
+	assert.Nil(t, m.rounds.pending)
+
+	// a new round change message is written
+	m.state.AddRoundMessage(&proto.MessageReq{
+		From: m.pool.get("B").Address().String(),
+		Type: proto.MessageReq_RoundChange,
+		View: proto.ViewMsg(1, 2),
+	})
+	m.saveRound()
+	assert.Len(t, m.rounds.pending.RoundChanges, 1)
+
+	// and so is a new round
+	m.flushRound()
+	m.state.view = proto.ViewMsg(1, 3)
+	m.saveRound()
+	assert.Equal(t, uint64(3), m.rounds.pending.Round)
+}
+
+func TestRound_WriteReplacesFile(t *testing.T) {
+	path := t.TempDir()
+
+	m := newMockIbft(t, []string{"A", "B", "C", "D"}, "A")
+	m.config.Path = path
+
+	m.state.view = proto.ViewMsg(1, 2)
+	m.saveRound()
+	m.flushRound()
+
+	m.state.view = proto.ViewMsg(1, 3)
+	m.saveRound()
+	m.flushRound()
+
+	// the file is replaced whole, without any temporary file left
+	entries, err := ioutil.ReadDir(path)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, roundFile, entries[0].Name())
+
+	var data *roundData
+	assert.NoError(t, readDataStore(filepath.Join(path, roundFile), &data))
+	assert.Equal(t, uint64(3), data.Round)
+}
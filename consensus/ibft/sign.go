@@ -3,6 +3,8 @@ package ibft
 import (
 	"crypto/ecdsa"
 	"fmt"
+	"runtime"
+	"sync"
 
 	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
 	"github.com/dogechain-lab/dogechain/crypto"
@@ -147,6 +149,11 @@ func calculateHeaderHash(h *types.Header) ([]byte, error) {
 	vv.Set(arena.NewUint(h.Timestamp))
 	vv.Set(arena.NewCopyBytes(h.ExtraData))
 
+	// the base fee is only part of the london headers
+	if h.BaseFee != 0 {
+		vv.Set(arena.NewUint(h.BaseFee))
+	}
+
 	buf := keccak.Keccak256Rlp(nil, vv)
 
 	return buf, nil
@@ -165,6 +172,84 @@ func verifySigner(snap *Snapshot, header *types.Header) error {
 	return nil
 }
 
+// verifyCommittedSeal checks that the committed seal of the commit message
+// is signed by its sender for the header
+func verifyCommittedSeal(msg *proto.MessageReq, header *types.Header) error {
+	seal, err := hex.DecodeHex(msg.Seal)
+	if err != nil {
+		return err
+	}
+
+	if len(seal) != IstanbulExtraSeal {
+		return fmt.Errorf("invalid committed seal length")
+	}
+
+	hash, err := calculateHeaderHash(header)
+	if err != nil {
+		return err
+	}
+
+	addr, err := ecrecoverImpl(seal, commitMsg(hash))
+	if err != nil {
+		return err
+	}
+
+	if addr != msg.FromAddr() {
+		return fmt.Errorf("committed seal not signed by the sender")
+	}
+
+	return nil
+}
+
+// recoverCommittedSeals recovers the signers of the committed seals, spreading
+// the recoveries over up to GOMAXPROCS workers since they dominate the header
+// verification of large validator sets
+func recoverCommittedSeals(seals [][]byte, msg []byte) ([]types.Address, error) {
+	var (
+		signers = make([]types.Address, len(seals))
+		errs    = make([]error, len(seals))
+		workers = runtime.GOMAXPROCS(0)
+	)
+
+	if workers > len(seals) {
+		workers = len(seals)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		indexCh = make(chan int, len(seals))
+	)
+
+	for index := range seals {
+		indexCh <- index
+	}
+
+	close(indexCh)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range indexCh {
+				signers[index], errs[index] = ecrecoverImpl(seals[index], msg)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// report the error of the first failing seal, as the sequential recovery would
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return signers, nil
+}
+
 // verifyCommittedFields is checking for consensus proof in the header
 func verifyCommittedFields(snap *Snapshot, header *types.Header) error {
 	extra, err := getIbftExtra(header)
@@ -184,16 +269,14 @@ func verifyCommittedFields(snap *Snapshot, header *types.Header) error {
 		return err
 	}
 
-	rawMsg := commitMsg(hash)
+	signers, err := recoverCommittedSeals(extra.CommittedSeal, commitMsg(hash))
+	if err != nil {
+		return err
+	}
 
 	visited := map[types.Address]struct{}{}
 
-	for _, seal := range extra.CommittedSeal {
-		addr, err := ecrecoverImpl(seal, rawMsg)
-		if err != nil {
-			return err
-		}
-
+	for _, addr := range signers {
 		if _, ok := visited[addr]; ok {
 			return fmt.Errorf("repeated seal")
 		} else {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
 	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/helper/hex"
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -76,6 +77,41 @@ func TestSign_CommittedSeals(t *testing.T) {
 	assert.Error(t, buildCommittedSeal([]string{"A"}))
 }
 
+func TestSign_CommittedSealOfMessage(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B")
+
+	h := &types.Header{}
+	putIbftExtraValidators(h, pool.ValidatorSet())
+
+	buildCommitMsg := func(signer, sender string, mutate func(seal []byte) []byte) *proto.MessageReq {
+		seal, err := writeCommittedSeal(pool.get(signer).priv, h)
+		assert.NoError(t, err)
+
+		return &proto.MessageReq{
+			Type: proto.MessageReq_Commit,
+			From: pool.get(sender).Address().String(),
+			Seal: hex.EncodeToHex(mutate(seal)),
+		}
+	}
+
+	keep := func(seal []byte) []byte { return seal }
+
+	// Correct
+	assert.NoError(t, verifyCommittedSeal(buildCommitMsg("A", "A", keep), h))
+
+	// Failed - Seal of another validator
+	assert.Error(t, verifyCommittedSeal(buildCommitMsg("B", "A", keep), h))
+
+	// Failed - Truncated seal
+	assert.Error(t, verifyCommittedSeal(buildCommitMsg("A", "A", func(seal []byte) []byte { return seal[1:] }), h))
+
+	// Failed - Seal of another header
+	other := h.Copy()
+	other.Number = 1
+	assert.Error(t, verifyCommittedSeal(buildCommitMsg("A", "A", keep), other))
+}
+
 func TestSign_EmptyMessages(t *testing.T) {
 	err := validateMsg(&proto.MessageReq{})
 	if assert.Error(t, err) {
@@ -95,3 +131,71 @@ func TestSign_Messages(t *testing.T) {
 
 	assert.Equal(t, msg.From, pool.get("A").Address().String())
 }
+
+// newBenchmarkSeals returns the committed seals of n validators for the header
+func newBenchmarkSeals(b *testing.B, n int) (*Snapshot, *types.Header, [][]byte, []byte) {
+	b.Helper()
+
+	pool := newTesterAccountPool(n)
+	snap := &Snapshot{
+		Set: pool.ValidatorSet(),
+	}
+
+	h := &types.Header{}
+	putIbftExtraValidators(h, pool.ValidatorSet())
+
+	seals := make([][]byte, 0, n)
+
+	for _, account := range pool.accounts {
+		seal, err := writeCommittedSeal(account.priv, h)
+		assert.NoError(b, err)
+
+		seals = append(seals, seal)
+	}
+
+	sealed, err := writeCommittedSeals(h, seals)
+	assert.NoError(b, err)
+
+	hash, err := calculateHeaderHash(sealed)
+	assert.NoError(b, err)
+
+	return snap, sealed, seals, commitMsg(hash)
+}
+
+func BenchmarkRecoverCommittedSeals_Sequential(b *testing.B) {
+	_, _, seals, msg := newBenchmarkSeals(b, 64)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, seal := range seals {
+			if _, err := ecrecoverImpl(seal, msg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkRecoverCommittedSeals_Parallel(b *testing.B) {
+	_, _, seals, msg := newBenchmarkSeals(b, 64)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := recoverCommittedSeals(seals, msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerifyCommittedFields(b *testing.B) {
+	snap, sealed, _, _ := newBenchmarkSeals(b, 64)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := verifyCommittedFields(snap, sealed); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
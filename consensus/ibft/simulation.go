@@ -0,0 +1,162 @@
+package ibft
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/consensus"
+	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/txpool"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+)
+
+// Transport gossips the messages of a simulated node to the other validators
+type Transport interface {
+	transport
+}
+
+// Syncer syncs a simulated node with the chain of the other validators
+type Syncer interface {
+	syncerInterface
+}
+
+// SimulationParams are the params of an IBFT node run without
+// the networking layer nor the transaction pool
+type SimulationParams struct {
+	Config       *consensus.Config
+	Blockchain   *blockchain.Blockchain
+	Executor     *state.Executor
+	Logger       hclog.Logger
+	ValidatorKey *ecdsa.PrivateKey
+	Transport    Transport
+	Syncer       Syncer
+	BlockTime    time.Duration
+
+	// RoundTimeout returns the timeout of the round, the exponential timeout is used if nil
+	RoundTimeout func(round uint64) time.Duration
+}
+
+// NewSimulationNode creates a sealing IBFT node gossiping through the transport
+// and syncing through the syncer of the params. It proposes empty blocks only
+func NewSimulationNode(params *SimulationParams) (*Ibft, error) {
+	if params.ValidatorKey == nil {
+		return nil, errors.New("validator key is required")
+	}
+
+	c, err := Factory(&consensus.ConsensusParams{
+		Seal:       true,
+		Config:     params.Config,
+		Blockchain: params.Blockchain,
+		Executor:   params.Executor,
+		Logger:     params.Logger,
+		Metrics:    consensus.NilMetrics(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i, ok := c.(*Ibft)
+	if !ok {
+		return nil, errors.New("invalid type assertion")
+	}
+
+	i.validatorKey = params.ValidatorKey
+	i.validatorKeyAddr = crypto.PubKeyToAddress(&params.ValidatorKey.PublicKey)
+	i.blockTime = params.BlockTime
+	i.roundTimeout = params.RoundTimeout
+	i.txpool = &simulationTxPool{}
+	i.operator = &operator{ibft: i}
+	i.transport = params.Transport
+	i.syncer = params.Syncer
+
+	// the message queue must exist before any other node gossips
+	if err := i.createKey(); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// StartSimulation starts the IBFT protocol of a node created by NewSimulationNode
+func (i *Ibft) StartSimulation() {
+	go i.start()
+}
+
+// TamperProposal applies fn to the block proposed by the preprepare message, then seals
+// the block and signs the message again with the key.
+// It is meant to simulate byzantine proposers only
+func TamperProposal(
+	key *ecdsa.PrivateKey,
+	msg *proto.MessageReq,
+	fn func(block *types.Block),
+) (*proto.MessageReq, error) {
+	if msg.Type != proto.MessageReq_Preprepare || msg.Proposal == nil {
+		return nil, errors.New("not a preprepare message")
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(msg.Proposal.Value); err != nil {
+		return nil, err
+	}
+
+	fn(block)
+
+	header, err := writeSeal(key, block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	block.Header = header
+	block.Header.ComputeHash()
+
+	return TamperMessage(key, msg, func(tampered *proto.MessageReq) {
+		tampered.Proposal = &anypb.Any{
+			Value: block.MarshalRLP(),
+		}
+	})
+}
+
+// TamperMessage applies fn to a copy of the message, then signs the copy again with the key.
+// It is meant to simulate byzantine validators only
+func TamperMessage(
+	key *ecdsa.PrivateKey,
+	msg *proto.MessageReq,
+	fn func(msg *proto.MessageReq),
+) (*proto.MessageReq, error) {
+	tampered := msg.Copy()
+
+	fn(tampered)
+
+	if err := signMsg(key, tampered); err != nil {
+		return nil, err
+	}
+
+	return tampered, nil
+}
+
+// simulationTxPool is an always empty transaction pool
+type simulationTxPool struct{}
+
+func (p *simulationTxPool) Drop(tx *types.Transaction) {}
+
+func (p *simulationTxPool) DemoteAllPromoted(tx *types.Transaction, correctNonce uint64) {}
+
+func (p *simulationTxPool) ResetWithHeaders(headers ...*types.Header) {}
+
+func (p *simulationTxPool) Pending() map[types.Address][]*types.Transaction {
+	return nil
+}
+
+func (p *simulationTxPool) ExecutionHint(hash types.Hash) (*txpool.ExecutionHint, bool) {
+	return nil, false
+}
+
+func (p *simulationTxPool) Length() uint64 {
+	return 0
+}
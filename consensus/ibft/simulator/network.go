@@ -0,0 +1,285 @@
+package simulator
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/consensus/ibft"
+	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/types"
+	"go.uber.org/atomic"
+)
+
+// network delivers the messages gossiped by a validator to the other ones,
+// dropping and delaying them at random
+type network struct {
+	nodes []*node
+
+	dropRate float64
+	minDelay time.Duration
+	maxDelay time.Duration
+
+	randLock sync.Mutex
+	rand     *rand.Rand
+
+	// groups maps the index of a validator to its partition,
+	// only the validators of the same partition reach each other
+	groupsLock sync.RWMutex
+	groups     map[int]int
+
+	closeLock sync.RWMutex
+	closed    bool
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+
+	delivered *atomic.Uint64
+	dropped   *atomic.Uint64
+}
+
+func newNetwork(config *Config, nodes []*node) *network {
+	return &network{
+		nodes:     nodes,
+		dropRate:  config.DropRate,
+		minDelay:  config.MinDelay,
+		maxDelay:  config.MaxDelay,
+		rand:      rand.New(rand.NewSource(config.Seed)), //nolint:gosec
+		groups:    map[int]int{},
+		closeCh:   make(chan struct{}),
+		delivered: atomic.NewUint64(0),
+		dropped:   atomic.NewUint64(0),
+	}
+}
+
+// gossip sends the message of the validator to the other ones,
+// according to the behavior of the validator
+func (n *network) gossip(from *node, msg *proto.MessageReq) error {
+	peers := make([]*node, 0, len(n.nodes)-1)
+
+	for _, peer := range n.nodes {
+		if peer != from {
+			peers = append(peers, peer)
+		}
+	}
+
+	switch from.behavior {
+	case Silent:
+		n.dropped.Add(uint64(len(peers)))
+
+		return nil
+	case Withholding:
+		// only half of the validators ever hear from it
+		half := len(peers) / 2
+		n.dropped.Add(uint64(len(peers) - half))
+
+		n.broadcast(from, peers[:half], msg)
+
+		return nil
+	case StaleViews:
+		stale, err := ibft.TamperMessage(from.key, msg, func(msg *proto.MessageReq) {
+			if msg.View.Sequence > 0 {
+				msg.View.Sequence--
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		n.broadcast(from, peers, stale)
+
+		return nil
+	case MalformedSeals:
+		if msg.Type == proto.MessageReq_Commit {
+			malformed, err := ibft.TamperMessage(from.key, msg, func(msg *proto.MessageReq) {
+				msg.Seal = hex.EncodeToHex(n.randomBytes(ibft.IstanbulExtraSeal))
+			})
+			if err != nil {
+				return err
+			}
+
+			msg = malformed
+		}
+
+		n.broadcast(from, peers, msg)
+
+		return nil
+	}
+
+	if msg.Type != proto.MessageReq_Preprepare {
+		n.broadcast(from, peers, msg)
+
+		return nil
+	}
+
+	switch from.behavior {
+	case InvalidProposing:
+		invalid, err := ibft.TamperProposal(from.key, msg, func(block *types.Block) {
+			// the execution result does not match anymore
+			block.Header.StateRoot = types.ZeroHash
+		})
+		if err != nil {
+			return err
+		}
+
+		n.broadcast(from, peers, invalid)
+	case Equivocating:
+		conflicting, err := ibft.TamperProposal(from.key, msg, func(block *types.Block) {
+			// a valid block, but a different one
+			block.Header.Timestamp++
+		})
+		if err != nil {
+			return err
+		}
+
+		half := len(peers) / 2
+
+		n.broadcast(from, peers[:half], msg)
+		n.broadcast(from, peers[half:], conflicting)
+	default:
+		n.broadcast(from, peers, msg)
+	}
+
+	return nil
+}
+
+func (n *network) broadcast(from *node, peers []*node, msg *proto.MessageReq) {
+	for _, peer := range peers {
+		n.send(from, peer, msg)
+	}
+}
+
+// setDelays changes the bounds of the random delivery delay
+func (n *network) setDelays(minDelay, maxDelay time.Duration) {
+	n.randLock.Lock()
+	defer n.randLock.Unlock()
+
+	n.minDelay, n.maxDelay = minDelay, maxDelay
+}
+
+// partition splits the validators, the ones not in any group being isolated
+func (n *network) partition(groups [][]int) {
+	n.groupsLock.Lock()
+	defer n.groupsLock.Unlock()
+
+	n.groups = make(map[int]int, len(n.nodes))
+
+	// isolated by default
+	for _, node := range n.nodes {
+		n.groups[node.index] = -1 - node.index
+	}
+
+	for group, indexes := range groups {
+		for _, index := range indexes {
+			n.groups[index] = group
+		}
+	}
+}
+
+// heal removes the partitions
+func (n *network) heal() {
+	n.groupsLock.Lock()
+	defer n.groupsLock.Unlock()
+
+	n.groups = map[int]int{}
+}
+
+// reachable returns whether the validators are in the same partition
+func (n *network) reachable(from, to *node) bool {
+	n.groupsLock.RLock()
+	defer n.groupsLock.RUnlock()
+
+	return n.groups[from.index] == n.groups[to.index]
+}
+
+// randomBytes returns n bytes from the seeded randomness of the network
+func (n *network) randomBytes(size int) []byte {
+	n.randLock.Lock()
+	defer n.randLock.Unlock()
+
+	buf := make([]byte, size)
+	_, _ = n.rand.Read(buf)
+
+	return buf
+}
+
+// send delivers a copy of the message to the validator after a random delay,
+// unless it is dropped or the validators are partitioned
+func (n *network) send(from, to *node, msg *proto.MessageReq) {
+	if !n.reachable(from, to) {
+		n.dropped.Inc()
+
+		return
+	}
+
+	n.randLock.Lock()
+	drop := n.rand.Float64() < n.dropRate
+	delay := n.minDelay
+
+	if n.maxDelay > n.minDelay {
+		delay += time.Duration(n.rand.Int63n(int64(n.maxDelay - n.minDelay)))
+	}
+	n.randLock.Unlock()
+
+	if drop {
+		n.dropped.Inc()
+
+		return
+	}
+
+	// the receiver overwrites the sender when validating the message
+	msg = msg.Copy()
+
+	n.closeLock.RLock()
+	defer n.closeLock.RUnlock()
+
+	if n.closed {
+		return
+	}
+
+	n.wg.Add(1)
+
+	go func() {
+		defer n.wg.Done()
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			n.delivered.Inc()
+			to.ibft.HandleMessage(msg)
+		case <-n.closeCh:
+		}
+	}()
+}
+
+// close stops delivering the messages
+func (n *network) close() {
+	n.closeLock.Lock()
+	if n.closed {
+		n.closeLock.Unlock()
+
+		return
+	}
+
+	n.closed = true
+	n.closeLock.Unlock()
+
+	close(n.closeCh)
+	n.wg.Wait()
+}
+
+// transport is the IBFT transport of a validator
+type transport struct {
+	network *network
+	node    *node
+}
+
+func (t *transport) Gossip(msg *proto.MessageReq) error {
+	return t.network.gossip(t.node, msg)
+}
+
+func (t *transport) Close() error {
+	return nil
+}
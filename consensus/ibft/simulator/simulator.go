@@ -0,0 +1,478 @@
+package simulator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/blockchain/storage/kvstorage"
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/consensus"
+	"github.com/dogechain-lab/dogechain/consensus/ibft"
+	"github.com/dogechain-lab/dogechain/contracts/governance"
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/state/runtime/evm"
+	"github.com/dogechain-lab/dogechain/state/runtime/precompiled"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	simulationChainID  = 100
+	simulationGasLimit = 5242880
+)
+
+var (
+	errNotEnoughNodes    = errors.New("at least 1 node is required")
+	errInvalidDropRate   = errors.New("drop rate should be in [0, 1)")
+	errInvalidDelays     = errors.New("delays should not be negative, and the minimum not above the maximum")
+	errUnknownNode       = errors.New("byzantine node out of range")
+	errInvalidTimeout    = errors.New("round timeout should be positive")
+	errSimulationStarted = errors.New("simulation already started")
+)
+
+// Behavior is the behavior of a simulated validator
+type Behavior string
+
+const (
+	// Honest validators follow the protocol
+	Honest Behavior = "honest"
+	// Silent validators never send any message
+	Silent Behavior = "silent"
+	// Equivocating validators propose conflicting blocks to different validators
+	Equivocating Behavior = "equivocating"
+	// InvalidProposing validators propose blocks failing the verification
+	InvalidProposing Behavior = "invalid-proposing"
+	// MalformedSeals validators commit with seals not recovering to them
+	MalformedSeals Behavior = "malformed-seals"
+	// StaleViews validators send their messages for the previous sequence
+	StaleViews Behavior = "stale-views"
+	// Withholding validators send their messages to half of the validators only
+	Withholding Behavior = "withholding"
+)
+
+// Behaviors are all the behaviors of the simulated validators
+var Behaviors = []Behavior{
+	Honest,
+	Silent,
+	Equivocating,
+	InvalidProposing,
+	MalformedSeals,
+	StaleViews,
+	Withholding,
+}
+
+// ParseBehavior parses a validator behavior
+func ParseBehavior(raw string) (Behavior, error) {
+	for _, b := range Behaviors {
+		if string(b) == raw {
+			return b, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown behavior %s", raw)
+}
+
+// Config is the configuration of a simulation
+type Config struct {
+	Logger hclog.Logger
+
+	// Nodes is the number of validators
+	Nodes int
+	// Byzantine maps the index of a validator to its behavior, the others are honest
+	Byzantine map[int]Behavior
+
+	// DropRate is the probability of a message not to be delivered to a validator
+	DropRate float64
+	// MinDelay and MaxDelay bound the random delivery delay of a message,
+	// so that the messages get reordered
+	MinDelay time.Duration
+	MaxDelay time.Duration
+
+	// BlockTime is the minimum block generation time
+	BlockTime time.Duration
+	// RoundTimeout is the timeout of the first round, doubled on every new round
+	RoundTimeout time.Duration
+	// EpochSize is the number of blocks of an epoch, the default one if zero
+	EpochSize uint64
+	// MaxIdleInterval suppresses the empty blocks if set, only sealing one after
+	// the interval. Rounded down to seconds
+	MaxIdleInterval time.Duration
+
+	// Seed seeds the randomness of the transport
+	Seed int64
+}
+
+// DefaultConfig returns the configuration of 4 honest validators on a perfect network
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:       hclog.NewNullLogger(),
+		Nodes:        4,
+		Byzantine:    map[int]Behavior{},
+		BlockTime:    0,
+		RoundTimeout: time.Second,
+		Seed:         time.Now().UnixNano(),
+	}
+}
+
+func (c *Config) validate() error {
+	if c.Nodes < 1 {
+		return errNotEnoughNodes
+	}
+
+	if c.DropRate < 0 || c.DropRate >= 1 {
+		return errInvalidDropRate
+	}
+
+	if c.MinDelay < 0 || c.MinDelay > c.MaxDelay {
+		return errInvalidDelays
+	}
+
+	if c.RoundTimeout <= 0 {
+		return errInvalidTimeout
+	}
+
+	for index := range c.Byzantine {
+		if index < 0 || index >= c.Nodes {
+			return errUnknownNode
+		}
+	}
+
+	return nil
+}
+
+// node is a simulated validator
+type node struct {
+	index    int
+	key      *ecdsa.PrivateKey
+	behavior Behavior
+
+	blockchain *blockchain.Blockchain
+	ibft       *ibft.Ibft
+}
+
+// height returns the latest block number of the node
+func (n *node) height() uint64 {
+	return n.blockchain.Header().Number
+}
+
+// Simulator runs in-memory IBFT validators wired through a lossy, reordering transport
+type Simulator struct {
+	config  *Config
+	network *network
+	nodes   []*node
+	started bool
+}
+
+// New creates the validators of the simulation sharing the same genesis
+func New(config *Config) (*Simulator, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	if config.Logger == nil {
+		config.Logger = hclog.NewNullLogger()
+	}
+
+	s := &Simulator{
+		config: config,
+		nodes:  make([]*node, config.Nodes),
+	}
+
+	validators := make([]types.Address, config.Nodes)
+
+	for i := range s.nodes {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		behavior, ok := config.Byzantine[i]
+		if !ok {
+			behavior = Honest
+		}
+
+		s.nodes[i] = &node{
+			index:    i,
+			key:      key,
+			behavior: behavior,
+		}
+		validators[i] = crypto.PubKeyToAddress(&key.PublicKey)
+	}
+
+	s.network = newNetwork(config, s.nodes)
+
+	for _, n := range s.nodes {
+		if err := s.setupNode(n, newGenesis(validators, config)); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// newGenesis returns the chain of the simulation, whose genesis sets the validators
+func newGenesis(validators []types.Address, config *Config) *chain.Chain {
+	extra := &ibft.IstanbulExtra{
+		Validators:    validators,
+		Seal:          []byte{},
+		CommittedSeal: [][]byte{},
+	}
+
+	extraData := make([]byte, ibft.IstanbulExtraVanity)
+	extraData = extra.MarshalRLPTo(extraData)
+
+	engineConfig := map[string]interface{}{
+		"type": string(ibft.PoA),
+	}
+
+	if config.EpochSize > 0 {
+		engineConfig["epochSize"] = float64(config.EpochSize)
+	}
+
+	if config.MaxIdleInterval > 0 {
+		engineConfig["noEmptyBlocks"] = true
+		engineConfig["maxIdleInterval"] = config.MaxIdleInterval.Seconds()
+	}
+
+	return &chain.Chain{
+		Name: "simulation",
+		Genesis: &chain.Genesis{
+			GasLimit:   simulationGasLimit,
+			Difficulty: 1,
+			Mixhash:    ibft.IstanbulDigest,
+			ExtraData:  extraData,
+			Alloc:      map[types.Address]*chain.GenesisAccount{},
+		},
+		Params: &chain.Params{
+			ChainID: simulationChainID,
+			Forks:   chain.AllForksEnabled,
+			Engine: map[string]interface{}{
+				"ibft": engineConfig,
+			},
+		},
+	}
+}
+
+// setupNode sets up the in-memory chain and the IBFT instance of the node
+func (s *Simulator) setupNode(n *node, config *chain.Chain) error {
+	logger := s.config.Logger.Named(fmt.Sprintf("node-%d", n.index))
+
+	executor := state.NewExecutor(config.Params, itrie.NewState(itrie.NewMemoryStorage()), logger)
+	executor.SetRuntime(governance.NewRuntime())
+	executor.SetRuntime(precompiled.NewPrecompiled())
+	executor.SetRuntime(evm.NewEVM())
+
+	config.Genesis.StateRoot = executor.WriteGenesis(config.Genesis.Alloc)
+
+	bc, err := blockchain.NewBlockchain(
+		logger,
+		config,
+		kvstorage.NewMemoryStorageBuilder(logger),
+		nil,
+		executor,
+		blockchain.NilMetrics(),
+	)
+	if err != nil {
+		return err
+	}
+
+	executor.GetHash = bc.GetHashHelper
+
+	engineConfig, _ := config.Params.Engine["ibft"].(map[string]interface{})
+
+	roundTimeout := s.config.RoundTimeout
+
+	n.blockchain = bc
+	n.ibft, err = ibft.NewSimulationNode(&ibft.SimulationParams{
+		Config: &consensus.Config{
+			Params: config.Params,
+			Config: engineConfig,
+		},
+		Blockchain:   bc,
+		Executor:     executor,
+		Logger:       logger,
+		ValidatorKey: n.key,
+		Transport:    &transport{network: s.network, node: n},
+		Syncer:       &syncer{sim: s, node: n},
+		BlockTime:    s.config.BlockTime,
+		RoundTimeout: func(round uint64) time.Duration {
+			if round > 8 {
+				round = 8
+			}
+
+			return roundTimeout << round
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	bc.SetConsensus(n.ibft)
+
+	if err := bc.ComputeGenesis(); err != nil {
+		return err
+	}
+
+	return n.ibft.Initialize()
+}
+
+// Start starts every validator
+func (s *Simulator) Start() error {
+	if s.started {
+		return errSimulationStarted
+	}
+
+	s.started = true
+
+	for _, n := range s.nodes {
+		n.ibft.StartSimulation()
+	}
+
+	return nil
+}
+
+// Stop stops the transport and every validator
+func (s *Simulator) Stop() {
+	s.network.close()
+
+	for _, n := range s.nodes {
+		if s.started {
+			_ = n.ibft.Close()
+		}
+
+		_ = n.blockchain.Close()
+	}
+}
+
+// Heights returns the latest block number of every validator
+func (s *Simulator) Heights() []uint64 {
+	heights := make([]uint64, len(s.nodes))
+
+	for i, n := range s.nodes {
+		heights[i] = n.height()
+	}
+
+	return heights
+}
+
+// WaitForHeight waits until every honest validator reaches the height
+func (s *Simulator) WaitForHeight(ctx context.Context, height uint64) error {
+	honest := make([]int, 0, len(s.nodes))
+
+	for _, n := range s.nodes {
+		if n.behavior == Honest {
+			honest = append(honest, n.index)
+		}
+	}
+
+	return s.WaitForNodesHeight(ctx, height, honest...)
+}
+
+// WaitForNodesHeight waits until the validators of the indexes reach the height
+func (s *Simulator) WaitForNodesHeight(ctx context.Context, height uint64, indexes ...int) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		reached := true
+
+		for _, index := range indexes {
+			if s.nodes[index].height() < height {
+				reached = false
+
+				break
+			}
+		}
+
+		if reached {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("height %d not reached, heights %v: %w", height, s.Heights(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckSafety returns an error if the validators finalized different blocks at the same height
+func (s *Simulator) CheckSafety() error {
+	heights := s.Heights()
+
+	for number := uint64(1); ; number++ {
+		var (
+			expected types.Hash
+			found    bool
+		)
+
+		for i, n := range s.nodes {
+			if heights[i] < number {
+				continue
+			}
+
+			header, ok := n.blockchain.GetHeaderByNumber(number)
+			if !ok {
+				return fmt.Errorf("node %d: header %d not found", i, number)
+			}
+
+			if !found {
+				expected, found = header.Hash, true
+			} else if header.Hash != expected {
+				return fmt.Errorf("fork at height %d: node %d has %s, expected %s", number, i, header.Hash, expected)
+			}
+		}
+
+		if !found {
+			return nil
+		}
+	}
+}
+
+// Partition splits the validators by the indexes of the groups, so that only the validators
+// of the same group reach each other. The validators not in any group are isolated
+func (s *Simulator) Partition(groups ...[]int) error {
+	for _, group := range groups {
+		for _, index := range group {
+			if index < 0 || index >= len(s.nodes) {
+				return errUnknownNode
+			}
+		}
+	}
+
+	s.network.partition(groups)
+
+	return nil
+}
+
+// Heal removes the partitions
+func (s *Simulator) Heal() {
+	s.network.heal()
+}
+
+// SetDelays changes the bounds of the random delivery delay of the messages
+func (s *Simulator) SetDelays(minDelay, maxDelay time.Duration) error {
+	if minDelay < 0 || minDelay > maxDelay {
+		return errInvalidDelays
+	}
+
+	s.network.setDelays(minDelay, maxDelay)
+
+	return nil
+}
+
+// Header returns the latest header of the validator
+func (s *Simulator) Header(index int) *types.Header {
+	return s.nodes[index].blockchain.Header()
+}
+
+// Stats returns the number of messages delivered and dropped by the transport
+func (s *Simulator) Stats() (delivered, dropped uint64) {
+	return s.network.delivered.Load(), s.network.dropped.Load()
+}
@@ -0,0 +1,144 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulator(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(c *Config)
+	}{
+		{
+			name:   "honest validators",
+			update: func(c *Config) {},
+		},
+		{
+			name: "silent validator",
+			update: func(c *Config) {
+				c.Byzantine[0] = Silent
+			},
+		},
+		{
+			name: "invalid proposing validator",
+			update: func(c *Config) {
+				c.Byzantine[1] = InvalidProposing
+			},
+		},
+		{
+			name: "equivocating validator",
+			update: func(c *Config) {
+				c.Byzantine[2] = Equivocating
+			},
+		},
+		{
+			name: "malformed seals validator",
+			update: func(c *Config) {
+				c.Byzantine[3] = MalformedSeals
+			},
+		},
+		{
+			name: "stale views validator",
+			update: func(c *Config) {
+				c.Byzantine[0] = StaleViews
+			},
+		},
+		{
+			name: "withholding validator",
+			update: func(c *Config) {
+				c.Byzantine[1] = Withholding
+			},
+		},
+		{
+			name: "lossy and reordering network",
+			update: func(c *Config) {
+				c.DropRate = 0.1
+				c.MaxDelay = 50 * time.Millisecond
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := DefaultConfig()
+			config.RoundTimeout = 500 * time.Millisecond
+			config.Seed = 1
+			tt.update(config)
+
+			sim, err := New(config)
+			assert.NoError(t, err)
+
+			defer sim.Stop()
+
+			assert.NoError(t, sim.Start())
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			assert.NoError(t, sim.WaitForHeight(ctx, 5))
+			assert.NoError(t, sim.CheckSafety())
+		})
+	}
+}
+
+func TestSimulator_NoEmptyBlocks(t *testing.T) {
+	config := DefaultConfig()
+	config.RoundTimeout = 500 * time.Millisecond
+	config.MaxIdleInterval = time.Second
+	config.Seed = 1
+
+	sim, err := New(config)
+	assert.NoError(t, err)
+
+	defer sim.Stop()
+
+	assert.NoError(t, sim.Start())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// the validators keep sealing heartbeat blocks
+	assert.NoError(t, sim.WaitForHeight(ctx, 3))
+	assert.NoError(t, sim.CheckSafety())
+
+	for number := uint64(1); number <= 3; number++ {
+		parent, ok := sim.nodes[0].blockchain.GetHeaderByNumber(number - 1)
+		assert.True(t, ok)
+
+		header, ok := sim.nodes[0].blockchain.GetHeaderByNumber(number)
+		assert.True(t, ok)
+
+		assert.GreaterOrEqual(t, header.Timestamp, parent.Timestamp+1)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(c *Config)
+		expected error
+	}{
+		{"no nodes", func(c *Config) { c.Nodes = 0 }, errNotEnoughNodes},
+		{"invalid drop rate", func(c *Config) { c.DropRate = 1 }, errInvalidDropRate},
+		{"invalid delays", func(c *Config) { c.MinDelay = time.Second }, errInvalidDelays},
+		{"invalid timeout", func(c *Config) { c.RoundTimeout = 0 }, errInvalidTimeout},
+		{"unknown byzantine node", func(c *Config) { c.Byzantine[4] = Silent }, errUnknownNode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.update(config)
+
+			assert.ErrorIs(t, config.validate(), tt.expected)
+		})
+	}
+}
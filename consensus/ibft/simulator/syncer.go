@@ -0,0 +1,102 @@
+package simulator
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/helper/progress"
+	"github.com/dogechain-lab/dogechain/protocol"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// syncer catches a validator up with the validator having the longest chain,
+// reading the blocks from its chain directly
+type syncer struct {
+	sim  *Simulator
+	node *node
+}
+
+func (s *syncer) Start() {}
+
+// bestNode returns the reachable validator with the longest chain, if longer than the local one
+func (s *syncer) bestNode() *node {
+	var (
+		best   *node
+		height = s.node.height()
+	)
+
+	for _, n := range s.sim.nodes {
+		if n == s.node || !s.sim.network.reachable(s.node, n) {
+			continue
+		}
+
+		if h := n.height(); h > height {
+			best, height = n, h
+		}
+	}
+
+	return best
+}
+
+// BestPeer returns a peer reporting the status of the validator having
+// the longest chain, if it is ahead, nil otherwise
+func (s *syncer) BestPeer() *protocol.SyncPeer {
+	best := s.bestNode()
+	if best == nil {
+		return nil
+	}
+
+	header := best.blockchain.Header()
+
+	return protocol.NewStatusSyncPeer(&protocol.Status{
+		Difficulty: new(big.Int).SetUint64(header.Difficulty),
+		Hash:       header.Hash,
+		Number:     header.Number,
+	})
+}
+
+// BulkSyncWithPeer verifies and writes the blocks the local chain is missing
+func (s *syncer) BulkSyncWithPeer(_ *protocol.SyncPeer, newBlockHandler func(block *types.Block)) error {
+	for {
+		best := s.bestNode()
+		if best == nil {
+			return nil
+		}
+
+		number := s.node.height() + 1
+
+		block, ok := best.blockchain.GetBlockByNumber(number, true)
+		if !ok {
+			return fmt.Errorf("block %d not found", number)
+		}
+
+		if err := s.node.blockchain.VerifyFinalizedBlock(block); err != nil {
+			return fmt.Errorf("unable to verify block %d, %w", number, err)
+		}
+
+		if err := s.node.blockchain.WriteBlock(block); err != nil {
+			return fmt.Errorf("failed to write block %d, %w", number, err)
+		}
+
+		newBlockHandler(block)
+	}
+}
+
+// WatchSyncWithPeer returns at once, since validators never stay in watch mode
+func (s *syncer) WatchSyncWithPeer(
+	_ *protocol.SyncPeer,
+	_ func(b *types.Block) bool,
+	_ time.Duration,
+) {
+}
+
+func (s *syncer) GetSyncProgression() *progress.Progression {
+	return nil
+}
+
+func (s *syncer) Broadcast(_ *types.Block) {}
+
+func (s *syncer) PeerScores() []*protocol.PeerScore {
+	return nil
+}
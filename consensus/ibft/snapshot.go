@@ -33,6 +33,18 @@ func (i *Ibft) setupSnapshot() error {
 		return err
 	}
 
+	// The chain was rewound, the snapshots of the blocks above the head are dropped
+	if meta.LastBlock > header.Number {
+		i.logger.Info("dropping the snapshots above the head", "head", header.Number, "last", meta.LastBlock)
+
+		i.store.deleteHigher(header.Number)
+		i.store.updateLastBlock(header.Number)
+
+		if meta, err = i.getSnapshotMetadata(); err != nil {
+			return err
+		}
+	}
+
 	if header.Number == 0 {
 		// Add genesis
 		if err := i.addHeaderSnap(header); err != nil {
@@ -92,6 +104,9 @@ func (i *Ibft) setupSnapshot() error {
 		}
 	}
 
+	// Rejoin the round of the next sequence if the node restarted in the middle of it
+	i.loadRound()
+
 	return nil
 }
 
@@ -438,6 +453,17 @@ func (s *snapshotStore) deleteLower(num uint64) {
 	s.list = s.list[i:]
 }
 
+// deleteHigher deletes snapshots that have a block number higher than the passed in parameter
+func (s *snapshotStore) deleteHigher(num uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	i := sort.Search(len(s.list), func(i int) bool {
+		return s.list[i].Number > num
+	})
+	s.list = s.list[:i]
+}
+
 // find returns the index of the first closest snapshot to the number specified
 func (s *snapshotStore) find(num uint64) *Snapshot {
 	s.lock.Lock()
@@ -530,17 +556,43 @@ func readDataStore(path string, obj interface{}) error {
 	return nil
 }
 
-// writeDataStore attempts to write the specific file to file storage
+// writeDataStore attempts to write the specific file to file storage.
+// The data is written to a temporary file of the same directory, which is synced
+// and renamed over the file, so that a crash never leaves the file truncated
 func writeDataStore(path string, obj interface{}) error {
 	data, err := json.Marshal(obj)
 	if err != nil {
 		return err
 	}
 
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	// the temporary file is left only if the rename failed
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
 	//nolint:gosec
-	if err := ioutil.WriteFile(path, data, 0755); err != nil {
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
 		return err
 	}
 
-	return nil
+	return os.Rename(tmp.Name(), path)
 }
@@ -872,3 +872,24 @@ func TestSnapshot_Store_Find(t *testing.T) {
 	check(21, 20)
 	check(1000, 100)
 }
+
+func TestSnapshot_Store_DeleteHigher(t *testing.T) {
+	store := newSnapshotStore()
+
+	for i := 0; i <= 100; i += 10 {
+		store.add(&Snapshot{
+			Number: uint64(i),
+		})
+	}
+
+	store.deleteHigher(35)
+
+	assert.Len(t, store.list, 4)
+	assert.Equal(t, uint64(30), store.find(1000).Number)
+
+	// the snapshot of the number is kept
+	store.deleteHigher(20)
+
+	assert.Len(t, store.list, 3)
+	assert.Equal(t, uint64(20), store.find(1000).Number)
+}
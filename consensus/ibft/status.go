@@ -0,0 +1,217 @@
+package ibft
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+const (
+	// maxStatusMessages is the number of recent consensus messages kept for the status page
+	maxStatusMessages = 20
+)
+
+// statusBlock is the locked block shown on the status page
+type statusBlock struct {
+	Number uint64     `json:"number"`
+	Hash   types.Hash `json:"hash"`
+}
+
+// statusMessage is a consensus message shown on the status page
+type statusMessage struct {
+	Type     string    `json:"type"`
+	From     string    `json:"from"`
+	Sequence uint64    `json:"sequence"`
+	Round    uint64    `json:"round"`
+	Received time.Time `json:"received"`
+}
+
+// statusPeer is the health of a connected peer
+type statusPeer struct {
+	ID        string `json:"id"`
+	Connected bool   `json:"connected"`
+}
+
+// consensusStatus is the live view of the consensus rendered by the status page
+type consensusStatus struct {
+	Sequence    uint64          `json:"sequence"`
+	Round       uint64          `json:"round"`
+	State       string          `json:"state"`
+	Proposer    types.Address   `json:"proposer"`
+	Locked      bool            `json:"locked"`
+	LockedBlock *statusBlock    `json:"lockedBlock"`
+	Validators  []types.Address `json:"validators"`
+	Messages    []statusMessage `json:"messages"`
+	Peers       []statusPeer    `json:"peers"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+// statusTracker keeps a copy of the consensus state, so that it could be read
+// outside of the consensus loop
+type statusTracker struct {
+	sync.RWMutex
+
+	status   consensusStatus
+	messages []statusMessage // ring buffer of the recent messages
+	next     int             // next position to write in the ring buffer
+}
+
+// observe copies the current state. It must be called from the consensus loop
+func (t *statusTracker) observe(c *currentState) {
+	t.Lock()
+	defer t.Unlock()
+
+	if c.view != nil {
+		t.status.Sequence = c.view.Sequence
+		t.status.Round = c.view.Round
+	}
+
+	t.status.State = c.getState().String()
+	t.status.Proposer = c.proposer
+	t.status.Locked = c.locked
+	t.status.LockedBlock = nil
+
+	if c.locked && c.block != nil {
+		t.status.LockedBlock = &statusBlock{
+			Number: c.block.Number(),
+			Hash:   c.block.Hash(),
+		}
+	}
+
+	t.status.Validators = append([]types.Address{}, c.validators...)
+	t.status.UpdatedAt = time.Now().UTC()
+}
+
+// recordMessage adds a consensus message to the recent message list
+func (t *statusTracker) recordMessage(msg *proto.MessageReq) {
+	m := statusMessage{
+		Type:     msg.Type.String(),
+		From:     msg.From,
+		Received: time.Now().UTC(),
+	}
+
+	if msg.View != nil {
+		m.Sequence = msg.View.Sequence
+		m.Round = msg.View.Round
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	if len(t.messages) < maxStatusMessages {
+		t.messages = append(t.messages, m)
+	} else {
+		t.messages[t.next] = m
+	}
+
+	t.next = (t.next + 1) % maxStatusMessages
+}
+
+// snapshot returns a copy of the status, with the most recent message first
+func (t *statusTracker) snapshot() consensusStatus {
+	t.RLock()
+	defer t.RUnlock()
+
+	status := t.status
+	status.Validators = append([]types.Address{}, t.status.Validators...)
+	status.Messages = make([]statusMessage, 0, len(t.messages))
+
+	for n := 1; n <= len(t.messages); n++ {
+		idx := (t.next - n + maxStatusMessages) % maxStatusMessages
+		status.Messages = append(status.Messages, t.messages[idx])
+	}
+
+	return status
+}
+
+// getStatus returns the current consensus status, together with the peer health
+func (i *Ibft) getStatus() consensusStatus {
+	status := i.status.snapshot()
+	status.Peers = []statusPeer{}
+
+	if i.network != nil {
+		for _, p := range i.network.Peers() {
+			status.Peers = append(status.Peers, statusPeer{
+				ID:        p.Info.ID.String(),
+				Connected: i.network.IsConnected(p.Info.ID),
+			})
+		}
+	}
+
+	return status
+}
+
+var statusTemplate = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="2">
+<title>IBFT status</title>
+<style>
+body { font-family: monospace; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 2px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h2>Consensus</h2>
+<table>
+<tr><th>Sequence</th><td>{{.Sequence}}</td></tr>
+<tr><th>Round</th><td>{{.Round}}</td></tr>
+<tr><th>State</th><td>{{.State}}</td></tr>
+<tr><th>Proposer</th><td>{{.Proposer}}</td></tr>
+<tr><th>Locked block</th><td>{{if .LockedBlock}}{{.LockedBlock.Number}} {{.LockedBlock.Hash}}{{else}}-{{end}}</td></tr>
+<tr><th>Updated at</th><td>{{.UpdatedAt.Format "2006-01-02 15:04:05"}}</td></tr>
+</table>
+<h2>Validators ({{len .Validators}})</h2>
+<table>
+{{range .Validators}}<tr><td>{{.}}</td></tr>
+{{end}}</table>
+<h2>Recent messages</h2>
+<table>
+<tr><th>Received</th><th>Type</th><th>From</th><th>Sequence</th><th>Round</th></tr>
+{{range .Messages}}<tr><td>{{.Received.Format "15:04:05.000"}}</td><td>{{.Type}}</td><td>{{.From}}</td><td>{{.Sequence}}</td><td>{{.Round}}</td></tr>
+{{end}}</table>
+<h2>Peers ({{len .Peers}})</h2>
+<table>
+<tr><th>ID</th><th>Connected</th></tr>
+{{range .Peers}}<tr><td>{{.ID}}</td><td>{{.Connected}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// StatusHandler returns the handler of the status page.
+// The page is rendered at "/" and its JSON form at "/status.json"
+func (i *Ibft) StatusHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/status.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(i.getStatus()); err != nil {
+			i.logger.Error("failed to write status", "err", err)
+		}
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		if err := statusTemplate.Execute(w, i.getStatus()); err != nil {
+			i.logger.Error("failed to render status page", "err", err)
+		}
+	})
+
+	return mux
+}
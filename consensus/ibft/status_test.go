@@ -0,0 +1,115 @@
+package ibft
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusTracker_RecordMessage(t *testing.T) {
+	tracker := &statusTracker{}
+
+	total := maxStatusMessages + 5
+	for seq := 1; seq <= total; seq++ {
+		tracker.recordMessage(&proto.MessageReq{
+			Type: proto.MessageReq_Prepare,
+			From: "A",
+			View: proto.ViewMsg(uint64(seq), 0),
+		})
+	}
+
+	status := tracker.snapshot()
+
+	// only the most recent messages are kept, newest first
+	assert.Len(t, status.Messages, maxStatusMessages)
+	assert.Equal(t, uint64(total), status.Messages[0].Sequence)
+	assert.Equal(t, uint64(total-maxStatusMessages+1), status.Messages[maxStatusMessages-1].Sequence)
+}
+
+func TestStatusTracker_Observe(t *testing.T) {
+	pool := newTesterAccountPool(3)
+	block := &types.Block{Header: &types.Header{Number: 10}}
+
+	state := newState()
+	state.validators = pool.ValidatorSet()
+	state.view = proto.ViewMsg(10, 2)
+	state.block = block
+	state.proposer = pool.get("0").Address()
+	state.lock()
+	state.setState(ValidateState)
+
+	tracker := &statusTracker{}
+	tracker.observe(state)
+
+	status := tracker.snapshot()
+
+	assert.Equal(t, uint64(10), status.Sequence)
+	assert.Equal(t, uint64(2), status.Round)
+	assert.Equal(t, ValidateState.String(), status.State)
+	assert.Equal(t, pool.get("0").Address(), status.Proposer)
+	assert.True(t, status.Locked)
+	assert.Equal(t, &statusBlock{Number: 10, Hash: block.Hash()}, status.LockedBlock)
+	assert.Equal(t, []types.Address(pool.ValidatorSet()), status.Validators)
+
+	// the locked block is gone once unlocked
+	state.unlock()
+	tracker.observe(state)
+
+	assert.Nil(t, tracker.snapshot().LockedBlock)
+}
+
+func TestIbft_StatusHandler(t *testing.T) {
+	pool := newTesterAccountPool(1)
+
+	i := &Ibft{
+		logger: hclog.NewNullLogger(),
+		state:  newState(),
+	}
+	i.state.validators = pool.ValidatorSet()
+	i.state.view = proto.ViewMsg(5, 0)
+	i.setState(AcceptState)
+	i.status.recordMessage(&proto.MessageReq{
+		Type: proto.MessageReq_Commit,
+		From: pool.get("0").Address().String(),
+		View: proto.ViewMsg(5, 0),
+	})
+
+	handler := i.StatusHandler()
+
+	t.Run("json", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status.json", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var status consensusStatus
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		assert.Equal(t, uint64(5), status.Sequence)
+		assert.Equal(t, AcceptState.String(), status.State)
+		assert.Len(t, status.Messages, 1)
+		assert.Empty(t, status.Peers)
+	})
+
+	t.Run("html", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, strings.Contains(rec.Body.String(), AcceptState.String()))
+		assert.True(t, strings.Contains(rec.Body.String(), "Commit"))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,197 @@
+package ibft
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// uptimeWindowEpochs is the number of the latest epochs whose participation is tracked
+const uptimeWindowEpochs = 8
+
+// epochUptime is the participation of the validators in the blocks of an epoch
+type epochUptime struct {
+	first, last uint64                   // the first and last blocks tracked in the epoch
+	expected    map[types.Address]uint64 // blocks the validator was expected to seal
+	signed      map[types.Address]uint64 // blocks the validator committed a seal to
+}
+
+// validatorUptime is the participation of a validator over several epochs
+type validatorUptime struct {
+	Address  types.Address
+	Signed   uint64
+	Expected uint64
+}
+
+// Percentage returns the share of the blocks the validator committed a seal to
+func (u *validatorUptime) Percentage() float64 {
+	if u.Expected == 0 {
+		return 0
+	}
+
+	return float64(u.Signed) * 100 / float64(u.Expected)
+}
+
+// uptimeReport is the participation of the validators over a range of blocks
+type uptimeReport struct {
+	From, To   uint64
+	Validators []*validatorUptime
+}
+
+// uptimeTracker tracks the presence of the validators in the committed seals
+// of the blocks, over a sliding window of the latest epochs.
+// The window starts from the first block inserted once the node is started
+type uptimeTracker struct {
+	sync.RWMutex
+
+	last   uint64 // the last block tracked
+	epochs map[uint64]*epochUptime
+}
+
+// record tracks the committed seals of the block. The validators are the
+// validator set of the block, the signers are the addresses of the committed seals
+func (t *uptimeTracker) record(number, epoch uint64, validators, signers []types.Address) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.epochs == nil {
+		t.epochs = make(map[uint64]*epochUptime)
+	}
+
+	// a block is never tracked twice
+	if number <= t.last {
+		return
+	}
+
+	t.last = number
+
+	uptime, ok := t.epochs[epoch]
+	if !ok {
+		uptime = &epochUptime{
+			first:    number,
+			expected: make(map[types.Address]uint64),
+			signed:   make(map[types.Address]uint64),
+		}
+		t.epochs[epoch] = uptime
+
+		// slide the window
+		for e := range t.epochs {
+			if e+uptimeWindowEpochs <= epoch {
+				delete(t.epochs, e)
+			}
+		}
+	}
+
+	uptime.last = number
+
+	isValidator := make(map[types.Address]bool, len(validators))
+
+	for _, addr := range validators {
+		isValidator[addr] = true
+		uptime.expected[addr]++
+	}
+
+	for _, addr := range signers {
+		if isValidator[addr] {
+			uptime.signed[addr]++
+		}
+	}
+}
+
+// report returns the participation of the validators over the latest epochs tracked,
+// sorted by address
+func (t *uptimeTracker) report(epochs uint64) (*uptimeReport, error) {
+	if epochs == 0 || epochs > uptimeWindowEpochs {
+		return nil, fmt.Errorf("epochs should be between 1 and %d", uptimeWindowEpochs)
+	}
+
+	t.RLock()
+	defer t.RUnlock()
+
+	if len(t.epochs) == 0 {
+		return nil, fmt.Errorf("no block tracked yet")
+	}
+
+	latest := uint64(0)
+
+	for e := range t.epochs {
+		if e > latest {
+			latest = e
+		}
+	}
+
+	report := &uptimeReport{To: t.last}
+	uptimes := make(map[types.Address]*validatorUptime)
+
+	for e, uptime := range t.epochs {
+		if e+epochs <= latest {
+			continue
+		}
+
+		if report.From == 0 || uptime.first < report.From {
+			report.From = uptime.first
+		}
+
+		for addr, expected := range uptime.expected {
+			u, ok := uptimes[addr]
+			if !ok {
+				u = &validatorUptime{Address: addr}
+				uptimes[addr] = u
+			}
+
+			u.Expected += expected
+			u.Signed += uptime.signed[addr]
+		}
+	}
+
+	report.Validators = make([]*validatorUptime, 0, len(uptimes))
+
+	for _, u := range uptimes {
+		report.Validators = append(report.Validators, u)
+	}
+
+	sort.Slice(report.Validators, func(i, j int) bool {
+		return report.Validators[i].Address.String() < report.Validators[j].Address.String()
+	})
+
+	return report, nil
+}
+
+// recordUptime tracks the participation of the validators in the inserted block
+func (i *Ibft) recordUptime(header *types.Header) {
+	if header.Number == 0 {
+		return
+	}
+
+	snap, err := i.getSnapshot(header.Number - 1)
+	if err != nil || snap == nil {
+		i.logger.Debug("unable to track uptime, no snapshot", "number", header.Number-1)
+
+		return
+	}
+
+	extra, err := getIbftExtra(header)
+	if err != nil {
+		i.logger.Debug("unable to track uptime", "number", header.Number, "err", err)
+
+		return
+	}
+
+	hash, err := calculateHeaderHash(header)
+	if err != nil {
+		i.logger.Debug("unable to track uptime", "number", header.Number, "err", err)
+
+		return
+	}
+
+	signers, err := recoverCommittedSeals(extra.CommittedSeal, commitMsg(hash))
+	if err != nil {
+		i.logger.Debug("unable to track uptime", "number", header.Number, "err", err)
+
+		return
+	}
+
+	i.uptime.record(header.Number, i.GetEpoch(header.Number), snap.Set, signers)
+}
@@ -0,0 +1,125 @@
+package ibft
+
+import (
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUptimeTracker_Report(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B", "C", "D")
+
+	var (
+		a, b, c, d = pool.get("A").Address(), pool.get("B").Address(), pool.get("C").Address(), pool.get("D").Address()
+		validators = []types.Address{a, b, c, d}
+		tracker    = uptimeTracker{}
+	)
+
+	// D misses every other block of the first epoch, and every block of the second one
+	for number := uint64(1); number <= 4; number++ {
+		signers := []types.Address{a, b, c}
+		if number%2 == 0 {
+			signers = append(signers, d)
+		}
+
+		tracker.record(number, 1, validators, signers)
+	}
+
+	for number := uint64(5); number <= 8; number++ {
+		tracker.record(number, 2, validators, []types.Address{a, b, c})
+	}
+
+	// blocks already tracked are ignored
+	tracker.record(8, 2, validators, validators)
+
+	uptimes := func(report *uptimeReport) map[types.Address]float64 {
+		res := map[types.Address]float64{}
+		for _, u := range report.Validators {
+			res[u.Address] = u.Percentage()
+		}
+
+		return res
+	}
+
+	report, err := tracker.report(1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), report.From)
+	assert.Equal(t, uint64(8), report.To)
+	assert.Equal(t, map[types.Address]float64{a: 100, b: 100, c: 100, d: 0}, uptimes(report))
+
+	report, err = tracker.report(2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), report.From)
+	assert.Equal(t, map[types.Address]float64{a: 100, b: 100, c: 100, d: 25}, uptimes(report))
+
+	_, err = tracker.report(0)
+	assert.Error(t, err)
+
+	_, err = tracker.report(uptimeWindowEpochs + 1)
+	assert.Error(t, err)
+}
+
+func TestUptimeTracker_SlidingWindow(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B")
+
+	var (
+		a, b       = pool.get("A").Address(), pool.get("B").Address()
+		validators = []types.Address{a, b}
+		tracker    = uptimeTracker{}
+	)
+
+	// B is only absent in the first epoch
+	tracker.record(1, 1, validators, []types.Address{a})
+
+	for epoch := uint64(2); epoch <= uptimeWindowEpochs+1; epoch++ {
+		tracker.record(epoch, epoch, validators, validators)
+	}
+
+	// the first epoch has slid out of the window
+	assert.Len(t, tracker.epochs, uptimeWindowEpochs)
+
+	report, err := tracker.report(uptimeWindowEpochs)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), report.From)
+
+	for _, u := range report.Validators {
+		assert.Equal(t, float64(100), u.Percentage())
+	}
+}
+
+func TestUptimeTracker_NonValidatorSigners(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B", "C")
+
+	tracker := uptimeTracker{}
+
+	// a seal of an address out of the validator set is not counted
+	tracker.record(1, 1,
+		[]types.Address{pool.get("A").Address(), pool.get("B").Address()},
+		[]types.Address{pool.get("A").Address(), pool.get("C").Address()},
+	)
+
+	report, err := tracker.report(1)
+	assert.NoError(t, err)
+	assert.Len(t, report.Validators, 2)
+
+	for _, u := range report.Validators {
+		assert.Equal(t, uint64(1), u.Expected)
+
+		if u.Address == pool.get("A").Address() {
+			assert.Equal(t, uint64(1), u.Signed)
+		} else {
+			assert.Equal(t, uint64(0), u.Signed)
+		}
+	}
+}
+
+func TestUptimeTracker_NoBlocks(t *testing.T) {
+	tracker := uptimeTracker{}
+
+	_, err := tracker.report(1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,280 @@
+package ibft
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/network/common"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// Define the validator discovery libp2p protocol
+var validatorDiscoveryProto = "/ibft/validators/0.1"
+
+const (
+	// announceInterval is the interval between the announcements of a validator
+	announceInterval = 30 * time.Second
+	// redialInterval is the minimum interval between the dials of the same validator
+	redialInterval = 2 * announceInterval
+)
+
+var (
+	errAnnouncementSigner       = errors.New("announcement not signed by the validator")
+	errAnnouncementEpoch        = errors.New("announcement of another epoch")
+	errAnnouncementNonValidator = errors.New("announcement of a non validator")
+)
+
+// discoveryNetwork is the networking layer the validators are dialed through
+type discoveryNetwork interface {
+	AddrInfo() *peer.AddrInfo
+	IsConnected(peerID peer.ID) bool
+	JoinPeer(rawPeerMultiaddr string) error
+}
+
+// announcementTopic is the discovery topic the announcements are published to
+type announcementTopic interface {
+	Publish(obj protobuf.Message) error
+	Close() error
+}
+
+// validatorDiscovery announces the address of the node to the other validators,
+// and connects to the validators announced, as soon as they are in the current
+// validator set or in the one staged for the next epoch. The validators joining
+// the set are then connected to the consensus mesh before their first epoch
+type validatorDiscovery struct {
+	logger  hclog.Logger
+	network discoveryNetwork
+	topic   announcementTopic
+
+	key  *ecdsa.PrivateKey
+	addr types.Address
+
+	// validators returns the current validators, and the ones of the next epoch
+	validators func() ([]ValidatorSet, error)
+	// epoch returns the current epoch
+	epoch func() uint64
+
+	lock   sync.Mutex
+	dialed map[peer.ID]time.Time // the last dial of the validators
+}
+
+// isValidator checks whether the address is in the current or the next validator set
+func (d *validatorDiscovery) isValidator(addr types.Address) (bool, error) {
+	sets, err := d.validators()
+	if err != nil {
+		return false, err
+	}
+
+	for _, set := range sets {
+		if set.Includes(addr) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// run announces the node periodically until the close channel is closed
+func (d *validatorDiscovery) run(closeCh <-chan struct{}) {
+	ticker := time.NewTicker(announceInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.announce(); err != nil {
+			d.logger.Debug("failed to announce validator", "err", err)
+		}
+
+		select {
+		case <-closeCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// announce publishes the address of the node, if it is a validator
+func (d *validatorDiscovery) announce() error {
+	ok, err := d.isValidator(d.addr)
+	if err != nil || !ok {
+		return err
+	}
+
+	addrInfo := d.network.AddrInfo()
+	if len(addrInfo.Addrs) == 0 {
+		return nil
+	}
+
+	announcement := &proto.ValidatorAnnouncement{
+		Validator: d.addr.String(),
+		Peer:      common.AddrInfoToString(addrInfo),
+		Epoch:     d.epoch(),
+	}
+
+	if err := signAnnouncement(d.key, announcement); err != nil {
+		return err
+	}
+
+	return d.topic.Publish(announcement)
+}
+
+// handle connects to the validator announced, if the node is a validator too
+func (d *validatorDiscovery) handle(announcement *proto.ValidatorAnnouncement) error {
+	// only the validators form the consensus mesh
+	if ok, err := d.isValidator(d.addr); err != nil || !ok {
+		return err
+	}
+
+	if err := d.verify(announcement); err != nil {
+		return err
+	}
+
+	peerInfo, err := common.StringToAddrInfo(announcement.Peer)
+	if err != nil {
+		return err
+	}
+
+	if peerInfo.ID == d.network.AddrInfo().ID || d.network.IsConnected(peerInfo.ID) {
+		return nil
+	}
+
+	d.lock.Lock()
+
+	if last, ok := d.dialed[peerInfo.ID]; ok && time.Since(last) < redialInterval {
+		d.lock.Unlock()
+
+		return nil
+	}
+
+	d.dialed[peerInfo.ID] = time.Now()
+
+	d.lock.Unlock()
+
+	d.logger.Info("connecting to validator", "validator", announcement.Validator, "peer", peerInfo.ID)
+
+	return d.network.JoinPeer(announcement.Peer)
+}
+
+// verify checks that the announcement is recent, and signed by a validator
+func (d *validatorDiscovery) verify(announcement *proto.ValidatorAnnouncement) error {
+	// the announcements of the previous epochs are stale, but the next epoch
+	// may already be reached by the announcer
+	if epoch := d.epoch(); announcement.Epoch != epoch && announcement.Epoch != epoch+1 {
+		return errAnnouncementEpoch
+	}
+
+	signer, err := recoverAnnouncementSigner(announcement)
+	if err != nil {
+		return err
+	}
+
+	if signer != types.StringToAddress(announcement.Validator) {
+		return errAnnouncementSigner
+	}
+
+	ok, err := d.isValidator(signer)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errAnnouncementNonValidator
+	}
+
+	return nil
+}
+
+func signAnnouncement(key *ecdsa.PrivateKey, announcement *proto.ValidatorAnnouncement) error {
+	payload, err := announcement.PayloadNoSig()
+	if err != nil {
+		return err
+	}
+
+	sig, err := crypto.Sign(key, crypto.Keccak256(payload))
+	if err != nil {
+		return err
+	}
+
+	announcement.Signature = hex.EncodeToHex(sig)
+
+	return nil
+}
+
+func recoverAnnouncementSigner(announcement *proto.ValidatorAnnouncement) (types.Address, error) {
+	payload, err := announcement.PayloadNoSig()
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	sig, err := hex.DecodeHex(announcement.Signature)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	return ecrecoverImpl(sig, payload)
+}
+
+// discoveryValidators returns the current validators, and the ones staged for the next epoch
+func (i *Ibft) discoveryValidators() ([]ValidatorSet, error) {
+	snap, err := i.getLatestSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	sets := []ValidatorSet{snap.Set}
+
+	if pos := i.getPoSMechanism(); pos != nil {
+		pending, err := pos.pendingValidators()
+		if err != nil {
+			return nil, err
+		}
+
+		sets = append(sets, pending.validators)
+	}
+
+	return sets, nil
+}
+
+// currentEpoch returns the epoch of the next block
+func (i *Ibft) currentEpoch() uint64 {
+	return i.GetEpoch(i.blockchain.Header().Number + 1)
+}
+
+// setupValidatorDiscovery sets up the discovery topic of the validators
+func (i *Ibft) setupValidatorDiscovery() error {
+	topic, err := i.network.NewTopic(validatorDiscoveryProto, &proto.ValidatorAnnouncement{})
+	if err != nil {
+		return err
+	}
+
+	i.discovery = &validatorDiscovery{
+		logger:     i.logger.Named("validator_discovery"),
+		network:    i.network,
+		topic:      topic,
+		key:        i.validatorKey,
+		addr:       i.validatorKeyAddr,
+		validators: i.discoveryValidators,
+		epoch:      i.currentEpoch,
+		dialed:     make(map[peer.ID]time.Time),
+	}
+
+	return topic.Subscribe(func(obj interface{}) {
+		announcement, ok := obj.(*proto.ValidatorAnnouncement)
+		if !ok {
+			i.logger.Error("invalid type assertion for validator announcement")
+
+			return
+		}
+
+		if err := i.discovery.handle(announcement); err != nil {
+			i.logger.Debug("validator announcement ignored", "validator", announcement.Validator, "err", err)
+		}
+	})
+}
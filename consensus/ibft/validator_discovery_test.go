@@ -0,0 +1,181 @@
+package ibft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/consensus/ibft/proto"
+	"github.com/dogechain-lab/dogechain/network/common"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+type mockDiscoveryNetwork struct {
+	addrInfo  *peer.AddrInfo
+	connected map[peer.ID]bool
+	joined    []string
+}
+
+func newMockDiscoveryNetwork(t *testing.T, port string) *mockDiscoveryNetwork {
+	t.Helper()
+
+	key, _, err := crypto.GenerateSecp256k1Key(nil)
+	assert.NoError(t, err)
+
+	id, err := peer.IDFromPrivateKey(key)
+	assert.NoError(t, err)
+
+	addr, err := multiaddr.NewMultiaddr("/ip4/10.0.0.1/tcp/" + port)
+	assert.NoError(t, err)
+
+	return &mockDiscoveryNetwork{
+		addrInfo:  &peer.AddrInfo{ID: id, Addrs: []multiaddr.Multiaddr{addr}},
+		connected: map[peer.ID]bool{},
+	}
+}
+
+func (m *mockDiscoveryNetwork) AddrInfo() *peer.AddrInfo {
+	return m.addrInfo
+}
+
+func (m *mockDiscoveryNetwork) IsConnected(peerID peer.ID) bool {
+	return m.connected[peerID]
+}
+
+func (m *mockDiscoveryNetwork) JoinPeer(rawPeerMultiaddr string) error {
+	m.joined = append(m.joined, rawPeerMultiaddr)
+
+	return nil
+}
+
+type mockAnnouncementTopic struct {
+	published []*proto.ValidatorAnnouncement
+}
+
+func (m *mockAnnouncementTopic) Publish(obj protobuf.Message) error {
+	announcement, _ := obj.(*proto.ValidatorAnnouncement)
+	m.published = append(m.published, announcement)
+
+	return nil
+}
+
+func (m *mockAnnouncementTopic) Close() error {
+	return nil
+}
+
+func newTestValidatorDiscovery(
+	t *testing.T,
+	account *testerAccount,
+	port string,
+	current, next ValidatorSet,
+) *validatorDiscovery {
+	t.Helper()
+
+	return &validatorDiscovery{
+		logger:  hclog.NewNullLogger(),
+		network: newMockDiscoveryNetwork(t, port),
+		topic:   &mockAnnouncementTopic{},
+		key:     account.priv,
+		addr:    account.Address(),
+		validators: func() ([]ValidatorSet, error) {
+			return []ValidatorSet{current, next}, nil
+		},
+		epoch:  func() uint64 { return 2 },
+		dialed: make(map[peer.ID]time.Time),
+	}
+}
+
+func TestValidatorDiscovery_JoiningValidator(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B", "C")
+
+	var (
+		current = ValidatorSet{pool.get("A").Address(), pool.get("B").Address()}
+		next    = ValidatorSet{pool.get("A").Address(), pool.get("B").Address(), pool.get("C").Address()}
+		joining = newTestValidatorDiscovery(t, pool.get("C"), "30301", current, next)
+		member  = newTestValidatorDiscovery(t, pool.get("A"), "30302", current, next)
+	)
+
+	// the validator staged for the next epoch announces itself
+	assert.NoError(t, joining.announce())
+
+	published := joining.topic.(*mockAnnouncementTopic).published
+	assert.Len(t, published, 1)
+	assert.Equal(t, uint64(2), published[0].Epoch)
+
+	// the current validators connect to it in advance
+	assert.NoError(t, member.handle(published[0]))
+
+	network, _ := member.network.(*mockDiscoveryNetwork)
+	assert.Equal(t, []string{common.AddrInfoToString(joining.network.AddrInfo())}, network.joined)
+
+	// the validator is not dialed again right away
+	assert.NoError(t, member.handle(published[0]))
+	assert.Len(t, network.joined, 1)
+}
+
+func TestValidatorDiscovery_NonValidator(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B", "C")
+
+	var (
+		set      = ValidatorSet{pool.get("A").Address(), pool.get("B").Address()}
+		outsider = newTestValidatorDiscovery(t, pool.get("C"), "30301", set, set)
+		member   = newTestValidatorDiscovery(t, pool.get("A"), "30302", set, set)
+	)
+
+	// a node out of the validator sets does not announce itself
+	assert.NoError(t, outsider.announce())
+	assert.Empty(t, outsider.topic.(*mockAnnouncementTopic).published)
+
+	// nor is it connected to if it does
+	announcement := &proto.ValidatorAnnouncement{
+		Validator: pool.get("C").Address().String(),
+		Peer:      common.AddrInfoToString(outsider.network.AddrInfo()),
+		Epoch:     2,
+	}
+	assert.NoError(t, signAnnouncement(pool.get("C").priv, announcement))
+
+	assert.ErrorIs(t, member.handle(announcement), errAnnouncementNonValidator)
+
+	// the validators do not connect to the announcements of a non validator node
+	assert.NoError(t, outsider.handle(announcement))
+	assert.Empty(t, outsider.network.(*mockDiscoveryNetwork).joined)
+}
+
+func TestValidatorDiscovery_Verify(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B")
+
+	set := ValidatorSet{pool.get("A").Address(), pool.get("B").Address()}
+	d := newTestValidatorDiscovery(t, pool.get("A"), "30301", set, set)
+
+	newAnnouncement := func(epoch uint64) *proto.ValidatorAnnouncement {
+		return &proto.ValidatorAnnouncement{
+			Validator: pool.get("B").Address().String(),
+			Peer:      common.AddrInfoToString(newMockDiscoveryNetwork(t, "30302").AddrInfo()),
+			Epoch:     epoch,
+		}
+	}
+
+	testTable := []struct {
+		name         string
+		announcement *proto.ValidatorAnnouncement
+		signer       *testerAccount
+		err          error
+	}{
+		{"current epoch", newAnnouncement(2), pool.get("B"), nil},
+		{"next epoch", newAnnouncement(3), pool.get("B"), nil},
+		{"stale epoch", newAnnouncement(1), pool.get("B"), errAnnouncementEpoch},
+		{"signed by another validator", newAnnouncement(2), pool.get("A"), errAnnouncementSigner},
+	}
+
+	for _, testCase := range testTable {
+		assert.NoError(t, signAnnouncement(testCase.signer.priv, testCase.announcement))
+		assert.ErrorIs(t, d.verify(testCase.announcement), testCase.err, testCase.name)
+	}
+}
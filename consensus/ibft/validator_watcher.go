@@ -0,0 +1,119 @@
+package ibft
+
+import (
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
+	"github.com/dogechain-lab/dogechain/contracts/validatorset"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// validatorDelta is a validator set change parsed from the ValidatorSet contract events
+type validatorDelta struct {
+	Address types.Address
+	Added   bool   // true if the validator joins the set, false if it leaves
+	Number  uint64 // the block emitting the event
+}
+
+// validatorWatcher stages the validator set changes of an epoch, in the order
+// the events were emitted (block, transaction and log index), so that the operator
+// sees them ahead of the end of the epoch. The validator set itself is read from
+// the contract at the end of the epoch
+type validatorWatcher struct {
+	sync.RWMutex
+
+	epoch  uint64 // the epoch being watched
+	deltas []*validatorDelta
+}
+
+// watch stages the validator set changes of the block.
+// The receipts must be in the transaction order of the block
+func (w *validatorWatcher) watch(number, epoch uint64, receipts []*types.Receipt) error {
+	deltas := make([]*validatorDelta, 0)
+
+	for _, receipt := range receipts {
+		if receipt.Status != nil && *receipt.Status == types.ReceiptFailed {
+			continue
+		}
+
+		for _, log := range receipt.Logs {
+			if log.Address != systemcontracts.AddrValidatorSetContract || len(log.Topics) == 0 {
+				continue
+			}
+
+			var (
+				delta = &validatorDelta{Number: number}
+				err   error
+			)
+
+			switch log.Topics[0] {
+			case validatorset.ValidatorAddedEventID:
+				delta.Added = true
+				delta.Address, err = validatorset.ParseValidatorAddedLog(log)
+			case validatorset.ValidatorDeletedEventID:
+				delta.Address, err = validatorset.ParseValidatorDeletedLog(log)
+			default:
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			deltas = append(deltas, delta)
+		}
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	if epoch != w.epoch {
+		// a new epoch drops the changes of the previous one
+		w.epoch = epoch
+		w.deltas = nil
+	}
+
+	w.deltas = append(w.deltas, deltas...)
+
+	return nil
+}
+
+// pending returns the epoch being watched and a copy of the staged changes
+func (w *validatorWatcher) pending() (uint64, []*validatorDelta) {
+	w.RLock()
+	defer w.RUnlock()
+
+	deltas := make([]*validatorDelta, len(w.deltas))
+	copy(deltas, w.deltas)
+
+	return w.epoch, deltas
+}
+
+// reset drops the staged changes at the end of the epoch
+func (w *validatorWatcher) reset() {
+	w.Lock()
+	defer w.Unlock()
+
+	w.deltas = nil
+}
+
+func applyValidatorDeltas(set ValidatorSet, deltas []*validatorDelta) ValidatorSet {
+	newSet := make(ValidatorSet, len(set))
+	copy(newSet, set)
+
+	for _, delta := range deltas {
+		if delta.Added {
+			if !newSet.Includes(delta.Address) {
+				newSet.Add(delta.Address)
+			}
+
+			continue
+		}
+
+		if index := newSet.Index(delta.Address); index != -1 {
+			newSet = append(newSet[:index], newSet[index+1:]...)
+		}
+	}
+
+	return newSet
+}
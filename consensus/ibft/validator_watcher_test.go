@@ -0,0 +1,137 @@
+package ibft
+
+import (
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
+	"github.com/dogechain-lab/dogechain/contracts/validatorset"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newValidatorEventReceipt(status types.ReceiptStatus, events ...*types.Log) *types.Receipt {
+	receipt := &types.Receipt{Logs: events}
+	receipt.SetStatus(status)
+
+	return receipt
+}
+
+func newValidatorEvent(eventID types.Hash, account types.Address) *types.Log {
+	return &types.Log{
+		Address: systemcontracts.AddrValidatorSetContract,
+		Topics: []types.Hash{
+			eventID,
+			types.BytesToHash(types.ZeroAddress.Bytes()),
+			types.BytesToHash(account.Bytes()),
+		},
+	}
+}
+
+func TestValidatorWatcher_Watch(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B", "D")
+
+	var (
+		addrA = pool.get("A").Address()
+		addrB = pool.get("B").Address()
+		addrD = pool.get("D").Address()
+	)
+
+	w := &validatorWatcher{}
+
+	// block 1 adds D and removes B, the reverted transaction and foreign logs are ignored
+	assert.NoError(t, w.watch(1, 1, []*types.Receipt{
+		newValidatorEventReceipt(types.ReceiptSuccess,
+			newValidatorEvent(validatorset.ValidatorAddedEventID, addrD),
+			&types.Log{
+				Address: types.StringToAddress("1"),
+				Topics:  []types.Hash{validatorset.ValidatorDeletedEventID},
+			},
+		),
+		newValidatorEventReceipt(types.ReceiptFailed,
+			newValidatorEvent(validatorset.ValidatorDeletedEventID, addrA),
+		),
+		newValidatorEventReceipt(types.ReceiptSuccess,
+			newValidatorEvent(validatorset.ValidatorDeletedEventID, addrB),
+		),
+	}))
+
+	// block 2 has no events
+	assert.NoError(t, w.watch(2, 1, nil))
+
+	epoch, deltas := w.pending()
+	assert.Equal(t, uint64(1), epoch)
+	assert.Equal(t, []*validatorDelta{
+		{Address: addrD, Added: true, Number: 1},
+		{Address: addrB, Added: false, Number: 1},
+	}, deltas)
+
+	// a new epoch drops the changes of the previous one
+	assert.NoError(t, w.watch(11, 2, nil))
+
+	_, deltas = w.pending()
+	assert.Empty(t, deltas)
+}
+
+func TestApplyValidatorDeltas(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B", "C")
+
+	var (
+		addrA = pool.get("A").Address()
+		addrB = pool.get("B").Address()
+		addrC = pool.get("C").Address()
+	)
+
+	tests := []struct {
+		name     string
+		set      ValidatorSet
+		deltas   []*validatorDelta
+		expected ValidatorSet
+	}{
+		{
+			name:     "no changes",
+			set:      ValidatorSet{addrA, addrB},
+			deltas:   nil,
+			expected: ValidatorSet{addrA, addrB},
+		},
+		{
+			name: "added in event order",
+			set:  ValidatorSet{addrA},
+			deltas: []*validatorDelta{
+				{Address: addrC, Added: true},
+				{Address: addrB, Added: true},
+			},
+			expected: ValidatorSet{addrA, addrC, addrB},
+		},
+		{
+			name: "removed then added back",
+			set:  ValidatorSet{addrA, addrB, addrC},
+			deltas: []*validatorDelta{
+				{Address: addrA, Added: false},
+				{Address: addrA, Added: true},
+			},
+			expected: ValidatorSet{addrB, addrC, addrA},
+		},
+		{
+			name: "duplicated and unknown changes are ignored",
+			set:  ValidatorSet{addrA, addrB},
+			deltas: []*validatorDelta{
+				{Address: addrA, Added: true},
+				{Address: addrC, Added: false},
+			},
+			expected: ValidatorSet{addrA, addrB},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := make(ValidatorSet, len(tt.set))
+			copy(set, tt.set)
+
+			assert.Equal(t, tt.expected, applyValidatorDeltas(set, tt.deltas))
+			// the passed in set is left untouched
+			assert.Equal(t, tt.set, set)
+		})
+	}
+}
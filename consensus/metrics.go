@@ -18,6 +18,17 @@ type Metrics struct {
 
 	//Time between current block and the previous block in seconds
 	BlockInterval metrics.Gauge
+
+	// Time spent in each state of the consensus state machine, labelled by state
+	StateDuration metrics.Histogram
+	// No.of consensus messages received from the peers, labelled by message type
+	MessagesReceived metrics.Counter
+	// No.of consensus messages sent, labelled by message type
+	MessagesSent metrics.Counter
+	// Round in which the blocks are committed
+	CommitRound metrics.Histogram
+	// Time spent building the proposals in seconds
+	ProposalBuildTime metrics.Histogram
 }
 
 // GetPrometheusMetrics return the consensus metrics instance
@@ -28,6 +39,10 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 		labels = append(labels, labelsWithValues[i])
 	}
 
+	// the labels of the metrics partitioned by state and message type
+	stateLabels := append(append([]string{}, labels...), "state")
+	typeLabels := append(append([]string{}, labels...), "type")
+
 	return &Metrics{
 		Validators: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
@@ -54,6 +69,40 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 			Name:      "block_interval",
 			Help:      "Time between current block and the previous block in seconds.",
 		}, labels).With(labelsWithValues...),
+
+		StateDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "consensus",
+			Name:      "state_seconds",
+			Help:      "Time spent in each consensus state in seconds.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 2, 5, 10},
+		}, stateLabels).With(labelsWithValues...),
+		MessagesReceived: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "consensus",
+			Name:      "messages_received",
+			Help:      "Number of consensus messages received from the peers.",
+		}, typeLabels).With(labelsWithValues...),
+		MessagesSent: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "consensus",
+			Name:      "messages_sent",
+			Help:      "Number of consensus messages sent.",
+		}, typeLabels).With(labelsWithValues...),
+		CommitRound: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "consensus",
+			Name:      "commit_round",
+			Help:      "Round in which the blocks are committed.",
+			Buckets:   []float64{0, 1, 2, 3, 5, 10},
+		}, labels).With(labelsWithValues...),
+		ProposalBuildTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "consensus",
+			Name:      "proposal_build_seconds",
+			Help:      "Time spent building the proposals in seconds.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 2},
+		}, labels).With(labelsWithValues...),
 	}
 }
 
@@ -64,5 +113,11 @@ func NilMetrics() *Metrics {
 		Rounds:        discard.NewGauge(),
 		NumTxs:        discard.NewGauge(),
 		BlockInterval: discard.NewGauge(),
+
+		StateDuration:     discard.NewHistogram(),
+		MessagesReceived:  discard.NewCounter(),
+		MessagesSent:      discard.NewCounter(),
+		CommitRound:       discard.NewHistogram(),
+		ProposalBuildTime: discard.NewHistogram(),
 	}
 }
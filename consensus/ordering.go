@@ -0,0 +1,216 @@
+package consensus
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"sort"
+
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+const (
+	// PriceTimeOrdering packs the highest gas price first, ties are broken by the received time
+	PriceTimeOrdering = "price-time"
+	// FIFOOrdering packs transactions in the order they were received, regardless of the gas price
+	FIFOOrdering = "fifo"
+	// RoundRobinOrdering packs one transaction per account in turns, so no account could fill up a block
+	RoundRobinOrdering = "round-robin"
+
+	// DefaultOrderingPolicy is the ordering policy used when none is configured
+	DefaultOrderingPolicy = PriceTimeOrdering
+)
+
+// TxIterator returns pending transactions in the order decided by a policy,
+// always honoring the nonce order of each account
+type TxIterator interface {
+	// Peek returns the next transaction, nil if there is none
+	Peek() *types.Transaction
+
+	// Shift replaces the current transaction with the next one from the same account
+	Shift()
+
+	// Pop removes the current transaction, *not* replacing it with the next one
+	// from the same account. All subsequent transactions of the account are skipped.
+	Pop()
+}
+
+// OrderingPolicy decides the order in which pending transactions are written into a block
+type OrderingPolicy interface {
+	// Name returns the name of the policy
+	Name() string
+
+	// Order takes over the nonce-sorted pending transactions of all accounts
+	// and returns an iterator over them. The base fee is the one of the block
+	// being built, zero before the london fork.
+	Order(pending map[types.Address][]*types.Transaction, baseFee uint64) TxIterator
+}
+
+// NewOrderingPolicy returns the ordering policy by name, an empty name means the default one
+func NewOrderingPolicy(name string) (OrderingPolicy, error) {
+	switch name {
+	case "", PriceTimeOrdering:
+		return &priceTimePolicy{}, nil
+	case FIFOOrdering:
+		return &fifoPolicy{}, nil
+	case RoundRobinOrdering:
+		return &roundRobinPolicy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown transaction ordering policy: %s", name)
+	}
+}
+
+// priceTimePolicy is the greedy price ordering
+type priceTimePolicy struct{}
+
+func (p *priceTimePolicy) Name() string {
+	return PriceTimeOrdering
+}
+
+func (p *priceTimePolicy) Order(pending map[types.Address][]*types.Transaction, baseFee uint64) TxIterator {
+	return types.NewTransactionsByPriceAndNonce(pending, baseFee)
+}
+
+// fifoPolicy orders transactions by the time they were received
+type fifoPolicy struct{}
+
+func (p *fifoPolicy) Name() string {
+	return FIFOOrdering
+}
+
+func (p *fifoPolicy) Order(pending map[types.Address][]*types.Transaction, _ uint64) TxIterator {
+	heads := make(txByTime, 0, len(pending))
+
+	for from, accTxs := range pending {
+		if len(accTxs) == 0 {
+			continue
+		}
+
+		heads = append(heads, accTxs[0])
+		pending[from] = accTxs[1:]
+	}
+
+	heap.Init(&heads)
+
+	return &transactionsByTimeAndNonce{
+		txs:   pending,
+		heads: heads,
+	}
+}
+
+// txByTime implements the heap interface, ordering by the received time.
+// Ties are broken by the hash for deterministic ordering.
+type txByTime []*types.Transaction
+
+func (s txByTime) Len() int {
+	return len(s)
+}
+
+func (s txByTime) Less(i, j int) bool {
+	if s[i].ReceivedTime.Equal(s[j].ReceivedTime) {
+		return bytes.Compare(s[i].Hash.Bytes(), s[j].Hash.Bytes()) < 0
+	}
+
+	return s[i].ReceivedTime.Before(s[j].ReceivedTime)
+}
+
+func (s txByTime) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s *txByTime) Push(x interface{}) {
+	if v, ok := x.(*types.Transaction); ok {
+		*s = append(*s, v)
+	}
+}
+
+func (s *txByTime) Pop() interface{} {
+	old := *s
+	n := len(old)
+	x := old[n-1]
+	*s = old[0 : n-1]
+
+	return x
+}
+
+type transactionsByTimeAndNonce struct {
+	txs   map[types.Address][]*types.Transaction // Per account nonce-sorted list of transactions
+	heads txByTime                               // Next transaction for each unique account (time heap)
+}
+
+func (t *transactionsByTimeAndNonce) Peek() *types.Transaction {
+	if len(t.heads) == 0 {
+		return nil
+	}
+
+	return t.heads[0]
+}
+
+func (t *transactionsByTimeAndNonce) Shift() {
+	account := t.heads[0].From
+	if txs, ok := t.txs[account]; ok && len(txs) > 0 {
+		t.heads[0], t.txs[account] = txs[0], txs[1:]
+		heap.Fix(&t.heads, 0)
+
+		return
+	}
+
+	heap.Pop(&t.heads)
+}
+
+func (t *transactionsByTimeAndNonce) Pop() {
+	heap.Pop(&t.heads)
+}
+
+// roundRobinPolicy takes one transaction from every account in turns
+type roundRobinPolicy struct{}
+
+func (p *roundRobinPolicy) Name() string {
+	return RoundRobinOrdering
+}
+
+func (p *roundRobinPolicy) Order(pending map[types.Address][]*types.Transaction, _ uint64) TxIterator {
+	accounts := make([][]*types.Transaction, 0, len(pending))
+
+	for _, accTxs := range pending {
+		if len(accTxs) > 0 {
+			accounts = append(accounts, accTxs)
+		}
+	}
+
+	// the first round follows the time the account heads were received,
+	// so that the result does not depend on the map iteration order
+	sort.Slice(accounts, func(i, j int) bool {
+		return txByTime{accounts[i][0], accounts[j][0]}.Less(0, 1)
+	})
+
+	return &transactionsByRoundRobin{
+		accounts: accounts,
+	}
+}
+
+type transactionsByRoundRobin struct {
+	accounts [][]*types.Transaction // ring of per account nonce-sorted list of transactions
+}
+
+func (t *transactionsByRoundRobin) Peek() *types.Transaction {
+	if len(t.accounts) == 0 {
+		return nil
+	}
+
+	return t.accounts[0][0]
+}
+
+func (t *transactionsByRoundRobin) Shift() {
+	head := t.accounts[0][1:]
+	t.accounts = t.accounts[1:]
+
+	// move the account to the end of the ring, waiting for its next turn
+	if len(head) > 0 {
+		t.accounts = append(t.accounts, head)
+	}
+}
+
+func (t *transactionsByRoundRobin) Pop() {
+	t.accounts = t.accounts[1:]
+}
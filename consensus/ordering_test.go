@@ -0,0 +1,150 @@
+package consensus
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+)
+
+func newOrderingTx(from types.Address, nonce, gasPrice uint64, received time.Time) *types.Transaction {
+	return &types.Transaction{
+		From:         from,
+		Nonce:        nonce,
+		GasPrice:     new(big.Int).SetUint64(gasPrice),
+		ReceivedTime: received,
+	}
+}
+
+// drain walks over the iterator, shifting every transaction
+func drain(iter TxIterator) []*types.Transaction {
+	var txs []*types.Transaction
+
+	for tx := iter.Peek(); tx != nil; tx = iter.Peek() {
+		txs = append(txs, tx)
+		iter.Shift()
+	}
+
+	return txs
+}
+
+func TestNewOrderingPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   string
+		expected string
+		err      bool
+	}{
+		{"default", "", PriceTimeOrdering, false},
+		{"price-time", PriceTimeOrdering, PriceTimeOrdering, false},
+		{"fifo", FIFOOrdering, FIFOOrdering, false},
+		{"round-robin", RoundRobinOrdering, RoundRobinOrdering, false},
+		{"unknown", "lifo", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewOrderingPolicy(tt.policy)
+			if tt.err {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, policy.Name())
+		})
+	}
+}
+
+func TestOrderingPolicy_Order(t *testing.T) {
+	now := time.Now()
+
+	// addr1 sends the cheapest transactions first,
+	// addr2 later sends the most expensive ones,
+	// addr3 is in the middle of both
+	var (
+		a1n0 = newOrderingTx(addr1, 0, 1, now)
+		a1n1 = newOrderingTx(addr1, 1, 1, now.Add(1*time.Second))
+		a1n2 = newOrderingTx(addr1, 2, 1, now.Add(2*time.Second))
+		a2n0 = newOrderingTx(addr2, 0, 10, now.Add(5*time.Second))
+		a2n1 = newOrderingTx(addr2, 1, 10, now.Add(6*time.Second))
+		a3n0 = newOrderingTx(addr3, 0, 5, now.Add(3*time.Second))
+	)
+
+	pending := func() map[types.Address][]*types.Transaction {
+		return map[types.Address][]*types.Transaction{
+			addr1: {a1n0, a1n1, a1n2},
+			addr2: {a2n0, a2n1},
+			addr3: {a3n0},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		policy   string
+		expected []*types.Transaction
+	}{
+		{
+			"price-time",
+			PriceTimeOrdering,
+			[]*types.Transaction{a2n0, a2n1, a3n0, a1n0, a1n1, a1n2},
+		},
+		{
+			"fifo",
+			FIFOOrdering,
+			[]*types.Transaction{a1n0, a1n1, a1n2, a3n0, a2n0, a2n1},
+		},
+		{
+			"round-robin",
+			RoundRobinOrdering,
+			[]*types.Transaction{a1n0, a3n0, a2n0, a1n1, a2n1, a1n2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewOrderingPolicy(tt.policy)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expected, drain(policy.Order(pending(), 0)))
+		})
+	}
+}
+
+func TestOrderingPolicy_PopSkipsAccount(t *testing.T) {
+	now := time.Now()
+
+	var (
+		a1n0 = newOrderingTx(addr1, 0, 1, now)
+		a1n1 = newOrderingTx(addr1, 1, 1, now.Add(2*time.Second))
+		a2n0 = newOrderingTx(addr2, 0, 1, now.Add(1*time.Second))
+		a2n1 = newOrderingTx(addr2, 1, 1, now.Add(3*time.Second))
+	)
+
+	for _, name := range []string{PriceTimeOrdering, FIFOOrdering, RoundRobinOrdering} {
+		t.Run(name, func(t *testing.T) {
+			policy, err := NewOrderingPolicy(name)
+			assert.NoError(t, err)
+
+			iter := policy.Order(map[types.Address][]*types.Transaction{
+				addr1: {a1n0, a1n1},
+				addr2: {a2n0, a2n1},
+			}, 0)
+
+			// drop addr1 at its first transaction
+			assert.Equal(t, a1n0, iter.Peek())
+			iter.Pop()
+
+			assert.Equal(t, []*types.Transaction{a2n0, a2n1}, drain(iter))
+		})
+	}
+}
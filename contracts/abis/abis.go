@@ -12,6 +12,8 @@ var (
 	BridgeABI = abi.MustNewABI(BridgeJSONABI)
 	// vault contract abi
 	VaultABI = abi.MustNewABI(VaultJSONABI)
+	// governance contract abi
+	GovernanceABI = abi.MustNewABI(GovernanceJSONABI)
 )
 
 // Temporarily deployed contract ABI
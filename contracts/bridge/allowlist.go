@@ -0,0 +1,68 @@
+package bridge
+
+import (
+	"math/big"
+
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
+	"github.com/dogechain-lab/dogechain/helper/keccak"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// Slot definitions of the bridge contract signers, which are the addresses allowed
+// to mint and burn bridged coins. The layout is the one of the bridge contract:
+//
+//	address[] _signers;                   // slot 2
+//	mapping(address => bool) _isSigner;   // slot 3
+const (
+	signersSlot  = uint64(2)
+	isSignerSlot = uint64(3)
+)
+
+// maxSigners bounds the signers read from the state
+const maxSigners = 1024
+
+// State is the state holding the bridge contract storage
+type State interface {
+	GetState(addr types.Address, key types.Hash) types.Hash
+}
+
+func uint64ToHash(v uint64) types.Hash {
+	return types.BytesToHash(new(big.Int).SetUint64(v).Bytes())
+}
+
+// SignerSlot returns the storage slot of the _isSigner mapping of the address,
+// which is keccak(address . slot)
+func SignerSlot(addr types.Address) types.Hash {
+	key := types.BytesToHash(addr.Bytes())
+	slot := uint64ToHash(isSignerSlot)
+
+	return types.BytesToHash(keccak.Keccak256(nil, append(key.Bytes(), slot.Bytes()...)))
+}
+
+// IsSigner returns whether the address is allowed to mint and burn bridged coins
+func IsSigner(state State, addr types.Address) bool {
+	return state.GetState(systemcontracts.AddrBridgeContract, SignerSlot(addr)) != types.ZeroHash
+}
+
+// Signers returns the addresses allowed to mint and burn bridged coins
+func Signers(state State) []types.Address {
+	length := new(big.Int).SetBytes(
+		state.GetState(systemcontracts.AddrBridgeContract, uint64ToHash(signersSlot)).Bytes(),
+	)
+	if !length.IsUint64() || length.Uint64() > maxSigners {
+		return nil
+	}
+
+	// the elements of a dynamic array start at keccak(slot)
+	start := new(big.Int).SetBytes(keccak.Keccak256(nil, uint64ToHash(signersSlot).Bytes()))
+	signers := make([]types.Address, 0, length.Uint64())
+
+	for i := uint64(0); i < length.Uint64(); i++ {
+		index := new(big.Int).Add(start, new(big.Int).SetUint64(i))
+		value := state.GetState(systemcontracts.AddrBridgeContract, types.BytesToHash(index.Bytes()))
+
+		signers = append(signers, types.BytesToAddress(value.Bytes()))
+	}
+
+	return signers
+}
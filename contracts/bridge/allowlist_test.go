@@ -0,0 +1,50 @@
+package bridge
+
+import (
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
+	bridgeHelper "github.com/dogechain-lab/dogechain/helper/bridge"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockState map[types.Hash]types.Hash
+
+func (m mockState) GetState(addr types.Address, key types.Hash) types.Hash {
+	if addr != systemcontracts.AddrBridgeContract {
+		return types.ZeroHash
+	}
+
+	return m[key]
+}
+
+func TestAllowlist_PredeployedSigners(t *testing.T) {
+	signers := []types.Address{
+		types.StringToAddress("1"),
+		types.StringToAddress("2"),
+		types.StringToAddress("3"),
+	}
+
+	account, err := bridgeHelper.PredeployBridgeSC(bridgeHelper.PredeployParams{
+		Owner:   types.StringToAddress("100"),
+		Signers: signers,
+	})
+	assert.NoError(t, err)
+
+	state := mockState(account.Storage)
+
+	for _, signer := range signers {
+		assert.True(t, IsSigner(state, signer))
+	}
+
+	assert.False(t, IsSigner(state, types.StringToAddress("100")))
+	assert.Equal(t, signers, Signers(state))
+}
+
+func TestAllowlist_Empty(t *testing.T) {
+	state := mockState{}
+
+	assert.False(t, IsSigner(state, types.StringToAddress("1")))
+	assert.Empty(t, Signers(state))
+}
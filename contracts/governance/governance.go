@@ -0,0 +1,244 @@
+package governance
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
+	"github.com/dogechain-lab/dogechain/helper/keccak"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// Param is the identifier of a parameter governed on chain
+type Param uint64
+
+const (
+	// MinGasPrice is the minimum gas price accepted by the txpool
+	MinGasPrice Param = iota + 1
+	// BlockGasTargetMin is the lower bound of the block gas target
+	BlockGasTargetMin
+	// BlockGasTargetMax is the upper bound of the block gas target
+	BlockGasTargetMax
+	// TxPoolMaxSlots is the maximum number of slots of the txpool
+	TxPoolMaxSlots
+)
+
+// AllParams are the parameters validators could vote for
+var AllParams = []Param{
+	MinGasPrice,
+	BlockGasTargetMin,
+	BlockGasTargetMax,
+	TxPoolMaxSlots,
+}
+
+func (p Param) String() string {
+	switch p {
+	case MinGasPrice:
+		return "min-gas-price"
+	case BlockGasTargetMin:
+		return "block-gas-target-min"
+	case BlockGasTargetMax:
+		return "block-gas-target-max"
+	case TxPoolMaxSlots:
+		return "txpool-max-slots"
+	default:
+		return "unknown"
+	}
+}
+
+// IsValid returns whether the parameter could be voted for
+func (p Param) IsValid() bool {
+	return p >= MinGasPrice && p <= TxPoolMaxSlots
+}
+
+// Slot definitions for the contract storage, laid out as the
+// solidity mappings below would be
+//
+//	mapping(uint256 => uint256) params;
+//	mapping(uint256 => mapping(address => uint256)) votes;
+//	mapping(address => address) payouts;
+const (
+	paramsSlot  = uint64(iota) // Slot 0
+	votesSlot                  // Slot 1
+	payoutsSlot                // Slot 2
+)
+
+// StubCode is the code of the governance contract account. The contract is run
+// natively by the node, the code only marks the account as a deployed contract,
+// and reverts if it is ever run by the EVM.
+//
+// PUSH1 0x00 PUSH1 0x00 REVERT
+var StubCode = []byte{0x60, 0x00, 0x60, 0x00, 0xfd}
+
+// PredeployGovernanceSC returns the genesis account of the governance contract
+func PredeployGovernanceSC() *chain.GenesisAccount {
+	return &chain.GenesisAccount{
+		Code: StubCode,
+	}
+}
+
+// State is the state holding the governance contract storage
+type State interface {
+	GetCode(addr types.Address) []byte
+	GetState(addr types.Address, key types.Hash) types.Hash
+	SetState(addr types.Address, key types.Hash, value types.Hash)
+}
+
+// mappingSlot returns the storage slot of the key in a solidity mapping
+func mappingSlot(key types.Hash, slot types.Hash) types.Hash {
+	return types.BytesToHash(keccak.Keccak256(nil, append(key.Bytes(), slot.Bytes()...)))
+}
+
+// ParamSlot returns the storage slot of the enacted value of the parameter
+func ParamSlot(param Param) types.Hash {
+	return mappingSlot(uint64ToHash(uint64(param)), uint64ToHash(paramsSlot))
+}
+
+// VoteSlot returns the storage slot of the vote of the voter for the parameter
+func VoteSlot(param Param, voter types.Address) types.Hash {
+	inner := mappingSlot(uint64ToHash(uint64(param)), uint64ToHash(votesSlot))
+
+	return mappingSlot(types.BytesToHash(voter.Bytes()), inner)
+}
+
+// PayoutSlot returns the storage slot of the payout address registered by the validator
+func PayoutSlot(validator types.Address) types.Hash {
+	return mappingSlot(types.BytesToHash(validator.Bytes()), uint64ToHash(payoutsSlot))
+}
+
+func hashToUint64(h types.Hash) uint64 {
+	return new(big.Int).SetBytes(h.Bytes()).Uint64()
+}
+
+func uint64ToHash(v uint64) types.Hash {
+	return types.BytesToHash(new(big.Int).SetUint64(v).Bytes())
+}
+
+// Params are the enacted values of the governed parameters.
+// Zero means the parameter is not governed, and the node configuration applies.
+type Params struct {
+	MinGasPrice       uint64
+	BlockGasTargetMin uint64
+	BlockGasTargetMax uint64
+	TxPoolMaxSlots    uint64
+}
+
+// Get returns the value of the parameter
+func (p *Params) Get(param Param) uint64 {
+	switch param {
+	case MinGasPrice:
+		return p.MinGasPrice
+	case BlockGasTargetMin:
+		return p.BlockGasTargetMin
+	case BlockGasTargetMax:
+		return p.BlockGasTargetMax
+	case TxPoolMaxSlots:
+		return p.TxPoolMaxSlots
+	default:
+		return 0
+	}
+}
+
+// ClampGasTarget bounds the block gas target with the governed bounds
+func (p *Params) ClampGasTarget(target uint64) uint64 {
+	if p.BlockGasTargetMin > 0 && target < p.BlockGasTargetMin {
+		target = p.BlockGasTargetMin
+	}
+
+	if p.BlockGasTargetMax > 0 && target > p.BlockGasTargetMax {
+		target = p.BlockGasTargetMax
+	}
+
+	return target
+}
+
+// IsDeployed returns whether the governance contract is deployed in the state
+func IsDeployed(state State) bool {
+	return len(state.GetCode(systemcontracts.AddrGovernanceContract)) > 0
+}
+
+// ReadParams returns the enacted parameters, all of them are zero
+// if the governance contract is not deployed
+func ReadParams(state State) *Params {
+	params := &Params{}
+
+	if !IsDeployed(state) {
+		return params
+	}
+
+	read := func(param Param) uint64 {
+		return hashToUint64(state.GetState(systemcontracts.AddrGovernanceContract, ParamSlot(param)))
+	}
+
+	params.MinGasPrice = read(MinGasPrice)
+	params.BlockGasTargetMin = read(BlockGasTargetMin)
+	params.BlockGasTargetMax = read(BlockGasTargetMax)
+	params.TxPoolMaxSlots = read(TxPoolMaxSlots)
+
+	return params
+}
+
+// ReadPayout returns the payout address registered by the validator,
+// zero if the validator registered none or the contract is not deployed
+func ReadPayout(state State, validator types.Address) types.Address {
+	if !IsDeployed(state) {
+		return types.ZeroAddress
+	}
+
+	return types.BytesToAddress(state.GetState(systemcontracts.AddrGovernanceContract, PayoutSlot(validator)).Bytes())
+}
+
+// Change is a parameter value enacted by the tally
+type Change struct {
+	Param Param
+	Value uint64
+}
+
+// Tally counts the votes of the validators, and enacts the value of a parameter
+// once at least quorum validators voted for it. It returns the enacted changes.
+func Tally(state State, validators []types.Address, quorum int) []Change {
+	if !IsDeployed(state) || quorum <= 0 {
+		return nil
+	}
+
+	var changes []Change
+
+	for _, param := range AllParams {
+		counts := make(map[uint64]int)
+
+		for _, validator := range validators {
+			value := hashToUint64(state.GetState(systemcontracts.AddrGovernanceContract, VoteSlot(param, validator)))
+			if value == 0 {
+				// not voted, or withdrawn
+				continue
+			}
+
+			counts[value]++
+		}
+
+		// iterate the values in order, so that the result is deterministic
+		values := make([]uint64, 0, len(counts))
+		for value := range counts {
+			values = append(values, value)
+		}
+
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+		slot := ParamSlot(param)
+		current := hashToUint64(state.GetState(systemcontracts.AddrGovernanceContract, slot))
+
+		for _, value := range values {
+			if counts[value] < quorum || value == current {
+				continue
+			}
+
+			state.SetState(systemcontracts.AddrGovernanceContract, slot, uint64ToHash(value))
+			changes = append(changes, Change{Param: param, Value: value})
+
+			break
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,240 @@
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
+	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/state/runtime"
+	"github.com/dogechain-lab/dogechain/state/runtime/evm"
+	"github.com/dogechain-lab/dogechain/state/runtime/precompiled"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/go-web3"
+)
+
+var validators = []types.Address{
+	types.StringToAddress("1"),
+	types.StringToAddress("2"),
+	types.StringToAddress("3"),
+	types.StringToAddress("4"),
+}
+
+// newTestTransition returns a transition on top of a genesis predeploying
+// the governance contract if deployed is set
+func newTestTransition(t *testing.T, deployed bool) *state.Transition {
+	t.Helper()
+
+	executor := state.NewExecutor(
+		&chain.Params{ChainID: 100, Forks: chain.AllForksEnabled},
+		itrie.NewState(itrie.NewMemoryStorage()),
+		hclog.NewNullLogger(),
+	)
+	executor.SetRuntime(NewRuntime())
+	executor.SetRuntime(precompiled.NewPrecompiled())
+	executor.SetRuntime(evm.NewEVM())
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash { return types.ZeroHash }
+	}
+
+	alloc := map[types.Address]*chain.GenesisAccount{}
+	for _, validator := range validators {
+		alloc[validator] = &chain.GenesisAccount{Balance: big.NewInt(1)}
+	}
+
+	if deployed {
+		alloc[systemcontracts.AddrGovernanceContract] = PredeployGovernanceSC()
+	}
+
+	transition, err := executor.BeginTxn(
+		executor.WriteGenesis(alloc),
+		&types.Header{Number: 1, GasLimit: 10000000},
+		types.ZeroAddress,
+	)
+	assert.NoError(t, err)
+
+	return transition
+}
+
+// call applies a call to the governance contract
+func call(t *testing.T, transition *state.Transition, from types.Address, input []byte) *runtime.ExecutionResult {
+	t.Helper()
+
+	to := systemcontracts.AddrGovernanceContract
+	result, err := transition.Apply(&types.Transaction{
+		Nonce:    transition.GetNonce(from),
+		From:     from,
+		To:       &to,
+		Value:    big.NewInt(0),
+		Gas:      100000,
+		GasPrice: big.NewInt(0),
+		Input:    input,
+	})
+	assert.NoError(t, err)
+
+	return result
+}
+
+func encodeVote(t *testing.T, param Param, value uint64) []byte {
+	t.Helper()
+
+	input, err := voteMethod.Encode(map[string]interface{}{
+		fieldParam: new(big.Int).SetUint64(uint64(param)),
+		fieldValue: new(big.Int).SetUint64(value),
+	})
+	assert.NoError(t, err)
+
+	return input
+}
+
+func TestRuntime_Vote(t *testing.T) {
+	transition := newTestTransition(t, true)
+	voter := validators[0]
+
+	result := call(t, transition, voter, encodeVote(t, MinGasPrice, 50))
+	assert.NoError(t, result.Err)
+	assert.Equal(t, uint64(50), hashToUint64(transition.Txn().GetState(
+		systemcontracts.AddrGovernanceContract,
+		VoteSlot(MinGasPrice, voter),
+	)))
+
+	// the vote is readable through the contract
+	input, err := votesMethod.Encode(map[string]interface{}{
+		fieldParam: new(big.Int).SetUint64(uint64(MinGasPrice)),
+		fieldVoter: web3.Address(voter),
+	})
+	assert.NoError(t, err)
+
+	result = call(t, transition, voter, input)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, uint64ToHash(50).Bytes(), result.ReturnValue)
+
+	// the vote is logged
+	logs := transition.Txn().Logs()
+	assert.Len(t, logs, 1)
+	assert.Equal(t, VotedEventID, logs[0].Topics[0])
+}
+
+func TestRuntime_SetPayout(t *testing.T) {
+	transition := newTestTransition(t, true)
+	validator, payout := validators[0], types.StringToAddress("99")
+
+	input, err := setPayoutMethod.Encode(map[string]interface{}{
+		fieldPayout: web3.Address(payout),
+	})
+	assert.NoError(t, err)
+
+	result := call(t, transition, validator, input)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, payout, ReadPayout(transition.Txn(), validator))
+	assert.Equal(t, types.ZeroAddress, ReadPayout(transition.Txn(), validators[1]))
+
+	// the payout is readable through the contract
+	input, err = payoutsMethod.Encode(map[string]interface{}{
+		fieldValidator: web3.Address(validator),
+	})
+	assert.NoError(t, err)
+
+	result = call(t, transition, validators[1], input)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, types.BytesToHash(payout.Bytes()).Bytes(), result.ReturnValue)
+
+	// the registration is logged
+	logs := transition.Txn().Logs()
+	assert.Len(t, logs, 1)
+	assert.Equal(t, PayoutSetEventID, logs[0].Topics[0])
+	assert.Equal(t, types.BytesToHash(payout.Bytes()), logs[0].Topics[2])
+}
+
+func TestRuntime_Revert(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{"unknown param", encodeVote(t, Param(100), 1)},
+		{"unknown method", []byte{0x01, 0x02, 0x03, 0x04}},
+		{"short input", []byte{0x01}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transition := newTestTransition(t, true)
+
+			result := call(t, transition, validators[0], tt.input)
+			assert.ErrorIs(t, result.Err, runtime.ErrExecutionReverted)
+		})
+	}
+}
+
+func TestTally(t *testing.T) {
+	tests := []struct {
+		name     string
+		deployed bool
+		votes    map[types.Address]uint64
+		expected uint64
+	}{
+		{
+			name:     "quorum reached",
+			deployed: true,
+			votes:    map[types.Address]uint64{validators[0]: 7, validators[1]: 7, validators[2]: 7},
+			expected: 7,
+		},
+		{
+			name:     "quorum not reached",
+			deployed: true,
+			votes:    map[types.Address]uint64{validators[0]: 7, validators[1]: 7, validators[2]: 8},
+			expected: 0,
+		},
+		{
+			name:     "votes of non validators do not count",
+			deployed: true,
+			votes: map[types.Address]uint64{
+				validators[0]:               7,
+				validators[1]:               7,
+				types.StringToAddress("99"): 7,
+			},
+			expected: 0,
+		},
+		{
+			name:     "not deployed",
+			deployed: false,
+			votes:    map[types.Address]uint64{validators[0]: 7, validators[1]: 7, validators[2]: 7},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := newTestTransition(t, tt.deployed).Txn()
+
+			for voter, value := range tt.votes {
+				txn.SetState(systemcontracts.AddrGovernanceContract, VoteSlot(BlockGasTargetMin, voter), uint64ToHash(value))
+			}
+
+			changes := Tally(txn, validators, 3)
+			if tt.expected == 0 {
+				assert.Empty(t, changes)
+			} else {
+				assert.Equal(t, []Change{{Param: BlockGasTargetMin, Value: tt.expected}}, changes)
+			}
+
+			assert.Equal(t, tt.expected, ReadParams(txn).BlockGasTargetMin)
+
+			// enacted values are not enacted again
+			assert.Empty(t, Tally(txn, validators, 3))
+		})
+	}
+}
+
+func TestParams_ClampGasTarget(t *testing.T) {
+	params := &Params{BlockGasTargetMin: 100, BlockGasTargetMax: 200}
+
+	assert.Equal(t, uint64(100), params.ClampGasTarget(50))
+	assert.Equal(t, uint64(150), params.ClampGasTarget(150))
+	assert.Equal(t, uint64(200), params.ClampGasTarget(250))
+	assert.Equal(t, uint64(250), (&Params{}).ClampGasTarget(250))
+}
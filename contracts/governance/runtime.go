@@ -0,0 +1,291 @@
+package governance
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/contracts/abis"
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
+	"github.com/dogechain-lab/dogechain/state/runtime"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/umbracle/go-web3"
+	"github.com/umbracle/go-web3/abi"
+)
+
+const (
+	// voteGas is the fixed gas cost of a vote, about a storage write and a log
+	voteGas uint64 = 30000
+	// viewGas is the fixed gas cost of reading a value, about a cold storage read
+	viewGas uint64 = 2100
+	// setPayoutGas is the fixed gas cost of registering a payout address, about a storage write and a log
+	setPayoutGas uint64 = 30000
+)
+
+const (
+	methodVote   = "vote"
+	methodParams = "params"
+	methodVotes  = "votes"
+
+	methodSetPayout = "setPayout"
+	methodPayouts   = "payouts"
+
+	fieldParam     = "param"
+	fieldValue     = "value"
+	fieldVoter     = "voter"
+	fieldPayout    = "payout"
+	fieldValidator = "validator"
+)
+
+// Frequently used methods and events. Must exist.
+var (
+	voteMethod   = abis.GovernanceABI.Methods[methodVote]
+	paramsMethod = abis.GovernanceABI.Methods[methodParams]
+	votesMethod  = abis.GovernanceABI.Methods[methodVotes]
+
+	setPayoutMethod = abis.GovernanceABI.Methods[methodSetPayout]
+	payoutsMethod   = abis.GovernanceABI.Methods[methodPayouts]
+
+	VotedEvent   = abis.GovernanceABI.Events["Voted"]
+	VotedEventID = types.Hash(VotedEvent.ID())
+
+	PayoutSetEvent   = abis.GovernanceABI.Events["PayoutSet"]
+	PayoutSetEventID = types.Hash(PayoutSetEvent.ID())
+)
+
+var _ runtime.Runtime = &Runtime{}
+
+// Runtime runs the governance contract natively
+type Runtime struct{}
+
+// NewRuntime creates the runtime of the governance contract
+func NewRuntime() *Runtime {
+	return &Runtime{}
+}
+
+// Name implements the runtime interface
+func (r *Runtime) Name() string {
+	return "governance"
+}
+
+// CanRun implements the runtime interface
+func (r *Runtime) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.ForksInTime) bool {
+	return config.Governance &&
+		c.CodeAddress == systemcontracts.AddrGovernanceContract &&
+		len(c.Code) > 0
+}
+
+// Run implements the runtime interface
+func (r *Runtime) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) *runtime.ExecutionResult {
+	// the contract only manages its own storage
+	if c.Address != c.CodeAddress || len(c.Input) < 4 ||
+		(c.Value != nil && c.Value.Sign() != 0) {
+		return revert(c)
+	}
+
+	selector, input := c.Input[:4], c.Input[4:]
+
+	switch {
+	case bytes.Equal(selector, voteMethod.ID()):
+		return r.vote(c, host, config, input)
+	case bytes.Equal(selector, paramsMethod.ID()):
+		return r.view(c, host, paramsMethod, input)
+	case bytes.Equal(selector, votesMethod.ID()):
+		return r.view(c, host, votesMethod, input)
+	case config.FeeRecipient && bytes.Equal(selector, setPayoutMethod.ID()):
+		return r.setPayout(c, host, config, input)
+	case config.FeeRecipient && bytes.Equal(selector, payoutsMethod.ID()):
+		return r.payout(c, host, input)
+	default:
+		return revert(c)
+	}
+}
+
+// vote records the value the caller votes for, zero withdrawing the vote
+func (r *Runtime) vote(
+	c *runtime.Contract,
+	host runtime.Host,
+	config *chain.ForksInTime,
+	input []byte,
+) *runtime.ExecutionResult {
+	if c.Gas < voteGas {
+		return outOfGas()
+	}
+
+	if c.Static {
+		return revert(c)
+	}
+
+	args, err := decode(voteMethod, input)
+	if err != nil {
+		return revert(c)
+	}
+
+	param, ok := toParam(args[fieldParam])
+	if !ok {
+		return revert(c)
+	}
+
+	value, ok := args[fieldValue].(*big.Int)
+	if !ok || !value.IsUint64() {
+		return revert(c)
+	}
+
+	host.SetStorage(c.Address, VoteSlot(param, c.Caller), uint64ToHash(value.Uint64()), config)
+	host.EmitLog(
+		c.Address,
+		[]types.Hash{
+			VotedEventID,
+			types.BytesToHash(c.Caller.Bytes()),
+			uint64ToHash(uint64(param)),
+		},
+		types.BytesToHash(value.Bytes()).Bytes(),
+	)
+
+	return &runtime.ExecutionResult{
+		GasLeft: c.Gas - voteGas,
+	}
+}
+
+// view returns the enacted value of a parameter, or the vote of a voter
+func (r *Runtime) view(
+	c *runtime.Contract,
+	host runtime.Host,
+	method *abi.Method,
+	input []byte,
+) *runtime.ExecutionResult {
+	if c.Gas < viewGas {
+		return outOfGas()
+	}
+
+	args, err := decode(method, input)
+	if err != nil {
+		return revert(c)
+	}
+
+	param, ok := toParam(args[fieldParam])
+	if !ok {
+		return revert(c)
+	}
+
+	slot := ParamSlot(param)
+
+	if method == votesMethod {
+		voter, ok := args[fieldVoter].(web3.Address)
+		if !ok {
+			return revert(c)
+		}
+
+		slot = VoteSlot(param, types.Address(voter))
+	}
+
+	return &runtime.ExecutionResult{
+		ReturnValue: host.GetStorage(c.Address, slot).Bytes(),
+		GasLeft:     c.Gas - viewGas,
+	}
+}
+
+// setPayout registers the address credited with the fees of the blocks the caller
+// proposes, zero resetting it to the caller itself
+func (r *Runtime) setPayout(
+	c *runtime.Contract,
+	host runtime.Host,
+	config *chain.ForksInTime,
+	input []byte,
+) *runtime.ExecutionResult {
+	if c.Gas < setPayoutGas {
+		return outOfGas()
+	}
+
+	if c.Static {
+		return revert(c)
+	}
+
+	args, err := decode(setPayoutMethod, input)
+	if err != nil {
+		return revert(c)
+	}
+
+	payout, ok := args[fieldPayout].(web3.Address)
+	if !ok {
+		return revert(c)
+	}
+
+	host.SetStorage(c.Address, PayoutSlot(c.Caller), types.BytesToHash(payout.Bytes()), config)
+	host.EmitLog(
+		c.Address,
+		[]types.Hash{
+			PayoutSetEventID,
+			types.BytesToHash(c.Caller.Bytes()),
+			types.BytesToHash(payout.Bytes()),
+		},
+		nil,
+	)
+
+	return &runtime.ExecutionResult{
+		GasLeft: c.Gas - setPayoutGas,
+	}
+}
+
+// payout returns the payout address registered by a validator
+func (r *Runtime) payout(
+	c *runtime.Contract,
+	host runtime.Host,
+	input []byte,
+) *runtime.ExecutionResult {
+	if c.Gas < viewGas {
+		return outOfGas()
+	}
+
+	args, err := decode(payoutsMethod, input)
+	if err != nil {
+		return revert(c)
+	}
+
+	validator, ok := args[fieldValidator].(web3.Address)
+	if !ok {
+		return revert(c)
+	}
+
+	return &runtime.ExecutionResult{
+		ReturnValue: host.GetStorage(c.Address, PayoutSlot(types.Address(validator))).Bytes(),
+		GasLeft:     c.Gas - viewGas,
+	}
+}
+
+func decode(method *abi.Method, input []byte) (map[string]interface{}, error) {
+	raw, err := abi.Decode(method.Inputs, input)
+	if err != nil {
+		return nil, err
+	}
+
+	args, _ := raw.(map[string]interface{})
+
+	return args, nil
+}
+
+func toParam(raw interface{}) (Param, bool) {
+	v, ok := raw.(*big.Int)
+	if !ok || !v.IsUint64() {
+		return 0, false
+	}
+
+	param := Param(v.Uint64())
+
+	return param, param.IsValid()
+}
+
+// revert reverts the call, leaving the remaining gas to the caller
+func revert(c *runtime.Contract) *runtime.ExecutionResult {
+	return &runtime.ExecutionResult{
+		GasLeft: c.Gas,
+		Err:     runtime.ErrExecutionReverted,
+	}
+}
+
+func outOfGas() *runtime.ExecutionResult {
+	return &runtime.ExecutionResult{
+		GasLeft: 0,
+		Err:     runtime.ErrOutOfGas,
+	}
+}
@@ -9,4 +9,6 @@ var (
 	AddrBridgeContract = types.StringToAddress("0x0000000000000000000000000000000000001002")
 	// vault contract address
 	AddrVaultContract = types.StringToAddress("0x0000000000000000000000000000000000001003")
+	// governance contract address
+	AddrGovernanceContract = types.StringToAddress("0x0000000000000000000000000000000000001004")
 )
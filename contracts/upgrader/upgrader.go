@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/contracts/governance"
 	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
 	"github.com/dogechain-lab/dogechain/helper/hex"
 	"github.com/dogechain-lab/dogechain/state"
@@ -77,6 +78,23 @@ func UpgradeSystem(
 		applySystemContractUpgrade(up, blockNumber, txn,
 			logger.With("upgrade", up.UpgradeName, "network", network))
 	}
+
+	if config.IsOnGovernance(blockNumber) { // only deploy governance once
+		deployGovernanceContract(blockNumber, txn, logger)
+	}
+}
+
+// deployGovernanceContract deploys the stub code of the natively run governance
+// contract, unless the genesis already did
+func deployGovernanceContract(blockNumber uint64, txn *state.Txn, logger hclog.Logger) {
+	if governance.IsDeployed(txn) {
+		return
+	}
+
+	logger.Info(fmt.Sprintf("Deploy governance contract %s at height %d",
+		systemcontracts.AddrGovernanceContract.String(), blockNumber))
+
+	txn.SetCode(systemcontracts.AddrGovernanceContract, governance.StubCode)
 }
 
 func applySystemContractUpgrade(upgrade *Upgrade, blockNumber uint64, txn *state.Txn, logger hclog.Logger) {
@@ -0,0 +1,63 @@
+package validatorset
+
+import (
+	"errors"
+
+	"github.com/dogechain-lab/dogechain/contracts/abis"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/umbracle/go-web3"
+	"github.com/umbracle/go-web3/abi"
+)
+
+const (
+	EventValidatorAdded   = "ValidatorAdded"
+	EventValidatorDeleted = "ValidatorDeleted"
+
+	fieldAccount = "account"
+)
+
+// Frequently used events. Must exist.
+var (
+	ValidatorAddedEvent     = abis.ValidatorSetABI.Events[EventValidatorAdded]
+	ValidatorAddedEventID   = types.Hash(ValidatorAddedEvent.ID())
+	ValidatorDeletedEvent   = abis.ValidatorSetABI.Events[EventValidatorDeleted]
+	ValidatorDeletedEventID = types.Hash(ValidatorDeletedEvent.ID())
+)
+
+// ParseValidatorAddedLog returns the account joining the validator set
+func ParseValidatorAddedLog(log *types.Log) (types.Address, error) {
+	return parseValidatorAccount(ValidatorAddedEvent, log)
+}
+
+// ParseValidatorDeletedLog returns the account leaving the validator set
+func ParseValidatorDeletedLog(log *types.Log) (types.Address, error) {
+	return parseValidatorAccount(ValidatorDeletedEvent, log)
+}
+
+func parseValidatorAccount(event *abi.Event, log *types.Log) (types.Address, error) {
+	topics := make([]web3.Hash, 0, len(log.Topics))
+	for _, topic := range log.Topics {
+		topics = append(topics, web3.Hash(topic))
+	}
+
+	w3Log, err := event.ParseLog(&web3.Log{
+		Address: web3.Address(log.Address),
+		Topics:  topics,
+		Data:    log.Data,
+	})
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	account, ok := w3Log[fieldAccount]
+	if !ok {
+		return types.ZeroAddress, errors.New("account not exists in validator event")
+	}
+
+	addr, ok := account.(web3.Address)
+	if !ok {
+		return types.ZeroAddress, errors.New("account downcast failed")
+	}
+
+	return types.Address(addr), nil
+}
@@ -0,0 +1,63 @@
+package validatorset
+
+import (
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newValidatorLog(eventID types.Hash, sender, account types.Address) *types.Log {
+	return &types.Log{
+		Address: systemcontracts.AddrValidatorSetContract,
+		Topics: []types.Hash{
+			eventID,
+			types.BytesToHash(sender.Bytes()),
+			types.BytesToHash(account.Bytes()),
+		},
+	}
+}
+
+func TestParseValidatorLogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      *types.Log
+		parse    func(*types.Log) (types.Address, error)
+		expected types.Address
+		err      bool
+	}{
+		{
+			name:     "validator added",
+			log:      newValidatorLog(ValidatorAddedEventID, addr1, addr2),
+			parse:    ParseValidatorAddedLog,
+			expected: addr2,
+		},
+		{
+			name:     "validator deleted",
+			log:      newValidatorLog(ValidatorDeletedEventID, addr2, addr1),
+			parse:    ParseValidatorDeletedLog,
+			expected: addr1,
+		},
+		{
+			name:  "mismatched event",
+			log:   newValidatorLog(ValidatorDeletedEventID, addr1, addr2),
+			parse: ParseValidatorAddedLog,
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := tt.parse(tt.log)
+			if tt.err {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, account)
+		})
+	}
+}
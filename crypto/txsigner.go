@@ -27,11 +27,15 @@ type TxSigner interface {
 	CalculateV(parity byte) []byte
 }
 
-// NewSigner creates a new signer object (EIP155 or FrontierSigner)
+// NewSigner creates a new signer object (London, Berlin, EIP155 or FrontierSigner)
 func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 	var signer TxSigner
 
-	if forks.EIP155 {
+	if forks.London {
+		signer = NewLondonSigner(chainID)
+	} else if forks.Berlin {
+		signer = NewBerlinSigner(chainID)
+	} else if forks.EIP155 {
 		signer = &EIP155Signer{chainID: chainID}
 	} else {
 		signer = &FrontierSigner{}
@@ -227,6 +231,200 @@ func (e *EIP155Signer) CalculateV(parity byte) []byte {
 	return reference.Bytes()
 }
 
+// NewBerlinSigner returns a new BerlinSigner object
+func NewBerlinSigner(chainID uint64) *BerlinSigner {
+	return &BerlinSigner{EIP155Signer: EIP155Signer{chainID: chainID}}
+}
+
+// BerlinSigner handles access list transactions (EIP-2930),
+// legacy transactions are handled by the EIP155Signer
+type BerlinSigner struct {
+	EIP155Signer
+}
+
+// calcAccessListTxHash calculates the signing hash of the access list transaction,
+// which is the keccak256 hash of the type byte followed by the RLP payload
+func calcAccessListTxHash(tx *types.Transaction, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewUint(chainID))
+	v.Set(a.NewUint(tx.Nonce))
+	v.Set(a.NewBigInt(tx.GasPrice))
+	v.Set(a.NewUint(tx.Gas))
+
+	if tx.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tx.To).Bytes()))
+	}
+
+	v.Set(a.NewBigInt(tx.Value))
+	v.Set(a.NewCopyBytes(tx.Input))
+	v.Set(tx.AccessList.MarshalRLPWith(a))
+
+	hash := keccak.Keccak256(nil, v.MarshalTo([]byte{byte(types.AccessListTx)}))
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// Hash returns the signing hash of the transaction
+func (b *BerlinSigner) Hash(tx *types.Transaction) types.Hash {
+	if tx.Type != types.AccessListTx {
+		return b.EIP155Signer.Hash(tx)
+	}
+
+	return calcAccessListTxHash(tx, b.chainID)
+}
+
+// Sender returns the transaction sender
+func (b *BerlinSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	if tx.Type != types.AccessListTx {
+		return b.EIP155Signer.Sender(tx)
+	}
+
+	return typedTxSender(tx, b.chainID, b.Hash)
+}
+
+// SignTx signs the transaction using the passed in private key
+func (b *BerlinSigner) SignTx(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	if tx.Type != types.AccessListTx {
+		return b.EIP155Signer.SignTx(tx, privateKey)
+	}
+
+	return signTypedTx(tx, b.chainID, b.Hash, privateKey)
+}
+
+// NewLondonSigner returns a new LondonSigner object
+func NewLondonSigner(chainID uint64) *LondonSigner {
+	return &LondonSigner{BerlinSigner: *NewBerlinSigner(chainID)}
+}
+
+// LondonSigner handles dynamic fee transactions (EIP-1559),
+// the other transactions are handled by the BerlinSigner
+type LondonSigner struct {
+	BerlinSigner
+}
+
+// calcDynamicFeeTxHash calculates the signing hash of the dynamic fee transaction,
+// which is the keccak256 hash of the type byte followed by the RLP payload
+func calcDynamicFeeTxHash(tx *types.Transaction, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewUint(chainID))
+	v.Set(a.NewUint(tx.Nonce))
+	v.Set(a.NewBigInt(tx.GasTipCap))
+	v.Set(a.NewBigInt(tx.GasFeeCap))
+	v.Set(a.NewUint(tx.Gas))
+
+	if tx.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tx.To).Bytes()))
+	}
+
+	v.Set(a.NewBigInt(tx.Value))
+	v.Set(a.NewCopyBytes(tx.Input))
+	v.Set(tx.AccessList.MarshalRLPWith(a))
+
+	hash := keccak.Keccak256(nil, v.MarshalTo([]byte{byte(types.DynamicFeeTx)}))
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// Hash returns the signing hash of the transaction
+func (l *LondonSigner) Hash(tx *types.Transaction) types.Hash {
+	if !tx.IsDynamicFee() {
+		return l.BerlinSigner.Hash(tx)
+	}
+
+	return calcDynamicFeeTxHash(tx, l.chainID)
+}
+
+// Sender returns the transaction sender
+func (l *LondonSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	if !tx.IsDynamicFee() {
+		return l.BerlinSigner.Sender(tx)
+	}
+
+	return typedTxSender(tx, l.chainID, l.Hash)
+}
+
+// SignTx signs the transaction using the passed in private key
+func (l *LondonSigner) SignTx(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	if !tx.IsDynamicFee() {
+		return l.BerlinSigner.SignTx(tx, privateKey)
+	}
+
+	return signTypedTx(tx, l.chainID, l.Hash, privateKey)
+}
+
+// typedTxSender recovers the sender of the typed transaction from its signing hash,
+// its V being the signature parity
+func typedTxSender(
+	tx *types.Transaction,
+	chainID uint64,
+	hash func(*types.Transaction) types.Hash,
+) (types.Address, error) {
+	if tx.ChainID == nil || !tx.ChainID.IsUint64() || tx.ChainID.Uint64() != chainID {
+		return types.Address{}, fmt.Errorf("invalid chain id %v, expected %d", tx.ChainID, chainID)
+	}
+
+	// V is the signature parity
+	if tx.V == nil || !tx.V.IsUint64() || tx.V.Uint64() > 1 {
+		return types.Address{}, fmt.Errorf("invalid txn signature")
+	}
+
+	sig, err := encodeSignature(tx.R, tx.S, byte(tx.V.Uint64()))
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	pub, err := Ecrecover(hash(tx).Bytes(), sig)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	buf := Keccak256(pub[1:])[12:]
+
+	return types.BytesToAddress(buf), nil
+}
+
+// signTypedTx signs the typed transaction of the chain, setting its V to the signature parity
+func signTypedTx(
+	tx *types.Transaction,
+	chainID uint64,
+	hash func(*types.Transaction) types.Hash,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	tx = tx.Copy()
+	tx.ChainID = new(big.Int).SetUint64(chainID)
+
+	h := hash(tx)
+
+	sig, err := Sign(privateKey, h[:])
+	if err != nil {
+		return nil, err
+	}
+
+	tx.R = new(big.Int).SetBytes(sig[:32])
+	tx.S = new(big.Int).SetBytes(sig[32:64])
+	tx.V = new(big.Int).SetUint64(uint64(sig[64]))
+
+	return tx, nil
+}
+
 // encodeSignature generates a signature value based on the R, S and V value
 func encodeSignature(R, S *big.Int, V byte) ([]byte, error) {
 	if !ValidateSignatureValues(V, R, S) {
@@ -138,3 +138,97 @@ func TestEIP155Signer_ChainIDMismatch(t *testing.T) {
 		}
 	}
 }
+
+func TestLondonSigner_DynamicFeeTx(t *testing.T) {
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	txn := &types.Transaction{
+		Type:      types.DynamicFeeTx,
+		To:        &toAddress,
+		Value:     big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21000,
+	}
+
+	signer := NewLondonSigner(100)
+
+	signedTx, err := signer.SignTx(txn, key)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), signedTx.ChainID)
+
+	// the signature survives the envelope encoding
+	decoded := new(types.Transaction)
+	assert.NoError(t, decoded.UnmarshalRLP(signedTx.MarshalRLP()))
+
+	from, err := signer.Sender(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+
+	// a signer of another chain rejects it
+	_, err = NewLondonSigner(1).Sender(decoded)
+	assert.Error(t, err)
+
+	// legacy transactions are still handled
+	legacyTx, err := signer.SignTx(&types.Transaction{
+		To:       &toAddress,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(1),
+	}, key)
+	assert.NoError(t, err)
+
+	from, err = NewEIP155Signer(100).Sender(legacyTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+}
+
+func TestBerlinSigner_AccessListTx(t *testing.T) {
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	txn := &types.Transaction{
+		Type:     types.AccessListTx,
+		To:       &toAddress,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(1),
+		Gas:      21000,
+		AccessList: types.AccessList{
+			{
+				Address:     toAddress,
+				StorageKeys: []types.Hash{types.StringToHash("1")},
+			},
+		},
+	}
+
+	signer := NewBerlinSigner(100)
+
+	signedTx, err := signer.SignTx(txn, key)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), signedTx.ChainID)
+
+	// the signature survives the envelope encoding
+	decoded := new(types.Transaction)
+	assert.NoError(t, decoded.UnmarshalRLP(signedTx.MarshalRLP()))
+
+	from, err := signer.Sender(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+
+	// the London signer handles it as well
+	from, err = NewLondonSigner(100).Sender(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+
+	// the access list is signed
+	decoded.AccessList = nil
+
+	from, err = signer.Sender(decoded)
+	if err == nil {
+		assert.NotEqual(t, PubKeyToAddress(&key.PublicKey), from)
+	}
+}
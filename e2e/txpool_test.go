@@ -12,6 +12,7 @@ import (
 
 	"github.com/dogechain-lab/dogechain/crypto"
 	"github.com/dogechain-lab/dogechain/e2e/framework"
+	"github.com/dogechain-lab/dogechain/helper/errcode"
 	"github.com/dogechain-lab/dogechain/helper/tests"
 	"github.com/dogechain-lab/dogechain/txpool"
 	txpoolOp "github.com/dogechain-lab/dogechain/txpool/proto"
@@ -151,7 +152,7 @@ func TestTxPool_ErrorCodes(t *testing.T) {
 			}
 
 			assert.NotNil(t, addErr)
-			assert.Contains(t, addErr.Error(), testCase.expectedError.Error())
+			assert.Equal(t, errcode.GetCode(testCase.expectedError), errcode.GetCode(addErr))
 		})
 	}
 }
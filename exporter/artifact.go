@@ -0,0 +1,138 @@
+package exporter
+
+import (
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// Artifact is the execution result of a block published to the sinks
+type Artifact struct {
+	Number     uint64     `json:"number"`
+	Hash       types.Hash `json:"hash"`
+	ParentHash types.Hash `json:"parentHash"`
+	Timestamp  uint64     `json:"timestamp"`
+
+	// Block is the hex encoded RLP of the full block
+	Block     string         `json:"block"`
+	Receipts  []*Receipt     `json:"receipts"`
+	StateDiff []*AccountDiff `json:"stateDiff"`
+}
+
+// Receipt is the receipt of a transaction of the block
+type Receipt struct {
+	TxHash            types.Hash     `json:"transactionHash"`
+	TxIndex           uint64         `json:"transactionIndex"`
+	Status            uint64         `json:"status"`
+	GasUsed           uint64         `json:"gasUsed"`
+	CumulativeGasUsed uint64         `json:"cumulativeGasUsed"`
+	ContractAddress   *types.Address `json:"contractAddress,omitempty"`
+	Logs              []*Log         `json:"logs"`
+}
+
+// Log is a log emitted by a transaction of the block
+type Log struct {
+	Address types.Address `json:"address"`
+	Topics  []types.Hash  `json:"topics"`
+	Data    string        `json:"data"`
+	// LogIndex is the index of the log in the block
+	LogIndex uint64 `json:"logIndex"`
+}
+
+// AccountDiff is the state of an account changed by the block
+type AccountDiff struct {
+	Address  types.Address `json:"address"`
+	Deleted  bool          `json:"deleted,omitempty"`
+	Nonce    uint64        `json:"nonce"`
+	Balance  string        `json:"balance"`
+	CodeHash types.Hash    `json:"codeHash"`
+	// Code is only set if it is deployed by the block
+	Code    string         `json:"code,omitempty"`
+	Storage []*StorageDiff `json:"storage,omitempty"`
+}
+
+// StorageDiff is a storage slot changed by the block
+type StorageDiff struct {
+	Key     types.Hash `json:"key"`
+	Value   types.Hash `json:"value"`
+	Deleted bool       `json:"deleted,omitempty"`
+}
+
+// newArtifact builds the artifact of the block from its receipts and state changes
+func newArtifact(block *types.Block, receipts []*types.Receipt, objs []*state.Object) *Artifact {
+	artifact := &Artifact{
+		Number:     block.Number(),
+		Hash:       block.Hash(),
+		ParentHash: block.ParentHash(),
+		Timestamp:  block.Header.Timestamp,
+		Block:      hex.EncodeToHex(block.MarshalRLP()),
+		Receipts:   make([]*Receipt, 0, len(receipts)),
+		StateDiff:  NewStateDiff(objs),
+	}
+
+	logIndex := uint64(0)
+
+	for txIndex, raw := range receipts {
+		receipt := &Receipt{
+			TxHash:            raw.TxHash,
+			TxIndex:           uint64(txIndex),
+			GasUsed:           raw.GasUsed,
+			CumulativeGasUsed: raw.CumulativeGasUsed,
+			ContractAddress:   raw.ContractAddress,
+			Logs:              make([]*Log, 0, len(raw.Logs)),
+		}
+
+		if raw.Status != nil {
+			receipt.Status = uint64(*raw.Status)
+		}
+
+		for _, log := range raw.Logs {
+			receipt.Logs = append(receipt.Logs, &Log{
+				Address:  log.Address,
+				Topics:   log.Topics,
+				Data:     hex.EncodeToHex(log.Data),
+				LogIndex: logIndex,
+			})
+
+			logIndex++
+		}
+
+		artifact.Receipts = append(artifact.Receipts, receipt)
+	}
+
+	return artifact
+}
+
+// NewStateDiff returns the diff of the changed accounts
+func NewStateDiff(objs []*state.Object) []*AccountDiff {
+	stateDiff := make([]*AccountDiff, 0, len(objs))
+
+	for _, obj := range objs {
+		diff := &AccountDiff{
+			Address:  obj.Address,
+			Deleted:  obj.Deleted,
+			Nonce:    obj.Nonce,
+			CodeHash: obj.CodeHash,
+		}
+
+		if obj.Balance != nil {
+			diff.Balance = hex.EncodeBig(obj.Balance)
+		}
+
+		if obj.DirtyCode {
+			diff.Code = hex.EncodeToHex(obj.Code)
+		}
+
+		for _, entry := range obj.Storage {
+			diff.Storage = append(diff.Storage, &StorageDiff{
+				Key:     types.BytesToHash(entry.Key),
+				Value:   types.BytesToHash(entry.Val),
+				Deleted: entry.Deleted,
+			})
+		}
+
+		stateDiff = append(stateDiff, diff)
+	}
+
+	return stateDiff
+}
@@ -0,0 +1,228 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultRetryInterval is the delay before publishing an artifact again once the sink failed
+	DefaultRetryInterval = 5 * time.Second
+	// pollInterval is the delay before checking the head again, in case an event is missed
+	pollInterval = 2 * time.Second
+	// StateDiffCacheSize is the number of latest blocks whose state changes are
+	// kept for the exporter, so that they are not executed twice
+	StateDiffCacheSize = 64
+)
+
+var ErrBlockNotFound = errors.New("block not found")
+
+// store is the blockchain the artifacts are read from
+type store interface {
+	Header() *types.Header
+	GetBlockByNumber(number uint64, full bool) (*types.Block, bool)
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+	GetStateDiff(block *types.Block) ([]*state.Object, error)
+	SubscribeEvents() blockchain.Subscription
+}
+
+// Config is the configuration of the exporter
+type Config struct {
+	// CheckpointPath is the file holding the number of the last block acknowledged by the sink
+	CheckpointPath string
+	// From is the first block to export if there is no checkpoint yet, the genesis is never exported
+	From uint64
+	// RetryInterval is the delay before publishing an artifact again once the sink failed
+	RetryInterval time.Duration
+}
+
+// Exporter publishes the execution result of every block to a sink, in order.
+// The delivery is at least once: the checkpoint is saved once the sink acknowledged
+// the artifact, so that the last artifact could be published again after a crash.
+type Exporter struct {
+	logger hclog.Logger
+	store  store
+	sink   Sink
+	config *Config
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+// NewExporter creates an exporter of the blocks of the store to the sink
+func NewExporter(logger hclog.Logger, store store, sink Sink, config *Config) *Exporter {
+	if config.RetryInterval == 0 {
+		config.RetryInterval = DefaultRetryInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Exporter{
+		logger: logger.Named("exporter"),
+		store:  store,
+		sink:   sink,
+		config: config,
+		ctx:    ctx,
+		cancel: cancel,
+		doneCh: make(chan struct{}),
+	}
+}
+
+// Start starts exporting from the block following the checkpoint
+func (e *Exporter) Start() error {
+	next, err := e.resumeFrom()
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info("exporting blocks", "from", next)
+
+	go e.run(next)
+
+	return nil
+}
+
+// Close stops the exporter and closes the sink
+func (e *Exporter) Close() error {
+	e.cancel()
+	<-e.doneCh
+
+	return e.sink.Close()
+}
+
+// resumeFrom returns the first block to export
+func (e *Exporter) resumeFrom() (uint64, error) {
+	last, ok, err := ReadCheckpoint(e.config.CheckpointPath)
+	if err != nil {
+		return 0, err
+	}
+
+	if !ok {
+		// the genesis block has no execution result
+		if e.config.From == 0 {
+			return 1, nil
+		}
+
+		return e.config.From, nil
+	}
+
+	return last + 1, nil
+}
+
+func (e *Exporter) run(next uint64) {
+	defer close(e.doneCh)
+
+	sub := e.store.SubscribeEvents()
+	defer sub.Close()
+
+	eventCh := sub.GetEventCh()
+
+	for {
+		for header := e.store.Header(); header != nil && next <= header.Number; next++ {
+			if !e.exportWithRetry(next) {
+				return
+			}
+		}
+
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-eventCh:
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// exportWithRetry exports the block until the sink acknowledges it,
+// it returns false if the exporter is closed meanwhile
+func (e *Exporter) exportWithRetry(number uint64) bool {
+	for {
+		err := e.export(number)
+		if err == nil {
+			return true
+		}
+
+		if e.ctx.Err() != nil {
+			return false
+		}
+
+		e.logger.Warn("failed to export block, retrying", "number", number, "err", err)
+
+		select {
+		case <-e.ctx.Done():
+			return false
+		case <-time.After(e.config.RetryInterval):
+		}
+	}
+}
+
+// export publishes the artifact of the block, then saves the checkpoint
+func (e *Exporter) export(number uint64) error {
+	block, ok := e.store.GetBlockByNumber(number, true)
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrBlockNotFound, number)
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return fmt.Errorf("unable to get receipts, %w", err)
+	}
+
+	objs, err := e.store.GetStateDiff(block)
+	if err != nil {
+		return fmt.Errorf("unable to get state diff, %w", err)
+	}
+
+	if err := e.sink.Publish(e.ctx, newArtifact(block, receipts, objs)); err != nil {
+		return fmt.Errorf("unable to publish, %w", err)
+	}
+
+	if err := WriteCheckpoint(e.config.CheckpointPath, number); err != nil {
+		return fmt.Errorf("unable to save checkpoint, %w", err)
+	}
+
+	e.logger.Debug("block exported", "number", number, "hash", block.Hash())
+
+	return nil
+}
+
+// ReadCheckpoint returns the number of the last block processed, if any
+func ReadCheckpoint(path string) (uint64, bool, error) {
+	data, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+
+	number, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid checkpoint %s, %w", path, err)
+	}
+
+	return number, true, nil
+}
+
+// WriteCheckpoint saves the number of the last block processed,
+// replacing the checkpoint atomically
+func WriteCheckpoint(path string, number uint64) error {
+	tmp := path + ".tmp"
+
+	if err := ioutil.WriteFile(tmp, []byte(strconv.FormatUint(number, 10)), 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,253 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var errSinkDown = errors.New("sink down")
+
+type mockStore struct {
+	lock   sync.Mutex
+	blocks []*types.Block
+}
+
+func newMockStore(head uint64) *mockStore {
+	s := &mockStore{}
+
+	for i := uint64(0); i <= head; i++ {
+		s.addBlock()
+	}
+
+	return s
+}
+
+func (s *mockStore) addBlock() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	header := &types.Header{Number: uint64(len(s.blocks))}
+	if len(s.blocks) > 0 {
+		header.ParentHash = s.blocks[len(s.blocks)-1].Hash()
+	}
+
+	header.ComputeHash()
+
+	s.blocks = append(s.blocks, &types.Block{Header: header})
+}
+
+func (s *mockStore) Header() *types.Header {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.blocks[len(s.blocks)-1].Header
+}
+
+func (s *mockStore) GetBlockByNumber(number uint64, _ bool) (*types.Block, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if number >= uint64(len(s.blocks)) {
+		return nil, false
+	}
+
+	return s.blocks[number], true
+}
+
+func (s *mockStore) GetReceiptsByHash(types.Hash) ([]*types.Receipt, error) {
+	return []*types.Receipt{}, nil
+}
+
+func (s *mockStore) GetStateDiff(block *types.Block) ([]*state.Object, error) {
+	return []*state.Object{
+		{
+			Address: types.StringToAddress("1"),
+			Balance: new(big.Int).SetUint64(block.Number()),
+		},
+	}, nil
+}
+
+func (s *mockStore) SubscribeEvents() blockchain.Subscription {
+	return blockchain.NewMockSubscription()
+}
+
+type mockSink struct {
+	lock      sync.Mutex
+	failures  int
+	published []uint64
+}
+
+func (s *mockSink) Publish(_ context.Context, record interface{}) error {
+	artifact, ok := record.(*Artifact)
+	if !ok {
+		return errors.New("not an artifact")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+
+		return errSinkDown
+	}
+
+	s.published = append(s.published, artifact.Number)
+
+	return nil
+}
+
+func (s *mockSink) Close() error {
+	return nil
+}
+
+func (s *mockSink) Published() []uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]uint64{}, s.published...)
+}
+
+func newTestExporter(t *testing.T, store store, sink Sink, checkpoint string) *Exporter {
+	t.Helper()
+
+	e := NewExporter(hclog.NewNullLogger(), store, sink, &Config{
+		CheckpointPath: checkpoint,
+		RetryInterval:  10 * time.Millisecond,
+	})
+
+	assert.NoError(t, e.Start())
+
+	return e
+}
+
+func waitForPublished(t *testing.T, sink *mockSink, count int) {
+	t.Helper()
+
+	assert.Eventually(t, func() bool {
+		return len(sink.Published()) >= count
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestExporter_ExportsInOrder(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore(3)
+	sink := &mockSink{}
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+
+	e := newTestExporter(t, store, sink, checkpoint)
+
+	waitForPublished(t, sink, 3)
+
+	// blocks sealed afterwards are picked up as well
+	store.addBlock()
+	waitForPublished(t, sink, 4)
+
+	assert.NoError(t, e.Close())
+	assert.Equal(t, []uint64{1, 2, 3, 4}, sink.Published())
+
+	last, ok, err := ReadCheckpoint(checkpoint)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(4), last)
+}
+
+func TestExporter_RetriesOnSinkFailure(t *testing.T) {
+	t.Parallel()
+
+	sink := &mockSink{failures: 3}
+
+	e := newTestExporter(t, newMockStore(2), sink, filepath.Join(t.TempDir(), "checkpoint"))
+
+	waitForPublished(t, sink, 2)
+	assert.NoError(t, e.Close())
+
+	// no block is skipped nor published twice
+	assert.Equal(t, []uint64{1, 2}, sink.Published())
+}
+
+func TestExporter_ResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	assert.NoError(t, WriteCheckpoint(checkpoint, 2))
+
+	sink := &mockSink{}
+
+	e := newTestExporter(t, newMockStore(4), sink, checkpoint)
+
+	waitForPublished(t, sink, 2)
+	assert.NoError(t, e.Close())
+
+	assert.Equal(t, []uint64{3, 4}, sink.Published())
+}
+
+func TestNewSink(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name string
+		uri  string
+		err  error
+	}{
+		{"file sink", "file://" + filepath.Join(t.TempDir(), "artifacts"), nil},
+		{"http sink", "http://127.0.0.1:8080/topics/blocks", nil},
+		{"missing scheme", "127.0.0.1:8080", ErrInvalidSinkURI},
+		{"missing target", "kafka://", ErrInvalidSinkURI},
+		{"unknown scheme", "kafka://127.0.0.1:9092/blocks", ErrUnknownSink},
+	}
+
+	for _, testCase := range testTable {
+		sink, err := NewSink(testCase.uri)
+
+		if testCase.err != nil {
+			assert.ErrorIs(t, err, testCase.err, testCase.name)
+
+			continue
+		}
+
+		assert.NoError(t, err, testCase.name)
+		assert.NoError(t, sink.Close(), testCase.name)
+	}
+}
+
+func TestNewArtifact_LogIndexes(t *testing.T) {
+	t.Parallel()
+
+	status := types.ReceiptSuccess
+	receipts := []*types.Receipt{
+		{Status: &status, Logs: []*types.Log{{}, {}}},
+		{Status: &status},
+		{Status: &status, Logs: []*types.Log{{}}},
+	}
+
+	header := &types.Header{Number: 1}
+	header.ComputeHash()
+
+	artifact := newArtifact(&types.Block{Header: header}, receipts, nil)
+
+	indexes := []uint64{}
+
+	for txIndex, receipt := range artifact.Receipts {
+		assert.Equal(t, uint64(txIndex), receipt.TxIndex)
+
+		for _, log := range receipt.Logs {
+			indexes = append(indexes, log.LogIndex)
+		}
+	}
+
+	// log indexes are counted across the block
+	assert.Equal(t, []uint64{0, 1, 2}, indexes)
+}
@@ -0,0 +1,157 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidSinkURI = errors.New("invalid sink uri, expected <scheme>://<target>")
+	ErrUnknownSink    = errors.New("unknown sink")
+)
+
+// Sink publishes the records, such as the artifacts or the audit matches,
+// to an external system, such as a message queue
+type Sink interface {
+	// Publish delivers the JSON encoded record. It returns once the record is acknowledged,
+	// so that the exporter could move to the next block
+	Publish(ctx context.Context, record interface{}) error
+	// Close releases the resources of the sink
+	Close() error
+}
+
+// SinkFactory creates a sink publishing to the target
+type SinkFactory func(target string) (Sink, error)
+
+var (
+	sinksLock sync.RWMutex
+	sinks     = map[string]SinkFactory{
+		"file":  newFileSink,
+		"http":  newHTTPSink,
+		"https": newHTTPSink,
+	}
+)
+
+// RegisterSink registers the factory of the sinks of the scheme, so that
+// message queue clients such as Kafka or NATS could be plugged in
+func RegisterSink(scheme string, factory SinkFactory) {
+	sinksLock.Lock()
+	defer sinksLock.Unlock()
+
+	sinks[scheme] = factory
+}
+
+// NewSink creates the sink of the uri, in the form of <scheme>://<target>
+func NewSink(uri string) (Sink, error) {
+	parts := strings.SplitN(uri, "://", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, ErrInvalidSinkURI
+	}
+
+	scheme, target := parts[0], parts[1]
+
+	sinksLock.RLock()
+	factory, ok := sinks[scheme]
+	sinksLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSink, scheme)
+	}
+
+	// http sinks need the full url
+	if scheme == "http" || scheme == "https" {
+		target = uri
+	}
+
+	return factory(target)
+}
+
+// fileSink appends the records to a file, one JSON document per line
+type fileSink struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+func newFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileSink{file: file}, nil
+}
+
+func (s *fileSink) Publish(_ context.Context, record interface{}) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	// the record is only acknowledged once it is durable
+	return s.file.Sync()
+}
+
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
+
+// httpSink posts the records to an endpoint, such as a message queue REST proxy.
+// Any 2xx response acknowledges the record
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPSink(url string) (Sink, error) {
+	return &httpSink{
+		url:    url,
+		client: &http.Client{},
+	}, nil
+}
+
+func (s *httpSink) Publish(ctx context.Context, record interface{}) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	s.client.CloseIdleConnections()
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package gasprice
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultBlocks is the number of the latest blocks sampled by default
+	DefaultBlocks = 20
+	// DefaultPercentile is the percentile of the sampled tips suggested by default
+	DefaultPercentile = 60
+	// maxBlocks is the most blocks sampled
+	maxBlocks = 1024
+	// samplesPerBlock is the number of the lowest tips sampled in a block
+	samplesPerBlock = 3
+)
+
+// ignorePrice is the tip below which a transaction is not sampled
+var ignorePrice = big.NewInt(2)
+
+// store is the blockchain the transactions are sampled from
+type store interface {
+	Header() *types.Header
+	GetBlockByNumber(number uint64, full bool) (*types.Block, bool)
+	CalculateBaseFee(parent *types.Header) uint64
+}
+
+// Config is the configuration of the oracle
+type Config struct {
+	// Blocks is the number of the latest blocks sampled
+	Blocks uint64
+	// Percentile is the percentile of the sampled tips suggested
+	Percentile uint64
+	// Default is the tip suggested until a transaction is sampled
+	Default *big.Int
+}
+
+// Oracle suggests the tip and the gas price of a new transaction from the tips
+// of the transactions of the latest blocks, like the oracle of geth: the lowest
+// tips of every block are sampled, and the suggestion is a percentile of the samples.
+// Blocks without transactions sample the previous suggestion.
+type Oracle struct {
+	logger hclog.Logger
+	store  store
+	config *Config
+
+	lock     sync.Mutex
+	lastHead types.Hash
+	lastTip  *big.Int
+}
+
+// NewOracle creates an oracle sampling the blocks of the store
+func NewOracle(logger hclog.Logger, store store, config *Config) *Oracle {
+	logger = logger.Named("gasprice")
+
+	if config.Blocks == 0 {
+		config.Blocks = DefaultBlocks
+	} else if config.Blocks > maxBlocks {
+		logger.Warn("sanitizing the number of sampled blocks", "provided", config.Blocks, "updated", maxBlocks)
+		config.Blocks = maxBlocks
+	}
+
+	if config.Percentile > 100 {
+		logger.Warn("sanitizing the percentile", "provided", config.Percentile, "updated", 100)
+		config.Percentile = 100
+	}
+
+	lastTip := new(big.Int)
+	if config.Default != nil {
+		lastTip.Set(config.Default)
+	}
+
+	return &Oracle{
+		logger:  logger,
+		store:   store,
+		config:  config,
+		lastTip: lastTip,
+	}
+}
+
+// SuggestTipCap returns the tip a new transaction should pay to be included in the next blocks
+func (o *Oracle) SuggestTipCap() *big.Int {
+	head := o.store.Header()
+	if head == nil {
+		return new(big.Int)
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	// the suggestion only changes with the head
+	if head.Hash == o.lastHead {
+		return new(big.Int).Set(o.lastTip)
+	}
+
+	var samples []*big.Int
+
+	for i := uint64(0); i < o.config.Blocks && i < head.Number; i++ {
+		block, ok := o.store.GetBlockByNumber(head.Number-i, true)
+		if !ok {
+			o.logger.Debug("unable to sample block", "number", head.Number-i)
+
+			break
+		}
+
+		tips := blockTips(block)
+		if len(tips) == 0 {
+			tips = []*big.Int{o.lastTip}
+		}
+
+		samples = append(samples, tips...)
+	}
+
+	tip := o.lastTip
+
+	if len(samples) > 0 {
+		sort.Slice(samples, func(i, j int) bool {
+			return samples[i].Cmp(samples[j]) < 0
+		})
+
+		tip = samples[(len(samples)-1)*int(o.config.Percentile)/100]
+	}
+
+	o.lastHead = head.Hash
+	o.lastTip = new(big.Int).Set(tip)
+
+	return new(big.Int).Set(tip)
+}
+
+// SuggestGasPrice returns the gas price a new transaction should pay to be included
+// in the next blocks, the suggested tip plus the base fee of the next block
+func (o *Oracle) SuggestGasPrice() *big.Int {
+	head := o.store.Header()
+	if head == nil {
+		return new(big.Int)
+	}
+
+	tip := o.SuggestTipCap()
+
+	return tip.Add(tip, new(big.Int).SetUint64(o.store.CalculateBaseFee(head)))
+}
+
+// blockTips returns the lowest tips of the transactions of the block,
+// except the ones of the fee recipient, which may include its own transactions for free
+func blockTips(block *types.Block) []*big.Int {
+	baseFee := block.Header.BaseFee
+	tips := make([]*big.Int, 0, len(block.Transactions))
+
+	for _, tx := range block.Transactions {
+		if tx.From == block.Header.Miner {
+			continue
+		}
+
+		if tip := tx.EffectiveTip(baseFee); tip.Cmp(ignorePrice) >= 0 {
+			tips = append(tips, tip)
+		}
+	}
+
+	sort.Slice(tips, func(i, j int) bool {
+		return tips[i].Cmp(tips[j]) < 0
+	})
+
+	if len(tips) > samplesPerBlock {
+		tips = tips[:samplesPerBlock]
+	}
+
+	return tips
+}
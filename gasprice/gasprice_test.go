@@ -0,0 +1,119 @@
+package gasprice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStore struct {
+	blocks      []*types.Block
+	nextBaseFee uint64
+}
+
+func (m *mockStore) add(tips ...int64) {
+	number := uint64(len(m.blocks))
+	header := &types.Header{
+		Number: number,
+		Miner:  types.StringToAddress("1"),
+	}
+	header.Hash = types.BytesToHash(new(big.Int).SetUint64(number + 1).Bytes())
+
+	block := &types.Block{Header: header}
+
+	for _, tip := range tips {
+		block.Transactions = append(block.Transactions, &types.Transaction{
+			GasPrice: big.NewInt(tip),
+			From:     types.StringToAddress("2"),
+		})
+	}
+
+	m.blocks = append(m.blocks, block)
+}
+
+func (m *mockStore) Header() *types.Header {
+	if len(m.blocks) == 0 {
+		return nil
+	}
+
+	return m.blocks[len(m.blocks)-1].Header
+}
+
+func (m *mockStore) GetBlockByNumber(number uint64, full bool) (*types.Block, bool) {
+	if number >= uint64(len(m.blocks)) {
+		return nil, false
+	}
+
+	return m.blocks[number], true
+}
+
+func (m *mockStore) CalculateBaseFee(parent *types.Header) uint64 {
+	return m.nextBaseFee
+}
+
+func TestOracle_SuggestTipCap(t *testing.T) {
+	store := &mockStore{}
+	store.add() // genesis
+
+	oracle := NewOracle(hclog.NewNullLogger(), store, &Config{
+		Blocks:     3,
+		Percentile: 50,
+		Default:    big.NewInt(5),
+	})
+
+	// the default until a transaction is sampled
+	assert.Equal(t, big.NewInt(5), oracle.SuggestTipCap())
+
+	// the lowest tips of every block are sampled, the tips below the ignored price are not
+	store.add(100, 1, 20, 30, 40, 10)
+	store.add(50, 60)
+	store.add(70)
+
+	// samples: 10 20 30 | 50 60 | 70
+	assert.Equal(t, big.NewInt(30), oracle.SuggestTipCap())
+
+	// only the latest blocks are sampled, blocks without transactions sample the last suggestion
+	store.add()
+
+	// samples: 50 60 | 70 | 30
+	assert.Equal(t, big.NewInt(50), oracle.SuggestTipCap())
+}
+
+func TestOracle_IgnoreFeeRecipient(t *testing.T) {
+	store := &mockStore{}
+	store.add()
+	store.add(1000)
+
+	// the fee recipient may include its own transactions for free
+	store.blocks[1].Transactions = append(store.blocks[1].Transactions, &types.Transaction{
+		GasPrice: big.NewInt(3),
+		From:     store.blocks[1].Header.Miner,
+	})
+
+	oracle := NewOracle(hclog.NewNullLogger(), store, &Config{Percentile: 0})
+
+	assert.Equal(t, big.NewInt(1000), oracle.SuggestTipCap())
+}
+
+func TestOracle_SuggestGasPrice(t *testing.T) {
+	store := &mockStore{nextBaseFee: 7}
+	store.add()
+	store.add(100)
+
+	oracle := NewOracle(hclog.NewNullLogger(), store, &Config{Percentile: 100})
+
+	// the tip plus the base fee of the next block
+	assert.Equal(t, big.NewInt(107), oracle.SuggestGasPrice())
+}
+
+func TestNewOracle_Sanitize(t *testing.T) {
+	oracle := NewOracle(hclog.NewNullLogger(), &mockStore{}, &Config{Percentile: 101})
+	assert.Equal(t, uint64(DefaultBlocks), oracle.config.Blocks)
+	assert.Equal(t, uint64(100), oracle.config.Percentile)
+
+	oracle = NewOracle(hclog.NewNullLogger(), &mockStore{}, &Config{Blocks: maxBlocks + 1})
+	assert.Equal(t, uint64(maxBlocks), oracle.config.Blocks)
+}
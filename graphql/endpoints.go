@@ -54,8 +54,8 @@ type ethBlockchainStore interface {
 	// GetAvgGasPrice returns the average gas price
 	GetAvgGasPrice() *big.Int
 
-	// ApplyTxn applies a transaction object to the blockchain
-	ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	// ApplyTxn applies a transaction object to the blockchain, on top of the state overrides if any
+	ApplyTxn(header *types.Header, txn *types.Transaction, overrides state.Overrides) (*runtime.ExecutionResult, error)
 
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
@@ -93,4 +93,16 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// GetCommittedSeals returns the number of committed seals finalizing the header
+	GetCommittedSeals(header *types.Header) (int, error)
+
+	// AddPromoteHook registers the hook notified of the transactions promoted by the pool
+	AddPromoteHook(hook func(txs []*types.Transaction))
+
+	// BloomIndexedBlocks returns the number of the first blocks of the chain covered by the bloom bits index
+	BloomIndexedBlocks() uint64
+
+	// MatchBloomBits returns the numbers of the indexed blocks of the range whose logs bloom might match the filters
+	MatchBloomBits(from, to uint64, filters [][][]byte) ([]uint64, error)
 }
@@ -374,7 +374,12 @@ func (t *Transaction) GasPrice(ctx context.Context) (argtype.Big, error) {
 		return argtype.Big{}, fmt.Errorf("invalid transaction value %x", t.hash)
 	}
 
-	return argtype.Big(*tx.GasPrice), nil
+	// the price paid by a sealed transaction depends on the base fee of its block
+	if t.block != nil && t.block.block != nil {
+		return argtype.Big(*tx.EffectiveGasPrice(t.block.block.Header.BaseFee)), nil
+	}
+
+	return argtype.Big(*tx.GetGasFeeCap()), nil
 }
 
 func (t *Transaction) Value(ctx context.Context) (argtype.Big, error) {
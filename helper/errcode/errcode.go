@@ -0,0 +1,177 @@
+package errcode
+
+import (
+	"errors"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the error domain set in the gRPC error details
+const Domain = "dogechain"
+
+// metadataCode is the gRPC error details metadata key of the code
+const metadataCode = "code"
+
+// Code is a stable error code. Clients should branch on it instead of the error message
+type Code int32
+
+// The error code catalog. Codes must never be changed nor reused once released
+const (
+	Unknown Code = 0
+
+	// txpool errors
+	TxPoolIntrinsicGas        Code = 1001
+	TxPoolBlockLimitExceeded  Code = 1002
+	TxPoolNegativeValue       Code = 1003
+	TxPoolExtractSignature    Code = 1004
+	TxPoolInvalidSender       Code = 1005
+	TxPoolOverflow            Code = 1006
+	TxPoolUnderpriced         Code = 1007
+	TxPoolNonceTooLow         Code = 1008
+	TxPoolInsufficientFunds   Code = 1009
+	TxPoolInvalidAccountState Code = 1010
+	TxPoolAlreadyKnown        Code = 1011
+	TxPoolOversizedData       Code = 1012
+	TxPoolReplaceUnderpriced  Code = 1013
+	TxPoolBlackList           Code = 1014
+	TxPoolTxTypeNotSupported  Code = 1015
+	TxPoolTipAboveFeeCap      Code = 1016
+	TxPoolDeniedAddress       Code = 1017
+	TxPoolNotAllowedAddress   Code = 1018
+	TxPoolNonceTooHigh        Code = 1019
+
+	// executor errors
+	ExecutorNonceIncorrect        Code = 2001
+	ExecutorNotEnoughFundsForGas  Code = 2002
+	ExecutorBlockLimitReached     Code = 2003
+	ExecutorIntrinsicGasOverflow  Code = 2004
+	ExecutorNotEnoughIntrinsicGas Code = 2005
+	ExecutorNotEnoughFunds        Code = 2006
+	ExecutorAllGasUsed            Code = 2007
+	ExecutorExecutionStop         Code = 2008
+	ExecutorTxTypeNotSupported    Code = 2009
+	ExecutorTipAboveFeeCap        Code = 2010
+	ExecutorFeeCapTooLow          Code = 2011
+)
+
+var codeNames = map[Code]string{
+	Unknown: "UNKNOWN",
+
+	TxPoolIntrinsicGas:        "TXPOOL_INTRINSIC_GAS",
+	TxPoolBlockLimitExceeded:  "TXPOOL_BLOCK_LIMIT_EXCEEDED",
+	TxPoolNegativeValue:       "TXPOOL_NEGATIVE_VALUE",
+	TxPoolExtractSignature:    "TXPOOL_EXTRACT_SIGNATURE",
+	TxPoolInvalidSender:       "TXPOOL_INVALID_SENDER",
+	TxPoolOverflow:            "TXPOOL_OVERFLOW",
+	TxPoolUnderpriced:         "TXPOOL_UNDERPRICED",
+	TxPoolNonceTooLow:         "TXPOOL_NONCE_TOO_LOW",
+	TxPoolInsufficientFunds:   "TXPOOL_INSUFFICIENT_FUNDS",
+	TxPoolInvalidAccountState: "TXPOOL_INVALID_ACCOUNT_STATE",
+	TxPoolAlreadyKnown:        "TXPOOL_ALREADY_KNOWN",
+	TxPoolOversizedData:       "TXPOOL_OVERSIZED_DATA",
+	TxPoolReplaceUnderpriced:  "TXPOOL_REPLACE_UNDERPRICED",
+	TxPoolBlackList:           "TXPOOL_BLACKLIST",
+	TxPoolTxTypeNotSupported:  "TXPOOL_TX_TYPE_NOT_SUPPORTED",
+	TxPoolTipAboveFeeCap:      "TXPOOL_TIP_ABOVE_FEE_CAP",
+	TxPoolDeniedAddress:       "TXPOOL_DENIED_ADDRESS",
+	TxPoolNotAllowedAddress:   "TXPOOL_NOT_ALLOWED_ADDRESS",
+	TxPoolNonceTooHigh:        "TXPOOL_NONCE_TOO_HIGH",
+
+	ExecutorNonceIncorrect:        "EXECUTOR_NONCE_INCORRECT",
+	ExecutorNotEnoughFundsForGas:  "EXECUTOR_NOT_ENOUGH_FUNDS_FOR_GAS",
+	ExecutorBlockLimitReached:     "EXECUTOR_BLOCK_LIMIT_REACHED",
+	ExecutorIntrinsicGasOverflow:  "EXECUTOR_INTRINSIC_GAS_OVERFLOW",
+	ExecutorNotEnoughIntrinsicGas: "EXECUTOR_NOT_ENOUGH_INTRINSIC_GAS",
+	ExecutorNotEnoughFunds:        "EXECUTOR_NOT_ENOUGH_FUNDS",
+	ExecutorAllGasUsed:            "EXECUTOR_ALL_GAS_USED",
+	ExecutorExecutionStop:         "EXECUTOR_EXECUTION_STOP",
+	ExecutorTxTypeNotSupported:    "EXECUTOR_TX_TYPE_NOT_SUPPORTED",
+	ExecutorTipAboveFeeCap:        "EXECUTOR_TIP_ABOVE_FEE_CAP",
+	ExecutorFeeCapTooLow:          "EXECUTOR_FEE_CAP_TOO_LOW",
+}
+
+// String returns the name of the code
+func (c Code) String() string {
+	if name, ok := codeNames[c]; ok {
+		return name
+	}
+
+	return codeNames[Unknown]
+}
+
+// Error is an error carrying a stable code
+type Error struct {
+	code Code
+	msg  string
+}
+
+// New creates an error with the code and message
+func New(code Code, msg string) *Error {
+	return &Error{
+		code: code,
+		msg:  msg,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Code returns the code of the error
+func (e *Error) Code() Code {
+	return e.code
+}
+
+// GetCode returns the code of the first coded error in the chain,
+// or the code in the details of a gRPC status error.
+// Unknown is returned if none is found
+func GetCode(err error) Code {
+	if err == nil {
+		return Unknown
+	}
+
+	var codedErr *Error
+	if errors.As(err, &codedErr) {
+		return codedErr.code
+	}
+
+	if st, ok := status.FromError(err); ok {
+		for _, detail := range st.Details() {
+			info, ok := detail.(*errdetails.ErrorInfo)
+			if !ok || info.Domain != Domain {
+				continue
+			}
+
+			if code, err := strconv.ParseInt(info.Metadata[metadataCode], 10, 32); err == nil {
+				return Code(code)
+			}
+		}
+	}
+
+	return Unknown
+}
+
+// ToGRPCError converts the error into a gRPC status error, whose details
+// carry the code. Errors without code are returned as is
+func ToGRPCError(err error) error {
+	code := GetCode(err)
+	if code == Unknown {
+		return err
+	}
+
+	st, detailErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(&errdetails.ErrorInfo{
+		Reason: code.String(),
+		Domain: Domain,
+		Metadata: map[string]string{
+			metadataCode: strconv.Itoa(int(code)),
+		},
+	})
+	if detailErr != nil {
+		return err
+	}
+
+	return st.Err()
+}
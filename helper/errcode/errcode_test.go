@@ -0,0 +1,76 @@
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetCode(t *testing.T) {
+	errNonceTooLow := New(TxPoolNonceTooLow, "nonce too low")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected Code
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: Unknown,
+		},
+		{
+			name:     "plain error",
+			err:      errors.New("plain"),
+			expected: Unknown,
+		},
+		{
+			name:     "coded error",
+			err:      errNonceTooLow,
+			expected: TxPoolNonceTooLow,
+		},
+		{
+			name:     "wrapped coded error",
+			err:      fmt.Errorf("%w: %d < %d", errNonceTooLow, 1, 2),
+			expected: TxPoolNonceTooLow,
+		},
+		{
+			name:     "gRPC error",
+			err:      ToGRPCError(errNonceTooLow),
+			expected: TxPoolNonceTooLow,
+		},
+		{
+			name:     "gRPC error without details",
+			err:      status.Error(codes.InvalidArgument, "nonce too low"),
+			expected: Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetCode(tt.err))
+		})
+	}
+}
+
+func TestToGRPCError(t *testing.T) {
+	err := ToGRPCError(fmt.Errorf("%w: %s", New(ExecutorNotEnoughFunds, "not enough funds"), "0x1"))
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "not enough funds: 0x1", st.Message())
+
+	// errors without code are left untouched
+	plainErr := errors.New("plain")
+	assert.Equal(t, plainErr, ToGRPCError(plainErr))
+}
+
+func TestCode_String(t *testing.T) {
+	assert.Equal(t, "EXECUTOR_ALL_GAS_USED", ExecutorAllGasUsed.String())
+	assert.Equal(t, "UNKNOWN", Code(9999).String())
+}
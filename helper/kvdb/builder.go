@@ -33,27 +33,28 @@ func max(a, b int) int {
 	return b
 }
 
-type LevelDBBuilder interface {
+// Builder builds the database of a backend, the options it does not support being ignored
+type Builder interface {
 	// set cache size
-	SetCacheSize(int) LevelDBBuilder
+	SetCacheSize(int) Builder
 
 	// set handles
-	SetHandles(int) LevelDBBuilder
+	SetHandles(int) Builder
 
 	// set bloom key bits
-	SetBloomKeyBits(int) LevelDBBuilder
+	SetBloomKeyBits(int) Builder
 
 	// set compaction table size
-	SetCompactionTableSize(int) LevelDBBuilder
+	SetCompactionTableSize(int) Builder
 
 	// set compaction table total size
-	SetCompactionTotalSize(int) LevelDBBuilder
+	SetCompactionTotalSize(int) Builder
 
 	// set no sync
-	SetNoSync(bool) LevelDBBuilder
+	SetNoSync(bool) Builder
 
 	// build the storage
-	Build() (KVBatchStorage, error)
+	Build() (Database, error)
 }
 
 type leveldbBuilder struct {
@@ -62,7 +63,7 @@ type leveldbBuilder struct {
 	options *opt.Options
 }
 
-func (builder *leveldbBuilder) SetCacheSize(cacheSize int) LevelDBBuilder {
+func (builder *leveldbBuilder) SetCacheSize(cacheSize int) Builder {
 	cacheSize = max(cacheSize, minLevelDBCache)
 
 	builder.options.BlockCacheCapacity = (cacheSize / 2) * opt.MiB
@@ -76,7 +77,7 @@ func (builder *leveldbBuilder) SetCacheSize(cacheSize int) LevelDBBuilder {
 	return builder
 }
 
-func (builder *leveldbBuilder) SetHandles(handles int) LevelDBBuilder {
+func (builder *leveldbBuilder) SetHandles(handles int) Builder {
 	builder.options.OpenFilesCacheCapacity = max(handles, minLevelDBHandles)
 
 	builder.logger.Info("leveldb",
@@ -86,7 +87,7 @@ func (builder *leveldbBuilder) SetHandles(handles int) LevelDBBuilder {
 	return builder
 }
 
-func (builder *leveldbBuilder) SetBloomKeyBits(bloomKeyBits int) LevelDBBuilder {
+func (builder *leveldbBuilder) SetBloomKeyBits(bloomKeyBits int) Builder {
 	builder.options.Filter = filter.NewBloomFilter(bloomKeyBits)
 
 	builder.logger.Info("leveldb",
@@ -96,7 +97,7 @@ func (builder *leveldbBuilder) SetBloomKeyBits(bloomKeyBits int) LevelDBBuilder
 	return builder
 }
 
-func (builder *leveldbBuilder) SetCompactionTableSize(compactionTableSize int) LevelDBBuilder {
+func (builder *leveldbBuilder) SetCompactionTableSize(compactionTableSize int) Builder {
 	builder.options.CompactionTableSize = compactionTableSize * opt.MiB
 
 	builder.logger.Info("leveldb",
@@ -106,7 +107,7 @@ func (builder *leveldbBuilder) SetCompactionTableSize(compactionTableSize int) L
 	return builder
 }
 
-func (builder *leveldbBuilder) SetCompactionTotalSize(compactionTotalSize int) LevelDBBuilder {
+func (builder *leveldbBuilder) SetCompactionTotalSize(compactionTotalSize int) Builder {
 	builder.options.CompactionTotalSize = compactionTotalSize * opt.MiB
 
 	builder.logger.Info("leveldb",
@@ -116,7 +117,7 @@ func (builder *leveldbBuilder) SetCompactionTotalSize(compactionTotalSize int) L
 	return builder
 }
 
-func (builder *leveldbBuilder) SetNoSync(noSync bool) LevelDBBuilder {
+func (builder *leveldbBuilder) SetNoSync(noSync bool) Builder {
 	builder.options.NoSync = noSync
 
 	builder.logger.Info("leveldb",
@@ -126,7 +127,7 @@ func (builder *leveldbBuilder) SetNoSync(noSync bool) LevelDBBuilder {
 	return builder
 }
 
-func (builder *leveldbBuilder) Build() (KVBatchStorage, error) {
+func (builder *leveldbBuilder) Build() (Database, error) {
 	db, err := leveldb.OpenFile(builder.path, builder.options)
 	if err != nil {
 		return nil, err
@@ -135,8 +136,8 @@ func (builder *leveldbBuilder) Build() (KVBatchStorage, error) {
 	return &levelDBKV{db: db}, nil
 }
 
-// NewBuilder creates the new leveldb storage builder
-func NewLevelDBBuilder(logger hclog.Logger, path string) LevelDBBuilder {
+// NewLevelDBBuilder creates the new leveldb storage builder
+func NewLevelDBBuilder(logger hclog.Logger, path string) Builder {
 	return &leveldbBuilder{
 		logger: logger,
 		path:   path,
@@ -1,7 +1,27 @@
 package kvdb
 
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// Backends of the databases
+const (
+	BackendLevelDB = "leveldb"
+	BackendPebble  = "pebble"
+	BackendMemory  = "memory"
+)
+
+var ErrUnknownBackend = errors.New("unknown database backend")
+
 type KVBatch interface {
 	Set(k, v []byte)
+	Delete(k []byte)
 	Write() error
 }
 
@@ -13,8 +33,139 @@ type KVStorage interface {
 	Close() error
 }
 
-// KVBatchStorage is a batch write for leveldb
-type KVBatchStorage interface {
+// Database is the key-value database of the blockchain and state storages, on any backend
+type Database interface {
 	KVStorage
 	Batch() KVBatch
+
+	// Iterate calls fn with the pairs whose key has the prefix, in key order, until fn returns false.
+	// The slices passed to fn are only valid until it returns
+	Iterate(prefix []byte, fn func(k, v []byte) bool) error
+
+	// Compact compacts the whole storage, reclaiming the space of the deleted keys
+	Compact() error
+
+	// Stats returns the statistics of the backend, as the sizes and compactions of its levels
+	Stats() (string, error)
+}
+
+// NewBuilder creates the builder of the database of the backend at the path
+func NewBuilder(logger hclog.Logger, backend string, path string) (Builder, error) {
+	switch backend {
+	case BackendLevelDB:
+		return NewLevelDBBuilder(logger, path), nil
+	case BackendPebble:
+		return NewPebbleBuilder(logger, path), nil
+	case BackendMemory:
+		return NewMemoryBuilder(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBackend, backend)
+	}
+}
+
+// DetectBackend returns the backend of the database at the path, false if there is none.
+// Pebble writes its options next to the manifest, which leveldb does not
+func DetectBackend(path string) (string, bool) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return "", false
+	}
+
+	backend := ""
+
+	for _, entry := range entries {
+		switch name := entry.Name(); {
+		case strings.HasPrefix(name, "OPTIONS-"):
+			return BackendPebble, true
+		case name == "CURRENT":
+			backend = BackendLevelDB
+		}
+	}
+
+	return backend, backend != ""
+}
+
+// copyBatchSize is the size of the pairs copied per batch
+const copyBatchSize = 16 * 1024 * 1024
+
+// Copy writes all the pairs of the source database to the destination one, returning their number
+func Copy(dst, src Database) (uint64, error) {
+	var (
+		copied uint64
+		size   int
+		err    error
+	)
+
+	batch := dst.Batch()
+
+	if iterErr := src.Iterate(nil, func(k, v []byte) bool {
+		batch.Set(k, v)
+
+		copied++
+		size += len(k) + len(v)
+
+		if size >= copyBatchSize {
+			if err = batch.Write(); err != nil {
+				return false
+			}
+
+			batch = dst.Batch()
+			size = 0
+		}
+
+		return true
+	}); iterErr != nil {
+		return copied, iterErr
+	}
+
+	if err != nil {
+		return copied, err
+	}
+
+	return copied, batch.Write()
+}
+
+// KeyStats are the number and size of the pairs of a category of keys
+type KeyStats struct {
+	Category string `json:"category"`
+	Keys     uint64 `json:"keys"`
+	Size     uint64 `json:"size"`
+}
+
+// Inspect counts the pairs of the database per category of their keys,
+// returning the categories by decreasing size
+func Inspect(db Database, category func(k []byte) string) ([]*KeyStats, error) {
+	stats := map[string]*KeyStats{}
+
+	if err := db.Iterate(nil, func(k, v []byte) bool {
+		name := category(k)
+
+		entry, ok := stats[name]
+		if !ok {
+			entry = &KeyStats{Category: name}
+			stats[name] = entry
+		}
+
+		entry.Keys++
+		entry.Size += uint64(len(k) + len(v))
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	result := make([]*KeyStats, 0, len(stats))
+	for _, entry := range stats {
+		result = append(result, entry)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Size != result[j].Size {
+			return result[i].Size > result[j].Size
+		}
+
+		return result[i].Category < result[j].Category
+	})
+
+	return result, nil
 }
@@ -0,0 +1,140 @@
+package kvdb
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildDatabase(t *testing.T, backend string) (Database, string) {
+	t.Helper()
+
+	path := t.TempDir()
+
+	builder, err := NewBuilder(hclog.NewNullLogger(), backend, path)
+	assert.NoError(t, err)
+
+	db, err := builder.Build()
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, db.Close())
+	})
+
+	return db, path
+}
+
+func collect(t *testing.T, db Database, prefix []byte) []string {
+	t.Helper()
+
+	keys := []string{}
+
+	assert.NoError(t, db.Iterate(prefix, func(k, v []byte) bool {
+		keys = append(keys, string(k)+"="+string(v))
+
+		return true
+	}))
+
+	return keys
+}
+
+func TestDatabase_Backends(t *testing.T) {
+	for _, backend := range []string{BackendLevelDB, BackendPebble, BackendMemory} {
+		backend := backend
+
+		t.Run(backend, func(t *testing.T) {
+			db, _ := buildDatabase(t, backend)
+
+			assert.NoError(t, db.Set([]byte("a1"), []byte("x")))
+
+			batch := db.Batch()
+			batch.Set([]byte("b2"), []byte("z"))
+			batch.Set([]byte("b1"), []byte("y"))
+			batch.Set([]byte("c1"), []byte("w"))
+			assert.NoError(t, batch.Write())
+
+			v, ok, err := db.Get([]byte("b1"))
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("y"), v)
+
+			_, ok, err = db.Get([]byte("b3"))
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			// the pairs of the prefix, in key order
+			assert.Equal(t, []string{"b1=y", "b2=z"}, collect(t, db, []byte("b")))
+			assert.Equal(t, []string{"a1=x", "b1=y", "b2=z", "c1=w"}, collect(t, db, nil))
+
+			batch = db.Batch()
+			batch.Delete([]byte("b1"))
+			assert.NoError(t, batch.Write())
+
+			assert.Equal(t, []string{"b2=z"}, collect(t, db, []byte("b")))
+			assert.NoError(t, db.Compact())
+		})
+	}
+}
+
+func TestDatabase_Copy(t *testing.T) {
+	src, _ := buildDatabase(t, BackendLevelDB)
+	dst, _ := buildDatabase(t, BackendPebble)
+
+	for _, k := range []string{"a", "b", "c"} {
+		assert.NoError(t, src.Set([]byte(k), []byte(k+k)))
+	}
+
+	copied, err := Copy(dst, src)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), copied)
+	assert.Equal(t, collect(t, src, nil), collect(t, dst, nil))
+}
+
+func TestInspect(t *testing.T) {
+	for _, backend := range []string{BackendLevelDB, BackendPebble, BackendMemory} {
+		backend := backend
+
+		t.Run(backend, func(t *testing.T) {
+			db, _ := buildDatabase(t, backend)
+
+			for k, v := range map[string]string{"a1": "x", "a2": "y", "b1": "zzzzzzzz"} {
+				assert.NoError(t, db.Set([]byte(k), []byte(v)))
+			}
+
+			stats, err := Inspect(db, func(k []byte) string {
+				return string(k[:1])
+			})
+			assert.NoError(t, err)
+
+			// by decreasing size
+			assert.Equal(t, []*KeyStats{
+				{Category: "b", Keys: 1, Size: 10},
+				{Category: "a", Keys: 2, Size: 6},
+			}, stats)
+
+			_, err = db.Stats()
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDetectBackend(t *testing.T) {
+	_, ok := DetectBackend(t.TempDir())
+	assert.False(t, ok)
+
+	for _, backend := range []string{BackendLevelDB, BackendPebble} {
+		_, path := buildDatabase(t, backend)
+
+		detected, ok := DetectBackend(path)
+		assert.True(t, ok)
+		assert.Equal(t, backend, detected)
+	}
+}
+
+func TestPrefixEnd(t *testing.T) {
+	assert.Equal(t, []byte("c"), prefixEnd([]byte("b")))
+	assert.Equal(t, []byte{0x02}, prefixEnd([]byte{0x01, 0xff}))
+	assert.Nil(t, prefixEnd([]byte{0xff}))
+	assert.Nil(t, prefixEnd(nil))
+}
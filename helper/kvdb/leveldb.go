@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 type levelBatch struct {
@@ -15,6 +16,10 @@ func (b *levelBatch) Set(k, v []byte) {
 	b.batch.Put(k, v)
 }
 
+func (b *levelBatch) Delete(k []byte) {
+	b.batch.Delete(k)
+}
+
 func (b *levelBatch) Write() error {
 	return b.db.Write(b.batch, nil)
 }
@@ -49,6 +54,30 @@ func (kv *levelDBKV) Get(p []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+// Iterate iterates the key-value pairs of the prefix in leveldb storage
+func (kv *levelDBKV) Iterate(prefix []byte, fn func(k, v []byte) bool) error {
+	iter := kv.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if !fn(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+
+	return iter.Error()
+}
+
+// Compact compacts the whole key range of leveldb storage
+func (kv *levelDBKV) Compact() error {
+	return kv.db.CompactRange(util.Range{})
+}
+
+// Stats returns the statistics of the levels of leveldb storage, with their compactions
+func (kv *levelDBKV) Stats() (string, error) {
+	return kv.db.GetProperty("leveldb.stats")
+}
+
 // Close closes the leveldb storage instance
 func (kv *levelDBKV) Close() error {
 	return kv.db.Close()
@@ -0,0 +1,150 @@
+package kvdb
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+type memoryBatch struct {
+	db  *memoryDB
+	ops []memoryOp
+}
+
+// memoryOp is a write of a batch, a deletion if the value is nil
+type memoryOp struct {
+	key   string
+	value []byte
+}
+
+func (b *memoryBatch) Set(k, v []byte) {
+	b.ops = append(b.ops, memoryOp{key: string(k), value: append([]byte{}, v...)})
+}
+
+func (b *memoryBatch) Delete(k []byte) {
+	b.ops = append(b.ops, memoryOp{key: string(k)})
+}
+
+func (b *memoryBatch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	for _, op := range b.ops {
+		if op.value == nil {
+			delete(b.db.db, op.key)
+		} else {
+			b.db.db[op.key] = op.value
+		}
+	}
+
+	return nil
+}
+
+// memoryDB is the in memory implementation of the kv storage
+type memoryDB struct {
+	lock sync.RWMutex
+	db   map[string][]byte
+}
+
+func (kv *memoryDB) Batch() KVBatch {
+	return &memoryBatch{db: kv}
+}
+
+// Set sets the key-value pair in memory storage
+func (kv *memoryDB) Set(p []byte, v []byte) error {
+	kv.lock.Lock()
+	defer kv.lock.Unlock()
+
+	kv.db[string(p)] = append([]byte{}, v...)
+
+	return nil
+}
+
+// Get retrieves the key-value pair in memory storage
+func (kv *memoryDB) Get(p []byte) ([]byte, bool, error) {
+	kv.lock.RLock()
+	defer kv.lock.RUnlock()
+
+	v, ok := kv.db[string(p)]
+
+	return v, ok, nil
+}
+
+// Iterate iterates the key-value pairs of the prefix in memory storage,
+// on a snapshot of them
+func (kv *memoryDB) Iterate(prefix []byte, fn func(k, v []byte) bool) error {
+	kv.lock.RLock()
+
+	keys := make([]string, 0, len(kv.db))
+	values := make(map[string][]byte, len(kv.db))
+
+	for k, v := range kv.db {
+		if strings.HasPrefix(k, string(prefix)) {
+			keys = append(keys, k)
+			values[k] = v
+		}
+	}
+
+	kv.lock.RUnlock()
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if !fn([]byte(k), values[k]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Compact does nothing, the deleted keys being freed
+func (kv *memoryDB) Compact() error {
+	return nil
+}
+
+// Stats returns nothing, memory storage having no levels
+func (kv *memoryDB) Stats() (string, error) {
+	return "", nil
+}
+
+// Close closes the memory storage instance
+func (kv *memoryDB) Close() error {
+	return nil
+}
+
+// memoryBuilder builds an in memory database, which has no option
+type memoryBuilder struct{}
+
+func (builder *memoryBuilder) SetCacheSize(int) Builder {
+	return builder
+}
+
+func (builder *memoryBuilder) SetHandles(int) Builder {
+	return builder
+}
+
+func (builder *memoryBuilder) SetBloomKeyBits(int) Builder {
+	return builder
+}
+
+func (builder *memoryBuilder) SetCompactionTableSize(int) Builder {
+	return builder
+}
+
+func (builder *memoryBuilder) SetCompactionTotalSize(int) Builder {
+	return builder
+}
+
+func (builder *memoryBuilder) SetNoSync(bool) Builder {
+	return builder
+}
+
+func (builder *memoryBuilder) Build() (Database, error) {
+	return &memoryDB{db: map[string][]byte{}}, nil
+}
+
+// NewMemoryBuilder creates the new in memory storage builder
+func NewMemoryBuilder() Builder {
+	return &memoryBuilder{}
+}
@@ -0,0 +1,259 @@
+package kvdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	"github.com/hashicorp/go-hclog"
+)
+
+// pebbleBloomBitsPerKey is the bits per key of the pebble bloom filters,
+// the leveldb bloom key bits being far too many for its per-table filters
+const pebbleBloomBitsPerKey = 10
+
+type pebbleBatch struct {
+	db    *pebbleDB
+	batch *pebble.Batch
+}
+
+func (b *pebbleBatch) Set(k, v []byte) {
+	_ = b.batch.Set(k, v, nil)
+}
+
+func (b *pebbleBatch) Delete(k []byte) {
+	_ = b.batch.Delete(k, nil)
+}
+
+func (b *pebbleBatch) Write() error {
+	return b.batch.Commit(b.db.writeOptions)
+}
+
+// pebbleDB is the pebble implementation of the kv storage
+type pebbleDB struct {
+	db           *pebble.DB
+	writeOptions *pebble.WriteOptions
+}
+
+func (kv *pebbleDB) Batch() KVBatch {
+	return &pebbleBatch{db: kv, batch: kv.db.NewBatch()}
+}
+
+// Set sets the key-value pair in pebble storage
+func (kv *pebbleDB) Set(p []byte, v []byte) error {
+	return kv.db.Set(p, v, kv.writeOptions)
+}
+
+// Get retrieves the key-value pair in pebble storage
+func (kv *pebbleDB) Get(p []byte) ([]byte, bool, error) {
+	data, closer, err := kv.db.Get(p)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	defer closer.Close()
+
+	// the value is only valid until the closer is closed
+	return append([]byte{}, data...), true, nil
+}
+
+// Iterate iterates the key-value pairs of the prefix in pebble storage
+func (kv *pebbleDB) Iterate(prefix []byte, fn func(k, v []byte) bool) error {
+	iter := kv.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixEnd(prefix),
+	})
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		if !fn(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		iter.Close()
+
+		return err
+	}
+
+	return iter.Close()
+}
+
+// Compact compacts the whole key range of pebble storage
+func (kv *pebbleDB) Compact() error {
+	iter := kv.db.NewIter(nil)
+
+	var first, last []byte
+
+	if iter.First() {
+		first = append(first, iter.Key()...)
+	}
+
+	if iter.Last() {
+		last = append(last, iter.Key()...)
+	}
+
+	if err := iter.Close(); err != nil {
+		return err
+	}
+
+	if first == nil {
+		return nil
+	}
+
+	// the end of the range is exclusive
+	return kv.db.Compact(first, append(last, 0), true)
+}
+
+// Stats returns the metrics of pebble storage, with the compactions of its levels
+func (kv *pebbleDB) Stats() (string, error) {
+	return kv.db.Metrics().String(), nil
+}
+
+// Close closes the pebble storage instance
+func (kv *pebbleDB) Close() error {
+	return kv.db.Close()
+}
+
+// prefixEnd returns the first key after the keys of the prefix, nil if there is none
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
+
+type pebbleBuilder struct {
+	logger  hclog.Logger
+	path    string
+	options *pebble.Options
+	noSync  bool
+}
+
+func (builder *pebbleBuilder) SetCacheSize(cacheSize int) Builder {
+	cacheSize = max(cacheSize, minLevelDBCache)
+
+	builder.options.Cache = pebble.NewCache(int64(cacheSize/2) * 1024 * 1024)
+	builder.options.MemTableSize = (cacheSize / 4) * 1024 * 1024
+
+	builder.logger.Info("pebble",
+		"Cache", fmt.Sprintf("%d Mib", cacheSize/2),
+		"MemTableSize", fmt.Sprintf("%d Mib", cacheSize/4),
+	)
+
+	return builder
+}
+
+func (builder *pebbleBuilder) SetHandles(handles int) Builder {
+	builder.options.MaxOpenFiles = max(handles, minLevelDBHandles)
+
+	builder.logger.Info("pebble",
+		"MaxOpenFiles", builder.options.MaxOpenFiles,
+	)
+
+	return builder
+}
+
+func (builder *pebbleBuilder) SetBloomKeyBits(int) Builder {
+	// the filters of pebble are sized per key, see pebbleBloomBitsPerKey
+	return builder
+}
+
+func (builder *pebbleBuilder) SetCompactionTableSize(compactionTableSize int) Builder {
+	for i := range builder.options.Levels {
+		// the table size doubles on each level, as leveldb does
+		builder.options.Levels[i].TargetFileSize = int64(compactionTableSize*1024*1024) << i
+	}
+
+	builder.logger.Info("pebble",
+		"TargetFileSize", fmt.Sprintf("%d Mib", compactionTableSize),
+	)
+
+	return builder
+}
+
+func (builder *pebbleBuilder) SetCompactionTotalSize(compactionTotalSize int) Builder {
+	builder.options.LBaseMaxBytes = int64(compactionTotalSize) * 1024 * 1024
+
+	builder.logger.Info("pebble",
+		"LBaseMaxBytes", fmt.Sprintf("%d Mib", compactionTotalSize),
+	)
+
+	return builder
+}
+
+func (builder *pebbleBuilder) SetNoSync(noSync bool) Builder {
+	builder.noSync = noSync
+
+	builder.logger.Info("pebble",
+		"NoSync", noSync,
+	)
+
+	return builder
+}
+
+func (builder *pebbleBuilder) Build() (Database, error) {
+	db, err := pebble.Open(builder.path, builder.options)
+
+	// the database holds its own reference to the cache
+	if builder.options.Cache != nil {
+		builder.options.Cache.Unref()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	writeOptions := pebble.Sync
+	if builder.noSync {
+		writeOptions = pebble.NoSync
+	}
+
+	return &pebbleDB{db: db, writeOptions: writeOptions}, nil
+}
+
+// pebbleLogger writes the pebble logs to the logger
+type pebbleLogger struct {
+	logger hclog.Logger
+}
+
+func (l *pebbleLogger) Infof(format string, args ...interface{}) {
+	l.logger.Debug(fmt.Sprintf(format, args...))
+}
+
+func (l *pebbleLogger) Fatalf(format string, args ...interface{}) {
+	l.logger.Error(fmt.Sprintf(format, args...))
+	panic(fmt.Sprintf(format, args...))
+}
+
+// NewPebbleBuilder creates the new pebble storage builder
+func NewPebbleBuilder(logger hclog.Logger, path string) Builder {
+	options := &pebble.Options{
+		MaxOpenFiles: minLevelDBHandles,
+		Levels:       make([]pebble.LevelOptions, 7),
+		Logger:       &pebbleLogger{logger: logger.Named("pebble")},
+	}
+
+	for i := range options.Levels {
+		options.Levels[i].FilterPolicy = bloom.FilterPolicy(pebbleBloomBitsPerKey)
+		options.Levels[i].TargetFileSize = int64(DefaultLevelDBCompactionTableSize*1024*1024) << i
+	}
+
+	return &pebbleBuilder{
+		logger:  logger,
+		path:    path,
+		options: options,
+	}
+}
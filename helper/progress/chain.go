@@ -28,6 +28,15 @@ type Progression struct {
 
 	// HighestBlock is the target block in the sync batch
 	HighestBlock uint64
+
+	// PulledHeaders is the number of headers fetched from the peers in the sync
+	PulledHeaders uint64
+
+	// PulledBodies is the number of block bodies fetched from the peers in the sync
+	PulledBodies uint64
+
+	// SyncPeers is the number of peers the node can sync from
+	SyncPeers uint64
 }
 
 type ProgressionWrapper struct {
@@ -84,8 +93,7 @@ func (pw *ProgressionWrapper) RunUpdateLoop(subscription blockchain.Subscription
 				continue
 			}
 
-			lastBlock := event.NewChain[len(event.NewChain)-1]
-			pw.UpdateCurrentProgression(lastBlock.Number)
+			pw.UpdateCurrentProgression(event.Header().Number)
 		case <-pw.stopCh:
 			subscription.Close()
 
@@ -120,10 +128,41 @@ func (pw *ProgressionWrapper) UpdateHighestProgression(highestBlock uint64) {
 	pw.progression.HighestBlock = highestBlock
 }
 
-// GetProgression returns the latest sync progression
+// AddPulled counts the headers and bodies fetched from a peer in the bulk sync
+func (pw *ProgressionWrapper) AddPulled(headers, bodies uint64) {
+	pw.lock.Lock()
+	defer pw.lock.Unlock()
+
+	if pw.progression == nil {
+		return
+	}
+
+	pw.progression.PulledHeaders += headers
+	pw.progression.PulledBodies += bodies
+}
+
+// UpdateSyncPeers sets the number of peers the node can sync from
+func (pw *ProgressionWrapper) UpdateSyncPeers(peers uint64) {
+	pw.lock.Lock()
+	defer pw.lock.Unlock()
+
+	if pw.progression == nil {
+		return
+	}
+
+	pw.progression.SyncPeers = peers
+}
+
+// GetProgression returns a copy of the latest sync progression, nil if no sync is in progress
 func (pw *ProgressionWrapper) GetProgression() *Progression {
 	pw.lock.RLock()
 	defer pw.lock.RUnlock()
 
-	return pw.progression
+	if pw.progression == nil {
+		return nil
+	}
+
+	progression := *pw.progression
+
+	return &progression
 }
@@ -0,0 +1,135 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/dogechain-lab/dogechain/versioning"
+)
+
+// PeerInfo is a peer connected to the node
+type PeerInfo struct {
+	ID        string
+	Addrs     []string
+	Protocols []string
+	Inbound   bool
+	Static    bool
+	Latency   time.Duration
+}
+
+// NodeInfo is the network identity of the node
+type NodeInfo struct {
+	ID          string
+	P2PAddrs    []string // the addresses the peers dial, with the node id
+	ListenAddrs []string
+}
+
+// adminStore provides the node administration needed by the Admin endpoint
+type adminStore interface {
+	// GetPeerInfos returns the peers connected to the node
+	GetPeerInfos() []*PeerInfo
+
+	// AddStaticPeer dials the peer of the multiaddr, and keeps it connected
+	AddStaticPeer(addr string) error
+
+	// RemoveStaticPeer stops keeping the peer of the multiaddr or id connected, and disconnects from it
+	RemoveStaticPeer(addr string) error
+
+	// GetNodeInfo returns the network identity of the node
+	GetNodeInfo() *NodeInfo
+
+	// Header returns the current header of the chain
+	Header() *types.Header
+}
+
+// Admin is the node administration jsonrpc endpoint. It is only served on its own path,
+// to the loopback clients or the ones bearing the admin token
+type Admin struct {
+	store   adminStore
+	chainID uint64
+}
+
+type peerNetwork struct {
+	Inbound bool   `json:"inbound"`
+	Static  bool   `json:"static"`
+	Latency string `json:"latency"`
+}
+
+type adminPeer struct {
+	ID        string      `json:"id"`
+	Addrs     []string    `json:"addrs"`
+	Protocols []string    `json:"protocols"`
+	Network   peerNetwork `json:"network"`
+}
+
+type nodeHead struct {
+	Number argUint64  `json:"number"`
+	Hash   types.Hash `json:"hash"`
+}
+
+type adminNodeInfo struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	P2PAddrs    []string `json:"p2pAddrs"`
+	ListenAddrs []string `json:"listenAddrs"`
+	ChainID     string   `json:"chainId"`
+	Head        nodeHead `json:"head"`
+}
+
+// Peers returns the peers connected to the node, with their protocols and latency
+func (a *Admin) Peers() (interface{}, error) {
+	infos := a.store.GetPeerInfos()
+
+	peers := make([]*adminPeer, 0, len(infos))
+	for _, info := range infos {
+		peers = append(peers, &adminPeer{
+			ID:        info.ID,
+			Addrs:     info.Addrs,
+			Protocols: info.Protocols,
+			Network: peerNetwork{
+				Inbound: info.Inbound,
+				Static:  info.Static,
+				Latency: info.Latency.String(),
+			},
+		})
+	}
+
+	return peers, nil
+}
+
+// AddPeer dials the peer of the multiaddr, and dials it again whenever it is disconnected
+func (a *Admin) AddPeer(addr string) (interface{}, error) {
+	if err := a.store.AddStaticPeer(addr); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// RemovePeer stops dialing the static peer of the multiaddr or id, and disconnects from it
+func (a *Admin) RemovePeer(addr string) (interface{}, error) {
+	if err := a.store.RemoveStaticPeer(addr); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// NodeInfo returns the network identity and the head of the node
+func (a *Admin) NodeInfo() (interface{}, error) {
+	info := a.store.GetNodeInfo()
+	header := a.store.Header()
+
+	return &adminNodeInfo{
+		ID:          info.ID,
+		Name:        fmt.Sprintf("dogechain [%s]", versioning.Version),
+		P2PAddrs:    info.P2PAddrs,
+		ListenAddrs: info.ListenAddrs,
+		ChainID:     fmt.Sprintf("%d", a.chainID),
+		Head: nodeHead{
+			Number: argUint64(header.Number),
+			Hash:   header.Hash,
+		},
+	}, nil
+}
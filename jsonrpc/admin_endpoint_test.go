@@ -0,0 +1,173 @@
+package jsonrpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var errUnknownPeer = errors.New("unknown peer")
+
+type mockAdminStore struct {
+	peers       []*PeerInfo
+	staticPeers map[string]struct{}
+}
+
+func (m *mockAdminStore) GetPeerInfos() []*PeerInfo {
+	return m.peers
+}
+
+func (m *mockAdminStore) AddStaticPeer(addr string) error {
+	m.staticPeers[addr] = struct{}{}
+
+	return nil
+}
+
+func (m *mockAdminStore) RemoveStaticPeer(addr string) error {
+	if _, ok := m.staticPeers[addr]; !ok {
+		return errUnknownPeer
+	}
+
+	delete(m.staticPeers, addr)
+
+	return nil
+}
+
+func (m *mockAdminStore) GetNodeInfo() *NodeInfo {
+	return &NodeInfo{
+		ID:          "node",
+		P2PAddrs:    []string{"/ip4/1.2.3.4/tcp/1478/p2p/node"},
+		ListenAddrs: []string{"/ip4/0.0.0.0/tcp/1478"},
+	}
+}
+
+func (m *mockAdminStore) Header() *types.Header {
+	return &types.Header{Number: 10, Hash: hash1}
+}
+
+func TestAdmin(t *testing.T) {
+	store := &mockAdminStore{
+		peers: []*PeerInfo{
+			{
+				ID:        "peer",
+				Addrs:     []string{"/ip4/1.2.3.5/tcp/1478"},
+				Protocols: []string{"/proto/0.1"},
+				Inbound:   true,
+				Latency:   12 * time.Millisecond,
+			},
+		},
+		staticPeers: map[string]struct{}{},
+	}
+	admin := &Admin{store: store, chainID: 2000}
+
+	t.Run("lists the peers", func(t *testing.T) {
+		res, err := admin.Peers()
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		peers := res.([]*adminPeer)
+		assert.Len(t, peers, 1)
+		assert.Equal(t, "peer", peers[0].ID)
+		assert.Equal(t, []string{"/proto/0.1"}, peers[0].Protocols)
+		assert.True(t, peers[0].Network.Inbound)
+		assert.False(t, peers[0].Network.Static)
+		assert.Equal(t, "12ms", peers[0].Network.Latency)
+	})
+
+	t.Run("adds and removes static peers", func(t *testing.T) {
+		addr := "/ip4/1.2.3.6/tcp/1478/p2p/static"
+
+		res, err := admin.AddPeer(addr)
+		assert.NoError(t, err)
+		assert.Equal(t, true, res)
+		assert.Contains(t, store.staticPeers, addr)
+
+		res, err = admin.RemovePeer(addr)
+		assert.NoError(t, err)
+		assert.Equal(t, true, res)
+		assert.NotContains(t, store.staticPeers, addr)
+
+		_, err = admin.RemovePeer(addr)
+		assert.ErrorIs(t, err, errUnknownPeer)
+	})
+
+	t.Run("returns the node info", func(t *testing.T) {
+		res, err := admin.NodeInfo()
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		info := res.(*adminNodeInfo)
+		assert.Equal(t, "node", info.ID)
+		assert.Equal(t, "2000", info.ChainID)
+		assert.Equal(t, argUint64(10), info.Head.Number)
+		assert.Equal(t, hash1, info.Head.Hash)
+	})
+}
+
+func TestAdmin_ServedOnlyByAdminView(t *testing.T) {
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 0, 0, []Namespace{
+		NamespaceAll,
+		NamespaceAdmin,
+	})
+
+	_, ok := dispatcher.serviceMap[string(NamespaceAdmin)]
+	assert.False(t, ok)
+
+	admin := dispatcher.adminView()
+
+	_, ok = admin.serviceMap[string(NamespaceAdmin)]
+	assert.True(t, ok)
+
+	_, ok = admin.serviceMap[string(NamespaceEth)]
+	assert.False(t, ok)
+}
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	cases := []struct {
+		name       string
+		token      string
+		remoteAddr string
+		auth       string
+		allowed    bool
+	}{
+		{"loopback client", "", "127.0.0.1:4567", "", true},
+		{"loopback ipv6 client", "", "[::1]:4567", "", true},
+		{"remote client", "", "10.0.0.1:4567", "", false},
+		{"bearer of the token", "secret", "10.0.0.1:4567", "Bearer secret", true},
+		{"bearer of another token", "secret", "10.0.0.1:4567", "Bearer other", false},
+		{"loopback client without the token", "secret", "127.0.0.1:4567", "", false},
+		{"token without bearer", "secret", "10.0.0.1:4567", "secret", false},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			handler := adminAuthMiddleware(c.token)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, adminPath, nil)
+			req.RemoteAddr = c.remoteAddr
+
+			if c.auth != "" {
+				req.Header.Set("Authorization", c.auth)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if c.allowed {
+				assert.Equal(t, http.StatusOK, rec.Code)
+			} else {
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+			}
+		})
+	}
+}
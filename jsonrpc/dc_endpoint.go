@@ -0,0 +1,81 @@
+package jsonrpc
+
+import (
+	"github.com/dogechain-lab/dogechain/consensus"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// dcStore provides access to the methods needed by the dc endpoint
+type dcStore interface {
+	// GetValidatorEarnings returns the blocks proposed and the fees earned by the validator,
+	// per epoch, the latest epoch indexed if the range is not set
+	GetValidatorEarnings(validator types.Address, fromEpoch, toEpoch *uint64) (*consensus.EarningsReport, error)
+}
+
+// Dc is the dogechain specific jsonrpc endpoint
+type Dc struct {
+	store dcStore
+}
+
+// epochRange is a range of epochs, both ends included
+type epochRange struct {
+	From *argUint64 `json:"from"`
+	To   *argUint64 `json:"to"`
+}
+
+type epochEarnings struct {
+	Epoch  argUint64 `json:"epoch"`
+	Blocks argUint64 `json:"blocks"`
+	Fees   argBig    `json:"fees"`
+}
+
+type validatorEarnings struct {
+	Validator types.Address    `json:"validator"`
+	FromEpoch argUint64        `json:"fromEpoch"`
+	ToEpoch   argUint64        `json:"toEpoch"`
+	Blocks    argUint64        `json:"blocks"`
+	Fees      argBig           `json:"fees"`
+	Epochs    []*epochEarnings `json:"epochs"`
+}
+
+// GetValidatorEarnings returns the blocks proposed by the validator, and the fees credited to
+// the coinbase of these blocks, over the range of epochs
+func (d *Dc) GetValidatorEarnings(validator types.Address, rng *epochRange) (interface{}, error) {
+	var fromEpoch, toEpoch *uint64
+
+	if rng != nil {
+		if rng.From != nil {
+			from := uint64(*rng.From)
+			fromEpoch = &from
+		}
+
+		if rng.To != nil {
+			to := uint64(*rng.To)
+			toEpoch = &to
+		}
+	}
+
+	report, err := d.store.GetValidatorEarnings(validator, fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &validatorEarnings{
+		Validator: report.Validator,
+		FromEpoch: argUint64(report.FromEpoch),
+		ToEpoch:   argUint64(report.ToEpoch),
+		Blocks:    argUint64(report.Blocks),
+		Fees:      argBig(*report.Fees),
+		Epochs:    make([]*epochEarnings, 0, len(report.Epochs)),
+	}
+
+	for _, e := range report.Epochs {
+		res.Epochs = append(res.Epochs, &epochEarnings{
+			Epoch:  argUint64(e.Epoch),
+			Blocks: argUint64(e.Blocks),
+			Fees:   argBig(*e.Fees),
+		})
+	}
+
+	return res, nil
+}
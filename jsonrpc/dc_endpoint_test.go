@@ -0,0 +1,70 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/consensus"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockDcStore struct {
+	*mockStore
+
+	validator          types.Address
+	fromEpoch, toEpoch *uint64
+}
+
+func (m *mockDcStore) GetValidatorEarnings(
+	validator types.Address,
+	fromEpoch, toEpoch *uint64,
+) (*consensus.EarningsReport, error) {
+	m.validator, m.fromEpoch, m.toEpoch = validator, fromEpoch, toEpoch
+
+	return &consensus.EarningsReport{
+		Validator: validator,
+		FromEpoch: 2,
+		ToEpoch:   3,
+		Blocks:    5,
+		Fees:      big.NewInt(300),
+		Epochs: []*consensus.EpochEarnings{
+			{Epoch: 2, Blocks: 2, Fees: big.NewInt(100)},
+			{Epoch: 3, Blocks: 3, Fees: big.NewInt(200)},
+		},
+	}, nil
+}
+
+func TestDcEndpoint_GetValidatorEarnings(t *testing.T) {
+	store := &mockDcStore{mockStore: newMockStore()}
+	dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0, 20, 1000, 0, []Namespace{
+		NamespaceDc,
+	})
+
+	resp, err := dispatcher.Handle([]byte(`{
+		"method": "dc_getValidatorEarnings",
+		"params": ["0x0000000000000000000000000000000000000001", {"from": "0x2", "to": "0x3"}]
+	}`))
+	assert.NoError(t, err)
+
+	var res map[string]interface{}
+
+	assert.NoError(t, expectJSONResult(resp, &res))
+	assert.Equal(t, types.StringToAddress("1"), store.validator)
+	assert.Equal(t, uint64(2), *store.fromEpoch)
+	assert.Equal(t, uint64(3), *store.toEpoch)
+	assert.Equal(t, "0x5", res["blocks"])
+	assert.Equal(t, "0x12c", res["fees"])
+	assert.Len(t, res["epochs"], 2)
+
+	// the range is optional
+	resp, err = dispatcher.Handle([]byte(`{
+		"method": "dc_getValidatorEarnings",
+		"params": ["0x0000000000000000000000000000000000000001"]
+	}`))
+	assert.NoError(t, err)
+	assert.NoError(t, expectJSONResult(resp, &res))
+	assert.Nil(t, store.fromEpoch)
+	assert.Nil(t, store.toEpoch)
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/dogechain-lab/dogechain/helper/hex"
 	"github.com/dogechain-lab/dogechain/state"
 	"github.com/dogechain-lab/dogechain/state/runtime"
+	"github.com/dogechain-lab/dogechain/state/tracer"
+	"github.com/dogechain-lab/dogechain/state/tracer/calltracer"
 	"github.com/dogechain-lab/dogechain/state/tracer/structlogger"
 	"github.com/dogechain-lab/dogechain/types"
 )
@@ -15,28 +17,117 @@ var (
 	ErrTransactionNotSeal         = errors.New("transaction not sealed")
 	ErrGenesisNotTracable         = errors.New("genesis is not traceable")
 	ErrTransactionNotFoundInBlock = errors.New("transaction not found in block")
+	ErrTracerNotSupported         = errors.New("tracer not supported")
+)
+
+const (
+	// callTracerName is the name of the tracer returning the tree of the calls of a transaction
+	callTracerName = "callTracer"
 )
 
 type Debug struct {
 	store ethStore
 }
 
-func (d *Debug) TraceTransaction(hash types.Hash) (interface{}, error) {
+// TraceConfig holds the options of a trace
+type TraceConfig struct {
+	// Tracer is the name of the tracer, the struct logger if not set
+	Tracer *string `json:"tracer"`
+}
+
+// txTraceResult is the trace of a transaction of a block, or the error tracing it
+type txTraceResult struct {
+	TxHash types.Hash  `json:"txHash"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// TraceTransaction replays the transaction on top of the state it was executed in,
+// and returns its trace
+func (d *Debug) TraceTransaction(hash types.Hash, config *TraceConfig) (interface{}, error) {
+	// Fail fast on an unknown tracer, before replaying anything
+	if _, err := newTracer(config, nil); err != nil {
+		return nil, err
+	}
+
+	block, tx, txIdx, err := d.sealedTransaction(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := d.store.StateAtTransaction(block, txIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.traceTx(txn, tx, config, &tracer.Context{
+		BlockHash: block.Hash(),
+		TxIndex:   txIdx,
+		TxHash:    tx.Hash,
+	})
+}
+
+// RevertReason is why a transaction failed
+type RevertReason struct {
+	// Error is the error failing the execution
+	Error string `json:"error"`
+	// Reason is the decoded reason of a revert, empty for the custom errors
+	Reason string `json:"reason,omitempty"`
+	// Output is the raw output of a revert
+	Output argBytes `json:"output,omitempty"`
+}
+
+// GetRevertReason replays the transaction on top of the state it was executed in,
+// and returns why it failed, nil if it succeeded
+func (d *Debug) GetRevertReason(hash types.Hash) (interface{}, error) {
+	block, tx, txIdx, err := d.sealedTransaction(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := d.store.StateAtTransaction(block, txIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := txn.Apply(tx)
+	if err != nil {
+		return nil, fmt.Errorf("replay failed: %w", err)
+	}
+
+	if !result.Failed() {
+		return nil, nil
+	}
+
+	reason := &RevertReason{
+		Error: result.Err.Error(),
+	}
+
+	if result.Reverted() {
+		reason.Reason = unpackRevertReason(result.ReturnValue)
+		reason.Output = argBytes(result.ReturnValue)
+	}
+
+	return reason, nil
+}
+
+// sealedTransaction returns the transaction, the block it is sealed in and its index in the block
+func (d *Debug) sealedTransaction(hash types.Hash) (*types.Block, *types.Transaction, int, error) {
 	// Check the chain state for the transaction
 	blockHash, ok := d.store.ReadTxLookup(hash)
 	if !ok {
 		// Block not found in storage
-		return nil, ErrBlockNotFound
+		return nil, nil, 0, ErrBlockNotFound
 	}
 
 	block, ok := d.store.GetBlockByHash(blockHash, true)
 	if !ok {
 		// Block receipts not found in storage
-		return nil, ErrTransactionNotSeal
+		return nil, nil, 0, ErrTransactionNotSeal
 	}
 	// It shouldn't happen in practice.
 	if block.Number() == 0 {
-		return nil, ErrGenesisNotTracable
+		return nil, nil, 0, ErrGenesisNotTracable
 	}
 
 	var (
@@ -56,28 +147,125 @@ func (d *Debug) TraceTransaction(hash types.Hash) (interface{}, error) {
 
 	if txIdx < 0 {
 		// it shouldn't be
-		return nil, ErrTransactionNotFoundInBlock
+		return nil, nil, 0, ErrTransactionNotFoundInBlock
 	}
 
-	txn, err := d.store.StateAtTransaction(block, txIdx)
+	return block, tx, txIdx, nil
+}
+
+// TraceBlockByNumber replays all the transactions of the block on top of the state
+// of its parent, and returns their traces
+func (d *Debug) TraceBlockByNumber(number BlockNumber, config *TraceConfig) (interface{}, error) {
+	if _, err := newTracer(config, nil); err != nil {
+		return nil, err
+	}
+
+	var num uint64
+
+	switch number {
+	case LatestBlockNumber:
+		num = d.store.Header().Number
+	case EarliestBlockNumber:
+		return nil, ErrGenesisNotTracable
+	case PendingBlockNumber:
+		return nil, ErrPendingBlockNumber
+	default:
+		if number < 0 {
+			return nil, fmt.Errorf("invalid argument 0: block number larger than int64")
+		}
+
+		num = uint64(number)
+	}
+
+	block, ok := d.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrGenesisNotTracable
+	}
+
+	results := make([]*txTraceResult, len(block.Transactions))
+	if len(block.Transactions) == 0 {
+		return results, nil
+	}
+
+	// the transactions are replayed one after the other on the same state
+	txn, err := d.store.StateAtTransaction(block, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	return d.traceTx(txn, tx)
+	for idx, tx := range block.Transactions {
+		results[idx] = &txTraceResult{TxHash: tx.Hash}
+
+		trace, err := d.traceTx(txn, tx, config, &tracer.Context{
+			BlockHash: block.Hash(),
+			TxIndex:   idx,
+			TxHash:    tx.Hash,
+		})
+		if err != nil {
+			results[idx].Error = err.Error()
+
+			continue
+		}
+
+		results[idx].Result = trace
+	}
+
+	return results, nil
+}
+
+// newTracer returns the tracer named in the config, the struct logger by default.
+// The names other than the built-in ones are looked up in the tracers registered
+// by tracer.RegisterLookup, run with the context of the traced transaction
+func newTracer(config *TraceConfig, ctx *tracer.Context) (runtime.EVMLogger, error) {
+	if config == nil || config.Tracer == nil || *config.Tracer == "" {
+		return nil, nil
+	}
+
+	switch *config.Tracer {
+	case callTracerName:
+		return calltracer.NewCallTracer(), nil
+	default:
+		if ctx == nil {
+			ctx = &tracer.Context{}
+		}
+
+		registered, err := tracer.New(*config.Tracer, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrTracerNotSupported, *config.Tracer)
+		}
+
+		return registered, nil
+	}
 }
 
-func (d *Debug) traceTx(txn *state.Transition, tx *types.Transaction) (interface{}, error) {
-	var tracer runtime.EVMLogger = structlogger.NewStructLogger(txn.Txn())
+func (d *Debug) traceTx(
+	txn *state.Transition,
+	tx *types.Transaction,
+	config *TraceConfig,
+	ctx *tracer.Context,
+) (interface{}, error) {
+	logger, err := newTracer(config, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if logger == nil {
+		// the struct logger needs the state of the transition
+		logger = structlogger.NewStructLogger(txn.Txn())
+	}
 
-	txn.SetEVMLogger(tracer)
+	txn.SetEVMLogger(logger)
 
 	result, err := txn.Apply(tx)
 	if err != nil {
 		return nil, fmt.Errorf("tracing failed: %w", err)
 	}
 
-	switch tracer := tracer.(type) {
+	switch logger := logger.(type) {
 	case *structlogger.StructLogger:
 		returnVal := fmt.Sprintf("%x", result.Return())
 		// If the result contains a revert reason, return it.
@@ -89,10 +277,14 @@ func (d *Debug) traceTx(txn *state.Transition, tx *types.Transaction) (interface
 			Gas:         result.GasUsed,
 			Failed:      result.Failed(),
 			ReturnValue: returnVal,
-			StructLogs:  formatLogs(tracer.StructLogs()),
+			StructLogs:  FormatLogs(logger.StructLogs()),
 		}, nil
+	case *calltracer.CallTracer:
+		return logger.Result(), nil
+	case tracer.Tracer:
+		return logger.GetResult()
 	default:
-		panic(fmt.Sprintf("bad tracer type %T", tracer))
+		panic(fmt.Sprintf("bad tracer type %T", logger))
 	}
 }
 
@@ -120,8 +312,8 @@ type StructLogRes struct {
 	Storage *map[string]string `json:"storage,omitempty"`
 }
 
-// formatLogs formats EVM returned structured logs for json output
-func formatLogs(logs []*structlogger.StructLog) []StructLogRes {
+// FormatLogs formats EVM returned structured logs for json output
+func FormatLogs(logs []*structlogger.StructLog) []StructLogRes {
 	formatted := make([]StructLogRes, len(logs))
 
 	for index, trace := range logs {
@@ -1,13 +1,23 @@
 package jsonrpc
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 
+	"github.com/dogechain-lab/dogechain/chain"
 	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/state/runtime"
 	"github.com/dogechain-lab/dogechain/state/runtime/evm"
+	"github.com/dogechain-lab/dogechain/state/tracer"
+	"github.com/dogechain-lab/dogechain/state/tracer/calltracer"
 	"github.com/dogechain-lab/dogechain/state/tracer/structlogger"
 	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -190,10 +200,229 @@ func TestDebug_FormatLogs(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// format it
-			v := formatLogs(tt.input)
+			v := FormatLogs(tt.input)
 
 			// Assert equality
 			assert.Equal(t, tt.result, v)
 		})
 	}
 }
+
+// mockTraceStore replays the transactions of its blocks on top of a genesis
+// deploying a contract storing 1 in its first slot, and a contract reverting
+type mockTraceStore struct {
+	*mockBlockStore
+	t *testing.T
+}
+
+var (
+	traceSender   = types.StringToAddress("1")
+	traceContract = types.StringToAddress("2")
+	traceReverter = types.StringToAddress("3")
+
+	// traceRevertOutput is the output of the revert with the "revert reason" reason
+	traceRevertOutput = hex.MustDecodeHex("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000000d" +
+		"72657665727420726561736f6e00000000000000000000000000000000000000")
+)
+
+func (m *mockTraceStore) StateAtTransaction(block *types.Block, txIndex int) (*state.Transition, error) {
+	executor := state.NewExecutor(
+		&chain.Params{ChainID: 100, Forks: chain.AllForksEnabled},
+		itrie.NewState(itrie.NewMemoryStorage()),
+		hclog.NewNullLogger(),
+	)
+	executor.SetRuntime(evm.NewEVM())
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash { return types.ZeroHash }
+	}
+
+	txn, err := executor.BeginTxn(
+		executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+			traceSender: {Balance: big.NewInt(1)},
+			// PUSH1 1 PUSH1 0 SSTORE STOP
+			traceContract: {Code: []byte{0x60, 0x01, 0x60, 0x00, 0x55, 0x00}},
+			// PUSH1 100 PUSH1 12 PUSH1 0 CODECOPY PUSH1 100 PUSH1 0 REVERT, followed by the output
+			traceReverter: {Code: append(
+				[]byte{0x60, 0x64, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x64, 0x60, 0x00, 0xfd},
+				traceRevertOutput...,
+			)},
+		}),
+		block.Header,
+		types.ZeroAddress,
+	)
+	assert.NoError(m.t, err)
+
+	for _, tx := range block.Transactions[:txIndex] {
+		if _, err := txn.Apply(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	return txn, nil
+}
+
+func newTraceTx(nonce uint64) *types.Transaction {
+	return &types.Transaction{
+		Nonce:    nonce,
+		From:     traceSender,
+		To:       &traceContract,
+		Value:    big.NewInt(0),
+		Gas:      100000,
+		GasPrice: big.NewInt(0),
+		Hash:     types.BytesToHash([]byte{byte(nonce + 1)}),
+	}
+}
+
+func newTestTraceDebug(t *testing.T) (*Debug, []*types.Transaction) {
+	t.Helper()
+
+	txs := []*types.Transaction{newTraceTx(0), newTraceTx(1)}
+
+	return newTestTraceDebugWithTxs(t, txs), txs
+}
+
+func newTestTraceDebugWithTxs(t *testing.T, txs []*types.Transaction) *Debug {
+	t.Helper()
+
+	store := newMockBlockStore()
+	store.add(
+		&types.Block{Header: &types.Header{Number: 0, Hash: types.StringToHash("0")}},
+		&types.Block{
+			Header:       &types.Header{Number: 1, Hash: types.StringToHash("1"), GasLimit: 1000000},
+			Transactions: txs,
+		},
+	)
+
+	return &Debug{store: &mockTraceStore{mockBlockStore: store, t: t}}
+}
+
+func TestDebug_TraceTransaction(t *testing.T) {
+	t.Parallel()
+
+	debug, txs := newTestTraceDebug(t)
+
+	// struct logger by default
+	res, err := debug.TraceTransaction(txs[1].Hash, nil)
+	assert.NoError(t, err)
+
+	result, ok := res.(*ExecutionResult)
+	assert.True(t, ok)
+	assert.False(t, result.Failed)
+	assert.Len(t, result.StructLogs, 4)
+	assert.Equal(t, evm.OpCode(evm.SSTORE).String(), result.StructLogs[2].Op)
+
+	// call tracer
+	res, err = debug.TraceTransaction(txs[1].Hash, &TraceConfig{Tracer: stringPtr(callTracerName)})
+	assert.NoError(t, err)
+
+	frame, ok := res.(*calltracer.CallFrame)
+	assert.True(t, ok)
+	assert.Equal(t, "CALL", frame.Type)
+	assert.Equal(t, traceSender, frame.From)
+	assert.Equal(t, traceContract, frame.To)
+
+	// unknown tracer
+	_, err = debug.TraceTransaction(txs[1].Hash, &TraceConfig{Tracer: stringPtr("prestateTracer")})
+	assert.ErrorIs(t, err, ErrTracerNotSupported)
+
+	// unknown transaction
+	_, err = debug.TraceTransaction(types.StringToHash("3"), nil)
+	assert.ErrorIs(t, err, ErrBlockNotFound)
+}
+
+// opCountTracer counts the opcodes run by the transaction of its context
+type opCountTracer struct {
+	runtime.DummyLogger
+	ctx   *tracer.Context
+	count int
+}
+
+func (o *opCountTracer) CaptureState(*runtime.ScopeContext, uint64, int, uint64, uint64, []byte, int, error) {
+	o.count++
+}
+
+func (o *opCountTracer) GetResult() (json.RawMessage, error) {
+	return json.RawMessage(fmt.Sprintf(`{"txIndex":%d,"ops":%d}`, o.ctx.TxIndex, o.count)), nil
+}
+
+func (o *opCountTracer) Stop(error) {}
+
+func init() {
+	tracer.RegisterLookup(false, func(name string, ctx *tracer.Context) (tracer.Tracer, error) {
+		if name != "opCountTracer" {
+			return nil, errors.New("not found")
+		}
+
+		return &opCountTracer{ctx: ctx}, nil
+	})
+}
+
+func TestDebug_TraceTransaction_RegisteredTracer(t *testing.T) {
+	t.Parallel()
+
+	debug, txs := newTestTraceDebug(t)
+
+	res, err := debug.TraceTransaction(txs[1].Hash, &TraceConfig{Tracer: stringPtr("opCountTracer")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"txIndex":1,"ops":4}`, string(res.(json.RawMessage))) //nolint:forcetypeassert
+}
+
+func TestDebug_TraceBlockByNumber(t *testing.T) {
+	t.Parallel()
+
+	debug, txs := newTestTraceDebug(t)
+
+	res, err := debug.TraceBlockByNumber(LatestBlockNumber, &TraceConfig{Tracer: stringPtr(callTracerName)})
+	assert.NoError(t, err)
+
+	results, ok := res.([]*txTraceResult)
+	assert.True(t, ok)
+	assert.Len(t, results, len(txs))
+
+	for i, result := range results {
+		assert.Equal(t, txs[i].Hash, result.TxHash)
+		assert.Empty(t, result.Error)
+		assert.IsType(t, &calltracer.CallFrame{}, result.Result)
+	}
+
+	_, err = debug.TraceBlockByNumber(BlockNumber(0), nil)
+	assert.ErrorIs(t, err, ErrGenesisNotTracable)
+
+	_, err = debug.TraceBlockByNumber(BlockNumber(2), nil)
+	assert.ErrorIs(t, err, ErrBlockNotFound)
+
+	_, err = debug.TraceBlockByNumber(PendingBlockNumber, nil)
+	assert.ErrorIs(t, err, ErrPendingBlockNumber)
+}
+
+func TestDebug_GetRevertReason(t *testing.T) {
+	t.Parallel()
+
+	reverting := newTraceTx(1)
+	reverting.To = &traceReverter
+
+	txs := []*types.Transaction{newTraceTx(0), reverting}
+	debug := newTestTraceDebugWithTxs(t, txs)
+
+	res, err := debug.GetRevertReason(txs[1].Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, &RevertReason{
+		Error:  runtime.ErrExecutionReverted.Error(),
+		Reason: "revert reason",
+		Output: argBytes(traceRevertOutput),
+	}, res)
+
+	// no reason for a successful transaction
+	res, err = debug.GetRevertReason(txs[0].Hash)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	_, err = debug.GetRevertReason(types.StringToHash("4"))
+	assert.ErrorIs(t, err, ErrBlockNotFound)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
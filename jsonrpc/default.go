@@ -2,8 +2,10 @@ package jsonrpc
 
 const (
 	// DefaultJSONRPCBatchRequestLimit maximum length allowed for json_rpc batch requests
-	DefaultJSONRPCBatchRequestLimit uint64 = 1
+	DefaultJSONRPCBatchRequestLimit uint64 = 20
 	// DefaultJSONRPCBlockRangeLimit maximum block range allowed for json_rpc
 	// requests with fromBlock/toBlock values (e.g. eth_getLogs)
 	DefaultJSONRPCBlockRangeLimit uint64 = 100
+	// DefaultJSONRPCLogLimit maximum number of logs returned by eth_getLogs
+	DefaultJSONRPCLogLimit uint64 = 10000
 )
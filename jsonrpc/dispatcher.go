@@ -21,6 +21,9 @@ const (
 	NamespaceWeb3   Namespace = "web3"
 	NamespaceTxpool Namespace = "txpool"
 	NamespaceDebug  Namespace = "debug"
+	NamespaceDc     Namespace = "dc"
+	NamespaceTrace  Namespace = "trace"
+	NamespaceAdmin  Namespace = "admin" // only served on the admin path, never by the namespaces
 	NamespaceAll    Namespace = "*"
 )
 
@@ -46,6 +49,9 @@ type endpoints struct {
 	Net    *Net
 	TxPool *TxPool
 	Debug  *Debug
+	Dc     *Dc
+	Trace  *Trace
+	Admin  *Admin
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -59,6 +65,7 @@ type Dispatcher struct {
 	jsonRPCBatchLengthLimit uint64
 	priceLimit              uint64
 	namespaces              map[Namespace]struct{}
+	disabledMethods         map[string]struct{}
 }
 
 func newDispatcher(
@@ -89,13 +96,58 @@ func newDispatcher(
 		go d.filterManager.Run()
 	}
 
-	d.initEndpoints(store)
+	d.initEndpoints(store, blockRangeLimit)
 	d.registerEndpoints()
 
 	return d
 }
 
-func (d *Dispatcher) initEndpoints(store JSONRPCStore) {
+// view returns a dispatcher serving only the namespaces, with its own batch length limit.
+// It shares the endpoints and the filter manager of the dispatcher.
+func (d *Dispatcher) view(namespaces []Namespace, jsonRPCBatchLengthLimit uint64) *Dispatcher {
+	v := &Dispatcher{
+		logger:                  d.logger,
+		filterManager:           d.filterManager,
+		endpoints:               d.endpoints,
+		chainID:                 d.chainID,
+		jsonRPCBatchLengthLimit: jsonRPCBatchLengthLimit,
+		priceLimit:              d.priceLimit,
+		namespaces:              make(map[Namespace]struct{}),
+		disabledMethods:         d.disabledMethods,
+	}
+
+	for _, ns := range namespaces {
+		v.namespaces[ns] = struct{}{}
+	}
+
+	v.registerEndpoints()
+
+	return v
+}
+
+// adminView returns a dispatcher serving only the admin namespace
+func (d *Dispatcher) adminView() *Dispatcher {
+	v := d.view(nil, d.jsonRPCBatchLengthLimit)
+	v.registerService(string(NamespaceAdmin), d.endpoints.Admin)
+
+	return v
+}
+
+// disableMethods stops serving the methods, though their namespace is enabled.
+// The views created afterwards do not serve them either
+func (d *Dispatcher) disableMethods(methods []string) {
+	if len(methods) == 0 {
+		return
+	}
+
+	d.disabledMethods = make(map[string]struct{}, len(methods))
+
+	for _, method := range methods {
+		d.disabledMethods[method] = struct{}{}
+	}
+}
+
+func (d *Dispatcher) initEndpoints(store JSONRPCStore, blockRangeLimit uint64) {
 	d.endpoints.Eth = &Eth{
 		logger:        d.logger,
 		store:         store,
@@ -107,6 +159,9 @@ func (d *Dispatcher) initEndpoints(store JSONRPCStore) {
 	d.endpoints.Web3 = &Web3{}
 	d.endpoints.TxPool = &TxPool{store}
 	d.endpoints.Debug = &Debug{store}
+	d.endpoints.Dc = &Dc{store}
+	d.endpoints.Trace = &Trace{store, blockRangeLimit}
+	d.endpoints.Admin = &Admin{store, d.chainID}
 }
 
 func (d *Dispatcher) registerEndpoints() {
@@ -117,6 +172,8 @@ func (d *Dispatcher) registerEndpoints() {
 		d.registerService(string(NamespaceWeb3), d.endpoints.Web3)
 		d.registerService(string(NamespaceTxpool), d.endpoints.TxPool)
 		d.registerService(string(NamespaceDebug), d.endpoints.Debug)
+		d.registerService(string(NamespaceDc), d.endpoints.Dc)
+		d.registerService(string(NamespaceTrace), d.endpoints.Trace)
 
 		return
 	}
@@ -133,11 +190,19 @@ func (d *Dispatcher) registerEndpoints() {
 			d.registerService(string(ns), d.endpoints.TxPool)
 		case NamespaceDebug:
 			d.registerService(string(ns), d.endpoints.Debug)
+		case NamespaceDc:
+			d.registerService(string(ns), d.endpoints.Dc)
+		case NamespaceTrace:
+			d.registerService(string(ns), d.endpoints.Trace)
 		}
 	}
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
+	if _, ok := d.disabledMethods[req.Method]; ok {
+		return nil, nil, NewMethodNotFoundError(req.Method)
+	}
+
 	callName := strings.SplitN(req.Method, "_", 2)
 	if len(callName) != 2 {
 		return nil, nil, NewMethodNotFoundError(req.Method)
@@ -208,6 +273,10 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 			return "", NewInternalError(err.Error())
 		}
 		filterID = d.filterManager.NewLogFilter(logQuery, conn)
+	} else if subscribeMethod == "newPendingTransactions" {
+		filterID = d.filterManager.NewPendingTxFilter(conn)
+	} else if subscribeMethod == "finalizedHeads" && req.Method == "dc_subscribe" {
+		filterID = d.filterManager.NewFinalizedHeadFilter(conn)
 	} else {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
@@ -233,19 +302,54 @@ func (d *Dispatcher) handleUnsubscribe(req Request) (bool, Error) {
 	return d.filterManager.Uninstall(filterID), nil
 }
 
+// subscriptionsEnabled returns whether the subscriptions are served, which come with the eth namespace
+func (d *Dispatcher) subscriptionsEnabled() bool {
+	_, ok := d.serviceMap[string(NamespaceEth)]
+
+	return ok
+}
+
 func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
 }
 
 func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+	if isBatchRequest(reqBody) {
+		return d.handleBatch(reqBody, func(req Request) ([]byte, error) {
+			resp, err := d.handleWsReq(req, conn)
+			if err != nil {
+				// answered in the batch instead of failing it
+				var rpcErr Error
+				if !errors.As(err, &rpcErr) {
+					rpcErr = NewInternalError("Internal error")
+				}
+
+				return NewRPCResponse(req.ID, "2.0", nil, rpcErr).Bytes()
+			}
+
+			return resp, nil
+		})
+	}
+
 	var req Request
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 	}
 
-	// if the request method is eth_subscribe we need to create a
-	// new filter with ws connection
-	if req.Method == "eth_subscribe" {
+	return d.handleWsReq(req, conn)
+}
+
+// handleWsReq handles a request of the web socket connection, and returns the encoded response
+func (d *Dispatcher) handleWsReq(req Request, conn wsConn) ([]byte, error) {
+	isSubscription := req.Method == "eth_subscribe" || req.Method == "dc_subscribe" ||
+		req.Method == "eth_unsubscribe" || req.Method == "dc_unsubscribe"
+	if _, disabled := d.disabledMethods[req.Method]; isSubscription && (disabled || !d.subscriptionsEnabled()) {
+		return NewRPCResponse(req.ID, "2.0", nil, NewMethodNotFoundError(req.Method)).Bytes()
+	}
+
+	// if the request method is eth_subscribe or dc_subscribe we need
+	// to create a new filter with ws connection
+	if req.Method == "eth_subscribe" || req.Method == "dc_subscribe" {
 		filterID, err := d.handleSubscribe(req, conn)
 		if err != nil {
 			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
@@ -260,7 +364,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		return []byte(resp), nil
 	}
 
-	if req.Method == "eth_unsubscribe" {
+	if req.Method == "eth_unsubscribe" || req.Method == "dc_unsubscribe" {
 		ok, err := d.handleUnsubscribe(req)
 		if err != nil {
 			return nil, err
@@ -309,30 +413,58 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 	}
 
-	// handle batch requests
-	var requests []Request
+	return d.handleBatch(reqBody, func(req Request) ([]byte, error) {
+		resp, err := d.handleReq(req)
+
+		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
+	})
+}
+
+// isBatchRequest checks if the request body is a batch, a JSON array of requests
+func isBatchRequest(reqBody []byte) bool {
+	x := bytes.TrimLeft(reqBody, " \t\r\n")
+
+	return len(x) > 0 && x[0] == '['
+}
+
+// handleBatch handles the requests of the batch one after the other with the handler, which returns
+// the encoded response of a request. The responses are returned in the order of the requests, and
+// the malformed requests are answered with an error without failing the batch
+func (d *Dispatcher) handleBatch(reqBody []byte, handle func(req Request) ([]byte, error)) ([]byte, error) {
+	var requests []json.RawMessage
 	if err := json.Unmarshal(reqBody, &requests); err != nil {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 	}
 
+	if len(requests) == 0 {
+		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Empty batch request")).Bytes()
+	}
+
 	// if not disabled, avoid handling long batch requests
 	if d.jsonRPCBatchLengthLimit > 0 &&
 		len(requests) > int(d.jsonRPCBatchLengthLimit) {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Batch request length too long")).Bytes()
 	}
 
-	responses := make([]Response, 0)
+	responses := make([]json.RawMessage, 0, len(requests))
 
-	for _, req := range requests {
-		var response, err = d.handleReq(req)
-		if err != nil {
-			errorResponse := NewRPCResponse(req.ID, "2.0", nil, err)
-			responses = append(responses, errorResponse)
+	for _, raw := range requests {
+		var (
+			req  Request
+			resp []byte
+			err  error
+		)
 
-			continue
+		if jsonErr := json.Unmarshal(raw, &req); jsonErr != nil || req.Method == "" {
+			resp, err = NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
+		} else {
+			resp, err = handle(req)
+		}
+
+		if err != nil {
+			return NewRPCResponse(nil, "2.0", nil, NewInternalError("Internal error")).Bytes()
 		}
 
-		resp := NewRPCResponse(req.ID, "2.0", response, nil)
 		responses = append(responses, resp)
 	}
 
@@ -373,7 +505,7 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	if err := getError(output[1]); err != nil {
 		d.logInternalError(req.Method, err)
 
-		return nil, NewInvalidRequestError(err.Error())
+		return nil, NewRequestError(err)
 	}
 
 	var (
@@ -2,11 +2,15 @@ package jsonrpc
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/helper/errcode"
+	"github.com/dogechain-lab/dogechain/state/runtime"
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -92,6 +96,102 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 			t.Fatal("\"newHeads\" event not received in 2 seconds")
 		}
 	})
+
+	t.Run("clients should be able to receive \"newPendingTransactions\" event thru eth_subscribe", func(t *testing.T) {
+		store := newMockStore()
+		dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0, 0, 0, 0, []Namespace{
+			NamespaceEth,
+		})
+
+		mockConnection := &mockWsConn{
+			msgCh: make(chan []byte, 1),
+		}
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions"]
+	}`)
+		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+			t.Fatal(err)
+		}
+
+		store.promote(&types.Transaction{Hash: types.StringToHash("1")})
+
+		delayTimer := time.NewTimer(2 * time.Second)
+
+		select {
+		case <-mockConnection.msgCh:
+		case <-delayTimer.C:
+			t.Fatal("\"newPendingTransactions\" event not received in 2 seconds")
+		}
+	})
+}
+
+func TestDispatcher_HandleWebsocketConnection_DcSubscribe(t *testing.T) {
+	store := newMockStore()
+	dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0, 0, 0, 0, []Namespace{
+		NamespaceEth,
+	})
+
+	// finalized heads are only served by dc_subscribe
+	resp, err := dispatcher.HandleWs([]byte(`{
+		"method": "eth_subscribe",
+		"params": ["finalizedHeads"]
+	}`), &mockWsConn{})
+	assert.NoError(t, err)
+	assert.Error(t, expectJSONResult(resp, new(string)))
+
+	mockConnection := &mockWsConn{
+		msgCh: make(chan []byte, 1),
+	}
+
+	_, err = dispatcher.HandleWs([]byte(`{
+		"method": "dc_subscribe",
+		"params": ["finalizedHeads"]
+	}`), mockConnection)
+	assert.NoError(t, err)
+
+	// the reorganized heads are not final
+	store.emitEvent(&mockEvent{
+		NewChain: []*mockHeader{{header: &types.Header{Number: 1, Hash: types.StringToHash("1")}}},
+		Type:     blockchain.EventReorg,
+	})
+	store.emitEvent(&mockEvent{
+		NewChain: []*mockHeader{{header: &types.Header{Number: 2, Hash: types.StringToHash("2")}}},
+	})
+
+	select {
+	case msg := <-mockConnection.msgCh:
+		var notification struct {
+			Method string `json:"method"`
+			Params struct {
+				Subscription string        `json:"subscription"`
+				Result       finalizedHead `json:"result"`
+			} `json:"params"`
+		}
+
+		assert.NoError(t, json.Unmarshal(msg, &notification))
+		assert.Equal(t, "dc_subscription", notification.Method)
+		assert.Equal(t, mockConnection.filterID, notification.Params.Subscription)
+		assert.Equal(t, types.StringToHash("2"), notification.Params.Result.Hash)
+		assert.Equal(t, argUint64(2), notification.Params.Result.Number)
+		assert.Equal(t, argUint64(mockCommittedSeals), notification.Params.Result.CommittedSeals)
+	case <-time.After(2 * time.Second):
+		t.Fatal("\"finalizedHeads\" event not received in 2 seconds")
+	}
+
+	// unsubscribe
+	resp, err = dispatcher.HandleWs([]byte(fmt.Sprintf(`{
+		"id": 1,
+		"method": "dc_unsubscribe",
+		"params": ["%s"]
+	}`, mockConnection.filterID)), mockConnection)
+	assert.NoError(t, err)
+
+	var unsubscribed string
+
+	assert.NoError(t, expectJSONResult(resp, &unsubscribed))
+	assert.Equal(t, "true", unsubscribed)
 }
 
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
@@ -263,6 +363,17 @@ func (m *mockService) Filter(f LogQuery) (interface{}, error) {
 	return nil, nil
 }
 
+func (m *mockService) Fail(code errcode.Code) (interface{}, error) {
+	return nil, fmt.Errorf("failed: %w", errcode.New(code, "coded error"))
+}
+
+func (m *mockService) Revert(output argBytes) (interface{}, error) {
+	return nil, fmt.Errorf("call failed: %w", constructErrorFromRevert(&runtime.ExecutionResult{
+		ReturnValue: output,
+		Err:         runtime.ErrExecutionReverted,
+	}))
+}
+
 func TestDispatcherFuncDecode(t *testing.T) {
 	srv := &mockService{msgCh: make(chan interface{}, 10)}
 
@@ -330,6 +441,69 @@ func TestDispatcherFuncDecode(t *testing.T) {
 	}
 }
 
+func TestDispatcher_ErrorData(t *testing.T) {
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 0, 0, nil)
+	dispatcher.registerService("mock", &mockService{})
+
+	tests := []struct {
+		name string
+		code errcode.Code
+		data string
+	}{
+		{
+			name: "coded error",
+			code: errcode.TxPoolNonceTooLow,
+			data: `{"code":1008,"reason":"TXPOOL_NONCE_TOO_LOW"}`,
+		},
+		{
+			name: "error without code",
+			code: errcode.Unknown,
+			data: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := dispatcher.Handle([]byte(fmt.Sprintf(
+				`{"jsonrpc":"2.0","id":1,"method":"mock_fail","params":[%d]}`, tt.code,
+			)))
+			assert.NoError(t, err)
+
+			var resp struct {
+				Error struct {
+					Code    int             `json:"code"`
+					Message string          `json:"message"`
+					Data    json.RawMessage `json:"data"`
+				} `json:"error"`
+			}
+
+			assert.NoError(t, json.Unmarshal(res, &resp))
+			assert.Equal(t, -32000, resp.Error.Code)
+			assert.Equal(t, "failed: coded error", resp.Error.Message)
+			assert.Equal(t, tt.data, string(resp.Error.Data))
+		})
+	}
+}
+
+func TestDispatcher_RevertError(t *testing.T) {
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 0, 0, nil)
+	dispatcher.registerService("mock", &mockService{})
+
+	res, err := dispatcher.Handle([]byte(
+		`{"jsonrpc":"2.0","id":1,"method":"mock_revert","params":["0x4e487b710000000000000000000000000000000000000000000000000000000000000011"]}`,
+	))
+	assert.NoError(t, err)
+
+	var resp SuccessResponse
+
+	assert.NoError(t, json.Unmarshal(res, &resp))
+	assert.Equal(t, &ObjectError{
+		Code:    3,
+		Message: "execution reverted: arithmetic underflow or overflow",
+		Data:    "0x4e487b710000000000000000000000000000000000000000000000000000000000000011",
+	}, resp.Error)
+}
+
 func TestDispatcherBatchRequest(t *testing.T) {
 	handle := func(dispatcher *Dispatcher, reqBody []byte) []byte {
 		res, _ := dispatcher.Handle(reqBody)
@@ -470,3 +644,90 @@ func TestDispatcherBatchRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestDispatcherBatchRequest_Malformed(t *testing.T) {
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 0, 0, []Namespace{
+		NamespaceEth,
+		NamespaceWeb3,
+	})
+
+	// the malformed requests are answered in order, without failing the batch
+	res, err := dispatcher.Handle([]byte(`[
+		{"id":1,"jsonrpc":"2.0","method":"web3_sha3","params":["0x01"]},
+		1,
+		{"id":3,"jsonrpc":"2.0"},
+		{"id":"4","jsonrpc":"2.0","method":"web3_sha3","params":["0x02"]}]`))
+	assert.NoError(t, err)
+
+	var batchResp []SuccessResponse
+	assert.NoError(t, expectBatchJSONResult(res, &batchResp))
+	assert.Len(t, batchResp, 4)
+
+	assert.Equal(t, float64(1), batchResp[0].ID)
+	assert.Nil(t, batchResp[0].Error)
+	assert.Equal(t, -32600, batchResp[1].Error.Code)
+	assert.Equal(t, float64(3), batchResp[2].ID)
+	assert.Equal(t, -32600, batchResp[2].Error.Code)
+	assert.Equal(t, "4", batchResp[3].ID)
+	assert.Nil(t, batchResp[3].Error)
+
+	// an empty batch is invalid
+	res, err = dispatcher.Handle([]byte(`[]`))
+	assert.NoError(t, err)
+
+	var resp ErrorResponse
+
+	assert.NoError(t, expectBatchJSONResult(res, &resp))
+	assert.Equal(t, &ObjectError{Code: -32600, Message: "Empty batch request"}, resp.Error)
+}
+
+func TestDispatcherBatchRequest_Websocket(t *testing.T) {
+	store := newMockStore()
+	dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0, 3, 0, 0, []Namespace{
+		NamespaceEth,
+		NamespaceWeb3,
+	})
+
+	mockConnection := &mockWsConn{
+		msgCh: make(chan []byte, 1),
+	}
+
+	res, err := dispatcher.HandleWs([]byte(`[
+		{"id":1,"jsonrpc":"2.0","method":"eth_subscribe","params":["newHeads"]},
+		{"id":2,"jsonrpc":"2.0","method":"eth_getBalance","params":["0x1", true]},
+		{"id":3,"jsonrpc":"2.0","method":"web3_sha3","params":["0x01"]}]`), mockConnection)
+	assert.NoError(t, err)
+
+	var batchResp []SuccessResponse
+	assert.NoError(t, expectBatchJSONResult(res, &batchResp))
+	assert.Len(t, batchResp, 3)
+
+	// the subscription is created within the batch
+	assert.Equal(t, float64(1), batchResp[0].ID)
+	assert.Nil(t, batchResp[0].Error)
+
+	var filterID string
+
+	assert.NoError(t, json.Unmarshal(batchResp[0].Result, &filterID))
+	assert.True(t, dispatcher.filterManager.Exists(filterID))
+
+	// the failed requests are answered in the batch
+	assert.Equal(t, float64(2), batchResp[1].ID)
+	assert.Equal(t, &ObjectError{Code: -32602, Message: "Invalid Params"}, batchResp[1].Error)
+
+	assert.Equal(t, float64(3), batchResp[2].ID)
+	assert.Nil(t, batchResp[2].Error)
+
+	// the batch length limit applies to the web sockets too
+	res, err = dispatcher.HandleWs([]byte(`[
+		{"id":1,"jsonrpc":"2.0","method":"web3_sha3","params":["0x01"]},
+		{"id":2,"jsonrpc":"2.0","method":"web3_sha3","params":["0x01"]},
+		{"id":3,"jsonrpc":"2.0","method":"web3_sha3","params":["0x01"]},
+		{"id":4,"jsonrpc":"2.0","method":"web3_sha3","params":["0x01"]}]`), mockConnection)
+	assert.NoError(t, err)
+
+	var resp ErrorResponse
+
+	assert.NoError(t, expectBatchJSONResult(res, &resp))
+	assert.Equal(t, &ObjectError{Code: -32600, Message: "Batch request length too long"}, resp.Error)
+}
@@ -1,9 +1,13 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"math/big"
 
+	"github.com/dogechain-lab/dogechain/helper/errcode"
+	"github.com/dogechain-lab/dogechain/helper/hex"
 	"github.com/dogechain-lab/dogechain/state/runtime"
 	"github.com/umbracle/go-web3/abi"
 )
@@ -16,6 +20,13 @@ type Error interface {
 	Error() string
 	ErrorCode() int
 }
+
+// DataError is an Error carrying additional data for the error object
+type DataError interface {
+	Error
+	ErrorData() interface{}
+}
+
 type invalidParamsError struct {
 	err string
 }
@@ -52,6 +63,74 @@ func (e *invalidRequestError) ErrorCode() int {
 	return -32600
 }
 
+// serverError is the error of a method failing to serve a well formed request
+type serverError struct {
+	err string
+}
+
+func (e *serverError) Error() string {
+	return e.err
+}
+
+func (e *serverError) ErrorCode() int {
+	return -32000
+}
+
+// codedError is a server error carrying a stable error code
+type codedError struct {
+	err  string
+	code errcode.Code
+}
+
+// codedErrorData is the data of a coded error object
+type codedErrorData struct {
+	Code   errcode.Code `json:"code"`
+	Reason string       `json:"reason"`
+}
+
+func (e *codedError) Error() string {
+	return e.err
+}
+
+func (e *codedError) ErrorCode() int {
+	return -32000
+}
+
+func (e *codedError) ErrorData() interface{} {
+	return &codedErrorData{
+		Code:   e.code,
+		Reason: e.code.String(),
+	}
+}
+
+// revertError is the error of an execution reverted by the EVM, carrying the
+// hex encoded revert output as data for the clients to decode
+type revertError struct {
+	err    error
+	reason string
+	output []byte
+}
+
+func (e *revertError) Error() string {
+	if e.reason == "" {
+		return "execution reverted"
+	}
+
+	return "execution reverted: " + e.reason
+}
+
+func (e *revertError) Unwrap() error {
+	return e.err
+}
+
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+func (e *revertError) ErrorData() interface{} {
+	return hex.EncodeToHex(e.output)
+}
+
 type subscriptionNotFoundError struct {
 	err string
 }
@@ -82,6 +161,22 @@ func NewMethodNotFoundError(method string) *methodNotFoundError {
 func NewInvalidRequestError(msg string) *invalidRequestError {
 	return &invalidRequestError{msg}
 }
+
+// NewRequestError returns the error of a failed method. The jsonrpc errors keep their code,
+// the errors carrying an error code are coded errors, and the others server errors
+func NewRequestError(err error) Error {
+	var rpcErr Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+
+	if code := errcode.GetCode(err); code != errcode.Unknown {
+		return &codedError{err.Error(), code}
+	}
+
+	return &serverError{err.Error()}
+}
+
 func NewInvalidParamsError(msg string) *invalidParamsError {
 	return &invalidParamsError{msg}
 }
@@ -94,11 +189,46 @@ func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
 
-func constructErrorFromRevert(result *runtime.ExecutionResult) error {
-	revertErrMsg, unpackErr := abi.UnpackRevertError(result.ReturnValue)
-	if unpackErr != nil {
-		return result.Err
+// panicSelector is the selector of the Panic(uint256) error of the solidity checks
+var panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+
+// panicReasons are the reasons of the solidity panic codes
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array accessed",
+	0x31: "out-of-bounds array access; popping on an empty array",
+	0x32: "out-of-bounds access of an array or bytesN",
+	0x41: "out of memory",
+	0x51: "uninitialized function",
+}
+
+// unpackRevertReason decodes the reason of the Error(string) and Panic(uint256) revert outputs,
+// and returns an empty reason for the custom errors
+func unpackRevertReason(output []byte) string {
+	if reason, err := abi.UnpackRevertError(output); err == nil {
+		return reason
+	}
+
+	if len(output) != len(panicSelector)+32 || !bytes.Equal(output[:len(panicSelector)], panicSelector) {
+		return ""
 	}
 
-	return fmt.Errorf("%w: %s", result.Err, revertErrMsg)
+	code := new(big.Int).SetBytes(output[len(panicSelector):])
+	if reason, ok := panicReasons[code.Uint64()]; ok && code.IsUint64() {
+		return reason
+	}
+
+	return fmt.Sprintf("unknown panic code: %#x", code)
+}
+
+func constructErrorFromRevert(result *runtime.ExecutionResult) error {
+	return &revertError{
+		err:    result.Err,
+		reason: unpackRevertReason(result.ReturnValue),
+		output: result.ReturnValue,
+	}
 }
@@ -0,0 +1,74 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/state/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnpackRevertReason(t *testing.T) {
+	cases := []struct {
+		name   string
+		output string
+		reason string
+	}{
+		{
+			"error string",
+			"0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"000000000000000000000000000000000000000000000000000000000000000d" +
+				"72657665727420726561736f6e00000000000000000000000000000000000000",
+			"revert reason",
+		},
+		{
+			"known panic code",
+			"0x4e487b710000000000000000000000000000000000000000000000000000000000000012",
+			"division or modulo by zero",
+		},
+		{
+			"unknown panic code",
+			"0x4e487b710000000000000000000000000000000000000000000000000000000000000099",
+			"unknown panic code: 0x99",
+		},
+		{
+			"custom error",
+			"0x12345678",
+			"",
+		},
+		{
+			"empty output",
+			"0x",
+			"",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			output, err := hex.DecodeHex(c.output)
+			assert.NoError(t, err)
+
+			assert.Equal(t, c.reason, unpackRevertReason(output))
+		})
+	}
+}
+
+func TestRevertError(t *testing.T) {
+	err := constructErrorFromRevert(&runtime.ExecutionResult{
+		ReturnValue: []byte{0x12, 0x34, 0x56, 0x78},
+		Err:         runtime.ErrExecutionReverted,
+	})
+
+	assert.ErrorIs(t, err, runtime.ErrExecutionReverted)
+	assert.EqualError(t, err, "execution reverted")
+
+	//nolint:forcetypeassert
+	rpcErr := NewRequestError(err).(DataError)
+	assert.Equal(t, 3, rpcErr.ErrorCode())
+	assert.Equal(t, "0x12345678", rpcErr.ErrorData())
+
+	assert.Equal(t, -32000, NewRequestError(ErrInsufficientFunds).ErrorCode())
+}
@@ -8,7 +8,9 @@ import (
 	"testing"
 
 	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
 	"github.com/dogechain-lab/dogechain/helper/progress"
+	"github.com/dogechain-lab/dogechain/state"
 	"github.com/dogechain-lab/dogechain/state/runtime"
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/stretchr/testify/assert"
@@ -160,6 +162,54 @@ func TestEth_GetTransactionByHash(t *testing.T) {
 	})
 }
 
+func TestEth_GetTransactionByBlockAndIndex(t *testing.T) {
+	store := &mockBlockStore{}
+	eth := newTestEthEndpoint(store)
+	block := newTestBlock(1, hash1)
+	store.add(block)
+
+	for i := 0; i < 3; i++ {
+		block.Transactions = append(block.Transactions, newTestTransaction(uint64(i), addr0))
+	}
+
+	t.Run("returns the transaction of the block number at the index", func(t *testing.T) {
+		res, err := eth.GetTransactionByBlockNumberAndIndex(BlockNumber(1), argUint64(2))
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		foundTxn := res.(*transaction)
+		assert.Equal(t, block.Transactions[2].Hash, foundTxn.Hash)
+		assert.Equal(t, argUint64(2), *foundTxn.TxIndex)
+		assert.Equal(t, block.Hash(), *foundTxn.BlockHash)
+	})
+
+	t.Run("returns the transaction of the block hash at the index", func(t *testing.T) {
+		res, err := eth.GetTransactionByBlockHashAndIndex(hash1, argUint64(0))
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		foundTxn := res.(*transaction)
+		assert.Equal(t, block.Transactions[0].Hash, foundTxn.Hash)
+		assert.Equal(t, argUint64(0), *foundTxn.TxIndex)
+	})
+
+	t.Run("returns nil for an index out of range", func(t *testing.T) {
+		res, err := eth.GetTransactionByBlockNumberAndIndex(BlockNumber(1), argUint64(3))
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("returns nil for an unknown block", func(t *testing.T) {
+		res, err := eth.GetTransactionByBlockNumberAndIndex(BlockNumber(2), argUint64(0))
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+
+		res, err = eth.GetTransactionByBlockHashAndIndex(hash2, argUint64(0))
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
 func TestEth_GetTransactionReceipt(t *testing.T) {
 	t.Run("returns nil if transaction with same hash not found", func(t *testing.T) {
 		store := &mockBlockStore{}
@@ -203,6 +253,75 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 	})
 }
 
+func TestEth_GetBlockReceipts(t *testing.T) {
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+
+	block := newTestBlock(1, hash4)
+	block.Transactions = []*types.Transaction{
+		newTestTransaction(uint64(0), addr0),
+		newTestTransaction(uint64(1), addr0),
+	}
+	store.add(block, newTestBlock(2, hash3))
+
+	newReceipt := func(logs int) *types.Receipt {
+		rec := &types.Receipt{}
+		for i := 0; i < logs; i++ {
+			rec.Logs = append(rec.Logs, &types.Log{Topics: []types.Hash{hash4}})
+		}
+
+		rec.SetStatus(types.ReceiptSuccess)
+
+		return rec
+	}
+
+	store.receipts[hash4] = []*types.Receipt{newReceipt(2), newReceipt(1)}
+
+	t.Run("returns the receipts of all the transactions", func(t *testing.T) {
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash4})
+
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		receipts := res.([]*receipt)
+		assert.Len(t, receipts, 2)
+
+		for i, rec := range receipts {
+			assert.Equal(t, block.Transactions[i].Hash, rec.TxHash)
+			assert.Equal(t, argUint64(i), rec.TxIndex)
+			assert.Equal(t, block.Hash(), rec.BlockHash)
+		}
+
+		// the log indexes run through the block
+		assert.Equal(t, argUint64(0), receipts[0].Logs[0].LogIndex)
+		assert.Equal(t, argUint64(1), receipts[0].Logs[1].LogIndex)
+		assert.Equal(t, argUint64(2), receipts[1].Logs[0].LogIndex)
+		assert.Equal(t, argUint64(1), receipts[1].Logs[0].TxIndex)
+	})
+
+	t.Run("returns an empty list for a block without transactions", func(t *testing.T) {
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*receipt{}, res)
+	})
+
+	t.Run("returns nil if the block is not found", func(t *testing.T) {
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash1})
+
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("fails if the receipts mismatch the transactions", func(t *testing.T) {
+		store.receipts[hash4] = []*types.Receipt{newReceipt(0)}
+
+		_, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash4})
+
+		assert.ErrorIs(t, err, ErrReceiptsMismatch)
+	})
+}
+
 func TestEth_Syncing(t *testing.T) {
 	store := newMockBlockStore()
 	eth := newTestEthEndpoint(store)
@@ -221,6 +340,9 @@ func TestEth_Syncing(t *testing.T) {
 		assert.Equal(t, fmt.Sprintf("0x%x", 1), response.StartingBlock)
 		assert.Equal(t, fmt.Sprintf("0x%x", 10), response.CurrentBlock)
 		assert.Equal(t, fmt.Sprintf("0x%x", 100), response.HighestBlock)
+		assert.Equal(t, fmt.Sprintf("0x%x", 12), response.PulledHeaders)
+		assert.Equal(t, fmt.Sprintf("0x%x", 11), response.PulledBodies)
+		assert.Equal(t, fmt.Sprintf("0x%x", 3), response.PeerCount)
 	})
 
 	t.Run("returns \"false\" if sync is not progress", func(t *testing.T) {
@@ -246,6 +368,112 @@ func TestEth_GasPrice(t *testing.T) {
 	//nolint:forcetypeassert
 	response := res.(string)
 	assert.Equal(t, fmt.Sprintf("0x%x", store.averageGasPrice), response)
+
+	// the suggestion of the oracle above the minimum gas price
+	store.suggestedPrice = new(big.Int).Add(big.NewInt(store.averageGasPrice), big.NewInt(1))
+
+	res, err = eth.GasPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("0x%x", store.suggestedPrice), res)
+}
+
+func TestEth_MaxPriorityFeePerGas(t *testing.T) {
+	store := newMockBlockStore()
+	store.suggestedTip = big.NewInt(1000)
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.MaxPriorityFeePerGas()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x3e8", res)
+}
+
+func TestEth_FeeHistory(t *testing.T) {
+	store := newMockBlockStore()
+
+	for i := 0; i < 3; i++ {
+		block := newTestBlock(uint64(i), types.StringToHash(strconv.Itoa(i)))
+		block.Header.GasLimit = 100
+		block.Header.GasUsed = uint64(i * 50)
+		block.Header.BaseFee = uint64(i * 10)
+		store.add(block)
+	}
+
+	store.nextBaseFee = 30
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.FeeHistory(2, LatestBlockNumber, nil)
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	history := res.(*feeHistory)
+	assert.Equal(t, argUint64(1), history.OldestBlock)
+	assert.Equal(t, []argUint64{10, 20, 30}, history.BaseFee)
+	assert.Equal(t, []float64{0.5, 1}, history.GasUsedRatio)
+
+	// the range is cut at the genesis block
+	res, err = eth.FeeHistory(10, BlockNumber(1), nil)
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	history = res.(*feeHistory)
+	assert.Equal(t, argUint64(0), history.OldestBlock)
+	assert.Len(t, history.GasUsedRatio, 2)
+	assert.Nil(t, history.Reward)
+}
+
+func TestEth_FeeHistory_Reward(t *testing.T) {
+	store := newMockBlockStore()
+
+	// an empty block, then a block of three transactions of tips 5, 1 and 3
+	store.add(newTestBlock(0, types.StringToHash("0")))
+
+	block := newTestBlock(1, types.StringToHash("1"))
+	block.Header.GasLimit = 100
+	block.Header.GasUsed = 60
+	block.Header.BaseFee = 10
+	block.Transactions = []*types.Transaction{
+		{GasPrice: big.NewInt(15)},
+		{GasPrice: big.NewInt(11)},
+		{GasPrice: big.NewInt(13)},
+	}
+	store.add(block)
+
+	store.receipts[block.Hash()] = []*types.Receipt{
+		{CumulativeGasUsed: 30},
+		{CumulativeGasUsed: 40},
+		{CumulativeGasUsed: 60},
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.FeeHistory(2, LatestBlockNumber, &[]float64{0, 20, 50, 100})
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	history := res.(*feeHistory)
+
+	rewards := make([][]string, len(history.Reward))
+
+	for i, reward := range history.Reward {
+		for _, tip := range reward {
+			raw, err := tip.MarshalText()
+			assert.NoError(t, err)
+
+			rewards[i] = append(rewards[i], string(raw))
+		}
+	}
+
+	// the tips by gas used are 1 up to 10, 3 up to 30 and 5 up to 60
+	assert.Equal(t, [][]string{
+		{"0x0", "0x0", "0x0", "0x0"},
+		{"0x1", "0x3", "0x3", "0x5"},
+	}, rewards)
+
+	// the percentiles are validated
+	for _, percentiles := range [][]float64{{-1}, {101}, {50, 20}} {
+		_, err := eth.FeeHistory(2, LatestBlockNumber, &percentiles)
+		assert.ErrorIs(t, err, ErrInvalidRewardPercentiles)
+	}
 }
 
 func TestEth_Call(t *testing.T) {
@@ -264,7 +492,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{})
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), store.ethCallError.Error())
@@ -286,7 +514,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{})
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -301,7 +529,12 @@ type mockBlockStore struct {
 	receipts        map[types.Hash][]*types.Receipt
 	isSyncing       bool
 	averageGasPrice int64
+	suggestedPrice  *big.Int
+	suggestedTip    *big.Int
 	ethCallError    error
+	nextBaseFee     uint64
+	bloomIndexed    uint64
+	bloomMatches    []uint64
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -408,6 +641,10 @@ func (m *mockBlockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, e
 	return receipts, nil
 }
 
+func (m *mockBlockStore) GetCommittedSeals(header *types.Header) (int, error) {
+	return 0, nil
+}
+
 func (m *mockBlockStore) GetHeaderByNumber(blockNumber uint64) (*types.Header, bool) {
 	b, ok := m.GetBlockByNumber(blockNumber, false)
 	if !ok {
@@ -453,6 +690,22 @@ func (m *mockBlockStore) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
 	return types.ZeroHash, false
 }
 
+func (m *mockBlockStore) ReadTxLookupEntry(txnHash types.Hash) (*storage.TxLookupEntry, bool) {
+	for _, block := range m.blocks {
+		for idx, txn := range block.Transactions {
+			if txn.Hash == txnHash {
+				return &storage.TxLookupEntry{
+					BlockHash:   block.Hash(),
+					BlockNumber: block.Number(),
+					Index:       uint64(idx),
+				}, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
 func (m *mockBlockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
 	for _, txn := range m.pendingTxns {
 		if txn.Hash == txHash {
@@ -470,6 +723,9 @@ func (m *mockBlockStore) GetSyncProgression() *progress.Progression {
 			StartingBlock: 1,
 			CurrentBlock:  10,
 			HighestBlock:  100,
+			PulledHeaders: 12,
+			PulledBodies:  11,
+			SyncPeers:     3,
 		}
 	} else {
 		return nil
@@ -480,7 +736,31 @@ func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
 	return big.NewInt(m.averageGasPrice)
 }
 
-func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+func (m *mockBlockStore) SuggestGasPrice() *big.Int {
+	if m.suggestedPrice == nil {
+		return new(big.Int)
+	}
+
+	return m.suggestedPrice
+}
+
+func (m *mockBlockStore) SuggestTipCap() *big.Int {
+	if m.suggestedTip == nil {
+		return new(big.Int)
+	}
+
+	return m.suggestedTip
+}
+
+func (m *mockBlockStore) CalculateBaseFee(*types.Header) uint64 {
+	return m.nextBaseFee
+}
+
+func (m *mockBlockStore) ApplyTxn(
+	header *types.Header,
+	txn *types.Transaction,
+	overrides state.Overrides,
+) (*runtime.ExecutionResult, error) {
 	return &runtime.ExecutionResult{Err: m.ethCallError}, nil
 }
 
@@ -488,6 +768,24 @@ func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
 
+func (m *mockBlockStore) AddPromoteHook(hook func(txs []*types.Transaction)) {}
+
+func (m *mockBlockStore) BloomIndexedBlocks() uint64 {
+	return m.bloomIndexed
+}
+
+func (m *mockBlockStore) MatchBloomBits(from, to uint64, filters [][][]byte) ([]uint64, error) {
+	var matches []uint64
+
+	for _, n := range m.bloomMatches {
+		if n >= from && n <= to && n < m.bloomIndexed {
+			matches = append(matches, n)
+		}
+	}
+
+	return matches, nil
+}
+
 func newTestBlock(number uint64, hash types.Hash) *types.Block {
 	return &types.Block{
 		Header: &types.Header{
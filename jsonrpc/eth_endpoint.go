@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
 	"github.com/dogechain-lab/dogechain/chain"
 	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/helper/keccak"
 	"github.com/dogechain-lab/dogechain/helper/progress"
 	"github.com/dogechain-lab/dogechain/state"
 	"github.com/dogechain-lab/dogechain/state/runtime"
@@ -17,6 +20,11 @@ import (
 
 const (
 	defaultMinGasPrice = "0Xba43b7400" // 50 GWei
+
+	// defaultBalanceChangesLimit is the maximum number of balance changes per page
+	defaultBalanceChangesLimit = 1000
+	// maxBalanceChangesBlockRange is the maximum number of blocks scanned per page
+	maxBalanceChangesBlockRange = 1000
 )
 
 type ethTxPoolStore interface {
@@ -35,6 +43,9 @@ type ethStateStore interface {
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(hash types.Hash) ([]byte, error)
+
+	// GetProof returns the merkle proof of the key, once hashed, in the state trie at the root
+	GetProof(root types.Hash, key []byte) ([][]byte, error)
 }
 
 type ethBlockchainStore interface {
@@ -53,14 +64,26 @@ type ethBlockchainStore interface {
 	// ReadTxLookup returns a block hash in which a given txn was mined
 	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
 
+	// ReadTxLookupEntry returns the block in which a given txn was mined, and its index in the block
+	ReadTxLookupEntry(txnHash types.Hash) (*storage.TxLookupEntry, bool)
+
 	// GetReceiptsByHash returns the receipts for a block hash
 	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
 
 	// GetAvgGasPrice returns the average gas price
 	GetAvgGasPrice() *big.Int
 
-	// ApplyTxn applies a transaction object to the blockchain
-	ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	// SuggestGasPrice returns the gas price suggested by the gas price oracle
+	SuggestGasPrice() *big.Int
+
+	// SuggestTipCap returns the tip suggested by the gas price oracle
+	SuggestTipCap() *big.Int
+
+	// CalculateBaseFee returns the base fee of the next block after parent
+	CalculateBaseFee(parent *types.Header) uint64
+
+	// ApplyTxn applies a transaction object to the blockchain, on top of the state overrides if any
+	ApplyTxn(header *types.Header, txn *types.Transaction, overrides state.Overrides) (*runtime.ExecutionResult, error)
 
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
@@ -68,6 +91,10 @@ type ethBlockchainStore interface {
 	// StateAtTransaction returns the execution environment of a certain transaction.
 	// The transition should not commit, it shall be collected by GC.
 	StateAtTransaction(block *types.Block, txIndex int) (*state.Transition, error)
+
+	// PendingState returns the pending block, made of the pending pool transactions applied
+	// on top of the latest state, and its transition. The transition should not commit.
+	PendingState() (*types.Block, *state.Transition, error)
 }
 
 // ethStore provides access to the methods needed by eth endpoint
@@ -86,9 +113,12 @@ type Eth struct {
 	priceLimit    uint64
 }
 
+var emptyCodeHash = types.BytesToHash(keccak.Keccak256(nil, nil))
+
 var (
 	ErrInsufficientFunds = errors.New("insufficient funds for execution")
 	ErrGasCapOverflow    = errors.New("unable to apply transaction for the highest gas limit")
+	ErrReceiptsMismatch  = errors.New("receipts mismatch the block transactions")
 )
 
 // ChainId returns the chain id of the client
@@ -121,6 +151,8 @@ func (e *Eth) getHeaderFromBlockNumberOrHash(bnh *BlockNumberOrHash) (*types.Hea
 	return header, nil
 }
 
+// Syncing returns the sync progression of the node, with the headers and bodies pulled
+// and the peers to sync from, or false if the node is not syncing
 func (e *Eth) Syncing() (interface{}, error) {
 	if syncProgression := e.store.GetSyncProgression(); syncProgression != nil {
 		// Node is bulk syncing, return the status
@@ -129,6 +161,9 @@ func (e *Eth) Syncing() (interface{}, error) {
 			StartingBlock: hex.EncodeUint64(syncProgression.StartingBlock),
 			CurrentBlock:  hex.EncodeUint64(syncProgression.CurrentBlock),
 			HighestBlock:  hex.EncodeUint64(syncProgression.HighestBlock),
+			PulledHeaders: hex.EncodeUint64(syncProgression.PulledHeaders),
+			PulledBodies:  hex.EncodeUint64(syncProgression.PulledBodies),
+			PeerCount:     hex.EncodeUint64(syncProgression.SyncPeers),
 		}, nil
 	}
 
@@ -158,6 +193,15 @@ func GetNumericBlockNumber(number BlockNumber, e *Eth) (uint64, error) {
 
 // GetBlockByNumber returns information about a block by block number
 func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, error) {
+	if number == PendingBlockNumber {
+		block, _, err := e.store.PendingState()
+		if err != nil {
+			return nil, err
+		}
+
+		return toBlock(block, fullTx), nil
+	}
+
 	num, err := GetNumericBlockNumber(number, e)
 	if err != nil {
 		return nil, err
@@ -197,6 +241,44 @@ func (e *Eth) GetBlockTransactionCountByNumber(number BlockNumber) (interface{},
 	return len(block.Transactions), nil
 }
 
+// GetTransactionByBlockNumberAndIndex returns the transaction of the block at the index,
+// or nil if the block or the index is unknown
+func (e *Eth) GetTransactionByBlockNumberAndIndex(number BlockNumber, index argUint64) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, e)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := e.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, nil
+	}
+
+	return toIndexedTransaction(block, index), nil
+}
+
+// GetTransactionByBlockHashAndIndex returns the transaction of the block at the index,
+// or nil if the block or the index is unknown
+func (e *Eth) GetTransactionByBlockHashAndIndex(hash types.Hash, index argUint64) (interface{}, error) {
+	block, ok := e.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	return toIndexedTransaction(block, index), nil
+}
+
+// toIndexedTransaction returns the transaction of the block at the index, nil if out of range
+func toIndexedTransaction(block *types.Block, index argUint64) interface{} {
+	if uint64(index) >= uint64(len(block.Transactions)) {
+		return nil
+	}
+
+	idx := int(index)
+
+	return toTransaction(block.Transactions[idx], block.Header, &idx)
+}
+
 // BlockNumber returns current block number
 func (e *Eth) BlockNumber() (interface{}, error) {
 	h := e.store.Header()
@@ -242,32 +324,17 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 	// for the transaction with the provided hash
 	findSealedTx := func() *transaction {
 		// Check the chain state for the transaction
-		blockHash, ok := e.store.ReadTxLookup(hash)
+		block, idx, ok := e.getSealedTx(hash)
 		if !ok {
-			// Block not found in storage
+			// Transaction not found in storage
 			return nil
 		}
 
-		block, ok := e.store.GetBlockByHash(blockHash, true)
-
-		if !ok {
-			// Block receipts not found in storage
-			return nil
-		}
-
-		// Find the transaction within the block
-		for idx, txn := range block.Transactions {
-			if txn.Hash == hash {
-				return toTransaction(
-					txn,
-					argUintPtr(block.Number()),
-					argHashPtr(block.Hash()),
-					&idx,
-				)
-			}
-		}
-
-		return nil
+		return toTransaction(
+			block.Transactions[idx],
+			block.Header,
+			&idx,
+		)
 	}
 
 	// findPendingTx is a helper method for checking the TxPool
@@ -302,21 +369,13 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 
 // GetTransactionReceipt returns a transaction receipt by his hash
 func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
-	blockHash, ok := e.store.ReadTxLookup(hash)
+	block, indx, ok := e.getSealedTx(hash)
 	if !ok {
 		// txn not found
 		return nil, nil
 	}
 
-	block, ok := e.store.GetBlockByHash(blockHash, true)
-	if !ok {
-		// block not found
-		e.logger.Warn(
-			fmt.Sprintf("Block with hash [%s] not found", blockHash.String()),
-		)
-
-		return nil, nil
-	}
+	blockHash := block.Hash()
 
 	receipts, err := e.store.GetReceiptsByHash(blockHash)
 	if err != nil {
@@ -336,54 +395,59 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 
 		return nil, nil
 	}
-	// find the transaction in the body
-	indx := -1
 
-	for i, txn := range block.Transactions {
-		if txn.Hash == hash {
-			indx = i
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("%w: block %s", ErrReceiptsMismatch, blockHash)
+	}
 
-			break
-		}
+	// the logs are indexed in the block
+	logIndex := 0
+	for _, raw := range receipts[:indx] {
+		logIndex += len(raw.Logs)
 	}
 
-	if indx == -1 {
-		// txn not found
+	return toReceipt(block, receipts[indx], indx, logIndex), nil
+}
+
+// GetBlockReceipts returns the receipts of all the transactions of the block, read at once,
+// or nil if the block is not found
+func (e *Eth) GetBlockReceipts(filter BlockNumberOrHash) (interface{}, error) {
+	// The filter is empty, use the latest block by default
+	if filter.BlockNumber == nil && filter.BlockHash == nil {
+		filter.BlockNumber, _ = CreateBlockNumberPointer(LatestBlockFlag)
+	}
+
+	header, err := e.getHeaderFromBlockNumberOrHash(&filter)
+	if err != nil {
 		return nil, nil
 	}
 
-	txn := block.Transactions[indx]
-	raw := receipts[indx]
-
-	logs := make([]*Log, len(raw.Logs))
-	for indx, elem := range raw.Logs {
-		logs[indx] = &Log{
-			Address:     elem.Address,
-			Topics:      elem.Topics,
-			Data:        argBytes(elem.Data),
-			BlockHash:   block.Hash(),
-			BlockNumber: argUint64(block.Number()),
-			TxHash:      txn.Hash,
-			TxIndex:     argUint64(indx),
-			LogIndex:    argUint64(indx),
-			Removed:     false,
-		}
-	}
-
-	res := &receipt{
-		Root:              raw.Root,
-		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
-		LogsBloom:         raw.LogsBloom,
-		Status:            argUint64(*raw.Status),
-		TxHash:            txn.Hash,
-		TxIndex:           argUint64(indx),
-		BlockHash:         block.Hash(),
-		BlockNumber:       argUint64(block.Number()),
-		GasUsed:           argUint64(raw.GasUsed),
-		ContractAddress:   raw.ContractAddress,
-		FromAddr:          txn.From,
-		ToAddr:            txn.To,
-		Logs:              logs,
+	block, ok := e.store.GetBlockByHash(header.Hash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	res := make([]*receipt, 0, len(block.Transactions))
+
+	if len(block.Transactions) == 0 {
+		return res, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("%w: %d receipts for %d transactions in block %s",
+			ErrReceiptsMismatch, len(receipts), len(block.Transactions), header.Hash)
+	}
+
+	logIndex := 0
+
+	for i, raw := range receipts {
+		res = append(res, toReceipt(block, raw, i, logIndex))
+		logIndex += len(raw.Logs)
 	}
 
 	return res, nil
@@ -436,10 +500,96 @@ func (e *Eth) GetStorageAt(
 	return argBytesPtr(data), nil
 }
 
-// GasPrice returns the average gas price based on the last x blocks
+// GetProof returns the merkle proofs of the account and of its storage slots at the block (EIP-1186),
+// for the callers verifying them against the state root of the header
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	// The filter is empty, use the latest block by default
+	if filter.BlockNumber == nil && filter.BlockHash == nil {
+		filter.BlockNumber, _ = CreateBlockNumberPointer(LatestBlockFlag)
+	}
+
+	header, err := e.getHeaderFromBlockNumberOrHash(&filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header from block hash or block number")
+	}
+
+	// an absent account is proven absent, with the fields of an empty account
+	account := &state.Account{
+		Balance:  big.NewInt(0),
+		Root:     types.EmptyRootHash,
+		CodeHash: emptyCodeHash.Bytes(),
+	}
+
+	if acc, err := e.store.GetAccount(header.StateRoot, address); err == nil {
+		account = acc
+	} else if !errors.Is(err, ErrStateNotFound) {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &accountProof{
+		Address:      address,
+		AccountProof: toArgBytesList(proof),
+		Balance:      argBig(*account.Balance),
+		CodeHash:     types.BytesToHash(account.CodeHash),
+		Nonce:        argUint64(account.Nonce),
+		StorageHash:  account.Root,
+		StorageProof: make([]*storageProof, 0, len(storageKeys)),
+	}
+
+	for _, key := range storageKeys {
+		value := new(big.Int)
+
+		data, err := e.store.GetStorage(header.StateRoot, address, key)
+		if err == nil {
+			value.SetBytes(decodeStorageValue(data))
+		} else if !errors.Is(err, ErrStateNotFound) {
+			return nil, err
+		}
+
+		proof, err := e.store.GetProof(account.Root, key.Bytes())
+		if err != nil {
+			return nil, err
+		}
+
+		result.StorageProof = append(result.StorageProof, &storageProof{
+			Key:   key,
+			Value: argBig(*value),
+			Proof: toArgBytesList(proof),
+		})
+	}
+
+	return result, nil
+}
+
+// decodeStorageValue returns the bytes of the RLP encoded value of a storage slot, nil if malformed
+func decodeStorageValue(data []byte) []byte {
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return nil
+	}
+
+	b, err := v.Bytes()
+	if err != nil {
+		return nil
+	}
+
+	return b
+}
+
+// GasPrice returns the gas price suggested by the oracle from the latest blocks,
+// at least the price limit of the node
 func (e *Eth) GasPrice() (interface{}, error) {
-	// var avgGasPrice string
-	// Grab the average gas price and convert it to a hex value
 	priceLimit := new(big.Int).SetUint64(e.priceLimit)
 	minGasPrice, _ := new(big.Int).SetString(defaultMinGasPrice, 0)
 
@@ -447,19 +597,182 @@ func (e *Eth) GasPrice() (interface{}, error) {
 		priceLimit = minGasPrice
 	}
 
-	// if e.store.GetAvgGasPrice().Cmp(minGasPrice) == -1 {
-	// 	avgGasPrice = hex.EncodeBig(minGasPrice)
-	// } else {
-	// 	avgGasPrice = hex.EncodeBig(e.store.GetAvgGasPrice())
-	// }
-
-	// return avgGasPrice, nil
+	if price := e.store.SuggestGasPrice(); price.Cmp(priceLimit) > 0 {
+		return hex.EncodeBig(price), nil
+	}
 
 	return hex.EncodeBig(priceLimit), nil
 }
 
-// Call executes a smart contract call using the transaction object data
-func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+// MaxPriorityFeePerGas returns the tip suggested by the oracle from the latest blocks
+func (e *Eth) MaxPriorityFeePerGas() (interface{}, error) {
+	return hex.EncodeBig(e.store.SuggestTipCap()), nil
+}
+
+// maxFeeHistoryBlocks is the most blocks eth_feeHistory returns at once
+const maxFeeHistoryBlocks = 1024
+
+// maxFeeHistoryPercentiles is the most reward percentiles eth_feeHistory accepts
+const maxFeeHistoryPercentiles = 100
+
+var (
+	ErrInvalidRewardPercentiles = errors.New("reward percentiles must be increasing values between 0 and 100")
+)
+
+type feeHistory struct {
+	OldestBlock  argUint64   `json:"oldestBlock"`
+	BaseFee      []argUint64 `json:"baseFeePerGas"`
+	GasUsedRatio []float64   `json:"gasUsedRatio"`
+	Reward       [][]*argBig `json:"reward,omitempty"`
+}
+
+// FeeHistory returns the base fees and gas used ratios of up to blockCount blocks
+// ending at newestBlock. The base fees include the one of the block after newestBlock,
+// they are zero before the london fork. If reward percentiles are given, it also returns
+// the tips paid at these percentiles of the gas used by each block.
+func (e *Eth) FeeHistory(
+	blockCount argUint64,
+	newestBlock BlockNumber,
+	rewardPercentiles *[]float64,
+) (interface{}, error) {
+	var percentiles []float64
+	if rewardPercentiles != nil {
+		percentiles = *rewardPercentiles
+	}
+
+	if len(percentiles) > maxFeeHistoryPercentiles {
+		return nil, fmt.Errorf("%w, got %d percentiles", ErrInvalidRewardPercentiles, len(percentiles))
+	}
+
+	for i, p := range percentiles {
+		if p < 0 || p > 100 || (i > 0 && p < percentiles[i-1]) {
+			return nil, fmt.Errorf("%w, got %v", ErrInvalidRewardPercentiles, percentiles)
+		}
+	}
+
+	if blockCount == 0 {
+		return &feeHistory{BaseFee: []argUint64{}, GasUsedRatio: []float64{}}, nil
+	} else if blockCount > maxFeeHistoryBlocks {
+		blockCount = maxFeeHistoryBlocks
+	}
+
+	newest, err := e.getBlockHeader(newestBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	// the range can not go past the genesis block
+	if uint64(blockCount) > newest.Number+1 {
+		blockCount = argUint64(newest.Number + 1)
+	}
+
+	oldest := newest.Number + 1 - uint64(blockCount)
+	res := &feeHistory{
+		OldestBlock:  argUint64(oldest),
+		BaseFee:      make([]argUint64, 0, blockCount+1),
+		GasUsedRatio: make([]float64, 0, blockCount),
+	}
+
+	if len(percentiles) > 0 {
+		res.Reward = make([][]*argBig, 0, blockCount)
+	}
+
+	for num := oldest; num <= newest.Number; num++ {
+		header, ok := e.store.GetHeaderByNumber(num)
+		if !ok {
+			return nil, fmt.Errorf("error fetching block number %d header", num)
+		}
+
+		res.BaseFee = append(res.BaseFee, argUint64(header.BaseFee))
+
+		var ratio float64
+		if header.GasLimit > 0 {
+			ratio = float64(header.GasUsed) / float64(header.GasLimit)
+		}
+
+		res.GasUsedRatio = append(res.GasUsedRatio, ratio)
+
+		if len(percentiles) > 0 {
+			reward, err := e.blockRewards(header, percentiles)
+			if err != nil {
+				return nil, err
+			}
+
+			res.Reward = append(res.Reward, reward)
+		}
+	}
+
+	res.BaseFee = append(res.BaseFee, argUint64(e.store.CalculateBaseFee(newest)))
+
+	return res, nil
+}
+
+// blockRewards returns the tips paid at the percentiles of the gas used by the block,
+// its transactions being sorted by tip. They are zero if the block is empty
+func (e *Eth) blockRewards(header *types.Header, percentiles []float64) ([]*argBig, error) {
+	reward := make([]*argBig, len(percentiles))
+
+	block, ok := e.store.GetBlockByNumber(header.Number, true)
+	if !ok || len(block.Transactions) == 0 || header.GasUsed == 0 {
+		for i := range reward {
+			reward[i] = argBigPtr(big.NewInt(0))
+		}
+
+		return reward, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("block %d has %d receipts for %d transactions",
+			header.Number, len(receipts), len(block.Transactions))
+	}
+
+	type txGasAndTip struct {
+		gasUsed uint64
+		tip     *big.Int
+	}
+
+	txs := make([]txGasAndTip, len(block.Transactions))
+
+	for i, tx := range block.Transactions {
+		gasUsed := receipts[i].CumulativeGasUsed
+		if i > 0 {
+			gasUsed -= receipts[i-1].CumulativeGasUsed
+		}
+
+		txs[i] = txGasAndTip{gasUsed: gasUsed, tip: tx.EffectiveTip(header.BaseFee)}
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].tip.Cmp(txs[j].tip) < 0
+	})
+
+	var (
+		idx     int
+		sumUsed = txs[0].gasUsed
+	)
+
+	for i, p := range percentiles {
+		threshold := uint64(float64(header.GasUsed) * p / 100)
+
+		for sumUsed < threshold && idx < len(txs)-1 {
+			idx++
+			sumUsed += txs[idx].gasUsed
+		}
+
+		reward[i] = argBigPtr(txs[idx].tip)
+	}
+
+	return reward, nil
+}
+
+// Call executes a smart contract call using the transaction object data,
+// on top of the state overrides if any
+func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, override *stateOverride) (interface{}, error) {
 	var (
 		header *types.Header
 		err    error
@@ -470,9 +783,29 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 		filter.BlockNumber, _ = CreateBlockNumberPointer(LatestBlockFlag)
 	}
 
-	header, err = e.getHeaderFromBlockNumberOrHash(&filter)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get header from block hash or block number")
+	var pending *state.Transition
+
+	if isPending(filter) {
+		var block *types.Block
+
+		block, pending, err = e.store.PendingState()
+		if err != nil {
+			return nil, err
+		}
+
+		// The pending transactions already used a part of the block gas
+		header = block.Header.Copy()
+		header.GasLimit -= header.GasUsed
+
+		// The sender nonce follows its pending transactions
+		if arg.From != nil && arg.Nonce == nil {
+			arg.Nonce = argUintPtr(pending.GetNonce(*arg.From))
+		}
+	} else {
+		header, err = e.getHeaderFromBlockNumberOrHash(&filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get header from block hash or block number")
+		}
 	}
 
 	transaction, err := e.decodeTxn(arg)
@@ -486,7 +819,14 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 	}
 
 	// The return value of the execution is saved in the transition (returnValue field)
-	result, err := e.store.ApplyTxn(header, transaction)
+	var result *runtime.ExecutionResult
+
+	if pending != nil {
+		result, err = applyPendingTxn(pending, transaction, override.toOverrides())
+	} else {
+		result, err = e.store.ApplyTxn(header, transaction, override.toOverrides())
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -503,13 +843,16 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 	return argBytesPtr(result.ReturnValue), nil
 }
 
-// EstimateGas estimates the gas needed to execute a transaction
-func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
+// EstimateGas estimates the gas needed to execute a transaction,
+// on top of the state overrides if any
+func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber, override *stateOverride) (interface{}, error) {
 	transaction, err := e.decodeTxn(arg)
 	if err != nil {
 		return nil, err
 	}
 
+	overrides := override.toOverrides()
+
 	number := LatestBlockNumber
 	if rawNum != nil {
 		number = *rawNum
@@ -521,13 +864,12 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		return nil, err
 	}
 
-	forksInTime := e.store.GetForksInTime(uint64(number))
+	forksInTime := e.store.GetForksInTime(header.Number)
 
-	var standardGas uint64
-	if transaction.IsContractCreation() && forksInTime.Homestead {
-		standardGas = state.TxGasContractCreation
-	} else {
-		standardGas = state.TxGas
+	// The intrinsic gas is the lowest possible gas, the same as the executor charges
+	standardGas, err := state.TransactionGasCost(transaction, forksInTime.Homestead, forksInTime.Istanbul)
+	if err != nil {
+		return nil, err
 	}
 
 	var (
@@ -543,7 +885,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		highEnd = header.GasLimit
 	}
 
-	gasPriceInt := new(big.Int).Set(transaction.GasPrice)
+	gasPriceInt := new(big.Int).Set(transaction.GetGasFeeCap())
 	valueInt := new(big.Int).Set(transaction.Value)
 
 	var availableBalance *big.Int
@@ -566,6 +908,11 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 			accountBalance = acc.Balance
 		}
 
+		// The overridden balance replaces the one of the state
+		if account, ok := overrides[transaction.From]; ok && account.Balance != nil {
+			accountBalance = account.Balance
+		}
+
 		availableBalance = new(big.Int).Set(accountBalance)
 
 		if transaction.Value != nil {
@@ -620,7 +967,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		txn := transaction.Copy()
 		txn.Gas = gas
 
-		result, applyErr := e.store.ApplyTxn(header, txn)
+		result, applyErr := e.store.ApplyTxn(header, txn, overrides)
 
 		if applyErr != nil {
 			// Check the application error.
@@ -701,9 +1048,27 @@ func (e *Eth) GetFilterLogs(id string) (interface{}, error) {
 	return e.GetLogs(logFilter.query)
 }
 
-// GetLogs returns an array of logs matching the filter options
+// GetLogs returns an array of logs matching the filter options.
+// A query with a cursor, empty for the first page, returns a page of the logs and the cursor of the next one
 func (e *Eth) GetLogs(query *LogQuery) (interface{}, error) {
-	return e.filterManager.GetLogs(query)
+	if query.Cursor == nil {
+		return e.filterManager.GetLogs(query)
+	}
+
+	// the paged query returns the cursor of the next page along the logs
+	logs, next, err := e.filterManager.GetLogsPage(query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &logPage{Logs: logs}
+
+	if next != nil {
+		cursor := next.String()
+		page.Cursor = &cursor
+	}
+
+	return page, nil
 }
 
 // GetBalance returns the account's balance at the referenced block.
@@ -718,6 +1083,15 @@ func (e *Eth) GetBalance(address types.Address, filter BlockNumberOrHash) (inter
 		filter.BlockNumber, _ = CreateBlockNumberPointer(LatestBlockFlag)
 	}
 
+	if isPending(filter) {
+		_, pending, err := e.store.PendingState()
+		if err != nil {
+			return nil, err
+		}
+
+		return argBigPtr(pending.GetBalance(address)), nil
+	}
+
 	header, err = e.getHeaderFromBlockNumberOrHash(&filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get header from block hash or block number")
@@ -735,6 +1109,108 @@ func (e *Eth) GetBalance(address types.Address, filter BlockNumberOrHash) (inter
 	return argBigPtr(acc.Balance), nil
 }
 
+// GetBalanceChanges returns the balance changes of the account within the block range
+// [fromBlock, toBlock], by comparing the account balance between the state roots of
+// consecutive blocks. The result is paginated: when the range (or the limit) is not
+// fully covered, the returned next block should be used as fromBlock of the next call.
+func (e *Eth) GetBalanceChanges(
+	address types.Address,
+	fromBlock BlockNumber,
+	toBlock BlockNumber,
+	limit *argUint64,
+) (interface{}, error) {
+	from, err := GetNumericBlockNumber(fromBlock, e)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := GetNumericBlockNumber(toBlock, e)
+	if err != nil {
+		return nil, err
+	}
+
+	if from > to {
+		return nil, ErrIncorrectBlockRange
+	}
+
+	maxChanges := uint64(defaultBalanceChangesLimit)
+	if limit != nil && *limit > 0 && uint64(*limit) < maxChanges {
+		maxChanges = uint64(*limit)
+	}
+
+	// never scan more than a page of blocks per call
+	end := to
+	if end-from >= maxBalanceChangesBlockRange {
+		end = from + maxBalanceChangesBlockRange - 1
+	}
+
+	// the balance before the first block, genesis starts from nothing
+	prevBalance := big.NewInt(0)
+
+	if from > 0 {
+		header, ok := e.store.GetHeaderByNumber(from - 1)
+		if !ok {
+			return nil, fmt.Errorf("error fetching block number %d header", from-1)
+		}
+
+		if prevBalance, err = e.getBalanceAtRoot(header.StateRoot, address); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &balanceChanges{
+		Changes: []*balanceChange{},
+	}
+
+	for num := from; num <= end; num++ {
+		header, ok := e.store.GetHeaderByNumber(num)
+		if !ok {
+			return nil, fmt.Errorf("error fetching block number %d header", num)
+		}
+
+		balance, err := e.getBalanceAtRoot(header.StateRoot, address)
+		if err != nil {
+			return nil, err
+		}
+
+		if balance.Cmp(prevBalance) != 0 {
+			res.Changes = append(res.Changes, &balanceChange{
+				BlockNumber: argUint64(num),
+				BlockHash:   header.Hash,
+				Previous:    argBig(*prevBalance),
+				Current:     argBig(*balance),
+			})
+		}
+
+		prevBalance = balance
+
+		if uint64(len(res.Changes)) >= maxChanges {
+			end = num
+
+			break
+		}
+	}
+
+	if end < to {
+		res.Next = argUintPtr(end + 1)
+	}
+
+	return res, nil
+}
+
+// getBalanceAtRoot returns the account balance in the given state,
+// an account which is not initialized yet has an empty balance
+func (e *Eth) getBalanceAtRoot(root types.Hash, address types.Address) (*big.Int, error) {
+	acc, err := e.store.GetAccount(root, address)
+	if errors.Is(err, ErrStateNotFound) {
+		return big.NewInt(0), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Set(acc.Balance), nil
+}
+
 // GetTransactionCount returns account nonce
 func (e *Eth) GetTransactionCount(address types.Address, filter BlockNumberOrHash) (interface{}, error) {
 	var (
@@ -818,6 +1294,11 @@ func (e *Eth) NewBlockFilter() (interface{}, error) {
 	return e.filterManager.NewBlockFilter(nil), nil
 }
 
+// NewPendingTransactionFilter creates a filter in the node, to notify when new transactions are pending
+func (e *Eth) NewPendingTransactionFilter() (interface{}, error) {
+	return e.filterManager.NewPendingTxFilter(nil), nil
+}
+
 // GetFilterChanges is a polling method for a filter, which returns an array of logs
 // which occurred since last poll. WebSocket polling log filter changes would not be
 // accepted anymore.
@@ -892,6 +1373,48 @@ func (e *Eth) getNextNonce(address types.Address, number BlockNumber) (uint64, e
 	return acc.Nonce, nil
 }
 
+// getSealedTx returns the block of the sealed transaction and its index in the block
+func (e *Eth) getSealedTx(hash types.Hash) (*types.Block, int, bool) {
+	entry, ok := e.store.ReadTxLookupEntry(hash)
+	if !ok {
+		return nil, 0, false
+	}
+
+	block, ok := e.store.GetBlockByHash(entry.BlockHash, true)
+	if !ok {
+		e.logger.Warn(
+			fmt.Sprintf("Block with hash [%s] not found", entry.BlockHash.String()),
+		)
+
+		return nil, 0, false
+	}
+
+	// the lookup may be stale, when the block was reorganized out
+	if entry.Index >= uint64(len(block.Transactions)) || block.Transactions[entry.Index].Hash != hash {
+		return nil, 0, false
+	}
+
+	return block, int(entry.Index), true
+}
+
+// isPending returns whether the filter references the pending block
+func isPending(filter BlockNumberOrHash) bool {
+	return filter.BlockNumber != nil && *filter.BlockNumber == PendingBlockNumber
+}
+
+// applyPendingTxn applies the transaction on top of the pending state and the state overrides if any
+func applyPendingTxn(
+	pending *state.Transition,
+	txn *types.Transaction,
+	overrides state.Overrides,
+) (*runtime.ExecutionResult, error) {
+	if err := pending.ApplyOverrides(overrides); err != nil {
+		return nil, err
+	}
+
+	return pending.Apply(txn)
+}
+
 func (e *Eth) decodeTxn(arg *txnArgs) (*types.Transaction, error) {
 	// set default values
 	if arg.From == nil {
@@ -947,6 +1470,32 @@ func (e *Eth) decodeTxn(arg *txnArgs) (*types.Transaction, error) {
 		txn.To = arg.To
 	}
 
+	// fee caps make it a dynamic fee transaction
+	if (arg.Type != nil && types.TxType(*arg.Type) == types.DynamicFeeTx) ||
+		arg.MaxFeePerGas != nil || arg.MaxPriorityFeePerGas != nil {
+		if arg.MaxFeePerGas == nil {
+			arg.MaxFeePerGas = argBytesPtr([]byte{})
+		}
+
+		if arg.MaxPriorityFeePerGas == nil {
+			arg.MaxPriorityFeePerGas = argBytesPtr([]byte{})
+		}
+
+		txn.Type = types.DynamicFeeTx
+		txn.GasPrice = nil
+		txn.GasFeeCap = new(big.Int).SetBytes(*arg.MaxFeePerGas)
+		txn.GasTipCap = new(big.Int).SetBytes(*arg.MaxPriorityFeePerGas)
+		txn.ChainID = new(big.Int).SetUint64(e.chainID)
+	} else if (arg.Type != nil && types.TxType(*arg.Type) == types.AccessListTx) || arg.AccessList != nil {
+		// an access list alone makes it an access list transaction
+		txn.Type = types.AccessListTx
+		txn.ChainID = new(big.Int).SetUint64(e.chainID)
+	}
+
+	if arg.AccessList != nil {
+		txn.AccessList = fromAccessList(*arg.AccessList)
+	}
+
 	txn.ComputeHash()
 
 	return txn, nil
@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -10,9 +11,12 @@ import (
 	"github.com/dogechain-lab/dogechain/chain"
 	"github.com/dogechain-lab/dogechain/helper/hex"
 	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
 	"github.com/dogechain-lab/dogechain/state/runtime"
+	"github.com/dogechain-lab/dogechain/state/runtime/evm"
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/dogechain-lab/fastrlp"
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -582,6 +586,56 @@ func TestEth_State_GetStorageAt(t *testing.T) {
 	}
 }
 
+func TestEth_State_GetProof(t *testing.T) {
+	storageRoot := types.StringToHash("3")
+
+	store := getExampleStore()
+	store.account.account.Nonce = 2
+	store.account.account.Root = storageRoot
+	store.account.account.CodeHash = hash2.Bytes()
+	store.account.Storage(hash1, []byte{0x82, 0x01, 0x00})
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetProof(addr0, []types.Hash{hash1, hash2}, BlockNumberOrHash{})
+	assert.NoError(t, err)
+
+	// the mock proofs are the roots and keys they are requested for
+	assert.Equal(t, &accountProof{
+		Address:      addr0,
+		AccountProof: []argBytes{types.EmptyRootHash.Bytes(), addr0.Bytes()},
+		Balance:      argBig(*big.NewInt(100)),
+		CodeHash:     hash2,
+		Nonce:        2,
+		StorageHash:  storageRoot,
+		StorageProof: []*storageProof{
+			{
+				Key:   hash1,
+				Value: argBig(*big.NewInt(0x100)),
+				Proof: []argBytes{storageRoot.Bytes(), hash1.Bytes()},
+			},
+			{
+				Key:   hash2,
+				Value: argBig(*big.NewInt(0)),
+				Proof: []argBytes{storageRoot.Bytes(), hash2.Bytes()},
+			},
+		},
+	}, res)
+
+	// an absent account is proven with the empty account fields
+	res, err = eth.GetProof(uninitializedAddress, []types.Hash{hash1}, BlockNumberOrHash{})
+	assert.NoError(t, err)
+
+	proof, ok := res.(*accountProof)
+	assert.True(t, ok)
+	assert.Equal(t, argBig(*big.NewInt(0)), proof.Balance)
+	assert.Equal(t, argUint64(0), proof.Nonce)
+	assert.Equal(t, emptyCodeHash, proof.CodeHash)
+	assert.Equal(t, types.EmptyRootHash, proof.StorageHash)
+	assert.Equal(t, argBig(*big.NewInt(0)), proof.StorageProof[0].Value)
+	assert.Equal(t, []argBytes{types.EmptyRootHash.Bytes(), hash1.Bytes()}, proof.StorageProof[0].Proof)
+}
+
 func constructMockTx(gasLimit *argUint64, data *argBytes) *txnArgs {
 	return &txnArgs{
 		From:     &addr0,
@@ -662,6 +716,7 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 				store.applyTxnHook = func(
 					header *types.Header,
 					txn *types.Transaction,
+					_ state.Overrides,
 				) (*runtime.ExecutionResult, error) {
 					return &runtime.ExecutionResult{}, state.ErrNotEnoughIntrinsicGas
 				}
@@ -671,6 +726,7 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 				store.applyTxnHook = func(
 					header *types.Header,
 					txn *types.Transaction,
+					_ state.Overrides,
 				) (*runtime.ExecutionResult, error) {
 					if txn.Gas < testCase.intrinsicGasCost {
 						return &runtime.ExecutionResult{}, state.ErrNotEnoughIntrinsicGas
@@ -681,7 +737,7 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 			}
 
 			// Run the estimation
-			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil)
+			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil, nil)
 
 			if testCase.expectedError != nil {
 				if estimateErr == nil {
@@ -721,6 +777,7 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 	store.applyTxnHook = func(
 		header *types.Header,
 		txn *types.Transaction,
+		_ state.Overrides,
 	) (*runtime.ExecutionResult, error) {
 		return &runtime.ExecutionResult{
 			ReturnValue: rawReturnData,
@@ -732,6 +789,7 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 	estimate, estimateErr := ethEndpoint.EstimateGas(
 		constructMockTx(nil, nil),
 		nil,
+		nil,
 	)
 
 	assert.Equal(t, 0, estimate)
@@ -758,6 +816,7 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 	estimate, estimateErr := ethEndpoint.EstimateGas(
 		mockTx,
 		nil,
+		nil,
 	)
 
 	assert.Equal(t, 0, estimate)
@@ -766,12 +825,65 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 	assert.ErrorIs(t, estimateErr, ErrInsufficientFunds)
 }
 
+func TestEth_EstimateGas_StateOverride(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	// Account doesn't have any balance
+	store.account.account.Balance = big.NewInt(0)
+
+	var applied []state.Overrides
+
+	store.applyTxnHook = func(
+		header *types.Header,
+		txn *types.Transaction,
+		overrides state.Overrides,
+	) (*runtime.ExecutionResult, error) {
+		applied = append(applied, overrides)
+
+		return &runtime.ExecutionResult{}, nil
+	}
+
+	// The transaction has a value > 0
+	mockTx := constructMockTx(nil, nil)
+	mockTx.Value = argBytesPtr([]byte{0x1})
+
+	// The overridden balance covers the value
+	override := stateOverride{}
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"`+mockTx.From.String()+`": {
+			"balance": "0x10",
+			"stateDiff": {"0x01": "0x02"}
+		}
+	}`), &override))
+
+	_, estimateErr := ethEndpoint.EstimateGas(mockTx, nil, &override)
+	assert.NoError(t, estimateErr)
+
+	// Every execution runs on top of the overrides
+	assert.NotEmpty(t, applied)
+
+	for _, overrides := range applied {
+		account := overrides[*mockTx.From]
+		assert.Equal(t, big.NewInt(0x10), account.Balance)
+		assert.Equal(t, map[types.Hash]types.Hash{
+			types.StringToHash("0x01"): types.StringToHash("0x02"),
+		}, account.StateDiff)
+		assert.Nil(t, account.Nonce)
+		assert.Nil(t, account.State)
+	}
+}
+
 type mockSpecialStore struct {
 	ethStore
 	account *mockAccount
 	block   *types.Block
 
-	applyTxnHook func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	applyTxnHook func(
+		header *types.Header,
+		txn *types.Transaction,
+		overrides state.Overrides,
+	) (*runtime.ExecutionResult, error)
 }
 
 func (m *mockSpecialStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
@@ -829,14 +941,254 @@ func (m *mockSpecialStore) GetCode(hash types.Hash) ([]byte, error) {
 	return nil, fmt.Errorf("code not found")
 }
 
+func (m *mockSpecialStore) GetProof(root types.Hash, key []byte) ([][]byte, error) {
+	return [][]byte{root.Bytes(), key}, nil
+}
+
 func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return chain.ForksInTime{}
 }
 
-func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+func (m *mockSpecialStore) ApplyTxn(
+	header *types.Header,
+	txn *types.Transaction,
+	overrides state.Overrides,
+) (*runtime.ExecutionResult, error) {
 	if m.applyTxnHook != nil {
-		return m.applyTxnHook(header, txn)
+		return m.applyTxnHook(header, txn, overrides)
 	}
 
 	return &runtime.ExecutionResult{}, nil
 }
+
+// mockBalanceStore keeps the balance of addr0 in every block
+type mockBalanceStore struct {
+	ethStore
+	balances []int64
+}
+
+func (m *mockBalanceStore) Header() *types.Header {
+	header, _ := m.GetHeaderByNumber(uint64(len(m.balances) - 1))
+
+	return header
+}
+
+func (m *mockBalanceStore) GetHeaderByNumber(blockNumber uint64) (*types.Header, bool) {
+	if blockNumber >= uint64(len(m.balances)) {
+		return nil, false
+	}
+
+	return &types.Header{
+		Number:    blockNumber,
+		Hash:      types.BytesToHash(big.NewInt(int64(blockNumber) + 1).Bytes()),
+		StateRoot: types.BytesToHash(big.NewInt(int64(blockNumber)).Bytes()),
+	}, true
+}
+
+func (m *mockBalanceStore) GetAccount(root types.Hash, addr types.Address) (*state.Account, error) {
+	if addr != addr0 {
+		return nil, ErrStateNotFound
+	}
+
+	return &state.Account{
+		Balance: big.NewInt(m.balances[new(big.Int).SetBytes(root.Bytes()).Uint64()]),
+	}, nil
+}
+
+func TestEth_State_GetBalanceChanges(t *testing.T) {
+	store := &mockBalanceStore{
+		balances: []int64{100, 100, 150, 150, 120, 120, 200},
+	}
+	eth := newTestEthEndpoint(store)
+
+	tests := []struct {
+		name            string
+		address         types.Address
+		from            BlockNumber
+		to              BlockNumber
+		limit           *argUint64
+		shouldFail      bool
+		expectedBlocks  []uint64
+		expectedCurrent []int64
+		expectedNext    *argUint64
+	}{
+		{
+			name:            "whole chain",
+			address:         addr0,
+			from:            EarliestBlockNumber,
+			to:              LatestBlockNumber,
+			expectedBlocks:  []uint64{0, 2, 4, 6},
+			expectedCurrent: []int64{100, 150, 120, 200},
+		},
+		{
+			name:            "range in the middle",
+			address:         addr0,
+			from:            BlockNumber(1),
+			to:              BlockNumber(4),
+			expectedBlocks:  []uint64{2, 4},
+			expectedCurrent: []int64{150, 120},
+		},
+		{
+			name:            "paginated by limit",
+			address:         addr0,
+			from:            BlockNumber(1),
+			to:              LatestBlockNumber,
+			limit:           argUintPtr(1),
+			expectedBlocks:  []uint64{2},
+			expectedCurrent: []int64{150},
+			expectedNext:    argUintPtr(3),
+		},
+		{
+			name:            "uninitialized account",
+			address:         uninitializedAddress,
+			from:            EarliestBlockNumber,
+			to:              LatestBlockNumber,
+			expectedBlocks:  []uint64{},
+			expectedCurrent: []int64{},
+		},
+		{
+			name:       "invalid range",
+			address:    addr0,
+			from:       BlockNumber(4),
+			to:         BlockNumber(1),
+			shouldFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := eth.GetBalanceChanges(tt.address, tt.from, tt.to, tt.limit)
+			if tt.shouldFail {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			changes, ok := res.(*balanceChanges)
+			if !ok {
+				t.Fatalf("invalid type assertion")
+			}
+
+			assert.Len(t, changes.Changes, len(tt.expectedBlocks))
+
+			for i, change := range changes.Changes {
+				current := big.Int(change.Current)
+
+				assert.Equal(t, tt.expectedBlocks[i], uint64(change.BlockNumber))
+				assert.Equal(t, tt.expectedCurrent[i], current.Int64())
+			}
+
+			assert.Equal(t, tt.expectedNext, changes.Next)
+		})
+	}
+}
+
+// mockPendingStore builds its pending block by applying a transfer of 10 from
+// pendingSender to addr1 on top of the latest state
+type mockPendingStore struct {
+	*mockSpecialStore
+	t *testing.T
+}
+
+var pendingSender = types.StringToAddress("10")
+
+func (m *mockPendingStore) PendingState() (*types.Block, *state.Transition, error) {
+	executor := state.NewExecutor(
+		&chain.Params{ChainID: 100, Forks: chain.AllForksEnabled},
+		itrie.NewState(itrie.NewMemoryStorage()),
+		hclog.NewNullLogger(),
+	)
+	executor.SetRuntime(evm.NewEVM())
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash { return types.ZeroHash }
+	}
+
+	latest := m.Header()
+	header := &types.Header{
+		ParentHash: latest.Hash,
+		Number:     latest.Number + 1,
+		GasLimit:   100000,
+	}
+
+	txn, err := executor.BeginTxn(
+		executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+			pendingSender: {Balance: big.NewInt(100)},
+		}),
+		header,
+		types.ZeroAddress,
+	)
+	assert.NoError(m.t, err)
+
+	tx := &types.Transaction{
+		From:     pendingSender,
+		To:       &addr1,
+		Value:    big.NewInt(10),
+		Gas:      21000,
+		GasPrice: big.NewInt(0),
+		Hash:     hash1,
+	}
+	assert.NoError(m.t, txn.Write(tx))
+
+	header.GasUsed = txn.TotalGas()
+
+	return &types.Block{Header: header, Transactions: []*types.Transaction{tx}}, txn, nil
+}
+
+func TestEth_State_Pending(t *testing.T) {
+	store := &mockPendingStore{mockSpecialStore: getExampleStore(), t: t}
+	eth := newTestEthEndpoint(store)
+
+	pending := PendingBlockNumber
+	filter := BlockNumberOrHash{BlockNumber: &pending}
+
+	t.Run("returns the pending block", func(t *testing.T) {
+		res, err := eth.GetBlockByNumber(PendingBlockNumber, false)
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		block := res.(*block)
+		assert.Equal(t, argUint64(1), block.Number)
+		assert.Equal(t, argUint64(21000), block.GasUsed)
+		assert.Equal(t, []transactionOrHash{transactionHash(hash1)}, block.Transactions)
+	})
+
+	t.Run("returns the pending balance", func(t *testing.T) {
+		balance, err := eth.GetBalance(addr1, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, argBigPtr(big.NewInt(10)), balance)
+
+		balance, err = eth.GetBalance(pendingSender, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, argBigPtr(big.NewInt(90)), balance)
+	})
+
+	t.Run("returns the pool nonce", func(t *testing.T) {
+		nonce, err := eth.GetTransactionCount(addr0, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, argUintPtr(1), nonce)
+	})
+
+	t.Run("calls on top of the pending state", func(t *testing.T) {
+		value := argBytes(big.NewInt(10).Bytes())
+		zero := argBytes{}
+
+		// addr1 only holds the value it received in the pending block
+		_, err := eth.Call(&txnArgs{
+			From:     &addr1,
+			To:       &addr2,
+			Value:    &value,
+			GasPrice: &zero,
+		}, filter, nil)
+		assert.NoError(t, err)
+
+		_, err = eth.Call(&txnArgs{
+			From:     &pendingSender,
+			To:       &addr2,
+			Value:    &value,
+			GasPrice: &zero,
+		}, filter, nil)
+		assert.NoError(t, err)
+	})
+}
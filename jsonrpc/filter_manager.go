@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -24,8 +25,10 @@ var (
 	ErrBlockNotFound                    = errors.New("block not found")
 	ErrIncorrectBlockRange              = errors.New("incorrect range")
 	ErrBlockRangeTooHigh                = errors.New("block range too high")
+	ErrLogLimitExceeded                 = errors.New("query returned more logs than the limit")
 	ErrPendingBlockNumber               = errors.New("pending block number is not supported")
 	ErrNoWSConnection                   = errors.New("no websocket connection")
+	ErrWSWriteFailed                    = errors.New("web socket write failed")
 )
 
 // defaultTimeout is the timeout to remove the filters that don't have a web socket stream
@@ -36,6 +39,9 @@ const (
 	NoIndexInHeap = -1
 	// _checkDuration is for filter timeout check
 	_checkDuration = time.Second
+	// maxPendingTxUpdates is the number of promoted transaction hashes a filter keeps
+	// for a lagging subscriber, the oldest ones being dropped beyond
+	maxPendingTxUpdates = 4096
 )
 
 // filter is an interface that BlockFilter and LogFilter implement
@@ -66,6 +72,9 @@ type filterBase struct {
 
 	// websocket connection
 	ws wsConn
+
+	// namespace of the subscription notifications
+	namespace Namespace
 }
 
 // newFilterBase initializes filterBase with unique ID
@@ -74,6 +83,7 @@ func newFilterBase(ws wsConn) filterBase {
 		id:        uuid.New().String(),
 		ws:        ws,
 		heapIndex: NoIndexInHeap,
+		namespace: NamespaceEth,
 	}
 }
 
@@ -87,9 +97,9 @@ func (f *filterBase) hasWSConn() bool {
 	return f.ws != nil
 }
 
-const ethSubscriptionTemplate = `{
+const subscriptionTemplate = `{
 	"jsonrpc": "2.0",
-	"method": "eth_subscription",
+	"method": "%s_subscription",
 	"params": {
 		"subscription":"%s",
 		"result": %s
@@ -103,14 +113,16 @@ func (f *filterBase) writeMessageToWs(msg string) error {
 	}
 
 	var v bytes.Buffer
-	if _, err := v.WriteString(fmt.Sprintf(ethSubscriptionTemplate, f.id, msg)); err != nil {
+	if _, err := v.WriteString(fmt.Sprintf(subscriptionTemplate, f.namespace, f.id, msg)); err != nil {
 		return err
 	}
 
-	return f.ws.WriteMessage(
-		websocket.TextMessage,
-		v.Bytes(),
-	)
+	if err := f.ws.WriteMessage(websocket.TextMessage, v.Bytes()); err != nil {
+		// the connection is not usable anymore once a write failed
+		return fmt.Errorf("%w: %v", ErrWSWriteFailed, err)
+	}
+
+	return nil
 }
 
 // blockFilter is a filter to store the updates of block
@@ -164,6 +176,71 @@ func (f *blockFilter) sendUpdates() error {
 	return nil
 }
 
+// finalizedHead is the json representation of a finalized head
+type finalizedHead struct {
+	ParentHash     types.Hash    `json:"parentHash"`
+	Miner          types.Address `json:"miner"`
+	StateRoot      types.Hash    `json:"stateRoot"`
+	TxRoot         types.Hash    `json:"transactionsRoot"`
+	ReceiptsRoot   types.Hash    `json:"receiptsRoot"`
+	Number         argUint64     `json:"number"`
+	GasLimit       argUint64     `json:"gasLimit"`
+	GasUsed        argUint64     `json:"gasUsed"`
+	Timestamp      argUint64     `json:"timestamp"`
+	Hash           types.Hash    `json:"hash"`
+	BaseFee        *argUint64    `json:"baseFeePerGas,omitempty"`
+	CommittedSeals argUint64     `json:"committedSeals"`
+}
+
+// finalizedHeadFilter is a filter to store the updates of finalized heads
+type finalizedHeadFilter struct {
+	blockFilter
+
+	// committedSeals returns the number of committed seals finalizing the header
+	committedSeals func(header *types.Header) (int, error)
+}
+
+// sendUpdates writes the finalized heads with their committed seals to web socket stream
+func (f *finalizedHeadFilter) sendUpdates() error {
+	updates := f.takeBlockUpdates()
+
+	for _, header := range updates {
+		seals, err := f.committedSeals(header)
+		if err != nil {
+			return err
+		}
+
+		head := &finalizedHead{
+			ParentHash:     header.ParentHash,
+			Miner:          header.Miner,
+			StateRoot:      header.StateRoot,
+			TxRoot:         header.TxRoot,
+			ReceiptsRoot:   header.ReceiptsRoot,
+			Number:         argUint64(header.Number),
+			GasLimit:       argUint64(header.GasLimit),
+			GasUsed:        argUint64(header.GasUsed),
+			Timestamp:      argUint64(header.Timestamp),
+			Hash:           header.Hash,
+			CommittedSeals: argUint64(seals),
+		}
+
+		if header.BaseFee != 0 {
+			head.BaseFee = argUintPtr(header.BaseFee)
+		}
+
+		raw, err := json.Marshal(head)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(raw)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // logFilter is a filter to store logs that meet the conditions in query
 type logFilter struct {
 	filterBase
@@ -221,6 +298,75 @@ func (f *logFilter) sendUpdates() error {
 	return nil
 }
 
+// pendingTxFilter is a filter to store the hashes of the transactions promoted by the pool
+type pendingTxFilter struct {
+	filterBase
+	sync.Mutex
+	logger hclog.Logger
+	hashes []types.Hash
+
+	// dropped is the number of hashes dropped since the last updates, the subscriber lagging behind
+	dropped int
+}
+
+// appendTxs appends the hashes of the transactions, dropping the oldest ones beyond the limit
+func (f *pendingTxFilter) appendTxs(txs []*types.Transaction) {
+	f.Lock()
+	defer f.Unlock()
+
+	for _, tx := range txs {
+		f.hashes = append(f.hashes, tx.Hash)
+	}
+
+	if overflow := len(f.hashes) - maxPendingTxUpdates; overflow > 0 {
+		f.hashes = append(f.hashes[:0], f.hashes[overflow:]...)
+		f.dropped += overflow
+	}
+}
+
+// takeTxUpdates returns all saved hashes in filter, and reports the ones dropped since the last updates
+func (f *pendingTxFilter) takeTxUpdates() []types.Hash {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.dropped > 0 {
+		f.logger.Warn("subscriber lagging behind, promoted transactions dropped", "id", f.id, "dropped", f.dropped)
+	}
+
+	hashes := f.hashes
+	f.hashes, f.dropped = nil, 0
+
+	return hashes
+}
+
+// getUpdates returns stored hashes in string
+func (f *pendingTxFilter) getUpdates() (string, error) {
+	hashes := f.takeTxUpdates()
+	if len(hashes) == 0 {
+		return "[]", nil
+	}
+
+	updates := make([]string, len(hashes))
+	for i, hash := range hashes {
+		updates[i] = hash.String()
+	}
+
+	return fmt.Sprintf("[\"%s\"]", strings.Join(updates, "\",\"")), nil
+}
+
+// sendUpdates writes stored hashes to web socket stream
+func (f *pendingTxFilter) sendUpdates() error {
+	hashes := f.takeTxUpdates()
+
+	for _, hash := range hashes {
+		if err := f.writeMessageToWs(fmt.Sprintf("\"%s\"", hash)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
@@ -237,6 +383,18 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// GetCommittedSeals returns the number of committed seals finalizing the header
+	GetCommittedSeals(header *types.Header) (int, error)
+
+	// AddPromoteHook registers the hook notified of the transactions promoted by the pool
+	AddPromoteHook(hook func(txs []*types.Transaction))
+
+	// BloomIndexedBlocks returns the number of the first blocks of the chain covered by the bloom bits index
+	BloomIndexedBlocks() uint64
+
+	// MatchBloomBits returns the numbers of the indexed blocks of the range whose logs bloom might match the filters
+	MatchBloomBits(from, to uint64, filters [][][]byte) ([]uint64, error)
 }
 
 // FilterManager manages all running filters
@@ -250,13 +408,16 @@ type FilterManager struct {
 	store           filterManagerStore
 	subscription    blockchain.Subscription
 	blockStream     *blockStream
+	finalizedStream *blockStream
 	blockRangeLimit uint64
+	logLimit        uint64
 
 	filters  map[string]filter
 	timeouts timeHeapImpl
 
-	updateCh chan struct{}
-	closeCh  chan struct{}
+	updateCh  chan struct{}
+	pendingCh chan struct{}
+	closeCh   chan struct{}
 }
 
 func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeLimit uint64) *FilterManager {
@@ -265,20 +426,26 @@ func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeL
 		timeout:         defaultTimeout,
 		store:           store,
 		blockStream:     &blockStream{},
+		finalizedStream: &blockStream{},
 		blockRangeLimit: blockRangeLimit,
 		filters:         make(map[string]filter),
 		timeouts:        timeHeapImpl{},
 		updateCh:        make(chan struct{}),
+		pendingCh:       make(chan struct{}, 1),
 		closeCh:         make(chan struct{}),
 	}
 
 	// start blockstream with the current header
 	header := store.Header()
 	m.blockStream.push(header)
+	m.finalizedStream.push(header)
 
 	// start the head watcher
 	m.subscription = store.SubscribeEvents()
 
+	// watch the promoted transactions
+	store.AddPromoteHook(m.appendPendingTxs)
+
 	return m
 }
 
@@ -325,6 +492,11 @@ func (f *FilterManager) Run() {
 			if err := f.dispatchEvent(ev); err != nil {
 				f.logger.Error("failed to dispatch event", "err", err)
 			}
+		case <-f.pendingCh:
+			// new promoted transactions
+			if err := f.flushWsFilters(); err != nil {
+				f.logger.Error("failed to flush pending transactions", "err", err)
+			}
 		case <-checkTimer.C:
 			// no need to do anything, checkout the timeout filter in the next loop
 		case <-f.updateCh:
@@ -355,6 +527,25 @@ func (f *FilterManager) NewBlockFilter(ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewFinalizedHeadFilter adds new FinalizedHeadFilter
+func (f *FilterManager) NewFinalizedHeadFilter(ws wsConn) string {
+	filter := &finalizedHeadFilter{
+		blockFilter: blockFilter{
+			filterBase: newFilterBase(ws),
+			block:      f.finalizedStream.Head(),
+		},
+		committedSeals: f.store.GetCommittedSeals,
+	}
+
+	filter.namespace = NamespaceDc
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
 // NewLogFilter adds new LogFilter
 func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	filter := &logFilter{
@@ -362,9 +553,55 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 		query:      logQuery,
 	}
 
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
+// NewPendingTxFilter adds new PendingTxFilter
+func (f *FilterManager) NewPendingTxFilter(ws wsConn) string {
+	filter := &pendingTxFilter{
+		filterBase: newFilterBase(ws),
+		logger:     f.logger,
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
 	return f.addFilter(filter)
 }
 
+// appendPendingTxs makes each PendingTxFilter append the promoted transactions,
+// and signals the worker to flush them to the web socket streams. It never blocks the pool
+func (f *FilterManager) appendPendingTxs(txs []*types.Transaction) {
+	f.RLock()
+
+	found := false
+
+	for _, filter := range f.filters {
+		if pendingFilter, ok := filter.(*pendingTxFilter); ok {
+			pendingFilter.appendTxs(txs)
+
+			found = true
+		}
+	}
+
+	f.RUnlock()
+
+	if !found {
+		return
+	}
+
+	select {
+	case f.pendingCh <- struct{}{}:
+	default:
+		// the worker is already signaled
+	}
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.RLock()
@@ -375,6 +612,12 @@ func (f *FilterManager) Exists(id string) bool {
 	return ok
 }
 
+// SetLogLimit bounds the number of logs returned by a query, unlimited if 0.
+// The queries exceeding it fail, unless they are paged by a cursor
+func (f *FilterManager) SetLogLimit(limit uint64) {
+	f.logLimit = limit
+}
+
 func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([]*Log, error) {
 	receipts, err := f.store.GetReceiptsByHash(block.Header.Hash)
 	if err != nil {
@@ -383,8 +626,13 @@ func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([
 
 	logs := make([]*Log, 0)
 
+	// the logs are indexed in the block
+	logIndex := 0
+
 	for idx, receipt := range receipts {
-		for logIdx, log := range receipt.Logs {
+		for _, log := range receipt.Logs {
+			logIndex++
+
 			if !query.Match(log) {
 				continue
 			}
@@ -397,7 +645,7 @@ func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([
 				BlockHash:   block.Header.Hash,
 				TxHash:      block.Transactions[idx].Hash,
 				TxIndex:     argUint64(idx),
-				LogIndex:    argUint64(logIdx),
+				LogIndex:    argUint64(logIndex - 1),
 			})
 		}
 	}
@@ -405,7 +653,25 @@ func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([
 	return logs, nil
 }
 
-func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
+// appendLogs appends the logs of a block not skipped by the cursor of the query. Once the log limit
+// is reached, it returns the cursor of the first log left out
+func (f *FilterManager) appendLogs(query *LogQuery, logs, blockLogs []*Log) ([]*Log, *logCursor) {
+	for _, log := range blockLogs {
+		if query.Cursor != nil && query.Cursor.skips(log) {
+			continue
+		}
+
+		if f.logLimit > 0 && uint64(len(logs)) >= f.logLimit {
+			return logs, &logCursor{BlockNumber: uint64(log.BlockNumber), LogIndex: uint64(log.LogIndex)}
+		}
+
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
+
+func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, *logCursor, error) {
 	latestBlockNumber := f.store.Header().Number
 
 	resolveNum := func(num BlockNumber) (uint64, error) {
@@ -423,12 +689,12 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 
 	from, err := resolveNum(query.FromBlock)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	to, err := resolveNum(query.ToBlock)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// If from equals genesis block
@@ -438,17 +704,52 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 	}
 
 	if to < from {
-		return nil, ErrIncorrectBlockRange
+		return nil, nil, ErrIncorrectBlockRange
 	}
 
-	// if not disabled, avoid handling large block ranges
-	if f.blockRangeLimit > 0 && to-from > f.blockRangeLimit {
-		return nil, ErrBlockRangeTooHigh
+	// a paged query continues from the block of its cursor
+	if query.Cursor != nil && query.Cursor.BlockNumber > from {
+		if query.Cursor.BlockNumber > to {
+			return nil, nil, ErrInvalidLogCursor
+		}
+
+		from = query.Cursor.BlockNumber
+	}
+
+	// the indexed blocks whose bloom does not match the query are skipped,
+	// the ones above the index are all read
+	var (
+		candidates []uint64
+		tail       = from
+	)
+
+	if filters := query.bloomFilters(); len(filters) > 0 {
+		if indexed := f.store.BloomIndexedBlocks(); indexed > from {
+			if candidates, err = f.store.MatchBloomBits(from, to, filters); err != nil {
+				return nil, nil, err
+			}
+
+			tail = indexed
+		}
+	}
+
+	// if not disabled, avoid reading too many blocks, a range of blockRangeLimit spanning one more block
+	scanned := uint64(len(candidates))
+	if tail <= to {
+		scanned += to - tail + 1
+	}
+
+	if f.blockRangeLimit > 0 && scanned > f.blockRangeLimit+1 {
+		return nil, nil, ErrBlockRangeTooHigh
+	}
+
+	for i := tail; i <= to; i++ {
+		candidates = append(candidates, i)
 	}
 
 	logs := make([]*Log, 0)
 
-	for i := from; i <= to; i++ {
+	for _, i := range candidates {
 		block, ok := f.store.GetBlockByNumber(i, true)
 		if !ok {
 			break
@@ -461,34 +762,66 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 
 		blockLogs, err := f.getLogsFromBlock(query, block)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		logs = append(logs, blockLogs...)
+		var next *logCursor
+
+		if logs, next = f.appendLogs(query, logs, blockLogs); next != nil {
+			return logs, next, nil
+		}
 	}
 
-	return logs, nil
+	return logs, nil, nil
 }
 
 // GetLogs return array of logs for given query
 func (f *FilterManager) GetLogs(query *LogQuery) ([]*Log, error) {
+	logs, _, err := f.GetLogsPage(query)
+
+	return logs, err
+}
+
+// GetLogsPage returns the logs of the query, and the cursor of the next page if the query is paged
+// and exceeds the log limit. A query exceeding the limit without a cursor fails
+func (f *FilterManager) GetLogsPage(query *LogQuery) ([]*Log, *logCursor, error) {
+	var (
+		logs []*Log
+		next *logCursor
+	)
+
 	if query.BlockHash != nil {
 		//	BlockHash is set -> fetch logs from this block only
 		block, ok := f.store.GetBlockByHash(*query.BlockHash, true)
 		if !ok {
-			return nil, ErrBlockNotFound
+			return nil, nil, ErrBlockNotFound
 		}
 
 		if len(block.Transactions) == 0 {
 			// no txs in block, return empty response
-			return []*Log{}, nil
+			return []*Log{}, nil, nil
 		}
 
-		return f.getLogsFromBlock(query, block)
+		blockLogs, err := f.getLogsFromBlock(query, block)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		logs, next = f.appendLogs(query, make([]*Log, 0), blockLogs)
+	} else {
+		//	gets logs from a range of blocks
+		var err error
+
+		if logs, next, err = f.getLogsFromBlocks(query); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if next != nil && query.Cursor == nil {
+		return nil, nil, fmt.Errorf("%w of %d, page them with a cursor or narrow the range", ErrLogLimitExceeded, f.logLimit)
 	}
 
-	//	gets logs from a range of blocks
-	return f.getLogsFromBlocks(query)
+	return logs, next, nil
 }
 
 // getFilterByID fetches the filter by the ID
@@ -564,11 +897,15 @@ func (f *FilterManager) getFilterAndChanges(id string) (filter, string, error) {
 	return filter, res, nil
 }
 
-// Uninstall removes the filter with given ID from list
+// Uninstall removes the filter with given ID from list, and returns false if there was none
 func (f *FilterManager) Uninstall(id string) bool {
 	f.Lock()
 	defer f.Unlock()
 
+	if _, ok := f.filters[id]; !ok {
+		return false
+	}
+
 	return f.removeFilterByID(id)
 }
 
@@ -596,12 +933,16 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 	return true
 }
 
-// RemoveFilterByWs removes the filter with given WS [Thread safe]
+// RemoveFilterByWs removes all the filters subscribed through the given WS [Thread safe]
 func (f *FilterManager) RemoveFilterByWs(ws wsConn) {
 	f.Lock()
 	defer f.Unlock()
 
-	f.removeFilterByID(ws.GetFilterID())
+	for id, filter := range f.filters {
+		if filter.getFilterBase().ws == ws {
+			f.removeFilterByID(id)
+		}
+	}
 }
 
 // addFilter is an internal method to add given filter to list and heap
@@ -665,53 +1006,90 @@ func (f *FilterManager) processEvent(evnt *blockchain.Event) {
 	f.RLock()
 	defer f.RUnlock()
 
-	for _, header := range evnt.NewChain {
+	// the logs of the blocks reorganized out of the canonical chain are removed first,
+	// from the old head down. The forked blocks never were canonical
+	if evnt.Type == blockchain.EventReorg {
+		for _, header := range sortHeaders(evnt.OldChain, true) {
+			if processErr := f.appendLogsToFilters(header, true); processErr != nil {
+				f.logger.Error(fmt.Sprintf("Unable to process removed block, %v", processErr))
+			}
+		}
+	}
+
+	for _, header := range sortHeaders(evnt.NewChain, false) {
 		// first include all the new headers in the blockstream for BlockFilter
 		f.blockStream.push(header)
 
+		// only the heads extending the canonical chain are final,
+		// the reorganized and forked ones are not
+		if evnt.Type == blockchain.EventHead {
+			f.finalizedStream.push(header)
+		}
+
 		// process new chain to include new logs for LogFilter
-		if processErr := f.appendLogsToFilters(header); processErr != nil {
+		if processErr := f.appendLogsToFilters(header, false); processErr != nil {
 			f.logger.Error(fmt.Sprintf("Unable to process block, %v", processErr))
 		}
 	}
 }
 
-// appendLogsToFilters makes each LogFilters append logs in the header
-//
-// Would not append any removed logs.
-func (f *FilterManager) appendLogsToFilters(header *types.Header) error {
-	receipts, err := f.store.GetReceiptsByHash(header.Hash)
-	if err != nil {
-		return err
-	}
+// sortHeaders returns a copy of the headers sorted by number, in ascending order unless descending
+func sortHeaders(headers []*types.Header, descending bool) []*types.Header {
+	sorted := make([]*types.Header, len(headers))
+	copy(sorted, headers)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if descending {
+			return sorted[i].Number > sorted[j].Number
+		}
+
+		return sorted[i].Number < sorted[j].Number
+	})
 
+	return sorted
+}
+
+// appendLogsToFilters makes each LogFilters append logs in the header,
+// marked as removed if the block left the canonical chain
+func (f *FilterManager) appendLogsToFilters(header *types.Header, removed bool) error {
 	// Get logFilters from filters
 	logFilters := f.getLogFilters()
 	if len(logFilters) == 0 {
 		return nil
 	}
 
-	block, ok := f.store.GetBlockByHash(header.Hash, true)
-	if !ok {
-		f.logger.Error("could not find block in store", "hash", header.Hash.String())
-
-		return nil
+	receipts, err := f.store.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return err
 	}
 
+	// the block is only read for the receipts missing their transaction hash
+	var block *types.Block
+
 	for indx, receipt := range receipts {
 		// check the logs with the filters
 		for _, log := range receipt.Logs {
-			for _, f := range logFilters {
-				if !f.query.Match(log) {
+			for _, lf := range logFilters {
+				if !lf.query.matchBlock(header) || !lf.query.Match(log) {
 					continue
 				}
 
 				if receipt.TxHash == types.ZeroHash {
+					if block == nil {
+						var ok bool
+
+						if block, ok = f.store.GetBlockByHash(header.Hash, true); !ok {
+							f.logger.Error("could not find block in store", "hash", header.Hash.String())
+
+							return nil
+						}
+					}
+
 					// Extract tx Hash
 					receipt.TxHash = block.Transactions[indx].Hash
 				}
 
-				f.appendLog(&Log{
+				lf.appendLog(&Log{
 					Address:     log.Address,
 					Topics:      log.Topics,
 					Data:        argBytes(log.Data),
@@ -719,7 +1097,7 @@ func (f *FilterManager) appendLogsToFilters(header *types.Header) error {
 					BlockHash:   header.Hash,
 					TxHash:      receipt.TxHash,
 					TxIndex:     argUint64(indx),
-					Removed:     false,
+					Removed:     removed,
 				})
 			}
 		}
@@ -741,8 +1119,8 @@ func (f *FilterManager) flushWsFilters() error {
 		}
 
 		if flushErr := filter.sendUpdates(); flushErr != nil {
-			// mark as closed if the connection is closed
-			if errors.Is(flushErr, websocket.ErrCloseSent) {
+			// mark as closed if the connection is closed or stuck
+			if errors.Is(flushErr, ErrWSWriteFailed) {
 				closedFilterIDs = append(closedFilterIDs, id)
 
 				f.logger.Warn(fmt.Sprintf("Subscription %s has been closed", id))
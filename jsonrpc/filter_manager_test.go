@@ -1,6 +1,9 @@
 package jsonrpc
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 	"strconv"
 	"testing"
@@ -80,6 +83,116 @@ func TestFilterLog(t *testing.T) {
 	}
 }
 
+func TestFilterLog_Reorg(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	// filter manager should Close(), but mock one might crash on writing on a closed channel
+	//nolint:errcheck
+	defer recover()
+	defer m.Close()
+
+	go m.Run()
+
+	id := m.NewLogFilter(&LogQuery{
+		FromBlock: LatestBlockNumber,
+		ToBlock:   LatestBlockNumber,
+		Topics: [][]types.Hash{
+			{hash1},
+		},
+	}, nil)
+
+	// a filter from a later block
+	laterID := m.NewLogFilter(&LogQuery{
+		FromBlock: 5,
+		ToBlock:   LatestBlockNumber,
+	}, nil)
+
+	mockBlock := func(number uint64, hash types.Hash) *mockHeader {
+		return &mockHeader{
+			header: &types.Header{
+				Number: number,
+				Hash:   hash,
+			},
+			receipts: []*types.Receipt{
+				{
+					Logs: []*types.Log{
+						{
+							Topics: []types.Hash{hash1},
+						},
+					},
+					TxHash: hash,
+				},
+			},
+		}
+	}
+
+	getLogs := func(id string) []*Log {
+		t.Helper()
+
+		res, err := m.GetFilterChanges(id)
+		assert.NoError(t, err)
+
+		var logs []*Log
+
+		assert.NoError(t, json.Unmarshal([]byte(res), &logs))
+
+		return logs
+	}
+
+	oldHash1, oldHash2, newHash1 := types.StringToHash("11"), types.StringToHash("12"), types.StringToHash("21")
+
+	store.emitEvent(&mockEvent{
+		NewChain: []*mockHeader{mockBlock(1, oldHash1), mockBlock(2, oldHash2)},
+		Type:     blockchain.EventHead,
+	})
+
+	// a fork does not remove anything
+	store.emitEvent(&mockEvent{
+		OldChain: []*mockHeader{mockBlock(1, newHash1)},
+		Type:     blockchain.EventFork,
+	})
+
+	time.Sleep(500 * time.Millisecond)
+
+	logs := getLogs(id)
+	assert.Len(t, logs, 2)
+
+	// the blocks are reorganized out, the new chain being listed from its head
+	store.emitEvent(&mockEvent{
+		OldChain: []*mockHeader{mockBlock(1, oldHash1), mockBlock(2, oldHash2)},
+		NewChain: []*mockHeader{mockBlock(1, newHash1)},
+		Type:     blockchain.EventReorg,
+	})
+
+	time.Sleep(500 * time.Millisecond)
+
+	logs = getLogs(id)
+	assert.Len(t, logs, 3)
+
+	// the removed logs come first, from the old head down
+	for i, expected := range []struct {
+		hash    types.Hash
+		removed bool
+	}{
+		{oldHash2, true},
+		{oldHash1, true},
+		{newHash1, false},
+	} {
+		assert.Equal(t, expected.hash, logs[i].BlockHash)
+		assert.Equal(t, expected.removed, logs[i].Removed)
+	}
+
+	// out of the range of the later filter
+	assert.Empty(t, getLogs(laterID))
+
+	// unknown filters cannot be uninstalled
+	assert.True(t, m.Uninstall(id))
+	assert.False(t, m.Uninstall(id))
+}
+
 func TestFilterBlock(t *testing.T) {
 	t.Parallel()
 
@@ -283,6 +396,150 @@ func Test_GetLogsForQuery(t *testing.T) {
 	}
 }
 
+func Test_GetLogsForQuery_LogLimit(t *testing.T) {
+	t.Parallel()
+
+	topics := []types.Hash{types.StringToHash("4"), types.StringToHash("5"), types.StringToHash("6")}
+
+	store := &mockBlockStore{
+		topics: topics,
+	}
+	store.setupLogs()
+
+	blocks := make([]*types.Block, 5)
+
+	for i := range blocks {
+		blocks[i] = &types.Block{
+			Header: &types.Header{
+				Number: uint64(i),
+				Hash:   types.StringToHash(strconv.Itoa(i)),
+			},
+			Transactions: []*types.Transaction{
+				{
+					Value: big.NewInt(10),
+				},
+				{
+					Value: big.NewInt(11),
+				},
+				{
+					Value: big.NewInt(12),
+				},
+			},
+		}
+	}
+
+	store.appendBlocksToStore(blocks)
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	f.SetLogLimit(2)
+
+	t.Cleanup(func() {
+		f.Close() // prevent memory leak
+	})
+
+	query := &LogQuery{
+		FromBlock: 1,
+		ToBlock:   3,
+		Topics:    [][]types.Hash{{topics[0]}, {topics[1]}, {topics[2]}},
+	}
+
+	// the query exceeding the limit fails without a cursor
+	_, err := f.GetLogs(query)
+	assert.ErrorIs(t, err, ErrLogLimitExceeded)
+
+	// it is paged with a cursor
+	query.Cursor = &logCursor{}
+
+	logs, next, err := f.GetLogsPage(query)
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+	assert.Equal(t, argUint64(1), logs[0].BlockNumber)
+	assert.Equal(t, argUint64(2), logs[1].BlockNumber)
+	assert.Equal(t, uint64(3), next.BlockNumber)
+
+	cursor, err := parseLogCursor(next.String())
+	assert.NoError(t, err)
+
+	query.Cursor = cursor
+
+	logs, next, err = f.GetLogsPage(query)
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, argUint64(3), logs[0].BlockNumber)
+	assert.Nil(t, next)
+
+	// the cursor must be within the range
+	query.Cursor = &logCursor{BlockNumber: 4}
+
+	_, _, err = f.GetLogsPage(query)
+	assert.ErrorIs(t, err, ErrInvalidLogCursor)
+}
+
+func Test_GetLogsForQuery_BloomIndex(t *testing.T) {
+	t.Parallel()
+
+	topics := []types.Hash{types.StringToHash("4"), types.StringToHash("5"), types.StringToHash("6")}
+
+	store := &mockBlockStore{
+		topics: topics,
+		// block 2 is the only indexed block matching
+		bloomIndexed: 3,
+		bloomMatches: []uint64{2},
+	}
+	store.setupLogs()
+
+	blocks := make([]*types.Block, 5)
+
+	for i := range blocks {
+		blocks[i] = &types.Block{
+			Header: &types.Header{
+				Number: uint64(i),
+				Hash:   types.StringToHash(strconv.Itoa(i)),
+			},
+			Transactions: []*types.Transaction{
+				{
+					Value: big.NewInt(10),
+				},
+				{
+					Value: big.NewInt(11),
+				},
+				{
+					Value: big.NewInt(12),
+				},
+			},
+		}
+	}
+
+	store.appendBlocksToStore(blocks)
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 2)
+
+	t.Cleanup(func() {
+		f.Close() // prevent memory leak
+	})
+
+	// the block 1 is skipped, the block 3 above the index is read
+	logs, err := f.GetLogs(&LogQuery{
+		FromBlock: 1,
+		ToBlock:   3,
+		Topics:    [][]types.Hash{{topics[0]}, {topics[1]}, {topics[2]}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+
+	for i, log := range logs {
+		assert.Equal(t, argUint64(i+2), log.BlockNumber)
+	}
+
+	// the index is not used for a query matching any log,
+	// so that the range is limited
+	_, err = f.GetLogs(&LogQuery{
+		FromBlock: 1,
+		ToBlock:   4,
+	})
+	assert.ErrorIs(t, err, ErrBlockRangeTooHigh)
+}
+
 func Test_GetLogFilterFromID(t *testing.T) {
 	t.Parallel() // speed it up
 
@@ -358,6 +615,145 @@ func TestRemoveFilterByWebsocket(t *testing.T) {
 	assert.False(t, m.Exists(id))
 }
 
+func TestRemoveFilterByWebsocket_AllSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	mock := &mockWsConn{
+		msgCh: make(chan []byte, 1),
+	}
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	ids := []string{
+		m.NewBlockFilter(mock),
+		m.NewLogFilter(&LogQuery{}, mock),
+		m.NewPendingTxFilter(mock),
+	}
+	other := m.NewPendingTxFilter(&mockWsConn{msgCh: make(chan []byte, 1)})
+
+	m.RemoveFilterByWs(mock)
+
+	// all the subscriptions of the connection are removed
+	for _, id := range ids {
+		assert.False(t, m.Exists(id))
+	}
+
+	assert.True(t, m.Exists(other))
+}
+
+func TestPendingTxFilter(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	id := m.NewPendingTxFilter(nil)
+
+	res, err := m.GetFilterChanges(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", res)
+
+	store.promote(
+		&types.Transaction{Hash: types.StringToHash("1")},
+		&types.Transaction{Hash: types.StringToHash("2")},
+	)
+
+	res, err = m.GetFilterChanges(id)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("[\"%s\",\"%s\"]", types.StringToHash("1"), types.StringToHash("2")), res)
+
+	// the updates are taken
+	res, err = m.GetFilterChanges(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", res)
+}
+
+func TestPendingTxFilter_Overflow(t *testing.T) {
+	t.Parallel()
+
+	filter := &pendingTxFilter{
+		filterBase: newFilterBase(nil),
+		logger:     hclog.NewNullLogger(),
+	}
+
+	txs := make([]*types.Transaction, maxPendingTxUpdates+2)
+	for i := range txs {
+		txs[i] = &types.Transaction{Hash: types.BytesToHash([]byte{byte(i >> 8), byte(i)})}
+	}
+
+	filter.appendTxs(txs)
+
+	// the oldest hashes are dropped
+	assert.Equal(t, 2, filter.dropped)
+
+	hashes := filter.takeTxUpdates()
+	assert.Len(t, hashes, maxPendingTxUpdates)
+	assert.Equal(t, txs[2].Hash, hashes[0])
+	assert.Equal(t, 0, filter.dropped)
+}
+
+func TestPendingTxFilter_Websocket(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	mock := &mockWsConn{
+		msgCh: make(chan []byte, 1),
+	}
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	// filter manager should Close(), but mock one might crash on writing on a closed channel
+	//nolint:errcheck
+	defer recover()
+	defer m.Close()
+
+	go m.Run()
+
+	id := m.NewPendingTxFilter(mock)
+	assert.Equal(t, id, mock.GetFilterID())
+
+	hash := types.StringToHash("1")
+	store.promote(&types.Transaction{Hash: hash})
+
+	select {
+	case msg := <-mock.msgCh:
+		assert.Contains(t, string(msg), fmt.Sprintf("\"result\": \"%s\"", hash))
+		assert.Contains(t, string(msg), id)
+	case <-time.After(5 * time.Second):
+		t.Fatal("promoted transaction not sent")
+	}
+}
+
+func TestFailedWriteFilterDeletion(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	id := m.NewPendingTxFilter(&mockFailingWSConnection{})
+
+	store.promote(&types.Transaction{Hash: types.StringToHash("1")})
+
+	// the stuck connection is dropped
+	assert.NoError(t, m.flushWsFilters())
+	assert.False(t, m.Exists(id))
+}
+
+type mockFailingWSConnection struct {
+	MockClosedWSConnection
+}
+
+func (m *mockFailingWSConnection) WriteMessage(_messageType int, _data []byte) error {
+	return errors.New("i/o timeout")
+}
+
 func TestFilterWebsocket(t *testing.T) {
 	t.Parallel()
 
@@ -1,15 +1,20 @@
 package jsonrpc
 
 import (
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
+	"go.uber.org/atomic"
 )
 
 type serverType int
@@ -33,12 +38,33 @@ func (s serverType) String() string {
 	}
 }
 
+var (
+	errNotReady          = errors.New("node is warming up, not ready to serve requests")
+	errAdminUnauthorized = errors.New("admin namespace unauthorized")
+)
+
 // JSONRPC is an API backend
 type JSONRPC struct {
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
 	metrics    *Metrics
+	ready      *atomic.Bool // readiness gate, requests are rejected until it is set
+	vhosts     []*vhostEndpoint
+	admin      dispatcher // serves the admin namespace, nil if not enabled
+
+	// limiter limits the request rate of the clients of the default endpoint
+	limiter *rateLimiter
+	// methodLimiter limits the request rate of the clients on some methods, on every endpoint
+	methodLimiter methodRateLimiter
+}
+
+// vhostEndpoint is the handling state of a virtual host
+type vhostEndpoint struct {
+	*VirtualHost
+
+	dispatcher dispatcher
+	limiter    *rateLimiter
 }
 
 type dispatcher interface {
@@ -54,6 +80,8 @@ type JSONRPCStore interface {
 	networkStore
 	txPoolStore
 	filterManagerStore
+	dcStore
+	adminStore
 }
 
 type Config struct {
@@ -63,27 +91,81 @@ type Config struct {
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	LogLimit                 uint64 // logs returned by a query, unlimited if 0
 	JSONNamespaces           []Namespace
 	EnableWS                 bool
 	PriceLimit               uint64
 	Metrics                  *Metrics
+	WaitReady                bool // reject requests until SetReady is called
+	VirtualHosts             []*VirtualHost
+	RateLimit                uint64            // requests per second of a client of the default endpoint, unlimited if 0
+	MethodRateLimits         map[string]uint64 // requests per second of a client on a method, on every endpoint
+	DisabledMethods          []string          // methods not served, though their namespace is enabled
+	TLSCertFile              string            // serves https and wss with the certificate if set
+	TLSKeyFile               string            // the private key of the certificate
+	TLSClientCAFile          string            // requires the client certificates signed by the ca if set
+	EnableAdmin              bool              // serves the admin namespace on the admin path
+	AdminToken               string            // the bearer token of the admin clients, loopback clients only if not set
 }
 
 // NewJSONRPC returns the JSONRPC http server
 func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
+	if err := validateVirtualHosts(config.VirtualHosts); err != nil {
+		return nil, err
+	}
+
+	d := newDispatcher(
+		logger,
+		config.Store,
+		config.ChainID,
+		config.BatchLengthLimit,
+		config.BlockRangeLimit,
+		config.PriceLimit,
+		config.JSONNamespaces,
+	)
+
+	d.disableMethods(config.DisabledMethods)
+
+	if d.filterManager != nil {
+		d.filterManager.SetLogLimit(config.LogLimit)
+	}
+
+	limiter, err := newRateLimiter(config.RateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	methodLimiter, err := newMethodRateLimiter(config.MethodRateLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	srv := &JSONRPC{
-		logger: logger.Named("jsonrpc"),
-		config: config,
-		dispatcher: newDispatcher(
-			logger,
-			config.Store,
-			config.ChainID,
-			config.BatchLengthLimit,
-			config.BlockRangeLimit,
-			config.PriceLimit,
-			config.JSONNamespaces,
-		),
-		metrics: NewDummyMetrics(config.Metrics),
+		logger:        logger.Named("jsonrpc"),
+		config:        config,
+		dispatcher:    d,
+		metrics:       NewDummyMetrics(config.Metrics),
+		ready:         atomic.NewBool(!config.WaitReady),
+		limiter:       limiter,
+		methodLimiter: methodLimiter,
+	}
+
+	// the virtual hosts share the endpoints and filters of the server
+	for _, vhost := range config.VirtualHosts {
+		limiter, err := newRateLimiter(vhost.RateLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		srv.vhosts = append(srv.vhosts, &vhostEndpoint{
+			VirtualHost: vhost,
+			dispatcher:  d.view(vhost.Namespaces, vhost.BatchLengthLimit),
+			limiter:     limiter,
+		})
+	}
+
+	if config.EnableAdmin {
+		srv.admin = d.adminView()
 	}
 
 	// start http server
@@ -94,25 +176,77 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 	return srv, nil
 }
 
+// SetReady opens the server to requests
+func (j *JSONRPC) SetReady() {
+	if !j.ready.Swap(true) {
+		j.logger.Info("ready to serve requests")
+	}
+}
+
+// IsReady returns whether the server is open to requests
+func (j *JSONRPC) IsReady() bool {
+	return j.ready.Load()
+}
+
 func (j *JSONRPC) setupHTTP() error {
-	j.logger.Info("http server started", "addr", j.config.Addr.String())
+	tlsConfig, err := newTLSConfig(j.config.TLSCertFile, j.config.TLSKeyFile, j.config.TLSClientCAFile)
+	if err != nil {
+		return err
+	}
+
+	j.logger.Info("http server started", "addr", j.config.Addr.String(), "tls", tlsConfig != nil)
 
 	lis, err := net.Listen("tcp", j.config.Addr.String())
 	if err != nil {
 		return err
 	}
 
+	// the tls is terminated by the server, for both http and web sockets
+	if tlsConfig != nil {
+		lis = tls.NewListener(lis, tlsConfig)
+	}
+
 	mux := http.DefaultServeMux
 
 	// The middleware factory returns a handler, so we need to wrap the handler function properly.
 	jsonRPCHandler := http.HandlerFunc(j.handle)
-	mux.Handle("/", middlewareFactory(j.config)(jsonRPCHandler))
+	mux.Handle("/", middlewareFactory(j.config.AccessControlAllowOrigin)(rateLimitMiddleware(j.limiter)(jsonRPCHandler)))
 
 	// would only enable websocket when set
 	if j.config.EnableWS {
 		mux.HandleFunc("/ws", j.handleWs)
 	}
 
+	for _, vhost := range j.vhosts {
+		vhost := vhost
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			j.serveHTTP(vhost.dispatcher, w, req)
+		})
+		mux.Handle(
+			vhost.pattern(),
+			middlewareFactory(vhost.AccessControlAllowOrigin)(rateLimitMiddleware(vhost.limiter)(handler)),
+		)
+
+		if j.config.EnableWS {
+			mux.HandleFunc(vhost.wsPattern(), func(w http.ResponseWriter, req *http.Request) {
+				j.serveWs(vhost.dispatcher, vhost.limiter, w, req)
+			})
+		}
+
+		j.logger.Info("virtual host enabled", "name", vhost.Name, "pattern", vhost.pattern(),
+			"namespaces", vhost.Namespaces)
+	}
+
+	if j.admin != nil {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			j.serveHTTP(j.admin, w, req)
+		})
+		mux.Handle(adminPath, adminAuthMiddleware(j.config.AdminToken)(handler))
+
+		j.logger.Info("admin namespace enabled", "path", adminPath, "token", j.config.AdminToken != "")
+	}
+
 	srv := http.Server{
 		Handler:           mux,
 		ReadHeaderTimeout: time.Minute,
@@ -127,13 +261,52 @@ func (j *JSONRPC) setupHTTP() error {
 	return nil
 }
 
-// The middlewareFactory builds a middleware which enables CORS using the provided config.
-func middlewareFactory(config *Config) func(http.Handler) http.Handler {
+// adminPath is the path the admin namespace is served at
+const adminPath = "/admin"
+
+// adminAuthMiddleware rejects the requests not bearing the token,
+// or not coming from a loopback address if the token is not set
+func adminAuthMiddleware(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isAdminAuthorized(r, token) {
+				http.Error(w, errAdminUnauthorized.Error(), http.StatusForbidden)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isAdminAuthorized(r *http.Request, token string) bool {
+	if token != "" {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") {
+			return false
+		}
+
+		return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(token)) == 1
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// The middlewareFactory builds a middleware which enables CORS using the provided allowed origins.
+func middlewareFactory(allowedOrigins []string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
 
-			for _, allowedOrigin := range config.AccessControlAllowOrigin {
+			for _, allowedOrigin := range allowedOrigins {
 				if allowedOrigin == "*" {
 					w.Header().Set("Access-Control-Allow-Origin", "*")
 
@@ -161,6 +334,10 @@ var wsUpgrader = websocket.Upgrader{
 	WriteBufferSize: 1024,
 }
 
+// wsWriteTimeout is the time a write to a web socket connection may take, so that
+// a subscriber not reading its messages cannot stall the notifications of the others
+const wsWriteTimeout = 10 * time.Second
+
 // wsWrapper is a wrapping object for the web socket connection and logger
 type wsWrapper struct {
 	sync.Mutex // basic r/w lock
@@ -182,6 +359,11 @@ func (w *wsWrapper) GetFilterID() string {
 func (w *wsWrapper) WriteMessage(messageType int, data []byte) error {
 	w.Lock()
 	defer w.Unlock()
+
+	if err := w.ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+
 	writeErr := w.ws.WriteMessage(messageType, data)
 
 	if writeErr != nil {
@@ -200,6 +382,18 @@ func isSupportedWSType(messageType int) bool {
 }
 
 func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
+	j.serveWs(j.dispatcher, j.limiter, w, req)
+}
+
+// serveWs serves the websocket connection with the dispatcher,
+// the messages exceeding the rate limit of the client are rejected
+func (j *JSONRPC) serveWs(d dispatcher, limiter *rateLimiter, w http.ResponseWriter, req *http.Request) {
+	if !j.IsReady() {
+		http.Error(w, errNotReady.Error(), http.StatusServiceUnavailable)
+
+		return
+	}
+
 	// CORS rule - Allow requests from anywhere
 	wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
 
@@ -242,14 +436,21 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 			}
 
 			// remove websocket connection when closed
-			j.dispatcher.RemoveFilterByWs(wrapConn)
+			d.RemoveFilterByWs(wrapConn)
 
 			break
 		}
 
 		if isSupportedWSType(msgType) {
+			if !limiter.allow(req) || !j.methodLimiter.allow(req, message) {
+				resp, _ := NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError(errRateLimited.Error())).Bytes()
+				_ = wrapConn.WriteMessage(msgType, resp)
+
+				continue
+			}
+
 			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+				resp, handleErr := d.HandleWs(message, wrapConn)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
 
@@ -266,6 +467,11 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 }
 
 func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
+	j.serveHTTP(j.dispatcher, w, req)
+}
+
+// serveHTTP serves the http request with the dispatcher
+func (j *JSONRPC) serveHTTP(d dispatcher, w http.ResponseWriter, req *http.Request) {
 	defer j.metrics.Requests.Add(1.0)
 
 	w.Header().Set("Content-Type", "application/json")
@@ -279,6 +485,15 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if !j.IsReady() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		//nolint
+		w.Write([]byte(errNotReady.Error()))
+		j.metrics.Errors.Add(1.0)
+
+		return
+	}
+
 	if req.Method == "GET" {
 		//nolint
 		w.Write([]byte("Dogechain-Lab Dogechain JSON-RPC"))
@@ -305,13 +520,20 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if !j.methodLimiter.allow(req, data) {
+		http.Error(w, errRateLimited.Error(), http.StatusTooManyRequests)
+		j.metrics.Errors.Add(1.0)
+
+		return
+	}
+
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
 	startT := time.Now()
 
 	// handle request
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := d.Handle(data)
 
 	endT := time.Now()
 	j.metrics.ResponseTime.Observe(endT.Sub(startT).Seconds())
@@ -2,9 +2,14 @@ package jsonrpc
 
 import (
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/dogechain-lab/dogechain/helper/tests"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
 
 	"github.com/hashicorp/go-hclog"
 )
@@ -27,3 +32,43 @@ func TestHTTPServer(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+type mockDispatcher struct{}
+
+func (m *mockDispatcher) RemoveFilterByWs(conn wsConn) {}
+
+func (m *mockDispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+	return reqBody, nil
+}
+
+func (m *mockDispatcher) Handle(reqBody []byte) ([]byte, error) {
+	return reqBody, nil
+}
+
+func TestJSONRPC_ReadinessGate(t *testing.T) {
+	j := &JSONRPC{
+		logger:     hclog.NewNullLogger(),
+		config:     &Config{},
+		dispatcher: &mockDispatcher{},
+		metrics:    NilMetrics(),
+		ready:      atomic.NewBool(false),
+	}
+
+	request := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		j.handle(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}")))
+
+		return rec
+	}
+
+	// rejected while warming up
+	rec := request()
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, errNotReady.Error(), rec.Body.String())
+
+	j.SetReady()
+
+	rec = request()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{}", rec.Body.String())
+}
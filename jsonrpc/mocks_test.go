@@ -42,6 +42,7 @@ type mockHeader struct {
 type mockEvent struct {
 	OldChain []*mockHeader
 	NewChain []*mockHeader
+	Type     blockchain.EventType
 }
 
 type mockStore struct {
@@ -52,6 +53,7 @@ type mockStore struct {
 	receiptsLock sync.Mutex
 	receipts     map[types.Hash][]*types.Receipt
 	accounts     map[types.Address]*state.Account
+	promoteHooks []func(txs []*types.Transaction)
 }
 
 func newMockStore() *mockStore {
@@ -70,6 +72,7 @@ func (m *mockStore) emitEvent(evnt *mockEvent) {
 	bEvnt := &blockchain.Event{
 		NewChain: []*types.Header{},
 		OldChain: []*types.Header{},
+		Type:     evnt.Type,
 	}
 
 	m.receiptsLock.Lock()
@@ -125,6 +128,13 @@ func (m *mockStore) GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 	return nil, false
 }
 
+// mockCommittedSeals is the number of committed seals of every mock header
+const mockCommittedSeals = 3
+
+func (m *mockStore) GetCommittedSeals(header *types.Header) (int, error) {
+	return mockCommittedSeals, nil
+}
+
 func (m *mockStore) GetTxs(inclQueued bool) (
 	map[types.Address][]*types.Transaction,
 	map[types.Address][]*types.Transaction,
@@ -135,3 +145,21 @@ func (m *mockStore) GetTxs(inclQueued bool) (
 func (m *mockStore) GetCapacity() (uint64, uint64) {
 	return 0, 0
 }
+
+func (m *mockStore) GetReplacement(txHash types.Hash) (types.Hash, bool) {
+	return types.ZeroHash, false
+}
+
+func (m *mockStore) IsLocal(addr types.Address) bool {
+	return false
+}
+
+func (m *mockStore) AddPromoteHook(hook func(txs []*types.Transaction)) {
+	m.promoteHooks = append(m.promoteHooks, hook)
+}
+
+func (m *mockStore) promote(txs ...*types.Transaction) {
+	for _, hook := range m.promoteHooks {
+		hook(txs)
+	}
+}
@@ -1,12 +1,22 @@
 package jsonrpc
 
 import (
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/dogechain-lab/dogechain/helper/hex"
 	"github.com/dogechain-lab/dogechain/types"
 )
 
+var (
+	ErrInvalidLogCursor = errors.New("invalid log cursor")
+)
+
+// logCursorLength is the length of an encoded log cursor, a block number and a log index
+const logCursorLength = 16
+
 // LogQuery is a query to filter	 logs
 type LogQuery struct {
 	BlockHash *types.Hash
@@ -16,6 +26,47 @@ type LogQuery struct {
 
 	Addresses []types.Address
 	Topics    [][]types.Hash
+
+	// Cursor is the position of the first log to return, set if the logs are paged
+	Cursor *logCursor
+}
+
+// logCursor is the position of a log in the chain, continuing a paged logs query
+type logCursor struct {
+	BlockNumber uint64
+	LogIndex    uint64
+}
+
+// String encodes the cursor as an opaque hex string
+func (c *logCursor) String() string {
+	buf := make([]byte, logCursorLength)
+	binary.BigEndian.PutUint64(buf[:8], c.BlockNumber)
+	binary.BigEndian.PutUint64(buf[8:], c.LogIndex)
+
+	return hex.EncodeToHex(buf)
+}
+
+// skips returns whether the log is before the cursor
+func (c *logCursor) skips(log *Log) bool {
+	return uint64(log.BlockNumber) < c.BlockNumber ||
+		(uint64(log.BlockNumber) == c.BlockNumber && uint64(log.LogIndex) < c.LogIndex)
+}
+
+// parseLogCursor decodes the cursor, the empty string being the start of the logs
+func parseLogCursor(str string) (*logCursor, error) {
+	if str == "" {
+		return &logCursor{}, nil
+	}
+
+	buf, err := hex.DecodeHex(str)
+	if err != nil || len(buf) != logCursorLength {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLogCursor, str)
+	}
+
+	return &logCursor{
+		BlockNumber: binary.BigEndian.Uint64(buf[:8]),
+		LogIndex:    binary.BigEndian.Uint64(buf[8:]),
+	}, nil
 }
 
 // addTopicSet adds specific topics to the log filter topics
@@ -57,6 +108,37 @@ func (q *LogQuery) addAddress(raw string) error {
 	return nil
 }
 
+// bloomFilters returns the values the logs blooms must contain to match the query, the address
+// then each topic position being one of its alternatives. It is empty if the query matches any log
+func (q *LogQuery) bloomFilters() [][][]byte {
+	var filters [][][]byte
+
+	if len(q.Addresses) > 0 {
+		addrs := make([][]byte, len(q.Addresses))
+		for i, addr := range q.Addresses {
+			addrs[i] = addr.Bytes()
+		}
+
+		filters = append(filters, addrs)
+	}
+
+	for _, alternatives := range q.Topics {
+		if len(alternatives) == 0 {
+			// wildcard
+			continue
+		}
+
+		topics := make([][]byte, len(alternatives))
+		for i, topic := range alternatives {
+			topics[i] = topic.Bytes()
+		}
+
+		filters = append(filters, topics)
+	}
+
+	return filters
+}
+
 func decodeLogQueryFromInterface(i interface{}) (*LogQuery, error) {
 	// once the log filter is decoded as map[string]interface we cannot use unmarshal json
 	raw, err := json.Marshal(i)
@@ -80,6 +162,7 @@ func (q *LogQuery) UnmarshalJSON(data []byte) error {
 		ToBlock   string        `json:"toBlock"`
 		Address   interface{}   `json:"address"`
 		Topics    []interface{} `json:"topics"`
+		Cursor    *string       `json:"cursor"`
 	}
 
 	err := json.Unmarshal(data, &obj)
@@ -90,6 +173,12 @@ func (q *LogQuery) UnmarshalJSON(data []byte) error {
 
 	q.BlockHash = obj.BlockHash
 
+	if obj.Cursor != nil {
+		if q.Cursor, err = parseLogCursor(*obj.Cursor); err != nil {
+			return err
+		}
+	}
+
 	if obj.FromBlock == "" {
 		q.FromBlock = LatestBlockNumber
 	} else {
@@ -174,6 +263,24 @@ func (q *LogQuery) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// matchBlock returns whether the block is the one or within the range of the filter,
+// the block tags (latest, earliest...) leaving the range open
+func (q *LogQuery) matchBlock(header *types.Header) bool {
+	if q.BlockHash != nil {
+		return *q.BlockHash == header.Hash
+	}
+
+	if q.FromBlock >= 0 && header.Number < uint64(q.FromBlock) {
+		return false
+	}
+
+	if q.ToBlock >= 0 && header.Number > uint64(q.ToBlock) {
+		return false
+	}
+
+	return true
+}
+
 // Match returns whether the receipt includes topics for this filter
 func (q *LogQuery) Match(log *types.Log) bool {
 	// check addresses
@@ -139,6 +139,32 @@ func TestFilterDecode(t *testing.T) {
 				ToBlock:   1000,
 			},
 		},
+		{
+			`{
+				"cursor": ""
+			}`,
+			&LogQuery{
+				FromBlock: LatestBlockNumber,
+				ToBlock:   LatestBlockNumber,
+				Cursor:    &logCursor{},
+			},
+		},
+		{
+			`{
+				"cursor": "0x00000000000000020000000000000003"
+			}`,
+			&LogQuery{
+				FromBlock: LatestBlockNumber,
+				ToBlock:   LatestBlockNumber,
+				Cursor:    &logCursor{BlockNumber: 2, LogIndex: 3},
+			},
+		},
+		{
+			`{
+				"cursor": "0x02"
+			}`,
+			nil,
+		},
 	}
 
 	for indx, c := range cases {
@@ -0,0 +1,147 @@
+package jsonrpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCert writes a self signed certificate for localhost and its key,
+// and returns their files and the certificate
+func writeTestCert(t *testing.T, name string) (string, string, tls.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0600))
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	return certFile, keyFile, cert
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile, _ := writeTestCert(t, "server")
+
+	config, err := newTLSConfig("", "", "")
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	_, err = newTLSConfig(certFile, "", "")
+	assert.ErrorIs(t, err, ErrTLSKeyPairIncomplete)
+
+	_, err = newTLSConfig("", "", certFile)
+	assert.ErrorIs(t, err, ErrTLSClientCANoCert)
+
+	_, err = newTLSConfig(certFile, filepath.Join(t.TempDir(), "missing.key"), "")
+	assert.Error(t, err)
+
+	config, err = newTLSConfig(certFile, keyFile, "")
+	assert.NoError(t, err)
+	assert.Len(t, config.Certificates, 1)
+	assert.Equal(t, tls.NoClientCert, config.ClientAuth)
+}
+
+func TestNewTLSConfig_ClientAuth(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile, serverCert := writeTestCert(t, "server")
+	caFile, _, clientCert := writeTestCert(t, "client")
+
+	config, err := newTLSConfig(certFile, keyFile, caFile)
+	require.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+
+	go func() {
+		_ = srv.Serve(tls.NewListener(lis, config))
+	}()
+
+	t.Cleanup(func() {
+		srv.Close()
+	})
+
+	leaf, err := x509.ParseCertificate(serverCert.Certificate[0])
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+
+	get := func(certs ...tls.Certificate) error {
+		client := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:      roots,
+					Certificates: certs,
+					MinVersion:   tls.VersionTLS12,
+				},
+			},
+			Timeout: 5 * time.Second,
+		}
+
+		resp, err := client.Get("https://" + lis.Addr().String())
+		if err != nil {
+			return err
+		}
+
+		return resp.Body.Close()
+	}
+
+	// the client without a certificate is rejected
+	assert.Error(t, get())
+
+	// the client presenting a certificate signed by the ca is served
+	assert.NoError(t, get(clientCert))
+}
@@ -0,0 +1,367 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/state/runtime/evm"
+	"github.com/dogechain-lab/dogechain/state/tracer/calltracer"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+const (
+	traceTypeCall   = "call"
+	traceTypeCreate = "create"
+)
+
+// Trace is the OpenEthereum compatible trace endpoint, returning the flat call traces
+// of the transactions by replaying their blocks
+type Trace struct {
+	store           ethStore
+	blockRangeLimit uint64
+}
+
+// traceAction is the call or the contract creation of a trace
+type traceAction struct {
+	CallType string         `json:"callType,omitempty"`
+	From     types.Address  `json:"from"`
+	To       *types.Address `json:"to,omitempty"`
+	Gas      argUint64      `json:"gas"`
+	Input    *string        `json:"input,omitempty"`
+	Init     *string        `json:"init,omitempty"`
+	Value    string         `json:"value"`
+}
+
+// traceResult is the outcome of a successful call or contract creation
+type traceResult struct {
+	GasUsed argUint64      `json:"gasUsed"`
+	Output  *string        `json:"output,omitempty"`
+	Address *types.Address `json:"address,omitempty"`
+	Code    *string        `json:"code,omitempty"`
+}
+
+// flatTrace is a call of a transaction, located in the tree of its calls by its trace address
+type flatTrace struct {
+	Action              *traceAction `json:"action"`
+	BlockHash           types.Hash   `json:"blockHash"`
+	BlockNumber         argUint64    `json:"blockNumber"`
+	Error               string       `json:"error,omitempty"`
+	Result              *traceResult `json:"result"`
+	Subtraces           int          `json:"subtraces"`
+	TraceAddress        []int        `json:"traceAddress"`
+	TransactionHash     types.Hash   `json:"transactionHash"`
+	TransactionPosition argUint64    `json:"transactionPosition"`
+	Type                string       `json:"type"`
+}
+
+// traceFilter selects the traces of a range of blocks by their sender and recipient
+type traceFilter struct {
+	FromBlock   *BlockNumber    `json:"fromBlock"`
+	ToBlock     *BlockNumber    `json:"toBlock"`
+	FromAddress []types.Address `json:"fromAddress"`
+	ToAddress   []types.Address `json:"toAddress"`
+	After       *argUint64      `json:"after"`
+	Count       *argUint64      `json:"count"`
+}
+
+// Block returns the traces of all the transactions of the block
+func (t *Trace) Block(number BlockNumber) (interface{}, error) {
+	num, err := t.resolveBlockNumber(number)
+	if err != nil {
+		return nil, err
+	}
+
+	if num == 0 {
+		return nil, ErrGenesisNotTracable
+	}
+
+	block, ok := t.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	return t.traceBlock(block)
+}
+
+// Transaction returns the traces of the transaction, or nil if it is not sealed in a block
+func (t *Trace) Transaction(hash types.Hash) (interface{}, error) {
+	blockHash, ok := t.store.ReadTxLookup(hash)
+	if !ok {
+		return nil, nil
+	}
+
+	block, ok := t.store.GetBlockByHash(blockHash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrGenesisNotTracable
+	}
+
+	for idx, tx := range block.Transactions {
+		if tx.Hash != hash {
+			continue
+		}
+
+		txn, err := t.store.StateAtTransaction(block, idx)
+		if err != nil {
+			return nil, err
+		}
+
+		return traceTxCalls(txn, block, idx)
+	}
+
+	return nil, ErrTransactionNotFoundInBlock
+}
+
+// Filter returns the traces of the blocks in the range whose sender is one of the from addresses
+// and recipient one of the to addresses, any of them if a list is empty.
+// The matching traces are paged by skipping the first after ones and returning up to count of them
+func (t *Trace) Filter(filter traceFilter) (interface{}, error) {
+	from, to := LatestBlockNumber, LatestBlockNumber
+
+	if filter.FromBlock != nil {
+		from = *filter.FromBlock
+	}
+
+	if filter.ToBlock != nil {
+		to = *filter.ToBlock
+	}
+
+	fromNum, err := t.resolveBlockNumber(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toNum, err := t.resolveBlockNumber(to)
+	if err != nil {
+		return nil, err
+	}
+
+	// the genesis has no transaction to trace
+	if fromNum == 0 {
+		fromNum = 1
+	}
+
+	if toNum < fromNum {
+		return nil, ErrIncorrectBlockRange
+	}
+
+	if t.blockRangeLimit > 0 && toNum-fromNum > t.blockRangeLimit {
+		return nil, ErrBlockRangeTooHigh
+	}
+
+	var (
+		fromAddrs = toAddressSet(filter.FromAddress)
+		toAddrs   = toAddressSet(filter.ToAddress)
+		skip      uint64
+		traces    = make([]*flatTrace, 0)
+	)
+
+	if filter.After != nil {
+		skip = uint64(*filter.After)
+	}
+
+	for i := fromNum; i <= toNum; i++ {
+		block, ok := t.store.GetBlockByNumber(i, true)
+		if !ok {
+			break
+		}
+
+		blockTraces, err := t.traceBlock(block)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, trace := range blockTraces {
+			if !trace.match(fromAddrs, toAddrs) {
+				continue
+			}
+
+			if skip > 0 {
+				skip--
+
+				continue
+			}
+
+			traces = append(traces, trace)
+
+			if filter.Count != nil && uint64(len(traces)) >= uint64(*filter.Count) {
+				return traces, nil
+			}
+		}
+	}
+
+	return traces, nil
+}
+
+func (t *Trace) resolveBlockNumber(number BlockNumber) (uint64, error) {
+	switch number {
+	case LatestBlockNumber:
+		return t.store.Header().Number, nil
+	case EarliestBlockNumber:
+		return 0, nil
+	case PendingBlockNumber:
+		return 0, ErrPendingBlockNumber
+	default:
+		if number < 0 {
+			return 0, fmt.Errorf("invalid argument 0: block number larger than int64")
+		}
+
+		return uint64(number), nil
+	}
+}
+
+// traceBlock replays the transactions of the block one after the other
+// on the state of its parent, and returns their traces in order
+func (t *Trace) traceBlock(block *types.Block) ([]*flatTrace, error) {
+	traces := make([]*flatTrace, 0)
+	if len(block.Transactions) == 0 {
+		return traces, nil
+	}
+
+	txn, err := t.store.StateAtTransaction(block, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range block.Transactions {
+		txTraces, err := traceTxCalls(txn, block, idx)
+		if err != nil {
+			return nil, err
+		}
+
+		traces = append(traces, txTraces...)
+	}
+
+	return traces, nil
+}
+
+// traceTxCalls applies the transaction at the index of the block on the transition,
+// recording its calls, and returns them flattened
+func traceTxCalls(txn *state.Transition, block *types.Block, idx int) ([]*flatTrace, error) {
+	tx := block.Transactions[idx]
+
+	tracer := calltracer.NewCallTracer()
+	txn.SetEVMLogger(tracer)
+
+	if _, err := txn.Apply(tx); err != nil {
+		return nil, fmt.Errorf("tracing failed: %w", err)
+	}
+
+	traces := make([]*flatTrace, 0)
+
+	if root := tracer.Result(); root != nil {
+		traces = flattenCallFrame(traces, root, []int{}, func(trace *flatTrace) {
+			trace.BlockHash = block.Hash()
+			trace.BlockNumber = argUint64(block.Number())
+			trace.TransactionHash = tx.Hash
+			trace.TransactionPosition = argUint64(idx)
+		})
+	}
+
+	return traces, nil
+}
+
+// flattenCallFrame appends the trace of the call and the ones of its inner calls, depth first
+func flattenCallFrame(
+	traces []*flatTrace,
+	frame *calltracer.CallFrame,
+	traceAddress []int,
+	locate func(*flatTrace),
+) []*flatTrace {
+	trace := &flatTrace{
+		Action: &traceAction{
+			From:  frame.From,
+			Gas:   argUint64(frame.Gas),
+			Value: frame.Value,
+		},
+		Error:        frame.Error,
+		Subtraces:    len(frame.Calls),
+		TraceAddress: traceAddress,
+	}
+	locate(trace)
+
+	if trace.Action.Value == "" {
+		trace.Action.Value = "0x0"
+	}
+
+	output := frame.Output
+	if output == "" {
+		output = "0x"
+	}
+
+	to, input := frame.To, frame.Input
+
+	if frame.Type == evm.OpCode(evm.CREATE).String() || frame.Type == evm.OpCode(evm.CREATE2).String() {
+		trace.Type = traceTypeCreate
+		trace.Action.Init = &input
+
+		if frame.Error == "" {
+			trace.Result = &traceResult{
+				GasUsed: argUint64(frame.GasUsed),
+				Address: &to,
+				Code:    &output,
+			}
+		}
+	} else {
+		trace.Type = traceTypeCall
+		trace.Action.CallType = strings.ToLower(frame.Type)
+		trace.Action.To = &to
+		trace.Action.Input = &input
+
+		if frame.Error == "" {
+			trace.Result = &traceResult{
+				GasUsed: argUint64(frame.GasUsed),
+				Output:  &output,
+			}
+		}
+	}
+
+	traces = append(traces, trace)
+
+	for i, call := range frame.Calls {
+		childAddress := append(append(make([]int, 0, len(traceAddress)+1), traceAddress...), i)
+		traces = flattenCallFrame(traces, call, childAddress, locate)
+	}
+
+	return traces
+}
+
+// match returns whether the sender of the trace is in the from addresses and its recipient,
+// or created contract, in the to addresses. An empty set matches any address
+func (f *flatTrace) match(from, to map[types.Address]struct{}) bool {
+	if len(from) > 0 {
+		if _, ok := from[f.Action.From]; !ok {
+			return false
+		}
+	}
+
+	if len(to) > 0 {
+		recipient := f.Action.To
+		if f.Result != nil && f.Result.Address != nil {
+			recipient = f.Result.Address
+		}
+
+		if recipient == nil {
+			return false
+		}
+
+		if _, ok := to[*recipient]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func toAddressSet(addrs []types.Address) map[types.Address]struct{} {
+	set := make(map[types.Address]struct{}, len(addrs))
+	for _, addr := range addrs {
+		set[addr] = struct{}{}
+	}
+
+	return set
+}
@@ -0,0 +1,169 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/state/tracer/calltracer"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTrace(t *testing.T, blockRangeLimit uint64) (*Trace, []*types.Transaction) {
+	t.Helper()
+
+	debug, txs := newTestTraceDebug(t)
+
+	return &Trace{store: debug.store, blockRangeLimit: blockRangeLimit}, txs
+}
+
+func TestTrace_Transaction(t *testing.T) {
+	t.Parallel()
+
+	trace, txs := newTestTrace(t, 0)
+
+	res, err := trace.Transaction(txs[1].Hash)
+	assert.NoError(t, err)
+
+	traces, ok := res.([]*flatTrace)
+	assert.True(t, ok)
+	assert.Len(t, traces, 1)
+
+	assert.Equal(t, traceTypeCall, traces[0].Type)
+	assert.Equal(t, "call", traces[0].Action.CallType)
+	assert.Equal(t, traceSender, traces[0].Action.From)
+	assert.Equal(t, traceContract, *traces[0].Action.To)
+	assert.Equal(t, txs[1].Hash, traces[0].TransactionHash)
+	assert.Equal(t, argUint64(1), traces[0].TransactionPosition)
+	assert.Equal(t, argUint64(1), traces[0].BlockNumber)
+	assert.Empty(t, traces[0].TraceAddress)
+	assert.NotNil(t, traces[0].Result)
+
+	// unknown transaction
+	res, err = trace.Transaction(types.StringToHash("3"))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestTrace_Block(t *testing.T) {
+	t.Parallel()
+
+	trace, txs := newTestTrace(t, 0)
+
+	res, err := trace.Block(LatestBlockNumber)
+	assert.NoError(t, err)
+
+	traces, ok := res.([]*flatTrace)
+	assert.True(t, ok)
+	assert.Len(t, traces, len(txs))
+
+	for i, tr := range traces {
+		assert.Equal(t, txs[i].Hash, tr.TransactionHash)
+		assert.Equal(t, argUint64(i), tr.TransactionPosition)
+	}
+
+	_, err = trace.Block(EarliestBlockNumber)
+	assert.ErrorIs(t, err, ErrGenesisNotTracable)
+
+	_, err = trace.Block(BlockNumber(2))
+	assert.ErrorIs(t, err, ErrBlockNotFound)
+}
+
+func TestTrace_Filter(t *testing.T) {
+	t.Parallel()
+
+	earliest, latest := EarliestBlockNumber, LatestBlockNumber
+
+	t.Run("filters by the addresses", func(t *testing.T) {
+		trace, txs := newTestTrace(t, 0)
+
+		res, err := trace.Filter(traceFilter{
+			FromBlock:   &earliest,
+			ToBlock:     &latest,
+			FromAddress: []types.Address{traceSender},
+			ToAddress:   []types.Address{traceContract},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, res, len(txs))
+
+		res, err = trace.Filter(traceFilter{
+			ToAddress: []types.Address{traceSender},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, res, 0)
+	})
+
+	t.Run("pages the traces", func(t *testing.T) {
+		trace, txs := newTestTrace(t, 0)
+
+		after, count := argUint64(1), argUint64(1)
+
+		res, err := trace.Filter(traceFilter{
+			FromBlock: &earliest,
+			After:     &after,
+			Count:     &count,
+		})
+		assert.NoError(t, err)
+
+		traces, ok := res.([]*flatTrace)
+		assert.True(t, ok)
+		assert.Len(t, traces, 1)
+		assert.Equal(t, txs[1].Hash, traces[0].TransactionHash)
+	})
+
+	t.Run("bounds the block range", func(t *testing.T) {
+		trace, _ := newTestTrace(t, 1)
+
+		from, to := BlockNumber(1), BlockNumber(3)
+
+		_, err := trace.Filter(traceFilter{FromBlock: &from, ToBlock: &to})
+		assert.ErrorIs(t, err, ErrBlockRangeTooHigh)
+
+		_, err = trace.Filter(traceFilter{FromBlock: &to, ToBlock: &from})
+		assert.ErrorIs(t, err, ErrIncorrectBlockRange)
+	})
+}
+
+func TestTrace_FlattenCallFrame(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		addr3 = types.StringToAddress("3")
+	)
+
+	root := &calltracer.CallFrame{
+		Type: "CALL", From: addr1, To: addr2, Gas: 100, GasUsed: 50, Input: "0x01",
+		Calls: []*calltracer.CallFrame{
+			{
+				Type: "CREATE", From: addr2, To: addr3, Gas: 40, GasUsed: 20, Input: "0x60", Output: "0x00",
+				Calls: []*calltracer.CallFrame{
+					{Type: "STATICCALL", From: addr3, To: addr1, Gas: 10, Input: "0x", Error: "execution reverted"},
+				},
+			},
+			{Type: "DELEGATECALL", From: addr2, To: addr1, Gas: 5, Input: "0x"},
+		},
+	}
+
+	traces := flattenCallFrame(nil, root, []int{}, func(*flatTrace) {})
+	assert.Len(t, traces, 4)
+
+	assert.Equal(t, []int{}, traces[0].TraceAddress)
+	assert.Equal(t, 2, traces[0].Subtraces)
+	assert.Equal(t, "0x0", traces[0].Action.Value)
+	assert.Equal(t, "0x", *traces[0].Result.Output)
+
+	assert.Equal(t, traceTypeCreate, traces[1].Type)
+	assert.Equal(t, []int{0}, traces[1].TraceAddress)
+	assert.Equal(t, "0x60", *traces[1].Action.Init)
+	assert.Equal(t, addr3, *traces[1].Result.Address)
+	assert.Equal(t, "0x00", *traces[1].Result.Code)
+
+	assert.Equal(t, "staticcall", traces[2].Action.CallType)
+	assert.Equal(t, []int{0, 0}, traces[2].TraceAddress)
+	assert.Equal(t, "execution reverted", traces[2].Error)
+	assert.Nil(t, traces[2].Result)
+
+	assert.Equal(t, "delegatecall", traces[3].Action.CallType)
+	assert.Equal(t, []int{1}, traces[3].TraceAddress)
+}
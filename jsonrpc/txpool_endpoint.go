@@ -14,16 +14,40 @@ type txPoolStore interface {
 
 	// GetCapacity returns the current and max capacity of the pool in slots
 	GetCapacity() (uint64, uint64)
+
+	// GetReplacement returns the hash of the transaction which replaced the given one, if known
+	GetReplacement(txHash types.Hash) (types.Hash, bool)
+
+	// IsLocal returns whether the account is local, having submitted transactions through the node
+	IsLocal(addr types.Address) bool
 }
 
+const (
+	// txClassLocal is the class of the transactions of the accounts local to the node
+	txClassLocal = "local"
+	// txClassRemote is the class of the transactions gossiped from other nodes
+	txClassRemote = "remote"
+)
+
 // TxPool is the txpool jsonrpc endpoint
 type TxPool struct {
 	store txPoolStore
 }
 
+// poolTransaction is the json representation of a transaction of the pool, along with its class
+type poolTransaction struct {
+	*transaction
+	Class string `json:"class"`
+}
+
 type ContentResponse struct {
-	Pending map[types.Address]map[uint64]*txpoolTransaction `json:"pending"`
-	Queued  map[types.Address]map[uint64]*txpoolTransaction `json:"queued"`
+	Pending map[types.Address]map[uint64]*poolTransaction `json:"pending"`
+	Queued  map[types.Address]map[uint64]*poolTransaction `json:"queued"`
+}
+
+type ContentFromResponse struct {
+	Pending map[uint64]*poolTransaction `json:"pending"`
+	Queued  map[uint64]*poolTransaction `json:"queued"`
 }
 
 type InspectResponse struct {
@@ -34,38 +58,42 @@ type InspectResponse struct {
 }
 
 type StatusResponse struct {
-	Pending uint64 `json:"pending"`
-	Queued  uint64 `json:"queued"`
+	Pending argUint64 `json:"pending"`
+	Queued  argUint64 `json:"queued"`
+}
+
+// txClass returns the class of the transactions of the account
+func (t *TxPool) txClass(addr types.Address) string {
+	if t.store.IsLocal(addr) {
+		return txClassLocal
+	}
+
+	return txClassRemote
 }
 
-type txpoolTransaction struct {
-	Nonce       argUint64      `json:"nonce"`
-	GasPrice    argBig         `json:"gasPrice"`
-	Gas         argUint64      `json:"gas"`
-	To          *types.Address `json:"to"`
-	Value       argBig         `json:"value"`
-	Input       argBytes       `json:"input"`
-	Hash        types.Hash     `json:"hash"`
-	From        types.Address  `json:"from"`
-	BlockHash   types.Hash     `json:"blockHash"`
-	BlockNumber interface{}    `json:"blockNumber"`
-	TxIndex     interface{}    `json:"transactionIndex"`
+// toNonceTransactions indexes the json representations of the transactions of the account by nonce
+func (t *TxPool) toNonceTransactions(addr types.Address, txs []*types.Transaction) map[uint64]*poolTransaction {
+	class := t.txClass(addr)
+	res := make(map[uint64]*poolTransaction, len(txs))
+
+	for _, tx := range txs {
+		res[tx.Nonce] = &poolTransaction{
+			transaction: toPendingTransaction(tx),
+			Class:       class,
+		}
+	}
+
+	return res
 }
 
-func toTxPoolTransaction(t *types.Transaction) *txpoolTransaction {
-	return &txpoolTransaction{
-		Nonce:       argUint64(t.Nonce),
-		GasPrice:    argBig(*t.GasPrice),
-		Gas:         argUint64(t.Gas),
-		To:          t.To,
-		Value:       argBig(*t.Value),
-		Input:       t.Input,
-		Hash:        t.Hash,
-		From:        t.From,
-		BlockHash:   types.ZeroHash,
-		BlockNumber: nil,
-		TxIndex:     nil,
+// toInspectSummary returns the summary of the transaction in the txpool_inspect format
+func toInspectSummary(tx *types.Transaction) string {
+	to := "contract creation"
+	if tx.To != nil {
+		to = tx.To.String()
 	}
+
+	return fmt.Sprintf("%s: %d wei + %d gas × %d wei", to, tx.Value, tx.Gas, tx.GetGasFeeCap())
 }
 
 // Create response for txpool_content request.
@@ -74,29 +102,15 @@ func (t *TxPool) Content() (interface{}, error) {
 	pendingTxs, queuedTxs := t.store.GetTxs(true)
 
 	// collect pending
-	pendingRPCTxs := make(map[types.Address]map[uint64]*txpoolTransaction)
+	pendingRPCTxs := make(map[types.Address]map[uint64]*poolTransaction, len(pendingTxs))
 	for addr, txs := range pendingTxs {
-		pendingRPCTxs[addr] = make(map[uint64]*txpoolTransaction, len(txs))
-
-		for _, tx := range txs {
-			nonce := tx.Nonce
-			rpcTx := toTxPoolTransaction(tx)
-
-			pendingRPCTxs[addr][nonce] = rpcTx
-		}
+		pendingRPCTxs[addr] = t.toNonceTransactions(addr, txs)
 	}
 
 	// collect enqueued
-	queuedRPCTxs := make(map[types.Address]map[uint64]*txpoolTransaction)
+	queuedRPCTxs := make(map[types.Address]map[uint64]*poolTransaction, len(queuedTxs))
 	for addr, txs := range queuedTxs {
-		queuedRPCTxs[addr] = make(map[uint64]*txpoolTransaction, len(txs))
-
-		for _, tx := range txs {
-			nonce := tx.Nonce
-			rpcTx := toTxPoolTransaction(tx)
-
-			queuedRPCTxs[addr][nonce] = rpcTx
-		}
+		queuedRPCTxs[addr] = t.toNonceTransactions(addr, txs)
 	}
 
 	resp := ContentResponse{
@@ -107,6 +121,19 @@ func (t *TxPool) Content() (interface{}, error) {
 	return resp, nil
 }
 
+// Create response for txpool_contentFrom request, which is the txpool_content of a single account.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_contentfrom.
+func (t *TxPool) ContentFrom(addr types.Address) (interface{}, error) {
+	pendingTxs, queuedTxs := t.store.GetTxs(true)
+
+	resp := ContentFromResponse{
+		Pending: t.toNonceTransactions(addr, pendingTxs[addr]),
+		Queued:  t.toNonceTransactions(addr, queuedTxs[addr]),
+	}
+
+	return resp, nil
+}
+
 // Create response for txpool_inspect request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect.
 func (t *TxPool) Inspect() (interface{}, error) {
@@ -119,9 +146,7 @@ func (t *TxPool) Inspect() (interface{}, error) {
 
 		for _, tx := range txs {
 			nonceStr := strconv.FormatUint(tx.Nonce, 10)
-			pendingRPCTxs[addr.String()][nonceStr] = fmt.Sprintf(
-				"%d wei + %d gas x %d wei", tx.Value, tx.Gas, tx.GasPrice,
-			)
+			pendingRPCTxs[addr.String()][nonceStr] = toInspectSummary(tx)
 		}
 	}
 
@@ -132,9 +157,7 @@ func (t *TxPool) Inspect() (interface{}, error) {
 
 		for _, tx := range txs {
 			nonceStr := strconv.FormatUint(tx.Nonce, 10)
-			queuedRPCTxs[addr.String()][nonceStr] = fmt.Sprintf(
-				"%d wei + %d gas x %d wei", tx.Value, tx.Gas, tx.GasPrice,
-			)
+			queuedRPCTxs[addr.String()][nonceStr] = toInspectSummary(tx)
 		}
 	}
 
@@ -169,9 +192,20 @@ func (t *TxPool) Status() (interface{}, error) {
 	}
 
 	resp := StatusResponse{
-		Pending: uint64(pendingCount),
-		Queued:  uint64(queuedCount),
+		Pending: argUint64(pendingCount),
+		Queued:  argUint64(queuedCount),
 	}
 
 	return resp, nil
 }
+
+// Create response for txpool_replacement request, which is the hash of the
+// transaction of the same nonce which replaced the given pending transaction, if any.
+func (t *TxPool) Replacement(hash types.Hash) (interface{}, error) {
+	replacement, ok := t.store.GetReplacement(hash)
+	if !ok {
+		return nil, nil
+	}
+
+	return replacement, nil
+}
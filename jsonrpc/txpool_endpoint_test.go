@@ -1,6 +1,8 @@
 package jsonrpc
 
 import (
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"strconv"
 	"testing"
@@ -48,8 +50,9 @@ func TestContentEndpoint(t *testing.T) {
 		assert.Equal(t, testTx.From, txData.From)
 		assert.Equal(t, *testTx.Value, big.Int(txData.Value))
 		assert.Equal(t, testTx.Input, []byte(txData.Input))
-		assert.Equal(t, nil, txData.BlockNumber)
-		assert.Equal(t, nil, txData.TxIndex)
+		assert.Nil(t, txData.BlockHash)
+		assert.Nil(t, txData.BlockNumber)
+		assert.Nil(t, txData.TxIndex)
 	})
 
 	//nolint:dupl
@@ -76,8 +79,9 @@ func TestContentEndpoint(t *testing.T) {
 		assert.Equal(t, testTx.From, txData.From)
 		assert.Equal(t, *testTx.Value, big.Int(txData.Value))
 		assert.Equal(t, testTx.Input, []byte(txData.Input))
-		assert.Equal(t, nil, txData.BlockNumber)
-		assert.Equal(t, nil, txData.TxIndex)
+		assert.Nil(t, txData.BlockHash)
+		assert.Nil(t, txData.BlockNumber)
+		assert.Nil(t, txData.TxIndex)
 	})
 
 	t.Run("returns correct ContentResponse data for multiple transactions", func(t *testing.T) {
@@ -107,6 +111,67 @@ func TestContentEndpoint(t *testing.T) {
 	})
 }
 
+func TestContentFromEndpoint(t *testing.T) {
+	mockStore := newMockTxPoolStore()
+	address1 := types.Address{0x1}
+	testTx1 := newTestTransaction(2, address1)
+	testTx2 := newTestTransaction(11, address1)
+	address2 := types.Address{0x2}
+	testTx3 := newTestTransaction(7, address2)
+	mockStore.pending[address1] = []*types.Transaction{testTx1}
+	mockStore.pending[address2] = []*types.Transaction{testTx3}
+	mockStore.queued[address1] = []*types.Transaction{testTx2}
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, _ := txPoolEndpoint.ContentFrom(address1)
+	//nolint:forcetypeassert
+	response := result.(ContentFromResponse)
+
+	assert.Equal(t, 1, len(response.Pending))
+	assert.Equal(t, testTx1.Hash, response.Pending[testTx1.Nonce].Hash)
+	assert.Equal(t, 1, len(response.Queued))
+	assert.Equal(t, testTx2.Hash, response.Queued[testTx2.Nonce].Hash)
+
+	// unknown account
+	result, _ = txPoolEndpoint.ContentFrom(types.Address{0x3})
+	//nolint:forcetypeassert
+	response = result.(ContentFromResponse)
+
+	assert.Equal(t, 0, len(response.Pending))
+	assert.Equal(t, 0, len(response.Queued))
+}
+
+func TestContentEndpoint_TransactionClass(t *testing.T) {
+	mockStore := newMockTxPoolStore()
+	localAddr := types.Address{0x1}
+	remoteAddr := types.Address{0x2}
+	localTx := newTestTransaction(2, localAddr)
+	remoteTx := newTestTransaction(3, remoteAddr)
+	mockStore.pending[localAddr] = []*types.Transaction{localTx}
+	mockStore.queued[remoteAddr] = []*types.Transaction{remoteTx}
+	mockStore.locals[localAddr] = true
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, _ := txPoolEndpoint.Content()
+	//nolint:forcetypeassert
+	response := result.(ContentResponse)
+
+	assert.Equal(t, txClassLocal, response.Pending[localAddr][localTx.Nonce].Class)
+	assert.Equal(t, txClassRemote, response.Queued[remoteAddr][remoteTx.Nonce].Class)
+
+	result, _ = txPoolEndpoint.ContentFrom(localAddr)
+	//nolint:forcetypeassert
+	fromResponse := result.(ContentFromResponse)
+
+	assert.Equal(t, txClassLocal, fromResponse.Pending[localTx.Nonce].Class)
+
+	// the class is serialized along the fields of the transaction
+	data, err := json.Marshal(fromResponse.Pending[localTx.Nonce])
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"class":"local"`)
+	assert.Contains(t, string(data), `"nonce":"0x2"`)
+}
+
 func TestInspectEndpoint(t *testing.T) {
 	t.Run("returns empty InspectResponse if tx pool has no transactions", func(t *testing.T) {
 		mockStore := newMockTxPoolStore()
@@ -141,7 +206,28 @@ func TestInspectEndpoint(t *testing.T) {
 		assert.Equal(t, uint64(1), response.CurrentCapacity)
 		transactionInfo := response.Queued[testTx.From.String()]
 		assert.NotNil(t, transactionInfo)
-		assert.NotNil(t, transactionInfo[strconv.FormatUint(testTx.Nonce, 10)])
+		assert.Equal(t,
+			fmt.Sprintf("%s: 200 wei + 200 gas × 1 wei", addr1),
+			transactionInfo[strconv.FormatUint(testTx.Nonce, 10)],
+		)
+	})
+
+	t.Run("returns contract creations", func(t *testing.T) {
+		mockStore := newMockTxPoolStore()
+		address1 := types.Address{0x1}
+		testTx := newTestTransaction(2, address1)
+		testTx.To = nil
+		mockStore.pending[address1] = []*types.Transaction{testTx}
+		txPoolEndpoint := &TxPool{mockStore}
+
+		result, _ := txPoolEndpoint.Inspect()
+		//nolint:forcetypeassert
+		response := result.(InspectResponse)
+
+		assert.Equal(t,
+			"contract creation: 200 wei + 200 gas × 1 wei",
+			response.Pending[testTx.From.String()][strconv.FormatUint(testTx.Nonce, 10)],
+		)
 	})
 
 	t.Run("returns correct data for pending transactions", func(t *testing.T) {
@@ -176,8 +262,8 @@ func TestStatusEndpoint(t *testing.T) {
 		//nolint:forcetypeassert
 		response := result.(StatusResponse)
 
-		assert.Equal(t, uint64(0), response.Pending)
-		assert.Equal(t, uint64(0), response.Queued)
+		assert.Equal(t, argUint64(0), response.Pending)
+		assert.Equal(t, argUint64(0), response.Queued)
 	})
 
 	t.Run("returns correct count of pending/queued transactions", func(t *testing.T) {
@@ -199,23 +285,43 @@ func TestStatusEndpoint(t *testing.T) {
 		//nolint:forcetypeassert
 		response := result.(StatusResponse)
 
-		assert.Equal(t, uint64(3), response.Pending)
-		assert.Equal(t, uint64(2), response.Queued)
+		assert.Equal(t, argUint64(3), response.Pending)
+		assert.Equal(t, argUint64(2), response.Queued)
 	})
 }
 
+func TestReplacementEndpoint(t *testing.T) {
+	mockStore := newMockTxPoolStore()
+	replaced, replacement := types.StringToHash("1"), types.StringToHash("2")
+	mockStore.replacements[replaced] = replacement
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, err := txPoolEndpoint.Replacement(replaced)
+	assert.NoError(t, err)
+	assert.Equal(t, replacement, result)
+
+	// unknown replacement
+	result, err = txPoolEndpoint.Replacement(replacement)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
 type mockTxPoolStore struct {
 	pending       map[types.Address][]*types.Transaction
 	queued        map[types.Address][]*types.Transaction
 	capacity      uint64
 	maxSlots      uint64
 	includeQueued bool
+	replacements  map[types.Hash]types.Hash
+	locals        map[types.Address]bool
 }
 
 func newMockTxPoolStore() *mockTxPoolStore {
 	return &mockTxPoolStore{
-		pending: make(map[types.Address][]*types.Transaction),
-		queued:  make(map[types.Address][]*types.Transaction),
+		pending:      make(map[types.Address][]*types.Transaction),
+		queued:       make(map[types.Address][]*types.Transaction),
+		replacements: make(map[types.Hash]types.Hash),
+		locals:       make(map[types.Address]bool),
 	}
 }
 
@@ -230,6 +336,16 @@ func (s *mockTxPoolStore) GetCapacity() (uint64, uint64) {
 	return s.capacity, s.maxSlots
 }
 
+func (s *mockTxPoolStore) GetReplacement(txHash types.Hash) (types.Hash, bool) {
+	replacement, ok := s.replacements[txHash]
+
+	return replacement, ok
+}
+
+func (s *mockTxPoolStore) IsLocal(addr types.Address) bool {
+	return s.locals[addr]
+}
+
 func newTestTransaction(nonce uint64, from types.Address) *types.Transaction {
 	txn := &types.Transaction{
 		Nonce:    nonce,
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/state"
 	"github.com/dogechain-lab/dogechain/types"
 )
 
@@ -15,8 +16,13 @@ type transactionOrHash interface {
 }
 
 type transaction struct {
+	Type        argUint64      `json:"type"`
 	Nonce       argUint64      `json:"nonce"`
 	GasPrice    argBig         `json:"gasPrice"`
+	GasFeeCap   *argBig        `json:"maxFeePerGas,omitempty"`
+	GasTipCap   *argBig        `json:"maxPriorityFeePerGas,omitempty"`
+	ChainID     *argBig        `json:"chainId,omitempty"`
+	AccessList  []accessTuple  `json:"accessList,omitempty"`
 	Gas         argUint64      `json:"gas"`
 	To          *types.Address `json:"to"`
 	Value       argBig         `json:"value"`
@@ -42,19 +48,53 @@ func (h transactionHash) MarshalText() ([]byte, error) {
 	return []byte(types.Hash(h).String()), nil
 }
 
+// accessTuple is the json representation of an access list entry
+type accessTuple struct {
+	Address     types.Address `json:"address"`
+	StorageKeys []types.Hash  `json:"storageKeys"`
+}
+
+func toAccessList(al types.AccessList) []accessTuple {
+	res := make([]accessTuple, len(al))
+
+	for i, tuple := range al {
+		res[i] = accessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]types.Hash{}, tuple.StorageKeys...),
+		}
+	}
+
+	return res
+}
+
+func fromAccessList(al []accessTuple) types.AccessList {
+	res := make(types.AccessList, len(al))
+
+	for i, tuple := range al {
+		res[i] = types.AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]types.Hash{}, tuple.StorageKeys...),
+		}
+	}
+
+	return res
+}
+
 func toPendingTransaction(t *types.Transaction) *transaction {
-	return toTransaction(t, nil, nil, nil)
+	return toTransaction(t, nil, nil)
 }
 
+// toTransaction converts the transaction to its json representation, header is the
+// header of the block including the transaction, nil if the transaction is pending
 func toTransaction(
 	t *types.Transaction,
-	blockNumber *argUint64,
-	blockHash *types.Hash,
+	header *types.Header,
 	txIndex *int,
 ) *transaction {
 	res := &transaction{
+		Type:     argUint64(t.Type),
 		Nonce:    argUint64(t.Nonce),
-		GasPrice: argBig(*t.GasPrice),
+		GasPrice: argBig(*t.GetGasFeeCap()),
 		Gas:      argUint64(t.Gas),
 		To:       t.To,
 		Value:    argBig(*t.Value),
@@ -66,12 +106,22 @@ func toTransaction(
 		From:     t.From,
 	}
 
-	if blockNumber != nil {
-		res.BlockNumber = blockNumber
+	// the typed transactions carry their chain id and access list
+	if t.Type != types.LegacyTx {
+		res.ChainID = argBigPtr(t.ChainID)
+		res.AccessList = toAccessList(t.AccessList)
 	}
 
-	if blockHash != nil {
-		res.BlockHash = blockHash
+	if t.IsDynamicFee() {
+		res.GasFeeCap = argBigPtr(t.GasFeeCap)
+		res.GasTipCap = argBigPtr(t.GasTipCap)
+	}
+
+	if header != nil {
+		// the price paid by a sealed transaction depends on the base fee of its block
+		res.GasPrice = argBig(*t.EffectiveGasPrice(header.BaseFee))
+		res.BlockNumber = argUintPtr(header.Number)
+		res.BlockHash = argHashPtr(header.Hash)
 	}
 
 	if txIndex != nil {
@@ -102,6 +152,7 @@ type block struct {
 	Hash            types.Hash          `json:"hash"`
 	Transactions    []transactionOrHash `json:"transactions"`
 	Uncles          []types.Hash        `json:"uncles"`
+	BaseFee         *argUint64          `json:"baseFeePerGas,omitempty"`
 }
 
 func toBlock(b *types.Block, fullTx bool) *block {
@@ -135,8 +186,7 @@ func toBlock(b *types.Block, fullTx bool) *block {
 				res.Transactions,
 				toTransaction(
 					txn,
-					argUintPtr(b.Number()),
-					argHashPtr(b.Hash()),
+					b.Header,
 					&idx,
 				),
 			)
@@ -152,6 +202,10 @@ func toBlock(b *types.Block, fullTx bool) *block {
 		res.Uncles = append(res.Uncles, uncle.Hash)
 	}
 
+	if h.BaseFee != 0 {
+		res.BaseFee = argUintPtr(h.BaseFee)
+	}
+
 	return res
 }
 
@@ -171,6 +225,43 @@ type receipt struct {
 	ToAddr            *types.Address `json:"to"`
 }
 
+// toReceipt converts the receipt of the transaction at the index of the block to its json representation,
+// logIndex being the index in the block of the first log of the transaction
+func toReceipt(b *types.Block, raw *types.Receipt, txIndex int, logIndex int) *receipt {
+	txn := b.Transactions[txIndex]
+
+	logs := make([]*Log, len(raw.Logs))
+	for i, elem := range raw.Logs {
+		logs[i] = &Log{
+			Address:     elem.Address,
+			Topics:      elem.Topics,
+			Data:        argBytes(elem.Data),
+			BlockHash:   b.Hash(),
+			BlockNumber: argUint64(b.Number()),
+			TxHash:      txn.Hash,
+			TxIndex:     argUint64(txIndex),
+			LogIndex:    argUint64(logIndex + i),
+			Removed:     false,
+		}
+	}
+
+	return &receipt{
+		Root:              raw.Root,
+		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
+		LogsBloom:         raw.LogsBloom,
+		Status:            argUint64(*raw.Status),
+		TxHash:            txn.Hash,
+		TxIndex:           argUint64(txIndex),
+		BlockHash:         b.Hash(),
+		BlockNumber:       argUint64(b.Number()),
+		GasUsed:           argUint64(raw.GasUsed),
+		ContractAddress:   raw.ContractAddress,
+		FromAddr:          txn.From,
+		ToAddr:            txn.To,
+		Logs:              logs,
+	}
+}
+
 type Log struct {
 	Address     types.Address `json:"address"`
 	Topics      []types.Hash  `json:"topics"`
@@ -183,6 +274,12 @@ type Log struct {
 	Removed     bool          `json:"removed"`
 }
 
+// logPage is a page of the logs of a paged query, with the cursor of the next page if any
+type logPage struct {
+	Logs   []*Log  `json:"logs"`
+	Cursor *string `json:"cursor"`
+}
+
 type argBig big.Int
 
 func argBigPtr(b *big.Int) *argBig {
@@ -302,6 +399,62 @@ type txnArgs struct {
 	Data     *argBytes
 	Input    *argBytes
 	Nonce    *argUint64
+
+	// typed transaction fields
+	Type                 *argUint64
+	MaxFeePerGas         *argBytes
+	MaxPriorityFeePerGas *argBytes
+	AccessList           *[]accessTuple
+}
+
+// overrideAccount is the account fields replaced before an eth_call or eth_estimateGas
+type overrideAccount struct {
+	Nonce     *argUint64                 `json:"nonce"`
+	Code      *argBytes                  `json:"code"`
+	Balance   *argBig                    `json:"balance"`
+	State     *map[types.Hash]types.Hash `json:"state"`
+	StateDiff *map[types.Hash]types.Hash `json:"stateDiff"`
+}
+
+// stateOverride is the set of the accounts replaced before an eth_call or eth_estimateGas, by address
+type stateOverride map[types.Address]overrideAccount
+
+// toOverrides converts the overrides to the state ones, nil if there are none
+func (o *stateOverride) toOverrides() state.Overrides {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	overrides := make(state.Overrides, len(*o))
+
+	for addr, account := range *o {
+		override := &state.AccountOverride{}
+
+		if account.Nonce != nil {
+			nonce := uint64(*account.Nonce)
+			override.Nonce = &nonce
+		}
+
+		if account.Code != nil {
+			override.Code = *account.Code
+		}
+
+		if account.Balance != nil {
+			override.Balance = new(big.Int).Set((*big.Int)(account.Balance))
+		}
+
+		if account.State != nil {
+			override.State = *account.State
+		}
+
+		if account.StateDiff != nil {
+			override.StateDiff = *account.StateDiff
+		}
+
+		overrides[addr] = override
+	}
+
+	return overrides
 }
 
 type progression struct {
@@ -309,4 +462,49 @@ type progression struct {
 	StartingBlock string `json:"startingBlock"`
 	CurrentBlock  string `json:"currentBlock"`
 	HighestBlock  string `json:"highestBlock"`
+	PulledHeaders string `json:"pulledHeaders"`
+	PulledBodies  string `json:"pulledBodies"`
+	PeerCount     string `json:"peerCount"`
+}
+
+// balanceChange is a single balance change of an account within a block
+type balanceChange struct {
+	BlockNumber argUint64  `json:"blockNumber"`
+	BlockHash   types.Hash `json:"blockHash"`
+	Previous    argBig     `json:"previous"`
+	Current     argBig     `json:"current"`
+}
+
+// balanceChanges is a page of balance changes, Next is the block number
+// the following page starts from, nil if the range is fully covered
+type balanceChanges struct {
+	Changes []*balanceChange `json:"changes"`
+	Next    *argUint64       `json:"next"`
+}
+
+// accountProof is the merkle proof of an account and of some of its storage slots (EIP-1186)
+type accountProof struct {
+	Address      types.Address   `json:"address"`
+	AccountProof []argBytes      `json:"accountProof"`
+	Balance      argBig          `json:"balance"`
+	CodeHash     types.Hash      `json:"codeHash"`
+	Nonce        argUint64       `json:"nonce"`
+	StorageHash  types.Hash      `json:"storageHash"`
+	StorageProof []*storageProof `json:"storageProof"`
+}
+
+// storageProof is the merkle proof of a storage slot against the storage hash of its account
+type storageProof struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+func toArgBytesList(list [][]byte) []argBytes {
+	res := make([]argBytes, len(list))
+	for i, b := range list {
+		res[i] = argBytes(b)
+	}
+
+	return res
 }
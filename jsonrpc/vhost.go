@@ -0,0 +1,218 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// number of the latest clients whose request rate is tracked by a virtual host
+	rateLimitClients = 4096
+)
+
+var (
+	ErrVirtualHostPath       = errors.New("virtual host path should start with /")
+	ErrVirtualHostNamespaces = errors.New("virtual host without namespaces")
+	ErrDuplicateVirtualHost  = errors.New("duplicate virtual host")
+)
+
+var errRateLimited = errors.New("request rate limit exceeded")
+
+// VirtualHost is a logical endpoint of the server, matched by its path and host,
+// serving its own namespaces with its own limits and CORS policy.
+// It lets one node back both public and internal consumers,
+// e.g. /public with eth only and /internal with debug and txpool
+type VirtualHost struct {
+	// Name identifies the virtual host in the logs
+	Name string `json:"name"`
+
+	// Path is the path the virtual host is served at, its websocket at <path>/ws
+	Path string `json:"path"`
+
+	// Host restricts the virtual host to the requests of the host name, any host if empty
+	Host string `json:"host"`
+
+	// Namespaces are the namespaces enabled on the virtual host
+	Namespaces []Namespace `json:"namespaces"`
+
+	// AccessControlAllowOrigin is the CORS allowed origins of the virtual host
+	AccessControlAllowOrigin []string `json:"accessControlAllowOrigin"`
+
+	// BatchLengthLimit is the max length of the batch requests, unlimited if 0
+	BatchLengthLimit uint64 `json:"batchLengthLimit"`
+
+	// RateLimit is the number of requests per second allowed to a client, unlimited if 0
+	RateLimit uint64 `json:"rateLimit"`
+}
+
+// pattern returns the pattern of the virtual host in the server mux
+func (v *VirtualHost) pattern() string {
+	return v.Host + v.Path
+}
+
+// wsPattern returns the pattern of the virtual host websocket in the server mux
+func (v *VirtualHost) wsPattern() string {
+	return v.Host + path.Join(v.Path, "ws")
+}
+
+// validateVirtualHosts checks the virtual hosts are served on distinct patterns,
+// apart from the default endpoint of the server
+func validateVirtualHosts(vhosts []*VirtualHost) error {
+	patterns := map[string]struct{}{
+		"/":   {},
+		"/ws": {},
+	}
+
+	for _, vhost := range vhosts {
+		if !strings.HasPrefix(vhost.Path, "/") {
+			return fmt.Errorf("%w: %s", ErrVirtualHostPath, vhost.Name)
+		}
+
+		if len(vhost.Namespaces) == 0 {
+			return fmt.Errorf("%w: %s", ErrVirtualHostNamespaces, vhost.Name)
+		}
+
+		for _, pattern := range []string{vhost.pattern(), vhost.wsPattern()} {
+			if _, ok := patterns[pattern]; ok {
+				return fmt.Errorf("%w: %s", ErrDuplicateVirtualHost, pattern)
+			}
+
+			patterns[pattern] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// rateLimiter limits the request rate of every client, identified by its IP
+type rateLimiter struct {
+	limit   rate.Limit
+	burst   int
+	clients *lru.Cache
+}
+
+// newRateLimiter creates a limiter of the requests per second, nil if unlimited
+func newRateLimiter(requestsPerSecond uint64) (*rateLimiter, error) {
+	if requestsPerSecond == 0 {
+		return nil, nil
+	}
+
+	clients, err := lru.New(rateLimitClients)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rateLimiter{
+		limit:   rate.Limit(requestsPerSecond),
+		burst:   int(requestsPerSecond),
+		clients: clients,
+	}, nil
+}
+
+// allow returns whether the client request is within the limit, always true if unlimited
+func (l *rateLimiter) allow(req *http.Request) bool {
+	if l == nil {
+		return true
+	}
+
+	client, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		client = req.RemoteAddr
+	}
+
+	limiter, ok := l.clients.Get(client)
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.clients.Add(client, limiter)
+	}
+
+	//nolint:forcetypeassert
+	return limiter.(*rate.Limiter).Allow()
+}
+
+// rateLimitMiddleware rejects the requests of the clients exceeding the limit
+func rateLimitMiddleware(limiter *rateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !limiter.allow(r) {
+				http.Error(w, errRateLimited.Error(), http.StatusTooManyRequests)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// methodRateLimiter limits the request rate of every client on some methods, by method
+type methodRateLimiter map[string]*rateLimiter
+
+// newMethodRateLimiter creates a limiter of the requests per second of the methods, unlimited if 0
+func newMethodRateLimiter(limits map[string]uint64) (methodRateLimiter, error) {
+	l := make(methodRateLimiter, len(limits))
+
+	for method, requestsPerSecond := range limits {
+		limiter, err := newRateLimiter(requestsPerSecond)
+		if err != nil {
+			return nil, err
+		}
+
+		if limiter != nil {
+			l[method] = limiter
+		}
+	}
+
+	return l, nil
+}
+
+// allow returns whether the methods called by the request body, a request or a batch,
+// are all within the limits of the client. Every call of a batch counts
+func (l methodRateLimiter) allow(req *http.Request, body []byte) bool {
+	if len(l) == 0 {
+		return true
+	}
+
+	for _, method := range requestMethods(body) {
+		// the methods without a limit have a nil limiter
+		if !l[method].allow(req) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// requestMethods returns the methods called by the request body, a request or a batch.
+// The malformed requests are left to the dispatcher
+func requestMethods(body []byte) []string {
+	type methodCall struct {
+		Method string `json:"method"`
+	}
+
+	var calls []methodCall
+
+	if isBatchRequest(body) {
+		_ = json.Unmarshal(body, &calls)
+	} else {
+		var call methodCall
+		if err := json.Unmarshal(body, &call); err == nil {
+			calls = append(calls, call)
+		}
+	}
+
+	methods := make([]string, 0, len(calls))
+	for _, call := range calls {
+		methods = append(methods, call.Method)
+	}
+
+	return methods
+}
@@ -0,0 +1,201 @@
+package jsonrpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+)
+
+func TestValidateVirtualHosts(t *testing.T) {
+	tests := []struct {
+		name   string
+		vhosts []*VirtualHost
+		err    error
+	}{
+		{
+			"relative path",
+			[]*VirtualHost{{Name: "public", Path: "public", Namespaces: []Namespace{NamespaceEth}}},
+			ErrVirtualHostPath,
+		},
+		{
+			"no namespace",
+			[]*VirtualHost{{Name: "public", Path: "/public"}},
+			ErrVirtualHostNamespaces,
+		},
+		{
+			"default endpoint",
+			[]*VirtualHost{{Name: "public", Path: "/", Namespaces: []Namespace{NamespaceEth}}},
+			ErrDuplicateVirtualHost,
+		},
+		{
+			"same path",
+			[]*VirtualHost{
+				{Name: "public", Path: "/public", Namespaces: []Namespace{NamespaceEth}},
+				{Name: "internal", Path: "/public", Namespaces: []Namespace{NamespaceDebug}},
+			},
+			ErrDuplicateVirtualHost,
+		},
+		{
+			"path and host based",
+			[]*VirtualHost{
+				{Name: "public", Path: "/public", Namespaces: []Namespace{NamespaceEth}},
+				{Name: "internal", Path: "/internal", Namespaces: []Namespace{NamespaceDebug, NamespaceTxpool}},
+				{Name: "host", Host: "rpc.example.com", Path: "/", Namespaces: []Namespace{NamespaceEth}},
+			},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVirtualHosts(tt.vhosts)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestDispatcher_View(t *testing.T) {
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 20, 1000, 0, []Namespace{
+		NamespaceAll,
+	})
+
+	request := []byte(`{
+		"method": "web3_clientVersion",
+		"params": []
+	}`)
+
+	// the namespace enabled on the view is served
+	resp, err := dispatcher.view([]Namespace{NamespaceWeb3}, 0).Handle(request)
+	assert.NoError(t, err)
+
+	var res string
+
+	assert.NoError(t, expectJSONResult(resp, &res))
+
+	// the others are not, though enabled on the server
+	view := dispatcher.view([]Namespace{NamespaceDebug}, 0)
+
+	resp, err = view.Handle(request)
+	assert.NoError(t, err)
+	assert.Error(t, expectJSONResult(resp, &res))
+
+	// nor the subscriptions without the eth namespace
+	resp, err = view.HandleWs([]byte(`{
+		"method": "eth_subscribe",
+		"params": ["newHeads"]
+	}`), &mockWsConn{})
+	assert.NoError(t, err)
+	assert.Error(t, expectJSONResult(resp, &res))
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limiter, err := newRateLimiter(2)
+	assert.NoError(t, err)
+
+	handler := rateLimitMiddleware(limiter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	request := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodPost, "/public", nil)
+		req.RemoteAddr = remoteAddr
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:1000"))
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:1001"))
+	assert.Equal(t, http.StatusTooManyRequests, request("10.0.0.1:1002"))
+
+	// the limit is per client
+	assert.Equal(t, http.StatusOK, request("10.0.0.2:1000"))
+
+	// unlimited
+	limiter, err = newRateLimiter(0)
+	assert.NoError(t, err)
+	assert.Nil(t, limiter)
+	assert.True(t, limiter.allow(httptest.NewRequest(http.MethodPost, "/", nil)))
+}
+
+func TestMethodRateLimiter(t *testing.T) {
+	limiter, err := newMethodRateLimiter(map[string]uint64{
+		"eth_getLogs": 2,
+		"eth_call":    0,
+	})
+	assert.NoError(t, err)
+
+	j := &JSONRPC{
+		logger:        hclog.NewNullLogger(),
+		config:        &Config{},
+		dispatcher:    &mockDispatcher{},
+		metrics:       NilMetrics(),
+		ready:         atomic.NewBool(true),
+		methodLimiter: limiter,
+	}
+
+	request := func(remoteAddr string, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.RemoteAddr = remoteAddr
+
+		rec := httptest.NewRecorder()
+		j.handle(rec, req)
+
+		return rec.Code
+	}
+
+	getLogs := `{"method": "eth_getLogs", "params": []}`
+
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:1000", getLogs))
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:1000", getLogs))
+	assert.Equal(t, http.StatusTooManyRequests, request("10.0.0.1:1000", getLogs))
+
+	// the other methods are not limited
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, request("10.0.0.1:1000", `{"method": "eth_call", "params": []}`))
+	}
+
+	// every call of a batch counts
+	assert.Equal(t, http.StatusTooManyRequests, request("10.0.0.2:1000", "["+getLogs+","+getLogs+","+getLogs+"]"))
+
+	// the limit is per client
+	assert.Equal(t, http.StatusOK, request("10.0.0.3:1000", getLogs))
+}
+
+func TestDispatcher_DisabledMethods(t *testing.T) {
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 20, 1000, 0, []Namespace{
+		NamespaceAll,
+	})
+	dispatcher.disableMethods([]string{"web3_clientVersion", "eth_subscribe"})
+
+	var res string
+
+	// the disabled method is not found, on the views as well
+	for _, d := range []*Dispatcher{dispatcher, dispatcher.view([]Namespace{NamespaceWeb3}, 0)} {
+		resp, err := d.Handle([]byte(`{"method": "web3_clientVersion", "params": []}`))
+		assert.NoError(t, err)
+		assert.Error(t, expectJSONResult(resp, &res))
+
+		// the other methods of the namespace are served
+		resp, err = d.Handle([]byte(`{"method": "web3_sha3", "params": ["0x01"]}`))
+		assert.NoError(t, err)
+		assert.NoError(t, expectJSONResult(resp, &res))
+	}
+
+	// the subscriptions can be disabled too
+	resp, err := dispatcher.HandleWs([]byte(`{
+		"method": "eth_subscribe",
+		"params": ["newHeads"]
+	}`), &mockWsConn{})
+	assert.NoError(t, err)
+	assert.Error(t, expectJSONResult(resp, &res))
+}
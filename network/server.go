@@ -87,6 +87,8 @@ type Server struct {
 
 	temporaryDials cmap.ConcurrentMap // map of temporary connections; peerID -> bool
 
+	staticPeers cmap.ConcurrentMap // map of the peers kept connected; peerID -> *peer.AddrInfo
+
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
 }
 
@@ -159,6 +161,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			config.MaxOutboundPeers,
 		),
 		temporaryDials: cmap.NewConcurrentMap(),
+		staticPeers:    cmap.NewConcurrentMap(),
 	}
 
 	// start gossip protocol
@@ -337,6 +340,9 @@ func (s *Server) keepAliveMinimumPeerConnections() {
 			return
 		}
 
+		// the static peers are dialed again whatever the peer count
+		s.dialStaticPeers()
+
 		if s.numPeers() < MinimumPeerConnections {
 			if s.config.NoDiscover || !s.bootnodes.hasBootnodes() {
 				// dial unconnected peer
@@ -558,14 +564,8 @@ var (
 
 // JoinPeer attempts to add a new peer to the networking server
 func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
-	// Parse the raw string to a MultiAddr format
-	parsedMultiaddr, err := multiaddr.NewMultiaddr(rawPeerMultiaddr)
-	if err != nil {
-		return err
-	}
-
 	// Extract the peer info from the Multiaddr
-	peerInfo, err := peer.AddrInfoFromP2pAddr(parsedMultiaddr)
+	peerInfo, err := parseP2PAddr(rawPeerMultiaddr)
 	if err != nil {
 		return err
 	}
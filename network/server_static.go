@@ -0,0 +1,97 @@
+package network
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/network/common"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+var (
+	ErrStaticPeerNotFound = errors.New("static peer not found")
+)
+
+// AddStaticPeer dials the peer of the multiaddr, and dials it again whenever it is disconnected
+func (s *Server) AddStaticPeer(rawPeerMultiaddr string) error {
+	peerInfo, err := parseP2PAddr(rawPeerMultiaddr)
+	if err != nil {
+		return err
+	}
+
+	s.staticPeers.Store(peerInfo.ID, peerInfo)
+	s.joinPeer(peerInfo)
+
+	return nil
+}
+
+// RemoveStaticPeer stops dialing the peer of the multiaddr or id, and disconnects from it
+func (s *Server) RemoveStaticPeer(rawPeer string) error {
+	peerID, err := peer.Decode(rawPeer)
+	if err != nil {
+		peerInfo, parseErr := parseP2PAddr(rawPeer)
+		if parseErr != nil {
+			return parseErr
+		}
+
+		peerID = peerInfo.ID
+	}
+
+	if _, ok := s.staticPeers.LoadAndDelete(peerID); !ok {
+		return ErrStaticPeerNotFound
+	}
+
+	s.DisconnectFromPeer(peerID, "static peer removed")
+
+	return nil
+}
+
+// IsStaticPeer returns whether the peer is kept connected as a static peer
+func (s *Server) IsStaticPeer(peerID peer.ID) bool {
+	_, ok := s.staticPeers.Load(peerID)
+
+	return ok
+}
+
+// IsInboundPeer returns whether the peer connected to the node
+func (s *Server) IsInboundPeer(peerID peer.ID) bool {
+	s.peersLock.Lock()
+	defer s.peersLock.Unlock()
+
+	info, ok := s.peers[peerID]
+
+	return ok && info.connDirections[network.DirInbound]
+}
+
+// PeerLatency returns the average round trip time to the peer, 0 if unknown
+func (s *Server) PeerLatency(peerID peer.ID) time.Duration {
+	return s.host.Peerstore().LatencyEWMA(peerID)
+}
+
+// ListenAddrs returns the addresses the node listens on
+func (s *Server) ListenAddrs() []multiaddr.Multiaddr {
+	return s.host.Network().ListenAddresses()
+}
+
+// dialStaticPeers dials the static peers not connected
+func (s *Server) dialStaticPeers() {
+	s.staticPeers.Range(func(_, value interface{}) bool {
+		peerInfo, ok := value.(*peer.AddrInfo)
+		if ok && !s.IsConnected(peerInfo.ID) {
+			s.addToDialQueue(peerInfo, common.PriorityRequestedDial)
+		}
+
+		return true
+	})
+}
+
+func parseP2PAddr(rawPeerMultiaddr string) (*peer.AddrInfo, error) {
+	parsedMultiaddr, err := multiaddr.NewMultiaddr(rawPeerMultiaddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return peer.AddrInfoFromP2pAddr(parsedMultiaddr)
+}
@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
 	"github.com/dogechain-lab/dogechain/types"
 )
 
@@ -15,12 +16,20 @@ type blockchainShim interface {
 
 	GetTD(hash types.Hash) (*big.Int, bool)
 	GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
+	GetCompressedReceiptsByHash(types.Hash) ([]byte, error)
 	GetBodyByHash(types.Hash) (*types.Body, bool)
 	GetHeaderByHash(types.Hash) (*types.Header, bool)
 	GetHeaderByNumber(n uint64) (*types.Header, bool)
+	IsBadBlock(hash types.Hash) bool
 
 	// advance chain methods
 	WriteBlock(block *types.Block) error
+	WriteBlockWithReceipts(block *types.Block, receipts []*types.Receipt) error
 	VerifyFinalizedBlock(block *types.Block) error
+	VerifyTrustedBlock(block *types.Block) error
 	CalculateGasLimit(number uint64) (uint64, error)
+
+	// bulk sync progression methods
+	ReadSyncProgress() (*storage.SyncProgress, bool)
+	WriteSyncProgress(progress *storage.SyncProgress, blocks []*types.Block) error
 }
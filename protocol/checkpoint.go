@@ -0,0 +1,311 @@
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/protocol/proto"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// checkpointLinkWindow is the number of blocks whose link to the checkpoint is proven at once
+var checkpointLinkWindow uint64 = 4096
+
+var (
+	ErrInvalidCheckpoint    = errors.New("invalid checkpoint, expected <number>:<hash>")
+	ErrCheckpointMismatch   = errors.New("chain doesn't contain the checkpoint")
+	errCheckpointNotReached = errors.New("peer behind the checkpoint")
+	errCheckpointLinkBroken = errors.New("headers not linked to the checkpoint")
+)
+
+// Checkpoint is a block trusted by the operator. The syncer refuses the chains not
+// containing it, and doesn't verify the seals of the blocks proven to be its ancestors
+type Checkpoint struct {
+	Number uint64
+	Hash   types.Hash
+}
+
+// ParseCheckpoint parses a checkpoint of the form <number>:<hash>
+func ParseCheckpoint(raw string) (*Checkpoint, error) {
+	parts := strings.Split(raw, ":")
+	if len(parts) != 2 {
+		return nil, ErrInvalidCheckpoint
+	}
+
+	number, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %v", ErrInvalidCheckpoint, err)
+	}
+
+	rawHash, err := hex.DecodeHex(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w, %v", ErrInvalidCheckpoint, err)
+	}
+
+	hash := types.BytesToHash(rawHash)
+
+	if number == 0 || len(rawHash) != types.HashLength || hash == types.ZeroHash {
+		return nil, ErrInvalidCheckpoint
+	}
+
+	return &Checkpoint{
+		Number: number,
+		Hash:   hash,
+	}, nil
+}
+
+func (c *Checkpoint) String() string {
+	return fmt.Sprintf("%d:%s", c.Number, c.Hash)
+}
+
+// Check returns an error if the header is the one of the number of the checkpoint,
+// with another hash
+func (c *Checkpoint) Check(header *types.Header) error {
+	if header.Number != c.Number || header.Hash == c.Hash {
+		return nil
+	}
+
+	return fmt.Errorf("%w %s, found %s", ErrCheckpointMismatch, c, header.Hash)
+}
+
+// SetCheckpoint makes the syncer refuse the peers whose chain doesn't contain the
+// checkpoint, and skip the verification of the seals of the blocks proven to be its ancestors
+func (s *Syncer) SetCheckpoint(checkpoint *Checkpoint) {
+	s.checkpoint = checkpoint
+}
+
+// checkPeerCheckpoint checks that the chain of the peer contains the checkpoint, the
+// peer is banned otherwise. A peer behind the checkpoint can't prove it, it is refused
+// until the local chain is past the checkpoint
+func (s *Syncer) checkPeerCheckpoint(p *SyncPeer) error {
+	if s.checkpoint == nil || atomic.LoadUint32(&p.checkpointChecked) == 1 {
+		return nil
+	}
+
+	if p.Number() < s.checkpoint.Number {
+		if s.blockchain.Header().Number < s.checkpoint.Number {
+			return errCheckpointNotReached
+		}
+
+		return nil
+	}
+
+	header, err := getHeader(p.client, &s.checkpoint.Number, nil)
+	if err != nil {
+		s.scores.failed(p.peer, err)
+
+		return err
+	}
+
+	if header == nil {
+		s.scores.failed(p.peer, errNilHeaderResponse)
+
+		return errNilHeaderResponse
+	}
+
+	if err := s.checkpoint.Check(header); err != nil {
+		s.scores.ban(p.peer)
+
+		return err
+	}
+
+	atomic.StoreUint32(&p.checkpointChecked, 1)
+
+	return nil
+}
+
+// proveCheckpointLink proves the link to the checkpoint of the blocks above the local head,
+// by the headers of the peer whose chain contains the checkpoint. The proof is kept across
+// the syncs, only its peer being replaced, as long as it covers the local head
+func (s *Syncer) proveCheckpointLink(p *SyncPeer) error {
+	head := s.blockchain.Header()
+	if s.checkpoint == nil || head.Number >= s.checkpoint.Number || p.Number() < s.checkpoint.Number {
+		return nil
+	}
+
+	if s.checkpointLink != nil && s.checkpointLink.covers(head.Number+1) {
+		s.checkpointLink.setClient(p.client)
+
+		return nil
+	}
+
+	link, err := newCheckpointLink(p.client, s.checkpoint, head.Number+1)
+	if err != nil {
+		s.scores.failed(p.peer, err)
+
+		return err
+	}
+
+	s.checkpointLink = link
+
+	return nil
+}
+
+// verifyBlock verifies the block before its write. The blocks proven to be ancestors
+// of the checkpoint are trusted by their link to it, their seals are not verified.
+// The seals of the other ones are, until the link is proven
+func (s *Syncer) verifyBlock(block *types.Block) error {
+	if s.checkpoint == nil || block.Number() > s.checkpoint.Number {
+		return s.blockchain.VerifyFinalizedBlock(block)
+	}
+
+	if err := s.checkpoint.Check(block.Header); err != nil {
+		return err
+	}
+
+	if s.checkpointLink == nil {
+		return s.blockchain.VerifyFinalizedBlock(block)
+	}
+
+	linked, err := s.checkpointLink.proven(block.Header)
+	if err != nil {
+		s.logger.Warn("failed to prove the link to the checkpoint", "number", block.Number(), "err", err)
+	}
+
+	if !linked {
+		return s.blockchain.VerifyFinalizedBlock(block)
+	}
+
+	return s.blockchain.VerifyTrustedBlock(block)
+}
+
+// checkpointLink proves that the blocks up to the checkpoint are its ancestors, by the
+// parent hashes of the headers of a peer, walked backward from the checkpoint. Only the
+// hashes bounding the windows of blocks are kept, the hashes of the window of a verified
+// block being fetched and linked again from its upper bound
+type checkpointLink struct {
+	sync.Mutex
+
+	clt        proto.V1Client
+	checkpoint *Checkpoint
+	from       uint64 // the lowest block proven
+
+	anchors     map[uint64]types.Hash // the proven hashes of the upper bounds of the windows
+	window      map[uint64]types.Hash // the proven hashes of the current window
+	windowUpper uint64                // the upper bound of the current window
+}
+
+// newCheckpointLink walks the headers of the peer from the checkpoint down to the number
+func newCheckpointLink(clt proto.V1Client, checkpoint *Checkpoint, from uint64) (*checkpointLink, error) {
+	l := &checkpointLink{
+		clt:        clt,
+		checkpoint: checkpoint,
+		from:       from,
+		anchors:    map[uint64]types.Hash{},
+		window:     map[uint64]types.Hash{},
+	}
+
+	l.windowUpper = l.upperBound(from)
+
+	err := walkHeadersBackward(clt, checkpoint.Number, checkpoint.Hash, from, func(header *types.Header) {
+		if header.Number == checkpoint.Number || header.Number%checkpointLinkWindow == 0 {
+			l.anchors[header.Number] = header.Hash
+		}
+
+		// the window of the first blocks synced is kept
+		if l.upperBound(header.Number) == l.windowUpper {
+			l.window[header.Number] = header.Hash
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// upperBound returns the upper bound of the window of the block
+func (l *checkpointLink) upperBound(number uint64) uint64 {
+	upper := ((number-1)/checkpointLinkWindow + 1) * checkpointLinkWindow
+	if upper > l.checkpoint.Number {
+		return l.checkpoint.Number
+	}
+
+	return upper
+}
+
+// covers returns whether the link of the block is proven
+func (l *checkpointLink) covers(number uint64) bool {
+	return number >= l.from && number <= l.checkpoint.Number
+}
+
+// setClient replaces the peer the windows are fetched from
+func (l *checkpointLink) setClient(clt proto.V1Client) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.clt = clt
+}
+
+// proven returns whether the header is the ancestor of the checkpoint of its number
+func (l *checkpointLink) proven(header *types.Header) (bool, error) {
+	if !l.covers(header.Number) {
+		return false, nil
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if upper := l.upperBound(header.Number); upper != l.windowUpper {
+		window := map[uint64]types.Hash{}
+		lower := ((upper-1)/checkpointLinkWindow)*checkpointLinkWindow + 1
+
+		if lower < l.from {
+			lower = l.from
+		}
+
+		err := walkHeadersBackward(l.clt, upper, l.anchors[upper], lower, func(header *types.Header) {
+			window[header.Number] = header.Hash
+		})
+		if err != nil {
+			return false, err
+		}
+
+		l.window = window
+		l.windowUpper = upper
+	}
+
+	return l.window[header.Number] == header.Hash, nil
+}
+
+// walkHeadersBackward fetches the headers of the peer from the number down to the lower
+// one, the first one being of the hash and the next ones linked by their parent hashes
+func walkHeadersBackward(
+	clt proto.V1Client,
+	number uint64,
+	hash types.Hash,
+	lower uint64,
+	visit func(header *types.Header),
+) error {
+	for {
+		amount := number - lower + 1
+		if amount > maxSkeletonHeadersAmount {
+			amount = maxSkeletonHeadersAmount
+		}
+
+		headers, err := getHeadersBackward(clt, number, int64(amount))
+		if err != nil {
+			return err
+		}
+
+		if len(headers) == 0 || headers[0].Hash != hash {
+			return fmt.Errorf("%w at %d", errCheckpointLinkBroken, number)
+		}
+
+		for _, header := range headers {
+			visit(header)
+		}
+
+		last := headers[len(headers)-1]
+		if last.Number <= lower {
+			return nil
+		}
+
+		number, hash = last.Number-1, last.ParentHash
+	}
+}
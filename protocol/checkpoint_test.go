@@ -0,0 +1,216 @@
+package protocol
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// verifyCountingBlockchain counts the verifications of the blocks by their kind
+type verifyCountingBlockchain struct {
+	*mockBlockchain
+
+	finalized int
+	trusted   int
+}
+
+func (b *verifyCountingBlockchain) VerifyFinalizedBlock(block *types.Block) error {
+	b.finalized++
+
+	return nil
+}
+
+func (b *verifyCountingBlockchain) VerifyTrustedBlock(block *types.Block) error {
+	b.trusted++
+
+	return nil
+}
+
+func TestParseCheckpoint(t *testing.T) {
+	hash := types.StringToHash("1")
+
+	checkpoint, err := ParseCheckpoint(fmt.Sprintf("100:%s", hash))
+	assert.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Number: 100, Hash: hash}, checkpoint)
+	assert.Equal(t, fmt.Sprintf("100:%s", hash), checkpoint.String())
+
+	for _, raw := range []string{
+		"",
+		"100",
+		fmt.Sprintf("0x64:%s", hash),
+		"100:0x1",
+		fmt.Sprintf("0:%s", hash),
+		fmt.Sprintf("100:%s", types.ZeroHash),
+		fmt.Sprintf("100:%s:1", hash),
+	} {
+		_, err := ParseCheckpoint(raw)
+		assert.ErrorIs(t, err, ErrInvalidCheckpoint, raw)
+	}
+}
+
+func TestCheckpoint_Check(t *testing.T) {
+	headers := blockchain.NewTestHeadersWithSeed(nil, 10, 0)
+	checkpoint := &Checkpoint{Number: 5, Hash: headers[5].Hash}
+
+	for _, header := range headers {
+		assert.NoError(t, checkpoint.Check(header))
+	}
+
+	fork := blockchain.NewTestHeadersWithSeed(nil, 10, 1)
+	assert.ErrorIs(t, checkpoint.Check(fork[5]), ErrCheckpointMismatch)
+	assert.NoError(t, checkpoint.Check(fork[6]))
+}
+
+func TestSyncer_VerifyBlock(t *testing.T) {
+	headers := blockchain.NewTestHeadersWithSeed(nil, 10, 0)
+	blocks := blockchain.HeadersToBlocks(headers)
+
+	chain := &verifyCountingBlockchain{mockBlockchain: NewMockBlockchain(headers[:1])}
+	syncer := NewSyncer(hclog.NewNullLogger(), nil, chain)
+
+	// all the seals are verified without checkpoint
+	for _, block := range blocks[1:] {
+		assert.NoError(t, syncer.verifyBlock(block))
+	}
+
+	assert.Equal(t, 9, chain.finalized)
+	assert.Equal(t, 0, chain.trusted)
+
+	// the seals are verified until the link to the checkpoint is proven
+	chain.finalized = 0
+	syncer.SetCheckpoint(&Checkpoint{Number: 5, Hash: headers[5].Hash})
+
+	for _, block := range blocks[1:] {
+		assert.NoError(t, syncer.verifyBlock(block))
+	}
+
+	assert.Equal(t, 9, chain.finalized)
+	assert.Equal(t, 0, chain.trusted)
+
+	// the block of the checkpoint must be the trusted one
+	fork := blockchain.HeadersToBlocks(blockchain.NewTestHeadersWithSeed(nil, 10, 1))
+	assert.ErrorIs(t, syncer.verifyBlock(fork[5]), ErrCheckpointMismatch)
+}
+
+func TestBulkSyncWithPeer_Checkpoint(t *testing.T) {
+	peerHeaders := blockchain.NewTestHeadersWithSeed(nil, 30, 0)
+
+	tests := []struct {
+		name       string
+		checkpoint *Checkpoint
+		err        error
+		banned     bool
+	}{
+		{
+			name:       "should sync the chain containing the checkpoint",
+			checkpoint: &Checkpoint{Number: 20, Hash: peerHeaders[20].Hash},
+		},
+		{
+			name:       "should refuse and ban the peer whose chain doesn't contain the checkpoint",
+			checkpoint: &Checkpoint{Number: 20, Hash: types.StringToHash("1")},
+			err:        ErrCheckpointMismatch,
+			banned:     true,
+		},
+		{
+			name:       "should refuse the peer behind the checkpoint",
+			checkpoint: &Checkpoint{Number: 40, Hash: types.StringToHash("1")},
+			err:        errCheckpointNotReached,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := &verifyCountingBlockchain{
+				mockBlockchain: NewMockBlockchain(blockchain.NewTestHeadersWithSeed(nil, 10, 0)),
+			}
+			peerChain := NewMockBlockchain(peerHeaders)
+
+			syncer, peerSyncers := SetupSyncerNetwork(t, chain, []blockchainShim{peerChain})
+			syncer.SetCheckpoint(tt.checkpoint)
+
+			peer := getPeer(syncer, peerSyncers[0].server.AddrInfo().ID)
+			assert.NotNil(t, peer)
+
+			err := syncer.BulkSyncWithPeer(peer, func(block *types.Block) {})
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.banned, syncer.scores.banned(peer.peer))
+
+			if tt.err == nil {
+				assert.Equal(t, peerChain.blocks, chain.blocks, "chain is not synced")
+				// the seals are verified above the checkpoint only
+				assert.Equal(t, 11, chain.trusted)
+				assert.Equal(t, 9, chain.finalized)
+			} else {
+				assert.Len(t, chain.blocks, 10)
+			}
+		})
+	}
+}
+
+func TestCheckpointLink(t *testing.T) {
+	defaultWindow := checkpointLinkWindow
+	checkpointLinkWindow = 4
+
+	t.Cleanup(func() {
+		checkpointLinkWindow = defaultWindow
+	})
+
+	peerHeaders := blockchain.NewTestHeadersWithSeed(nil, 30, 0)
+
+	syncer, peerSyncers := SetupSyncerNetwork(
+		t,
+		NewMockBlockchain(peerHeaders[:1]),
+		[]blockchainShim{NewMockBlockchain(peerHeaders)},
+	)
+
+	peer := getPeer(syncer, peerSyncers[0].server.AddrInfo().ID)
+	assert.NotNil(t, peer)
+
+	link, err := newCheckpointLink(peer.client, &Checkpoint{Number: 22, Hash: peerHeaders[22].Hash}, 3)
+	assert.NoError(t, err)
+
+	// the windows are walked again from their upper bounds
+	for _, number := range []uint64{3, 10, 4, 22, 21, 17} {
+		linked, err := link.proven(peerHeaders[number])
+		assert.NoError(t, err)
+		assert.True(t, linked, number)
+	}
+
+	fork := blockchain.NewTestHeadersWithSeed(nil, 30, 1)
+
+	for _, header := range []*types.Header{fork[9], peerHeaders[2], peerHeaders[23]} {
+		linked, err := link.proven(header)
+		assert.NoError(t, err)
+		assert.False(t, linked, header.Number)
+	}
+
+	// the chain of another checkpoint can't be linked to it
+	_, err = newCheckpointLink(peer.client, &Checkpoint{Number: 22, Hash: fork[22].Hash}, 3)
+	assert.ErrorIs(t, err, errCheckpointLinkBroken)
+}
+
+func TestSyncPeers_Checkpoint(t *testing.T) {
+	peerHeaders := blockchain.NewTestHeadersWithSeed(nil, 30, 0)
+	forkHeaders := blockchain.NewTestHeadersWithSeed(nil, 30, 1)
+
+	syncer, peerSyncers := SetupSyncerNetwork(
+		t,
+		NewMockBlockchain(peerHeaders[:1]),
+		[]blockchainShim{NewMockBlockchain(peerHeaders), NewMockBlockchain(forkHeaders)},
+	)
+	syncer.SetCheckpoint(&Checkpoint{Number: 20, Hash: peerHeaders[20].Hash})
+
+	peer := getPeer(syncer, peerSyncers[0].server.AddrInfo().ID)
+	forkPeer := getPeer(syncer, peerSyncers[1].server.AddrInfo().ID)
+
+	assert.NotNil(t, peer)
+	assert.NotNil(t, forkPeer)
+
+	// the peer whose chain doesn't contain the checkpoint doesn't serve blocks
+	assert.Equal(t, []*SyncPeer{peer}, syncer.syncPeers(peer))
+	assert.True(t, syncer.scores.banned(forkPeer.peer))
+}
@@ -0,0 +1,133 @@
+package protocol
+
+import (
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// syncEventBufferSize is the number of the events a subscription buffers,
+// the events pushed to a full subscription being dropped
+const syncEventBufferSize = 64
+
+type SyncEventType int
+
+const (
+	SyncEventPeerConnected    SyncEventType = iota // A sync peer connected
+	SyncEventPeerDisconnected                      // A sync peer disconnected
+	SyncEventBulkSyncStarted                       // A bulk sync with a peer started
+	SyncEventBulkSyncFinished                      // A bulk sync with a peer finished
+	SyncEventNewBlock                              // A peer announced a new block
+	SyncEventFork                                  // The chain of a peer forks from the local one
+)
+
+func (t SyncEventType) String() string {
+	switch t {
+	case SyncEventPeerConnected:
+		return "peer connected"
+	case SyncEventPeerDisconnected:
+		return "peer disconnected"
+	case SyncEventBulkSyncStarted:
+		return "bulk sync started"
+	case SyncEventBulkSyncFinished:
+		return "bulk sync finished"
+	case SyncEventNewBlock:
+		return "new block"
+	case SyncEventFork:
+		return "fork"
+	default:
+		return "unknown"
+	}
+}
+
+// SyncEvent is the syncer event passed to the subscribers
+type SyncEvent struct {
+	Type SyncEventType
+	Peer peer.ID
+
+	// Number is the head of the local chain for the bulk sync events,
+	// and the common ancestor for a fork
+	Number uint64
+
+	// Block is the block announced by the peer, for a new block
+	Block *types.Block
+
+	// Err is the error the bulk sync failed with, if any
+	Err error
+}
+
+// SyncSubscription is a subscription to the syncer events
+type SyncSubscription struct {
+	eventCh chan *SyncEvent
+	stream  *syncEventStream
+
+	closeOnce sync.Once
+}
+
+// EventCh returns the channel of the events, closed once the subscription is
+func (s *SyncSubscription) EventCh() <-chan *SyncEvent {
+	return s.eventCh
+}
+
+// Close stops the events of the subscription
+func (s *SyncSubscription) Close() {
+	s.closeOnce.Do(func() {
+		s.stream.unsubscribe(s)
+	})
+}
+
+// syncEventStream dispatches the syncer events to the subscriptions
+type syncEventStream struct {
+	lock sync.Mutex
+
+	subscriptions map[*SyncSubscription]struct{}
+}
+
+func newSyncEventStream() *syncEventStream {
+	return &syncEventStream{
+		subscriptions: make(map[*SyncSubscription]struct{}),
+	}
+}
+
+// subscribe creates a new subscription
+func (e *syncEventStream) subscribe() *SyncSubscription {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	sub := &SyncSubscription{
+		eventCh: make(chan *SyncEvent, syncEventBufferSize),
+		stream:  e,
+	}
+
+	e.subscriptions[sub] = struct{}{}
+
+	return sub
+}
+
+// unsubscribe removes the subscription, and closes its channel
+func (e *syncEventStream) unsubscribe(sub *SyncSubscription) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	delete(e.subscriptions, sub)
+	close(sub.eventCh)
+}
+
+// push notifies the subscriptions of the event, without blocking on the slow ones
+func (e *syncEventStream) push(event *SyncEvent) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for sub := range e.subscriptions {
+		select {
+		case sub.eventCh <- event:
+		default:
+		}
+	}
+}
+
+// SubscribeEvents returns a subscription to the syncer events, to be closed once done
+func (s *Syncer) SubscribeEvents() *SyncSubscription {
+	return s.events.subscribe()
+}
@@ -0,0 +1,116 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/network"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// nextSyncEvent returns the next event of the type, skipping the other ones
+func nextSyncEvent(t *testing.T, sub *SyncSubscription, typ SyncEventType) *SyncEvent {
+	t.Helper()
+
+	timeout := time.After(10 * time.Second)
+
+	for {
+		select {
+		case event, ok := <-sub.EventCh():
+			if !ok {
+				t.Fatalf("subscription closed waiting for %s", typ)
+			}
+
+			if event.Type == typ {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("timeout waiting for %s", typ)
+		}
+	}
+}
+
+func TestSyncEventStream(t *testing.T) {
+	stream := newSyncEventStream()
+
+	sub1, sub2 := stream.subscribe(), stream.subscribe()
+
+	stream.push(&SyncEvent{Type: SyncEventPeerConnected})
+	assert.Equal(t, SyncEventPeerConnected, (<-sub1.EventCh()).Type)
+	assert.Equal(t, SyncEventPeerConnected, (<-sub2.EventCh()).Type)
+
+	// the closed subscription is not notified anymore
+	sub1.Close()
+	sub1.Close()
+
+	_, ok := <-sub1.EventCh()
+	assert.False(t, ok)
+
+	stream.push(&SyncEvent{Type: SyncEventFork})
+	assert.Equal(t, SyncEventFork, (<-sub2.EventCh()).Type)
+
+	// the events are dropped for a full subscription, instead of blocking
+	for i := 0; i < 2*syncEventBufferSize; i++ {
+		stream.push(&SyncEvent{Type: SyncEventNewBlock})
+	}
+
+	assert.Len(t, sub2.EventCh(), syncEventBufferSize)
+
+	sub2.Close()
+	assert.Empty(t, stream.subscriptions)
+}
+
+func TestSyncer_Events(t *testing.T) {
+	headers := blockchain.NewTestHeadersWithSeed(nil, 100, 0)
+	peerHeaders := blockchain.AppendNewTestheadersWithSeed(
+		blockchain.NewTestHeadersWithSeed(nil, 50, 0),
+		80,
+		1,
+	)
+
+	chain, peerChain := NewMockBlockchain(headers), NewMockBlockchain(peerHeaders)
+
+	syncer := CreateSyncer(t, chain, nil)
+
+	sub := syncer.SubscribeEvents()
+	defer sub.Close()
+
+	peerSyncer := CreateSyncer(t, peerChain, nil)
+	peerID := peerSyncer.server.AddrInfo().ID
+
+	assert.NoError(t, network.JoinAndWait(
+		syncer.server,
+		peerSyncer.server,
+		network.DefaultBufferTimeout,
+		network.DefaultJoinTimeout,
+	))
+
+	assert.Equal(t, peerID, nextSyncEvent(t, sub, SyncEventPeerConnected).Peer)
+
+	peer := getPeer(syncer, peerID)
+	assert.NotNil(t, peer)
+
+	assert.NoError(t, syncer.BulkSyncWithPeer(peer, func(block *types.Block) {}))
+
+	started := nextSyncEvent(t, sub, SyncEventBulkSyncStarted)
+	assert.Equal(t, peerID, started.Peer)
+	assert.Equal(t, uint64(99), started.Number)
+
+	fork := nextSyncEvent(t, sub, SyncEventFork)
+	assert.Equal(t, uint64(49), fork.Number)
+
+	finished := nextSyncEvent(t, sub, SyncEventBulkSyncFinished)
+	assert.NoError(t, finished.Err)
+	assert.Equal(t, uint64(129), finished.Number)
+
+	// the blocks announced by the peer
+	newBlock := GenerateNewBlocks(t, peerChain, 1)[0]
+	syncer.enqueueBlock(peerID, newBlock)
+
+	assert.Equal(t, newBlock, nextSyncEvent(t, sub, SyncEventNewBlock).Block)
+
+	assert.NoError(t, syncer.DeletePeer(peerID))
+	assert.Equal(t, peerID, nextSyncEvent(t, sub, SyncEventPeerDisconnected).Peer)
+}
@@ -0,0 +1,401 @@
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/network"
+	libp2pGrpc "github.com/dogechain-lab/dogechain/network/grpc"
+	"github.com/dogechain-lab/dogechain/protocol/proto"
+	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// fastSyncPivotDistance is the number of blocks the pivot is behind the head of the peer,
+	// so that its state is kept by the peers and its block is not reorganized
+	fastSyncPivotDistance = 64
+	// fastSyncBlocksAmount is the number of blocks, along with their receipts, of a request
+	fastSyncBlocksAmount = 64
+	// fastSyncMaxRetries is the number of the consecutive failed range requests
+	// before the fast sync is aborted
+	fastSyncMaxRetries = 10
+	// fastSyncLogInterval is the interval of the state download progress logs
+	fastSyncLogInterval = 30 * time.Second
+)
+
+var (
+	ErrPivotNotFound          = errors.New("pivot block not found")
+	ErrPivotMismatch          = errors.New("pivot block mismatch")
+	errInvalidStateRange      = errors.New("invalid state range")
+	errHeaderReceiptsMismatch = errors.New("requested receipts and header mismatch")
+	errNoFastSyncBlocks       = errors.New("no block retrieved")
+)
+
+// fastSync downloads the state of a pivot block from the peers, trie by trie and range
+// by range, then heals it, so that a node catches up with the chain without executing
+// all the blocks preceding the pivot
+type fastSync struct {
+	logger  hclog.Logger
+	server  *network.Server
+	storage itrie.Storage
+	healer  *StateHealer
+
+	// requestRange retrieves the leaves of the trie of the root from the origin
+	requestRange func(ctx context.Context, root types.Hash, origin []byte) (*proto.GetStateRangeResponse, error)
+
+	// pivotLimit returns the highest pivot of a fast sync from the head, if any, the blocks
+	// written without their state must not be read by the consensus when inserted
+	pivotLimit func(head uint64) (uint64, bool)
+
+	ctx context.Context
+
+	accounts uint64
+	slots    uint64
+	lastLog  time.Time
+}
+
+func newFastSync(
+	logger hclog.Logger,
+	server *network.Server,
+	blockchain stateHealShim,
+	storage itrie.Storage,
+) *fastSync {
+	f := &fastSync{
+		logger:  logger.Named("fastsync"),
+		server:  server,
+		storage: storage,
+		healer:  NewStateHealer(logger, server, blockchain, storage, NilMetrics()),
+		ctx:     context.Background(),
+	}
+
+	f.requestRange = f.requestPeerRange
+
+	return f
+}
+
+// needed returns whether the state of the head is missing, or the chain is
+// at the genesis, so that the state is downloaded instead of executing the blocks
+func (f *fastSync) needed(header *types.Header) bool {
+	if header.Number == 0 {
+		return true
+	}
+
+	_, ok, _ := itrie.GetNode(header.StateRoot.Bytes(), f.storage)
+
+	return !ok
+}
+
+// syncState downloads the state of the root, then retrieves the contract codes
+// and the nodes still missing, such as the ones of the ranges changed meanwhile
+func (f *fastSync) syncState(root types.Hash) error {
+	start := time.Now()
+	f.lastLog = start
+
+	f.logger.Info("downloading state", "root", root)
+
+	err := f.syncTrie(root, func(_, value []byte) error {
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return err
+		}
+
+		f.accounts++
+
+		if account.Root == types.EmptyRootHash {
+			return nil
+		}
+
+		// the tries of the same storage are shared
+		if _, ok, _ := itrie.GetNode(account.Root.Bytes(), f.storage); ok {
+			return nil
+		}
+
+		return f.syncTrie(account.Root, func(_, _ []byte) error {
+			f.slots++
+
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	f.logger.Info(
+		"state downloaded, healing",
+		"root", root,
+		"accounts", f.accounts,
+		"slots", f.slots,
+		"elapsed", time.Since(start),
+	)
+
+	return f.healer.heal(root)
+}
+
+// syncTrie downloads the leaves of the trie of the root, range by range, and writes
+// the trie they build. A trie built from invalid leaves doesn't match the root,
+// the nodes it lacks are then retrieved by the healing
+func (f *fastSync) syncTrie(root types.Hash, onLeaf func(key, value []byte) error) error {
+	var (
+		local   = types.EmptyRootHash
+		origin  []byte
+		retries int
+	)
+
+	for {
+		resp, err := f.requestRange(f.ctx, root, origin)
+		if err == nil {
+			err = validateStateRange(resp, origin)
+		}
+
+		if err != nil {
+			if retries++; retries >= fastSyncMaxRetries {
+				return fmt.Errorf("failed to retrieve state range of %s, %w", root, err)
+			}
+
+			f.logger.Debug("failed to retrieve state range, retrying", "root", root, "err", err)
+
+			select {
+			case <-f.ctx.Done():
+				return f.ctx.Err()
+			case <-time.After(healRetryInterval):
+			}
+
+			continue
+		}
+
+		retries = 0
+
+		if local, err = itrie.WriteRange(f.storage, local, resp.Keys, resp.Values); err != nil {
+			return err
+		}
+
+		for i, key := range resp.Keys {
+			if err := onLeaf(key, resp.Values[i]); err != nil {
+				return err
+			}
+		}
+
+		if time.Since(f.lastLog) > fastSyncLogInterval {
+			f.logger.Info("downloading state", "accounts", f.accounts, "slots", f.slots)
+
+			f.lastLog = time.Now()
+		}
+
+		if !resp.More || len(resp.Keys) == 0 {
+			break
+		}
+
+		if origin = nextKey(resp.Keys[len(resp.Keys)-1]); origin == nil {
+			break
+		}
+	}
+
+	if local != root {
+		f.logger.Debug("state trie mismatch, left to the healing", "root", root, "built", local)
+	}
+
+	return nil
+}
+
+// validateStateRange checks that the keys of the range are hashes in increasing order from the origin
+func validateStateRange(resp *proto.GetStateRangeResponse, origin []byte) error {
+	if len(resp.Keys) != len(resp.Values) {
+		return errInvalidStateRange
+	}
+
+	prev := origin
+
+	for i, key := range resp.Keys {
+		if len(key) != types.HashLength || len(resp.Values[i]) == 0 {
+			return errInvalidStateRange
+		}
+
+		if cmp := bytes.Compare(key, prev); cmp < 0 || (cmp == 0 && i > 0) {
+			return errInvalidStateRange
+		}
+
+		prev = key
+	}
+
+	return nil
+}
+
+// nextKey returns the key following the key, nil if it is the last one
+func nextKey(key []byte) []byte {
+	next := append([]byte{}, key...)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+
+		if next[i] != 0 {
+			return next
+		}
+	}
+
+	return nil
+}
+
+// requestPeerRange retrieves the range of the trie of the root from a random peer
+func (f *fastSync) requestPeerRange(
+	ctx context.Context,
+	root types.Hash,
+	origin []byte,
+) (*proto.GetStateRangeResponse, error) {
+	peerID := f.server.GetRandomPeer()
+	if peerID == nil {
+		return nil, ErrNoHealPeer
+	}
+
+	stream, err := f.server.NewStream(healV1, *peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := libp2pGrpc.WrapClient(stream)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, healRequestTimeout)
+	defer cancel()
+
+	return proto.NewHealClient(conn).GetStateRange(ctx, &proto.GetStateRangeRequest{
+		Root:   root.Bytes(),
+		Origin: origin,
+		Amount: maxStateRangeAmount,
+	})
+}
+
+// EnableFastSync makes the bulk sync of a node lacking the state of its head download
+// the state of a pivot block from the peers, then write the blocks up to the pivot along
+// with their receipts, instead of executing all of them. The pivot limit, if not nil,
+// bounds the pivot below the first block whose state the consensus reads when inserted
+func (s *Syncer) EnableFastSync(storage itrie.Storage, pivotLimit func(head uint64) (uint64, bool)) {
+	s.fastSync = newFastSync(s.logger, s.server, s.blockchain, storage)
+	s.fastSync.pivotLimit = pivotLimit
+}
+
+// pivot returns the number of the pivot block of a fast sync from the head to the
+// head of the peer, behind it and within the pivot limit
+func (f *fastSync) pivot(head, peerHead uint64) uint64 {
+	pivot := peerHead - fastSyncPivotDistance
+
+	if f.pivotLimit != nil {
+		if limit, ok := f.pivotLimit(head); ok && limit < pivot {
+			pivot = limit
+		}
+	}
+
+	return pivot
+}
+
+// fastSyncWithPeer downloads the state of the pivot block behind the head of the peer,
+// then writes the blocks up to the pivot without executing them. The bulk sync then
+// executes the blocks following the pivot
+func (s *Syncer) fastSyncWithPeer(p *SyncPeer, newBlockHandler func(block *types.Block)) error {
+	header := s.blockchain.Header()
+	if !s.fastSync.needed(header) || p.status.Number < header.Number+fastSyncPivotDistance {
+		// close enough to the peer to execute the blocks
+		return nil
+	}
+
+	pivotNumber := s.fastSync.pivot(header.Number, p.status.Number)
+
+	pivot, err := getHeader(p.client, &pivotNumber, nil)
+	if err != nil {
+		return err
+	}
+
+	if pivot == nil {
+		return ErrPivotNotFound
+	}
+
+	s.logger.Info("fast syncing", "pivot", pivot.Number, "hash", pivot.Hash, "root", pivot.StateRoot)
+
+	// download the state first, the head being complete once the pivot is written
+	if err := s.fastSync.syncState(pivot.StateRoot); err != nil {
+		return err
+	}
+
+	s.syncProgression.StartProgression(header.Number+1, s.blockchain.SubscribeEvents())
+	defer s.syncProgression.StopProgression()
+
+	s.syncProgression.UpdateHighestProgression(pivot.Number)
+
+	for number := header.Number + 1; number <= pivot.Number; {
+		amount := pivot.Number - number + 1
+		if amount > fastSyncBlocksAmount {
+			amount = fastSyncBlocksAmount
+		}
+
+		sk := &skeleton{
+			amount: int64(amount),
+		}
+
+		err := sk.getBlocksFromPeer(p.client, number)
+
+		s.syncProgression.AddPulled(sk.pulledHeaders, sk.pulledBodies)
+
+		if err != nil {
+			return fmt.Errorf("unable to fetch blocks from peer, %w", err)
+		}
+
+		if len(sk.blocks) == 0 {
+			return errNoFastSyncBlocks
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), defaultBodyFetchTimeout)
+		receipts, err := getReceiptsByRange(ctx, p.client, sk.blocks)
+
+		if isUnimplemented(err) {
+			// the peer doesn't serve the receipts by range, request them by hash
+			hashes := make([]types.Hash, len(sk.blocks))
+			for i, block := range sk.blocks {
+				hashes[i] = block.Hash()
+			}
+
+			receipts, err = getReceipts(ctx, p.client, hashes)
+		}
+
+		cancel()
+
+		if err != nil {
+			return fmt.Errorf("unable to fetch receipts from peer, %w", err)
+		}
+
+		if len(receipts) != len(sk.blocks) {
+			return errHeaderReceiptsMismatch
+		}
+
+		for i, block := range sk.blocks {
+			if s.checkpoint != nil {
+				if err := s.checkpoint.Check(block.Header); err != nil {
+					s.scores.ban(p.peer)
+
+					return err
+				}
+			}
+
+			if err := s.blockchain.WriteBlockWithReceipts(block, receipts[i]); err != nil {
+				s.scores.invalidBlock(p.peer)
+				return fmt.Errorf("failed to write block while fast syncing: %w", err)
+			}
+
+			newBlockHandler(block)
+			s.prunePeerEnqueuedBlocks(block)
+			number++
+		}
+	}
+
+	if head := s.blockchain.Header(); head.Hash != pivot.Hash {
+		return fmt.Errorf("%w, expected %s, found %s", ErrPivotMismatch, pivot.Hash, head.Hash)
+	}
+
+	s.logger.Info("fast sync done", "pivot", pivot.Number)
+
+	return nil
+}
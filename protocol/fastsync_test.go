@@ -0,0 +1,117 @@
+package protocol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/protocol/proto"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestFastSync creates a fast sync retrieving the state from the source storage
+func newTestFastSync(source, storage itrie.Storage, amount uint64) *fastSync {
+	service := &healService{storage: source}
+
+	f := newFastSync(hclog.NewNullLogger(), nil, &mockHealBlockchain{}, storage)
+	f.requestRange = func(ctx context.Context, root types.Hash, origin []byte) (*proto.GetStateRangeResponse, error) {
+		return service.GetStateRange(ctx, &proto.GetStateRangeRequest{
+			Root:   root.Bytes(),
+			Origin: origin,
+			Amount: amount,
+		})
+	}
+	f.healer.requestNodes = func(ctx context.Context, hashes []types.Hash) ([][]byte, error) {
+		req := &proto.GetNodesRequest{}
+		for _, hash := range hashes {
+			req.Hashes = append(req.Hashes, hash.Bytes())
+		}
+
+		resp, err := service.GetNodes(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	}
+
+	return f
+}
+
+func TestFastSync_SyncState(t *testing.T) {
+	source, root := newHealTestState(t)
+	storage := itrie.NewMemoryStorage()
+
+	f := newTestFastSync(source, storage, 5)
+
+	// the ranges build the tries, only the code is left to the healing
+	requestNodes := f.healer.requestNodes
+	f.healer.requestNodes = func(ctx context.Context, hashes []types.Hash) ([][]byte, error) {
+		assert.Equal(t, []types.Hash{types.BytesToHash(crypto.Keccak256([]byte{0x60, 0x01}))}, hashes)
+
+		return requestNodes(ctx, hashes)
+	}
+
+	assert.True(t, f.needed(&types.Header{Number: 10, StateRoot: root}))
+	assert.NoError(t, f.syncState(root))
+	assert.False(t, f.needed(&types.Header{Number: 10, StateRoot: root}))
+
+	assert.Equal(t, uint64(32), f.accounts)
+	assert.Equal(t, uint64(16), f.slots)
+
+	// the state is complete
+	hashes, err := itrie.NewSync(storage, root).Missing(1)
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
+}
+
+func TestFastSync_ValidateStateRange(t *testing.T) {
+	low, high := types.StringToHash("1").Bytes(), types.StringToHash("2").Bytes()
+
+	tests := []struct {
+		name   string
+		keys   [][]byte
+		values [][]byte
+		origin []byte
+		valid  bool
+	}{
+		{"ordered", [][]byte{low, high}, [][]byte{{1}, {2}}, nil, true},
+		{"from the origin", [][]byte{low}, [][]byte{{1}}, low, true},
+		{"before the origin", [][]byte{low}, [][]byte{{1}}, high, false},
+		{"unordered", [][]byte{high, low}, [][]byte{{1}, {2}}, nil, false},
+		{"duplicated", [][]byte{low, low}, [][]byte{{1}, {2}}, nil, false},
+		{"missing value", [][]byte{low}, nil, nil, false},
+		{"not a hash", [][]byte{{1}}, [][]byte{{1}}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStateRange(&proto.GetStateRangeResponse{Keys: tt.keys, Values: tt.values}, tt.origin)
+			assert.Equal(t, tt.valid, err == nil)
+		})
+	}
+}
+
+func TestNextKey(t *testing.T) {
+	assert.Equal(t, []byte{0x01, 0x00}, nextKey([]byte{0x00, 0xff}))
+	assert.Equal(t, []byte{0x00, 0x02}, nextKey([]byte{0x00, 0x01}))
+	assert.Nil(t, nextKey([]byte{0xff, 0xff}))
+}
+
+func TestFastSync_Pivot(t *testing.T) {
+	f := newFastSync(hclog.NewNullLogger(), nil, &mockHealBlockchain{}, itrie.NewMemoryStorage())
+
+	// behind the head of the peer
+	assert.Equal(t, uint64(1000-fastSyncPivotDistance), f.pivot(0, 1000))
+
+	// within the limit, the epoch end of a PoS chain
+	f.pivotLimit = func(head uint64) (uint64, bool) {
+		return head + 100, true
+	}
+
+	assert.Equal(t, uint64(100), f.pivot(0, 1000))
+	assert.Equal(t, uint64(1000-fastSyncPivotDistance), f.pivot(900, 1000))
+}
@@ -0,0 +1,307 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/network"
+	libp2pGrpc "github.com/dogechain-lab/dogechain/network/grpc"
+	"github.com/dogechain-lab/dogechain/protocol/proto"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const healV1 = "/heal/0.1"
+
+const (
+	// maxHealNodesAmount is the max number of trie nodes or codes of a request
+	maxHealNodesAmount = 384
+	// maxStateRangeAmount is the max number of trie leaves of a range request
+	maxStateRangeAmount = 1024
+	// healRequestTimeout is the timeout of a request to a peer
+	healRequestTimeout = 10 * time.Second
+	// healRetryInterval is the delay before requesting again once a request failed
+	healRetryInterval = 5 * time.Second
+	// healLogInterval is the interval of the healing progress logs
+	healLogInterval = 30 * time.Second
+)
+
+var (
+	ErrNoHealPeer          = errors.New("no peer to heal from")
+	ErrTooManyHealHashes   = errors.New("too many hashes requested")
+	ErrTooManyRangeLeaves  = errors.New("too many range leaves requested")
+	errNoHealNodeRetrieved = errors.New("no item retrieved")
+)
+
+// stateHealShim is the blockchain whose head state is healed
+type stateHealShim interface {
+	Header() *types.Header
+}
+
+// healService is the GRPC server serving the local state to the healing peers
+type healService struct {
+	proto.UnimplementedHealServer
+
+	storage itrie.Storage
+}
+
+// GetNodes implements the HealServer interface
+func (s *healService) GetNodes(_ context.Context, req *proto.GetNodesRequest) (*proto.GetNodesResponse, error) {
+	if len(req.Hashes) > maxHealNodesAmount {
+		return nil, ErrTooManyHealHashes
+	}
+
+	resp := &proto.GetNodesResponse{
+		Data: make([][]byte, 0, len(req.Hashes)),
+	}
+
+	for _, hash := range req.Hashes {
+		data, ok, err := s.storage.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			// not a trie node, a contract code otherwise
+			data, _ = s.storage.GetCode(types.BytesToHash(hash))
+		}
+
+		resp.Data = append(resp.Data, data)
+	}
+
+	return resp, nil
+}
+
+// GetStateRange implements the HealServer interface
+func (s *healService) GetStateRange(
+	_ context.Context,
+	req *proto.GetStateRangeRequest,
+) (*proto.GetStateRangeResponse, error) {
+	if req.Amount > maxStateRangeAmount {
+		return nil, ErrTooManyRangeLeaves
+	}
+
+	keys, values, more, err := itrie.ReadRange(
+		s.storage,
+		types.BytesToHash(req.Root),
+		req.Origin,
+		int(req.Amount),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.GetStateRangeResponse{
+		Keys:   keys,
+		Values: values,
+		More:   more,
+	}, nil
+}
+
+// StateHealer serves the local state to the peers and, once asked to heal,
+// retrieves from them the trie nodes and contract codes missing from the head state,
+// so that a node whose state is incomplete converges to a fully consistent state.
+// The blocks imported meanwhile write the nodes they modify, so the states
+// following the healed one are complete too.
+type StateHealer struct {
+	logger     hclog.Logger
+	server     *network.Server
+	blockchain stateHealShim
+	storage    itrie.Storage
+	metrics    *Metrics
+
+	// requestNodes retrieves the items of the hashes, in order
+	requestNodes func(ctx context.Context, hashes []types.Hash) ([][]byte, error)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+// NewStateHealer creates a healer of the state of the storage
+func NewStateHealer(
+	logger hclog.Logger,
+	server *network.Server,
+	blockchain stateHealShim,
+	storage itrie.Storage,
+	metrics *Metrics,
+) *StateHealer {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	h := &StateHealer{
+		logger:     logger.Named("heal"),
+		server:     server,
+		blockchain: blockchain,
+		storage:    storage,
+		metrics:    metrics,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	h.requestNodes = h.requestPeerNodes
+
+	return h
+}
+
+// Start registers the grpc protocol serving the local state
+func (h *StateHealer) Start() {
+	grpcStream := libp2pGrpc.NewGrpcStream()
+	proto.RegisterHealServer(grpcStream.GrpcServer(), &healService{storage: h.storage})
+	grpcStream.Serve()
+	h.server.RegisterProtocol(healV1, grpcStream)
+}
+
+// Heal starts healing the head state in the background
+func (h *StateHealer) Heal() {
+	h.doneCh = make(chan struct{})
+
+	go func() {
+		defer close(h.doneCh)
+
+		if header := h.blockchain.Header(); header != nil {
+			if err := h.heal(header.StateRoot); err != nil {
+				h.logger.Error("healing aborted", "root", header.StateRoot, "err", err)
+			}
+		}
+	}()
+}
+
+// Close stops the healing, if any
+func (h *StateHealer) Close() {
+	h.cancel()
+
+	if h.doneCh != nil {
+		<-h.doneCh
+	}
+}
+
+// heal retrieves the items missing from the state of the root, until it is complete
+func (h *StateHealer) heal(root types.Hash) error {
+	var (
+		start   = time.Now()
+		lastLog = start
+		sync    = itrie.NewSync(h.storage, root)
+
+		healedNodes, healedCodes uint64
+	)
+
+	h.logger.Info("healing state", "root", root)
+
+	for {
+		hashes, err := sync.Missing(maxHealNodesAmount)
+		if err != nil {
+			return fmt.Errorf("failed to walk state, %w", err)
+		}
+
+		h.metrics.HealPendingNodes.Set(float64(len(hashes)))
+
+		if len(hashes) == 0 {
+			break
+		}
+
+		if err := h.healNodes(sync, hashes); err != nil {
+			h.metrics.HealRetries.Add(1)
+			h.logger.Debug("failed to retrieve state, retrying", "pending", len(hashes), "err", err)
+
+			select {
+			case <-h.ctx.Done():
+				return h.ctx.Err()
+			case <-time.After(healRetryInterval):
+			}
+		}
+
+		nodes, codes := sync.Healed()
+		h.metrics.HealedNodes.Add(float64(nodes - healedNodes))
+		h.metrics.HealedCodes.Add(float64(codes - healedCodes))
+		healedNodes, healedCodes = nodes, codes
+
+		if time.Since(lastLog) > healLogInterval {
+			h.logger.Info("healing state", "root", root, "nodes", nodes, "codes", codes)
+
+			lastLog = time.Now()
+		}
+
+		if err := h.ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	h.logger.Info(
+		"state healed",
+		"root", root,
+		"nodes", healedNodes,
+		"codes", healedCodes,
+		"elapsed", time.Since(start),
+	)
+
+	return nil
+}
+
+// healNodes retrieves the items of the hashes and writes the valid ones,
+// the others are requested again by the next round
+func (h *StateHealer) healNodes(sync *itrie.Sync, hashes []types.Hash) error {
+	data, err := h.requestNodes(h.ctx, hashes)
+	if err != nil {
+		return err
+	}
+
+	processed := 0
+
+	for i, hash := range hashes {
+		if i >= len(data) || len(data[i]) == 0 {
+			// unknown to the peer
+			continue
+		}
+
+		if err := sync.Process(hash, data[i]); err != nil {
+			h.logger.Debug("invalid state item retrieved", "hash", hash, "err", err)
+
+			continue
+		}
+
+		processed++
+	}
+
+	if processed == 0 {
+		return errNoHealNodeRetrieved
+	}
+
+	return nil
+}
+
+// requestPeerNodes retrieves the items of the hashes from a random peer
+func (h *StateHealer) requestPeerNodes(ctx context.Context, hashes []types.Hash) ([][]byte, error) {
+	peerID := h.server.GetRandomPeer()
+	if peerID == nil {
+		return nil, ErrNoHealPeer
+	}
+
+	stream, err := h.server.NewStream(healV1, *peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := libp2pGrpc.WrapClient(stream)
+	defer conn.Close()
+
+	req := &proto.GetNodesRequest{
+		Hashes: make([][]byte, 0, len(hashes)),
+	}
+
+	for _, hash := range hashes {
+		req.Hashes = append(req.Hashes, hash.Bytes())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healRequestTimeout)
+	defer cancel()
+
+	resp, err := proto.NewHealClient(conn).GetNodes(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
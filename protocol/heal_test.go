@@ -0,0 +1,163 @@
+package protocol
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/network"
+	"github.com/dogechain-lab/dogechain/protocol/proto"
+	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockHealBlockchain struct {
+	root types.Hash
+}
+
+func (m *mockHealBlockchain) Header() *types.Header {
+	return &types.Header{StateRoot: m.root}
+}
+
+// newHealTestState commits accounts, some of them contracts, to a new storage
+func newHealTestState(t *testing.T) (itrie.Storage, types.Hash) {
+	t.Helper()
+
+	storage := itrie.NewMemoryStorage()
+	code := []byte{0x60, 0x01}
+
+	objs := []*state.Object{}
+
+	for i := 0; i < 32; i++ {
+		obj := &state.Object{
+			Address:  types.StringToAddress(big.NewInt(int64(i + 1)).String()),
+			Balance:  big.NewInt(int64(i)),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+		}
+
+		if i%2 == 0 {
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.Code = code
+			obj.DirtyCode = true
+			obj.Storage = []*state.StorageObject{{
+				Key: types.StringToHash("1").Bytes(),
+				Val: big.NewInt(int64(i + 1)).Bytes(),
+			}}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root := itrie.NewState(storage).NewSnapshot().Commit(objs)
+
+	return storage, types.BytesToHash(root)
+}
+
+func newHealTestServer(t *testing.T) *network.Server {
+	t.Helper()
+
+	srv, err := network.CreateServer(&network.CreateServerParams{
+		ConfigCallback: func(c *network.Config) {
+			c.DataDir = t.TempDir()
+			c.NoDiscover = true
+		},
+	})
+	assert.NoError(t, err)
+
+	return srv
+}
+
+func TestStateHealer_Heal(t *testing.T) {
+	source, root := newHealTestState(t)
+	storage := itrie.NewMemoryStorage()
+
+	sourceSrv, srv := newHealTestServer(t), newHealTestServer(t)
+
+	sourceHealer := NewStateHealer(hclog.NewNullLogger(), sourceSrv, &mockHealBlockchain{root}, source, NilMetrics())
+	sourceHealer.Start()
+
+	healer := NewStateHealer(hclog.NewNullLogger(), srv, &mockHealBlockchain{root}, storage, NilMetrics())
+	healer.Start()
+
+	assert.NoError(t, network.JoinAndWait(srv, sourceSrv, network.DefaultBufferTimeout, network.DefaultJoinTimeout))
+
+	healer.Heal()
+
+	select {
+	case <-healer.doneCh:
+	case <-time.After(10 * time.Second):
+		t.Fatal("state not healed")
+	}
+
+	healer.Close()
+
+	// the state is complete
+	hashes, err := itrie.NewSync(storage, root).Missing(1)
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
+}
+
+func TestHealService_GetNodes(t *testing.T) {
+	source, root := newHealTestState(t)
+	service := &healService{storage: source}
+
+	code := []byte{0x60, 0x01}
+	unknown := types.StringToHash("1")
+
+	resp, err := service.GetNodes(context.Background(), &proto.GetNodesRequest{
+		Hashes: [][]byte{root.Bytes(), crypto.Keccak256(code), unknown.Bytes()},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 3)
+
+	node, _, _ := source.Get(root.Bytes())
+	assert.Equal(t, node, resp.Data[0])
+	assert.Equal(t, code, resp.Data[1])
+	assert.Empty(t, resp.Data[2])
+
+	_, err = service.GetNodes(context.Background(), &proto.GetNodesRequest{
+		Hashes: make([][]byte, maxHealNodesAmount+1),
+	})
+	assert.ErrorIs(t, err, ErrTooManyHealHashes)
+}
+
+func TestHealService_GetStateRange(t *testing.T) {
+	source, root := newHealTestState(t)
+	service := &healService{storage: source}
+
+	resp, err := service.GetStateRange(context.Background(), &proto.GetStateRangeRequest{
+		Root:   root.Bytes(),
+		Amount: 20,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Keys, 20)
+	assert.Len(t, resp.Values, 20)
+	assert.True(t, resp.More)
+
+	resp, err = service.GetStateRange(context.Background(), &proto.GetStateRangeRequest{
+		Root:   root.Bytes(),
+		Origin: nextKey(resp.Keys[19]),
+		Amount: 20,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Keys, 12)
+	assert.False(t, resp.More)
+
+	_, err = service.GetStateRange(context.Background(), &proto.GetStateRangeRequest{
+		Root:   types.StringToHash("1").Bytes(),
+		Amount: 20,
+	})
+	assert.ErrorIs(t, err, itrie.ErrMissingTrieNode)
+
+	_, err = service.GetStateRange(context.Background(), &proto.GetStateRangeRequest{
+		Root:   root.Bytes(),
+		Amount: maxStateRangeAmount + 1,
+	})
+	assert.ErrorIs(t, err, ErrTooManyRangeLeaves)
+}
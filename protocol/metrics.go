@@ -0,0 +1,66 @@
+package protocol
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics represents the sync protocol metrics
+type Metrics struct {
+	// Trie nodes retrieved by the state healing
+	HealedNodes metrics.Counter
+	// Contract codes retrieved by the state healing
+	HealedCodes metrics.Counter
+	// State items requested and not retrieved yet
+	HealPendingNodes metrics.Gauge
+	// Failed state healing requests
+	HealRetries metrics.Counter
+}
+
+// GetPrometheusMetrics return the sync protocol metrics instance
+func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics {
+	labels := []string{}
+
+	for i := 0; i < len(labelsWithValues); i += 2 {
+		labels = append(labels, labelsWithValues[i])
+	}
+
+	return &Metrics{
+		HealedNodes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "state_heal",
+			Name:      "healed_nodes",
+			Help:      "Trie nodes retrieved by the state healing",
+		}, labels).With(labelsWithValues...),
+		HealedCodes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "state_heal",
+			Name:      "healed_codes",
+			Help:      "Contract codes retrieved by the state healing",
+		}, labels).With(labelsWithValues...),
+		HealPendingNodes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "state_heal",
+			Name:      "pending_nodes",
+			Help:      "State items requested and not retrieved yet",
+		}, labels).With(labelsWithValues...),
+		HealRetries: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "state_heal",
+			Name:      "retries",
+			Help:      "Failed state healing requests",
+		}, labels).With(labelsWithValues...),
+	}
+}
+
+// NilMetrics will return the non operational sync protocol metrics
+func NilMetrics() *Metrics {
+	return &Metrics{
+		HealedNodes:      discard.NewCounter(),
+		HealedCodes:      discard.NewCounter(),
+		HealPendingNodes: discard.NewGauge(),
+		HealRetries:      discard.NewCounter(),
+	}
+}
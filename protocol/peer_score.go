@@ -0,0 +1,248 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+const (
+	// nilHeaderPenalty is the score lost by a peer responding nil headers
+	nilHeaderPenalty = 10
+	// invalidBlockPenalty is the score lost by a peer serving a block failing the verification
+	invalidBlockPenalty = 25
+	// timeoutPenalty is the score lost by a peer not responding in time
+	timeoutPenalty = 5
+	// failurePenalty is the score lost by a peer failing a request otherwise
+	failurePenalty = 2
+	// maxServedScore caps the score earned by the served requests
+	maxServedScore = 100
+	// peerBanScore is the score a peer is banned at
+	peerBanScore = -50
+	// peerBanDuration is the duration a peer is not synced from once banned
+	peerBanDuration = 10 * time.Minute
+)
+
+// PeerScore is the statistics of the requests served by a sync peer, and the score derived from them
+type PeerScore struct {
+	ID    peer.ID
+	Score int64
+	// Requests is the number of the requests served
+	Requests      uint64
+	Blocks        uint64
+	NilHeaders    uint64
+	InvalidBlocks uint64
+	Timeouts      uint64
+	Failures      uint64
+	// Throughput is the number of the blocks served per second of request
+	Throughput float64
+	// BannedUntil is the end of the ban of the peer, zero if not banned
+	BannedUntil time.Time
+}
+
+// peerStats is the statistics of a sync peer
+type peerStats struct {
+	requests      uint64
+	blocks        uint64
+	nilHeaders    uint64
+	invalidBlocks uint64
+	timeouts      uint64
+	failures      uint64
+	elapsed       time.Duration
+	bannedUntil   time.Time
+}
+
+func (ps *peerStats) score() int64 {
+	served := int64(ps.requests)
+	if served > maxServedScore {
+		served = maxServedScore
+	}
+
+	return served -
+		int64(ps.nilHeaders)*nilHeaderPenalty -
+		int64(ps.invalidBlocks)*invalidBlockPenalty -
+		int64(ps.timeouts)*timeoutPenalty -
+		int64(ps.failures)*failurePenalty
+}
+
+// peerScores keeps the statistics of the sync peers, and bans the ones whose score
+// falls to the ban score. A banned peer starts over once its ban is over
+type peerScores struct {
+	logger hclog.Logger
+
+	lock  sync.Mutex
+	stats map[peer.ID]*peerStats
+
+	now func() time.Time
+}
+
+func newPeerScores(logger hclog.Logger) *peerScores {
+	return &peerScores{
+		logger: logger,
+		stats:  make(map[peer.ID]*peerStats),
+		now:    time.Now,
+	}
+}
+
+// get returns the statistics of the peer, reset once its ban is over. The lock must be held
+func (s *peerScores) get(id peer.ID) *peerStats {
+	stats, ok := s.stats[id]
+	if !ok || (!stats.bannedUntil.IsZero() && !s.now().Before(stats.bannedUntil)) {
+		stats = &peerStats{}
+		s.stats[id] = stats
+	}
+
+	return stats
+}
+
+// update applies the change to the statistics of the peer, and bans it if its score
+// falls to the ban score
+func (s *peerScores) update(id peer.ID, change func(stats *peerStats)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := s.get(id)
+	if !stats.bannedUntil.IsZero() {
+		return
+	}
+
+	change(stats)
+
+	if score := stats.score(); score <= peerBanScore {
+		stats.bannedUntil = s.now().Add(peerBanDuration)
+
+		s.logger.Warn("sync peer banned", "id", id, "score", score, "until", stats.bannedUntil)
+	}
+}
+
+// ban bans the peer, whatever its score
+func (s *peerScores) ban(id peer.ID) {
+	s.update(id, func(stats *peerStats) {
+		stats.bannedUntil = s.now().Add(peerBanDuration)
+
+		s.logger.Warn("sync peer banned", "id", id, "until", stats.bannedUntil)
+	})
+}
+
+// served records the blocks served by the peer in a request
+func (s *peerScores) served(id peer.ID, blocks int, elapsed time.Duration) {
+	s.update(id, func(stats *peerStats) {
+		stats.requests++
+		stats.blocks += uint64(blocks)
+		stats.elapsed += elapsed
+	})
+}
+
+// failed records the failed request to the peer
+func (s *peerScores) failed(id peer.ID, err error) {
+	s.update(id, func(stats *peerStats) {
+		switch {
+		case errors.Is(err, errNilHeaderResponse):
+			stats.nilHeaders++
+		case isTimeout(err):
+			stats.timeouts++
+		default:
+			stats.failures++
+		}
+	})
+}
+
+// invalidBlock records the block of the peer failing the verification
+func (s *peerScores) invalidBlock(id peer.ID) {
+	s.update(id, func(stats *peerStats) {
+		stats.invalidBlocks++
+	})
+}
+
+// score returns the score of the peer
+func (s *peerScores) score(id peer.ID) int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.get(id).score()
+}
+
+// banned returns whether the peer is banned
+func (s *peerScores) banned(id peer.ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return !s.get(id).bannedUntil.IsZero()
+}
+
+// remove forgets the statistics of the peer, unless it is banned
+func (s *peerScores) remove(id peer.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.get(id).bannedUntil.IsZero() {
+		return
+	}
+
+	delete(s.stats, id)
+}
+
+// list returns the scores of the peers, the highest first
+func (s *peerScores) list() []*PeerScore {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	scores := make([]*PeerScore, 0, len(s.stats))
+
+	for id := range s.stats {
+		stats := s.get(id)
+
+		score := &PeerScore{
+			ID:            id,
+			Score:         stats.score(),
+			Requests:      stats.requests,
+			Blocks:        stats.blocks,
+			NilHeaders:    stats.nilHeaders,
+			InvalidBlocks: stats.invalidBlocks,
+			Timeouts:      stats.timeouts,
+			Failures:      stats.failures,
+			BannedUntil:   stats.bannedUntil,
+		}
+
+		if stats.elapsed > 0 {
+			score.Throughput = float64(stats.blocks) / stats.elapsed.Seconds()
+		}
+
+		scores = append(scores, score)
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+
+		return scores[i].ID < scores[j].ID
+	})
+
+	return scores
+}
+
+// isTimeout returns whether the error is the one of a request not responded in time
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrPopTimeout) {
+		return true
+	}
+
+	var grpcErr interface {
+		GRPCStatus() *grpcstatus.Status
+	}
+
+	return errors.As(err, &grpcErr) && grpcErr.GRPCStatus().Code() == grpccodes.DeadlineExceeded
+}
+
+// PeerScores returns the scores of the sync peers, the highest first
+func (s *Syncer) PeerScores() []*PeerScore {
+	return s.scores.list()
+}
@@ -0,0 +1,138 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// newTestPeerScores creates the peer scores of a clock controlled by the test
+func newTestPeerScores() (*peerScores, *time.Time) {
+	now := time.Unix(1_000_000, 0)
+
+	scores := newPeerScores(hclog.NewNullLogger())
+	scores.now = func() time.Time {
+		return now
+	}
+
+	return scores, &now
+}
+
+func TestPeerScores_Score(t *testing.T) {
+	scores, _ := newTestPeerScores()
+	id := peer.ID("A")
+
+	scores.served(id, 10, time.Second)
+	scores.served(id, 30, time.Second)
+	assert.Equal(t, int64(2), scores.score(id))
+
+	scores.failed(id, errNilHeaderResponse)
+	scores.failed(id, grpcstatus.Error(grpccodes.DeadlineExceeded, "deadline"))
+	scores.failed(id, errors.New("closed"))
+	scores.invalidBlock(id)
+	assert.Equal(t, int64(2-nilHeaderPenalty-timeoutPenalty-failurePenalty-invalidBlockPenalty), scores.score(id))
+
+	list := scores.list()
+	assert.Len(t, list, 1)
+	assert.Equal(t, &PeerScore{
+		ID:            id,
+		Score:         scores.score(id),
+		Requests:      2,
+		Blocks:        40,
+		NilHeaders:    1,
+		InvalidBlocks: 1,
+		Timeouts:      1,
+		Failures:      1,
+		Throughput:    20,
+	}, list[0])
+
+	// the served requests earn a bounded score
+	for i := 0; i < 2*maxServedScore; i++ {
+		scores.served(peer.ID("B"), 1, time.Millisecond)
+	}
+
+	assert.Equal(t, int64(maxServedScore), scores.score(peer.ID("B")))
+	assert.Equal(t, peer.ID("B"), scores.list()[0].ID)
+}
+
+func TestPeerScores_Ban(t *testing.T) {
+	scores, now := newTestPeerScores()
+	id := peer.ID("A")
+
+	scores.invalidBlock(id)
+	assert.False(t, scores.banned(id))
+
+	scores.invalidBlock(id)
+	assert.True(t, scores.banned(id))
+	assert.Equal(t, now.Add(peerBanDuration), scores.list()[0].BannedUntil)
+
+	// the banned peer is not scored nor forgotten
+	scores.served(id, 10, time.Second)
+	assert.Equal(t, uint64(0), scores.list()[0].Requests)
+
+	scores.remove(id)
+	assert.True(t, scores.banned(id))
+
+	// the peer starts over once the ban is over
+	*now = now.Add(peerBanDuration)
+
+	assert.False(t, scores.banned(id))
+	assert.Equal(t, int64(0), scores.score(id))
+
+	scores.remove(id)
+	assert.Empty(t, scores.list())
+}
+
+func TestIsTimeout(t *testing.T) {
+	assert.True(t, isTimeout(context.DeadlineExceeded))
+	assert.True(t, isTimeout(fmt.Errorf("unable to fetch blocks from peer, %w", ErrPopTimeout)))
+	assert.True(t, isTimeout(grpcstatus.Error(grpccodes.DeadlineExceeded, "deadline")))
+	assert.False(t, isTimeout(grpcstatus.Error(grpccodes.Unavailable, "closed")))
+	assert.False(t, isTimeout(errNilHeaderResponse))
+}
+
+func TestBestPeer_Score(t *testing.T) {
+	syncer := NewSyncer(hclog.NewNullLogger(), nil, NewRandomChain(t, 10))
+
+	peers := map[peer.ID]uint64{
+		"A": 100,
+		"B": 50,
+		"C": 20,
+		"D": 5,
+	}
+
+	for id, number := range peers {
+		syncer.peers.Store(id, &SyncPeer{peer: id, status: &Status{Number: number}})
+	}
+
+	// the highest block first, for equal scores
+	assert.Equal(t, peer.ID("A"), syncer.BestPeer().peer)
+
+	// the highest score first
+	syncer.scores.served("B", 10, time.Second)
+	syncer.scores.served("C", 10, time.Second)
+	syncer.scores.served("C", 10, time.Second)
+	assert.Equal(t, peer.ID("C"), syncer.BestPeer().peer)
+
+	// the banned peers are skipped
+	syncer.scores.invalidBlock("C")
+	syncer.scores.invalidBlock("C")
+	syncer.scores.invalidBlock("C")
+	assert.Equal(t, peer.ID("B"), syncer.BestPeer().peer)
+
+	// the peers behind the local chain are skipped whatever their score
+	syncer.scores.served("D", 10, time.Second)
+	syncer.scores.served("D", 10, time.Second)
+	syncer.scores.served("D", 10, time.Second)
+	assert.Equal(t, peer.ID("B"), syncer.BestPeer().peer)
+
+	assert.Len(t, syncer.syncPeers(syncer.BestPeer()), 3)
+}
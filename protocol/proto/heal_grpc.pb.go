@@ -0,0 +1,141 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// HealClient is the client API for Heal service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HealClient interface {
+	// GetNodes returns the trie nodes or contract codes of the hashes
+	GetNodes(ctx context.Context, in *GetNodesRequest, opts ...grpc.CallOption) (*GetNodesResponse, error)
+	// GetStateRange returns the leaves of a state trie in key order, from an origin
+	GetStateRange(ctx context.Context, in *GetStateRangeRequest, opts ...grpc.CallOption) (*GetStateRangeResponse, error)
+}
+
+type healClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHealClient(cc grpc.ClientConnInterface) HealClient {
+	return &healClient{cc}
+}
+
+func (c *healClient) GetNodes(ctx context.Context, in *GetNodesRequest, opts ...grpc.CallOption) (*GetNodesResponse, error) {
+	out := new(GetNodesResponse)
+	err := c.cc.Invoke(ctx, "/v1.Heal/GetNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *healClient) GetStateRange(ctx context.Context, in *GetStateRangeRequest, opts ...grpc.CallOption) (*GetStateRangeResponse, error) {
+	out := new(GetStateRangeResponse)
+	err := c.cc.Invoke(ctx, "/v1.Heal/GetStateRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HealServer is the server API for Heal service.
+// All implementations must embed UnimplementedHealServer
+// for forward compatibility
+type HealServer interface {
+	// GetNodes returns the trie nodes or contract codes of the hashes
+	GetNodes(context.Context, *GetNodesRequest) (*GetNodesResponse, error)
+	// GetStateRange returns the leaves of a state trie in key order, from an origin
+	GetStateRange(context.Context, *GetStateRangeRequest) (*GetStateRangeResponse, error)
+	mustEmbedUnimplementedHealServer()
+}
+
+// UnimplementedHealServer must be embedded to have forward compatible implementations.
+type UnimplementedHealServer struct {
+}
+
+func (UnimplementedHealServer) GetNodes(context.Context, *GetNodesRequest) (*GetNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodes not implemented")
+}
+func (UnimplementedHealServer) GetStateRange(context.Context, *GetStateRangeRequest) (*GetStateRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateRange not implemented")
+}
+func (UnimplementedHealServer) mustEmbedUnimplementedHealServer() {}
+
+// UnsafeHealServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HealServer will
+// result in compilation errors.
+type UnsafeHealServer interface {
+	mustEmbedUnimplementedHealServer()
+}
+
+func RegisterHealServer(s grpc.ServiceRegistrar, srv HealServer) {
+	s.RegisterService(&Heal_ServiceDesc, srv)
+}
+
+func _Heal_GetNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealServer).GetNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Heal/GetNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealServer).GetNodes(ctx, req.(*GetNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heal_GetStateRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealServer).GetStateRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Heal/GetStateRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealServer).GetStateRange(ctx, req.(*GetStateRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Heal_ServiceDesc is the grpc.ServiceDesc for Heal service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Heal_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.Heal",
+	HandlerType: (*HealServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetNodes",
+			Handler:    _Heal_GetNodes_Handler,
+		},
+		{
+			MethodName: "GetStateRange",
+			Handler:    _Heal_GetStateRange_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "protocol/proto/heal.proto",
+}
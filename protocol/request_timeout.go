@@ -0,0 +1,96 @@
+package protocol
+
+import (
+	"context"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/protocol/proto"
+	"google.golang.org/grpc"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// defaultRequestTimeout is the deadline of a request to a sync peer
+	defaultRequestTimeout = 10 * time.Second
+)
+
+// timeoutClient bounds each request to a peer by a deadline, so that a stalled
+// peer fails the request, to be retried on another peer, instead of freezing the sync.
+// The deadline of the context of the request applies if it is earlier
+type timeoutClient struct {
+	proto.V1Client
+
+	timeout time.Duration
+}
+
+func newTimeoutClient(clt proto.V1Client, timeout time.Duration) proto.V1Client {
+	if timeout <= 0 {
+		return clt
+	}
+
+	return &timeoutClient{
+		V1Client: clt,
+		timeout:  timeout,
+	}
+}
+
+func (c *timeoutClient) GetCurrent(
+	ctx context.Context,
+	in *empty.Empty,
+	opts ...grpc.CallOption,
+) (*proto.V1Status, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return c.V1Client.GetCurrent(ctx, in, opts...)
+}
+
+func (c *timeoutClient) GetObjectsByHash(
+	ctx context.Context,
+	in *proto.HashRequest,
+	opts ...grpc.CallOption,
+) (*proto.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return c.V1Client.GetObjectsByHash(ctx, in, opts...)
+}
+
+func (c *timeoutClient) GetHeaders(
+	ctx context.Context,
+	in *proto.GetHeadersRequest,
+	opts ...grpc.CallOption,
+) (*proto.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return c.V1Client.GetHeaders(ctx, in, opts...)
+}
+
+func (c *timeoutClient) GetReceipts(
+	ctx context.Context,
+	in *proto.GetReceiptsRequest,
+	opts ...grpc.CallOption,
+) (*proto.ReceiptsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return c.V1Client.GetReceipts(ctx, in, opts...)
+}
+
+func (c *timeoutClient) Notify(
+	ctx context.Context,
+	in *proto.NotifyReq,
+	opts ...grpc.CallOption,
+) (*empty.Empty, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return c.V1Client.Notify(ctx, in, opts...)
+}
+
+// SetRequestTimeout sets the deadline of the requests to the sync peers connected
+// afterwards, 0 disabling it
+func (s *Syncer) SetRequestTimeout(timeout time.Duration) {
+	s.requestTimeout = timeout
+}
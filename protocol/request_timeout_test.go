@@ -0,0 +1,50 @@
+package protocol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/protocol/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// stalledClient is a client of a peer never responding
+type stalledClient struct {
+	proto.V1Client
+}
+
+func (c *stalledClient) GetHeaders(
+	ctx context.Context,
+	_ *proto.GetHeadersRequest,
+	_ ...grpc.CallOption,
+) (*proto.Response, error) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func TestTimeoutClient(t *testing.T) {
+	clt := newTimeoutClient(&stalledClient{}, 50*time.Millisecond)
+
+	start := time.Now()
+	_, err := getHeaders(clt, &proto.GetHeadersRequest{Number: 1, Amount: 10})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, isTimeout(err))
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// the earlier deadline of the request applies
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	clt = newTimeoutClient(&stalledClient{}, time.Hour)
+
+	_, err = clt.GetHeaders(ctx, &proto.GetHeadersRequest{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// no deadline
+	stalled := &stalledClient{}
+	assert.Equal(t, proto.V1Client(stalled), newTimeoutClient(stalled, 0))
+}
@@ -5,11 +5,15 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
 	"github.com/dogechain-lab/dogechain/network/grpc"
 	"github.com/dogechain-lab/dogechain/protocol/proto"
 	"github.com/dogechain-lab/dogechain/types"
+	"github.com/golang/snappy"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p-core/peer"
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 	anypb "google.golang.org/protobuf/types/known/anypb"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
@@ -82,14 +86,17 @@ func (s *serviceV1) GetObjectsByHash(_ context.Context, req *proto.HashRequest)
 	}
 
 	resp := &proto.Response{
-		Objs: []*proto.Response_Component{},
+		Objs:       []*proto.Response_Component{},
+		Compressed: req.Compress,
 	}
 
 	for _, hash := range hashes {
 		var obj rlpObject
 
 		if req.Type == proto.HashRequest_BODIES {
-			obj, _ = s.store.GetBodyByHash(hash)
+			if body, ok := s.store.GetBodyByHash(hash); ok && body != nil {
+				obj = body
+			}
 		} else if req.Type == proto.HashRequest_RECEIPTS {
 			var raw []*types.Receipt
 			raw, err = s.store.GetReceiptsByHash(hash)
@@ -108,6 +115,10 @@ func (s *serviceV1) GetObjectsByHash(_ context.Context, req *proto.HashRequest)
 			data = []byte{}
 		}
 
+		if req.Compress && len(data) > 0 {
+			data = snappy.Encode(nil, data)
+		}
+
 		resp.Objs = append(resp.Objs, &proto.Response_Component{
 			Spec: &anypb.Any{
 				Value: data,
@@ -118,7 +129,11 @@ func (s *serviceV1) GetObjectsByHash(_ context.Context, req *proto.HashRequest)
 	return resp, nil
 }
 
-const maxSkeletonHeadersAmount = 190
+const (
+	maxSkeletonHeadersAmount = 190
+	// maxReceiptsAmount is the number of the blocks whose receipts are served by a request
+	maxReceiptsAmount = 128
+)
 
 // GetHeaders implements the V1Server interface
 func (s *serviceV1) GetHeaders(_ context.Context, req *proto.GetHeadersRequest) (*proto.Response, error) {
@@ -187,6 +202,50 @@ func (s *serviceV1) GetHeaders(_ context.Context, req *proto.GetHeadersRequest)
 	return resp, nil
 }
 
+// GetReceipts implements the V1Server interface. The receipts are served in the store
+// format, compressed as they are stored
+func (s *serviceV1) GetReceipts(_ context.Context, req *proto.GetReceiptsRequest) (*proto.ReceiptsResponse, error) {
+	amount := req.Amount
+	if amount > maxReceiptsAmount {
+		amount = maxReceiptsAmount
+	}
+
+	resp := &proto.ReceiptsResponse{
+		Receipts:   []*proto.ReceiptsResponse_BlockReceipts{},
+		Compressed: req.Compress,
+	}
+
+	for number := req.From; number < req.From+amount; number++ {
+		header, ok := s.store.GetHeaderByNumber(number)
+		if !ok {
+			break
+		}
+
+		data, err := s.store.GetCompressedReceiptsByHash(header.Hash)
+		if errors.Is(err, storage.ErrNotFound) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !req.Compress {
+			if data, err = snappy.Decode(nil, data); err != nil {
+				return nil, err
+			}
+		}
+
+		resp.Receipts = append(resp.Receipts, &proto.ReceiptsResponse_BlockReceipts{
+			Number: number,
+			Hash:   header.Hash.String(),
+			Data:   data,
+		})
+	}
+
+	return resp, nil
+}
+
 // Helper functions to decode responses from the grpc layer
 func getBodies(ctx context.Context, clt proto.V1Client, hashes []types.Hash) ([]*types.Body, error) {
 	input := make([]string, 0, len(hashes))
@@ -198,8 +257,9 @@ func getBodies(ctx context.Context, clt proto.V1Client, hashes []types.Hash) ([]
 	resp, err := clt.GetObjectsByHash(
 		ctx,
 		&proto.HashRequest{
-			Hash: input,
-			Type: proto.HashRequest_BODIES,
+			Hash:     input,
+			Type:     proto.HashRequest_BODIES,
+			Compress: true,
 		},
 	)
 	if err != nil {
@@ -210,8 +270,14 @@ func getBodies(ctx context.Context, clt proto.V1Client, hashes []types.Hash) ([]
 
 	for _, obj := range resp.Objs {
 		var body types.Body
-		if obj.Spec.Value != nil {
-			if err := body.UnmarshalRLP(obj.Spec.Value); err != nil {
+
+		value, err := responseValue(resp, obj)
+		if err != nil {
+			return nil, err
+		}
+
+		if value != nil {
+			if err := body.UnmarshalRLP(value); err != nil {
 				return nil, err
 			}
 		}
@@ -225,3 +291,119 @@ func getBodies(ctx context.Context, clt proto.V1Client, hashes []types.Hash) ([]
 
 	return res, nil
 }
+
+// responseValue returns the value of the object of the response, decompressed
+// if the peer compressed it
+func responseValue(resp *proto.Response, obj *proto.Response_Component) ([]byte, error) {
+	if obj.Spec == nil || len(obj.Spec.Value) == 0 || !resp.Compressed {
+		return obj.GetSpec().GetValue(), nil
+	}
+
+	return snappy.Decode(nil, obj.Spec.Value)
+}
+
+func getReceipts(ctx context.Context, clt proto.V1Client, hashes []types.Hash) ([][]*types.Receipt, error) {
+	input := make([]string, 0, len(hashes))
+
+	for _, h := range hashes {
+		input = append(input, h.String())
+	}
+
+	resp, err := clt.GetObjectsByHash(
+		ctx,
+		&proto.HashRequest{
+			Hash: input,
+			Type: proto.HashRequest_RECEIPTS,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]*types.Receipt, 0, len(resp.Objs))
+
+	for _, obj := range resp.Objs {
+		var receipts types.Receipts
+		if obj.Spec != nil && len(obj.Spec.Value) != 0 {
+			if err := receipts.UnmarshalRLP(obj.Spec.Value); err != nil {
+				return nil, err
+			}
+		}
+
+		res = append(res, receipts)
+	}
+
+	if len(res) != len(input) {
+		return nil, fmt.Errorf("not correct size")
+	}
+
+	return res, nil
+}
+
+// getReceiptsByRange fetches the receipts of the blocks, following each other, in a single
+// request. The context fields of the receipts are not trusted, but left to be derived
+// from the blocks once written
+func getReceiptsByRange(ctx context.Context, clt proto.V1Client, blocks []*types.Block) ([][]*types.Receipt, error) {
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+
+	resp, err := clt.GetReceipts(
+		ctx,
+		&proto.GetReceiptsRequest{
+			From:     blocks[0].Number(),
+			Amount:   uint64(len(blocks)),
+			Compress: true,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Receipts) != len(blocks) {
+		return nil, errHeaderReceiptsMismatch
+	}
+
+	res := make([][]*types.Receipt, len(blocks))
+
+	for i, obj := range resp.Receipts {
+		if obj.Number != blocks[i].Number() || types.StringToHash(obj.Hash) != blocks[i].Hash() {
+			return nil, errHeaderReceiptsMismatch
+		}
+
+		data := obj.Data
+		if resp.Compressed {
+			if data, err = snappy.Decode(nil, data); err != nil {
+				return nil, err
+			}
+		}
+
+		var receipts types.Receipts
+		if err := receipts.UnmarshalStoreRLP(data); err != nil {
+			return nil, err
+		}
+
+		for _, receipt := range receipts {
+			receipt.ContractAddress = nil
+			receipt.GasUsed = 0
+			receipt.TxHash = types.ZeroHash
+		}
+
+		res[i] = receipts
+	}
+
+	return res, nil
+}
+
+// isUnimplemented returns whether the error is the one of a request the peer doesn't serve
+func isUnimplemented(err error) bool {
+	var grpcErr interface {
+		GRPCStatus() *grpcstatus.Status
+	}
+
+	if !errors.As(err, &grpcErr) {
+		return false
+	}
+
+	return grpcErr.GRPCStatus().Code() == grpccodes.Unimplemented
+}
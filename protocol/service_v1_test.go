@@ -0,0 +1,241 @@
+package protocol
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/protocol/proto"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/golang/snappy"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// serviceClient is a client calling the service of a peer directly, and recording
+// the bodies requested to it
+type serviceClient struct {
+	proto.V1Client
+
+	service *serviceV1
+	// compress makes the peer support the compression
+	compress  bool
+	requested []string
+	// headerRequests is the number of the headers requests
+	headerRequests int
+}
+
+func (c *serviceClient) GetHeaders(
+	ctx context.Context,
+	in *proto.GetHeadersRequest,
+	_ ...grpc.CallOption,
+) (*proto.Response, error) {
+	c.headerRequests++
+
+	return c.service.GetHeaders(ctx, in)
+}
+
+func (c *serviceClient) GetReceipts(
+	ctx context.Context,
+	in *proto.GetReceiptsRequest,
+	_ ...grpc.CallOption,
+) (*proto.ReceiptsResponse, error) {
+	if !c.compress {
+		in.Compress = false
+	}
+
+	return c.service.GetReceipts(ctx, in)
+}
+
+func (c *serviceClient) GetObjectsByHash(
+	ctx context.Context,
+	in *proto.HashRequest,
+	_ ...grpc.CallOption,
+) (*proto.Response, error) {
+	c.requested = append(c.requested, in.Hash...)
+
+	if !c.compress {
+		in.Compress = false
+	}
+
+	return c.service.GetObjectsByHash(ctx, in)
+}
+
+// newBodiesChain returns a chain of blocks with transactions
+func newBodiesChain(n int) *mockBlockchain {
+	chain := NewMockBlockchain(blockchain.NewTestHeaders(n))
+
+	for i, block := range chain.blocks {
+		block.Transactions = []*types.Transaction{
+			{Nonce: uint64(i), Input: make([]byte, 256), Value: big.NewInt(0), GasPrice: big.NewInt(1)},
+		}
+	}
+
+	return chain
+}
+
+func TestServiceV1_GetObjectsByHash_Compress(t *testing.T) {
+	chain := newBodiesChain(5)
+	service := &serviceV1{logger: hclog.NewNullLogger(), store: chain}
+
+	hashes := []string{chain.blocks[1].Hash().String(), types.StringToHash("1").String()}
+
+	resp, err := service.GetObjectsByHash(context.Background(), &proto.HashRequest{
+		Hash:     hashes,
+		Type:     proto.HashRequest_BODIES,
+		Compress: true,
+	})
+	assert.NoError(t, err)
+	assert.True(t, resp.Compressed)
+
+	raw, err := snappy.Decode(nil, resp.Objs[0].Spec.Value)
+	assert.NoError(t, err)
+	assert.Equal(t, chain.blocks[1].Body().MarshalRLPTo(nil), raw)
+
+	// the unknown body is empty
+	assert.Empty(t, resp.Objs[1].Spec.Value)
+
+	// the bodies are decoded whether the peer compresses them or not
+	for _, compress := range []bool{true, false} {
+		clt := &serviceClient{service: service, compress: compress}
+
+		bodies, err := getBodies(context.Background(), clt, []types.Hash{chain.blocks[1].Hash(), chain.blocks[2].Hash()})
+		assert.NoError(t, err)
+		assert.Equal(t, chain.blocks[1].Transactions[0].Nonce, bodies[0].Transactions[0].Nonce)
+		assert.Equal(t, chain.blocks[2].Transactions[0].Nonce, bodies[1].Transactions[0].Nonce)
+	}
+}
+
+func TestSkeleton_LocalBodies(t *testing.T) {
+	peerChain := newBodiesChain(20)
+	clt := &serviceClient{
+		service:  &serviceV1{logger: hclog.NewNullLogger(), store: peerChain},
+		compress: true,
+	}
+
+	// the local database holds the bodies of the first blocks
+	local := &mockBlockchain{blocks: peerChain.blocks[:10]}
+
+	sk := &skeleton{
+		amount: 15,
+		local:  local.GetBodyByHash,
+	}
+
+	assert.NoError(t, sk.getBlocksFromPeer(clt, 5))
+	assert.Len(t, sk.blocks, 15)
+
+	for i, block := range sk.blocks {
+		expected := peerChain.blocks[5+i]
+
+		assert.Equal(t, expected.Hash(), block.Hash())
+		assert.Equal(t, expected.Transactions[0].Nonce, block.Transactions[0].Nonce)
+	}
+
+	// only the bodies missing locally are downloaded
+	requested := make([]string, 0, 10)
+	for _, block := range peerChain.blocks[10:20] {
+		requested = append(requested, block.Hash().String())
+	}
+
+	assert.Equal(t, requested, clt.requested)
+	assert.Equal(t, uint64(10), sk.pulledBodies)
+}
+
+func TestSkeleton_BadBlocks(t *testing.T) {
+	peerChain := newBodiesChain(20)
+	clt := &serviceClient{
+		service: &serviceV1{logger: hclog.NewNullLogger(), store: peerChain},
+	}
+
+	bad := peerChain.blocks[8].Hash()
+
+	sk := &skeleton{
+		amount: 10,
+		bad: func(hash types.Hash) bool {
+			return hash == bad
+		},
+	}
+
+	// the known bad block is refused before its body is downloaded
+	assert.ErrorIs(t, sk.getBlocksFromPeer(clt, 5), blockchain.ErrKnownBadBlock)
+	assert.Empty(t, clt.requested)
+	assert.Empty(t, sk.blocks)
+
+	assert.NoError(t, sk.getBlocksFromPeer(clt, 9))
+	assert.Len(t, sk.blocks, 10)
+}
+
+func TestServiceV1_GetReceipts(t *testing.T) {
+	chain := newBodiesChain(10)
+	chain.receipts = make(map[types.Hash][]*types.Receipt)
+
+	// the receipts of the blocks up to 8 are stored, along with their context fields
+	for _, block := range chain.blocks[1:9] {
+		status := types.ReceiptSuccess
+		chain.receipts[block.Hash()] = []*types.Receipt{
+			{
+				Status:            &status,
+				CumulativeGasUsed: block.Number() * 1000,
+				Logs:              []*types.Log{{Address: types.StringToAddress("1"), Data: []byte{0x1}}},
+				GasUsed:           block.Number() * 1000,
+				TxHash:            block.Transactions[0].Hash,
+				ContractAddress:   &types.Address{0x1},
+			},
+		}
+	}
+
+	service := &serviceV1{logger: hclog.NewNullLogger(), store: chain}
+
+	// the receipts are served up to the first block whose receipts are not available
+	resp, err := service.GetReceipts(context.Background(), &proto.GetReceiptsRequest{From: 6, Amount: 10})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Receipts, 3)
+	assert.Equal(t, uint64(8), resp.Receipts[2].Number)
+	assert.Equal(t, chain.blocks[8].Hash().String(), resp.Receipts[2].Hash)
+
+	// the receipts are decoded whether the peer compresses them or not
+	for _, compress := range []bool{true, false} {
+		clt := &serviceClient{service: service, compress: compress}
+
+		receipts, err := getReceiptsByRange(context.Background(), clt, chain.blocks[2:6])
+		assert.NoError(t, err)
+		assert.Len(t, receipts, 4)
+
+		for i, blockReceipts := range receipts {
+			assert.Len(t, blockReceipts, 1)
+
+			receipt := blockReceipts[0]
+			assert.Equal(t, uint64(2+i)*1000, receipt.CumulativeGasUsed)
+			assert.Equal(t, []byte{0x1}, receipt.Logs[0].Data)
+
+			// the context fields are not trusted
+			assert.Nil(t, receipt.ContractAddress)
+			assert.Zero(t, receipt.GasUsed)
+			assert.Equal(t, types.ZeroHash, receipt.TxHash)
+		}
+	}
+
+	clt := &serviceClient{service: service, compress: true}
+
+	// the receipts of the blocks of another chain
+	otherChain := newBodiesChain(10)
+	otherChain.blocks[3].Header.ExtraData = []byte{0x1}
+	otherChain.blocks[3].Header.ComputeHash()
+
+	_, err = getReceiptsByRange(context.Background(), clt, otherChain.blocks[2:6])
+	assert.ErrorIs(t, err, errHeaderReceiptsMismatch)
+
+	// the receipts not available
+	_, err = getReceiptsByRange(context.Background(), clt, chain.blocks[7:10])
+	assert.ErrorIs(t, err, errHeaderReceiptsMismatch)
+}
+
+func TestIsUnimplemented(t *testing.T) {
+	assert.True(t, isUnimplemented(grpcstatus.Error(grpccodes.Unimplemented, "method GetReceipts not implemented")))
+	assert.False(t, isUnimplemented(grpcstatus.Error(grpccodes.Unavailable, "unavailable")))
+	assert.False(t, isUnimplemented(errHeaderReceiptsMismatch))
+}
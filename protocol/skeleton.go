@@ -3,14 +3,19 @@ package protocol
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/dogechain-lab/dogechain/blockchain"
 	"github.com/dogechain-lab/dogechain/protocol/proto"
 	"github.com/dogechain-lab/dogechain/types"
 )
 
 const (
 	defaultBodyFetchTimeout = time.Second * 10
+	// maxAncestorHeadersAmount is the number of the latest headers requested backward
+	// at once to find the common ancestor, covering the shallow reorgs
+	maxAncestorHeadersAmount = 64
 )
 
 var (
@@ -44,10 +49,51 @@ func getHeaders(clt proto.V1Client, req *proto.GetHeadersRequest) ([]*types.Head
 	return headers, nil
 }
 
+// getHeadersBackward fetches at most the amount of headers of the peer, from the number
+// down to the genesis, linked by their parent hashes
+func getHeadersBackward(clt proto.V1Client, from uint64, amount int64) ([]*types.Header, error) {
+	headers, err := getHeaders(
+		clt,
+		&proto.GetHeadersRequest{
+			Number: int64(from),
+			Skip:   -2,
+			Amount: amount,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, header := range headers {
+		if header.Number != from-uint64(i) {
+			return nil, errInvalidHeaderSequence
+		}
+
+		if i > 0 && headers[i-1].ParentHash != header.Hash {
+			return nil, errInvalidHeaderSequence
+		}
+	}
+
+	return headers, nil
+}
+
 type skeleton struct {
 	blocks []*types.Block
 	skip   int64
 	amount int64
+
+	// local returns the body of the hash already in the local database, if any,
+	// so that it is not downloaded again
+	local func(hash types.Hash) (*types.Body, bool)
+
+	// bad returns whether the hash is the one of a known bad block, refused
+	// before its body is downloaded
+	bad func(hash types.Hash) bool
+
+	// the number of headers and bodies fetched, the blocks being
+	// built only once all of them are
+	pulledHeaders uint64
+	pulledBodies  uint64
 }
 
 // getBlocksFromPeer fetches the blocks from the peer,
@@ -69,6 +115,8 @@ func (s *skeleton) getBlocksFromPeer(
 		return err
 	}
 
+	s.pulledHeaders = uint64(len(headers))
+
 	// Make sure the number sequences match up
 	for i := 1; i < len(headers); i++ {
 		if headers[i].Number-headers[i-1].Number != 1 {
@@ -76,28 +124,57 @@ func (s *skeleton) getBlocksFromPeer(
 		}
 	}
 
-	// Construct the body request
-	headerHashes := make([]types.Hash, len(headers))
-	for index, header := range headers {
-		headerHashes[index] = header.Hash
+	if s.bad != nil {
+		for _, header := range headers {
+			if s.bad(header.Hash) {
+				return fmt.Errorf("%w %d (%s)", blockchain.ErrKnownBadBlock, header.Number, header.Hash)
+			}
+		}
 	}
 
-	getBodiesContext, cancelFn := context.WithTimeout(
-		context.Background(),
-		defaultBodyFetchTimeout,
-	)
-	defer cancelFn()
+	// Construct the body request, of the bodies missing locally
+	bodies := make([]*types.Body, len(headers))
+	missing := make([]int, 0, len(headers))
+	missingHashes := make([]types.Hash, 0, len(headers))
 
-	// Grab the block bodies
-	bodies, err := getBodies(getBodiesContext, peerClient, headerHashes)
-	if err != nil {
-		return err
+	for index, header := range headers {
+		if s.local != nil {
+			if body, ok := s.local(header.Hash); ok && body != nil {
+				bodies[index] = body
+
+				continue
+			}
+		}
+
+		missing = append(missing, index)
+		missingHashes = append(missingHashes, header.Hash)
 	}
 
-	if len(bodies) != len(headers) {
-		return errHeaderBodyMismatch
+	if len(missingHashes) > 0 {
+		getBodiesContext, cancelFn := context.WithTimeout(
+			context.Background(),
+			defaultBodyFetchTimeout,
+		)
+		defer cancelFn()
+
+		// Grab the block bodies
+		fetched, err := getBodies(getBodiesContext, peerClient, missingHashes)
+		if err != nil {
+			return err
+		}
+
+		if len(fetched) != len(missingHashes) {
+			return errHeaderBodyMismatch
+		}
+
+		for i, index := range missing {
+			bodies[index] = fetched[i]
+		}
 	}
 
+	// the bodies read from the local database are not pulled
+	s.pulledBodies = uint64(len(missingHashes))
+
 	s.blocks = make([]*types.Block, len(headers))
 
 	for index, body := range bodies {
@@ -0,0 +1,322 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/types"
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+const (
+	// maxSlotWorkersPerPeer is the number of the slots fetched concurrently from a peer
+	maxSlotWorkersPerPeer = 2
+	// maxSlotWorkers is the number of the slots fetched concurrently
+	maxSlotWorkers = 16
+	// maxSlotAttempts is the number of the peers a slot is requested to before failing
+	maxSlotAttempts = 3
+)
+
+var (
+	errIncompleteSlot = errors.New("incomplete slot")
+	errSlotNotLinked  = errors.New("slot blocks not linked")
+	errSlotForked     = errors.New("slot not on the chain of the sync peer")
+)
+
+// slot is a range of consecutive blocks fetched by a single request
+type slot struct {
+	index  int
+	from   uint64
+	amount uint64
+	blocks []*types.Block
+	err    error
+
+	// peer the blocks are fetched from
+	peer *SyncPeer
+
+	// done is closed once the slot is filled or failed
+	done chan struct{}
+}
+
+// slotFiller fetches the blocks of a range in slots, concurrently from the peers
+// holding them with a bounded number of requests per peer, and delivers the slots
+// in order. A failed slot is requested again to the other peers, and the slots of
+// the other peers are checked against the chain of the sync peer
+type slotFiller struct {
+	// peers holding the range, the sync peer first
+	peers  []*SyncPeer
+	amount uint64
+
+	// fetch retrieves the blocks of a slot from a peer
+	fetch func(p *SyncPeer, from, amount uint64) ([]*types.Block, error)
+	// hashOf returns the hash of the block of the number on the chain of the sync peer
+	hashOf func(number uint64) (types.Hash, error)
+
+	// stored returns the blocks of a slot downloaded before, if any
+	stored func(from, amount uint64) []*types.Block
+	// store records the blocks of a slot once downloaded
+	store func(blocks []*types.Block)
+
+	// perPeer bounds the concurrent requests to each peer
+	perPeer map[*SyncPeer]chan struct{}
+}
+
+func newSlotFiller(
+	peers []*SyncPeer,
+	amount uint64,
+	fetch func(p *SyncPeer, from, amount uint64) ([]*types.Block, error),
+	hashOf func(number uint64) (types.Hash, error),
+) *slotFiller {
+	f := &slotFiller{
+		peers:   peers,
+		amount:  amount,
+		fetch:   fetch,
+		hashOf:  hashOf,
+		perPeer: make(map[*SyncPeer]chan struct{}, len(peers)),
+	}
+
+	for _, p := range peers {
+		f.perPeer[p] = make(chan struct{}, maxSlotWorkersPerPeer)
+	}
+
+	return f
+}
+
+// fill fetches the blocks from the first number to the last one, both included,
+// and delivers them slot by slot in order, along with the peer they are fetched from,
+// until a slot or its delivery fails
+func (f *slotFiller) fill(first, last uint64, deliver func(p *SyncPeer, blocks []*types.Block) error) error {
+	if first > last {
+		return nil
+	}
+
+	workers := maxSlotWorkersPerPeer * len(f.peers)
+	if workers > maxSlotWorkers {
+		workers = maxSlotWorkers
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the slots queued for the delivery, bounding the slots fetched ahead of it
+	queue := make(chan *slot, 2*workers)
+	jobs := make(chan *slot)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for sl := range jobs {
+				f.fillSlot(ctx, sl)
+				close(sl.done)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(queue)
+		defer close(jobs)
+
+		for index, from := 0, first; from <= last; index++ {
+			amount := last - from + 1
+			if amount > f.amount {
+				amount = f.amount
+			}
+
+			sl := &slot{
+				index:  index,
+				from:   from,
+				amount: amount,
+				done:   make(chan struct{}),
+			}
+
+			select {
+			case queue <- sl:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case jobs <- sl:
+			case <-ctx.Done():
+				return
+			}
+
+			from += amount
+		}
+	}()
+
+	for sl := range queue {
+		<-sl.done
+
+		if sl.err != nil {
+			return sl.err
+		}
+
+		if err := deliver(sl.peer, sl.blocks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fillSlot fetches the blocks of the slot, from a peer holding them then from the
+// other ones if it fails. The slots are spread over the peers by their index. The
+// blocks already fetched are kept, only the remaining ones are requested again,
+// and the blocks downloaded before are not requested at all
+func (f *slotFiller) fillSlot(ctx context.Context, sl *slot) {
+	if f.stored != nil {
+		if blocks := f.stored(sl.from, sl.amount); len(blocks) > 0 {
+			sl.blocks = blocks
+			sl.peer = f.peers[0]
+
+			if uint64(len(blocks)) == sl.amount {
+				return
+			}
+		}
+	}
+
+	candidates := f.candidates(sl)
+
+	var err error
+
+	for try, failures := 0, 0; failures < maxSlotAttempts; try++ {
+		p := candidates[(sl.index+try)%len(candidates)]
+		sem := f.perPeer[p]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			sl.err = ctx.Err()
+
+			return
+		}
+
+		var blocks []*types.Block
+
+		blocks, err = f.fetchSlot(p, sl)
+
+		<-sem
+
+		if len(blocks) > 0 {
+			sl.blocks = append(sl.blocks, blocks...)
+			sl.peer = p
+
+			if f.store != nil {
+				f.store(blocks)
+			}
+		}
+
+		if err == nil {
+			return
+		}
+
+		// a smaller slot is needed, whatever the peer
+		if isResourceExhausted(err) {
+			break
+		}
+
+		// a partial response is progress, not a failure
+		if len(blocks) == 0 {
+			failures++
+		}
+	}
+
+	sl.err = fmt.Errorf("unable to fetch blocks from peer, %w", err)
+}
+
+// fetchSlot fetches the remaining blocks of the slot from the peer, and returns the
+// valid ones along with the error if they are not all of them. The blocks must follow
+// the ones already fetched, and the blocks of a peer other than the sync one must end
+// with the block of the sync peer, the parent hashes linking them to it
+func (f *slotFiller) fetchSlot(p *SyncPeer, sl *slot) ([]*types.Block, error) {
+	from := sl.from + uint64(len(sl.blocks))
+	remaining := sl.amount - uint64(len(sl.blocks))
+
+	blocks, err := f.fetch(p, from, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blocks) == 0 || uint64(len(blocks)) > remaining || blocks[0].Number() != from {
+		return nil, errIncompleteSlot
+	}
+
+	if n := len(sl.blocks); n > 0 && blocks[0].ParentHash() != sl.blocks[n-1].Hash() {
+		return nil, errSlotNotLinked
+	}
+
+	for i := 1; i < len(blocks); i++ {
+		if blocks[i].ParentHash() != blocks[i-1].Hash() {
+			return nil, errSlotNotLinked
+		}
+	}
+
+	if p != f.peers[0] {
+		last := blocks[len(blocks)-1]
+
+		hash, err := f.hashOf(last.Number())
+		if err != nil {
+			return nil, err
+		}
+
+		if hash != last.Hash() {
+			return nil, errSlotForked
+		}
+	}
+
+	if uint64(len(blocks)) != remaining {
+		return blocks, errIncompleteSlot
+	}
+
+	return blocks, nil
+}
+
+// candidates returns the peers holding the blocks of the slot, the sync peer first
+func (f *slotFiller) candidates(sl *slot) []*SyncPeer {
+	candidates := []*SyncPeer{f.peers[0]}
+
+	for _, p := range f.peers[1:] {
+		if p.Number() >= sl.from+sl.amount-1 {
+			candidates = append(candidates, p)
+		}
+	}
+
+	return candidates
+}
+
+// isResourceExhausted returns whether the error is the one of a grpc message
+// exceeding the size limit of the server or the client
+func isResourceExhausted(err error) bool {
+	var grpcErr interface {
+		GRPCStatus() *grpcstatus.Status
+	}
+
+	if !errors.As(err, &grpcErr) {
+		return false
+	}
+
+	return grpcErr.GRPCStatus().Code() == grpccodes.ResourceExhausted
+}
+
+// syncPeers returns the peers not banned and whose chain contains the checkpoint, the sync peer first
+func (s *Syncer) syncPeers(p *SyncPeer) []*SyncPeer {
+	peers := []*SyncPeer{p}
+
+	s.peers.Range(func(_, value interface{}) bool {
+		syncPeer, ok := value.(*SyncPeer)
+		if !ok || syncPeer == p || s.scores.banned(syncPeer.peer) {
+			return true
+		}
+
+		// the peers serving blocks must contain the checkpoint too
+		if err := s.checkPeerCheckpoint(syncPeer); err == nil {
+			peers = append(peers, syncPeer)
+		}
+
+		return true
+	})
+
+	return peers
+}
@@ -0,0 +1,362 @@
+package protocol
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/protocol/proto"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// newSlotTestPeers creates the peers of the given heads
+func newSlotTestPeers(numbers ...uint64) []*SyncPeer {
+	peers := make([]*SyncPeer, len(numbers))
+	for i, number := range numbers {
+		peers[i] = &SyncPeer{status: &Status{Number: number}}
+	}
+
+	return peers
+}
+
+// fetchFromChain returns a fetch of the blocks of the chain
+func fetchFromChain(blocks []*types.Block) func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+	return func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+		end := from + amount
+		if end > uint64(len(blocks)) {
+			end = uint64(len(blocks))
+		}
+
+		return blocks[from:end], nil
+	}
+}
+
+// hashOfChain returns the hashes of the blocks of the chain
+func hashOfChain(blocks []*types.Block) func(number uint64) (types.Hash, error) {
+	return func(number uint64) (types.Hash, error) {
+		return blocks[number].Hash(), nil
+	}
+}
+
+func TestSlotFiller_OrderedDelivery(t *testing.T) {
+	blocks := blockchain.HeadersToBlocks(blockchain.NewTestHeaders(100))
+	peers := newSlotTestPeers(99, 99, 99)
+
+	var (
+		lock      sync.Mutex
+		fetchedBy = map[*SyncPeer]int{}
+		inFlight  int32
+		maxFlight int32
+	)
+
+	fetch := fetchFromChain(blocks)
+
+	filler := newSlotFiller(peers, 7, func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		lock.Lock()
+		fetchedBy[p]++
+
+		if n > maxFlight {
+			maxFlight = n
+		}
+		lock.Unlock()
+
+		// the late slots are filled first
+		time.Sleep(time.Duration(100-from) * 50 * time.Microsecond)
+
+		return fetch(p, from, amount)
+	}, hashOfChain(blocks))
+
+	var delivered []*types.Block
+
+	assert.NoError(t, filler.fill(1, 99, func(_ *SyncPeer, slot []*types.Block) error {
+		delivered = append(delivered, slot...)
+
+		return nil
+	}))
+
+	assert.Equal(t, blocks[1:], delivered)
+
+	// the slots are spread over the peers, with bounded concurrent requests
+	assert.Len(t, fetchedBy, 3)
+	assert.LessOrEqual(t, int(maxFlight), maxSlotWorkersPerPeer*len(peers))
+}
+
+func TestSlotFiller_RetryAlternatePeer(t *testing.T) {
+	blocks := blockchain.HeadersToBlocks(blockchain.NewTestHeaders(50))
+	peers := newSlotTestPeers(49, 49)
+	fetch := fetchFromChain(blocks)
+
+	// the second peer fails every request
+	filler := newSlotFiller(peers, 10, func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+		if p == peers[1] {
+			return nil, errors.New("timeout")
+		}
+
+		return fetch(p, from, amount)
+	}, hashOfChain(blocks))
+
+	var delivered []*types.Block
+
+	assert.NoError(t, filler.fill(1, 49, func(_ *SyncPeer, slot []*types.Block) error {
+		delivered = append(delivered, slot...)
+
+		return nil
+	}))
+
+	assert.Equal(t, blocks[1:], delivered)
+}
+
+func TestSlotFiller_PartialProgress(t *testing.T) {
+	blocks := blockchain.HeadersToBlocks(blockchain.NewTestHeaders(50))
+	peers := newSlotTestPeers(49, 49)
+	fetch := fetchFromChain(blocks)
+
+	var (
+		lock      sync.Mutex
+		requested = map[*SyncPeer][]uint64{}
+	)
+
+	// the sync peer serves half of the requested blocks, then stalls
+	filler := newSlotFiller(peers, 10, func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+		lock.Lock()
+		requested[p] = append(requested[p], from)
+		lock.Unlock()
+
+		if p == peers[0] {
+			return fetch(p, from, amount/2)
+		}
+
+		return fetch(p, from, amount)
+	}, hashOfChain(blocks))
+
+	var delivered []*types.Block
+
+	assert.NoError(t, filler.fill(1, 20, func(_ *SyncPeer, slot []*types.Block) error {
+		delivered = append(delivered, slot...)
+
+		return nil
+	}))
+
+	assert.Equal(t, blocks[1:21], delivered)
+
+	// the blocks already fetched are not requested again, the first slot
+	// being completed by the second peer
+	assert.Equal(t, []uint64{1}, requested[peers[0]])
+	assert.ElementsMatch(t, []uint64{6, 11}, requested[peers[1]])
+}
+
+func TestSlotFiller_StalledPeer(t *testing.T) {
+	blocks := blockchain.HeadersToBlocks(blockchain.NewTestHeaders(50))
+	peers := newSlotTestPeers(49, 49, 49)
+	fetch := fetchFromChain(blocks)
+
+	// the requests to the stalled peer reach their deadline
+	filler := newSlotFiller(peers, 5, func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+		if p == peers[1] {
+			clt := newTimeoutClient(&stalledClient{}, 10*time.Millisecond)
+
+			if _, err := getHeaders(clt, &proto.GetHeadersRequest{Number: int64(from)}); err != nil {
+				return nil, err
+			}
+		}
+
+		return fetch(p, from, amount)
+	}, hashOfChain(blocks))
+
+	var delivered []*types.Block
+
+	start := time.Now()
+
+	assert.NoError(t, filler.fill(1, 49, func(_ *SyncPeer, slot []*types.Block) error {
+		delivered = append(delivered, slot...)
+
+		return nil
+	}))
+
+	assert.Equal(t, blocks[1:], delivered)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestSlotFiller_Candidates(t *testing.T) {
+	blocks := blockchain.HeadersToBlocks(blockchain.NewTestHeaders(50))
+	// the second peer is behind the range
+	peers := newSlotTestPeers(49, 20)
+	fetch := fetchFromChain(blocks)
+
+	filler := newSlotFiller(peers, 10, func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+		assert.False(t, p == peers[1] && from+amount-1 > 20, "slot requested to a peer not holding it")
+
+		return fetch(p, from, amount)
+	}, hashOfChain(blocks))
+
+	assert.NoError(t, filler.fill(1, 49, func(_ *SyncPeer, slot []*types.Block) error {
+		return nil
+	}))
+}
+
+func TestSlotFiller_Fork(t *testing.T) {
+	canonical := blockchain.HeadersToBlocks(blockchain.NewTestHeadersWithSeed(nil, 40, 0))
+	fork := blockchain.HeadersToBlocks(blockchain.AppendNewTestheadersWithSeed(
+		blockchain.NewTestHeadersWithSeed(nil, 15, 0),
+		25,
+		1,
+	))
+
+	peers := newSlotTestPeers(39, 39)
+	fetchCanonical, fetchFork := fetchFromChain(canonical), fetchFromChain(fork)
+
+	// the second peer is on another fork
+	filler := newSlotFiller(peers, 10, func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+		if p == peers[1] {
+			return fetchFork(p, from, amount)
+		}
+
+		return fetchCanonical(p, from, amount)
+	}, hashOfChain(canonical))
+
+	var delivered []*types.Block
+
+	assert.NoError(t, filler.fill(1, 39, func(_ *SyncPeer, slot []*types.Block) error {
+		delivered = append(delivered, slot...)
+
+		return nil
+	}))
+
+	// the slots of the fork are requested again to the sync peer
+	assert.Equal(t, canonical[1:], delivered)
+}
+
+func TestSlotFiller_Failures(t *testing.T) {
+	blocks := blockchain.HeadersToBlocks(blockchain.NewTestHeaders(50))
+	fetch := fetchFromChain(blocks)
+
+	t.Run("slot failed on all the peers", func(t *testing.T) {
+		errFetch := errors.New("timeout")
+
+		var delivered []*types.Block
+
+		filler := newSlotFiller(newSlotTestPeers(49, 49), 10, func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+			if from == 21 {
+				return nil, errFetch
+			}
+
+			return fetch(p, from, amount)
+		}, hashOfChain(blocks))
+
+		err := filler.fill(1, 49, func(_ *SyncPeer, slot []*types.Block) error {
+			delivered = append(delivered, slot...)
+
+			return nil
+		})
+
+		// the slots preceding the failed one are delivered
+		assert.ErrorIs(t, err, errFetch)
+		assert.Equal(t, blocks[1:21], delivered)
+	})
+
+	t.Run("incomplete slot", func(t *testing.T) {
+		filler := newSlotFiller(newSlotTestPeers(49), 10, func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+			return fetch(p, from, amount-1)
+		}, hashOfChain(blocks))
+
+		assert.ErrorIs(t, filler.fill(1, 49, func(_ *SyncPeer, slot []*types.Block) error {
+			return nil
+		}), errIncompleteSlot)
+	})
+
+	t.Run("resource exhausted", func(t *testing.T) {
+		var calls int32
+
+		filler := newSlotFiller(newSlotTestPeers(49, 49), 10, func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+			atomic.AddInt32(&calls, 1)
+
+			return nil, grpcstatus.Error(grpccodes.ResourceExhausted, "message too large")
+		}, hashOfChain(blocks))
+
+		err := filler.fill(1, 9, func(_ *SyncPeer, slot []*types.Block) error {
+			return nil
+		})
+
+		// not requested again to the other peers
+		assert.True(t, isResourceExhausted(err))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("delivery failed", func(t *testing.T) {
+		errWrite := errors.New("write failed")
+
+		filler := newSlotFiller(newSlotTestPeers(49), 10, fetch, hashOfChain(blocks))
+
+		assert.ErrorIs(t, filler.fill(1, 49, func(_ *SyncPeer, slot []*types.Block) error {
+			return errWrite
+		}), errWrite)
+	})
+}
+
+func TestSlotFiller_StoredSlots(t *testing.T) {
+	blocks := blockchain.HeadersToBlocks(blockchain.NewTestHeaders(100))
+	peers := newSlotTestPeers(99)
+
+	var (
+		lock    sync.Mutex
+		fetched []uint64
+		stored  []uint64
+	)
+
+	fetch := fetchFromChain(blocks)
+
+	filler := newSlotFiller(peers, 10, func(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+		lock.Lock()
+		fetched = append(fetched, from)
+		lock.Unlock()
+
+		return fetch(p, from, amount)
+	}, hashOfChain(blocks))
+
+	// the blocks up to 25 are downloaded before
+	filler.stored = func(from, amount uint64) []*types.Block {
+		if from > 25 {
+			return nil
+		}
+
+		end := from + amount
+		if end > 26 {
+			end = 26
+		}
+
+		return blocks[from:end]
+	}
+
+	filler.store = func(slot []*types.Block) {
+		lock.Lock()
+		stored = append(stored, slot[0].Number())
+		lock.Unlock()
+	}
+
+	var delivered []*types.Block
+
+	assert.NoError(t, filler.fill(1, 40, func(_ *SyncPeer, slot []*types.Block) error {
+		delivered = append(delivered, slot...)
+
+		return nil
+	}))
+
+	assert.Len(t, delivered, 40)
+
+	for i, block := range delivered {
+		assert.Equal(t, uint64(i+1), block.Number())
+	}
+
+	// only the remaining blocks are fetched, and stored
+	assert.ElementsMatch(t, []uint64{26, 31}, fetched)
+	assert.ElementsMatch(t, []uint64{26, 31}, stored)
+}
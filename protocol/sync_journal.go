@@ -0,0 +1,209 @@
+package protocol
+
+import (
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	"github.com/dogechain-lab/dogechain/protocol/proto"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// syncJournal persists the progression of a bulk sync, the highest header verified and
+// the slots of blocks downloaded ahead of the chain head, so that an interrupted sync is
+// resumed without finding the common ancestor and downloading the slots again
+type syncJournal struct {
+	logger hclog.Logger
+	chain  blockchainShim
+
+	lock     sync.Mutex
+	progress *storage.SyncProgress
+}
+
+func newSyncJournal(logger hclog.Logger, chain blockchainShim) *syncJournal {
+	j := &syncJournal{
+		logger:   logger,
+		chain:    chain,
+		progress: &storage.SyncProgress{},
+	}
+
+	if progress, ok := chain.ReadSyncProgress(); ok {
+		j.progress = progress
+	}
+
+	return j
+}
+
+// resume returns whether the progression of the interrupted sync is kept. It is only kept
+// if the chain of the peer holds its highest header, all the slots being below it, and
+// the slots already written to the chain are dropped
+func (j *syncJournal) resume(clt proto.V1Client, peerNumber uint64, head *types.Header) bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if len(j.progress.Slots) == 0 {
+		return false
+	}
+
+	if head.Number >= j.progress.Number || peerNumber < j.progress.Number {
+		j.reset()
+
+		return false
+	}
+
+	hash, err := getHeaderHash(clt, j.progress.Number)
+	if err != nil || hash != j.progress.Hash {
+		j.logger.Info("sync progression not on the chain of the peer", "number", j.progress.Number, "err", err)
+		j.reset()
+
+		return false
+	}
+
+	j.prune(head.Number)
+
+	j.logger.Info(
+		"resuming bulk sync",
+		"head", head.Number,
+		"highest", j.progress.Number,
+		"slots", len(j.progress.Slots),
+	)
+
+	return true
+}
+
+// fork returns the block stored following the head, if any, so that the head is the
+// common ancestor with the chain synced
+func (j *syncJournal) fork(head *types.Header) (*types.Header, bool) {
+	blocks := j.blocks(head.Number+1, 1)
+	if len(blocks) == 0 || blocks[0].ParentHash() != head.Hash {
+		return nil, false
+	}
+
+	return blocks[0].Header, true
+}
+
+// blocks returns the stored blocks linked to each other from the number, at most the amount
+func (j *syncJournal) blocks(from, amount uint64) []*types.Block {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	blocks := make([]*types.Block, 0)
+
+	for uint64(len(blocks)) < amount {
+		hash, ok := j.hashOf(from + uint64(len(blocks)))
+		if !ok {
+			break
+		}
+
+		header, ok := j.chain.GetHeaderByHash(hash)
+		if !ok {
+			break
+		}
+
+		body, ok := j.chain.GetBodyByHash(hash)
+		if !ok || body == nil {
+			break
+		}
+
+		if n := len(blocks); n > 0 && header.ParentHash != blocks[n-1].Hash() {
+			break
+		}
+
+		blocks = append(blocks, &types.Block{
+			Header:       header,
+			Transactions: body.Transactions,
+		})
+	}
+
+	return blocks
+}
+
+// store records the blocks downloaded, verified on the chain synced
+func (j *syncJournal) store(blocks []*types.Block) {
+	if len(blocks) == 0 {
+		return
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if _, ok := j.hashOf(blocks[0].Number()); ok {
+		return
+	}
+
+	slot := &storage.SyncSlot{
+		From:   blocks[0].Number(),
+		Hashes: make([]types.Hash, len(blocks)),
+	}
+
+	for i, block := range blocks {
+		slot.Hashes[i] = block.Hash()
+	}
+
+	j.progress.Slots = append(j.progress.Slots, slot)
+
+	if last := blocks[len(blocks)-1]; last.Number() > j.progress.Number {
+		j.progress.Number = last.Number()
+		j.progress.Hash = last.Hash()
+	}
+
+	j.write(blocks)
+}
+
+// written drops the slots written to the chain, up to the number
+func (j *syncJournal) written(number uint64) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.prune(number) {
+		j.write(nil)
+	}
+}
+
+// clear forgets the progression, once the sync is done or its blocks are invalid
+func (j *syncJournal) clear() {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.reset()
+	j.write(nil)
+}
+
+// hashOf returns the hash of the block of the number stored, if any. The lock must be held
+func (j *syncJournal) hashOf(number uint64) (types.Hash, bool) {
+	for _, slot := range j.progress.Slots {
+		if number >= slot.From && number < slot.From+uint64(len(slot.Hashes)) {
+			return slot.Hashes[number-slot.From], true
+		}
+	}
+
+	return types.ZeroHash, false
+}
+
+// prune drops the slots up to the number, and returns whether any is. The lock must be held
+func (j *syncJournal) prune(number uint64) bool {
+	slots := make([]*storage.SyncSlot, 0, len(j.progress.Slots))
+
+	for _, slot := range j.progress.Slots {
+		if slot.From+uint64(len(slot.Hashes)) > number+1 {
+			slots = append(slots, slot)
+		}
+	}
+
+	pruned := len(slots) != len(j.progress.Slots)
+	j.progress.Slots = slots
+
+	return pruned
+}
+
+// reset forgets the progression. The lock must be held
+func (j *syncJournal) reset() {
+	j.progress = &storage.SyncProgress{}
+}
+
+// write persists the progression, along with the blocks downloaded. The lock must be held
+func (j *syncJournal) write(blocks []*types.Block) {
+	if err := j.chain.WriteSyncProgress(j.progress, blocks); err != nil {
+		j.logger.Error("failed to write the sync progression", "err", err)
+	}
+}
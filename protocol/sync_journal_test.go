@@ -0,0 +1,96 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncJournal_Resume(t *testing.T) {
+	peerChain := newBodiesChain(50)
+	clt := &serviceClient{service: &serviceV1{logger: hclog.NewNullLogger(), store: peerChain}}
+
+	local := &mockBlockchain{blocks: append([]*types.Block{}, peerChain.blocks[:10]...)}
+	head := local.Header()
+
+	// the slots are downloaded out of order
+	journal := newSyncJournal(hclog.NewNullLogger(), local)
+	journal.store(peerChain.blocks[30:40])
+	journal.store(peerChain.blocks[10:20])
+
+	progress, ok := local.ReadSyncProgress()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(39), progress.Number)
+	assert.Equal(t, peerChain.blocks[39].Hash(), progress.Hash)
+	assert.Len(t, progress.Slots, 2)
+
+	// the progression is resumed once restarted
+	journal = newSyncJournal(hclog.NewNullLogger(), local)
+	assert.True(t, journal.resume(clt, 49, head))
+
+	fork, ok := journal.fork(head)
+	assert.True(t, ok)
+	assert.Equal(t, peerChain.blocks[10].Hash(), fork.Hash)
+
+	// the stored blocks end with the slot
+	blocks := journal.blocks(15, 10)
+	assert.Len(t, blocks, 5)
+	assert.Equal(t, peerChain.blocks[15].Hash(), blocks[0].Hash())
+	assert.Equal(t, peerChain.blocks[15].Transactions[0].Nonce, blocks[0].Transactions[0].Nonce)
+
+	assert.Empty(t, journal.blocks(20, 10))
+	assert.Len(t, journal.blocks(30, 10), 10)
+
+	// the slots written to the chain are dropped
+	journal.written(19)
+
+	progress, ok = local.ReadSyncProgress()
+	assert.True(t, ok)
+	assert.Len(t, progress.Slots, 1)
+	assert.Equal(t, uint64(30), progress.Slots[0].From)
+
+	journal.clear()
+
+	_, ok = local.ReadSyncProgress()
+	assert.False(t, ok)
+}
+
+func TestSyncJournal_ResumeOtherChain(t *testing.T) {
+	peerChain := newBodiesChain(50)
+	local := &mockBlockchain{blocks: append([]*types.Block{}, peerChain.blocks[:10]...)}
+	head := local.Header()
+
+	newJournal := func() *syncJournal {
+		journal := newSyncJournal(hclog.NewNullLogger(), local)
+		journal.store(peerChain.blocks[10:20])
+
+		return newSyncJournal(hclog.NewNullLogger(), local)
+	}
+
+	headers := make([]*types.Header, 16)
+	for i, block := range peerChain.blocks[:16] {
+		headers[i] = block.Header
+	}
+
+	// the peer forked below the highest header
+	forkedChain := NewMockBlockchain(blockchain.AppendNewTestheadersWithSeed(headers, 34, 1))
+	forkedClt := &serviceClient{service: &serviceV1{logger: hclog.NewNullLogger(), store: forkedChain}}
+
+	journal := newJournal()
+	assert.False(t, journal.resume(forkedClt, 49, head))
+	assert.Empty(t, journal.blocks(10, 10))
+
+	// the peer is behind the highest header
+	clt := &serviceClient{service: &serviceV1{logger: hclog.NewNullLogger(), store: peerChain}}
+
+	journal = newJournal()
+	assert.False(t, journal.resume(clt, 15, head))
+	assert.Equal(t, 0, clt.headerRequests)
+
+	// the head is beyond the highest header
+	journal = newJournal()
+	assert.False(t, journal.resume(clt, 49, peerChain.blocks[25].Header))
+}
@@ -74,6 +74,18 @@ type SyncPeer struct {
 	enqueueLock sync.Mutex
 	enqueue     minNumBlockQueue
 	enqueueCh   chan struct{}
+
+	// checkpointChecked is 1 once the chain of the peer is known to contain the checkpoint
+	checkpointChecked uint32
+}
+
+// NewStatusSyncPeer creates a peer which is not connected to any node,
+// only reporting the given status. It is meant for in-memory syncers
+func NewStatusSyncPeer(status *Status) *SyncPeer {
+	return &SyncPeer{
+		status:    status,
+		enqueueCh: make(chan struct{}),
+	}
 }
 
 // Number returns the latest peer block height
@@ -19,8 +19,6 @@ import (
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p-core/peer"
-	grpccodes "google.golang.org/grpc/codes"
-	grpcstatus "google.golang.org/grpc/status"
 	anypb "google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -76,6 +74,23 @@ type Syncer struct {
 	server *network.Server
 
 	syncProgression *progress.ProgressionWrapper
+
+	// fastSync downloads the state of a pivot block, if enabled
+	fastSync *fastSync
+
+	// scores of the sync peers, the banned ones not synced from
+	scores *peerScores
+
+	// checkpoint the synced chain must contain, if any
+	checkpoint *Checkpoint
+	// checkpointLink proves the blocks synced are ancestors of the checkpoint
+	checkpointLink *checkpointLink
+
+	// requestTimeout is the deadline of the requests to the sync peers
+	requestTimeout time.Duration
+
+	// events dispatches the syncer events to the subscribers
+	events *syncEventStream
 }
 
 // NewSyncer creates a new Syncer instance
@@ -87,8 +102,12 @@ func NewSyncer(logger hclog.Logger, server *network.Server, blockchain blockchai
 		server:          server,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
 		peers:           cmap.NewConcurrentMap(),
+		requestTimeout:  defaultRequestTimeout,
+		events:          newSyncEventStream(),
 	}
 
+	s.scores = newPeerScores(s.logger)
+
 	return s
 }
 
@@ -172,6 +191,12 @@ func (s *Syncer) enqueueBlock(peerID peer.ID, b *types.Block) {
 	}
 
 	syncPeer.appendBlock(b)
+
+	s.events.push(&SyncEvent{
+		Type:  SyncEventNewBlock,
+		Peer:  peerID,
+		Block: b,
+	})
 }
 
 func (s *Syncer) updatePeerStatus(peerID peer.ID, status *Status) {
@@ -324,13 +349,17 @@ func (s *Syncer) handlePeerEvent() {
 	}()
 }
 
-// BestPeer returns the best peer by difficulty (if any)
+// BestPeer returns the best peer ahead of the local chain (if any), the highest scoring
+// one first then the highest block. The banned peers are skipped
 func (s *Syncer) BestPeer() *SyncPeer {
 	var (
 		bestPeer        *SyncPeer
+		bestScore       int64
 		bestBlockNumber uint64
 	)
 
+	localNumber := s.blockchain.Header().Number
+
 	s.peers.Range(func(peerID, peer interface{}) bool {
 		syncPeer, ok := peer.(*SyncPeer)
 		if !ok {
@@ -338,18 +367,23 @@ func (s *Syncer) BestPeer() *SyncPeer {
 		}
 
 		peerBlockNumber := syncPeer.Number()
-		if bestPeer == nil || peerBlockNumber > bestBlockNumber {
+		if peerBlockNumber <= localNumber || s.scores.banned(syncPeer.peer) {
+			return true
+		}
+
+		score := s.scores.score(syncPeer.peer)
+
+		if bestPeer == nil ||
+			score > bestScore ||
+			(score == bestScore && peerBlockNumber > bestBlockNumber) {
 			bestPeer = syncPeer
+			bestScore = score
 			bestBlockNumber = peerBlockNumber
 		}
 
 		return true
 	})
 
-	if bestBlockNumber <= s.blockchain.Header().Number {
-		bestPeer = nil
-	}
-
 	return bestPeer
 }
 
@@ -368,7 +402,7 @@ func (s *Syncer) AddPeer(peerID peer.ID) error {
 	conn := libp2pGrpc.WrapClient(stream)
 
 	// watch for changes of the other node first
-	clt := proto.NewV1Client(conn)
+	clt := newTimeoutClient(proto.NewV1Client(conn), s.requestTimeout)
 
 	rawStatus, err := clt.GetCurrent(context.Background(), &emptypb.Empty{})
 	if err != nil {
@@ -390,6 +424,11 @@ func (s *Syncer) AddPeer(peerID peer.ID) error {
 		enqueueCh: make(chan struct{}),
 	})
 
+	s.events.push(&SyncEvent{
+		Type: SyncEventPeerConnected,
+		Peer: peerID,
+	})
+
 	return nil
 }
 
@@ -407,24 +446,111 @@ func (s *Syncer) DeletePeer(peerID peer.ID) error {
 		}
 
 		close(syncPeer.enqueueCh)
+
+		s.events.push(&SyncEvent{
+			Type: SyncEventPeerDisconnected,
+			Peer: peerID,
+		})
 	}
 
+	s.scores.remove(peerID)
+
 	return nil
 }
 
-// findCommonAncestor returns the common ancestor header and fork
+// findCommonAncestor returns the common ancestor header and fork. The latest headers are
+// requested backward at once, resolving the shallow reorgs in a single request, the
+// ancestor of a deeper reorg is then binary searched below them
 func (s *Syncer) findCommonAncestor(clt proto.V1Client, status *Status) (*types.Header, *types.Header, error) {
 	h := s.blockchain.Header()
 
-	min := uint64(0) // genesis
-	max := h.Number
-
-	targetHeight := status.Number
+	max := h.Number // the highest number both chains have
 
-	if heightNumber := targetHeight; max > heightNumber {
+	if heightNumber := status.Number; max > heightNumber {
 		max = heightNumber
 	}
 
+	header, fork, lowest, err := s.findAncestorBackward(clt, max)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if header == nil && lowest > 0 {
+		if header, err = s.searchAncestor(clt, 0, lowest-1); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if header == nil {
+		return nil, nil, ErrCommonAncestorNotFound
+	}
+
+	if fork != nil {
+		return header, fork, nil
+	}
+
+	// get the block fork
+	forkNum := header.Number + 1
+	fork, err = getHeader(clt, &forkNum, nil)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get fork at num %d", header.Number)
+	}
+
+	if fork == nil {
+		return nil, nil, ErrForkNotFound
+	}
+
+	return header, fork, nil
+}
+
+// findAncestorBackward requests the headers of the peer backward from the number, and returns
+// the highest one of the local chain along with the following one, the fork. The lowest number
+// requested is returned if none of them is of the local chain
+func (s *Syncer) findAncestorBackward(
+	clt proto.V1Client,
+	from uint64,
+) (*types.Header, *types.Header, uint64, error) {
+	if from == 0 {
+		// our common ancestor is the genesis
+		genesis, ok := s.blockchain.GetHeaderByNumber(0)
+		if !ok {
+			return nil, nil, 0, ErrLoadLocalGenesisFailed
+		}
+
+		return genesis, nil, 0, nil
+	}
+
+	headers, err := getHeadersBackward(clt, from, maxAncestorHeadersAmount)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var fork *types.Header
+
+	for _, found := range headers {
+		expectedHeader, ok := s.blockchain.GetHeaderByNumber(found.Number)
+		if !ok {
+			return nil, nil, 0, fmt.Errorf("cannot find the header %d in local chain", found.Number)
+		}
+
+		if expectedHeader.Hash == found.Hash {
+			return found, fork, 0, nil
+		}
+
+		if found.Number == 0 {
+			return nil, nil, 0, ErrMismatchGenesis
+		}
+
+		fork = found
+	}
+
+	return nil, nil, from + 1 - uint64(len(headers)), nil
+}
+
+// searchAncestor binary searches the highest header of the peer of the local chain,
+// between the min and max numbers
+func (s *Syncer) searchAncestor(clt proto.V1Client, min, max uint64) (*types.Header, error) {
 	var header *types.Header
 
 	for min <= max {
@@ -434,7 +560,7 @@ func (s *Syncer) findCommonAncestor(clt proto.V1Client, status *Status) (*types.
 			// our common ancestor is the genesis
 			genesis, ok := s.blockchain.GetHeaderByNumber(0)
 			if !ok {
-				return nil, nil, ErrLoadLocalGenesisFailed
+				return nil, ErrLoadLocalGenesisFailed
 			}
 
 			header = genesis
@@ -444,7 +570,7 @@ func (s *Syncer) findCommonAncestor(clt proto.V1Client, status *Status) (*types.
 
 		found, err := getHeader(clt, &m, nil)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		if found == nil {
@@ -453,37 +579,18 @@ func (s *Syncer) findCommonAncestor(clt proto.V1Client, status *Status) (*types.
 		} else {
 			expectedHeader, ok := s.blockchain.GetHeaderByNumber(m)
 			if !ok {
-				return nil, nil, fmt.Errorf("cannot find the header %d in local chain", m)
+				return nil, fmt.Errorf("cannot find the header %d in local chain", m)
 			}
 			if expectedHeader.Hash == found.Hash {
 				header = found
 				min = m + 1
 			} else {
-				if m == 0 {
-					return nil, nil, ErrMismatchGenesis
-				}
 				max = m - 1
 			}
 		}
 	}
 
-	if header == nil {
-		return nil, nil, ErrCommonAncestorNotFound
-	}
-
-	// get the block fork
-	forkNum := header.Number + 1
-	fork, err := getHeader(clt, &forkNum, nil)
-
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get fork at num %d", header.Number)
-	}
-
-	if fork == nil {
-		return nil, nil, ErrForkNotFound
-	}
-
-	return header, fork, nil
+	return header, nil
 }
 
 // WatchSyncWithPeer subscribes and adds peer's latest block
@@ -512,8 +619,9 @@ func (s *Syncer) WatchSyncWithPeer(
 			break
 		}
 
-		if err := s.blockchain.VerifyFinalizedBlock(b); err != nil {
+		if err := s.verifyBlock(b); err != nil {
 			s.logger.Error("unable to verify block, %w", err)
+			s.invalidBlock(p.peer, err)
 
 			return
 		}
@@ -546,16 +654,77 @@ func (s *Syncer) logSyncPeerPopBlockError(err error, peer *SyncPeer) {
 // BulkSyncWithPeer finds common ancestor with a peer and syncs block until latest block
 // Only missing blocks are synced up to the peer's highest block number
 func (s *Syncer) BulkSyncWithPeer(p *SyncPeer, newBlockHandler func(block *types.Block)) error {
-	// find the common ancestor
-	ancestor, fork, err := s.findCommonAncestor(p.client, p.status)
-	if err != nil {
-		// No need to sync with this peer
+	s.events.push(&SyncEvent{
+		Type:   SyncEventBulkSyncStarted,
+		Peer:   p.peer,
+		Number: s.blockchain.Header().Number,
+	})
+
+	err := s.bulkSyncWithPeer(p, newBlockHandler)
+
+	s.events.push(&SyncEvent{
+		Type:   SyncEventBulkSyncFinished,
+		Peer:   p.peer,
+		Number: s.blockchain.Header().Number,
+		Err:    err,
+	})
+
+	return err
+}
+
+func (s *Syncer) bulkSyncWithPeer(p *SyncPeer, newBlockHandler func(block *types.Block)) error {
+	if err := s.checkPeerCheckpoint(p); err != nil {
 		return err
 	}
 
+	if err := s.proveCheckpointLink(p); err != nil {
+		s.logger.Warn("failed to prove the link to the checkpoint, verifying the seals", "err", err)
+	}
+
+	if s.fastSync != nil {
+		if err := s.fastSyncWithPeer(p, newBlockHandler); err != nil {
+			return fmt.Errorf("failed to fast sync, %w", err)
+		}
+	}
+
+	journal := newSyncJournal(s.logger, s.blockchain)
+
+	var (
+		ancestor, fork *types.Header
+		err            error
+	)
+
+	// resume the interrupted sync from the local head if the blocks downloaded follow it,
+	// otherwise find the common ancestor
+	if head := s.blockchain.Header(); journal.resume(p.client, p.Number(), head) {
+		if forkHeader, ok := journal.fork(head); ok {
+			ancestor, fork = head, forkHeader
+		}
+	}
+
+	if ancestor == nil {
+		ancestor, fork, err = s.findCommonAncestor(p.client, p.status)
+		if err != nil {
+			if errors.Is(err, errNilHeaderResponse) || isTimeout(err) {
+				s.scores.failed(p.peer, err)
+			}
+
+			// No need to sync with this peer
+			return err
+		}
+	}
+
 	// find in batches
 	s.logger.Debug("fork found", "ancestor", ancestor.Number)
 
+	if ancestor.Number < s.blockchain.Header().Number {
+		s.events.push(&SyncEvent{
+			Type:   SyncEventFork,
+			Peer:   p.peer,
+			Number: ancestor.Number,
+		})
+	}
+
 	startBlock := fork
 
 	var (
@@ -581,13 +750,14 @@ func (s *Syncer) BulkSyncWithPeer(p *SyncPeer, newBlockHandler func(block *types
 		target := p.status.Number
 
 		s.syncProgression.UpdateHighestProgression(target)
+		s.syncProgression.UpdateSyncPeers(uint64(s.peers.Len()))
 
 		if target == lastTarget {
 			// there are no more changes to pull for now
 			break
 		}
 
-		for {
+		for currentSyncHeight <= target {
 			s.logger.Debug(
 				"sync up to block",
 				"from",
@@ -596,59 +766,123 @@ func (s *Syncer) BulkSyncWithPeer(p *SyncPeer, newBlockHandler func(block *types
 				target,
 			)
 
-			// Create the base request skeleton
-			sk := &skeleton{
-				amount: blockAmount,
-			}
+			// Fetch the slots of blocks concurrently from the peers, the sync one first
+			filler := newSlotFiller(
+				s.syncPeers(p),
+				uint64(blockAmount),
+				s.fetchSlot,
+				func(number uint64) (types.Hash, error) {
+					return getHeaderHash(p.client, number)
+				},
+			)
+			filler.stored = journal.blocks
+			filler.store = journal.store
+
+			err := filler.fill(currentSyncHeight, target, func(from *SyncPeer, blocks []*types.Block) error {
+				// increase block amount when succeeded
+				blockAmount++
+				if blockAmount > maxSkeletonHeadersAmount {
+					blockAmount = maxSkeletonHeadersAmount
+				}
 
-			// Fetch the blocks from the peer
-			if err := sk.getBlocksFromPeer(p.client, currentSyncHeight); err != nil {
-				if rpcErr, ok := grpcstatus.FromError(err); ok {
-					// the data size exceeds grpc server/client message size
-					if rpcErr.Code() == grpccodes.ResourceExhausted {
-						blockAmount /= 2
+				// Verify and write the data locally
+				for _, block := range blocks {
+					if err := s.verifyBlock(block); err != nil {
+						s.invalidBlock(from.peer, err)
+						journal.clear()
 
-						continue
+						return fmt.Errorf("unable to verify block, %w", err)
 					}
-				}
 
-				return fmt.Errorf("unable to fetch blocks from peer, %w", err)
-			}
-
-			// increase block amount when succeeded
-			blockAmount++
-			if blockAmount > maxSkeletonHeadersAmount {
-				blockAmount = maxSkeletonHeadersAmount
-			}
+					if err := s.blockchain.WriteBlock(block); err != nil {
+						return fmt.Errorf("failed to write block while bulk syncing: %w", err)
+					}
 
-			// Verify and write the data locally
-			for _, block := range sk.blocks {
-				if err := s.blockchain.VerifyFinalizedBlock(block); err != nil {
-					return fmt.Errorf("unable to verify block, %w", err)
+					newBlockHandler(block)
+					// prune the peers' enqueued block
+					s.prunePeerEnqueuedBlocks(block)
+					currentSyncHeight++
 				}
 
-				if err := s.blockchain.WriteBlock(block); err != nil {
-					return fmt.Errorf("failed to write block while bulk syncing: %w", err)
-				}
+				journal.written(currentSyncHeight - 1)
 
-				newBlockHandler(block)
-				// prune the peers' enqueued block
-				s.prunePeerEnqueuedBlocks(block)
-				currentSyncHeight++
+				return nil
+			})
+
+			// the data size exceeds grpc server/client message size
+			if isResourceExhausted(err) && blockAmount > 1 {
+				blockAmount /= 2
+
+				continue
 			}
 
-			if currentSyncHeight >= target {
-				// Target has been reached
-				break
+			if err != nil {
+				return err
 			}
 		}
 
 		lastTarget = target
 	}
 
+	journal.clear()
+
 	return nil
 }
 
+// invalidBlock records the block of the peer failing the verification, the peer
+// is banned if its chain doesn't contain the checkpoint
+func (s *Syncer) invalidBlock(id peer.ID, err error) {
+	if errors.Is(err, ErrCheckpointMismatch) {
+		s.scores.ban(id)
+
+		return
+	}
+
+	s.scores.invalidBlock(id)
+}
+
+// fetchSlot fetches the blocks of a slot from the peer
+func (s *Syncer) fetchSlot(p *SyncPeer, from, amount uint64) ([]*types.Block, error) {
+	sk := &skeleton{
+		amount: int64(amount),
+		local:  s.blockchain.GetBodyByHash,
+		bad:    s.blockchain.IsBadBlock,
+	}
+
+	start := time.Now()
+	err := sk.getBlocksFromPeer(p.client, from)
+
+	s.syncProgression.AddPulled(sk.pulledHeaders, sk.pulledBodies)
+
+	switch {
+	case errors.Is(err, blockchain.ErrKnownBadBlock):
+		s.scores.invalidBlock(p.peer)
+	case err != nil:
+		// an oversized response is not the fault of the peer
+		if !isResourceExhausted(err) {
+			s.scores.failed(p.peer, err)
+		}
+	default:
+		s.scores.served(p.peer, len(sk.blocks), time.Since(start))
+	}
+
+	return sk.blocks, err
+}
+
+// getHeaderHash returns the hash of the header of the number of the peer
+func getHeaderHash(clt proto.V1Client, number uint64) (types.Hash, error) {
+	header, err := getHeader(clt, &number, nil)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if header == nil {
+		return types.ZeroHash, errNilHeaderResponse
+	}
+
+	return header.Hash, nil
+}
+
 func getHeader(clt proto.V1Client, num *uint64, hash *types.Hash) (*types.Header, error) {
 	req := &proto.GetHeadersRequest{}
 	if num != nil {
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
 	"github.com/dogechain-lab/dogechain/helper/tests"
 	"github.com/dogechain-lab/dogechain/network"
 	"github.com/dogechain-lab/dogechain/protocol/proto"
@@ -269,6 +270,81 @@ func TestFindCommonAncestor(t *testing.T) {
 	}
 }
 
+func TestFindCommonAncestor_Reorg(t *testing.T) {
+	tests := []struct {
+		name          string
+		syncerHeaders []*types.Header
+		peerHeaders   []*types.Header
+		// result
+		headerIndex int
+		err         error
+		// the maximum number of the headers requests
+		maxRequests int
+	}{
+		{
+			name:          "shallow reorg",
+			syncerHeaders: blockchain.NewTestHeadersWithSeed(nil, 1000, 0),
+			peerHeaders: blockchain.AppendNewTestheadersWithSeed(
+				blockchain.NewTestHeadersWithSeed(nil, 990, 0),
+				20,
+				1,
+			),
+			headerIndex: 989,
+			maxRequests: 1,
+		},
+		{
+			name:          "deep reorg",
+			syncerHeaders: blockchain.NewTestHeadersWithSeed(nil, 1000, 0),
+			peerHeaders: blockchain.AppendNewTestheadersWithSeed(
+				blockchain.NewTestHeadersWithSeed(nil, 300, 0),
+				800,
+				1,
+			),
+			headerIndex: 299,
+			// the backward headers, the binary search and the fork
+			maxRequests: 1 + 10 + 1,
+		},
+		{
+			name:          "peer behind",
+			syncerHeaders: blockchain.NewTestHeadersWithSeed(nil, 1000, 0),
+			peerHeaders: blockchain.AppendNewTestheadersWithSeed(
+				blockchain.NewTestHeadersWithSeed(nil, 500, 0),
+				100,
+				1,
+			),
+			headerIndex: 499,
+			maxRequests: 1 + 10 + 1,
+		},
+		{
+			name:          "genesis mismatch",
+			syncerHeaders: blockchain.NewTestHeadersWithSeed(nil, 20, 0),
+			peerHeaders:   blockchain.NewTestHeadersWithSeed(nil, 30, 1),
+			err:           ErrMismatchGenesis,
+			maxRequests:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncer := NewSyncer(hclog.NewNullLogger(), nil, NewMockBlockchain(tt.syncerHeaders))
+			clt := &serviceClient{
+				service: &serviceV1{logger: hclog.NewNullLogger(), store: NewMockBlockchain(tt.peerHeaders)},
+			}
+
+			header, fork, err := syncer.findCommonAncestor(clt, HeaderToStatus(tt.peerHeaders[len(tt.peerHeaders)-1]))
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.peerHeaders[tt.headerIndex], header)
+				assert.Equal(t, tt.peerHeaders[tt.headerIndex+1], fork)
+			}
+
+			assert.LessOrEqual(t, clt.headerRequests, tt.maxRequests)
+		})
+	}
+}
+
 func TestWatchSyncWithPeer(t *testing.T) {
 	tests := []*struct {
 		name           string
@@ -599,6 +675,10 @@ func (m *mockBlockStore) GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
 	return nil, nil
 }
 
+func (m *mockBlockStore) GetCompressedReceiptsByHash(types.Hash) ([]byte, error) {
+	return nil, storage.ErrNotFound
+}
+
 func (m *mockBlockStore) GetHeaderByHash(hash types.Hash) (*types.Header, bool) {
 	for _, b := range m.blocks {
 		header := b.Header.ComputeHash()
@@ -616,7 +696,7 @@ func (m *mockBlockStore) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
 		}
 	}
 
-	return nil, true
+	return nil, false
 }
 
 func (m *mockBlockStore) WriteBlocks(blocks []*types.Block) error {
@@ -636,10 +716,30 @@ func (m *mockBlockStore) WriteBlock(block *types.Block) error {
 	return nil
 }
 
+func (m *mockBlockStore) WriteBlockWithReceipts(block *types.Block, receipts []*types.Receipt) error {
+	return m.WriteBlock(block)
+}
+
 func (m *mockBlockStore) VerifyFinalizedBlock(block *types.Block) error {
 	return nil
 }
 
+func (m *mockBlockStore) VerifyTrustedBlock(block *types.Block) error {
+	return nil
+}
+
+func (m *mockBlockStore) IsBadBlock(hash types.Hash) bool {
+	return false
+}
+
+func (m *mockBlockStore) ReadSyncProgress() (*storage.SyncProgress, bool) {
+	return nil, false
+}
+
+func (m *mockBlockStore) WriteSyncProgress(progress *storage.SyncProgress, blocks []*types.Block) error {
+	return nil
+}
+
 func (m *mockBlockStore) CurrentTD() *big.Int {
 	return m.td
 }
@@ -9,9 +9,11 @@ import (
 	"time"
 
 	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
 	"github.com/dogechain-lab/dogechain/helper/tests"
 	"github.com/dogechain-lab/dogechain/network"
 	"github.com/dogechain-lab/dogechain/types"
+	"github.com/golang/snappy"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/assert"
@@ -232,6 +234,13 @@ func HeaderToStatus(h *types.Header) *Status {
 type mockBlockchain struct {
 	blocks        []*types.Block
 	subscriptions []*mockSubscription
+
+	// the receipts of the blocks, by hash
+	receipts map[types.Hash][]*types.Receipt
+
+	// the sync progression, and the blocks stored along with it
+	syncProgress *storage.SyncProgress
+	syncBlocks   map[types.Hash]*types.Block
 }
 
 func (b *mockBlockchain) CalculateGasLimit(number uint64) (uint64, error) {
@@ -289,8 +298,27 @@ func (b *mockBlockchain) GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
 	panic("not implement")
 }
 
-func (b *mockBlockchain) GetBodyByHash(types.Hash) (*types.Body, bool) {
-	return &types.Body{}, true
+func (b *mockBlockchain) GetCompressedReceiptsByHash(h types.Hash) ([]byte, error) {
+	receipts, ok := b.receipts[h]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+
+	return snappy.Encode(nil, types.Receipts(receipts).MarshalStoreRLPTo(nil)), nil
+}
+
+func (b *mockBlockchain) GetBodyByHash(h types.Hash) (*types.Body, bool) {
+	for _, block := range b.blocks {
+		if block.Hash() == h {
+			return block.Body(), true
+		}
+	}
+
+	if block, ok := b.syncBlocks[h]; ok {
+		return block.Body(), true
+	}
+
+	return nil, false
 }
 
 func (b *mockBlockchain) GetHeaderByHash(h types.Hash) (*types.Header, bool) {
@@ -300,6 +328,10 @@ func (b *mockBlockchain) GetHeaderByHash(h types.Hash) (*types.Header, bool) {
 		}
 	}
 
+	if block, ok := b.syncBlocks[h]; ok {
+		return block.Header, true
+	}
+
 	return nil, false
 }
 
@@ -322,10 +354,49 @@ func (b *mockBlockchain) WriteBlock(block *types.Block) error {
 	return nil
 }
 
+func (b *mockBlockchain) WriteBlockWithReceipts(block *types.Block, receipts []*types.Receipt) error {
+	return b.WriteBlock(block)
+}
+
 func (b *mockBlockchain) VerifyFinalizedBlock(block *types.Block) error {
 	return nil
 }
 
+func (b *mockBlockchain) VerifyTrustedBlock(block *types.Block) error {
+	return nil
+}
+
+func (b *mockBlockchain) IsBadBlock(hash types.Hash) bool {
+	return false
+}
+
+func (b *mockBlockchain) ReadSyncProgress() (*storage.SyncProgress, bool) {
+	if b.syncProgress == nil || len(b.syncProgress.Slots) == 0 {
+		return nil, false
+	}
+
+	return b.syncProgress, true
+}
+
+func (b *mockBlockchain) WriteSyncProgress(progress *storage.SyncProgress, blocks []*types.Block) error {
+	if b.syncBlocks == nil {
+		b.syncBlocks = make(map[types.Hash]*types.Block)
+	}
+
+	for _, block := range blocks {
+		b.syncBlocks[block.Hash()] = block
+	}
+
+	// keep a copy, the progression being updated after it is written
+	b.syncProgress = &storage.SyncProgress{
+		Number: progress.Number,
+		Hash:   progress.Hash,
+		Slots:  append([]*storage.SyncSlot{}, progress.Slots...),
+	}
+
+	return nil
+}
+
 func (b *mockBlockchain) WriteBlocks(blocks []*types.Block) error {
 	for _, block := range blocks {
 		if writeErr := b.WriteBlock(block); writeErr != nil {
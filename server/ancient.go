@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	"github.com/dogechain-lab/dogechain/blockchain/storage/kvstorage"
+	"github.com/hashicorp/go-hclog"
+)
+
+// MinAncientBlocks is the minimum number of the latest blocks kept in the hot store,
+// covering the reorganizations
+const MinAncientBlocks uint64 = 128
+
+// DefaultAncientBlocks is the default number of the latest blocks kept in the hot store
+const DefaultAncientBlocks uint64 = 90000
+
+// ancientPathFile is the file of the data directory recording the path of its ancient store,
+// so that the offline commands find it without it being configured
+const ancientPathFile = "ancient.path"
+
+var ErrAncientBlocks = errors.New("too few blocks kept in the hot store")
+
+// ancientDir returns the path of the ancient store of the data directory, empty if it has none.
+// A configured path is recorded in the data directory, so that it may be moved by configuring
+// its new path once
+func ancientDir(config *Config) (string, error) {
+	record := filepath.Join(config.DataDir, ancientPathFile)
+
+	if config.AncientDir == "" {
+		data, err := os.ReadFile(record)
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		} else if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	path, err := filepath.Abs(config.AncientDir)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(config.DataDir, 0755); err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(record, []byte(path+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to record the ancient store path, %w", err)
+	}
+
+	return path, nil
+}
+
+// newChainStorageBuilder returns the builder of the blockchain storage of the data directory,
+// tiered with its ancient store if it has one
+func newChainStorageBuilder(logger hclog.Logger, config *Config) (storage.StorageBuilder, error) {
+	dbBuilder, err := newDBBuilder(logger, config, filepath.Join(config.DataDir, "blockchain"))
+	if err != nil {
+		return nil, err
+	}
+
+	ancient, err := ancientDir(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if ancient == "" {
+		return kvstorage.NewDatabaseStorageBuilder(logger, dbBuilder), nil
+	}
+
+	ancientBuilder, err := newDBBuilder(logger, config, ancient)
+	if err != nil {
+		return nil, err
+	}
+
+	return kvstorage.NewTieredStorageBuilder(logger, dbBuilder, ancientBuilder), nil
+}
+
+// ancientFreezer moves the old blocks to the ancient store in the background,
+// on startup then on every new head
+type ancientFreezer struct {
+	logger     hclog.Logger
+	blockchain *blockchain.Blockchain
+	blocks     uint64
+
+	sub    blockchain.Subscription
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newAncientFreezer(logger hclog.Logger, chain *blockchain.Blockchain, blocks uint64) *ancientFreezer {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &ancientFreezer{
+		logger:     logger,
+		blockchain: chain,
+		blocks:     blocks,
+		sub:        chain.SubscribeEvents(),
+		ctx:        ctx,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+}
+
+// run freezes the old blocks until the freezer is closed
+func (f *ancientFreezer) run() {
+	defer close(f.done)
+
+	for {
+		start := time.Now()
+
+		frozen, err := f.blockchain.FreezeAncients(f.ctx, f.blocks)
+		if err != nil {
+			f.logger.Error("failed to freeze ancient blocks", "frozen", frozen, "err", err)
+		} else if frozen > 1 {
+			f.logger.Info("ancient blocks frozen", "blocks", frozen, "elapsed", time.Since(start))
+		}
+
+		if f.sub.GetEvent() == nil {
+			return
+		}
+	}
+}
+
+// Close stops the freezer, waiting for the running freeze to record its progress
+func (f *ancientFreezer) Close() {
+	f.cancel()
+	f.sub.Close()
+	<-f.done
+}
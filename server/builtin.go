@@ -1,6 +1,7 @@
 package server
 
 import (
+	"github.com/dogechain-lab/dogechain/chain"
 	"github.com/dogechain-lab/dogechain/consensus"
 	consensusDev "github.com/dogechain-lab/dogechain/consensus/dev"
 	consensusDummy "github.com/dogechain-lab/dogechain/consensus/dummy"
@@ -9,6 +10,8 @@ import (
 	"github.com/dogechain-lab/dogechain/secrets/awsssm"
 	"github.com/dogechain-lab/dogechain/secrets/hashicorpvault"
 	"github.com/dogechain-lab/dogechain/secrets/local"
+	"github.com/dogechain-lab/dogechain/state/runtime/stateful"
+	"github.com/dogechain-lab/dogechain/types"
 )
 
 type ConsensusType string
@@ -38,3 +41,37 @@ func ConsensusSupported(value string) bool {
 
 	return ok
 }
+
+// statefulPrecompile is a chain-specific stateful precompiled contract,
+// activated at the fork of the chain returned by its fork getter
+type statefulPrecompile struct {
+	address  types.Address
+	fork     func(forks *chain.Forks) *chain.Fork
+	contract stateful.Contract
+}
+
+// statefulPrecompiles are the chain-specific stateful precompiled contracts run by the node.
+// A contract whose fork is not scheduled by the chain is not registered
+var statefulPrecompiles = []statefulPrecompile{}
+
+// newStatefulRegistry creates the registry of the stateful precompiled contracts of the chain
+func newStatefulRegistry(forks *chain.Forks) (*stateful.Registry, error) {
+	registry := stateful.NewRegistry()
+
+	if forks == nil {
+		return registry, nil
+	}
+
+	for _, p := range statefulPrecompiles {
+		fork := p.fork(forks)
+		if fork == nil {
+			continue
+		}
+
+		if err := registry.Register(p.address, *fork, p.contract); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
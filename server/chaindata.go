@@ -0,0 +1,117 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dogechain-lab/dogechain/archive"
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/helper/progress"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// importLogBlocks is the number of blocks imported between the progress logs
+const importLogBlocks = 10000
+
+// ImportResult is the outcome of a chain import
+type ImportResult struct {
+	Format string `json:"format"`
+	From   uint64 `json:"from"`
+	To     uint64 `json:"to"`
+	Blocks uint64 `json:"blocks"`
+}
+
+// ExportChain writes the blocks of the chain of the data directory to a new file, offline.
+// The range ends at the head of the chain when to is not set.
+func ExportChain(config *Config, from uint64, to *uint64, path string) (*archive.ExportResult, error) {
+	if _, err := os.Stat(filepath.Join(config.DataDir, "blockchain")); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoChainData, config.DataDir)
+	}
+
+	logger, err := newLoggerFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// the genesis state is written off the data directory, which is only read
+	chain, err := newOfflineChain(config, hclog.NewNullLogger(), func(storage itrie.Storage) itrie.Storage {
+		return newReplayStorage(storage)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defer chain.Close()
+
+	last := chain.blockchain.Header().Number
+	if to != nil {
+		last = *to
+	}
+
+	return archive.ExportChain(chain.blockchain, logger.Named("export"), from, last, path)
+}
+
+// ImportChain verifies and writes the blocks of the chain file to the chain of the data directory,
+// which is created if missing, offline. The blocks already in the chain are skipped.
+func ImportChain(config *Config, path string) (*ImportResult, error) {
+	logger, err := newLoggerFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SetupDataDir(config.DataDir, dirPaths); err != nil {
+		return nil, err
+	}
+
+	chain, err := newOfflineChain(config, logger, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer chain.Close()
+
+	if err := chain.consensus.Initialize(); err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		Format: archive.FormatOf(path),
+		From:   chain.blockchain.Header().Number,
+	}
+
+	importer := &loggedImporter{
+		Blockchain: chain.blockchain,
+		logger:     logger.Named("import"),
+		result:     result,
+	}
+
+	err = archive.RestoreChain(importer, path, progress.NewProgressionWrapper(progress.ChainSyncRestore))
+	result.To = chain.blockchain.Header().Number
+
+	return result, err
+}
+
+// loggedImporter counts and logs the blocks written by the import
+type loggedImporter struct {
+	*blockchain.Blockchain
+
+	logger hclog.Logger
+	result *ImportResult
+}
+
+func (i *loggedImporter) WriteBlock(block *types.Block) error {
+	if err := i.Blockchain.WriteBlock(block); err != nil {
+		return err
+	}
+
+	i.result.Blocks++
+
+	if i.result.Blocks%importLogBlocks == 0 {
+		i.logger.Info("importing blocks", "block", block.Number())
+	}
+
+	return nil
+}
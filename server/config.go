@@ -2,12 +2,16 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/jsonrpc"
 	"github.com/dogechain-lab/dogechain/network"
+	"github.com/dogechain-lab/dogechain/protocol"
 	"github.com/dogechain-lab/dogechain/secrets"
+	"github.com/dogechain-lab/dogechain/types"
 )
 
 const DefaultGRPCPort int = 9632
@@ -27,9 +31,31 @@ type Config struct {
 
 	PriceLimit            uint64
 	MaxSlots              uint64
+	MaxAccountEnqueued    uint64
+	MaxAccountSlots       uint64
 	BlockTime             uint64
 	PruneTickSeconds      uint64
 	PromoteOutdateSeconds uint64
+	TxLifetimeSeconds     uint64
+	PriceBump             uint64
+	TxPoolJournal         string
+	RebroadcastBlocks     uint64
+	MaxTxSize             uint64
+	MaxAccountFutureNonce uint64
+	NoLocals              bool
+	TxPoolDenyList        []types.Address
+	TxPoolAllowList       []types.Address
+	TxOrdering            string
+	MinerFeeRecipient     types.Address
+	CacheWarmBlocks       uint64
+	HealState             bool
+	PruneStateBlocks      uint64
+	FastSync              bool
+	ParallelExecution     bool
+	PrefetchState         bool
+	StateSnapshot         bool
+	Checkpoint            *protocol.Checkpoint
+	SyncRequestTimeout    time.Duration
 
 	Telemetry *Telemetry
 	Network   *network.Config
@@ -37,8 +63,12 @@ type Config struct {
 	DataDir     string
 	RestoreFile *string
 
+	DBBackend      string
 	LeveldbOptions *LeveldbOptions
 
+	AncientDir    string
+	AncientBlocks uint64
+
 	Seal           bool
 	SecretsManager *secrets.SecretsManagerConfig
 
@@ -47,6 +77,36 @@ type Config struct {
 
 	Daemon       bool
 	ValidatorKey string
+
+	Exporter *Exporter
+
+	Audit *Audit
+
+	GasPriceOracle *GasPriceOracle
+}
+
+// Exporter holds the config details for the block execution result exporter
+type Exporter struct {
+	// Sink is the uri of the sink, the exporter is disabled if empty
+	Sink string
+	// From is the first block to export if there is no checkpoint yet
+	From uint64
+}
+
+// Audit holds the config details for the block import audit
+type Audit struct {
+	// Rules is the path of the rules file, the audit is disabled if empty
+	Rules string
+	// Sink is the uri of the sink of the matches, the audit log of the data directory if empty
+	Sink string
+}
+
+// GasPriceOracle holds the config details for the gas price oracle
+type GasPriceOracle struct {
+	// Blocks is the number of the latest blocks sampled
+	Blocks uint64
+	// Percentile is the percentile of the sampled tips suggested
+	Percentile uint64
 }
 
 // LeveldbOptions holds the leveldb options
@@ -61,7 +121,9 @@ type LeveldbOptions struct {
 
 // Telemetry holds the config details for metric services
 type Telemetry struct {
-	PrometheusAddr *net.TCPAddr
+	PrometheusAddr  *net.TCPAddr
+	StatusAddr      *net.TCPAddr
+	StatusAuthToken string
 }
 
 // JSONRPC holds the config details for the JSON-RPC server
@@ -70,8 +132,18 @@ type JSONRPC struct {
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	LogLimit                 uint64
 	JSONNamespace            []string
 	EnableWS                 bool
+	VirtualHosts             []*jsonrpc.VirtualHost
+	RateLimit                uint64
+	MethodRateLimits         map[string]uint64
+	DisabledMethods          []string
+	TLSCertFile              string
+	TLSKeyFile               string
+	TLSClientCAFile          string
+	EnableAdmin              bool
+	AdminToken               string
 }
 
 type GraphQL struct {
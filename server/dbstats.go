@@ -0,0 +1,144 @@
+package server
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/dogechain-lab/dogechain/blockchain/storage/kvstorage"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/hashicorp/go-hclog"
+)
+
+// DBStats are the statistics of a database of the data directory
+type DBStats struct {
+	Path     string `json:"path"`
+	Backend  string `json:"backend"`
+	DiskSize uint64 `json:"diskSize"`
+
+	// Keys are the pairs per category of their keys, by decreasing size
+	Keys []*kvdb.KeyStats `json:"keys"`
+
+	// Backend statistics, as the sizes and compactions of the levels
+	BackendStats string `json:"backendStats"`
+}
+
+// DBStatsResult is the outcome of a database inspection
+type DBStatsResult struct {
+	Blockchain *DBStats `json:"blockchain"`
+	Ancient    *DBStats `json:"ancient,omitempty"`
+	State      *DBStats `json:"state"`
+}
+
+// DatabaseStats inspects the databases of the data directory, offline, counting their pairs
+// per category.
+func DatabaseStats(config *Config) (*DBStatsResult, error) {
+	if _, err := os.Stat(filepath.Join(config.DataDir, "blockchain")); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoChainData, config.DataDir)
+	}
+
+	logger, err := newLoggerFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DBStatsResult{}
+
+	if result.Blockchain, err = databaseStats(
+		logger, config, filepath.Join(config.DataDir, "blockchain"), kvstorage.KeyCategory,
+	); err != nil {
+		return nil, err
+	}
+
+	ancient, err := ancientDir(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if ancient != "" {
+		if result.Ancient, err = databaseStats(logger, config, ancient, kvstorage.KeyCategory); err != nil {
+			return nil, err
+		}
+	}
+
+	if result.State, err = databaseStats(
+		logger, config, filepath.Join(config.DataDir, "trie"), itrie.KeyCategory,
+	); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func databaseStats(
+	logger hclog.Logger,
+	config *Config,
+	path string,
+	category func(k []byte) string,
+) (*DBStats, error) {
+	backend, ok := kvdb.DetectBackend(path)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoChainData, path)
+	}
+
+	dbBuilder, err := newDBBuilder(logger, config, path)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := dbBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	stats := &DBStats{
+		Path:    path,
+		Backend: backend,
+	}
+
+	logger.Info("inspecting database", "path", path, "backend", backend)
+
+	if stats.Keys, err = kvdb.Inspect(db, category); err != nil {
+		return nil, err
+	}
+
+	if stats.BackendStats, err = db.Stats(); err != nil {
+		return nil, err
+	}
+
+	if stats.DiskSize, err = diskSize(path); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// diskSize returns the size of the files of the directory
+func diskSize(path string) (uint64, error) {
+	var size uint64
+
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		size += uint64(info.Size())
+
+		return nil
+	})
+
+	return size, err
+}
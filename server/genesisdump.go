@@ -0,0 +1,114 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// dumpLogAccounts is the number of accounts dumped between the progress logs
+const dumpLogAccounts = 100000
+
+// DumpGenesisResult is the outcome of a genesis dump
+type DumpGenesisResult struct {
+	Number    uint64     `json:"number"`
+	Hash      types.Hash `json:"hash"`
+	StateRoot types.Hash `json:"stateRoot"`
+	Accounts  uint64     `json:"accounts"`
+	Slots     uint64     `json:"slots"`
+}
+
+// DumpGenesis returns the chain config of a new chain whose genesis holds the state of the block
+// of the chain of the data directory, the head one if number is not set, offline.
+// The genesis keeps the consensus fields of the current one, and the forks and schedules
+// of the params are moved by the number of the block.
+// The state is dumped from the preimages recorded by the node, so that a state written by
+// a state sync, or before the preimages were recorded, cannot be dumped.
+func DumpGenesis(config *Config, number *uint64) (*chain.Chain, *DumpGenesisResult, error) {
+	if _, err := os.Stat(filepath.Join(config.DataDir, "blockchain")); err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNoChainData, config.DataDir)
+	}
+
+	logger, err := newLoggerFromConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the genesis state is written off the data directory, which is only read
+	offline, err := newOfflineChain(config, hclog.NewNullLogger(), func(storage itrie.Storage) itrie.Storage {
+		return newReplayStorage(storage)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer offline.Close()
+
+	header := offline.blockchain.Header()
+
+	if number != nil {
+		var ok bool
+
+		if header, ok = offline.blockchain.GetHeaderByNumber(*number); !ok {
+			return nil, nil, fmt.Errorf("%w: %d", ErrReplayBlockNotFound, *number)
+		}
+	}
+
+	result := &DumpGenesisResult{
+		Number:    header.Number,
+		Hash:      header.Hash,
+		StateRoot: header.StateRoot,
+	}
+
+	logger = logger.Named("dump-genesis")
+	logger.Info("dumping the state", "number", header.Number, "root", header.StateRoot)
+
+	alloc := map[types.Address]*chain.GenesisAccount{}
+
+	if err := itrie.DumpState(offline.stateStorage, header.StateRoot, func(account *itrie.DumpAccount) error {
+		genesisAccount := &chain.GenesisAccount{
+			Balance: account.Balance,
+			Nonce:   account.Nonce,
+			Code:    account.Code,
+		}
+
+		if len(account.Storage) > 0 {
+			genesisAccount.Storage = account.Storage
+		}
+
+		alloc[account.Address] = genesisAccount
+
+		result.Accounts++
+		result.Slots += uint64(len(account.Storage))
+
+		if result.Accounts%dumpLogAccounts == 0 {
+			logger.Info("dumping the state", "accounts", result.Accounts, "slots", result.Slots)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	genesis := config.Chain.Genesis
+
+	return &chain.Chain{
+		Name: config.Chain.Name,
+		Genesis: &chain.Genesis{
+			Nonce:      genesis.Nonce,
+			Timestamp:  header.Timestamp,
+			ExtraData:  genesis.ExtraData,
+			GasLimit:   header.GasLimit,
+			Difficulty: genesis.Difficulty,
+			Mixhash:    genesis.Mixhash,
+			Coinbase:   genesis.Coinbase,
+			Alloc:      alloc,
+		},
+		Params: config.Chain.Params.Rebase(header.Number),
+	}, result, nil
+}
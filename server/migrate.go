@@ -0,0 +1,138 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/hashicorp/go-hclog"
+)
+
+var ErrDBBackendNotPersistent = errors.New("database backend is not persistent")
+
+// MigrateResult is the outcome of a database migration
+type MigrateResult struct {
+	To         string `json:"to"`
+	Blockchain uint64 `json:"blockchain"`
+	State      uint64 `json:"state"`
+}
+
+// MigrateDatabase converts the databases of the data directory to the backend, offline.
+// A database already on the backend is left as is, so an interrupted migration can be run again
+func MigrateDatabase(config *Config, to string) (*MigrateResult, error) {
+	switch to {
+	case kvdb.BackendLevelDB, kvdb.BackendPebble:
+	case kvdb.BackendMemory:
+		return nil, fmt.Errorf("%w: %s", ErrDBBackendNotPersistent, to)
+	default:
+		return nil, fmt.Errorf("%w: %s", kvdb.ErrUnknownBackend, to)
+	}
+
+	if _, err := os.Stat(filepath.Join(config.DataDir, "blockchain")); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoChainData, config.DataDir)
+	}
+
+	logger, err := newLoggerFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrateResult{To: to}
+
+	if result.Blockchain, err = migrateDatabase(logger, config, filepath.Join(config.DataDir, "blockchain"), to); err != nil {
+		return nil, err
+	}
+
+	if result.State, err = migrateDatabase(logger, config, filepath.Join(config.DataDir, "trie"), to); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// migrateDatabase copies the database at the path to a new one on the backend, which then replaces it.
+// It returns the number of the pairs copied
+func migrateDatabase(logger hclog.Logger, config *Config, path string, to string) (uint64, error) {
+	from, ok := kvdb.DetectBackend(path)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrNoChainData, path)
+	}
+
+	if from == to {
+		logger.Info("database already migrated", "path", path, "backend", to)
+
+		return 0, nil
+	}
+
+	target := path + ".migrate"
+
+	// the leftover of an interrupted migration
+	if err := os.RemoveAll(target); err != nil {
+		return 0, err
+	}
+
+	logger.Info("migrating database", "path", path, "from", from, "to", to)
+
+	copied, err := copyDatabase(logger, config, path, from, target, to)
+	if err != nil {
+		return copied, err
+	}
+
+	// the source is only removed once the target replaced it
+	backup := path + ".old"
+
+	if err := os.Rename(path, backup); err != nil {
+		return copied, err
+	}
+
+	if err := os.Rename(target, path); err != nil {
+		return copied, err
+	}
+
+	if err := os.RemoveAll(backup); err != nil {
+		return copied, err
+	}
+
+	logger.Info("database migrated", "path", path, "pairs", copied)
+
+	return copied, nil
+}
+
+func copyDatabase(logger hclog.Logger, config *Config, srcPath, from, dstPath, to string) (uint64, error) {
+	srcConfig, dstConfig := *config, *config
+	srcConfig.DBBackend = from
+	dstConfig.DBBackend = to
+
+	srcBuilder, err := newDBBuilder(logger, &srcConfig, srcPath)
+	if err != nil {
+		return 0, err
+	}
+
+	dstBuilder, err := newDBBuilder(logger, &dstConfig, dstPath)
+	if err != nil {
+		return 0, err
+	}
+
+	src, err := srcBuilder.Build()
+	if err != nil {
+		return 0, err
+	}
+
+	defer src.Close()
+
+	dst, err := dstBuilder.Build()
+	if err != nil {
+		return 0, err
+	}
+
+	copied, err := kvdb.Copy(dst, src)
+	if err != nil {
+		dst.Close()
+
+		return copied, err
+	}
+
+	return copied, dst.Close()
+}
@@ -0,0 +1,150 @@
+// Package server runs the node services. The functions operating on a data directory offline,
+// such as SetHead or PruneState, open it exclusively, so the node of the data directory should be stopped.
+package server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/consensus"
+	"github.com/dogechain-lab/dogechain/contracts/governance"
+	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/state/runtime/evm"
+	"github.com/dogechain-lab/dogechain/state/runtime/precompiled"
+	"github.com/hashicorp/go-hclog"
+)
+
+// offlineChain is the chain of a data directory opened without the node services,
+// the consensus being only used to verify the headers and recover the block creators
+type offlineChain struct {
+	config *Config
+
+	stateStorage itrie.Storage
+	executor     *state.Executor
+	blockchain   *blockchain.Blockchain
+	consensus    consensus.Consensus
+}
+
+// newOfflineChain opens the chain of the data directory of the configuration.
+// The state storage is wrapped by the wrap function if set, to keep the writes off the data directory
+func newOfflineChain(
+	config *Config,
+	logger hclog.Logger,
+	wrap func(itrie.Storage) itrie.Storage,
+) (*offlineChain, error) {
+	stateBuilder, err := newDBBuilder(logger, config, filepath.Join(config.DataDir, "trie"))
+	if err != nil {
+		return nil, err
+	}
+
+	stateStorage, err := itrie.NewDatabaseStorage(stateBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &offlineChain{
+		config:       config,
+		stateStorage: stateStorage,
+	}
+
+	if err := c.setup(logger, wrap); err != nil {
+		c.Close()
+
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *offlineChain) setup(logger hclog.Logger, wrap func(itrie.Storage) itrie.Storage) error {
+	config := c.config
+
+	executorStorage := c.stateStorage
+	if wrap != nil {
+		executorStorage = wrap(c.stateStorage)
+	}
+
+	statefulRegistry, err := newStatefulRegistry(config.Chain.Params.Forks)
+	if err != nil {
+		return err
+	}
+
+	c.executor = state.NewExecutor(config.Chain.Params, itrie.NewState(executorStorage), logger)
+	c.executor.SetRuntime(governance.NewRuntime())
+	c.executor.SetRuntime(statefulRegistry)
+	c.executor.SetRuntime(precompiled.NewPrecompiled())
+	c.executor.SetRuntime(evm.NewEVM())
+
+	// compute the genesis root state
+	config.Chain.Genesis.StateRoot = c.executor.WriteGenesis(config.Chain.Genesis.Alloc)
+
+	chainBuilder, err := newChainStorageBuilder(logger, config)
+	if err != nil {
+		return err
+	}
+
+	c.blockchain, err = blockchain.NewBlockchain(
+		logger,
+		config.Chain,
+		chainBuilder,
+		nil,
+		c.executor,
+		blockchain.NilMetrics(),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.executor.GetHash = c.blockchain.GetHashHelper
+
+	// the consensus recovers the block creators, and may hash the headers its own way
+	engineName := config.Chain.Params.GetEngine()
+
+	engine, ok := consensusBackends[ConsensusType(engineName)]
+	if !ok {
+		return fmt.Errorf("consensus engine '%s' not found", engineName)
+	}
+
+	engineConfig, ok := config.Chain.Params.Engine[engineName].(map[string]interface{})
+	if !ok {
+		engineConfig = map[string]interface{}{}
+	}
+
+	c.consensus, err = engine(&consensus.ConsensusParams{
+		Context: context.Background(),
+		Config: &consensus.Config{
+			Params: config.Chain.Params,
+			Config: engineConfig,
+			Path:   filepath.Join(config.DataDir, "consensus"),
+		},
+		Blockchain: c.blockchain,
+		Executor:   c.executor,
+		Logger:     logger,
+		Metrics:    consensus.NilMetrics(),
+	})
+	if err != nil {
+		return err
+	}
+
+	c.blockchain.SetConsensus(c.consensus)
+
+	return c.blockchain.ComputeGenesis()
+}
+
+// Close closes the chain of the data directory
+func (c *offlineChain) Close() error {
+	var err error
+
+	if c.blockchain != nil {
+		err = c.blockchain.Close()
+	}
+
+	if closeErr := c.stateStorage.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+
+	return err
+}
@@ -33,6 +33,10 @@ type SystemClient interface {
 	BlockByNumber(ctx context.Context, in *BlockByNumberRequest, opts ...grpc.CallOption) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (System_ExportClient, error)
+	// BridgeSigners returns the addresses allowed to mint and burn bridged coins
+	BridgeSigners(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BridgeSignersResponse, error)
+	// BadBlocks returns the recent blocks rejected by the chain, the most recent first
+	BadBlocks(ctx context.Context, in *BadBlocksRequest, opts ...grpc.CallOption) (*BadBlocksResponse, error)
 }
 
 type systemClient struct {
@@ -152,6 +156,24 @@ func (x *systemExportClient) Recv() (*ExportEvent, error) {
 	return m, nil
 }
 
+func (c *systemClient) BridgeSigners(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BridgeSignersResponse, error) {
+	out := new(BridgeSignersResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/BridgeSigners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) BadBlocks(ctx context.Context, in *BadBlocksRequest, opts ...grpc.CallOption) (*BadBlocksResponse, error) {
+	out := new(BadBlocksResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/BadBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SystemServer is the server API for System service.
 // All implementations must embed UnimplementedSystemServer
 // for forward compatibility
@@ -170,6 +192,10 @@ type SystemServer interface {
 	BlockByNumber(context.Context, *BlockByNumberRequest) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(*ExportRequest, System_ExportServer) error
+	// BridgeSigners returns the addresses allowed to mint and burn bridged coins
+	BridgeSigners(context.Context, *emptypb.Empty) (*BridgeSignersResponse, error)
+	// BadBlocks returns the recent blocks rejected by the chain, the most recent first
+	BadBlocks(context.Context, *BadBlocksRequest) (*BadBlocksResponse, error)
 	mustEmbedUnimplementedSystemServer()
 }
 
@@ -198,6 +224,12 @@ func (UnimplementedSystemServer) BlockByNumber(context.Context, *BlockByNumberRe
 func (UnimplementedSystemServer) Export(*ExportRequest, System_ExportServer) error {
 	return status.Errorf(codes.Unimplemented, "method Export not implemented")
 }
+func (UnimplementedSystemServer) BridgeSigners(context.Context, *emptypb.Empty) (*BridgeSignersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BridgeSigners not implemented")
+}
+func (UnimplementedSystemServer) BadBlocks(context.Context, *BadBlocksRequest) (*BadBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BadBlocks not implemented")
+}
 func (UnimplementedSystemServer) mustEmbedUnimplementedSystemServer() {}
 
 // UnsafeSystemServer may be embedded to opt out of forward compatibility for this service.
@@ -343,6 +375,42 @@ func (x *systemExportServer) Send(m *ExportEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _System_BridgeSigners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).BridgeSigners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/BridgeSigners",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).BridgeSigners(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_BadBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BadBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).BadBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/BadBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).BadBlocks(ctx, req.(*BadBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // System_ServiceDesc is the grpc.ServiceDesc for System service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -370,6 +438,14 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BlockByNumber",
 			Handler:    _System_BlockByNumber_Handler,
 		},
+		{
+			MethodName: "BridgeSigners",
+			Handler:    _System_BridgeSigners_Handler,
+		},
+		{
+			MethodName: "BadBlocks",
+			Handler:    _System_BadBlocks_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -383,5 +459,5 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 		},
 	},
-	Metadata: "system.proto",
+	Metadata: "server/proto/system.proto",
 }
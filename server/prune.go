@@ -0,0 +1,226 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+	"github.com/dogechain-lab/dogechain/blockchain/storage"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// MinPruneStateBlocks is the minimum number of the latest blocks whose state is retained by a prune,
+// covering the reorganizations and the states cached in memory
+const MinPruneStateBlocks uint64 = 128
+
+// statePruneInterval is the number of blocks imported between the background prunes
+const statePruneInterval uint64 = 10000
+
+var ErrPruneStateBlocks = errors.New("too few blocks whose state is retained")
+
+// retainedRoots returns the state roots of the blocks up to the head, the latest ones first
+func retainedRoots(
+	head, blocks uint64,
+	header func(uint64) (*types.Header, error),
+) ([]types.Hash, error) {
+	if blocks > head+1 {
+		blocks = head + 1
+	}
+
+	roots := make([]types.Hash, 0, blocks)
+
+	for n := head; n+blocks > head; n-- {
+		h, err := header(n)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read header of block %d, %w", n, err)
+		}
+
+		roots = append(roots, h.StateRoot)
+
+		if n == 0 {
+			break
+		}
+	}
+
+	return roots, nil
+}
+
+// PruneState deletes the state trie nodes of the data directory unreachable from the states of the latest blocks,
+// offline.
+func PruneState(config *Config, blocks uint64) (*itrie.PruneResult, error) {
+	if blocks < MinPruneStateBlocks {
+		return nil, fmt.Errorf("%w: %d, at least %d", ErrPruneStateBlocks, blocks, MinPruneStateBlocks)
+	}
+
+	if _, err := os.Stat(filepath.Join(config.DataDir, "blockchain")); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoChainData, config.DataDir)
+	}
+
+	logger, err := newLoggerFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	chainBuilder, err := newChainStorageBuilder(logger, config)
+	if err != nil {
+		return nil, err
+	}
+
+	stateBuilder, err := newDBBuilder(logger, config, filepath.Join(config.DataDir, "trie"))
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := chainBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	stateStorage, err := itrie.NewDatabaseStorage(stateBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stateStorage.Close()
+
+	pruner, err := itrie.NewPruner(stateStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := pruner.Prune(context.Background(), func() ([]types.Hash, error) {
+		head, ok := db.ReadHeadNumber()
+		if !ok {
+			return nil, ErrNoChainData
+		}
+
+		return retainedRoots(head, blocks, func(n uint64) (*types.Header, error) {
+			hash, ok := db.ReadCanonicalHash(n)
+			if !ok {
+				return nil, storage.ErrNotFound
+			}
+
+			return db.ReadHeader(hash)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("state pruned, compacting", "retained", result.Retained, "pruned", result.Pruned)
+
+	if err := pruner.Compact(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// statePruner prunes the state in the background, on startup then every statePruneInterval blocks
+type statePruner struct {
+	logger     hclog.Logger
+	pruner     *itrie.Pruner
+	blockchain *blockchain.Blockchain
+	blocks     uint64
+
+	sub    blockchain.Subscription
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newStatePruner(
+	logger hclog.Logger,
+	pruner *itrie.Pruner,
+	chain *blockchain.Blockchain,
+	blocks uint64,
+) *statePruner {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// the states of the blocks being executed are committed before the head moves to them
+	pruner.TrackHead(func() uint64 {
+		if head := chain.Header(); head != nil {
+			return head.Number
+		}
+
+		return 0
+	})
+
+	return &statePruner{
+		logger:     logger,
+		pruner:     pruner,
+		blockchain: chain,
+		blocks:     blocks,
+		sub:        chain.SubscribeEvents(),
+		ctx:        ctx,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+}
+
+// run prunes the state until the pruner is closed
+func (p *statePruner) run() {
+	defer close(p.done)
+
+	var (
+		pruned  uint64
+		started bool
+	)
+
+	for {
+		if head := p.blockchain.Header(); head != nil && (!started || head.Number >= pruned+statePruneInterval) {
+			started = true
+			pruned = head.Number
+
+			p.prune()
+		}
+
+		if p.sub.GetEvent() == nil {
+			return
+		}
+	}
+}
+
+func (p *statePruner) prune() {
+	start := time.Now()
+
+	result, err := p.pruner.Prune(p.ctx, func() ([]types.Hash, error) {
+		return retainedRoots(p.blockchain.Header().Number, p.blocks, func(n uint64) (*types.Header, error) {
+			header, ok := p.blockchain.GetHeaderByNumber(n)
+			if !ok {
+				return nil, storage.ErrNotFound
+			}
+
+			return header, nil
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			p.logger.Warn("failed to prune state", "err", err)
+		}
+
+		return
+	}
+
+	p.logger.Info("state pruned",
+		"roots", result.Roots,
+		"retained", result.Retained,
+		"pruned", result.Pruned,
+		"elapsed", time.Since(start),
+	)
+}
+
+// Close stops the pruner, waiting for a running prune to be canceled
+func (p *statePruner) Close() {
+	p.cancel()
+	p.sub.Close()
+	<-p.done
+}
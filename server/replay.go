@@ -0,0 +1,227 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/exporter"
+	"github.com/dogechain-lab/dogechain/jsonrpc"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/state/tracer"
+	"github.com/dogechain-lab/dogechain/state/tracer/calltracer"
+	"github.com/dogechain-lab/dogechain/state/tracer/structlogger"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+var (
+	ErrNoChainData         = errors.New("no chain data in the data directory")
+	ErrDBBackendMismatch   = errors.New("database backend mismatch")
+	ErrReplayTxNotFound    = errors.New("transaction not found")
+	ErrReplayBlockNotFound = errors.New("block not found")
+	ErrReplayGenesis       = errors.New("genesis is not replayable")
+)
+
+// ReplayResult is the execution of a transaction replayed against the state of its block
+type ReplayResult struct {
+	BlockNumber uint64     `json:"blockNumber"`
+	BlockHash   types.Hash `json:"blockHash"`
+	TxHash      types.Hash `json:"transactionHash"`
+	TxIndex     int        `json:"transactionIndex"`
+
+	*jsonrpc.ExecutionResult
+
+	CallTrace *calltracer.CallFrame   `json:"callTrace"`
+	StateDiff []*exporter.AccountDiff `json:"stateDiff"`
+}
+
+// replayStorage reads the trie of the data directory and keeps the writes in memory,
+// so that the replays leave the data directory untouched
+type replayStorage struct {
+	itrie.Storage
+
+	overlay itrie.Storage
+}
+
+func newReplayStorage(storage itrie.Storage) *replayStorage {
+	return &replayStorage{
+		Storage: storage,
+		overlay: itrie.NewMemoryStorage(),
+	}
+}
+
+func (s *replayStorage) Set(k, v []byte) error {
+	return s.overlay.Set(k, v)
+}
+
+func (s *replayStorage) Get(k []byte) ([]byte, bool, error) {
+	if v, ok, _ := s.overlay.Get(k); ok {
+		return v, true, nil
+	}
+
+	return s.Storage.Get(k)
+}
+
+func (s *replayStorage) SetCode(hash types.Hash, code []byte) error {
+	return s.overlay.SetCode(hash, code)
+}
+
+func (s *replayStorage) GetCode(hash types.Hash) ([]byte, bool) {
+	if code, ok := s.overlay.GetCode(hash); ok {
+		return code, true
+	}
+
+	return s.Storage.GetCode(hash)
+}
+
+func (s *replayStorage) Batch() itrie.Batch {
+	return s.overlay.Batch()
+}
+
+// Replayer replays the transactions of the chain of a data directory, offline.
+type Replayer struct {
+	*offlineChain
+}
+
+// NewReplayer opens the chain of the data directory of the configuration
+func NewReplayer(config *Config) (*Replayer, error) {
+	if _, err := os.Stat(filepath.Join(config.DataDir, "blockchain")); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoChainData, config.DataDir)
+	}
+
+	chain, err := newOfflineChain(config, hclog.NewNullLogger(), func(storage itrie.Storage) itrie.Storage {
+		return newReplayStorage(storage)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Replayer{offlineChain: chain}, nil
+}
+
+// ReplayTx replays the sealed transaction of the hash
+func (r *Replayer) ReplayTx(hash types.Hash) (*ReplayResult, error) {
+	blockHash, ok := r.blockchain.ReadTxLookup(hash)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrReplayTxNotFound, hash)
+	}
+
+	block, ok := r.blockchain.GetBlockByHash(blockHash, true)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrReplayBlockNotFound, blockHash)
+	}
+
+	for idx, tx := range block.Transactions {
+		if tx.Hash == hash {
+			return r.replay(block, idx, tx)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s in block %s", ErrReplayTxNotFound, hash, blockHash)
+}
+
+// ReplayRawTx replays the signed transaction as the first one of the block of the number
+func (r *Replayer) ReplayRawTx(raw []byte, number uint64) (*ReplayResult, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(raw); err != nil {
+		return nil, err
+	}
+
+	tx.ComputeHash()
+
+	block, ok := r.blockchain.GetBlockByNumber(number, true)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrReplayBlockNotFound, number)
+	}
+
+	signer := crypto.NewSigner(
+		r.config.Chain.Params.Forks.At(number),
+		uint64(r.config.Chain.Params.ChainID),
+	)
+
+	from, err := signer.Sender(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.From = from
+
+	return r.replay(block, 0, tx)
+}
+
+// replay executes the transaction against the state of the block parent,
+// once the transactions of the block before the index are executed
+func (r *Replayer) replay(block *types.Block, txIndex int, tx *types.Transaction) (*ReplayResult, error) {
+	if block.Number() == 0 {
+		return nil, ErrReplayGenesis
+	}
+
+	parent, ok := r.blockchain.GetParent(block.Header)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrReplayBlockNotFound, block.ParentHash())
+	}
+
+	blockCreator, err := r.consensus.GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	root := parent.StateRoot
+
+	if txIndex > 0 {
+		// commit the previous transactions, so that the state diff is the one of the transaction
+		txn, err := r.executor.BeginTxn(root, block.Header, blockCreator)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, previous := range block.Transactions[:txIndex] {
+			if _, err := txn.Apply(previous); err != nil {
+				return nil, fmt.Errorf("transaction %s failed: %w", previous.Hash, err)
+			}
+		}
+
+		_, root = txn.Commit()
+	}
+
+	txn, err := r.executor.BeginTxn(root, block.Header, blockCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	structLogger := structlogger.NewStructLogger(txn.Txn())
+	callTracer := calltracer.NewCallTracer()
+
+	txn.SetEVMLogger(tracer.NewMultiLogger(structLogger, callTracer))
+
+	result, err := txn.Apply(tx)
+	if err != nil {
+		return nil, fmt.Errorf("replay failed: %w", err)
+	}
+
+	_, _, objs := txn.CommitWithObjects()
+
+	returnVal := fmt.Sprintf("%x", result.Return())
+	// If the result contains a revert reason, return it.
+	if result.Reverted() {
+		returnVal = fmt.Sprintf("%x", result.Revert())
+	}
+
+	return &ReplayResult{
+		BlockNumber: block.Number(),
+		BlockHash:   block.Hash(),
+		TxHash:      tx.Hash,
+		TxIndex:     txIndex,
+		ExecutionResult: &jsonrpc.ExecutionResult{
+			Gas:         result.GasUsed,
+			Failed:      result.Failed(),
+			ReturnValue: returnVal,
+			StructLogs:  jsonrpc.FormatLogs(structLogger.StructLogs()),
+		},
+		CallTrace: callTracer.Result(),
+		StateDiff: exporter.NewStateDiff(objs),
+	}, nil
+}
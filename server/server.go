@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"math/big"
@@ -9,14 +10,18 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	goruntime "runtime"
 	"time"
 
 	"github.com/dogechain-lab/dogechain/archive"
+	"github.com/dogechain-lab/dogechain/audit"
 	"github.com/dogechain-lab/dogechain/blockchain"
-	"github.com/dogechain-lab/dogechain/blockchain/storage/kvstorage"
 	"github.com/dogechain-lab/dogechain/chain"
 	"github.com/dogechain-lab/dogechain/consensus"
+	"github.com/dogechain-lab/dogechain/contracts/governance"
 	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/exporter"
+	"github.com/dogechain-lab/dogechain/gasprice"
 	"github.com/dogechain-lab/dogechain/graphql"
 	"github.com/dogechain-lab/dogechain/helper/common"
 	"github.com/dogechain-lab/dogechain/helper/keccak"
@@ -24,6 +29,7 @@ import (
 	"github.com/dogechain-lab/dogechain/helper/progress"
 	"github.com/dogechain-lab/dogechain/jsonrpc"
 	"github.com/dogechain-lab/dogechain/network"
+	"github.com/dogechain-lab/dogechain/protocol"
 	"github.com/dogechain-lab/dogechain/secrets"
 	"github.com/dogechain-lab/dogechain/server/proto"
 	"github.com/dogechain-lab/dogechain/state"
@@ -31,9 +37,11 @@ import (
 	"github.com/dogechain-lab/dogechain/state/runtime"
 	"github.com/dogechain-lab/dogechain/state/runtime/evm"
 	"github.com/dogechain-lab/dogechain/state/runtime/precompiled"
+	"github.com/dogechain-lab/dogechain/state/snapshot"
 	"github.com/dogechain-lab/dogechain/txpool"
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
@@ -73,12 +81,34 @@ type Server struct {
 	serverMetrics *serverMetrics
 
 	prometheusServer *http.Server
+	statusServer     *http.Server
 
 	// secrets manager
 	secretsManager secrets.SecretsManager
 
 	// restore
 	restoreProgression *progress.ProgressionWrapper
+
+	// block execution result exporter
+	exporter *exporter.Exporter
+
+	// block import auditor
+	auditor *audit.Auditor
+
+	// state healer
+	healer *protocol.StateHealer
+
+	// head subscription of the bloom bits indexer
+	bloomIndexSub blockchain.Subscription
+
+	// background state pruner
+	statePruner *statePruner
+
+	// flat snapshot of the latest states, nil unless enabled
+	stateSnapshot *snapshot.Tree
+
+	// background freezer of the old blocks
+	ancientFreezer *ancientFreezer
 }
 
 const (
@@ -135,20 +165,36 @@ func newLoggerFromConfig(config *Config) (hclog.Logger, error) {
 	return newCLILogger(config), nil
 }
 
-func newLevelDBBuilder(logger hclog.Logger, config *Config, path string) kvdb.LevelDBBuilder {
-	leveldbBuilder := kvdb.NewLevelDBBuilder(
-		logger,
-		path,
-	)
+// newDBBuilder returns the builder of the database at the path, on the backend of the configuration,
+// or on the one of the existing database if none is configured
+func newDBBuilder(logger hclog.Logger, config *Config, path string) (kvdb.Builder, error) {
+	backend := config.DBBackend
 
-	leveldbBuilder.SetCacheSize(config.LeveldbOptions.CacheSize).
+	if existing, ok := kvdb.DetectBackend(path); ok {
+		if backend == "" {
+			backend = existing
+		} else if backend != existing && backend != kvdb.BackendMemory {
+			return nil, fmt.Errorf("%w: %s is a %s database, migrate it to %s first", ErrDBBackendMismatch, path, existing, backend)
+		}
+	}
+
+	if backend == "" {
+		backend = kvdb.BackendLevelDB
+	}
+
+	dbBuilder, err := kvdb.NewBuilder(logger, backend, path)
+	if err != nil {
+		return nil, err
+	}
+
+	dbBuilder.SetCacheSize(config.LeveldbOptions.CacheSize).
 		SetHandles(config.LeveldbOptions.Handles).
 		SetBloomKeyBits(config.LeveldbOptions.BloomKeyBits).
 		SetCompactionTableSize(config.LeveldbOptions.CompactionTableSize).
 		SetCompactionTotalSize(config.LeveldbOptions.CompactionTotalSize).
 		SetNoSync(config.LeveldbOptions.NoSync)
 
-	return leveldbBuilder
+	return dbBuilder, nil
 }
 
 // NewServer creates a new Minimal server, using the passed in configuration
@@ -205,44 +251,98 @@ func NewServer(config *Config) (*Server, error) {
 
 	// start blockchain object
 	stateStorage, err := func() (itrie.Storage, error) {
-		leveldbBuilder := newLevelDBBuilder(
+		dbBuilder, err := newDBBuilder(
 			logger,
 			config,
 			filepath.Join(m.config.DataDir, "trie"),
 		)
+		if err != nil {
+			return nil, err
+		}
 
-		return itrie.NewLevelDBStorage(leveldbBuilder)
+		return itrie.NewDatabaseStorage(dbBuilder)
 	}()
 
 	if err != nil {
 		return nil, err
 	}
 
+	// record the nodes written while the background pruner runs
+	if config.PruneStateBlocks > 0 {
+		pruner, err := itrie.NewPruner(stateStorage)
+		if err != nil {
+			return nil, err
+		}
+
+		stateStorage = pruner
+	}
+
 	m.stateStorage = stateStorage
 
 	st := itrie.NewState(stateStorage)
 	m.state = st
 
-	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
+	// read the latest states from the flat snapshot, which tracks the states committed
+	if config.StateSnapshot {
+		snapDB, err := func() (kvdb.Database, error) {
+			dbBuilder, err := newDBBuilder(
+				logger,
+				config,
+				filepath.Join(m.config.DataDir, "snapshot"),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return dbBuilder.Build()
+		}()
+		if err != nil {
+			return nil, err
+		}
+
+		tree, err := snapshot.New(logger.Named("snapshot"), snapDB, st, stateStorage)
+		if err != nil {
+			return nil, err
+		}
+
+		m.stateSnapshot = tree
+		m.state = tree
+	}
+
+	statefulRegistry, err := newStatefulRegistry(config.Chain.Params.Forks)
+	if err != nil {
+		return nil, err
+	}
+
+	m.executor = state.NewExecutor(config.Chain.Params, m.state, logger)
+	m.executor.SetRuntime(governance.NewRuntime())
+	m.executor.SetRuntime(statefulRegistry)
 	m.executor.SetRuntime(precompiled.NewPrecompiled())
 	m.executor.SetRuntime(evm.NewEVM())
 
+	if config.ParallelExecution {
+		m.executor.SetParallelWorkers(goruntime.NumCPU())
+	}
+
+	if config.PrefetchState {
+		m.executor.EnablePrefetch()
+	}
+
 	// compute the genesis root state
 	genesisRoot := m.executor.WriteGenesis(config.Chain.Genesis.Alloc)
 	config.Chain.Genesis.StateRoot = genesisRoot
 
-	// create leveldb storageBuilder
-	leveldbBuilder := newLevelDBBuilder(
-		logger,
-		config,
-		filepath.Join(m.config.DataDir, "blockchain"),
-	)
+	// create the builder of the blockchain storage, tiered with the ancient store if configured
+	chainBuilder, err := newChainStorageBuilder(logger, config)
+	if err != nil {
+		return nil, err
+	}
 
 	// blockchain object
 	m.blockchain, err = blockchain.NewBlockchain(
 		logger,
 		config.Chain,
-		kvstorage.NewLevelDBStorageBuilder(logger, leveldbBuilder),
+		chainBuilder,
 		nil,
 		m.executor,
 		m.serverMetrics.blockchain,
@@ -264,10 +364,16 @@ func NewServer(config *Config) (*Server, error) {
 			blackList[i] = types.StringToAddress(a)
 		}
 
+		// the journal is relative to the data directory
+		journal := m.config.TxPoolJournal
+		if journal != "" && !filepath.IsAbs(journal) {
+			journal = filepath.Join(m.config.DataDir, journal)
+		}
+
 		// start transaction pool
 		m.txpool, err = txpool.NewTxPool(
 			logger,
-			m.chain.Params.Forks.At(0),
+			m.chain.Params.Forks,
 			hub,
 			m.grpcServer,
 			m.network,
@@ -275,18 +381,30 @@ func NewServer(config *Config) (*Server, error) {
 			&txpool.Config{
 				Sealing:               m.config.Seal,
 				MaxSlots:              m.config.MaxSlots,
+				MaxAccountEnqueued:    m.config.MaxAccountEnqueued,
+				MaxAccountSlots:       m.config.MaxAccountSlots,
 				PriceLimit:            m.config.PriceLimit,
 				PruneTickSeconds:      m.config.PruneTickSeconds,
 				PromoteOutdateSeconds: m.config.PromoteOutdateSeconds,
+				TxLifetimeSeconds:     m.config.TxLifetimeSeconds,
+				PriceBump:             m.config.PriceBump,
 				BlackList:             blackList,
+				Journal:               journal,
+				RebroadcastBlocks:     m.config.RebroadcastBlocks,
+				MaxTxSize:             m.config.MaxTxSize,
+				MaxAccountFutureNonce: m.config.MaxAccountFutureNonce,
+				NoLocals:              m.config.NoLocals,
+				DenyList:              m.config.TxPoolDenyList,
+				AllowList:             m.config.TxPoolAllowList,
+				PriceLimits:           m.config.Chain.Params.PriceLimits,
 			},
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		// use the eip155 signer
-		signer := crypto.NewEIP155Signer(uint64(m.config.Chain.Params.ChainID))
+		// use the london signer, which also handles eip155 transactions
+		signer := crypto.NewLondonSigner(uint64(m.config.Chain.Params.ChainID))
 		m.txpool.SetSigner(signer)
 	}
 
@@ -305,6 +423,13 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// generate the snapshot of the head state in the background, if it does not hold it
+	if m.stateSnapshot != nil {
+		if err := m.stateSnapshot.Start(m.blockchain.Header().StateRoot); err != nil {
+			return nil, err
+		}
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -325,16 +450,60 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// warm up the caches before opening json-rpc to requests
+	if m.config.CacheWarmBlocks > 0 {
+		go m.warmCaches()
+	}
+
+	// upgrade the storage codec of the blocks written by the previous versions
+	go m.reencodeStorage()
+
+	// index the logs blooms of the chain, then of the sections completed by the new blocks
+	m.bloomIndexSub = m.blockchain.SubscribeEvents()
+	go m.indexBloomBits()
+
+	// prune the states of the blocks older than the retained ones in the background
+	if pruner, ok := m.stateStorage.(*itrie.Pruner); ok {
+		m.statePruner = newStatePruner(logger.Named("prune"), pruner, m.blockchain, config.PruneStateBlocks)
+		go m.statePruner.run()
+	}
+
+	// move the old blocks to the ancient store in the background
+	if ancient, err := ancientDir(config); err != nil {
+		return nil, err
+	} else if ancient != "" {
+		m.ancientFreezer = newAncientFreezer(logger.Named("ancient"), m.blockchain, config.AncientBlocks)
+		go m.ancientFreezer.run()
+	}
+
+	// setup and start the exporter before any block is executed by the consensus
+	if err := m.setupExporter(); err != nil {
+		return nil, err
+	}
+
+	// setup and start the auditor before any block is imported by the consensus
+	if err := m.setupAudit(); err != nil {
+		return nil, err
+	}
+
 	// start consensus
 	if err := m.consensus.Start(); err != nil {
 		return nil, err
 	}
 
+	// serve the state to the healing peers, and heal the local one if asked to
+	m.setupHealer()
+
 	// setup and start grpc server
 	if err := m.setupGRPC(); err != nil {
 		return nil, err
 	}
 
+	// start the consensus status page
+	if config.Telemetry.StatusAddr != nil {
+		m.statusServer = m.startStatusServer(config.Telemetry.StatusAddr, config.Telemetry.StatusAuthToken)
+	}
+
 	if err := m.network.Start(); err != nil {
 		return nil, err
 	}
@@ -344,6 +513,67 @@ func NewServer(config *Config) (*Server, error) {
 	return m, nil
 }
 
+// stateLoader loads the state committed by a block into the cache of the states
+type stateLoader interface {
+	LoadState(root types.Hash) error
+}
+
+// warmCaches reads the latest blocks and the head state into the caches,
+// then opens the json-rpc server to requests
+func (s *Server) warmCaches() {
+	start := time.Now()
+
+	warmed := s.blockchain.WarmCaches(s.config.CacheWarmBlocks)
+
+	// the flat snapshot serves the head state otherwise
+	if loader, ok := s.state.(stateLoader); ok {
+		if header := s.blockchain.Header(); header != nil {
+			if err := loader.LoadState(header.StateRoot); err != nil {
+				s.logger.Warn("failed to warm head state", "err", err)
+			}
+		}
+	}
+
+	s.logger.Info("caches warmed", "blocks", warmed, "elapsed", time.Since(start))
+
+	s.jsonrpcServer.SetReady()
+}
+
+// reencodeStorage rewrites the bodies and receipts stored in the legacy codec
+func (s *Server) reencodeStorage() {
+	start := time.Now()
+
+	reencoded, err := s.blockchain.ReencodeStorage()
+	if err != nil {
+		s.logger.Error("failed to re-encode storage", "reencoded", reencoded, "err", err)
+
+		return
+	}
+
+	if reencoded > 0 {
+		s.logger.Info("storage re-encoded", "blocks", reencoded, "elapsed", time.Since(start))
+	}
+}
+
+// indexBloomBits indexes the logs blooms of the sections of blocks completed since the last run,
+// on startup then on every new head, until the subscription is closed
+func (s *Server) indexBloomBits() {
+	for {
+		start := time.Now()
+
+		indexed, err := s.blockchain.IndexBloomBits()
+		if err != nil {
+			s.logger.Error("failed to index bloom bits", "sections", indexed, "err", err)
+		} else if indexed > 0 {
+			s.logger.Info("bloom bits indexed", "sections", indexed, "elapsed", time.Since(start))
+		}
+
+		if s.bloomIndexSub.GetEvent() == nil {
+			return
+		}
+	}
+}
+
 func (s *Server) restoreChain() error {
 	if s.config.RestoreFile == nil {
 		return nil
@@ -465,9 +695,14 @@ func (s *Server) setupConsensus() error {
 	}
 
 	config := &consensus.Config{
-		Params: s.config.Chain.Params,
-		Config: engineConfig,
-		Path:   filepath.Join(s.config.DataDir, "consensus"),
+		Params:             s.config.Chain.Params,
+		Config:             engineConfig,
+		Path:               filepath.Join(s.config.DataDir, "consensus"),
+		TxOrdering:         s.config.TxOrdering,
+		FeeRecipient:       s.config.MinerFeeRecipient,
+		FastSync:           s.config.FastSync,
+		Checkpoint:         s.config.Checkpoint,
+		SyncRequestTimeout: s.config.SyncRequestTimeout,
 	}
 
 	consensus, err := engine(
@@ -479,6 +714,7 @@ func (s *Server) setupConsensus() error {
 			Network:        s.network,
 			Blockchain:     s.blockchain,
 			Executor:       s.executor,
+			StateStorage:   s.stateStorage,
 			Grpc:           s.grpcServer,
 			Logger:         s.logger.Named("consensus"),
 			Metrics:        s.serverMetrics.consensus,
@@ -498,21 +734,102 @@ func (s *Server) setupConsensus() error {
 
 type jsonRPCHub struct {
 	state              state.State
+	stateStorage       itrie.Storage
+	stateSnapshot      *snapshot.Tree
 	restoreProgression *progress.ProgressionWrapper
+	txOrdering         consensus.OrderingPolicy
 
 	*blockchain.Blockchain
 	*txpool.TxPool
 	*state.Executor
 	*network.Server
+	*gasprice.Oracle
 	consensus.Consensus
 }
 
 // HELPER + WRAPPER METHODS //
 
+// GetCommittedSeals returns the number of committed seals finalizing the header,
+// zero if the consensus doesn't finalize blocks with committed seals
+func (j *jsonRPCHub) GetCommittedSeals(header *types.Header) (int, error) {
+	provider, ok := j.Consensus.(consensus.FinalityProvider)
+	if !ok {
+		return 0, nil
+	}
+
+	return provider.CommittedSeals(header)
+}
+
+// GetValidatorEarnings returns the blocks proposed and the fees earned by the validator, per epoch
+func (j *jsonRPCHub) GetValidatorEarnings(
+	validator types.Address,
+	fromEpoch, toEpoch *uint64,
+) (*consensus.EarningsReport, error) {
+	provider, ok := j.Consensus.(consensus.EarningsProvider)
+	if !ok {
+		return nil, errors.New("the consensus does not index the validator earnings")
+	}
+
+	return provider.ValidatorEarnings(validator, fromEpoch, toEpoch)
+}
+
 func (j *jsonRPCHub) GetPeers() int {
 	return len(j.Server.Peers())
 }
 
+// GetPeerInfos returns the peers connected to the node, with their protocols and latency
+func (j *jsonRPCHub) GetPeerInfos() []*jsonrpc.PeerInfo {
+	peers := j.Server.Peers()
+
+	infos := make([]*jsonrpc.PeerInfo, 0, len(peers))
+
+	for _, p := range peers {
+		id := p.Info.ID
+
+		// the peer might have just disconnected
+		protocols, _ := j.Server.GetProtocols(id)
+
+		addrs := make([]string, 0, len(p.Info.Addrs))
+		for _, addr := range p.Info.Addrs {
+			addrs = append(addrs, addr.String())
+		}
+
+		infos = append(infos, &jsonrpc.PeerInfo{
+			ID:        id.String(),
+			Addrs:     addrs,
+			Protocols: protocols,
+			Inbound:   j.Server.IsInboundPeer(id),
+			Static:    j.Server.IsStaticPeer(id),
+			Latency:   j.Server.PeerLatency(id),
+		})
+	}
+
+	return infos
+}
+
+// GetNodeInfo returns the network identity of the node
+func (j *jsonRPCHub) GetNodeInfo() *jsonrpc.NodeInfo {
+	addrInfo := j.Server.AddrInfo()
+
+	info := &jsonrpc.NodeInfo{
+		ID:          addrInfo.ID.String(),
+		P2PAddrs:    []string{},
+		ListenAddrs: []string{},
+	}
+
+	if p2pAddrs, err := peer.AddrInfoToP2pAddrs(addrInfo); err == nil {
+		for _, addr := range p2pAddrs {
+			info.P2PAddrs = append(info.P2PAddrs, addr.String())
+		}
+	}
+
+	for _, addr := range j.Server.ListenAddrs() {
+		info.ListenAddrs = append(info.ListenAddrs, addr.String())
+	}
+
+	return info
+}
+
 func (j *jsonRPCHub) getState(root types.Hash, slot []byte) ([]byte, error) {
 	// the values in the trie are the hashed objects of the keys
 	key := keccak.Keccak256(nil, slot)
@@ -545,12 +862,33 @@ func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*state.Acc
 	return &account, nil
 }
 
+// GetProof returns the merkle proof of the key, once hashed, in the state trie at the root
+func (j *jsonRPCHub) GetProof(root types.Hash, key []byte) ([][]byte, error) {
+	return itrie.Prove(j.stateStorage, root, keccak.Keccak256(nil, key))
+}
+
 // GetForksInTime returns the active forks at the given block height
 func (j *jsonRPCHub) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return j.Executor.GetForksInTime(blockNumber)
 }
 
 func (j *jsonRPCHub) GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error) {
+	// the slot is read from the trie when it is not covered by the snapshot
+	if j.stateSnapshot != nil {
+		obj, err := j.stateSnapshot.Storage(
+			root,
+			types.BytesToHash(keccak.Keccak256(nil, addr.Bytes())),
+			types.BytesToHash(keccak.Keccak256(nil, slot.Bytes())),
+		)
+		if err == nil {
+			if obj == nil {
+				return nil, jsonrpc.ErrStateNotFound
+			}
+
+			return obj, nil
+		}
+	}
+
 	account, err := j.GetAccount(root, addr)
 
 	if err != nil {
@@ -579,6 +917,7 @@ func (j *jsonRPCHub) GetCode(hash types.Hash) ([]byte, error) {
 func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
+	overrides state.Overrides,
 ) (result *runtime.ExecutionResult, err error) {
 	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
 	if err != nil {
@@ -591,6 +930,10 @@ func (j *jsonRPCHub) ApplyTxn(
 		return
 	}
 
+	if err = transition.ApplyOverrides(overrides); err != nil {
+		return
+	}
+
 	result, err = transition.Apply(txn)
 
 	return
@@ -654,18 +997,105 @@ func (j *jsonRPCHub) StateAtTransaction(block *types.Block, txIndex int) (*state
 	return nil, fmt.Errorf("transaction index %d out of range for block %s", txIndex, block.Hash())
 }
 
+// PendingState returns the pending block, made of the pending transactions of the pool
+// applied on top of the latest state in the order the block builder picks them, and its
+// transition. The pool is not altered, and the transition should not commit.
+func (j *jsonRPCHub) PendingState() (*types.Block, *state.Transition, error) {
+	parent := j.Header()
+
+	header := &types.Header{
+		ParentHash: parent.Hash,
+		Number:     parent.Number + 1,
+		Miner:      parent.Miner,
+		Sha3Uncles: types.EmptyUncleHash,
+		Difficulty: parent.Difficulty,
+		GasLimit:   parent.GasLimit,
+		Timestamp:  uint64(time.Now().Unix()),
+		BaseFee:    j.CalculateBaseFee(parent),
+	}
+
+	if header.Timestamp <= parent.Timestamp {
+		header.Timestamp = parent.Timestamp + 1
+	}
+
+	blockCreator, err := j.GetConsensus().GetBlockCreator(parent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transition, err := j.BeginTxn(parent.StateRoot, header, blockCreator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ordering := j.txOrdering
+	if ordering == nil {
+		ordering, _ = consensus.NewOrderingPolicy(consensus.DefaultOrderingPolicy)
+	}
+
+	var (
+		txs       []*types.Transaction
+		priceTxs  = ordering.Order(j.TxPool.Pending(), header.BaseFee)
+		allGasErr *state.AllGasUsedError
+	)
+
+	for tx := priceTxs.Peek(); tx != nil; tx = priceTxs.Peek() {
+		if tx.ExceedsBlockGasLimit(header.GasLimit) {
+			priceTxs.Pop()
+
+			continue
+		}
+
+		if err := transition.Write(tx); err != nil {
+			if errors.As(err, &allGasErr) {
+				break
+			}
+
+			// skip the account, its next transactions could not apply either
+			priceTxs.Pop()
+
+			continue
+		}
+
+		priceTxs.Shift()
+
+		txs = append(txs, tx)
+	}
+
+	header.GasUsed = transition.TotalGas()
+	header.ComputeHash()
+
+	return &types.Block{
+		Header:       header,
+		Transactions: txs,
+	}, transition, nil
+}
+
 // SETUP //
 
 // setupJSONRCP sets up the JSONRPC server, using the set configuration
 func (s *Server) setupJSONRPC() error {
+	txOrdering, err := consensus.NewOrderingPolicy(s.config.TxOrdering)
+	if err != nil {
+		return err
+	}
+
 	hub := &jsonRPCHub{
 		state:              s.state,
+		stateStorage:       s.stateStorage,
+		stateSnapshot:      s.stateSnapshot,
 		restoreProgression: s.restoreProgression,
+		txOrdering:         txOrdering,
 		Blockchain:         s.blockchain,
 		TxPool:             s.txpool,
 		Executor:           s.executor,
 		Consensus:          s.consensus,
 		Server:             s.network,
+		Oracle: gasprice.NewOracle(s.logger, s.blockchain, &gasprice.Config{
+			Blocks:     s.config.GasPriceOracle.Blocks,
+			Percentile: s.config.GasPriceOracle.Percentile,
+			Default:    new(big.Int).SetUint64(s.config.PriceLimit),
+		}),
 	}
 
 	// format the jsonrpc endpoint namespaces
@@ -681,10 +1111,21 @@ func (s *Server) setupJSONRPC() error {
 		AccessControlAllowOrigin: s.config.JSONRPC.AccessControlAllowOrigin,
 		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
+		LogLimit:                 s.config.JSONRPC.LogLimit,
 		JSONNamespaces:           namespaces,
 		EnableWS:                 s.config.JSONRPC.EnableWS,
 		PriceLimit:               s.config.PriceLimit,
 		Metrics:                  s.serverMetrics.jsonrpc,
+		WaitReady:                s.config.CacheWarmBlocks > 0,
+		VirtualHosts:             s.config.JSONRPC.VirtualHosts,
+		RateLimit:                s.config.JSONRPC.RateLimit,
+		MethodRateLimits:         s.config.JSONRPC.MethodRateLimits,
+		DisabledMethods:          s.config.JSONRPC.DisabledMethods,
+		TLSCertFile:              s.config.JSONRPC.TLSCertFile,
+		TLSKeyFile:               s.config.JSONRPC.TLSKeyFile,
+		TLSClientCAFile:          s.config.JSONRPC.TLSClientCAFile,
+		EnableAdmin:              s.config.JSONRPC.EnableAdmin,
+		AdminToken:               s.config.JSONRPC.AdminToken,
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -697,6 +1138,58 @@ func (s *Server) setupJSONRPC() error {
 	return nil
 }
 
+// setupExporter sets up the exporter of the block execution results, using the set configuration
+func (s *Server) setupExporter() error {
+	if s.config.Exporter == nil || s.config.Exporter.Sink == "" {
+		return nil
+	}
+
+	sink, err := exporter.NewSink(s.config.Exporter.Sink)
+	if err != nil {
+		return err
+	}
+
+	// keep the state changes of the latest blocks, so that they are not executed twice
+	if err := s.blockchain.EnableStateDiffs(exporter.StateDiffCacheSize); err != nil {
+		return err
+	}
+
+	s.exporter = exporter.NewExporter(s.logger, s.blockchain, sink, &exporter.Config{
+		CheckpointPath: filepath.Join(s.config.DataDir, "exporter.checkpoint"),
+		From:           s.config.Exporter.From,
+	})
+
+	return s.exporter.Start()
+}
+
+// setupAudit sets up the auditor of the imported blocks, using the set configuration
+func (s *Server) setupAudit() error {
+	if s.config.Audit == nil || s.config.Audit.Rules == "" {
+		return nil
+	}
+
+	rules, err := audit.LoadRules(s.config.Audit.Rules)
+	if err != nil {
+		return err
+	}
+
+	uri := s.config.Audit.Sink
+	if uri == "" {
+		uri = "file://" + filepath.Join(s.config.DataDir, "audit.log")
+	}
+
+	sink, err := exporter.NewSink(uri)
+	if err != nil {
+		return err
+	}
+
+	s.auditor = audit.NewAuditor(s.logger, s.blockchain, sink, rules, &audit.Config{
+		CheckpointPath: filepath.Join(s.config.DataDir, "audit.checkpoint"),
+	})
+
+	return s.auditor.Start()
+}
+
 // setupGraphQL sets up the graphql server, using the set configuration
 func (s *Server) setupGraphQL() error {
 	if !s.config.EnableGraphQL {
@@ -705,6 +1198,8 @@ func (s *Server) setupGraphQL() error {
 
 	hub := &jsonRPCHub{
 		state:              s.state,
+		stateStorage:       s.stateStorage,
+		stateSnapshot:      s.stateSnapshot,
 		restoreProgression: s.restoreProgression,
 		Blockchain:         s.blockchain,
 		TxPool:             s.txpool,
@@ -751,6 +1246,23 @@ func (s *Server) setupGRPC() error {
 	return nil
 }
 
+// setupHealer sets up the state healer, using the set configuration
+func (s *Server) setupHealer() {
+	s.healer = protocol.NewStateHealer(
+		s.logger,
+		s.network,
+		s.blockchain,
+		s.stateStorage,
+		s.serverMetrics.protocol,
+	)
+
+	s.healer.Start()
+
+	if s.config.HealState {
+		s.healer.Heal()
+	}
+}
+
 // Chain returns the chain object of the client
 func (s *Server) Chain() *chain.Chain {
 	return s.chain
@@ -763,6 +1275,30 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	// Close the exporter before the blockchain it reads
+	if s.exporter != nil {
+		if err := s.exporter.Close(); err != nil {
+			s.logger.Error("failed to close exporter", "err", err.Error())
+		}
+	}
+
+	// Close the auditor before the blockchain it reads
+	if s.auditor != nil {
+		if err := s.auditor.Close(); err != nil {
+			s.logger.Error("failed to close auditor", "err", err.Error())
+		}
+	}
+
+	// Stop healing before the state storage is closed
+	if s.healer != nil {
+		s.healer.Close()
+	}
+
+	// Stop pruning before the state storage is closed
+	if s.statePruner != nil {
+		s.statePruner.Close()
+	}
+
 	// Close the consensus layer
 	if err := s.consensus.Close(); err != nil {
 		s.logger.Error("failed to close consensus", "err", err.Error())
@@ -776,6 +1312,30 @@ func (s *Server) Close() {
 	// close the txpool's main loop
 	s.txpool.Close()
 
+	// Persist the head state in the snapshot once no block is committed anymore,
+	// then stop generating it before the state storage is closed
+	if s.stateSnapshot != nil {
+		if header := s.blockchain.Header(); header != nil {
+			if err := s.stateSnapshot.Flatten(header.StateRoot); err != nil {
+				s.logger.Warn("failed to persist the state snapshot", "err", err)
+			}
+		}
+
+		if err := s.stateSnapshot.Close(); err != nil {
+			s.logger.Error("failed to close state snapshot", "err", err.Error())
+		}
+	}
+
+	// Stop indexing the bloom bits before the blockchain is closed
+	if s.bloomIndexSub != nil {
+		s.bloomIndexSub.Close()
+	}
+
+	// Stop freezing before the blockchain is closed
+	if s.ancientFreezer != nil {
+		s.ancientFreezer.Close()
+	}
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
@@ -791,6 +1351,12 @@ func (s *Server) Close() {
 			s.logger.Error("Prometheus server shutdown error", err)
 		}
 	}
+
+	if s.statusServer != nil {
+		if err := s.statusServer.Shutdown(context.Background()); err != nil {
+			s.logger.Error("Status server shutdown error", err)
+		}
+	}
 }
 
 // Entry is a backend configuration entry
@@ -838,6 +1404,51 @@ func (s *Server) startPrometheusServer(listenAddr *net.TCPAddr) *http.Server {
 	return srv
 }
 
+func (s *Server) startStatusServer(listenAddr *net.TCPAddr, authToken string) *http.Server {
+	provider, ok := s.consensus.(consensus.StatusProvider)
+	if !ok {
+		s.logger.Warn("consensus engine does not provide a status page")
+
+		return nil
+	}
+
+	srv := &http.Server{
+		Addr:              listenAddr.String(),
+		Handler:           statusAuthHandler(authToken, provider.StatusHandler()),
+		ReadHeaderTimeout: time.Minute,
+	}
+
+	go func() {
+		s.logger.Info("Status server started", "addr=", listenAddr.String())
+
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("Status HTTP server ListenAndServe", "err", err)
+		}
+	}()
+
+	return srv
+}
+
+// statusAuthHandler protects the handler with basic auth, checking the password only.
+// No auth is required if the token is empty
+func statusAuthHandler(authToken string, next http.Handler) http.Handler {
+	if authToken == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, password, ok := r.BasicAuth()
+		if !ok || subtle.ConstantTimeCompare([]byte(password), []byte(authToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="status"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // createDir creates a file system directory if it doesn't exist
 func createDir(path string) error {
 	_, err := os.Stat(path)
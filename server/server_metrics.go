@@ -5,6 +5,7 @@ import (
 	"github.com/dogechain-lab/dogechain/consensus"
 	"github.com/dogechain-lab/dogechain/jsonrpc"
 	"github.com/dogechain-lab/dogechain/network"
+	"github.com/dogechain-lab/dogechain/protocol"
 	"github.com/dogechain-lab/dogechain/txpool"
 )
 
@@ -15,6 +16,7 @@ type serverMetrics struct {
 	network    *network.Metrics
 	txpool     *txpool.Metrics
 	jsonrpc    *jsonrpc.Metrics
+	protocol   *protocol.Metrics
 }
 
 // metricProvider serverMetric instance for the given ChainID and nameSpace
@@ -26,6 +28,7 @@ func metricProvider(nameSpace string, chainID string, metricsRequired bool) *ser
 			network:    network.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
 			txpool:     txpool.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
 			jsonrpc:    jsonrpc.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
+			protocol:   protocol.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
 		}
 	}
 
@@ -35,5 +38,6 @@ func metricProvider(nameSpace string, chainID string, metricsRequired bool) *ser
 		network:    network.NilMetrics(),
 		txpool:     txpool.NilMetrics(),
 		jsonrpc:    jsonrpc.NilMetrics(),
+		protocol:   protocol.NilMetrics(),
 	}
 }
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+)
+
+// SetHead rewinds the chain of the data directory to the block of the number, offline.
+// The blocks above it are deleted, and the state of the chain is the one of the block.
+func SetHead(config *Config, number uint64) (*blockchain.SetHeadResult, error) {
+	if _, err := os.Stat(filepath.Join(config.DataDir, "blockchain")); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoChainData, config.DataDir)
+	}
+
+	logger, err := newLoggerFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := newOfflineChain(config, logger, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer chain.Close()
+
+	return chain.blockchain.SetHead(number)
+}
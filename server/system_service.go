@@ -52,6 +52,11 @@ func (s *systemService) Subscribe(req *empty.Empty, stream proto.System_Subscrib
 			break
 		}
 
+		// a fork leaves the canonical chain untouched, its header never was canonical
+		if evnt.Type == blockchain.EventFork {
+			continue
+		}
+
 		pEvent := &proto.BlockchainEvent{
 			Added:   []*proto.BlockchainEvent_Header{},
 			Removed: []*proto.BlockchainEvent_Header{},
@@ -171,6 +176,50 @@ func (s *systemService) BlockByNumber(
 	}, nil
 }
 
+// BridgeSigners implements the BridgeSigners operator service
+func (s *systemService) BridgeSigners(ctx context.Context, req *empty.Empty) (*proto.BridgeSignersResponse, error) {
+	header := s.server.blockchain.Header()
+
+	signers, err := s.server.blockchain.BridgeSigners(header)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.BridgeSignersResponse{
+		Number:  header.Number,
+		Signers: make([]string, 0, len(signers)),
+	}
+
+	for _, signer := range signers {
+		resp.Signers = append(resp.Signers, signer.String())
+	}
+
+	return resp, nil
+}
+
+// BadBlocks implements the BadBlocks operator service
+func (s *systemService) BadBlocks(ctx context.Context, req *proto.BadBlocksRequest) (*proto.BadBlocksResponse, error) {
+	blocks := s.server.blockchain.BadBlocks()
+	if req.Limit != 0 && uint64(len(blocks)) > req.Limit {
+		blocks = blocks[:req.Limit]
+	}
+
+	resp := &proto.BadBlocksResponse{
+		Blocks: make([]*proto.BadBlocksResponse_BadBlock, 0, len(blocks)),
+	}
+
+	for _, block := range blocks {
+		resp.Blocks = append(resp.Blocks, &proto.BadBlocksResponse_BadBlock{
+			Number:    block.Number,
+			Hash:      block.Hash.String(),
+			Reason:    block.Reason,
+			Timestamp: block.Timestamp,
+		})
+	}
+
+	return resp, nil
+}
+
 func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_ExportServer) error {
 	var (
 		from uint64 = 0
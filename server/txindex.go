@@ -0,0 +1,35 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dogechain-lab/dogechain/blockchain"
+)
+
+// RebuildTxIndex rewrites the transaction lookups of the chain of the data directory, offline.
+func RebuildTxIndex(config *Config) (*blockchain.TxIndexResult, error) {
+	if _, err := os.Stat(filepath.Join(config.DataDir, "blockchain")); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoChainData, config.DataDir)
+	}
+
+	logger, err := newLoggerFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	chainBuilder, err := newChainStorageBuilder(logger, config)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := chainBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	return blockchain.RebuildTxIndex(db, logger.Named("txindex"))
+}
@@ -12,17 +12,22 @@ import (
 	"github.com/dogechain-lab/dogechain/contracts/bridge"
 	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
 	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/helper/errcode"
 	"github.com/dogechain-lab/dogechain/state/runtime"
 	"github.com/dogechain-lab/dogechain/state/runtime/evm"
+	"github.com/dogechain-lab/dogechain/state/runtime/precompiled"
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/hashicorp/go-hclog"
 )
 
 const (
-	spuriousDragonMaxCodeSize = 24576
-
-	TxGas                 uint64 = 21000 // Per transaction not creating a contract
-	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
+	TxGas                    uint64 = 21000 // Per transaction not creating a contract
+	TxGasContractCreation    uint64 = 53000 // Per transaction that creates a contract
+	TxDataZeroGas            uint64 = 4     // Per byte of data attached to a transaction that equals zero
+	TxDataNonZeroGasFrontier uint64 = 68    // Per byte of data attached to a transaction that is not equal to zero
+	TxDataNonZeroGasEIP2028  uint64 = 16    // Per byte of non zero data attached to a transaction after EIP 2028 (Istanbul)
+	TxAccessListAddressGas   uint64 = 2400  // Per address specified in the access list (EIP 2930)
+	TxAccessListStorageGas   uint64 = 1900  // Per storage key specified in the access list (EIP 2930)
 )
 
 var emptyCodeHashTwo = types.BytesToHash(crypto.Keccak256(nil))
@@ -41,6 +46,13 @@ type Executor struct {
 	GetHash  GetHashByNumberHelper
 	stopped  uint32 // atomic flag for stopping
 
+	// parallelWorkers is the number of workers executing the transactions
+	// of a block concurrently, the block is executed serially below two
+	parallelWorkers int
+
+	// prefetcher warms the state of the blocks being executed, nil when disabled
+	prefetcher *prefetcher
+
 	PostHook func(txn *Transition)
 }
 
@@ -81,6 +93,11 @@ func (e *Executor) WriteGenesis(alloc map[types.Address]*chain.GenesisAccount) t
 	return types.BytesToHash(root)
 }
 
+// EnablePrefetch prefetches the state a block is expected to access while it is executed
+func (e *Executor) EnablePrefetch() {
+	e.prefetcher = newPrefetcher()
+}
+
 // SetRuntime adds a runtime to the runtime set
 func (e *Executor) SetRuntime(r runtime.Runtime) {
 	e.runtimes = append(e.runtimes, r)
@@ -105,26 +122,52 @@ func (e *Executor) ProcessBlock(
 
 	txn.block = block
 
-	for _, t := range block.Transactions {
+	if e.prefetcher != nil {
+		txn.state.trackAccesses()
+
+		stop := e.prefetcher.start(e.state, txn.state.snapshot, block)
+		defer stop()
+	}
+
+	if e.canProcessParallel(txn) {
+		err = e.processParallel(parentRoot, txn)
+	} else {
+		err = e.processSerial(txn)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if e.prefetcher != nil {
+		e.prefetcher.record(txn.state.accessed)
+	}
+
+	return txn, nil
+}
+
+// processSerial executes the transactions of the block in order
+func (e *Executor) processSerial(txn *Transition) error {
+	for _, t := range txn.block.Transactions {
 		if e.IsStopped() {
 			// halt more elegantly
-			return nil, ErrExecutionStop
+			return ErrExecutionStop
 		}
 
-		if t.ExceedsBlockGasLimit(block.Header.GasLimit) {
+		if t.ExceedsBlockGasLimit(txn.block.Header.GasLimit) {
 			if err := txn.WriteFailedReceipt(t); err != nil {
-				return nil, err
+				return err
 			}
 
 			continue
 		}
 
 		if err := txn.Write(t); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return txn, nil
+	return nil
 }
 
 func (e *Executor) IsStopped() bool {
@@ -147,7 +190,7 @@ func (e *Executor) StateAt(root types.Hash) (Snapshot, error) {
 
 // GetForksInTime returns the active forks at the given block height
 func (e *Executor) GetForksInTime(blockNumber uint64) chain.ForksInTime {
-	return e.config.Forks.At(blockNumber)
+	return e.config.ForksInTime(blockNumber)
 }
 
 func (e *Executor) BeginTxn(
@@ -155,7 +198,7 @@ func (e *Executor) BeginTxn(
 	header *types.Header,
 	coinbaseReceiver types.Address,
 ) (*Transition, error) {
-	config := e.config.Forks.At(header.Number)
+	config := e.config.ForksInTime(header.Number)
 
 	auxSnap2, err := e.state.NewSnapshotAt(parentRoot)
 	if err != nil {
@@ -171,6 +214,7 @@ func (e *Executor) BeginTxn(
 		Difficulty: types.BytesToHash(new(big.Int).SetUint64(header.Difficulty).Bytes()),
 		GasLimit:   int64(header.GasLimit),
 		ChainID:    int64(e.config.ChainID),
+		BaseFee:    types.BytesToHash(new(big.Int).SetUint64(header.BaseFee).Bytes()),
 	}
 
 	txn := &Transition{
@@ -212,6 +256,10 @@ type Transition struct {
 	receipts []*types.Receipt
 	totalGas uint64
 
+	// coinbaseFee collects the fees of the coinbase instead of crediting them,
+	// so that the speculative executions do not all write the coinbase
+	coinbaseFee *big.Int
+
 	// evmLogger for debugging, set a dummy logger to 'collect' tracing,
 	// then we wouldn't have to judge any tracing flag
 	evmLogger runtime.EVMLogger
@@ -240,6 +288,14 @@ func (t *Transition) TotalGas() uint64 {
 	return t.totalGas
 }
 
+// Prewarm reads the accounts, so that the trie nodes leading to them are resolved
+// before the transactions touching them get executed
+func (t *Transition) Prewarm(addrs []types.Address) {
+	for _, addr := range addrs {
+		t.state.GetAccount(addr)
+	}
+}
+
 func (t *Transition) Receipts() []*types.Receipt {
 	return t.receipts
 }
@@ -299,9 +355,17 @@ func (t *Transition) Write(txn *types.Transaction) error {
 		return e
 	}
 
-	t.totalGas += result.GasUsed
+	return t.writeReceipt(txn, msg, result, t.state.Logs())
+}
 
-	logs := t.state.Logs()
+// writeReceipt writes the receipt of the applied transaction, with the logs it emitted
+func (t *Transition) writeReceipt(
+	txn *types.Transaction,
+	msg *types.Transaction,
+	result *runtime.ExecutionResult,
+	logs []*types.Log,
+) error {
+	t.totalGas += result.GasUsed
 
 	var root []byte
 
@@ -345,6 +409,39 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	return nil
 }
 
+var errUnauthorizedBridge = errors.New("unauthorized bridge transaction")
+
+// isBridgeAuthorized returns whether the sender is allowed to emit the bridge logs.
+// Once the bridge allowlist fork is active, bridge logs must be emitted by the bridge
+// contract, and only the signers of the bridge contract could mint or burn coins.
+func (t *Transition) isBridgeAuthorized(msg *types.Transaction, logs []*types.Log) bool {
+	if !t.config.BridgeAllowlist ||
+		msg.To == nil ||
+		*msg.To != systemcontracts.AddrBridgeContract {
+		return true
+	}
+
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+
+		switch log.Topics[0] {
+		case bridge.BridgeDepositedEventID, bridge.BridgeBurnedEventID:
+			if log.Address != systemcontracts.AddrBridgeContract ||
+				!bridge.IsSigner(t.state, msg.From) {
+				return false
+			}
+		case bridge.BridgeWithdrawnEventID:
+			if log.Address != systemcontracts.AddrBridgeContract {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 func (t *Transition) handleBridgeLogs(msg *types.Transaction, logs []*types.Log) error {
 	// filter bridge contract logs
 	if len(logs) == 0 ||
@@ -404,6 +501,13 @@ func (t *Transition) Commit() (Snapshot, types.Hash) {
 	return s2, types.BytesToHash(root)
 }
 
+// CommitWithObjects commits the final result, and also returns the state changes
+func (t *Transition) CommitWithObjects() (Snapshot, types.Hash, []*Object) {
+	s2, root, objs := t.state.CommitWithObjects(t.config.EIP155)
+
+	return s2, types.BytesToHash(root), objs
+}
+
 func (t *Transition) subGasPool(amount uint64) error {
 	if t.gasPool < amount {
 		return ErrBlockLimitReached
@@ -452,9 +556,20 @@ func (t *Transition) ContextPtr() *runtime.TxContext {
 	return &t.ctx
 }
 
-func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
+func (t *Transition) subGasLimitPrice(msg *types.Transaction, gasPrice *big.Int) error {
+	// the balance must cover the gas at the fee cap and the value of a dynamic fee transaction,
+	// before the gas is bought at the effective price (EIP-1559)
+	if msg.IsDynamicFee() {
+		balanceCheck := new(big.Int).Mul(msg.GasFeeCap, new(big.Int).SetUint64(msg.Gas))
+		balanceCheck.Add(balanceCheck, msg.Value)
+
+		if t.state.GetBalance(msg.From).Cmp(balanceCheck) < 0 {
+			return ErrNotEnoughFundsForGas
+		}
+	}
+
 	// deduct the upfront max gas cost
-	upfrontGasCost := new(big.Int).Set(msg.GasPrice)
+	upfrontGasCost := new(big.Int).Set(gasPrice)
 	upfrontGasCost.Mul(upfrontGasCost, new(big.Int).SetUint64(msg.Gas))
 
 	if err := t.state.SubBalance(msg.From, upfrontGasCost); err != nil {
@@ -468,6 +583,38 @@ func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	return nil
 }
 
+// baseFee returns the base fee of the block, zero before the london fork
+func (t *Transition) baseFee() uint64 {
+	return new(big.Int).SetBytes(t.ctx.BaseFee.Bytes()).Uint64()
+}
+
+// feeCheck checks the transaction pays at least the base fee of the block
+func (t *Transition) feeCheck(msg *types.Transaction) error {
+	if msg.Type == types.AccessListTx && !t.config.Berlin {
+		return ErrTxTypeNotSupported
+	}
+
+	if msg.IsDynamicFee() {
+		if !t.config.London {
+			return ErrTxTypeNotSupported
+		}
+
+		if msg.GasTipCap.Cmp(msg.GasFeeCap) > 0 {
+			return ErrTipAboveFeeCap
+		}
+	}
+
+	if !t.config.London {
+		return nil
+	}
+
+	if baseFee := new(big.Int).SetUint64(t.baseFee()); msg.GetGasFeeCap().Cmp(baseFee) < 0 {
+		return fmt.Errorf("%w, feeCap: %s, baseFee: %s", ErrFeeCapTooLow, msg.GetGasFeeCap(), baseFee)
+	}
+
+	return nil
+}
+
 func (t *Transition) nonceCheck(msg *types.Transaction) error {
 	nonce := t.state.GetNonce(msg.From)
 
@@ -484,14 +631,17 @@ func (t *Transition) nonceCheck(msg *types.Transaction) error {
 // surfacing of these errors reject the transaction thus not including it in the block
 
 var (
-	ErrNonceIncorrect        = errors.New("incorrect nonce")
-	ErrNotEnoughFundsForGas  = errors.New("not enough funds to cover gas costs")
-	ErrBlockLimitReached     = errors.New("gas limit reached in the pool")
-	ErrIntrinsicGasOverflow  = errors.New("overflow in intrinsic gas calculation")
-	ErrNotEnoughIntrinsicGas = errors.New("not enough gas supplied for intrinsic gas costs")
-	ErrNotEnoughFunds        = errors.New("not enough funds for transfer with given value")
-	ErrAllGasUsed            = errors.New("all gas used")
-	ErrExecutionStop         = errors.New("execution stop")
+	ErrNonceIncorrect        = errcode.New(errcode.ExecutorNonceIncorrect, "incorrect nonce")
+	ErrNotEnoughFundsForGas  = errcode.New(errcode.ExecutorNotEnoughFundsForGas, "not enough funds to cover gas costs")
+	ErrBlockLimitReached     = errcode.New(errcode.ExecutorBlockLimitReached, "gas limit reached in the pool")
+	ErrIntrinsicGasOverflow  = errcode.New(errcode.ExecutorIntrinsicGasOverflow, "overflow in intrinsic gas calculation")
+	ErrNotEnoughIntrinsicGas = errcode.New(errcode.ExecutorNotEnoughIntrinsicGas, "not enough gas supplied for intrinsic gas costs")
+	ErrNotEnoughFunds        = errcode.New(errcode.ExecutorNotEnoughFunds, "not enough funds for transfer with given value")
+	ErrAllGasUsed            = errcode.New(errcode.ExecutorAllGasUsed, "all gas used")
+	ErrExecutionStop         = errcode.New(errcode.ExecutorExecutionStop, "execution stop")
+	ErrTxTypeNotSupported    = errcode.New(errcode.ExecutorTxTypeNotSupported, "transaction type not supported")
+	ErrTipAboveFeeCap        = errcode.New(errcode.ExecutorTipAboveFeeCap, "max priority fee per gas higher than max fee per gas")
+	ErrFeeCapTooLow          = errcode.New(errcode.ExecutorFeeCapTooLow, "max fee per gas less than block base fee")
 )
 
 type TransitionApplicationError struct {
@@ -503,6 +653,11 @@ func (e *TransitionApplicationError) Error() string {
 	return e.Err.Error()
 }
 
+// Unwrap returns the underlying error, so that errors.Is matches the consensus rule errors
+func (e *TransitionApplicationError) Unwrap() error {
+	return e.Err
+}
+
 func NewTransitionApplicationError(err error, isRecoverable bool) *TransitionApplicationError {
 	return &TransitionApplicationError{
 		Err:           err,
@@ -560,15 +715,20 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	//
 	// 0. the basic amount of gas is required
 	// 1. the nonce of the message caller is correct
-	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
+	// 1.1 the fee cap of the message covers the base fee (london)
+	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice),
+	//    and gaslimit * feecap + value for a dynamic fee transaction (london)
 	// 3. the amount of gas required is available in the block
 	// 4. there is no overflow when calculating intrinsic gas
 	// 5. the purchased gas is enough to cover intrinsic usage
 	// 6. caller has enough balance to cover asset transfer for **topmost** call
 	txn := t.state
 
+	// the price paid per gas, which is the gas price of a legacy transaction
+	gasPrice := msg.EffectiveGasPrice(t.baseFee())
+
 	t.logger.Debug("try to apply transaction",
-		"hash", msg.Hash, "from", msg.From, "nonce", msg.Nonce, "price", msg.GasPrice.String(),
+		"hash", msg.Hash, "from", msg.From, "nonce", msg.Nonce, "price", gasPrice.String(),
 		"remainingGas", t.gasPool, "wantGas", msg.Gas)
 
 	// 0. the basic amount of gas is required
@@ -581,8 +741,13 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		return nil, err // the error already formatted
 	}
 
+	// 1.1 the fee cap of the message covers the base fee (london)
+	if err := t.feeCheck(msg); err != nil {
+		return nil, NewTransitionApplicationError(err, false)
+	}
+
 	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
-	if err := t.subGasLimitPrice(msg); err != nil {
+	if err := t.subGasLimitPrice(msg, gasPrice); err != nil {
 		// It is not recoverable. All the transactions after that should be dropped
 		return nil, NewTransitionApplicationError(err, true)
 	}
@@ -613,19 +778,36 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		return nil, NewTransitionApplicationError(ErrNotEnoughFunds, true)
 	}
 
-	gasPrice := new(big.Int).Set(msg.GasPrice)
 	value := new(big.Int).Set(msg.Value)
 
 	// Set the specific transaction fields in the context
 	t.ctx.GasPrice = types.BytesToHash(gasPrice.Bytes())
 	t.ctx.Origin = msg.From
 
+	// warm the accounts and slots the transaction accesses first (berlin)
+	if t.config.Berlin {
+		t.prepareAccessList(msg)
+	}
+
 	var result *runtime.ExecutionResult
 	if msg.IsContractCreation() {
 		result = t.Create2(msg.From, msg.Input, value, gasLeft)
 	} else {
 		txn.IncrNonce(msg.From)
+
+		snapshot := txn.Snapshot()
 		result = t.Call2(msg.From, *msg.To, msg.Input, value, gasLeft)
+
+		// mints and burns of bridged coins are only honored for the bridge signers, the
+		// effects of the unauthorized transaction are reverted and only its gas is charged
+		if !result.Failed() && !t.isBridgeAuthorized(msg, txn.peekLogs()) {
+			t.logger.Warn("unauthorized bridge transaction", "hash", msg.Hash, "from", msg.From)
+
+			txn.RevertToSnapshot(snapshot)
+
+			result.Err = errUnauthorizedBridge
+			result.ReturnValue = nil
+		}
 	}
 
 	refund := txn.GetRefund()
@@ -635,9 +817,13 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
 	txn.AddBalance(msg.From, remaining)
 
-	// pay the coinbase
-	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), gasPrice)
-	txn.AddBalance(t.ctx.Coinbase, coinbaseFee)
+	// pay the coinbase the tip, the base fee is burnt (london)
+	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), msg.EffectiveTip(t.baseFee()))
+	if t.coinbaseFee != nil {
+		t.coinbaseFee.Add(t.coinbaseFee, coinbaseFee)
+	} else {
+		txn.AddBalance(t.ctx.Coinbase, coinbaseFee)
+	}
 
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
@@ -645,6 +831,30 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	return result, nil
 }
 
+// prepareAccessList resets the access list for the transaction, warming its sender,
+// its destination, the precompiled contracts and its own access list (EIP-2929, EIP-2930)
+func (t *Transition) prepareAccessList(msg *types.Transaction) {
+	t.state.ClearAccessList()
+
+	t.state.AddAddressToAccessList(msg.From)
+
+	if msg.To != nil {
+		t.state.AddAddressToAccessList(*msg.To)
+	}
+
+	for _, addr := range precompiled.ActiveAddresses(&t.config) {
+		t.state.AddAddressToAccessList(addr)
+	}
+
+	for _, tuple := range msg.AccessList {
+		t.state.AddAddressToAccessList(tuple.Address)
+
+		for _, key := range tuple.StorageKeys {
+			t.state.AddSlotToAccessList(tuple.Address, key)
+		}
+	}
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -715,22 +925,25 @@ func (t *Transition) applyCall(
 	var result *runtime.ExecutionResult
 
 	if t.needDebug {
-		if c.Depth == 0 {
+		if c.Depth == 1 {
 			t.evmLogger.CaptureStart(t.Txn(), c.Caller, c.Address, false, c.Input, c.Gas, c.Value)
 
-			defer func(result *runtime.ExecutionResult) {
+			start := time.Now()
+
+			// the result is read once the call returns
+			defer func() {
 				if result != nil {
-					t.evmLogger.CaptureEnd(result.ReturnValue, result.GasUsed, time.Since(time.Now()), result.Err)
+					t.evmLogger.CaptureEnd(result.ReturnValue, c.Gas-result.GasLeft, time.Since(start), result.Err)
 				}
-			}(result)
+			}()
 		} else {
 			t.evmLogger.CaptureEnter(int(evm.RuntimeType2OpCode(callType)), c.Caller, c.Address, c.Input, c.Gas, c.Value)
 
-			defer func(result *runtime.ExecutionResult) {
+			defer func() {
 				if result != nil {
-					t.evmLogger.CaptureExit(result.ReturnValue, result.GasUsed, result.Err)
+					t.evmLogger.CaptureExit(result.ReturnValue, c.Gas-result.GasLeft, result.Err)
 				}
-			}(result)
+			}()
 		}
 	}
 
@@ -788,6 +1001,11 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	// Increment the nonce of the caller
 	t.state.IncrNonce(c.Caller)
 
+	// The created address is warm, even if the creation fails (berlin)
+	if t.config.Berlin {
+		t.state.AddAddressToAccessList(c.Address)
+	}
+
 	// Check if there if there is a collision and the address already exists
 	if t.hasCodeOrNonce(c.Address) {
 		return &runtime.ExecutionResult{
@@ -808,22 +1026,25 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	var result *runtime.ExecutionResult
 
 	if t.needDebug {
-		if c.Depth == 0 {
+		if c.Depth == 1 {
 			t.evmLogger.CaptureStart(t.Txn(), c.Caller, c.Address, true, c.Input, c.Gas, c.Value)
 
-			defer func(result *runtime.ExecutionResult) {
+			start := time.Now()
+
+			// the result is read once the call returns
+			defer func() {
 				if result != nil {
-					t.evmLogger.CaptureEnd(result.ReturnValue, result.GasUsed, time.Since(time.Now()), result.Err)
+					t.evmLogger.CaptureEnd(result.ReturnValue, c.Gas-result.GasLeft, time.Since(start), result.Err)
 				}
-			}(result)
+			}()
 		} else {
 			t.evmLogger.CaptureEnter(int(evm.RuntimeType2OpCode(c.Type)), c.Caller, c.Address, c.Input, c.Gas, c.Value)
 
-			defer func(result *runtime.ExecutionResult) {
+			defer func() {
 				if result != nil {
-					t.evmLogger.CaptureExit(result.ReturnValue, result.GasUsed, result.Err)
+					t.evmLogger.CaptureExit(result.ReturnValue, c.Gas-result.GasLeft, result.Err)
 				}
-			}(result)
+			}()
 		}
 	}
 
@@ -845,17 +1066,19 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		return result
 	}
 
-	if t.config.EIP158 && len(result.ReturnValue) > spuriousDragonMaxCodeSize {
+	if t.config.EIP158 && uint64(len(result.ReturnValue)) > t.maxCodeSize() {
 		// Contract size exceeds 'SpuriousDragon' size limit
 		t.state.RevertToSnapshot(snapshot)
 
-		return &runtime.ExecutionResult{
+		result = &runtime.ExecutionResult{
 			GasLeft: 0,
 			Err:     runtime.ErrMaxCodeSizeExceeded,
 		}
+
+		return result
 	}
 
-	gasCost := uint64(len(result.ReturnValue)) * 200
+	gasCost := uint64(len(result.ReturnValue)) * t.codeDepositGas()
 
 	if result.GasLeft < gasCost {
 		result.Err = runtime.ErrCodeStoreOutOfGas
@@ -877,6 +1100,24 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	return result
 }
 
+// maxCodeSize returns the maximum size of the contract code, the EIP-170 one by default
+func (t *Transition) maxCodeSize() uint64 {
+	if t.config.MaxCodeSize == 0 {
+		return chain.DefaultMaxCodeSize
+	}
+
+	return t.config.MaxCodeSize
+}
+
+// codeDepositGas returns the cost per byte of the code stored by a contract creation
+func (t *Transition) codeDepositGas() uint64 {
+	if costs := t.config.GasCosts; costs != nil && costs.CodeDeposit != 0 {
+		return costs.CodeDeposit
+	}
+
+	return 200
+}
+
 func (t *Transition) SetStorage(
 	addr types.Address,
 	key types.Hash,
@@ -939,6 +1180,22 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 	t.state.Suicide(addr)
 }
 
+func (t *Transition) AddressInAccessList(addr types.Address) bool {
+	return t.state.AddressInAccessList(addr)
+}
+
+func (t *Transition) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	return t.state.SlotInAccessList(addr, slot)
+}
+
+func (t *Transition) AddAddressToAccessList(addr types.Address) {
+	t.state.AddAddressToAccessList(addr)
+}
+
+func (t *Transition) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	t.state.AddSlotToAccessList(addr, slot)
+}
+
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
 	if c.Type == runtime.Create {
 		return t.applyCreate(c, h)
@@ -966,6 +1223,8 @@ func (t *Transition) SetAccountDirectly(addr types.Address, account *chain.Genes
 	return nil
 }
 
+// TransactionGasCost returns the intrinsic gas of the transaction, which is charged
+// before any execution. Both the txpool and the executor rely on it.
 func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (uint64, error) {
 	cost := uint64(0)
 
@@ -987,10 +1246,10 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 		}
 
 		nonZeros := uint64(len(payload)) - zeros
-		nonZeroCost := uint64(68)
+		nonZeroCost := TxDataNonZeroGasFrontier
 
 		if isIstanbul {
-			nonZeroCost = 16
+			nonZeroCost = TxDataNonZeroGasEIP2028
 		}
 
 		if (math.MaxUint64-cost)/nonZeroCost < nonZeros {
@@ -999,11 +1258,22 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 
 		cost += nonZeros * nonZeroCost
 
-		if (math.MaxUint64-cost)/4 < zeros {
+		if (math.MaxUint64-cost)/TxDataZeroGas < zeros {
+			return 0, ErrIntrinsicGasOverflow
+		}
+
+		cost += zeros * TxDataZeroGas
+	}
+
+	// the access list is only carried by dynamic fee transactions
+	for _, tuple := range msg.AccessList {
+		keys := uint64(len(tuple.StorageKeys))
+
+		if (math.MaxUint64-cost-TxAccessListAddressGas)/TxAccessListStorageGas < keys {
 			return 0, ErrIntrinsicGasOverflow
 		}
 
-		cost += zeros * 4
+		cost += TxAccessListAddressGas + keys*TxAccessListStorageGas
 	}
 
 	return cost, nil
@@ -0,0 +1,142 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/dogechain-lab/fastrlp"
+)
+
+var ErrMissingPreimage = errors.New("missing preimage")
+
+// dumpRangeSize is the number of leaves read at once while dumping a trie
+const dumpRangeSize = 1024
+
+// DumpAccount is an account of a state, along with its code and storage
+type DumpAccount struct {
+	Address types.Address
+	Nonce   uint64
+	Balance *big.Int
+	Code    []byte
+	Storage map[types.Hash]types.Hash
+}
+
+// preimageKey returns the key of the preimage of the hashed trie key
+func preimageKey(hash []byte) []byte {
+	return append(append([]byte{}, preimagePrefix...), hash...)
+}
+
+// ReadPreimage returns the key hashed into the trie key, recorded when it was committed
+func ReadPreimage(storage Storage, hash []byte) ([]byte, bool, error) {
+	return storage.Get(preimageKey(hash))
+}
+
+// DumpState calls fn with the accounts of the state of the root, in the order of their
+// hashed addresses. The addresses and the storage slots are recovered from the preimages
+// recorded on commit, so that the states written by a state sync cannot be dumped
+func DumpState(storage Storage, root types.Hash, fn func(*DumpAccount) error) error {
+	return iterateLeaves(storage, root, func(key, value []byte) error {
+		account := &state.Account{}
+		if err := account.UnmarshalRlp(value); err != nil {
+			return err
+		}
+
+		address, ok, err := ReadPreimage(storage, key)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("%w: account %x", ErrMissingPreimage, key)
+		}
+
+		dump := &DumpAccount{
+			Address: types.BytesToAddress(address),
+			Nonce:   account.Nonce,
+			Balance: account.Balance,
+			Storage: map[types.Hash]types.Hash{},
+		}
+
+		if codeHash := types.BytesToHash(account.CodeHash); codeHash != emptyCodeHash {
+			if dump.Code, ok = storage.GetCode(codeHash); !ok {
+				return fmt.Errorf("code %s of account %s not found", codeHash, dump.Address)
+			}
+		}
+
+		parser := &fastrlp.Parser{}
+
+		if err := iterateLeaves(storage, account.Root, func(key, value []byte) error {
+			slot, ok, err := ReadPreimage(storage, key)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return fmt.Errorf("%w: slot %x of account %s", ErrMissingPreimage, key, dump.Address)
+			}
+
+			v, err := parser.Parse(value)
+			if err != nil {
+				return err
+			}
+
+			data, err := v.Bytes()
+			if err != nil {
+				return err
+			}
+
+			dump.Storage[types.BytesToHash(slot)] = types.BytesToHash(data)
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		return fn(dump)
+	})
+}
+
+// iterateLeaves calls fn with the leaves of the trie of the root, in key order
+func iterateLeaves(storage Storage, root types.Hash, fn func(key, value []byte) error) error {
+	var origin []byte
+
+	for {
+		keys, values, more, err := ReadRange(storage, root, origin, dumpRangeSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range keys {
+			if err := fn(keys[i], values[i]); err != nil {
+				return err
+			}
+		}
+
+		if !more || len(keys) == 0 {
+			return nil
+		}
+
+		origin = nextLeafKey(keys[len(keys)-1])
+		if origin == nil {
+			return nil
+		}
+	}
+}
+
+// nextLeafKey returns the key following the key, nil if it is the last one
+func nextLeafKey(key []byte) []byte {
+	next := append([]byte{}, key...)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+
+		if next[i] != 0 {
+			return next
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDumpState(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	snap, err := NewState(storage).NewSnapshotAt(types.EmptyRootHash)
+	assert.NoError(t, err)
+
+	// more accounts than read at once
+	objs := []*state.Object{}
+
+	for i := 0; i < dumpRangeSize+100; i++ {
+		objs = append(objs, &state.Object{
+			Address:  types.StringToAddress(big.NewInt(int64(i + 1)).String()),
+			Balance:  big.NewInt(int64(1000 + i)),
+			Nonce:    uint64(i),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+		})
+	}
+
+	code := []byte{0x60, 0x01, 0x60, 0x00, 0x55}
+	contract := &state.Object{
+		Address:   types.StringToAddress("0xc0de"),
+		Balance:   big.NewInt(0),
+		Nonce:     1,
+		Root:      types.EmptyRootHash,
+		CodeHash:  types.BytesToHash(crypto.Keccak256(code)),
+		DirtyCode: true,
+		Code:      code,
+		Storage: []*state.StorageObject{
+			{Key: types.StringToHash("1").Bytes(), Val: types.StringToHash("0x2a").Bytes()},
+			{Key: types.StringToHash("2").Bytes(), Val: types.StringToHash("0xff00").Bytes()},
+		},
+	}
+	objs = append(objs, contract)
+
+	_, root := snap.Commit(objs)
+
+	dumped := map[types.Address]*DumpAccount{}
+
+	assert.NoError(t, DumpState(storage, types.BytesToHash(root), func(account *DumpAccount) error {
+		dumped[account.Address] = account
+
+		return nil
+	}))
+
+	assert.Len(t, dumped, len(objs))
+
+	account := dumped[objs[7].Address]
+	assert.Equal(t, uint64(7), account.Nonce)
+	assert.Equal(t, big.NewInt(1007), account.Balance)
+	assert.Empty(t, account.Code)
+	assert.Empty(t, account.Storage)
+
+	account = dumped[contract.Address]
+	assert.Equal(t, code, account.Code)
+	assert.Equal(t, map[types.Hash]types.Hash{
+		types.StringToHash("1"): types.StringToHash("0x2a"),
+		types.StringToHash("2"): types.StringToHash("0xff00"),
+	}, account.Storage)
+}
+
+func TestDumpState_MissingPreimage(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	// the states written by range hold no preimages
+	account := &state.Account{
+		Balance:  big.NewInt(1),
+		Root:     types.EmptyRootHash,
+		CodeHash: crypto.Keccak256(nil),
+	}
+
+	arena := accountArenaPool.Get()
+	defer accountArenaPool.Put(arena)
+
+	key := crypto.Keccak256(types.StringToAddress("1").Bytes())
+
+	root, err := WriteRange(storage, types.EmptyRootHash, [][]byte{key}, [][]byte{account.MarshalWith(arena).MarshalTo(nil)})
+	assert.NoError(t, err)
+
+	err = DumpState(storage, root, func(*DumpAccount) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrMissingPreimage)
+}
@@ -0,0 +1,123 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/state/runtime/evm"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// counterCode increments the slot 0 and sets the slot of the caller:
+// sstore(0, sload(0) + 1); sstore(caller, 1)
+var counterCode = []byte{
+	0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x60, 0x00, 0x55,
+	0x60, 0x01, 0x33, 0x55, 0x00,
+}
+
+// buildCounterState commits the funded senders and the counter contract with filled
+// storage, so that the tries opened on it load their nodes while they are read
+func buildCounterState(t *testing.T, storage Storage, senders []types.Address, counter types.Address) types.Hash {
+	t.Helper()
+
+	st := NewState(storage)
+	txn := state.NewTxn(st, st.NewSnapshot())
+
+	for _, sender := range senders {
+		txn.SetBalance(sender, big.NewInt(1000000000))
+	}
+
+	txn.SetCode(counter, counterCode)
+
+	for i := 1; i <= 256; i++ {
+		slot := types.BytesToHash(big.NewInt(int64(i)).Bytes())
+		txn.SetState(counter, slot, slot)
+	}
+
+	_, root := txn.Commit(true)
+
+	return types.BytesToHash(root)
+}
+
+func TestExecutor_ProcessBlock_Parallel(t *testing.T) {
+	counter := types.StringToAddress("c0de")
+	coinbase := types.StringToAddress("c01bba5e")
+
+	senders := []types.Address{}
+	for i := 1; i <= 32; i++ {
+		senders = append(senders, types.BytesToAddress(big.NewInt(int64(i)).Bytes()))
+	}
+
+	storage := NewMemoryStorage()
+	parentRoot := buildCounterState(t, storage, senders, counter)
+
+	process := func(workers int) *state.Transition {
+		// the state opened on the storage loads the tries from it
+		executor := state.NewExecutor(
+			&chain.Params{Forks: chain.AllForksEnabled, ChainID: 100},
+			NewState(storage),
+			hclog.NewNullLogger(),
+		)
+		executor.SetRuntime(evm.NewEVM())
+		executor.SetParallelWorkers(workers)
+		executor.GetHash = func(*types.Header) state.GetHashByNumber {
+			return func(uint64) types.Hash {
+				return types.Hash{}
+			}
+		}
+
+		block := &types.Block{
+			Header: &types.Header{
+				Number:   1,
+				GasLimit: 10000000,
+			},
+		}
+
+		for i, sender := range senders {
+			to := counter
+			if i%2 == 1 {
+				// the transfers between the senders do not conflict
+				to = senders[i-1]
+			}
+
+			block.Transactions = append(block.Transactions, &types.Transaction{
+				From:     sender,
+				To:       &to,
+				Value:    big.NewInt(1),
+				Gas:      100000,
+				GasPrice: big.NewInt(1),
+			})
+		}
+
+		txn, err := executor.ProcessBlock(parentRoot, block, coinbase)
+		assert.NoError(t, err)
+
+		return txn
+	}
+
+	serial := process(0)
+	parallel := process(4)
+
+	assert.Equal(t, serial.TotalGas(), parallel.TotalGas())
+	assert.Equal(t, serial.Receipts(), parallel.Receipts())
+
+	_, serialRoot := serial.Commit()
+	_, parallelRoot := parallel.Commit()
+
+	assert.Equal(t, serialRoot, parallelRoot)
+
+	// every call to the counter is applied
+	st := NewState(storage)
+	snap, err := st.NewSnapshotAt(parallelRoot)
+	assert.NoError(t, err)
+
+	assert.Equal(
+		t,
+		types.BytesToHash(big.NewInt(int64(len(senders)/2)).Bytes()),
+		state.NewTxn(st, snap).GetState(counter, types.Hash{}),
+	)
+}
@@ -0,0 +1,114 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+var (
+	ErrProofNodeMissing = errors.New("proof node missing")
+)
+
+// Prove returns the merkle proof of the key in the trie at the root: the encoded nodes on the path
+// from the root down to the value of the key, or down to the node proving the key is absent.
+// The nodes embedded in their parent are not listed on their own
+func Prove(storage Storage, root types.Hash, key []byte) ([][]byte, error) {
+	proof := [][]byte{}
+
+	if root == types.EmptyRootHash {
+		return proof, nil
+	}
+
+	hash, path := root.Bytes(), bytesToHexNibbles(key)
+
+	for hash != nil {
+		data, ok, err := storage.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("state node not found at hash %s", types.BytesToHash(hash))
+		}
+
+		node, err := parseNode(data, storage)
+		if err != nil {
+			return nil, err
+		}
+
+		proof = append(proof, data)
+		hash, path, _ = walkProof(node, path)
+	}
+
+	return proof, nil
+}
+
+// VerifyProof checks the merkle proof of the key against the root, and returns the value of the key,
+// or nil if the proof shows the key is absent
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil
+	}
+
+	nodes := make(map[types.Hash][]byte, len(proof))
+	for _, data := range proof {
+		nodes[types.BytesToHash(crypto.Keccak256(data))] = data
+	}
+
+	hash, path := root.Bytes(), bytesToHexNibbles(key)
+
+	for {
+		data, ok := nodes[types.BytesToHash(hash)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrProofNodeMissing, types.BytesToHash(hash))
+		}
+
+		node, err := parseNode(data, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		next, rest, value := walkProof(node, path)
+		if next == nil {
+			return value, nil
+		}
+
+		hash, path = next, rest
+	}
+}
+
+// walkProof follows the path of nibbles down the node and the nodes embedded in it. It returns
+// the hash of the next stored node and the rest of the path, or the value once the path is consumed
+func walkProof(node Node, path []byte) (next []byte, rest []byte, value []byte) {
+	switch n := node.(type) {
+	case *ValueNode:
+		if n.hash {
+			return n.buf, path, nil
+		}
+
+		if len(path) == 0 {
+			return nil, nil, n.buf
+		}
+
+	case *ShortNode:
+		plen := len(n.key)
+		if plen > len(path) || !bytes.Equal(path[:plen], n.key) {
+			return nil, nil, nil
+		}
+
+		return walkProof(n.child, path[plen:])
+
+	case *FullNode:
+		if len(path) == 0 {
+			return nil, nil, nil
+		}
+
+		return walkProof(n.getEdge(path[0]), path[1:])
+	}
+
+	return nil, nil, nil
+}
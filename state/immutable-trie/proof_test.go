@@ -0,0 +1,66 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProof(t *testing.T) {
+	storage := NewMemoryStorage()
+	root := buildSyncState(t, storage)
+
+	snap, err := NewState(storage).NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	addr := types.StringToAddress(big.NewInt(1).String())
+	key := crypto.Keccak256(addr.Bytes())
+
+	proof, err := Prove(storage, root, key)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, proof)
+
+	expected, ok := snap.Get(key)
+	assert.True(t, ok)
+
+	value, err := VerifyProof(root, key, proof)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, value)
+
+	// the storage of the account is proven against its root
+	var account state.Account
+	assert.NoError(t, account.UnmarshalRlp(value))
+
+	slot := crypto.Keccak256(types.StringToHash(big.NewInt(3).String()).Bytes())
+
+	storageProof, err := Prove(storage, account.Root, slot)
+	assert.NoError(t, err)
+
+	slotValue, err := VerifyProof(account.Root, slot, storageProof)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, slotValue)
+
+	// an absent key is proven absent
+	absent := crypto.Keccak256([]byte{0x1})
+
+	absentProof, err := Prove(storage, root, absent)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, absentProof)
+
+	value, err = VerifyProof(root, absent, absentProof)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	// an incomplete proof is rejected
+	_, err = VerifyProof(root, key, proof[:len(proof)-1])
+	assert.ErrorIs(t, err, ErrProofNodeMissing)
+
+	// the empty trie proves nothing
+	proof, err = Prove(storage, types.EmptyRootHash, key)
+	assert.NoError(t, err)
+	assert.Empty(t, proof)
+}
@@ -0,0 +1,316 @@
+package itrie
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+var (
+	ErrStorageNotPrunable = errors.New("trie storage does not support pruning")
+	ErrPruneRunning       = errors.New("state prune already running")
+	ErrPruneMissingNode   = errors.New("missing trie node of a retained state")
+)
+
+// pruneBatchSize is the number of the unreachable trie nodes deleted per batch
+const pruneBatchSize = 10000
+
+// PrunableStorage is a trie storage whose trie nodes can be listed and deleted
+type PrunableStorage interface {
+	Storage
+
+	// IterateNodes calls fn with the keys of the stored trie nodes until it returns false
+	IterateNodes(fn func(key []byte) bool) error
+	// DeleteNodes deletes the trie nodes of the keys
+	DeleteNodes(keys [][]byte) error
+	// Compact reclaims the space of the deleted nodes
+	Compact() error
+}
+
+// PruneResult is the outcome of a state prune
+type PruneResult struct {
+	Roots    int    `json:"roots"`
+	Retained uint64 `json:"retained"`
+	Pruned   uint64 `json:"pruned"`
+}
+
+// Pruner removes from the storage the trie nodes unreachable from the state roots to retain.
+// The contract codes are kept, as they are shared by the accounts and small.
+//
+// It wraps the trie storage, and records the nodes written while a prune runs, so that a prune
+// may run alongside the block imports. A block being executed commits its state before the head
+// moves to it, so the nodes are also recorded by the head they are written at once it is tracked:
+// the nodes written at the latest heads are kept by a prune, along with the retained states and
+// the nodes written since it started
+type Pruner struct {
+	PrunableStorage
+
+	lock sync.Mutex
+	// head returns the number of the head block, nil if it is not tracked
+	head func() uint64
+	// keys of the nodes written at the latest heads, by head
+	recent map[uint64]map[types.Hash]struct{}
+	// keys of the nodes written since the prune started, nil when no prune runs
+	written map[types.Hash]struct{}
+}
+
+// NewPruner wraps the storage to prune it
+func NewPruner(storage Storage) (*Pruner, error) {
+	prunable, ok := storage.(PrunableStorage)
+	if !ok {
+		return nil, ErrStorageNotPrunable
+	}
+
+	return &Pruner{
+		PrunableStorage: prunable,
+		recent:          map[uint64]map[types.Hash]struct{}{},
+	}, nil
+}
+
+// TrackHead records the nodes by the head they are written at, returned by head.
+// It must be called before the blocks are executed alongside the prunes
+func (p *Pruner) TrackHead(head func() uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.head = head
+}
+
+// Set implements the storage interface, recording the node
+func (p *Pruner) Set(k, v []byte) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.record(k)
+
+	return p.PrunableStorage.Set(k, v)
+}
+
+// Batch implements the storage interface, recording the nodes of the batch
+func (p *Pruner) Batch() Batch {
+	return &prunerBatch{
+		pruner: p,
+		batch:  p.PrunableStorage.Batch(),
+	}
+}
+
+// record marks the node as written, the lock held
+func (p *Pruner) record(k []byte) {
+	if len(k) != types.HashLength {
+		return
+	}
+
+	hash := types.BytesToHash(k)
+
+	if p.written != nil {
+		p.written[hash] = struct{}{}
+	}
+
+	if p.head == nil {
+		return
+	}
+
+	head := p.head()
+
+	nodes, ok := p.recent[head]
+	if !ok {
+		nodes = map[types.Hash]struct{}{}
+		p.recent[head] = nodes
+
+		// the nodes written before the previous head are the ones of the states up to it,
+		// and the ones written above the head were rewound
+		for number := range p.recent {
+			if number+1 < head || number > head {
+				delete(p.recent, number)
+			}
+		}
+	}
+
+	nodes[hash] = struct{}{}
+}
+
+// Prune deletes the trie nodes unreachable from the state roots returned by roots,
+// called once the writes are recorded so that no state written after it is missed.
+// The nodes written at the latest heads are kept too, as the ones of the blocks being executed.
+// It fails without deleting anything if a node of the retained states is missing
+func (p *Pruner) Prune(ctx context.Context, roots func() ([]types.Hash, error)) (*PruneResult, error) {
+	p.lock.Lock()
+
+	if p.written != nil {
+		p.lock.Unlock()
+
+		return nil, ErrPruneRunning
+	}
+
+	p.written = map[types.Hash]struct{}{}
+
+	for _, nodes := range p.recent {
+		for hash := range nodes {
+			p.written[hash] = struct{}{}
+		}
+	}
+
+	p.lock.Unlock()
+
+	defer func() {
+		p.lock.Lock()
+		p.written = nil
+		p.lock.Unlock()
+	}()
+
+	retained, err := roots()
+	if err != nil {
+		return nil, err
+	}
+
+	reachable, err := p.mark(ctx, retained)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PruneResult{
+		Roots:    len(retained),
+		Retained: uint64(len(reachable)),
+	}
+
+	if err := p.sweep(ctx, reachable, result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// mark walks the states of the roots, returning the hashes of their trie nodes
+func (p *Pruner) mark(ctx context.Context, roots []types.Hash) (map[types.Hash]struct{}, error) {
+	reachable := map[types.Hash]struct{}{}
+
+	var stack []syncItem
+
+	push := func(item syncItem) {
+		if !item.code {
+			stack = append(stack, item)
+		}
+	}
+
+	for _, root := range roots {
+		if root == types.EmptyRootHash {
+			continue
+		}
+
+		push(syncItem{hash: root, accounts: true})
+
+		for len(stack) > 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			item := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			// the subtrees shared by the states are walked once
+			if _, ok := reachable[item.hash]; ok {
+				continue
+			}
+
+			node, ok, err := GetNode(item.hash.Bytes(), p.PrunableStorage)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				return nil, fmt.Errorf("%w: %s of root %s", ErrPruneMissingNode, item.hash, root)
+			}
+
+			reachable[item.hash] = struct{}{}
+
+			if err := references(node, item.accounts, push); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return reachable, nil
+}
+
+// sweep deletes the stored trie nodes neither reachable nor written since the prune started
+func (p *Pruner) sweep(ctx context.Context, reachable map[types.Hash]struct{}, result *PruneResult) error {
+	keys := make([][]byte, 0, pruneBatchSize)
+
+	flush := func() error {
+		// the writes wait for the batch, so a node is either recorded before it is
+		// checked, or written again after it is deleted
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		unwritten := keys[:0]
+
+		for _, k := range keys {
+			if _, ok := p.written[types.BytesToHash(k)]; !ok {
+				unwritten = append(unwritten, k)
+			}
+		}
+
+		if err := p.PrunableStorage.DeleteNodes(unwritten); err != nil {
+			return err
+		}
+
+		result.Pruned += uint64(len(unwritten))
+		keys = keys[:0]
+
+		return nil
+	}
+
+	var err error
+
+	if iterErr := p.PrunableStorage.IterateNodes(func(key []byte) bool {
+		if err = ctx.Err(); err != nil {
+			return false
+		}
+
+		if _, ok := reachable[types.BytesToHash(key)]; ok {
+			return true
+		}
+
+		keys = append(keys, append([]byte{}, key...))
+
+		if len(keys) == pruneBatchSize {
+			err = flush()
+		}
+
+		return err == nil
+	}); iterErr != nil {
+		return iterErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+// prunerBatch records the nodes of a batch once written
+type prunerBatch struct {
+	pruner *Pruner
+	batch  Batch
+	keys   [][]byte
+}
+
+func (b *prunerBatch) Set(k, v []byte) {
+	b.keys = append(b.keys, append([]byte{}, k...))
+	b.batch.Set(k, v)
+}
+
+func (b *prunerBatch) Write() error {
+	b.pruner.lock.Lock()
+	defer b.pruner.lock.Unlock()
+
+	for _, k := range b.keys {
+		b.pruner.record(k)
+	}
+
+	return b.batch.Write()
+}
@@ -0,0 +1,179 @@
+package itrie
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// buildNextState commits balance changes of some accounts on top of the state of the root
+func buildNextState(t *testing.T, storage Storage, root types.Hash) types.Hash {
+	t.Helper()
+
+	snap, err := NewState(storage).NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	objs := []*state.Object{}
+
+	for i := 0; i < 8; i++ {
+		objs = append(objs, &state.Object{
+			Address:  types.StringToAddress(big.NewInt(int64(i + 1)).String()),
+			Balance:  big.NewInt(int64(1000 + i)),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+		})
+	}
+
+	_, next := snap.Commit(objs)
+
+	return types.BytesToHash(next)
+}
+
+// assertComplete asserts that no node nor code of the state of the root is missing
+func assertComplete(t *testing.T, storage Storage, root types.Hash) {
+	t.Helper()
+
+	missing, err := NewSync(storage, root).Missing(1)
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func countNodes(t *testing.T, storage PrunableStorage) int {
+	t.Helper()
+
+	count := 0
+
+	assert.NoError(t, storage.IterateNodes(func([]byte) bool {
+		count++
+
+		return true
+	}))
+
+	return count
+}
+
+func retain(roots ...types.Hash) func() ([]types.Hash, error) {
+	return func() ([]types.Hash, error) {
+		return roots, nil
+	}
+}
+
+func TestPruner_Prune(t *testing.T) {
+	pruner, err := NewPruner(NewMemoryStorage())
+	assert.NoError(t, err)
+
+	first := buildSyncState(t, pruner)
+	second := buildNextState(t, pruner, first)
+
+	nodes := countNodes(t, pruner)
+
+	// retaining both states prunes nothing
+	result, err := pruner.Prune(context.Background(), retain(first, second))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Roots)
+	assert.Equal(t, uint64(nodes), result.Retained)
+	assert.Zero(t, result.Pruned)
+
+	// the nodes of the first state only are pruned
+	result, err = pruner.Prune(context.Background(), retain(second))
+	assert.NoError(t, err)
+	assert.NotZero(t, result.Pruned)
+	assert.Equal(t, nodes, int(result.Retained+result.Pruned))
+	assert.Equal(t, int(result.Retained), countNodes(t, pruner))
+
+	assertComplete(t, pruner, second)
+
+	_, ok, _ := pruner.Get(first.Bytes())
+	assert.False(t, ok)
+
+	// the codes are kept
+	_, ok = pruner.GetCode(types.BytesToHash(crypto.Keccak256([]byte{0x60, 0x01})))
+	assert.True(t, ok)
+}
+
+func TestPruner_KeepsWrittenNodes(t *testing.T) {
+	pruner, err := NewPruner(NewMemoryStorage())
+	assert.NoError(t, err)
+
+	first := buildSyncState(t, pruner)
+
+	var second types.Hash
+
+	// the nodes written once the prune started are kept, though not retained
+	result, err := pruner.Prune(context.Background(), func() ([]types.Hash, error) {
+		second = buildNextState(t, pruner, first)
+
+		return []types.Hash{}, nil
+	})
+	assert.NoError(t, err)
+	assert.NotZero(t, result.Pruned)
+
+	_, ok, _ := pruner.Get(second.Bytes())
+	assert.True(t, ok)
+
+	_, ok, _ = pruner.Get(first.Bytes())
+	assert.False(t, ok)
+}
+
+func TestPruner_KeepsNodesOfLatestHeads(t *testing.T) {
+	pruner, err := NewPruner(NewMemoryStorage())
+	assert.NoError(t, err)
+
+	head := uint64(0)
+	pruner.TrackHead(func() uint64 {
+		return head
+	})
+
+	first := buildSyncState(t, pruner)
+
+	// the state of the block being executed is committed before the head moves to it
+	head = 1
+	second := buildNextState(t, pruner, first)
+
+	_, err = pruner.Prune(context.Background(), retain(first))
+	assert.NoError(t, err)
+	assertComplete(t, pruner, second)
+
+	// the nodes written before the previous head are not kept anymore
+	head = 3
+	assert.NoError(t, pruner.Set(types.StringToHash("0x1").Bytes(), []byte{0x1}))
+
+	result, err := pruner.Prune(context.Background(), retain(first))
+	assert.NoError(t, err)
+	assert.NotZero(t, result.Pruned)
+	assertComplete(t, pruner, first)
+
+	_, ok, _ := pruner.Get(second.Bytes())
+	assert.False(t, ok)
+}
+
+func TestPruner_MissingNode(t *testing.T) {
+	pruner, err := NewPruner(NewMemoryStorage())
+	assert.NoError(t, err)
+
+	first := buildSyncState(t, pruner)
+	nodes := countNodes(t, pruner)
+
+	// nothing is deleted if a retained state is incomplete
+	_, err = pruner.Prune(context.Background(), retain(first, types.StringToHash("0x1")))
+	assert.ErrorIs(t, err, ErrPruneMissingNode)
+	assert.Equal(t, nodes, countNodes(t, pruner))
+}
+
+func TestPruner_Canceled(t *testing.T) {
+	pruner, err := NewPruner(NewMemoryStorage())
+	assert.NoError(t, err)
+
+	first := buildSyncState(t, pruner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = pruner.Prune(ctx, retain(first))
+	assert.ErrorIs(t, err, context.Canceled)
+}
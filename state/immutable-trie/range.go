@@ -0,0 +1,206 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+var (
+	ErrMissingTrieNode = errors.New("missing trie node")
+	ErrRangeMismatch   = errors.New("range keys and values mismatch")
+)
+
+// rangeReader collects the leaves of a trie in key order, from an origin
+type rangeReader struct {
+	storage Storage
+	// origin is the first key of the range, in nibbles
+	origin []byte
+	max    int
+
+	keys   [][]byte
+	values [][]byte
+	more   bool
+}
+
+// ReadRange returns up to max leaves of the trie of the root, in key order, whose keys
+// are not lower than the origin, and whether the trie holds leaves after them
+func ReadRange(
+	storage Storage,
+	root types.Hash,
+	origin []byte,
+	max int,
+) (keys [][]byte, values [][]byte, more bool, err error) {
+	if root == types.EmptyRootHash || max <= 0 {
+		return nil, nil, false, nil
+	}
+
+	node, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	if !ok {
+		return nil, nil, false, fmt.Errorf("%w: %s", ErrMissingTrieNode, root)
+	}
+
+	r := &rangeReader{
+		storage: storage,
+		origin:  bytesToHexNibbles(origin),
+		max:     max,
+	}
+
+	// remove the terminator flag
+	r.origin = r.origin[:len(r.origin)-1]
+
+	if _, err := r.walk(node, nil); err != nil {
+		return nil, nil, false, err
+	}
+
+	return r.keys, r.values, r.more, nil
+}
+
+// below returns whether all the keys under the path are lower than the origin
+func (r *rangeReader) below(path []byte) bool {
+	l := len(path)
+	if l > len(r.origin) {
+		l = len(r.origin)
+	}
+
+	return bytes.Compare(path[:l], r.origin[:l]) < 0
+}
+
+// walk visits the leaves under the node at the path in key order,
+// and returns false once the range is full
+func (r *rangeReader) walk(node Node, path []byte) (bool, error) {
+	switch n := node.(type) {
+	case nil:
+		return true, nil
+
+	case *ValueNode:
+		if n.hash {
+			nc, ok, err := GetNode(n.buf, r.storage)
+			if err != nil {
+				return false, err
+			}
+
+			if !ok {
+				return false, fmt.Errorf("%w: %x", ErrMissingTrieNode, n.buf)
+			}
+
+			return r.walk(nc, path)
+		}
+
+		if bytes.Compare(path, r.origin) < 0 {
+			return true, nil
+		}
+
+		if len(r.keys) == r.max {
+			r.more = true
+
+			return false, nil
+		}
+
+		r.keys = append(r.keys, hexNibblesToBytes(path))
+		r.values = append(r.values, append([]byte{}, n.buf...))
+
+		return true, nil
+
+	case *ShortNode:
+		key := n.key
+		if hasTerminator(key) {
+			key = key[:len(key)-1]
+		}
+
+		childPath := concat(path, key)
+		if r.below(childPath) {
+			return true, nil
+		}
+
+		return r.walk(n.child, childPath)
+
+	case *FullNode:
+		// the value is the leaf of the shortest key
+		if ok, err := r.walk(n.value, path); !ok || err != nil {
+			return ok, err
+		}
+
+		for i, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			childPath := concat(path, []byte{byte(i)})
+			if r.below(childPath) {
+				continue
+			}
+
+			if ok, err := r.walk(child, childPath); !ok || err != nil {
+				return ok, err
+			}
+		}
+
+		return true, nil
+
+	default:
+		panic(fmt.Sprintf("unknown node type %v", n))
+	}
+}
+
+// hexNibblesToBytes packs an even sequence of nibbles, without terminator, into bytes
+func hexNibblesToBytes(nibbles []byte) []byte {
+	key := make([]byte, len(nibbles)/2)
+	for i := range key {
+		key[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+
+	return key
+}
+
+// WriteRange inserts the leaves into the trie of the root, writes the nodes it modifies
+// to the storage and returns the root of the resulting trie. The trie is loaded from the
+// storage on demand, so that a large trie is built range by range in a bounded memory
+func WriteRange(storage Storage, root types.Hash, keys, values [][]byte) (types.Hash, error) {
+	if len(keys) != len(values) {
+		return root, ErrRangeMismatch
+	}
+
+	// the loaded nodes are copied on write, so that their hashes are computed again
+	txn := &Txn{
+		epoch:   1,
+		storage: storage,
+	}
+
+	if root != types.EmptyRootHash {
+		node, ok, err := GetNode(root.Bytes(), storage)
+		if err != nil {
+			return root, err
+		}
+
+		if !ok {
+			return root, fmt.Errorf("%w: %s", ErrMissingTrieNode, root)
+		}
+
+		txn.root = node
+	}
+
+	for i, key := range keys {
+		txn.Insert(key, values[i])
+	}
+
+	batch := storage.Batch()
+	txn.batch = batch
+
+	hash, err := txn.Hash()
+	if err != nil {
+		return root, err
+	}
+
+	if err := batch.Write(); err != nil {
+		return root, err
+	}
+
+	return types.BytesToHash(hash), nil
+}
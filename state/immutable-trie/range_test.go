@@ -0,0 +1,136 @@
+package itrie
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// rangeLeaves returns sorted hashed keys with values
+func rangeLeaves(n int) ([][]byte, [][]byte) {
+	keys := make([][]byte, 0, n)
+
+	for i := 0; i < n; i++ {
+		keys = append(keys, crypto.Keccak256([]byte{byte(i), byte(i >> 8)}))
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	values := make([][]byte, 0, n)
+	for i := range keys {
+		values = append(values, []byte{0x80 + byte(i%64), byte(i)})
+	}
+
+	return keys, values
+}
+
+func TestRange_WriteRead(t *testing.T) {
+	keys, values := rangeLeaves(500)
+
+	// the root of the trie built in memory
+	txn := NewTrie().Txn()
+	for i := range keys {
+		txn.Insert(keys[i], values[i])
+	}
+
+	expected, err := txn.Hash()
+	assert.NoError(t, err)
+
+	// the same trie written range by range
+	storage := NewMemoryStorage()
+	root := types.EmptyRootHash
+
+	for i := 0; i < len(keys); i += 64 {
+		end := i + 64
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		root, err = WriteRange(storage, root, keys[i:end], values[i:end])
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, types.BytesToHash(expected), root)
+
+	// read back range by range
+	var (
+		readKeys, readValues [][]byte
+		origin               []byte
+	)
+
+	for {
+		k, v, more, err := ReadRange(storage, root, origin, 100)
+		assert.NoError(t, err)
+
+		readKeys = append(readKeys, k...)
+		readValues = append(readValues, v...)
+
+		if !more {
+			break
+		}
+
+		assert.Len(t, k, 100)
+
+		origin = k[len(k)-1]
+		origin = append(append([]byte{}, origin[:len(origin)-1]...), origin[len(origin)-1]+1)
+	}
+
+	assert.Equal(t, keys, readKeys)
+	assert.Equal(t, values, readValues)
+}
+
+func TestRange_ReadOrigin(t *testing.T) {
+	keys, values := rangeLeaves(100)
+
+	storage := NewMemoryStorage()
+
+	root, err := WriteRange(storage, types.EmptyRootHash, keys, values)
+	assert.NoError(t, err)
+
+	// the origin between two keys
+	origin := append([]byte{}, keys[40]...)
+	origin[len(origin)-1]++
+
+	k, v, more, err := ReadRange(storage, root, origin, 10)
+	assert.NoError(t, err)
+	assert.True(t, more)
+	assert.Equal(t, keys[41:51], k)
+	assert.Equal(t, values[41:51], v)
+
+	// the last leaves
+	k, _, more, err = ReadRange(storage, root, keys[95], 10)
+	assert.NoError(t, err)
+	assert.False(t, more)
+	assert.Equal(t, keys[95:], k)
+
+	// an unknown root
+	_, _, _, err = ReadRange(storage, types.StringToHash("1"), nil, 10)
+	assert.ErrorIs(t, err, ErrMissingTrieNode)
+
+	// the empty trie
+	k, _, more, err = ReadRange(storage, types.EmptyRootHash, nil, 10)
+	assert.NoError(t, err)
+	assert.False(t, more)
+	assert.Empty(t, k)
+}
+
+func TestRange_ReadState(t *testing.T) {
+	source := NewMemoryStorage()
+	root := buildSyncState(t, source)
+
+	// the accounts rebuilt from their range are the same trie
+	keys, values, more, err := ReadRange(source, root, nil, 1000)
+	assert.NoError(t, err)
+	assert.False(t, more)
+	assert.Len(t, keys, 64)
+
+	rebuilt, err := WriteRange(NewMemoryStorage(), types.EmptyRootHash, keys, values)
+	assert.NoError(t, err)
+	assert.Equal(t, root, rebuilt)
+}
@@ -26,6 +26,12 @@ func NewState(storage Storage) *State {
 	return s
 }
 
+// Isolated returns a state over the same storage with a cache of its own, so that its
+// snapshots share no trie nodes with the ones of s
+func (s *State) Isolated() state.State {
+	return NewState(s.storage)
+}
+
 func (s *State) NewSnapshot() state.Snapshot {
 	t := NewTrie()
 	t.state = s
@@ -87,3 +93,18 @@ func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
 func (s *State) AddState(root types.Hash, t *Trie) {
 	s.cache.Add(root, t)
 }
+
+// LoadState reads the state at the root, committed by a block, into the cache,
+// for the next snapshots at the same root
+func (s *State) LoadState(root types.Hash) error {
+	snap, err := s.NewSnapshotAt(root)
+	if err != nil {
+		return err
+	}
+
+	if t, ok := snap.(*Trie); ok && root != types.EmptyRootHash {
+		s.AddState(root, t)
+	}
+
+	return nil
+}
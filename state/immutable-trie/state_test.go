@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestState(t *testing.T) {
@@ -17,3 +19,20 @@ func buildPreState(pre state.PreStates) (state.State, state.Snapshot) {
 
 	return st, snap
 }
+
+func TestState_LoadState(t *testing.T) {
+	storage := NewMemoryStorage()
+	root := buildSyncState(t, storage)
+
+	// the state opened on the storage does not cache the roots it opens
+	st := NewState(storage)
+
+	_, err := st.NewSnapshotAt(root)
+	assert.NoError(t, err)
+	assert.False(t, st.cache.Contains(root))
+
+	assert.NoError(t, st.LoadState(root))
+	assert.True(t, st.cache.Contains(root))
+
+	assert.Error(t, st.LoadState(types.StringToHash("1")))
+}
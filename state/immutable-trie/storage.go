@@ -1,6 +1,7 @@
 package itrie
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/dogechain-lab/dogechain/helper/hex"
@@ -14,8 +15,25 @@ var parserPool fastrlp.ParserPool
 var (
 	// codePrefix is the code prefix for leveldb
 	codePrefix = []byte("code")
+
+	// preimagePrefix is the prefix of the preimages of the hashed trie keys
+	preimagePrefix = []byte("preimage")
 )
 
+// KeyCategory returns the name of the data stored under the key of the state storage
+func KeyCategory(key []byte) string {
+	switch {
+	case len(key) == types.HashLength:
+		return "state nodes"
+	case bytes.HasPrefix(key, codePrefix):
+		return "contract codes"
+	case bytes.HasPrefix(key, preimagePrefix):
+		return "preimages"
+	default:
+		return "other"
+	}
+}
+
 type Batch interface {
 	Set(k, v []byte)
 	Write() error
@@ -36,7 +54,7 @@ type Storage interface {
 
 // wrap generic kvdb storage to implement Storage interface
 type kvStorage struct {
-	db kvdb.KVBatchStorage
+	db kvdb.Database
 }
 
 func (kv *kvStorage) Get(k []byte) ([]byte, bool, error) {
@@ -64,12 +82,37 @@ func (kv *kvStorage) Batch() Batch {
 	return kv.db.Batch()
 }
 
+func (kv *kvStorage) IterateNodes(fn func(key []byte) bool) error {
+	return kv.db.Iterate(nil, func(k, _ []byte) bool {
+		// the codes are stored under their prefix, the nodes under their hash
+		if len(k) != types.HashLength {
+			return true
+		}
+
+		return fn(k)
+	})
+}
+
+func (kv *kvStorage) DeleteNodes(keys [][]byte) error {
+	batch := kv.db.Batch()
+	for _, k := range keys {
+		batch.Delete(k)
+	}
+
+	return batch.Write()
+}
+
+func (kv *kvStorage) Compact() error {
+	return kv.db.Compact()
+}
+
 func (kv *kvStorage) Close() error {
 	return kv.db.Close()
 }
 
-func NewLevelDBStorage(leveldbBuilder kvdb.LevelDBBuilder) (Storage, error) {
-	db, err := leveldbBuilder.Build()
+// NewDatabaseStorage creates a trie storage on the database of any backend
+func NewDatabaseStorage(dbBuilder kvdb.Builder) (Storage, error) {
+	db, err := dbBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -124,6 +167,37 @@ func (m *memStorage) Batch() Batch {
 	return &memBatch{db: &m.db}
 }
 
+func (m *memStorage) IterateNodes(fn func(key []byte) bool) error {
+	for k := range m.db {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			return err
+		}
+
+		if len(key) != types.HashLength {
+			continue
+		}
+
+		if !fn(key) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (m *memStorage) DeleteNodes(keys [][]byte) error {
+	for _, k := range keys {
+		delete(m.db, hex.EncodeToHex(k))
+	}
+
+	return nil
+}
+
+func (m *memStorage) Compact() error {
+	return nil
+}
+
 func (m *memStorage) Close() error {
 	return nil
 }
@@ -145,6 +219,13 @@ func GetNode(root []byte, storage Storage) (Node, bool, error) {
 		return nil, false, nil
 	}
 
+	n, err := parseNode(data, storage)
+
+	return n, err == nil, err
+}
+
+// parseNode decodes a node from its stored encoding
+func parseNode(data []byte, storage Storage) (Node, error) {
 	// NOTE. We dont need to make copies of the bytes because the nodes
 	// take the reference from data itself which is a safe copy.
 	p := parserPool.Get()
@@ -152,16 +233,14 @@ func GetNode(root []byte, storage Storage) (Node, bool, error) {
 
 	v, err := p.Parse(data)
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 
 	if v.Type() != fastrlp.TypeArray {
-		return nil, false, fmt.Errorf("storage item should be an array")
+		return nil, fmt.Errorf("storage item should be an array")
 	}
 
-	n, err := decodeNode(v, storage)
-
-	return n, err == nil, err
+	return decodeNode(v, storage)
 }
 
 func decodeNode(v *fastrlp.Value, s Storage) (Node, error) {
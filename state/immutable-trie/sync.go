@@ -0,0 +1,206 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+var (
+	ErrUnrequestedNode  = errors.New("node not requested")
+	ErrNodeHashMismatch = errors.New("node hash mismatch")
+)
+
+var emptyCodeHash = types.BytesToHash(crypto.Keccak256(nil))
+
+// syncItem is a trie node or a contract code referenced by the state
+type syncItem struct {
+	hash types.Hash
+	// code marks a contract code, a trie node otherwise
+	code bool
+	// accounts marks a node of the accounts trie, whose leaves are accounts
+	accounts bool
+}
+
+// Sync walks the state trie of a root and schedules the retrieval of the trie nodes
+// and contract codes missing from the storage, so that the state is complete once
+// all of them are processed. The walk is depth first, which bounds its memory.
+// Sync is not thread safe.
+type Sync struct {
+	storage Storage
+
+	// items left to walk
+	stack []syncItem
+	// missing items requested to the peers
+	requested map[types.Hash]syncItem
+
+	nodes uint64
+	codes uint64
+}
+
+// NewSync creates a sync of the state at the root
+func NewSync(storage Storage, root types.Hash) *Sync {
+	s := &Sync{
+		storage:   storage,
+		requested: map[types.Hash]syncItem{},
+	}
+
+	if root != types.EmptyRootHash {
+		s.stack = append(s.stack, syncItem{hash: root, accounts: true})
+	}
+
+	return s
+}
+
+// Missing walks the local state until up to max items are missing,
+// and returns the hashes of the missing items not processed yet
+func (s *Sync) Missing(max int) ([]types.Hash, error) {
+	for len(s.requested) < max && len(s.stack) > 0 {
+		item := s.stack[len(s.stack)-1]
+		s.stack = s.stack[:len(s.stack)-1]
+
+		if item.code {
+			if _, ok := s.storage.GetCode(item.hash); !ok {
+				s.requested[item.hash] = item
+			}
+
+			continue
+		}
+
+		node, ok, err := GetNode(item.hash.Bytes(), s.storage)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			s.requested[item.hash] = item
+
+			continue
+		}
+
+		if err := s.schedule(node, item.accounts); err != nil {
+			return nil, err
+		}
+	}
+
+	hashes := make([]types.Hash, 0, len(s.requested))
+	for hash := range s.requested {
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+// Process writes a requested item retrieved from a peer and schedules
+// the items it references
+func (s *Sync) Process(hash types.Hash, data []byte) error {
+	item, ok := s.requested[hash]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnrequestedNode, hash)
+	}
+
+	if !bytes.Equal(crypto.Keccak256(data), hash.Bytes()) {
+		return fmt.Errorf("%w: %s", ErrNodeHashMismatch, hash)
+	}
+
+	if item.code {
+		if err := s.storage.SetCode(hash, data); err != nil {
+			return err
+		}
+
+		s.codes++
+	} else {
+		node, err := parseNode(data, s.storage)
+		if err != nil {
+			return err
+		}
+
+		if err := s.storage.Set(hash.Bytes(), data); err != nil {
+			return err
+		}
+
+		if err := s.schedule(node, item.accounts); err != nil {
+			return err
+		}
+
+		s.nodes++
+	}
+
+	delete(s.requested, hash)
+
+	return nil
+}
+
+// Pending returns the number of the items requested and not processed yet
+func (s *Sync) Pending() int {
+	return len(s.requested)
+}
+
+// Done returns whether the state is complete
+func (s *Sync) Done() bool {
+	return len(s.stack) == 0 && len(s.requested) == 0
+}
+
+// Healed returns the number of the trie nodes and codes processed
+func (s *Sync) Healed() (uint64, uint64) {
+	return s.nodes, s.codes
+}
+
+// schedule adds the items referenced by the node to the walk
+func (s *Sync) schedule(node Node, accounts bool) error {
+	return references(node, accounts, func(item syncItem) {
+		s.stack = append(s.stack, item)
+	})
+}
+
+// references calls fn with the stored trie nodes and the contract codes referenced by the node,
+// the storage tries and codes of the accounts included if it is a node of the accounts trie
+func references(node Node, accounts bool, fn func(item syncItem)) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			fn(syncItem{hash: types.BytesToHash(n.buf), accounts: accounts})
+
+			return nil
+		}
+
+		if !accounts {
+			// storage slot
+			return nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(n.buf); err != nil {
+			return err
+		}
+
+		if root := account.Root; root != types.EmptyRootHash && root != types.ZeroHash {
+			fn(syncItem{hash: root})
+		}
+
+		if code := types.BytesToHash(account.CodeHash); code != emptyCodeHash && code != types.ZeroHash {
+			fn(syncItem{hash: code, code: true})
+		}
+
+	case *ShortNode:
+		return references(n.child, accounts, fn)
+
+	case *FullNode:
+		for _, child := range n.children {
+			if err := references(child, accounts, fn); err != nil {
+				return err
+			}
+		}
+
+		return references(n.value, accounts, fn)
+	}
+
+	return nil
+}
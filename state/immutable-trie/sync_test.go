@@ -0,0 +1,140 @@
+package itrie
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/state"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// buildSyncState commits accounts with storage and code to the storage
+func buildSyncState(t *testing.T, storage Storage) types.Hash {
+	t.Helper()
+
+	code := []byte{0x60, 0x01}
+
+	objs := []*state.Object{}
+
+	for i := 0; i < 64; i++ {
+		obj := &state.Object{
+			Address:  types.StringToAddress(big.NewInt(int64(i + 1)).String()),
+			Balance:  big.NewInt(int64(i)),
+			Nonce:    uint64(i),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+		}
+
+		if i%4 == 0 {
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.Code = code
+			obj.DirtyCode = true
+
+			for j := 0; j < 16; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.StringToHash(big.NewInt(int64(j)).String()).Bytes(),
+					Val: big.NewInt(int64(i*j + 1)).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root := NewState(storage).NewSnapshot().Commit(objs)
+
+	return types.BytesToHash(root)
+}
+
+// withoutPreimages returns a copy of the memory storage without the preimages of the keys
+func withoutPreimages(storage Storage) Storage {
+	//nolint:forcetypeassert
+	mem := storage.(*memStorage)
+	stripped := &memStorage{db: map[string][]byte{}, code: mem.code}
+
+	for k, v := range mem.db {
+		if !strings.HasPrefix(k, hex.EncodeToHex(preimagePrefix)) {
+			stripped.db[k] = v
+		}
+	}
+
+	return stripped
+}
+
+// syncFrom processes the missing items with the ones of the source, until done
+func syncFrom(t *testing.T, sync *Sync, source Storage) {
+	t.Helper()
+
+	for !sync.Done() {
+		hashes, err := sync.Missing(16)
+		assert.NoError(t, err)
+
+		for _, hash := range hashes {
+			data, ok, _ := source.Get(hash.Bytes())
+			if !ok {
+				data, ok = source.GetCode(hash)
+			}
+
+			assert.True(t, ok)
+			assert.NoError(t, sync.Process(hash, data))
+		}
+	}
+}
+
+func TestSync(t *testing.T) {
+	source := NewMemoryStorage()
+	root := buildSyncState(t, source)
+
+	storage := NewMemoryStorage()
+
+	sync := NewSync(storage, root)
+	syncFrom(t, sync, source)
+
+	// the state is complete, the preimages recorded on commit not being part of it
+	assert.Equal(t, withoutPreimages(source), storage)
+
+	nodes, codes := sync.Healed()
+	assert.Equal(t, uint64(1), codes)
+	assert.Greater(t, nodes, uint64(0))
+
+	hashes, err := NewSync(storage, root).Missing(16)
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
+}
+
+func TestSync_MissingNode(t *testing.T) {
+	source := NewMemoryStorage()
+	root := buildSyncState(t, source)
+
+	// a partial copy of the state, without the root node
+	storage := NewMemoryStorage()
+
+	//nolint:forcetypeassert
+	for k, v := range source.(*memStorage).db {
+		storage.(*memStorage).db[k] = v
+	}
+
+	delete(storage.(*memStorage).db, root.String())
+
+	sync := NewSync(storage, root)
+
+	hashes, err := sync.Missing(16)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{root}, hashes)
+
+	// the retrieved items are verified
+	assert.ErrorIs(t, sync.Process(root, []byte{0x01}), ErrNodeHashMismatch)
+	assert.ErrorIs(t, sync.Process(types.StringToHash("1"), []byte{0x01}), ErrUnrequestedNode)
+	assert.Equal(t, 1, sync.Pending())
+
+	syncFrom(t, sync, source)
+
+	// the code is missing too
+	nodes, codes := sync.Healed()
+	assert.Equal(t, uint64(1), nodes)
+	assert.Equal(t, uint64(1), codes)
+}
@@ -163,6 +163,7 @@ func (t *Trie) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 					} else {
 						vv := ar1.NewBytes(bytes.TrimLeft(entry.Val, "\x00"))
 						localTxn.Insert(k, vv.MarshalTo(nil))
+						batch.Set(preimageKey(k), entry.Key)
 					}
 				}
 
@@ -183,7 +184,9 @@ func (t *Trie) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 			vv := account.MarshalWith(arena)
 			data := vv.MarshalTo(nil)
 
-			tt.Insert(hashit(obj.Address.Bytes()), data)
+			key := hashit(obj.Address.Bytes())
+			tt.Insert(key, data)
+			batch.Set(preimageKey(key), obj.Address.Bytes())
 			arena.Reset()
 		}
 	}
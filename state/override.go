@@ -0,0 +1,64 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+var (
+	ErrOverrideStateAndDiff = errors.New("both state and state diff overridden")
+)
+
+// AccountOverride holds the fields of an account replaced before simulating a call.
+// The unset fields keep their value from the state
+type AccountOverride struct {
+	Nonce   *uint64
+	Code    []byte
+	Balance *big.Int
+	// State replaces the whole storage of the account
+	State map[types.Hash]types.Hash
+	// StateDiff replaces the given slots of the storage of the account
+	StateDiff map[types.Hash]types.Hash
+}
+
+// Overrides are the accounts replaced before simulating a call, by address
+type Overrides map[types.Address]*AccountOverride
+
+// ApplyOverrides layers the overrides over the state of the transition, so that the transactions
+// applied next see them. The transition must not be committed afterwards
+func (t *Transition) ApplyOverrides(overrides Overrides) error {
+	for addr, override := range overrides {
+		if override == nil {
+			continue
+		}
+
+		if override.State != nil && override.StateDiff != nil {
+			return fmt.Errorf("%w for account %s", ErrOverrideStateAndDiff, addr)
+		}
+
+		if override.Nonce != nil {
+			t.state.SetNonce(addr, *override.Nonce)
+		}
+
+		if override.Code != nil {
+			t.state.SetCode(addr, override.Code)
+		}
+
+		if override.Balance != nil {
+			t.state.SetBalance(addr, override.Balance)
+		}
+
+		if override.State != nil {
+			t.state.SetFullStorage(addr, override.State)
+		}
+
+		for key, value := range override.StateDiff {
+			t.state.SetState(addr, key, value)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package state
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/state/runtime"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// speculation is the result of a transaction executed on the state of the parent block
+type speculation struct {
+	msg         *types.Transaction
+	result      *runtime.ExecutionResult
+	err         error
+	state       *Txn
+	logs        []*types.Log
+	coinbaseFee *big.Int
+}
+
+// SetParallelWorkers sets the number of workers executing the transactions of a block
+// concurrently. It is experimental, the block is executed serially below two workers
+func (e *Executor) SetParallelWorkers(workers int) {
+	e.parallelWorkers = workers
+}
+
+// canProcessParallel returns whether the transactions of the block could be executed
+// concurrently. The receipts before byzantium hold the intermediate roots, the post hook
+// observes every transaction in order, and each worker reads a copy of the state
+func (e *Executor) canProcessParallel(txn *Transition) bool {
+	_, isolated := e.state.(IsolatedState)
+
+	return e.parallelWorkers > 1 &&
+		isolated &&
+		e.PostHook == nil &&
+		!txn.needDebug &&
+		txn.config.Byzantium &&
+		len(txn.block.Transactions) > 1
+}
+
+// processParallel executes the transactions of the block optimistically: all of them
+// run concurrently on the parent state, then they are committed in order, and any
+// transaction accessing an account written by a previous one is executed again
+func (e *Executor) processParallel(parentRoot types.Hash, txn *Transition) error {
+	specs := e.speculate(parentRoot, txn)
+
+	for i, t := range txn.block.Transactions {
+		if e.IsStopped() {
+			// halt more elegantly
+			return ErrExecutionStop
+		}
+
+		if t.ExceedsBlockGasLimit(txn.block.Header.GasLimit) {
+			if err := txn.WriteFailedReceipt(t); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		spec := specs[i]
+		if spec == nil || spec.err != nil || txn.gasPool < spec.msg.Gas || txn.conflicts(spec.state) {
+			if err := txn.Write(t); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := txn.commitSpeculation(t, spec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// speculate executes the transactions concurrently, each of them on its own state
+// over the parent state. The bridge transactions are only executed in order
+func (e *Executor) speculate(parentRoot types.Hash, txn *Transition) []*speculation {
+	txs := txn.block.Transactions
+	specs := make([]*speculation, len(txs))
+	jobs := make(chan int, len(txs))
+
+	for i, t := range txs {
+		if t.ExceedsBlockGasLimit(txn.block.Header.GasLimit) || isBridgeTx(t) {
+			continue
+		}
+
+		jobs <- i
+	}
+
+	close(jobs)
+
+	// the tries read load their nodes, so each worker reads its own copy of the parent state
+	isolated, _ := e.state.(IsolatedState)
+	wg := sync.WaitGroup{}
+
+	for w := 0; w < e.parallelWorkers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			st := isolated.Isolated()
+
+			parent, err := st.NewSnapshotAt(parentRoot)
+			if err != nil {
+				// the transactions left are executed in order
+				return
+			}
+
+			for i := range jobs {
+				if e.IsStopped() {
+					return
+				}
+
+				specs[i] = txn.speculate(txs[i], st, parent)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return specs
+}
+
+// speculate executes the transaction on a copy of the transition over the parent state,
+// tracking the accounts it accesses
+func (t *Transition) speculate(txn *types.Transaction, st State, parent Snapshot) *speculation {
+	if txn.From == emptyFrom {
+		signer := crypto.NewSigner(t.config, uint64(t.r.config.ChainID))
+
+		from, err := signer.Sender(txn)
+		if err != nil {
+			return &speculation{err: err}
+		}
+
+		txn.From = from
+	}
+
+	state := NewTxn(st, parent)
+	state.trackAccesses()
+
+	spec := &Transition{
+		logger:      t.logger,
+		r:           t.r,
+		ctx:         t.ctx,
+		state:       state,
+		getHash:     t.getHash,
+		auxState:    st,
+		config:      t.config,
+		gasPool:     uint64(t.ctx.GasLimit),
+		block:       t.block,
+		coinbaseFee: big.NewInt(0),
+		evmLogger:   runtime.NewDummyLogger(),
+	}
+
+	msg := txn.Copy()
+
+	result, err := spec.Apply(msg)
+	if err != nil {
+		return &speculation{err: err}
+	}
+
+	logs := state.Logs()
+	state.CleanDeleteObjects(true)
+
+	return &speculation{
+		msg:         msg,
+		result:      result,
+		state:       state,
+		logs:        logs,
+		coinbaseFee: spec.coinbaseFee,
+	}
+}
+
+// conflicts returns whether the speculative state accessed an account written
+// by the transactions committed before
+func (t *Transition) conflicts(state *Txn) bool {
+	for addr := range state.accessed {
+		if _, ok := t.state.txn.Get(addr.Bytes()); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// commitSpeculation writes the accounts of the speculative state, then pays the
+// coinbase and writes the receipt as if the transaction was executed in order
+func (t *Transition) commitSpeculation(txn *types.Transaction, spec *speculation) error {
+	spec.state.txn.Root().Walk(func(k []byte, v interface{}) bool {
+		if obj, ok := v.(*StateObject); ok {
+			t.state.txn.Insert(k, obj)
+		}
+
+		return false
+	})
+
+	if t.state.accessed != nil {
+		t.state.accessed.merge(spec.state.accessed)
+	}
+
+	t.state.AddBalance(t.ctx.Coinbase, spec.coinbaseFee)
+	t.gasPool -= spec.result.GasUsed
+
+	return t.writeReceipt(txn, spec.msg, spec.result, spec.logs)
+}
+
+func isBridgeTx(txn *types.Transaction) bool {
+	return txn.To != nil && *txn.To == systemcontracts.AddrBridgeContract
+}
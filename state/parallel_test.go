@@ -0,0 +1,143 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/state/runtime"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// transferRuntime runs the calls to the accounts without code
+type transferRuntime struct{}
+
+func (r *transferRuntime) Run(c *runtime.Contract, _ runtime.Host, _ *chain.ForksInTime) *runtime.ExecutionResult {
+	return &runtime.ExecutionResult{GasLeft: c.Gas}
+}
+
+func (r *transferRuntime) CanRun(*runtime.Contract, runtime.Host, *chain.ForksInTime) bool {
+	return true
+}
+
+func (r *transferRuntime) Name() string {
+	return "transfer"
+}
+
+// codelessState is the state of the accounts without code
+type codelessState struct {
+	*mockState
+}
+
+func (s *codelessState) GetCode(types.Hash) ([]byte, bool) {
+	return nil, false
+}
+
+// Isolated returns the state itself, its snapshots are only read from maps
+func (s *codelessState) Isolated() State {
+	return s
+}
+
+var testParentRoot = types.StringToHash("1")
+
+// newTestExecutor creates an executor of the transfers between the funded addr1 and addr2
+func newTestExecutor() *Executor {
+	state, snap := newStateWithPreState(map[types.Address]*PreState{
+		addr1: {Balance: 1000000},
+		addr2: {Balance: 1000000},
+	})
+	state.snapshots[testParentRoot] = snap
+
+	executor := NewExecutor(
+		&chain.Params{Forks: chain.AllForksEnabled, ChainID: 100},
+		&codelessState{state},
+		hclog.NewNullLogger(),
+	)
+	executor.SetRuntime(&transferRuntime{})
+	executor.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.Hash{}
+		}
+	}
+
+	return executor
+}
+
+func newTransfer(from, to types.Address, nonce uint64, value int64) *types.Transaction {
+	return &types.Transaction{
+		From:     from,
+		To:       &to,
+		Nonce:    nonce,
+		Value:    big.NewInt(value),
+		Gas:      TxGas,
+		GasPrice: big.NewInt(1),
+	}
+}
+
+func processTestBlock(t *testing.T, workers int, txs []*types.Transaction) *Transition {
+	t.Helper()
+
+	executor := newTestExecutor()
+	executor.SetParallelWorkers(workers)
+
+	block := &types.Block{
+		Header: &types.Header{
+			Number:   1,
+			GasLimit: 1000000,
+		},
+	}
+
+	// copy the transactions, as the senders are set on them
+	for _, tx := range txs {
+		block.Transactions = append(block.Transactions, tx.Copy())
+	}
+
+	txn, err := executor.ProcessBlock(testParentRoot, block, types.StringToAddress("c01bba5e"))
+	assert.NoError(t, err)
+
+	return txn
+}
+
+func TestProcessBlock_Parallel(t *testing.T) {
+	addr3 := types.StringToAddress("3")
+	addr4 := types.StringToAddress("4")
+
+	tests := []struct {
+		name string
+		txs  []*types.Transaction
+	}{
+		{
+			name: "independent transfers",
+			txs: []*types.Transaction{
+				newTransfer(addr1, addr3, 0, 100),
+				newTransfer(addr2, addr4, 0, 200),
+			},
+		},
+		{
+			name: "conflicting transfers",
+			txs: []*types.Transaction{
+				newTransfer(addr1, addr2, 0, 100),
+				newTransfer(addr2, addr3, 0, 30000),
+				newTransfer(addr1, addr4, 1, 300),
+				newTransfer(addr3, addr1, 0, 50),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serial := processTestBlock(t, 0, tt.txs)
+			parallel := processTestBlock(t, 4, tt.txs)
+
+			assert.Equal(t, serial.TotalGas(), parallel.TotalGas())
+			assert.Equal(t, serial.Receipts(), parallel.Receipts())
+
+			for _, addr := range []types.Address{addr1, addr2, addr3, addr4, serial.ctx.Coinbase} {
+				assert.Equal(t, serial.state.GetBalance(addr), parallel.state.GetBalance(addr), addr.String())
+				assert.Equal(t, serial.state.GetNonce(addr), parallel.state.GetNonce(addr), addr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package state
+
+import (
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// accessedState is the accounts and their storage slots read or written. The slots
+// of an account are nil until one of them is accessed
+type accessedState map[types.Address]map[types.Hash]struct{}
+
+func (s accessedState) addAccount(addr types.Address) {
+	if _, ok := s[addr]; !ok {
+		s[addr] = nil
+	}
+}
+
+func (s accessedState) addSlot(addr types.Address, key types.Hash) {
+	slots := s[addr]
+	if slots == nil {
+		slots = map[types.Hash]struct{}{}
+		s[addr] = slots
+	}
+
+	slots[key] = struct{}{}
+}
+
+func (s accessedState) merge(other accessedState) {
+	for addr, slots := range other {
+		s.addAccount(addr)
+
+		for key := range slots {
+			s.addSlot(addr, key)
+		}
+	}
+}
+
+// prefetcher warms the trie nodes of the accounts and the storage slots a block is
+// expected to access, while the block is being executed: the destinations and the
+// access lists of its transactions, then the state the previous block accessed
+type prefetcher struct {
+	lock sync.Mutex
+	// the accounts and slots accessed by the last block executed
+	pattern accessedState
+}
+
+func newPrefetcher() *prefetcher {
+	return &prefetcher{}
+}
+
+// record keeps the state accessed by the executed block, to be prefetched for the next one
+func (p *prefetcher) record(accessed accessedState) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.pattern = accessed
+}
+
+// start prefetches the state the block is expected to access from the snapshot
+// in the background. The returned function stops it
+func (p *prefetcher) start(state State, snapshot Snapshot, block *types.Block) func() {
+	p.lock.Lock()
+	pattern := p.pattern
+	p.lock.Unlock()
+
+	var (
+		stopCh = make(chan struct{})
+		doneCh = make(chan struct{})
+	)
+
+	go func() {
+		defer close(doneCh)
+
+		p.prefetch(NewTxn(state, snapshot), block, pattern, stopCh)
+	}()
+
+	return func() {
+		close(stopCh)
+		<-doneCh
+	}
+}
+
+func (p *prefetcher) prefetch(txn *Txn, block *types.Block, pattern accessedState, stopCh chan struct{}) {
+	stopped := func() bool {
+		select {
+		case <-stopCh:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// the senders are not known until they are recovered by the executor
+	for _, tx := range block.Transactions {
+		if stopped() {
+			return
+		}
+
+		if tx.To != nil {
+			txn.GetAccount(*tx.To)
+		}
+
+		for _, tuple := range tx.AccessList {
+			txn.GetAccount(tuple.Address)
+
+			for _, key := range tuple.StorageKeys {
+				txn.GetState(tuple.Address, key)
+			}
+		}
+	}
+
+	for addr, slots := range pattern {
+		if stopped() {
+			return
+		}
+
+		txn.GetAccount(addr)
+
+		for key := range slots {
+			txn.GetState(addr, key)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package state
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingSnapshot records the keys read from the snapshot
+type recordingSnapshot struct {
+	Snapshot
+
+	lock sync.Mutex
+	keys map[string]struct{}
+}
+
+func (s *recordingSnapshot) Get(k []byte) ([]byte, bool) {
+	s.lock.Lock()
+	s.keys[hex.EncodeToHex(k)] = struct{}{}
+	s.lock.Unlock()
+
+	return s.Snapshot.Get(k)
+}
+
+func (s *recordingSnapshot) read(addr types.Address) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, ok := s.keys[hex.EncodeToHex(hashit(addr.Bytes()))]
+
+	return ok
+}
+
+func TestTxn_TrackAccesses(t *testing.T) {
+	key := types.StringToHash("1")
+
+	txn := newTestTxn(defaultPreState)
+	txn.trackAccesses()
+
+	txn.GetState(addr1, key)
+	txn.AddBalance(addr2, big.NewInt(1))
+	// the slots of a missing account are not accessed
+	txn.GetState(types.StringToAddress("3"), key)
+
+	assert.Equal(t, accessedState{
+		addr1:                      {key: {}},
+		addr2:                      nil,
+		types.StringToAddress("3"): nil,
+	}, txn.accessed)
+}
+
+func TestProcessBlock_Prefetch(t *testing.T) {
+	addr3 := types.StringToAddress("3")
+	addr4 := types.StringToAddress("4")
+	coinbase := types.StringToAddress("c01bba5e")
+
+	executor := newTestExecutor()
+	executor.EnablePrefetch()
+
+	block := &types.Block{
+		Header: &types.Header{
+			Number:   1,
+			GasLimit: 1000000,
+		},
+		Transactions: []*types.Transaction{
+			newTransfer(addr1, addr3, 0, 100),
+		},
+	}
+
+	_, err := executor.ProcessBlock(testParentRoot, block, coinbase)
+	assert.NoError(t, err)
+
+	// the accounts accessed by the block are kept for the next one
+	assert.Equal(t, accessedState{
+		addr1:    nil,
+		addr3:    nil,
+		coinbase: nil,
+	}, executor.prefetcher.pattern)
+
+	parent, err := executor.state.NewSnapshotAt(testParentRoot)
+	assert.NoError(t, err)
+
+	snap := &recordingSnapshot{
+		Snapshot: parent,
+		keys:     map[string]struct{}{},
+	}
+
+	next := &types.Block{
+		Transactions: []*types.Transaction{
+			newTransfer(addr2, addr4, 0, 100),
+		},
+	}
+
+	executor.prefetcher.prefetch(NewTxn(executor.state, snap), next, executor.prefetcher.pattern, make(chan struct{}))
+
+	// the destination of the transaction and the accounts of the previous block
+	for _, addr := range []types.Address{addr4, addr1, addr3, coinbase} {
+		assert.True(t, snap.read(addr), addr.String())
+	}
+
+	// the sender is not known yet
+	assert.False(t, snap.read(addr2))
+}
@@ -125,7 +125,9 @@ func opExp(c *state) {
 	y := c.top()
 
 	var gas uint64
-	if c.config.EIP158 {
+	if costs := c.config.GasCosts; costs != nil && costs.ExpByte != 0 {
+		gas = costs.ExpByte
+	} else if c.config.EIP158 {
 		gas = 50
 	} else {
 		gas = 10
@@ -459,13 +461,45 @@ func opMStore8(c *state) {
 	c.memory[offset.Uint64()] = byte(val.Uint64() & 0xff)
 }
 
+// --- access lists (eip-2929) ---
+
+const (
+	coldAccountAccessGas uint64 = 2600
+	coldSloadGas         uint64 = 2100
+	warmStorageReadGas   uint64 = 100
+)
+
+// accountAccessGas returns the gas of accessing the account, warming it
+func (c *state) accountAccessGas(addr types.Address) uint64 {
+	if c.host.AddressInAccessList(addr) {
+		return warmStorageReadGas
+	}
+
+	c.host.AddAddressToAccessList(addr)
+
+	return coldAccountAccessGas
+}
+
+// slotAccessGas returns the gas of reading the storage slot of the contract, warming it
+func (c *state) slotAccessGas(slot types.Hash) uint64 {
+	if _, ok := c.host.SlotInAccessList(c.msg.Address, slot); ok {
+		return warmStorageReadGas
+	}
+
+	c.host.AddSlotToAccessList(c.msg.Address, slot)
+
+	return coldSloadGas
+}
+
 // --- storage ---
 
 func opSload(c *state) {
 	loc := c.top()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		gas = c.slotAccessGas(bigToHash(loc))
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 800
 	} else if c.config.EIP150 {
@@ -500,12 +534,24 @@ func opSStore(c *state) {
 
 	legacyGasMetering := !c.config.Istanbul && (c.config.Petersburg || !c.config.Constantinople)
 
-	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
 	cost := uint64(0)
 
+	// the cold slot is charged on top (eip-2929)
+	if c.config.Berlin {
+		if _, ok := c.host.SlotInAccessList(c.msg.Address, key); !ok {
+			c.host.AddSlotToAccessList(c.msg.Address, key)
+
+			cost = coldSloadGas
+		}
+	}
+
+	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
+
 	switch status {
 	case runtime.StorageUnchanged:
-		if c.config.Istanbul {
+		if c.config.Berlin {
+			cost += warmStorageReadGas
+		} else if c.config.Istanbul {
 			// eip-2200
 			cost = 800
 		} else if legacyGasMetering {
@@ -514,11 +560,17 @@ func opSStore(c *state) {
 			cost = 200
 		}
 
-	case runtime.StorageModified:
-		cost = 5000
+	case runtime.StorageModified, runtime.StorageDeleted:
+		if c.config.Berlin {
+			cost += 5000 - coldSloadGas
+		} else {
+			cost = 5000
+		}
 
 	case runtime.StorageModifiedAgain:
-		if c.config.Istanbul {
+		if c.config.Berlin {
+			cost += warmStorageReadGas
+		} else if c.config.Istanbul {
 			// eip-2200
 			cost = 800
 		} else if legacyGasMetering {
@@ -528,10 +580,11 @@ func opSStore(c *state) {
 		}
 
 	case runtime.StorageAdded:
-		cost = 20000
-
-	case runtime.StorageDeleted:
-		cost = 5000
+		if costs := c.config.GasCosts; costs != nil && costs.SstoreSet != 0 {
+			cost += costs.SstoreSet
+		} else {
+			cost += 20000
+		}
 	}
 
 	if !c.consumeGas(cost) {
@@ -575,7 +628,9 @@ func opBalance(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		gas = c.accountAccessGas(addr)
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 700
 	} else if c.config.EIP150 {
@@ -611,6 +666,16 @@ func opChainID(c *state) {
 	c.push1().SetUint64(uint64(c.host.GetTxContext().ChainID))
 }
 
+func opBaseFee(c *state) {
+	if !c.config.London {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	c.push1().SetBytes(c.host.GetTxContext().BaseFee.Bytes())
+}
+
 func opOrigin(c *state) {
 	c.push1().SetBytes(c.host.GetTxContext().Origin.Bytes())
 }
@@ -658,7 +723,9 @@ func opExtCodeSize(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		gas = c.accountAccessGas(addr)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -693,7 +760,9 @@ func opExtCodeHash(c *state) {
 	address, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		gas = c.accountAccessGas(address)
+	} else if c.config.Istanbul {
 		gas = 700
 	} else {
 		gas = 400
@@ -767,7 +836,9 @@ func opExtCodeCopy(c *state) {
 	}
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		gas = c.accountAccessGas(address)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -937,6 +1008,13 @@ func opSelfDestruct(c *state) {
 		}
 	}
 
+	// the cold beneficiary is charged on top (eip-2929)
+	if c.config.Berlin && !c.host.AddressInAccessList(address) {
+		c.host.AddAddressToAccessList(address)
+
+		gas += coldAccountAccessGas
+	}
+
 	if !c.consumeGas(gas) {
 		return
 	}
@@ -1199,7 +1277,9 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 	}
 
 	var gasCost uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		gasCost = c.accountAccessGas(addr)
+	} else if c.config.EIP150 {
 		gasCost = 700
 	} else {
 		gasCost = 40
@@ -598,3 +598,128 @@ func Test_opReturnDataCopy(t *testing.T) {
 		})
 	}
 }
+
+// mockHostForAccessList keeps an access list and reads empty storage
+type mockHostForAccessList struct {
+	mockHost
+	addresses map[types.Address]bool
+	slots     map[types.Hash]bool
+	baseFee   types.Hash
+}
+
+func newMockHostForAccessList() *mockHostForAccessList {
+	return &mockHostForAccessList{
+		addresses: map[types.Address]bool{},
+		slots:     map[types.Hash]bool{},
+	}
+}
+
+func (m *mockHostForAccessList) GetStorage(types.Address, types.Hash) types.Hash {
+	return types.Hash{}
+}
+
+func (m *mockHostForAccessList) GetBalance(types.Address) *big.Int {
+	return big.NewInt(0)
+}
+
+func (m *mockHostForAccessList) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{BaseFee: m.baseFee}
+}
+
+func (m *mockHostForAccessList) AddressInAccessList(addr types.Address) bool {
+	return m.addresses[addr]
+}
+
+func (m *mockHostForAccessList) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	return m.addresses[addr], m.slots[slot]
+}
+
+func (m *mockHostForAccessList) AddAddressToAccessList(addr types.Address) {
+	m.addresses[addr] = true
+}
+
+func (m *mockHostForAccessList) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	m.addresses[addr] = true
+	m.slots[slot] = true
+}
+
+func TestSload_Berlin(t *testing.T) {
+	s, closeFn := getState()
+	defer closeFn()
+
+	config := chain.ForksInTime{Istanbul: true, Berlin: true}
+
+	s.host = newMockHostForAccessList()
+	s.msg = &runtime.Contract{Address: addr1}
+	s.config = &config
+	s.gas = 10000
+
+	// the cold slot is warmed by its first read
+	s.push(big.NewInt(1))
+	opSload(s)
+	assert.Equal(t, uint64(10000-2100), s.gas)
+
+	s.push(big.NewInt(1))
+	opSload(s)
+	assert.Equal(t, uint64(10000-2100-100), s.gas)
+}
+
+func TestBalance_Berlin(t *testing.T) {
+	s, closeFn := getState()
+	defer closeFn()
+
+	config := chain.ForksInTime{Istanbul: true, Berlin: true}
+
+	s.host = newMockHostForAccessList()
+	s.msg = &runtime.Contract{Address: addr1}
+	s.config = &config
+	s.gas = 10000
+
+	// the cold account is warmed by its first access
+	s.push(big.NewInt(2))
+	opBalance(s)
+	assert.Equal(t, uint64(10000-2600), s.gas)
+
+	s.push(big.NewInt(2))
+	opBalance(s)
+	assert.Equal(t, uint64(10000-2600-100), s.gas)
+}
+
+func TestBaseFee(t *testing.T) {
+	s, closeFn := getState()
+	defer closeFn()
+
+	host := newMockHostForAccessList()
+	host.baseFee = types.BytesToHash(big.NewInt(7).Bytes())
+
+	// not found before london
+	s.host = host
+	s.config = &chain.ForksInTime{}
+
+	opBaseFee(s)
+	assert.True(t, s.stop)
+	assert.ErrorIs(t, s.err, errOpCodeNotFound)
+
+	s.reset()
+
+	s.host = host
+	s.config = &chain.ForksInTime{London: true}
+
+	opBaseFee(s)
+	assert.Equal(t, big.NewInt(7), s.pop())
+}
+
+func TestExp_GasCostOverride(t *testing.T) {
+	s, closeFn := getState()
+	defer closeFn()
+
+	s.gas = 1000
+	s.config = &chain.ForksInTime{EIP158: true, GasCosts: &chain.GasCosts{ExpByte: 20}}
+
+	// 2 ** 0x100, with a 2 bytes exponent
+	s.push(big.NewInt(0x100))
+	s.push(big.NewInt(2))
+
+	opExp(s)
+	assert.Equal(t, uint64(1000-2*20), s.gas)
+}
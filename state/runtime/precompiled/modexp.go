@@ -14,6 +14,7 @@ type modExp struct {
 
 var (
 	big1      = big.NewInt(1)
+	big7      = big.NewInt(7)
 	big4      = big.NewInt(4)
 	big8      = big.NewInt(8)
 	big16     = big.NewInt(16)
@@ -28,6 +29,10 @@ var (
 
 var (
 	divisor = big.NewInt(20)
+
+	// eip-2565
+	berlinDivisor = big.NewInt(3)
+	berlinMinGas  = big.NewInt(200)
 )
 
 func adjustedExponentLength(expLen, head *big.Int) *big.Int {
@@ -78,6 +83,16 @@ func multComplexity(x *big.Int) *big.Int {
 	return x
 }
 
+// berlinMultComplexity is the complexity of the multiplication repriced by eip-2565,
+// the square of the number of words
+func berlinMultComplexity(x *big.Int) *big.Int {
+	// ceil(x / 8) ** 2
+	x.Add(x, big7)
+	x.Div(x, big8)
+
+	return x.Mul(x, x)
+}
+
 func (m *modExp) gas(input []byte, config *chain.ForksInTime) uint64 {
 	var val, tail []byte
 
@@ -116,7 +131,11 @@ func (m *modExp) gas(input []byte, config *chain.ForksInTime) uint64 {
 		gasCost.Set(baseLen)
 	}
 
-	gasCost = multComplexity(gasCost)
+	if config.Berlin {
+		gasCost = berlinMultComplexity(gasCost)
+	} else {
+		gasCost = multComplexity(gasCost)
+	}
 
 	// a = a * max(ADJUSTED_EXPONENT_LENGTH, 1)
 	adjExpLen := adjustedExponentLength(expLen, expHead)
@@ -127,7 +146,15 @@ func (m *modExp) gas(input []byte, config *chain.ForksInTime) uint64 {
 	}
 
 	// a = a / div
-	gasCost.Div(gasCost, divisor)
+	if config.Berlin {
+		gasCost.Div(gasCost, berlinDivisor)
+
+		if gasCost.Cmp(berlinMinGas) < 0 {
+			gasCost.Set(berlinMinGas)
+		}
+	} else {
+		gasCost.Div(gasCost, divisor)
+	}
 
 	// cap to the max uint64
 	if !gasCost.IsUint64() {
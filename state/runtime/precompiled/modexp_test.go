@@ -3,6 +3,10 @@ package precompiled
 
 import (
 	"testing"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/stretchr/testify/assert"
 )
 
 var modExpTests = []precompiledTest{
@@ -91,3 +95,18 @@ func TestModExp(t *testing.T) {
 	p := &Precompiled{}
 	testPrecompiled(t, &modExp{p}, modExpTests)
 }
+
+func TestModExpGas(t *testing.T) {
+	p := &Precompiled{}
+	m := &modExp{p}
+
+	// nagydani-2-pow0x10001
+	input, _ := hex.DecodeString(modExpTests[7].Input)
+
+	// eip-2565 reprices the exponentiation
+	assert.Equal(t, uint64(10649), m.gas(input, &chain.ForksInTime{Byzantium: true}))
+	assert.Equal(t, uint64(1365), m.gas(input, &chain.ForksInTime{Byzantium: true, Berlin: true}))
+
+	// with a minimum gas
+	assert.Equal(t, uint64(200), m.gas(nil, &chain.ForksInTime{Byzantium: true, Berlin: true}))
+}
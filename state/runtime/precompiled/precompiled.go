@@ -61,14 +61,23 @@ var (
 	nine  = types.StringToAddress("9")
 )
 
-// CanRun implements the runtime interface
-func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.ForksInTime) bool {
-	if _, ok := p.contracts[c.CodeAddress]; !ok {
-		return false
-	}
+// addresses are the addresses of all the precompiled contracts
+var addresses = []types.Address{
+	types.StringToAddress("1"),
+	types.StringToAddress("2"),
+	types.StringToAddress("3"),
+	types.StringToAddress("4"),
+	five,
+	six,
+	seven,
+	eight,
+	nine,
+}
 
+// isActive returns whether the precompiled contract of the address is active in the forks
+func isActive(addr types.Address, config *chain.ForksInTime) bool {
 	// byzantium precompiles
-	switch c.CodeAddress {
+	switch addr {
 	case five:
 		fallthrough
 	case six:
@@ -80,7 +89,7 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 	}
 
 	// istanbul precompiles
-	switch c.CodeAddress {
+	switch addr {
 	case nine:
 		return config.Istanbul
 	}
@@ -88,6 +97,40 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 	return true
 }
 
+// IsReserved returns whether the address is the address of a precompiled contract
+func IsReserved(addr types.Address) bool {
+	for _, a := range addresses {
+		if a == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ActiveAddresses returns the addresses of the precompiled contracts active in the forks,
+// which are warm from the start of every transaction (EIP-2929)
+func ActiveAddresses(config *chain.ForksInTime) []types.Address {
+	active := make([]types.Address, 0, len(addresses))
+
+	for _, addr := range addresses {
+		if isActive(addr, config) {
+			active = append(active, addr)
+		}
+	}
+
+	return active
+}
+
+// CanRun implements the runtime interface
+func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.ForksInTime) bool {
+	if _, ok := p.contracts[c.CodeAddress]; !ok {
+		return false
+	}
+
+	return isActive(c.CodeAddress, config)
+}
+
 // Name implements the runtime interface
 func (p *Precompiled) Name() string {
 	return "precompiled"
@@ -18,6 +18,7 @@ type TxContext struct {
 	GasLimit   int64
 	ChainID    int64
 	Difficulty types.Hash
+	BaseFee    types.Hash
 }
 
 // StorageStatus is the status of the storage access
@@ -70,6 +71,10 @@ type Host interface {
 	Empty(addr types.Address) bool
 	GetNonce(addr types.Address) uint64
 	GetEVMLogger() EVMLogger
+	AddressInAccessList(addr types.Address) bool
+	SlotInAccessList(addr types.Address, slot types.Hash) (addrOk bool, slotOk bool)
+	AddAddressToAccessList(addr types.Address)
+	AddSlotToAccessList(addr types.Address, slot types.Hash)
 }
 
 // ExecutionResult includes all output after executing given evm
@@ -0,0 +1,244 @@
+package stateful
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/state/runtime"
+	"github.com/dogechain-lab/dogechain/state/runtime/precompiled"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+var (
+	ErrAddressRegistered = errors.New("address already registered")
+	ErrReservedAddress   = errors.New("address reserved to the standard precompiled contracts")
+	ErrWriteProtection   = errors.New("write protection")
+)
+
+// State is the constrained state a stateful precompiled contract runs on: the storage
+// of its own account, the balances of the accounts and the logs it emits
+type State interface {
+	// GetStorage returns the value of the slot of the contract storage
+	GetStorage(key types.Hash) types.Hash
+	// SetStorage sets the value of the slot of the contract storage,
+	// failing with ErrWriteProtection in a static call
+	SetStorage(key types.Hash, value types.Hash) error
+	// GetBalance returns the balance of the account
+	GetBalance(addr types.Address) *big.Int
+	// EmitLog emits the log from the contract, failing with ErrWriteProtection in a static call
+	EmitLog(topics []types.Hash, data []byte) error
+}
+
+// CallContext is the call of a stateful precompiled contract
+type CallContext struct {
+	Caller      types.Address
+	Value       *big.Int
+	Input       []byte
+	Static      bool
+	BlockNumber uint64
+	Config      *chain.ForksInTime
+}
+
+// Contract is a chain-specific precompiled contract with access to its state
+type Contract interface {
+	// Gas returns the gas of running the input, charged before running it
+	Gas(input []byte, config *chain.ForksInTime) uint64
+	// Run runs the call on the state of the contract. runtime.ErrExecutionReverted reverts
+	// the call, leaving the remaining gas to the caller, any other error consumes it all
+	Run(ctx *CallContext, state State) ([]byte, error)
+}
+
+type registration struct {
+	contract   Contract
+	activation chain.Fork
+}
+
+var _ runtime.Runtime = &Registry{}
+
+// Registry is the runtime of the stateful precompiled contracts registered at fixed
+// addresses, each of them running from its activation block
+type Registry struct {
+	lock      sync.RWMutex
+	contracts map[types.Address]*registration
+}
+
+// NewRegistry creates an empty registry of stateful precompiled contracts
+func NewRegistry() *Registry {
+	return &Registry{
+		contracts: map[types.Address]*registration{},
+	}
+}
+
+// Register registers the contract at the address, running from the activation block.
+// The addresses of the standard precompiled contracts are reserved
+func (r *Registry) Register(addr types.Address, activation chain.Fork, contract Contract) error {
+	if precompiled.IsReserved(addr) {
+		return fmt.Errorf("%w: %s", ErrReservedAddress, addr)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.contracts[addr]; ok {
+		return fmt.Errorf("%w: %s", ErrAddressRegistered, addr)
+	}
+
+	r.contracts[addr] = &registration{
+		contract:   contract,
+		activation: activation,
+	}
+
+	return nil
+}
+
+// ActiveAddresses returns the sorted addresses of the contracts active at the block
+func (r *Registry) ActiveAddresses(block uint64) []types.Address {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	addrs := make([]types.Address, 0, len(r.contracts))
+
+	for addr, reg := range r.contracts {
+		if reg.activation.Active(block) {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].String() < addrs[j].String()
+	})
+
+	return addrs
+}
+
+func (r *Registry) get(addr types.Address, block uint64) (Contract, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	reg, ok := r.contracts[addr]
+	if !ok || !reg.activation.Active(block) {
+		return nil, false
+	}
+
+	return reg.contract, true
+}
+
+// Name implements the runtime interface
+func (r *Registry) Name() string {
+	return "stateful precompiled"
+}
+
+// CanRun implements the runtime interface
+func (r *Registry) CanRun(c *runtime.Contract, host runtime.Host, _ *chain.ForksInTime) bool {
+	_, ok := r.get(c.CodeAddress, uint64(host.GetTxContext().Number))
+
+	return ok
+}
+
+// Run implements the runtime interface
+func (r *Registry) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) *runtime.ExecutionResult {
+	block := uint64(host.GetTxContext().Number)
+
+	contract, ok := r.get(c.CodeAddress, block)
+	if !ok {
+		return &runtime.ExecutionResult{
+			GasLeft: c.Gas,
+			Err:     runtime.ErrExecutionReverted,
+		}
+	}
+
+	gasCost := contract.Gas(c.Input, config)
+	if c.Gas < gasCost {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrOutOfGas,
+		}
+	}
+
+	gasLeft := c.Gas - gasCost
+
+	// the contract only manages its own storage, it is not run on the
+	// storage of the caller by a delegate call or a call code
+	if c.Address != c.CodeAddress {
+		return &runtime.ExecutionResult{
+			GasLeft: gasLeft,
+			Err:     runtime.ErrExecutionReverted,
+		}
+	}
+
+	ret, err := contract.Run(
+		&CallContext{
+			Caller:      c.Caller,
+			Value:       c.Value,
+			Input:       c.Input,
+			Static:      c.Static,
+			BlockNumber: block,
+			Config:      config,
+		},
+		&contractState{
+			host:    host,
+			address: c.Address,
+			static:  c.Static,
+			config:  config,
+		},
+	)
+
+	switch {
+	case err == nil:
+		return &runtime.ExecutionResult{
+			ReturnValue: ret,
+			GasLeft:     gasLeft,
+		}
+	case errors.Is(err, runtime.ErrExecutionReverted):
+		return &runtime.ExecutionResult{
+			ReturnValue: ret,
+			GasLeft:     gasLeft,
+			Err:         runtime.ErrExecutionReverted,
+		}
+	default:
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     err,
+		}
+	}
+}
+
+// contractState is the state of the contract over the host of the call
+type contractState struct {
+	host    runtime.Host
+	address types.Address
+	static  bool
+	config  *chain.ForksInTime
+}
+
+func (s *contractState) GetStorage(key types.Hash) types.Hash {
+	return s.host.GetStorage(s.address, key)
+}
+
+func (s *contractState) SetStorage(key types.Hash, value types.Hash) error {
+	if s.static {
+		return ErrWriteProtection
+	}
+
+	s.host.SetStorage(s.address, key, value, s.config)
+
+	return nil
+}
+
+func (s *contractState) GetBalance(addr types.Address) *big.Int {
+	return s.host.GetBalance(addr)
+}
+
+func (s *contractState) EmitLog(topics []types.Hash, data []byte) error {
+	if s.static {
+		return ErrWriteProtection
+	}
+
+	s.host.EmitLog(s.address, topics, data)
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package stateful
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/state/runtime"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	counterAddr = types.StringToAddress("1000")
+	counterSlot = types.Hash{}
+)
+
+// counterContract increments its counter, returning the previous value
+type counterContract struct{}
+
+func (c *counterContract) Gas([]byte, *chain.ForksInTime) uint64 {
+	return 100
+}
+
+func (c *counterContract) Run(ctx *CallContext, state State) ([]byte, error) {
+	prev := state.GetStorage(counterSlot)
+	if len(ctx.Input) > 0 {
+		return prev.Bytes(), runtime.ErrExecutionReverted
+	}
+
+	next := new(big.Int).Add(new(big.Int).SetBytes(prev.Bytes()), big.NewInt(1))
+
+	if err := state.SetStorage(counterSlot, types.BytesToHash(next.Bytes())); err != nil {
+		return nil, err
+	}
+
+	return prev.Bytes(), nil
+}
+
+// mockHost keeps the storage, other methods panic as the embedded host is nil
+type mockHost struct {
+	runtime.Host
+	number  int64
+	storage map[types.Address]map[types.Hash]types.Hash
+}
+
+func newMockHost(number int64) *mockHost {
+	return &mockHost{
+		number:  number,
+		storage: map[types.Address]map[types.Hash]types.Hash{},
+	}
+}
+
+func (m *mockHost) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Number: m.number}
+}
+
+func (m *mockHost) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.storage[addr][key]
+}
+
+func (m *mockHost) SetStorage(
+	addr types.Address,
+	key types.Hash,
+	value types.Hash,
+	_ *chain.ForksInTime,
+) runtime.StorageStatus {
+	if m.storage[addr] == nil {
+		m.storage[addr] = map[types.Hash]types.Hash{}
+	}
+
+	m.storage[addr][key] = value
+
+	return runtime.StorageModified
+}
+
+func newCall(gas uint64, input []byte) *runtime.Contract {
+	return runtime.NewContractCall(1, types.ZeroAddress, types.ZeroAddress, counterAddr, big.NewInt(0), gas, nil, input)
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := NewRegistry()
+
+	assert.NoError(t, registry.Register(counterAddr, 10, &counterContract{}))
+	assert.ErrorIs(t, registry.Register(counterAddr, 10, &counterContract{}), ErrAddressRegistered)
+	assert.ErrorIs(t, registry.Register(types.StringToAddress("1"), 0, &counterContract{}), ErrReservedAddress)
+
+	assert.Empty(t, registry.ActiveAddresses(9))
+	assert.Equal(t, []types.Address{counterAddr}, registry.ActiveAddresses(10))
+}
+
+func TestRegistry_Run(t *testing.T) {
+	registry := NewRegistry()
+	config := &chain.ForksInTime{}
+
+	assert.NoError(t, registry.Register(counterAddr, 10, &counterContract{}))
+
+	// not run before its activation
+	assert.False(t, registry.CanRun(newCall(1000, nil), newMockHost(9), config))
+
+	host := newMockHost(10)
+	assert.True(t, registry.CanRun(newCall(1000, nil), host, config))
+
+	result := registry.Run(newCall(1000, nil), host, config)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, uint64(900), result.GasLeft)
+	assert.Equal(t, types.StringToHash("1"), host.GetStorage(counterAddr, counterSlot))
+
+	// the revert leaves the remaining gas
+	result = registry.Run(newCall(1000, []byte{0x1}), host, config)
+	assert.ErrorIs(t, result.Err, runtime.ErrExecutionReverted)
+	assert.Equal(t, uint64(900), result.GasLeft)
+
+	// the write of a static call consumes all the gas
+	static := newCall(1000, nil)
+	static.Static = true
+
+	result = registry.Run(static, host, config)
+	assert.ErrorIs(t, result.Err, ErrWriteProtection)
+	assert.Equal(t, uint64(0), result.GasLeft)
+	assert.Equal(t, types.StringToHash("1"), host.GetStorage(counterAddr, counterSlot))
+
+	// out of gas
+	result = registry.Run(newCall(99, nil), host, config)
+	assert.ErrorIs(t, result.Err, runtime.ErrOutOfGas)
+
+	// not run on the storage of the caller
+	delegate := newCall(1000, nil)
+	delegate.Address = types.StringToAddress("2000")
+
+	result = registry.Run(delegate, host, config)
+	assert.ErrorIs(t, result.Err, runtime.ErrExecutionReverted)
+	assert.Empty(t, host.storage[delegate.Address])
+}
@@ -0,0 +1,282 @@
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+const (
+	// generateBudget is the number of accounts and slots generated at once
+	generateBudget = 4096
+
+	// wipeBatchSize is the number of keys deleted at once when the disk layer is wiped
+	wipeBatchSize = 10000
+
+	generateRetryInterval = 10 * time.Second
+	generateLogInterval   = 30 * time.Second
+)
+
+// generate generates the disk layer from the trie until the tree is closed. The generation
+// restarts when the disk layer is reset, and is retried on failure
+func (t *Tree) generate() {
+	defer close(t.doneCh)
+
+	var (
+		start   = time.Now()
+		logged  = time.Now()
+		running = false
+	)
+
+	for {
+		done, err := t.generateStep()
+
+		switch {
+		case err != nil:
+			t.logger.Warn("failed to generate the state snapshot", "err", err)
+
+			// the state of the disk layer is pruned, move it to the latest state
+			if errors.Is(err, itrie.ErrMissingTrieNode) {
+				t.flattenHead()
+			}
+
+			select {
+			case <-time.After(generateRetryInterval):
+			case <-t.closeCh:
+				return
+			}
+
+			continue
+		case done:
+			if running {
+				t.logger.Info("state snapshot generated", "elapsed", time.Since(start))
+			}
+
+			running = false
+
+			select {
+			case <-t.wakeCh:
+			case <-t.closeCh:
+				return
+			}
+
+			continue
+		}
+
+		if !running {
+			running = true
+			start = time.Now()
+		}
+
+		if time.Since(logged) > generateLogInterval {
+			logged = time.Now()
+
+			t.lock.RLock()
+			t.logger.Info("generating state snapshot", "root", t.root, "marker", hex.EncodeToHex(t.marker))
+			t.lock.RUnlock()
+		}
+
+		select {
+		case <-t.closeCh:
+			return
+		default:
+		}
+	}
+}
+
+// flattenHead flattens the layers up to the latest one
+func (t *Tree) flattenHead() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.cap(t.head, 0); err != nil {
+		t.logger.Warn("failed to flatten the state snapshot layers", "err", err)
+	}
+}
+
+// generateStep generates the next accounts and slots of the disk layer, from the trie of
+// its state, returning whether the disk layer is fully generated
+func (t *Tree) generateStep() (bool, error) {
+	t.lock.RLock()
+	root, marker, wipe, generated := t.root, t.marker, t.wipe, t.generated
+	t.lock.RUnlock()
+
+	if wipe {
+		return false, t.wipeDisk()
+	}
+
+	if generated {
+		return true, nil
+	}
+
+	var (
+		accountOrigin []byte
+		slotOrigin    []byte
+		slotsDone     bool
+	)
+
+	switch len(marker) {
+	case types.HashLength:
+		if accountOrigin = nextKey(marker); accountOrigin == nil {
+			return t.commitGenerated(root, marker, marker, true, t.db.Batch())
+		}
+	case 2 * types.HashLength:
+		accountOrigin = marker[:types.HashLength]
+		slotOrigin = nextKey(marker[types.HashLength:])
+		slotsDone = slotOrigin == nil
+	}
+
+	keys, values, more, err := itrie.ReadRange(t.storage, root, accountOrigin, generateBudget)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		batch  = t.db.Batch()
+		next   = marker
+		budget = generateBudget
+	)
+
+	for i, key := range keys {
+		if budget <= 0 {
+			more = true
+
+			break
+		}
+
+		// the storage of the account of the marker is partially generated
+		resume := len(marker) == 2*types.HashLength && bytes.Equal(key, accountOrigin)
+
+		var account state.Account
+		if err := account.UnmarshalRlp(values[i]); err != nil {
+			return false, err
+		}
+
+		complete := true
+
+		if account.Root != types.EmptyRootHash && (!resume || !slotsDone) {
+			var origin []byte
+			if resume {
+				origin = slotOrigin
+			}
+
+			slotKeys, slotValues, slotsMore, err := itrie.ReadRange(t.storage, account.Root, origin, budget)
+			if err != nil {
+				return false, err
+			}
+
+			for j, slotKey := range slotKeys {
+				batch.Set(storageKey(key, slotKey), slotValues[j])
+			}
+
+			budget -= len(slotKeys)
+
+			if slotsMore {
+				next = append(append([]byte{}, key...), slotKeys[len(slotKeys)-1]...)
+				complete = false
+			}
+		}
+
+		if !complete {
+			more = true
+
+			break
+		}
+
+		batch.Set(accountKey(key), values[i])
+		next = key
+		budget--
+	}
+
+	return t.commitGenerated(root, marker, next, !more, batch)
+}
+
+// commitGenerated writes the generated keys, unless the disk layer moved while they
+// were generated, then they are generated again
+func (t *Tree) commitGenerated(root types.Hash, marker, next []byte, done bool, batch kvdb.KVBatch) (bool, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.wipe || t.root != root || !bytes.Equal(t.marker, marker) {
+		return false, nil
+	}
+
+	if done {
+		batch.Set(generatorKey, []byte{generatorDone})
+	} else {
+		batch.Set(generatorKey, append([]byte{generatorRunning}, next...))
+	}
+
+	if err := batch.Write(); err != nil {
+		return false, err
+	}
+
+	t.marker = append([]byte{}, next...)
+	t.generated = done
+
+	return done, nil
+}
+
+// wipeDisk deletes the accounts and the slots of the disk layer, which are not read
+// while it is wiped since none of them is generated
+func (t *Tree) wipeDisk() error {
+	for _, prefix := range [][]byte{accountPrefix, storagePrefix} {
+		for {
+			var keys [][]byte
+
+			if err := t.db.Iterate(prefix, func(k, _ []byte) bool {
+				keys = append(keys, append([]byte{}, k...))
+
+				return len(keys) < wipeBatchSize
+			}); err != nil {
+				return err
+			}
+
+			if len(keys) == 0 {
+				break
+			}
+
+			batch := t.db.Batch()
+			for _, key := range keys {
+				batch.Delete(key)
+			}
+
+			if err := batch.Write(); err != nil {
+				return err
+			}
+		}
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.db.Set(generatorKey, []byte{generatorRunning}); err != nil {
+		return err
+	}
+
+	t.wipe = false
+	t.marker = nil
+
+	return nil
+}
+
+// nextKey returns the key following the key, nil if it is the last one
+func nextKey(key []byte) []byte {
+	next := append([]byte{}, key...)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+
+		if next[i] != 0 {
+			return next
+		}
+	}
+
+	return nil
+}
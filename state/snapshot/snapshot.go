@@ -0,0 +1,642 @@
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/dogechain-lab/fastrlp"
+	"github.com/hashicorp/go-hclog"
+)
+
+// maxDiffLayers is the number of diff layers kept in memory on top of the disk layer,
+// covering the reorganizations
+const maxDiffLayers = 128
+
+var (
+	ErrNotCovered     = errors.New("not covered by the state snapshot")
+	ErrUnknownRoot    = errors.New("unknown state snapshot root")
+	ErrMissingAccount = errors.New("committed account not found")
+)
+
+var (
+	// accountPrefix + account hash -> account rlp
+	accountPrefix = []byte("a")
+	// storagePrefix + account hash + slot hash -> slot rlp
+	storagePrefix = []byte("s")
+
+	// rootKey is the key of the state root of the disk layer
+	rootKey = []byte("root")
+	// generatorKey is the key of the progress of the generation of the disk layer,
+	// missing while the disk layer has to be wiped
+	generatorKey = []byte("generator")
+)
+
+// status of the generation, the first byte of the value of the generator key
+const (
+	generatorRunning byte = iota
+	generatorDone
+)
+
+var arenaPool fastrlp.ArenaPool
+
+func accountKey(hash []byte) []byte {
+	return append(append([]byte{}, accountPrefix...), hash...)
+}
+
+func storageKey(accountHash, slotHash []byte) []byte {
+	return append(append(append([]byte{}, storagePrefix...), accountHash...), slotHash...)
+}
+
+// diffLayer is the state changes of a block on top of the state of its parent
+type diffLayer struct {
+	root   types.Hash
+	parent types.Hash
+
+	// the rlp of the accounts written, nil if deleted
+	accounts map[types.Hash][]byte
+	// the accounts whose storage is wiped, before the slots of the layer are written
+	destructs map[types.Hash]struct{}
+	// the rlp of the slots written, nil if deleted
+	storage map[types.Hash]map[types.Hash][]byte
+}
+
+// newDiffLayer creates the layer of the objects committed on the parent trie snapshot,
+// the accounts being read from the committed one
+func newDiffLayer(
+	parent, root types.Hash,
+	parentSnap, snap state.Snapshot,
+	objs []*state.Object,
+) (*diffLayer, error) {
+	layer := &diffLayer{
+		root:      root,
+		parent:    parent,
+		accounts:  make(map[types.Hash][]byte, len(objs)),
+		destructs: map[types.Hash]struct{}{},
+		storage:   map[types.Hash]map[types.Hash][]byte{},
+	}
+
+	ar := arenaPool.Get()
+	defer arenaPool.Put(ar)
+
+	for _, obj := range objs {
+		hash := types.BytesToHash(crypto.Keccak256(obj.Address.Bytes()))
+
+		if obj.Deleted {
+			layer.accounts[hash] = nil
+			layer.destructs[hash] = struct{}{}
+
+			continue
+		}
+
+		data, ok := snap.Get(hash.Bytes())
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingAccount, obj.Address)
+		}
+
+		layer.accounts[hash] = data
+
+		// a recreated account is committed on the empty storage root
+		if obj.Root == types.EmptyRootHash && hasStorage(parentSnap, hash) {
+			layer.destructs[hash] = struct{}{}
+		}
+
+		if len(obj.Storage) == 0 {
+			continue
+		}
+
+		slots := make(map[types.Hash][]byte, len(obj.Storage))
+
+		for _, entry := range obj.Storage {
+			slot := types.BytesToHash(crypto.Keccak256(entry.Key))
+
+			if entry.Deleted {
+				slots[slot] = nil
+			} else {
+				// the same encoding as the storage trie
+				slots[slot] = ar.NewBytes(bytes.TrimLeft(entry.Val, "\x00")).MarshalTo(nil)
+				ar.Reset()
+			}
+		}
+
+		layer.storage[hash] = slots
+	}
+
+	return layer, nil
+}
+
+// hasStorage returns whether the account of the hash has storage in the trie snapshot
+func hasStorage(snap state.Snapshot, hash types.Hash) bool {
+	data, ok := snap.Get(hash.Bytes())
+	if !ok {
+		return false
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return false
+	}
+
+	return account.Root != types.EmptyRootHash
+}
+
+// Tree is a flat key-value snapshot of the accounts and the storage slots of the state,
+// keyed by their hashes as in the trie. The disk layer holds the state of a root, and the
+// diff layers on top of it the changes of the blocks committed since, up to maxDiffLayers
+// of them. It wraps the trie state, so that the states committed are tracked and the
+// accounts are read from the snapshot rather than the trie.
+//
+// The disk layer is generated from the trie in the background, the keys not generated yet
+// being read from the trie. It is wiped and generated again when it does not match the
+// state of the chain anymore, as after an unclean shutdown
+type Tree struct {
+	logger  hclog.Logger
+	db      kvdb.Database
+	state   state.State
+	storage itrie.Storage
+
+	lock sync.RWMutex
+	// root is the state root of the disk layer
+	root types.Hash
+	// marker is the last key generated in the disk layer, an account hash once its account
+	// and storage are generated, or an account hash and a slot hash while its storage is
+	marker    []byte
+	generated bool
+	// wipe is set while the disk layer has to be deleted before being generated again
+	wipe   bool
+	layers map[types.Hash]*diffLayer
+	// head is the root of the latest layer added
+	head    types.Hash
+	started bool
+	closed  bool
+
+	wakeCh  chan struct{}
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+// New creates the snapshot tree over the trie state, with the disk layer persisted in the database
+func New(logger hclog.Logger, db kvdb.Database, st state.State, storage itrie.Storage) (*Tree, error) {
+	t := &Tree{
+		logger:  logger,
+		db:      db,
+		state:   st,
+		storage: storage,
+		layers:  map[types.Hash]*diffLayer{},
+		wakeCh:  make(chan struct{}, 1),
+		closeCh: make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+
+	root, ok, err := db.Get(rootKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		t.root = types.BytesToHash(root)
+	}
+
+	progress, ok, err := db.Get(generatorKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !ok || len(progress) == 0:
+		t.wipe = true
+	case progress[0] == generatorDone:
+		t.generated = true
+	default:
+		t.marker = append([]byte{}, progress[1:]...)
+	}
+
+	t.head = t.root
+
+	return t, nil
+}
+
+// Start starts generating the disk layer in the background. The disk layer is wiped
+// and generated again if it is not the state of the head
+func (t *Tree) Start(head types.Hash) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.root != head || t.wipe {
+		if err := t.reset(head); err != nil {
+			return err
+		}
+	}
+
+	t.started = true
+
+	go t.generate()
+
+	return nil
+}
+
+// Close stops the generation and closes the database of the disk layer
+func (t *Tree) Close() error {
+	t.lock.Lock()
+	t.closed = true
+	started := t.started
+	t.lock.Unlock()
+
+	close(t.closeCh)
+
+	if started {
+		<-t.doneCh
+	}
+
+	return t.db.Close()
+}
+
+// NewSnapshot returns an empty trie snapshot
+func (t *Tree) NewSnapshot() state.Snapshot {
+	return t.state.NewSnapshot()
+}
+
+// NewSnapshotAt returns the snapshot of the state of the root, reading the accounts from the tree
+func (t *Tree) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
+	return t.snapshotAt(t.state, root)
+}
+
+// snapshotAt returns the snapshot of the state of the root, reading the accounts from the
+// tree and the tries from the trie state
+func (t *Tree) snapshotAt(st state.State, root types.Hash) (state.Snapshot, error) {
+	snap, err := st.NewSnapshotAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	// the empty storage tries are opened at the empty root as well
+	if root == types.EmptyRootHash {
+		return snap, nil
+	}
+
+	return &treeSnapshot{Snapshot: snap, tree: t, root: root}, nil
+}
+
+// GetCode returns the code of the hash from the trie state
+func (t *Tree) GetCode(hash types.Hash) ([]byte, bool) {
+	return t.state.GetCode(hash)
+}
+
+// Isolated returns a view of the tree over a trie state of its own, whose tries share
+// no nodes with the ones of the tree
+func (t *Tree) Isolated() state.State {
+	return &isolatedTree{tree: t, state: itrie.NewState(t.storage)}
+}
+
+// Account returns the rlp of the account of the hash in the state of the root, nil if it
+// does not exist. ErrNotCovered is returned if the account is not generated yet, and
+// ErrUnknownRoot if the state is not tracked by the tree
+func (t *Tree) Account(root, hash types.Hash) ([]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	for root != t.root {
+		layer, ok := t.layers[root]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownRoot, root)
+		}
+
+		if data, ok := layer.accounts[hash]; ok {
+			return data, nil
+		}
+
+		root = layer.parent
+	}
+
+	if !t.accountCovered(hash.Bytes()) {
+		return nil, ErrNotCovered
+	}
+
+	return t.get(accountKey(hash.Bytes()))
+}
+
+// Storage returns the rlp of the slot of the hash of the account in the state of the root,
+// nil if it does not exist, with the errors of Account
+func (t *Tree) Storage(root, accountHash, slotHash types.Hash) ([]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	for root != t.root {
+		layer, ok := t.layers[root]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownRoot, root)
+		}
+
+		if data, ok := layer.storage[accountHash][slotHash]; ok {
+			return data, nil
+		}
+
+		if _, ok := layer.destructs[accountHash]; ok {
+			return nil, nil
+		}
+
+		root = layer.parent
+	}
+
+	if !t.storageCovered(accountHash.Bytes(), slotHash.Bytes()) {
+		return nil, ErrNotCovered
+	}
+
+	return t.get(storageKey(accountHash.Bytes(), slotHash.Bytes()))
+}
+
+func (t *Tree) get(key []byte) ([]byte, error) {
+	data, ok, err := t.db.Get(key)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// accountCovered returns whether the account of the hash is generated in the disk layer
+func (t *Tree) accountCovered(hash []byte) bool {
+	if t.generated {
+		return true
+	}
+
+	switch len(t.marker) {
+	case types.HashLength:
+		return bytes.Compare(hash, t.marker) <= 0
+	case 2 * types.HashLength:
+		return bytes.Compare(hash, t.marker[:types.HashLength]) < 0
+	default:
+		return false
+	}
+}
+
+// storageCovered returns whether the slot of the hash of the account is generated in the disk layer
+func (t *Tree) storageCovered(accountHash, slotHash []byte) bool {
+	if t.accountCovered(accountHash) {
+		return true
+	}
+
+	return len(t.marker) == 2*types.HashLength &&
+		bytes.Equal(accountHash, t.marker[:types.HashLength]) &&
+		bytes.Compare(slotHash, t.marker[types.HashLength:]) <= 0
+}
+
+// update adds the layer of the objects committed on the state of the parent root
+func (t *Tree) update(parent, root types.Hash, parentSnap, snap state.Snapshot, objs []*state.Object) {
+	t.lock.RLock()
+	started := t.started
+	t.lock.RUnlock()
+
+	if parent == root || !started {
+		return
+	}
+
+	layer, err := newDiffLayer(parent, root, parentSnap, snap, objs)
+	if err != nil {
+		t.logger.Error("failed to create the state snapshot layer", "root", root, "err", err)
+
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if !t.started || t.closed {
+		return
+	}
+
+	if _, ok := t.layers[root]; ok || root == t.root {
+		return
+	}
+
+	if _, ok := t.layers[parent]; !ok && parent != t.root {
+		t.logger.Info("state snapshot does not track the parent state, generating it again",
+			"parent", parent,
+			"root", root,
+		)
+
+		if err := t.reset(root); err != nil {
+			t.logger.Error("failed to reset the state snapshot", "err", err)
+		}
+
+		return
+	}
+
+	t.layers[root] = layer
+	t.head = root
+
+	if err := t.cap(root, maxDiffLayers); err != nil {
+		t.logger.Error("failed to flatten the state snapshot layers", "err", err)
+	}
+}
+
+// Flatten writes the layers up to the state of the root into the disk layer, which
+// then persists the state of the root
+func (t *Tree) Flatten(root types.Hash) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.cap(root, 0)
+}
+
+// cap flattens the layers of the state of the root into the disk layer, but the latest
+// ones, then drops the layers not built on top of the disk layer anymore
+func (t *Tree) cap(root types.Hash, layers int) error {
+	var chain []*diffLayer
+
+	for r := root; r != t.root; {
+		layer, ok := t.layers[r]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownRoot, root)
+		}
+
+		chain = append(chain, layer)
+		r = layer.parent
+	}
+
+	if len(chain) <= layers {
+		return nil
+	}
+
+	for len(chain) > layers {
+		if err := t.flatten(chain[len(chain)-1]); err != nil {
+			return err
+		}
+
+		chain = chain[:len(chain)-1]
+	}
+
+	t.dropStale()
+
+	return nil
+}
+
+// flatten writes the bottom layer into the disk layer, only the keys generated, the
+// others being generated from the state of the layer later
+func (t *Tree) flatten(layer *diffLayer) error {
+	batch := t.db.Batch()
+
+	for hash := range layer.destructs {
+		// the partially generated storage of the account is wiped as well
+		keys, err := t.storageKeys(hash.Bytes())
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			batch.Delete(key)
+		}
+	}
+
+	for hash, data := range layer.accounts {
+		if !t.accountCovered(hash.Bytes()) {
+			continue
+		}
+
+		if data == nil {
+			batch.Delete(accountKey(hash.Bytes()))
+		} else {
+			batch.Set(accountKey(hash.Bytes()), data)
+		}
+	}
+
+	for accountHash, slots := range layer.storage {
+		for slotHash, data := range slots {
+			if !t.storageCovered(accountHash.Bytes(), slotHash.Bytes()) {
+				continue
+			}
+
+			if data == nil {
+				batch.Delete(storageKey(accountHash.Bytes(), slotHash.Bytes()))
+			} else {
+				batch.Set(storageKey(accountHash.Bytes(), slotHash.Bytes()), data)
+			}
+		}
+	}
+
+	batch.Set(rootKey, layer.root.Bytes())
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	t.root = layer.root
+	delete(t.layers, layer.root)
+
+	return nil
+}
+
+// storageKeys returns the keys of the slots of the account in the disk layer
+func (t *Tree) storageKeys(accountHash []byte) ([][]byte, error) {
+	var keys [][]byte
+
+	err := t.db.Iterate(storageKey(accountHash, nil), func(k, _ []byte) bool {
+		keys = append(keys, append([]byte{}, k...))
+
+		return true
+	})
+
+	return keys, err
+}
+
+// dropStale drops the layers which are not built on top of the disk layer
+func (t *Tree) dropStale() {
+	live := map[types.Hash]bool{t.root: true}
+
+	var reaches func(root types.Hash) bool
+
+	reaches = func(root types.Hash) bool {
+		if ok, seen := live[root]; seen {
+			return ok
+		}
+
+		layer, ok := t.layers[root]
+		ok = ok && reaches(layer.parent)
+		live[root] = ok
+
+		return ok
+	}
+
+	for root := range t.layers {
+		if !reaches(root) {
+			delete(t.layers, root)
+		}
+	}
+}
+
+// reset drops the layers and marks the disk layer to be wiped, then generated from the state of the root
+func (t *Tree) reset(root types.Hash) error {
+	batch := t.db.Batch()
+	batch.Set(rootKey, root.Bytes())
+	batch.Delete(generatorKey)
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	t.root = root
+	t.head = root
+	t.marker = nil
+	t.generated = false
+	t.wipe = true
+	t.layers = map[types.Hash]*diffLayer{}
+
+	select {
+	case t.wakeCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// isolatedTree reads the accounts from the tree, and the tries from its own trie state
+type isolatedTree struct {
+	tree  *Tree
+	state state.State
+}
+
+func (v *isolatedTree) NewSnapshot() state.Snapshot {
+	return v.state.NewSnapshot()
+}
+
+func (v *isolatedTree) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
+	return v.tree.snapshotAt(v.state, root)
+}
+
+func (v *isolatedTree) GetCode(hash types.Hash) ([]byte, bool) {
+	return v.state.GetCode(hash)
+}
+
+// treeSnapshot is the trie snapshot of a state whose accounts are read from the tree,
+// or from the trie when they are not covered by the tree
+type treeSnapshot struct {
+	state.Snapshot
+
+	tree *Tree
+	root types.Hash
+}
+
+func (s *treeSnapshot) Get(k []byte) ([]byte, bool) {
+	if len(k) == types.HashLength {
+		if data, err := s.tree.Account(s.root, types.BytesToHash(k)); err == nil {
+			return data, data != nil
+		}
+	}
+
+	return s.Snapshot.Get(k)
+}
+
+// Commit commits the objects to the trie, adding their layer to the tree
+func (s *treeSnapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
+	snap, root := s.Snapshot.Commit(objs)
+
+	s.tree.update(s.root, types.BytesToHash(root), s.Snapshot, snap, objs)
+
+	return &treeSnapshot{Snapshot: snap, tree: s.tree, root: types.BytesToHash(root)}, root
+}
@@ -0,0 +1,338 @@
+package snapshot
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/helper/kvdb"
+	"github.com/dogechain-lab/dogechain/state"
+	itrie "github.com/dogechain-lab/dogechain/state/immutable-trie"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+
+	testAddrs = []types.Address{addr1, addr2, addr3}
+)
+
+// testSlots are more slots than generated at once
+var testSlots = func() []types.Hash {
+	slots := make([]types.Hash, 0, generateBudget+100)
+	for i := 0; i < cap(slots); i++ {
+		slots = append(slots, types.BytesToHash(big.NewInt(int64(i+1)).Bytes()))
+	}
+
+	return slots
+}()
+
+type testTree struct {
+	*Tree
+
+	trie *itrie.State
+}
+
+func newTestTree(t *testing.T, db kvdb.Database, storage itrie.Storage) *testTree {
+	t.Helper()
+
+	st := itrie.NewState(storage)
+
+	tree, err := New(hclog.NewNullLogger(), db, st, storage)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		tree.Close()
+	})
+
+	return &testTree{Tree: tree, trie: st}
+}
+
+func newTestDB(t *testing.T) kvdb.Database {
+	t.Helper()
+
+	db, err := kvdb.NewMemoryBuilder().Build()
+	assert.NoError(t, err)
+
+	return db
+}
+
+// commit commits the changes of fn on the state of the root through the tree
+func (tt *testTree) commit(t *testing.T, root types.Hash, fn func(txn *state.Txn)) types.Hash {
+	t.Helper()
+
+	snap, err := tt.NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	txn := state.NewTxn(tt, snap)
+	fn(txn)
+
+	_, hash := txn.Commit(true)
+
+	return types.BytesToHash(hash)
+}
+
+// genesis commits the funded accounts, addr2 with the test slots
+func (tt *testTree) genesis(t *testing.T) types.Hash {
+	t.Helper()
+
+	txn := state.NewTxn(tt, tt.NewSnapshot())
+
+	for _, addr := range testAddrs[:2] {
+		txn.SetBalance(addr, big.NewInt(1000))
+	}
+
+	for _, slot := range testSlots {
+		txn.SetState(addr2, slot, slot)
+	}
+
+	_, hash := txn.Commit(true)
+
+	return types.BytesToHash(hash)
+}
+
+func (tt *testTree) waitGenerated(t *testing.T) {
+	t.Helper()
+
+	assert.Eventually(t, func() bool {
+		tt.lock.RLock()
+		defer tt.lock.RUnlock()
+
+		return tt.generated
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// assertState asserts the accounts and the slots read from the tree are the ones of the trie
+func (tt *testTree) assertState(t *testing.T, root types.Hash) {
+	t.Helper()
+
+	snap, err := tt.trie.NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	for _, addr := range testAddrs {
+		hash := types.BytesToHash(crypto.Keccak256(addr.Bytes()))
+
+		data, err := tt.Account(root, hash)
+		assert.NoError(t, err)
+
+		expected, ok := snap.Get(hash.Bytes())
+		if !ok {
+			assert.Nil(t, data, addr.String())
+
+			continue
+		}
+
+		assert.Equal(t, expected, data, addr.String())
+
+		var account state.Account
+		assert.NoError(t, account.UnmarshalRlp(expected))
+
+		storage, err := tt.trie.NewSnapshotAt(account.Root)
+		assert.NoError(t, err)
+
+		for _, slot := range testSlots[:10] {
+			slotHash := types.BytesToHash(crypto.Keccak256(slot.Bytes()))
+
+			data, err := tt.Storage(root, hash, slotHash)
+			assert.NoError(t, err)
+
+			expected, ok := storage.Get(slotHash.Bytes())
+			if !ok {
+				assert.Nil(t, data, addr.String())
+			} else {
+				assert.Equal(t, expected, data, addr.String())
+			}
+		}
+	}
+}
+
+func TestTree_Generate(t *testing.T) {
+	db := newTestDB(t)
+	tree := newTestTree(t, db, itrie.NewMemoryStorage())
+
+	root := tree.genesis(t)
+
+	// not tracked until started
+	_, err := tree.Account(root, types.BytesToHash(crypto.Keccak256(addr1.Bytes())))
+	assert.ErrorIs(t, err, ErrUnknownRoot)
+
+	assert.NoError(t, tree.Start(root))
+	tree.waitGenerated(t)
+
+	tree.assertState(t, root)
+
+	// all the slots are generated, across the steps
+	count := 0
+	assert.NoError(t, db.Iterate(storagePrefix, func(_, _ []byte) bool {
+		count++
+
+		return true
+	}))
+	assert.Equal(t, len(testSlots), count)
+}
+
+func TestTree_Update(t *testing.T) {
+	tree := newTestTree(t, newTestDB(t), itrie.NewMemoryStorage())
+
+	root0 := tree.genesis(t)
+
+	assert.NoError(t, tree.Start(root0))
+	tree.waitGenerated(t)
+
+	root1 := tree.commit(t, root0, func(txn *state.Txn) {
+		txn.AddBalance(addr1, big.NewInt(1))
+		txn.SetBalance(addr3, big.NewInt(3))
+		txn.SetState(addr2, testSlots[0], types.StringToHash("ff"))
+		txn.SetState(addr2, testSlots[1], types.Hash{})
+	})
+
+	// addr2 is recreated with a single slot
+	root2 := tree.commit(t, root1, func(txn *state.Txn) {
+		txn.SetFullStorage(addr2, map[types.Hash]types.Hash{
+			testSlots[2]: types.StringToHash("ee"),
+		})
+	})
+
+	// addr3 is destructed
+	root3 := tree.commit(t, root2, func(txn *state.Txn) {
+		txn.Suicide(addr3)
+	})
+
+	assert.Len(t, tree.layers, 3)
+
+	for _, root := range []types.Hash{root0, root1, root2, root3} {
+		tree.assertState(t, root)
+	}
+
+	// the state of the root is persisted in the disk layer
+	assert.NoError(t, tree.Flatten(root2))
+	assert.Equal(t, root2, tree.root)
+	assert.Len(t, tree.layers, 1)
+
+	tree.assertState(t, root2)
+	tree.assertState(t, root3)
+
+	_, err := tree.Account(root1, types.Hash{})
+	assert.ErrorIs(t, err, ErrUnknownRoot)
+}
+
+func TestTree_Isolated(t *testing.T) {
+	tree := newTestTree(t, newTestDB(t), itrie.NewMemoryStorage())
+
+	root0 := tree.genesis(t)
+
+	assert.NoError(t, tree.Start(root0))
+	tree.waitGenerated(t)
+
+	root1 := tree.commit(t, root0, func(txn *state.Txn) {
+		txn.AddBalance(addr1, big.NewInt(1))
+		txn.SetState(addr2, testSlots[0], types.StringToHash("ff"))
+	})
+
+	isolated := tree.Isolated()
+
+	snap, err := isolated.NewSnapshotAt(root1)
+	assert.NoError(t, err)
+
+	// the accounts of the layers are read from the tree
+	_, ok := snap.(*treeSnapshot)
+	assert.True(t, ok)
+
+	txn := state.NewTxn(isolated, snap)
+	assert.Equal(t, big.NewInt(1001), txn.GetBalance(addr1))
+	assert.Equal(t, types.StringToHash("ff"), txn.GetState(addr2, testSlots[0]))
+	assert.Equal(t, testSlots[1], txn.GetState(addr2, testSlots[1]))
+}
+
+func TestTree_Cap(t *testing.T) {
+	tree := newTestTree(t, newTestDB(t), itrie.NewMemoryStorage())
+
+	root := tree.genesis(t)
+
+	assert.NoError(t, tree.Start(root))
+	tree.waitGenerated(t)
+
+	fork := tree.commit(t, root, func(txn *state.Txn) {
+		txn.SetBalance(addr3, big.NewInt(1))
+	})
+
+	for i := 0; i <= maxDiffLayers; i++ {
+		root = tree.commit(t, root, func(txn *state.Txn) {
+			txn.AddBalance(addr1, big.NewInt(1))
+		})
+	}
+
+	// the bottom layer is flattened, and the fork on top of the previous disk layer dropped
+	assert.Len(t, tree.layers, maxDiffLayers)
+	assert.NotContains(t, tree.layers, fork)
+
+	tree.assertState(t, root)
+}
+
+func TestTree_Reset(t *testing.T) {
+	tree := newTestTree(t, newTestDB(t), itrie.NewMemoryStorage())
+
+	root := tree.genesis(t)
+
+	assert.NoError(t, tree.Start(root))
+	tree.waitGenerated(t)
+
+	// a state committed off the tree
+	snap, err := tree.trie.NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	txn := state.NewTxn(tree.trie, snap)
+	txn.SetBalance(addr3, big.NewInt(3))
+
+	_, hash := txn.Commit(true)
+	unknown := types.BytesToHash(hash)
+
+	// the child of the unknown state is generated again
+	root = tree.commit(t, unknown, func(txn *state.Txn) {
+		txn.AddBalance(addr1, big.NewInt(1))
+	})
+
+	assert.Equal(t, root, tree.root)
+	assert.Empty(t, tree.layers)
+
+	tree.waitGenerated(t)
+	tree.assertState(t, root)
+}
+
+func TestTree_Restart(t *testing.T) {
+	db := newTestDB(t)
+	storage := itrie.NewMemoryStorage()
+
+	tree := newTestTree(t, db, storage)
+
+	root0 := tree.genesis(t)
+
+	assert.NoError(t, tree.Start(root0))
+	tree.waitGenerated(t)
+
+	root1 := tree.commit(t, root0, func(txn *state.Txn) {
+		txn.AddBalance(addr1, big.NewInt(1))
+	})
+
+	assert.NoError(t, tree.Flatten(root1))
+
+	// the disk layer of the head is kept
+	tree = newTestTree(t, db, storage)
+	assert.True(t, tree.generated)
+
+	assert.NoError(t, tree.Start(root1))
+	assert.False(t, tree.wipe)
+	tree.assertState(t, root1)
+
+	// and generated again if it is not the state of the head
+	tree = newTestTree(t, db, storage)
+	assert.NoError(t, tree.Start(root0))
+
+	tree.waitGenerated(t)
+	tree.assertState(t, root0)
+}
@@ -18,6 +18,13 @@ type State interface {
 	GetCode(hash types.Hash) ([]byte, bool)
 }
 
+// IsolatedState is implemented by the states opening copies of themselves whose tries
+// share no nodes with theirs. The nodes of a trie are loaded into it while it is read, so
+// a snapshot can not be read concurrently, while the snapshots of each copy can
+type IsolatedState interface {
+	Isolated() State
+}
+
 type Snapshot interface {
 	Get(k []byte) ([]byte, bool)
 	Commit(objs []*Object) (Snapshot, []byte)
@@ -0,0 +1,132 @@
+package calltracer
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/helper/hex"
+	"github.com/dogechain-lab/dogechain/state/runtime"
+	"github.com/dogechain-lab/dogechain/state/runtime/evm"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// CallFrame is a call of the transaction, with the calls it made
+type CallFrame struct {
+	Type    string        `json:"type"`
+	From    types.Address `json:"from"`
+	To      types.Address `json:"to"`
+	Value   string        `json:"value,omitempty"`
+	Gas     uint64        `json:"gas"`
+	GasUsed uint64        `json:"gasUsed"`
+	Input   string        `json:"input"`
+	Output  string        `json:"output,omitempty"`
+	Error   string        `json:"error,omitempty"`
+	Calls   []*CallFrame  `json:"calls,omitempty"`
+
+	parent *CallFrame
+}
+
+// CallTracer is an EVM logger building the tree of the calls of a transaction
+type CallTracer struct {
+	root    *CallFrame
+	current *CallFrame
+}
+
+// NewCallTracer returns a new call tracer
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+// CaptureStart implements the EVMLogger interface, it opens the top call
+func (c *CallTracer) CaptureStart(txn runtime.Txn, from, to types.Address,
+	create bool, input []byte, gas uint64, value *big.Int) {
+	typ := evm.OpCode(evm.CALL)
+	if create {
+		typ = evm.CREATE
+	}
+
+	c.root = newCallFrame(typ, from, to, input, gas, value)
+	c.current = c.root
+}
+
+// CaptureState implements the EVMLogger interface
+func (c *CallTracer) CaptureState(ctx *runtime.ScopeContext, pc uint64, opCode int,
+	gas, cost uint64, rData []byte, depth int, err error) {
+}
+
+// CaptureEnter implements the EVMLogger interface, it opens an inner call
+func (c *CallTracer) CaptureEnter(opCode int, from, to types.Address,
+	input []byte, gas uint64, value *big.Int) {
+	if c.current == nil {
+		return
+	}
+
+	frame := newCallFrame(evm.OpCode(opCode), from, to, input, gas, value)
+	frame.parent = c.current
+
+	c.current.Calls = append(c.current.Calls, frame)
+	c.current = frame
+}
+
+// CaptureExit implements the EVMLogger interface, it closes the inner call
+func (c *CallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if c.current == nil || c.current.parent == nil {
+		return
+	}
+
+	c.current.close(output, gasUsed, err)
+	c.current = c.current.parent
+}
+
+// CaptureFault implements the EVMLogger interface
+func (c *CallTracer) CaptureFault(ctx *runtime.ScopeContext, pc uint64, opCode int,
+	gas, cost uint64, depth int, err error) {
+}
+
+// CaptureEnd implements the EVMLogger interface, it closes the top call
+func (c *CallTracer) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) {
+	if c.root == nil {
+		return
+	}
+
+	c.root.close(output, gasUsed, err)
+}
+
+// Result returns the top call, nil if the transaction made no call
+func (c *CallTracer) Result() *CallFrame {
+	return c.root
+}
+
+func newCallFrame(
+	typ evm.OpCode,
+	from, to types.Address,
+	input []byte,
+	gas uint64,
+	value *big.Int,
+) *CallFrame {
+	frame := &CallFrame{
+		Type:  typ.String(),
+		From:  from,
+		To:    to,
+		Gas:   gas,
+		Input: hex.EncodeToHex(input),
+	}
+
+	if value != nil {
+		frame.Value = hex.EncodeBig(value)
+	}
+
+	return frame
+}
+
+func (f *CallFrame) close(output []byte, gasUsed uint64, err error) {
+	f.GasUsed = gasUsed
+
+	if len(output) > 0 {
+		f.Output = hex.EncodeToHex(output)
+	}
+
+	if err != nil {
+		f.Error = err.Error()
+	}
+}
@@ -0,0 +1,78 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/state/runtime/evm"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallTracer(t *testing.T) {
+	var (
+		sender   = types.StringToAddress("1")
+		contract = types.StringToAddress("2")
+		callee   = types.StringToAddress("3")
+		created  = types.StringToAddress("4")
+
+		errReverted = errors.New("execution reverted")
+	)
+
+	tracer := NewCallTracer()
+	assert.Nil(t, tracer.Result())
+
+	tracer.CaptureStart(nil, sender, contract, false, []byte{0x01}, 100000, big.NewInt(1))
+
+	tracer.CaptureEnter(int(evm.STATICCALL), contract, callee, []byte{0x02}, 50000, nil)
+	tracer.CaptureExit([]byte{0x03}, 1000, nil)
+
+	tracer.CaptureEnter(int(evm.CREATE2), contract, created, []byte{0x04}, 40000, big.NewInt(0))
+	tracer.CaptureEnter(int(evm.CALL), created, callee, nil, 20000, big.NewInt(0))
+	tracer.CaptureExit(nil, 20000, errReverted)
+	tracer.CaptureExit(nil, 30000, nil)
+
+	tracer.CaptureEnd([]byte{0x05}, 60000, 0, nil)
+
+	root := tracer.Result()
+	assert.Equal(t, "CALL", root.Type)
+	assert.Equal(t, sender, root.From)
+	assert.Equal(t, contract, root.To)
+	assert.Equal(t, "0x1", root.Value)
+	assert.Equal(t, "0x01", root.Input)
+	assert.Equal(t, "0x05", root.Output)
+	assert.Equal(t, uint64(60000), root.GasUsed)
+	assert.Len(t, root.Calls, 2)
+
+	static := root.Calls[0]
+	assert.Equal(t, "STATICCALL", static.Type)
+	assert.Equal(t, callee, static.To)
+	assert.Empty(t, static.Value)
+	assert.Equal(t, "0x03", static.Output)
+	assert.Equal(t, uint64(1000), static.GasUsed)
+	assert.Empty(t, static.Calls)
+
+	create := root.Calls[1]
+	assert.Equal(t, "CREATE2", create.Type)
+	assert.Equal(t, created, create.To)
+	assert.Equal(t, uint64(30000), create.GasUsed)
+	assert.Len(t, create.Calls, 1)
+
+	inner := create.Calls[0]
+	assert.Equal(t, created, inner.From)
+	assert.Equal(t, errReverted.Error(), inner.Error)
+	assert.Equal(t, uint64(20000), inner.GasUsed)
+}
+
+func TestCallTracer_Create(t *testing.T) {
+	tracer := NewCallTracer()
+
+	tracer.CaptureStart(nil, types.StringToAddress("1"), types.StringToAddress("2"), true, nil, 100000, nil)
+	tracer.CaptureEnd(nil, 50000, 0, errors.New("out of gas"))
+
+	root := tracer.Result()
+	assert.Equal(t, "CREATE", root.Type)
+	assert.Empty(t, root.Value)
+	assert.Equal(t, "out of gas", root.Error)
+}
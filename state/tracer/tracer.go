@@ -3,6 +3,8 @@ package tracer
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
+	"time"
 
 	"github.com/dogechain-lab/dogechain/state/runtime"
 	"github.com/dogechain-lab/dogechain/types"
@@ -54,3 +56,51 @@ func New(code string, ctx *Context) (Tracer, error) {
 
 	return nil, errors.New("tracer not found")
 }
+
+// multiLogger dispatches the EVM events to several loggers, in order
+type multiLogger []runtime.EVMLogger
+
+// NewMultiLogger returns a logger dispatching the EVM events to all the loggers
+func NewMultiLogger(loggers ...runtime.EVMLogger) runtime.EVMLogger {
+	return multiLogger(loggers)
+}
+
+func (m multiLogger) CaptureStart(txn runtime.Txn, from, to types.Address,
+	create bool, input []byte, gas uint64, value *big.Int) {
+	for _, logger := range m {
+		logger.CaptureStart(txn, from, to, create, input, gas, value)
+	}
+}
+
+func (m multiLogger) CaptureState(ctx *runtime.ScopeContext, pc uint64, opCode int,
+	gas, cost uint64, rData []byte, depth int, err error) {
+	for _, logger := range m {
+		logger.CaptureState(ctx, pc, opCode, gas, cost, rData, depth, err)
+	}
+}
+
+func (m multiLogger) CaptureEnter(opCode int, from, to types.Address,
+	input []byte, gas uint64, value *big.Int) {
+	for _, logger := range m {
+		logger.CaptureEnter(opCode, from, to, input, gas, value)
+	}
+}
+
+func (m multiLogger) CaptureExit(output []byte, gasUsed uint64, err error) {
+	for _, logger := range m {
+		logger.CaptureExit(output, gasUsed, err)
+	}
+}
+
+func (m multiLogger) CaptureFault(ctx *runtime.ScopeContext, pc uint64, opCode int,
+	gas, cost uint64, depth int, err error) {
+	for _, logger := range m {
+		logger.CaptureFault(ctx, pc, opCode, gas, cost, depth, err)
+	}
+}
+
+func (m multiLogger) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) {
+	for _, logger := range m {
+		logger.CaptureEnd(output, gasUsed, t, err)
+	}
+}
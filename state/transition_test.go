@@ -4,7 +4,11 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/contracts/bridge"
+	"github.com/dogechain-lab/dogechain/contracts/systemcontracts"
 	"github.com/dogechain-lab/dogechain/state/runtime"
+	"github.com/dogechain-lab/dogechain/state/runtime/evm"
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -69,7 +73,7 @@ func TestSubGasLimitPrice(t *testing.T) {
 				GasPrice: big.NewInt(tt.gasPrice),
 			}
 
-			err := transition.subGasLimitPrice(msg)
+			err := transition.subGasLimitPrice(msg, msg.GasPrice)
 
 			assert.Equal(t, tt.expectedErr, err)
 			if err == nil {
@@ -83,6 +87,103 @@ func TestSubGasLimitPrice(t *testing.T) {
 	}
 }
 
+func TestSubGasLimitPrice_DynamicFee(t *testing.T) {
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {
+			Balance: 1000,
+		},
+	})
+
+	msg := &types.Transaction{
+		Type:      types.DynamicFeeTx,
+		From:      addr1,
+		Gas:       10,
+		GasFeeCap: big.NewInt(90),
+		GasTipCap: big.NewInt(1),
+		Value:     big.NewInt(200),
+	}
+
+	// the gas at the fee cap and the value exceed the balance, though the gas at the price doesn't
+	assert.Equal(t, ErrNotEnoughFundsForGas, transition.subGasLimitPrice(msg, big.NewInt(10)))
+	assert.Equal(t, big.NewInt(1000), transition.GetBalance(addr1))
+
+	msg.Value = big.NewInt(100)
+
+	assert.NoError(t, transition.subGasLimitPrice(msg, big.NewInt(10)))
+	assert.Equal(t, big.NewInt(900), transition.GetBalance(addr1))
+}
+
+func TestPrepareAccessList(t *testing.T) {
+	transition := newTestTransition(nil)
+	transition.config = chain.ForksInTime{Byzantium: true, Istanbul: true, Berlin: true}
+
+	listed, previous := types.StringToAddress("100"), types.StringToAddress("200")
+
+	// the entries of the previous transaction are dropped
+	transition.state.AddAddressToAccessList(previous)
+
+	transition.prepareAccessList(&types.Transaction{
+		From: addr1,
+		To:   &addr2,
+		AccessList: types.AccessList{
+			{Address: listed, StorageKeys: []types.Hash{hash1}},
+		},
+	})
+
+	assert.True(t, transition.AddressInAccessList(addr1))
+	assert.True(t, transition.AddressInAccessList(addr2))
+	assert.True(t, transition.AddressInAccessList(types.StringToAddress("9")))
+	assert.False(t, transition.AddressInAccessList(previous))
+
+	addrOk, slotOk := transition.SlotInAccessList(listed, hash1)
+	assert.True(t, addrOk)
+	assert.True(t, slotOk)
+
+	_, slotOk = transition.SlotInAccessList(listed, hash2)
+	assert.False(t, slotOk)
+}
+
+func TestFeeCheck_AccessList(t *testing.T) {
+	transition := newTestTransition(nil)
+	msg := &types.Transaction{Type: types.AccessListTx, GasPrice: big.NewInt(1)}
+
+	assert.ErrorIs(t, transition.feeCheck(msg), ErrTxTypeNotSupported)
+
+	transition.config = chain.ForksInTime{Berlin: true}
+	assert.NoError(t, transition.feeCheck(msg))
+}
+
+func TestApplyCreate_ChainParams(t *testing.T) {
+	// PUSH2 100 PUSH1 0 RETURN, deploying 100 zero bytes
+	initCode := []byte{0x61, 0x00, 0x64, 0x60, 0x00, 0xf3}
+
+	create := func(params func(*chain.Params)) *runtime.ExecutionResult {
+		executor := newTestExecutor()
+		executor.runtimes = []runtime.Runtime{evm.NewEVM()}
+		params(executor.config)
+
+		transition, err := executor.BeginTxn(testParentRoot, &types.Header{Number: 1}, types.ZeroAddress)
+		assert.NoError(t, err)
+
+		return transition.Create2(addr1, initCode, big.NewInt(0), 100000)
+	}
+
+	defaults := create(func(*chain.Params) {})
+	assert.NoError(t, defaults.Err)
+
+	// the code deposit is charged per byte of the code
+	overridden := create(func(p *chain.Params) {
+		p.GasCostOverrides = []*chain.GasCostOverride{{GasCosts: chain.GasCosts{CodeDeposit: 10}}}
+	})
+	assert.NoError(t, overridden.Err)
+	assert.Equal(t, uint64(100*(200-10)), overridden.GasLeft-defaults.GasLeft)
+
+	limited := create(func(p *chain.Params) {
+		p.CodeSizeLimits = []*chain.CodeSizeLimit{{Limit: 50}}
+	})
+	assert.ErrorIs(t, limited.Err, runtime.ErrMaxCodeSizeExceeded)
+}
+
 func TestTransfer(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -149,3 +250,239 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionGasCost(t *testing.T) {
+	to := types.StringToAddress("2")
+
+	tests := []struct {
+		name        string
+		to          *types.Address
+		input       []byte
+		homestead   bool
+		istanbul    bool
+		expectedGas uint64
+	}{
+		{
+			name:        "transfer without data",
+			to:          &to,
+			expectedGas: 21000,
+		},
+		{
+			name:        "zero bytes data",
+			to:          &to,
+			input:       make([]byte, 10),
+			homestead:   true,
+			istanbul:    true,
+			expectedGas: 21000 + 10*4,
+		},
+		{
+			name:        "non zero bytes data before istanbul",
+			to:          &to,
+			input:       []byte{0x1, 0x2, 0x3},
+			homestead:   true,
+			expectedGas: 21000 + 3*68,
+		},
+		{
+			name:        "non zero bytes data after istanbul",
+			to:          &to,
+			input:       []byte{0x1, 0x2, 0x3},
+			homestead:   true,
+			istanbul:    true,
+			expectedGas: 21000 + 3*16,
+		},
+		{
+			name:        "mixed data after istanbul",
+			to:          &to,
+			input:       []byte{0x0, 0x1, 0x0, 0x2, 0x0},
+			homestead:   true,
+			istanbul:    true,
+			expectedGas: 21000 + 2*16 + 3*4,
+		},
+		{
+			name:        "contract creation before homestead",
+			input:       []byte{0x1},
+			expectedGas: 21000 + 68,
+		},
+		{
+			name:        "contract creation after homestead",
+			input:       []byte{0x1},
+			homestead:   true,
+			expectedGas: 53000 + 68,
+		},
+		{
+			name:        "contract creation after istanbul",
+			input:       []byte{0x0, 0x1},
+			homestead:   true,
+			istanbul:    true,
+			expectedGas: 53000 + 16 + 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &types.Transaction{
+				To:    tt.to,
+				Input: tt.input,
+			}
+
+			gas, err := TransactionGasCost(msg, tt.homestead, tt.istanbul)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedGas, gas)
+
+			// the executor should reject the transaction right below the intrinsic gas
+			transition := newTestTransition(nil)
+			transition.config.Homestead = tt.homestead
+			transition.config.Istanbul = tt.istanbul
+			transition.gasPool = tt.expectedGas
+
+			msg.From = addr1
+			msg.Gas = tt.expectedGas - 1
+			msg.GasPrice = big.NewInt(0)
+			msg.Value = big.NewInt(0)
+
+			_, err = transition.apply(msg)
+
+			assert.ErrorIs(t, err, ErrNotEnoughIntrinsicGas)
+		})
+	}
+}
+
+func TestIsBridgeAuthorized(t *testing.T) {
+	var (
+		bridgeAddr = systemcontracts.AddrBridgeContract
+		signer     = types.StringToAddress("1")
+		stranger   = types.StringToAddress("2")
+		other      = types.StringToAddress("3")
+	)
+
+	tests := []struct {
+		name       string
+		disabled   bool
+		from       types.Address
+		to         types.Address
+		logAddress types.Address
+		topic      types.Hash
+		expected   bool
+	}{
+		{"mint by a signer", false, signer, bridgeAddr, bridgeAddr, bridge.BridgeDepositedEventID, true},
+		{"mint by a stranger", false, stranger, bridgeAddr, bridgeAddr, bridge.BridgeDepositedEventID, false},
+		{"burn by a stranger", false, stranger, bridgeAddr, bridgeAddr, bridge.BridgeBurnedEventID, false},
+		{"mint emitted by another contract", false, signer, bridgeAddr, other, bridge.BridgeDepositedEventID, false},
+		{"withdraw by a stranger", false, stranger, bridgeAddr, bridgeAddr, bridge.BridgeWithdrawnEventID, true},
+		{"withdraw emitted by another contract", false, stranger, bridgeAddr, other, bridge.BridgeWithdrawnEventID, false},
+		{"not a bridge transaction", false, stranger, other, other, bridge.BridgeDepositedEventID, true},
+		{"before the fork", true, stranger, bridgeAddr, bridgeAddr, bridge.BridgeDepositedEventID, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transition := newTestTransition(nil)
+			transition.config.BridgeAllowlist = !tt.disabled
+			transition.state.SetState(bridgeAddr, bridge.SignerSlot(signer), types.BytesToHash([]byte{1}))
+
+			to := tt.to
+			msg := &types.Transaction{
+				From: tt.from,
+				To:   &to,
+			}
+			logs := []*types.Log{
+				{Address: tt.logAddress, Topics: []types.Hash{tt.topic}},
+			}
+
+			assert.Equal(t, tt.expected, transition.isBridgeAuthorized(msg, logs))
+		})
+	}
+}
+
+func TestApply_UnauthorizedBridgeReverted(t *testing.T) {
+	var (
+		bridgeAddr = systemcontracts.AddrBridgeContract
+		signer     = types.StringToAddress("1")
+		stranger   = types.StringToAddress("2")
+	)
+
+	// PUSH1 1 PUSH1 0 SSTORE, then LOG1 the deposit event, STOP
+	code := []byte{0x60, 0x01, 0x60, 0x00, 0x55, 0x7f}
+	code = append(code, bridge.BridgeDepositedEventID.Bytes()...)
+	code = append(code, 0x60, 0x00, 0x60, 0x00, 0xa1, 0x00)
+
+	apply := func(from types.Address) (*Transition, *runtime.ExecutionResult) {
+		executor := newTestExecutor()
+		executor.runtimes = []runtime.Runtime{evm.NewEVM()}
+
+		transition, err := executor.BeginTxn(testParentRoot, &types.Header{Number: 1}, types.ZeroAddress)
+		assert.NoError(t, err)
+
+		transition.config.BridgeAllowlist = true
+		transition.gasPool = 1000000
+		transition.state.SetCode(bridgeAddr, code)
+		transition.state.SetState(bridgeAddr, bridge.SignerSlot(signer), types.BytesToHash([]byte{1}))
+
+		result, err := transition.apply(&types.Transaction{
+			From:     from,
+			To:       &bridgeAddr,
+			Gas:      100000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		})
+		assert.NoError(t, err)
+
+		return transition, result
+	}
+
+	transition, result := apply(signer)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, types.BytesToHash([]byte{1}), transition.GetStorage(bridgeAddr, types.ZeroHash))
+	assert.Len(t, transition.state.Logs(), 1)
+
+	// the writes and the logs of the stranger are reverted, its nonce and gas are charged
+	transition, result = apply(stranger)
+	assert.ErrorIs(t, result.Err, errUnauthorizedBridge)
+	assert.NotZero(t, result.GasUsed)
+	assert.Equal(t, types.ZeroHash, transition.GetStorage(bridgeAddr, types.ZeroHash))
+	assert.Empty(t, transition.state.Logs())
+	assert.Equal(t, uint64(1), transition.GetNonce(stranger))
+}
+
+func TestApplyOverrides(t *testing.T) {
+	transition := newTestTransition(nil)
+	transition.state.SetState(addr1, hash2, hash2)
+
+	nonce := uint64(5)
+
+	assert.NoError(t, transition.ApplyOverrides(Overrides{
+		addr1: {
+			Nonce:   &nonce,
+			Balance: big.NewInt(100),
+			// the previous slots are cleared
+			State: map[types.Hash]types.Hash{
+				hash0: hash1,
+			},
+		},
+		addr2: {
+			Code: []byte{0x1},
+			// the other slots are kept
+			StateDiff: map[types.Hash]types.Hash{
+				hash1: hash2,
+			},
+		},
+	}))
+
+	assert.Equal(t, nonce, transition.state.GetNonce(addr1))
+	assert.Equal(t, big.NewInt(100), transition.state.GetBalance(addr1))
+	assert.Equal(t, hash1, transition.state.GetState(addr1, hash0))
+	assert.Equal(t, types.Hash{}, transition.state.GetState(addr1, hash1))
+	assert.Equal(t, types.Hash{}, transition.state.GetState(addr1, hash2))
+
+	assert.Equal(t, []byte{0x1}, transition.state.GetCode(addr2))
+	assert.Equal(t, hash2, transition.state.GetState(addr2, hash1))
+
+	// the state and its diff cannot be both overridden
+	assert.ErrorIs(t, transition.ApplyOverrides(Overrides{
+		addr1: {
+			State:     map[types.Hash]types.Hash{},
+			StateDiff: map[types.Hash]types.Hash{},
+		},
+	}), ErrOverrideStateAndDiff)
+}
@@ -21,6 +21,9 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// accessListIndex is the prefix of the access list entries (EIP-2929)
+	accessListIndex = types.BytesToHash([]byte{4}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -31,6 +34,10 @@ type Txn struct {
 	txn       *iradix.Txn
 	codeCache *lru.Cache
 	hash      *keccak.Keccak
+
+	// accessed records the accounts and the storage slots read or written,
+	// nil when they are not tracked
+	accessed accessedState
 }
 
 func NewTxn(state State, snapshot Snapshot) *Txn {
@@ -60,6 +67,11 @@ func (txn *Txn) hashit(src []byte) []byte {
 	return txn.hash.Read()
 }
 
+// trackAccesses starts recording the accounts read or written by the transaction
+func (txn *Txn) trackAccesses() {
+	txn.accessed = accessedState{}
+}
+
 // Snapshot takes a snapshot at this point in time
 func (txn *Txn) Snapshot() int {
 	t := txn.txn.CommitOnly()
@@ -93,6 +105,10 @@ func (txn *Txn) GetAccount(addr types.Address) (*Account, bool) {
 }
 
 func (txn *Txn) getStateObject(addr types.Address) (*StateObject, bool) {
+	if txn.accessed != nil {
+		txn.accessed.addAccount(addr)
+	}
+
 	// Try to get state from radix tree which holds transient states during block processing first
 	val, exists := txn.txn.Get(addr.Bytes())
 	if exists {
@@ -303,13 +319,19 @@ func (txn *Txn) SetStorage(
 	if original == value {
 		if original == zeroHash { // reset to original nonexistent slot (2.2.2.1)
 			// Storage was used as memory (allocation and deallocation occurred within the same contract)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(19900)
+			} else if config.Istanbul {
 				txn.AddRefund(19200)
 			} else {
 				txn.AddRefund(19800)
 			}
 		} else { // reset to original existing slot (2.2.2.2)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(2800)
+			} else if config.Istanbul {
 				txn.AddRefund(4200)
 			} else {
 				txn.AddRefund(4800)
@@ -339,6 +361,21 @@ func (txn *Txn) SetState(
 	})
 }
 
+// SetFullStorage replaces the whole storage of the address by the slots, the others reading empty
+func (txn *Txn) SetFullStorage(addr types.Address, storage map[types.Hash]types.Hash) {
+	txn.upsertAccount(addr, true, func(object *StateObject) {
+		object.Account.Trie = txn.state.NewSnapshot()
+		object.Account.Root = emptyStateHash
+		object.Txn = iradix.New().Txn()
+
+		for key, value := range storage {
+			if value != zeroHash {
+				object.Txn.Insert(key.Bytes(), value.Bytes())
+			}
+		}
+	})
+}
+
 // GetState returns the state of the address at a given key
 func (txn *Txn) GetState(addr types.Address, key types.Hash) types.Hash {
 	object, exists := txn.getStateObject(addr)
@@ -346,6 +383,10 @@ func (txn *Txn) GetState(addr types.Address, key types.Hash) types.Hash {
 		return types.Hash{}
 	}
 
+	if txn.accessed != nil {
+		txn.accessed.addSlot(addr, key)
+	}
+
 	// Try to get account state from radix tree first
 	// Because the latest account state should be in in-memory radix tree
 	// if account state update happened in previous transactions of same block
@@ -489,6 +530,17 @@ func (txn *Txn) Logs() []*types.Log {
 	return data.([]*types.Log)
 }
 
+// peekLogs returns the logs emitted so far, left in the state
+func (txn *Txn) peekLogs() []*types.Log {
+	data, exists := txn.txn.Get(logIndex)
+	if !exists {
+		return nil
+	}
+
+	//nolint:forcetypeassert
+	return data.([]*types.Log)
+}
+
 func (txn *Txn) GetRefund() uint64 {
 	data, exists := txn.txn.Get(refundIndex)
 	if !exists {
@@ -499,6 +551,54 @@ func (txn *Txn) GetRefund() uint64 {
 	return data.(uint64)
 }
 
+// Access list (EIP-2929)
+
+// The entries of the access list are kept in the radix tree, under the accessListIndex
+// prefix, so that the warmed addresses and slots are reverted along with the snapshots
+
+func accessListKey(addr types.Address, slot *types.Hash) []byte {
+	key := append(append([]byte{}, accessListIndex...), addr.Bytes()...)
+	if slot != nil {
+		key = append(key, slot.Bytes()...)
+	}
+
+	return key
+}
+
+// AddAddressToAccessList adds the address to the access list
+func (txn *Txn) AddAddressToAccessList(addr types.Address) {
+	txn.txn.Insert(accessListKey(addr, nil), true)
+}
+
+// AddSlotToAccessList adds the address and its storage slot to the access list
+func (txn *Txn) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	txn.AddAddressToAccessList(addr)
+	txn.txn.Insert(accessListKey(addr, &slot), true)
+}
+
+// AddressInAccessList returns whether the address is in the access list
+func (txn *Txn) AddressInAccessList(addr types.Address) bool {
+	_, ok := txn.txn.Get(accessListKey(addr, nil))
+
+	return ok
+}
+
+// SlotInAccessList returns whether the address and its storage slot are in the access list
+func (txn *Txn) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	if !txn.AddressInAccessList(addr) {
+		return false, false
+	}
+
+	_, ok := txn.txn.Get(accessListKey(addr, &slot))
+
+	return true, ok
+}
+
+// ClearAccessList empties the access list, at the start of every transaction
+func (txn *Txn) ClearAccessList() {
+	txn.txn.DeletePrefix(accessListIndex)
+}
+
 // GetCommittedState returns the state of the address in the trie
 func (txn *Txn) GetCommittedState(addr types.Address, key types.Hash) types.Hash {
 	obj, ok := txn.getStateObject(addr)
@@ -595,9 +695,20 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) {
 
 	// delete refunds
 	txn.txn.Delete(refundIndex)
+
+	// delete the access list
+	txn.ClearAccessList()
 }
 
 func (txn *Txn) Commit(deleteEmptyObjects bool) (Snapshot, []byte) {
+	t, hash, _ := txn.CommitWithObjects(deleteEmptyObjects)
+
+	return t, hash
+}
+
+// CommitWithObjects commits the transaction, and also returns the objects written,
+// which are the state changes of the transaction
+func (txn *Txn) CommitWithObjects(deleteEmptyObjects bool) (Snapshot, []byte, []*Object) {
 	txn.CleanDeleteObjects(deleteEmptyObjects)
 
 	x := txn.txn.Commit()
@@ -646,5 +757,5 @@ func (txn *Txn) Commit(deleteEmptyObjects bool) (Snapshot, []byte) {
 
 	t, hash := txn.snapshot.Commit(objs)
 
-	return t, hash
+	return t, hash, objs
 }
@@ -145,3 +145,34 @@ func hashit(k []byte) []byte {
 
 	return h.Sum(nil)
 }
+
+func TestAccessList(t *testing.T) {
+	txn := newTestTxn(defaultPreState)
+
+	txn.AddAddressToAccessList(addr1)
+	assert.True(t, txn.AddressInAccessList(addr1))
+	assert.False(t, txn.AddressInAccessList(addr2))
+
+	ss := txn.Snapshot()
+
+	txn.AddSlotToAccessList(addr2, hash1)
+
+	addrOk, slotOk := txn.SlotInAccessList(addr2, hash1)
+	assert.True(t, addrOk)
+	assert.True(t, slotOk)
+
+	addrOk, slotOk = txn.SlotInAccessList(addr2, hash2)
+	assert.True(t, addrOk)
+	assert.False(t, slotOk)
+
+	// the warmed entries are reverted with the snapshot
+	txn.RevertToSnapshot(ss)
+
+	addrOk, slotOk = txn.SlotInAccessList(addr2, hash1)
+	assert.False(t, addrOk)
+	assert.False(t, slotOk)
+	assert.True(t, txn.AddressInAccessList(addr1))
+
+	txn.ClearAccessList()
+	assert.False(t, txn.AddressInAccessList(addr1))
+}
@@ -1,6 +1,7 @@
 package txpool
 
 import (
+	"math/big"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -154,8 +155,9 @@ func (m *accountsMap) allTxs(includeEnqueued bool) (
 		account.promoted.lock(false)
 		defer account.promoted.unlock()
 
+		// copy the queues, which keep changing once unlocked
 		if account.promoted.length() != 0 {
-			allPromoted[addr] = account.promoted.Transactions()
+			allPromoted[addr] = append([]*types.Transaction{}, account.promoted.Transactions()...)
 		}
 
 		if includeEnqueued {
@@ -163,7 +165,7 @@ func (m *accountsMap) allTxs(includeEnqueued bool) (
 			defer account.enqueued.unlock()
 
 			if account.enqueued.length() != 0 {
-				allEnqueued[addr] = account.enqueued.Transactions()
+				allEnqueued[addr] = append([]*types.Transaction{}, account.enqueued.Transactions()...)
 			}
 		}
 
@@ -173,14 +175,19 @@ func (m *accountsMap) allTxs(includeEnqueued bool) (
 	return
 }
 
-func (m *accountsMap) pruneStaleEnqueuedTxs(outdateDuration time.Duration) []*types.Transaction {
+// pruneStaleEnqueuedTxs clears the enqueued transactions of the accounts without promotion
+// for the duration, but the exempted ones
+func (m *accountsMap) pruneStaleEnqueuedTxs(
+	outdateDuration time.Duration,
+	exempt func(types.Address) bool,
+) []*types.Transaction {
 	var (
 		pruned = make([]*types.Transaction, 0)
 		// use same time for faster comparison
 		outdateTimeBound = time.Now().Add(-1 * outdateDuration)
 	)
 
-	m.cmap.Range(func(_, value interface{}) bool {
+	m.cmap.Range(func(key, value interface{}) bool {
 		account, ok := value.(*account)
 		if !ok {
 			// It shouldn't be. We just do some prevention work.
@@ -191,6 +198,10 @@ func (m *accountsMap) pruneStaleEnqueuedTxs(outdateDuration time.Duration) []*ty
 			return true
 		}
 
+		if addr, _ := key.(types.Address); exempt(addr) {
+			return true
+		}
+
 		if account.IsOutdated(outdateTimeBound) {
 			// only lock the account when needed
 			account.enqueued.lock(true)
@@ -207,6 +218,128 @@ func (m *accountsMap) pruneStaleEnqueuedTxs(outdateDuration time.Duration) []*ty
 	return pruned
 }
 
+// expireTxs removes the transactions of all accounts received before the bound,
+// but the exempted accounts, see account.expire.
+func (m *accountsMap) expireTxs(bound time.Time, exempt func(types.Address) bool) (
+	expiredPromoted,
+	expiredEnqueued,
+	demoted []*types.Transaction,
+) {
+	m.cmap.Range(func(key, value interface{}) bool {
+		account, ok := value.(*account)
+		if !ok {
+			// It shouldn't be. We just do some prevention work.
+			return false
+		}
+
+		if addr, _ := key.(types.Address); exempt(addr) {
+			return true
+		}
+
+		promoted, enqueued, accountDemoted := account.expire(bound)
+
+		expiredPromoted = append(expiredPromoted, promoted...)
+		expiredEnqueued = append(expiredEnqueued, enqueued...)
+		demoted = append(demoted, accountDemoted...)
+
+		return true
+	})
+
+	return
+}
+
+// accountTxs returns a copy of the promoted and enqueued transactions of the account
+func (m *accountsMap) accountTxs(addr types.Address) []*types.Transaction {
+	account := m.get(addr)
+	if account == nil {
+		return nil
+	}
+
+	account.promoted.lock(false)
+	defer account.promoted.unlock()
+
+	account.enqueued.lock(false)
+	defer account.enqueued.unlock()
+
+	txs := make([]*types.Transaction, 0, account.promoted.length()+account.enqueued.length())
+	txs = append(txs, account.promoted.Transactions()...)
+	txs = append(txs, account.enqueued.Transactions()...)
+
+	return txs
+}
+
+// accountUsage is what an account takes in the pool
+type accountUsage struct {
+	promoted uint64
+	enqueued uint64
+	slots    uint64
+}
+
+// usage returns the usage of the accounts having transactions in the pool
+func (m *accountsMap) usage() map[types.Address]accountUsage {
+	usages := make(map[types.Address]accountUsage)
+
+	m.cmap.Range(func(key, value interface{}) bool {
+		addr, _ := key.(types.Address)
+		account := m.get(addr)
+
+		account.promoted.lock(false)
+		defer account.promoted.unlock()
+
+		account.enqueued.lock(false)
+		defer account.enqueued.unlock()
+
+		if account.promoted.length() == 0 && account.enqueued.length() == 0 {
+			return true
+		}
+
+		usages[addr] = accountUsage{
+			promoted: account.promoted.length(),
+			enqueued: account.enqueued.length(),
+			slots:    account.slots(),
+		}
+
+		return true
+	})
+
+	return usages
+}
+
+// accountNonces is the nonces of the transactions of an account in the pool
+type accountNonces struct {
+	next     uint64   // the nonce of the next transaction to promote
+	promoted []uint64 // ascending
+	enqueued []uint64 // ascending
+}
+
+// nonces returns the nonces of the transactions of the account
+func (a *account) nonces() accountNonces {
+	a.promoted.lock(false)
+	defer a.promoted.unlock()
+
+	a.enqueued.lock(false)
+	defer a.enqueued.unlock()
+
+	return accountNonces{
+		next:     a.getNonce(),
+		promoted: sortedNonces(a.promoted.Transactions()),
+		enqueued: sortedNonces(a.enqueued.Transactions()),
+	}
+}
+
+func sortedNonces(txs []*types.Transaction) []uint64 {
+	nonces := make([]uint64, len(txs))
+	for i, tx := range txs {
+		nonces[i] = tx.Nonce
+	}
+
+	sort.Slice(nonces, func(i, j int) bool {
+		return nonces[i] < nonces[j]
+	})
+
+	return nonces
+}
+
 // poolPendings returns all promoted nonce ascending transactions.
 func (m *accountsMap) poolPendings() map[types.Address][]*types.Transaction {
 	allPromoted := make(map[types.Address][]*types.Transaction)
@@ -318,23 +451,51 @@ func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 	return
 }
 
+// replacement returns the transaction of the same nonce the transaction would replace, if any.
+// It fails if the gas price is not bumped enough to replace it.
+func (a *account) replacement(tx *types.Transaction, priceBump uint64) (*types.Transaction, error) {
+	oldTx := a.enqueued.GetTxByNonce(tx.Nonce)
+	if oldTx == nil {
+		oldTx = a.promoted.GetTxByNonce(tx.Nonce)
+	}
+
+	if oldTx == nil || oldTx.Hash == tx.Hash {
+		return nil, nil
+	}
+
+	if !txPriceReplacable(tx, oldTx, priceBump) {
+		return nil, ErrReplaceUnderpriced
+	}
+
+	return oldTx, nil
+}
+
 // enqueue attempts tp push the transaction onto the enqueued queue.
-func (a *account) enqueue(tx *types.Transaction) (oldTx *types.Transaction, err error) {
+// A promoted transaction of the same nonce is replaced in place,
+// in which case the returned promoted flag is set.
+func (a *account) enqueue(tx *types.Transaction, priceBump uint64) (
+	oldTx *types.Transaction,
+	promoted bool,
+	err error,
+) {
 	// find out the same nonce transaction in all queues
-	replacable, oldTx := a.enqueued.SameNonceTx(tx)
+	replacable, oldTx := a.enqueued.SameNonceTx(tx, priceBump)
 	if !replacable && oldTx == nil {
 		// find it in promoted queue when enqueued queue not found
-		replacable, oldTx = a.promoted.SameNonceTx(tx)
+		replacable, oldTx = a.promoted.SameNonceTx(tx, priceBump)
+		if replacable {
+			return a.replacePromoted(tx, priceBump)
+		}
 	}
 
 	if !replacable {
 		if oldTx != nil {
-			return nil, ErrReplaceUnderpriced
+			return nil, false, ErrReplaceUnderpriced
 		}
 
 		// check nonce
 		if tx.Nonce < a.getNonce() {
-			return nil, ErrNonceTooLow
+			return nil, false, ErrNonceTooLow
 		}
 	}
 
@@ -343,12 +504,26 @@ func (a *account) enqueue(tx *types.Transaction) (oldTx *types.Transaction, err
 	defer a.enqueued.unlock()
 
 	// all checks passed, we could add the transcation now.
-	inserted, oldTx := a.enqueued.Add(tx)
+	inserted, oldTx := a.enqueued.Add(tx, priceBump)
 	if !inserted {
-		return nil, ErrUnderpriced
+		return nil, false, ErrUnderpriced
 	}
 
-	return oldTx, nil
+	return oldTx, false, nil
+}
+
+// replacePromoted replaces the promoted transaction of the same nonce
+func (a *account) replacePromoted(tx *types.Transaction, priceBump uint64) (*types.Transaction, bool, error) {
+	a.promoted.lock(true)
+	defer a.promoted.unlock()
+
+	oldTx := a.promoted.replaceTxByNewTx(tx, priceBump)
+	if oldTx == nil {
+		// the promoted transaction is gone meanwhile, its nonce is used
+		return nil, false, ErrNonceTooLow
+	}
+
+	return oldTx, true, nil
 }
 
 // Promote moves eligible transactions from enqueued to promoted.
@@ -357,7 +532,6 @@ func (a *account) enqueue(tx *types.Transaction) (oldTx *types.Transaction, err
 // and the first one has to have nonce less (or equal) to the account's
 // nextNonce. Lower nonce transaction would be dropped when promoting.
 func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Transaction) {
-	{
 	a.promoted.lock(true)
 	a.enqueued.lock(true)
 
@@ -366,10 +540,13 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 		a.promoted.unlock()
 	}()
 
-	// sanity check
 	currentNonce := a.getNonce()
-	if a.enqueued.length() == 0 || a.enqueued.peek().Nonce > currentNonce {
 
+	// prune the transactions with lower nonce, so that they do not block the promotable ones
+	pruned = a.enqueued.prune(currentNonce)
+
+	// sanity check
+	if a.enqueued.length() == 0 || a.enqueued.peek().Nonce > currentNonce {
 		// nothing to promote
 		return
 	}
@@ -385,12 +562,10 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 			break // no transcation
 		}
 
-
 		// pop from enqueued
 		tx = a.enqueued.pop()
 		// push to promoted
 		a.promoted.push(tx)
-				// update counters
 
 		nextNonce = tx.Nonce + 1
 
@@ -409,6 +584,90 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 
 	return
 }
+
+// slots returns the slots taken by all the transactions of the account.
+//
+// not thread-safe, should be lock held.
+func (a *account) slots() uint64 {
+	return slotsRequired(a.promoted.Transactions()...) + slotsRequired(a.enqueued.Transactions()...)
+}
+
+// enforceLimits evicts the highest nonce enqueued transactions until the account
+// is within the limits of enqueued transactions and slots, and returns them.
+func (a *account) enforceLimits(maxEnqueued, maxSlots uint64) (evicted []*types.Transaction) {
+	a.promoted.lock(false)
+	defer a.promoted.unlock()
+
+	a.enqueued.lock(true)
+	defer a.enqueued.unlock()
+
+	slots := a.slots()
+
+	for a.enqueued.length() > maxEnqueued || (slots > maxSlots && a.enqueued.length() > 0) {
+		tx := a.enqueued.popHighest()
+		slots -= slotsRequired(tx)
+
+		evicted = append(evicted, tx)
+	}
+
+	return evicted
+}
+
+// expire removes the transactions received before the bound.
+//
+// The promoted transactions following an expired one are not executable anymore,
+// they are demoted to the enqueued queue, and the next nonce is rolled back.
+func (a *account) expire(bound time.Time) (
+	expiredPromoted,
+	expiredEnqueued,
+	demoted []*types.Transaction,
+) {
+	a.promoted.lock(true)
+	defer a.promoted.unlock()
+
+	a.enqueued.lock(true)
+	defer a.enqueued.unlock()
+
+	isExpired := func(tx *types.Transaction) bool {
+		return !tx.ReceivedTime.IsZero() && tx.ReceivedTime.Before(bound)
+	}
+
+	expiredEnqueued = a.enqueued.removeWhere(isExpired)
+
+	// the lowest expired promoted nonce
+	var (
+		rollbackNonce uint64
+		found         bool
+	)
+
+	for _, tx := range a.promoted.Transactions() {
+		if isExpired(tx) && (!found || tx.Nonce < rollbackNonce) {
+			rollbackNonce, found = tx.Nonce, true
+		}
+	}
+
+	if !found {
+		return
+	}
+
+	removed := a.promoted.removeWhere(func(tx *types.Transaction) bool {
+		return tx.Nonce >= rollbackNonce
+	})
+
+	for _, tx := range removed {
+		if isExpired(tx) {
+			expiredPromoted = append(expiredPromoted, tx)
+
+			continue
+		}
+
+		a.enqueued.push(tx)
+		demoted = append(demoted, tx)
+	}
+
+	a.setNonce(rollbackNonce)
+
+	return
 }
 
 // updatePromoted updates promoted timestamp
@@ -422,6 +681,20 @@ func (a *account) IsOutdated(outdateTimeBound time.Time) bool {
 	return a.lastPromoted.Before(outdateTimeBound)
 }
 
-func txPriceReplacable(newTx, oldTx *types.Transaction) bool {
-	return newTx.GasPrice.Cmp(oldTx.GasPrice) > 0
+// txPriceReplacable checks whether the new transaction pays enough to replace the old one,
+// that is a fee cap and tip cap both higher by at least priceBump percent
+func txPriceReplacable(newTx, oldTx *types.Transaction, priceBump uint64) bool {
+	return priceBumped(newTx.GetGasFeeCap(), oldTx.GetGasFeeCap(), priceBump) &&
+		priceBumped(newTx.GetGasTipCap(), oldTx.GetGasTipCap(), priceBump)
+}
+
+func priceBumped(newPrice, oldPrice *big.Int, priceBump uint64) bool {
+	if newPrice.Cmp(oldPrice) <= 0 {
+		return false
+	}
+
+	threshold := new(big.Int).Mul(oldPrice, new(big.Int).SetUint64(100+priceBump))
+	threshold.Div(threshold, big.NewInt(100))
+
+	return newPrice.Cmp(threshold) >= 0
 }
@@ -6,4 +6,22 @@ const (
 	// txpool transaction max slots. tx <= 32kB would only take 1 slot. tx > 32kB would take
 	// ceil(tx.size / 32kB) slots.
 	DefaultMaxSlots = 4096
+	// max number of enqueued (gapped) transactions of a single account
+	DefaultMaxAccountEnqueued = 64
+	// how far ahead of the account nonce a transaction nonce may be, disabled if zero
+	DefaultMaxAccountFutureNonce = 0
+	// max slots the transactions of a single account may take
+	DefaultMaxAccountSlots = 512
+	// maximum encoded size of a transaction
+	DefaultMaxTxSize = 128 * 1024 // 128Kb
+	// lifetime of the transactions sitting in the pool
+	DefaultTxLifetimeSeconds = 3 * 3600
+	// minimum gas price bump (percentage) to replace a transaction of the same nonce
+	DefaultPriceBump = 10
+	// file the local transactions are journaled to, relative to the data directory
+	DefaultJournal = "transactions.rlp"
+	// interval of regenerating the local transaction journal
+	DefaultJournalRotateSeconds = 3600
+	// blocks a local pending transaction stays unmined before it is gossiped again
+	DefaultRebroadcastBlocks = 10
 )
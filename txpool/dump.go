@@ -0,0 +1,93 @@
+package txpool
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// MaxPoolChunkSize is the size in bytes the transactions of a chunk of a pool dump do not exceed,
+// unless a single transaction is larger
+const MaxPoolChunkSize = 1024 * 1024
+
+// dumpTxs returns the transactions of the pool, the pending then enqueued ones of every account,
+// by account and nonce, so that they are restored in order
+func (p *TxPool) dumpTxs() []*types.Transaction {
+	promoted, enqueued := p.accounts.allTxs(true)
+
+	addrs := make([]types.Address, 0, len(promoted)+len(enqueued))
+
+	for addr := range promoted {
+		addrs = append(addrs, addr)
+	}
+
+	for addr := range enqueued {
+		if _, ok := promoted[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+
+	var txs []*types.Transaction
+
+	for _, addr := range addrs {
+		txs = append(txs, promoted[addr]...)
+		txs = append(txs, enqueued[addr]...)
+	}
+
+	return txs
+}
+
+// restoreTxs adds the RLP encoded transactions of a pool dump as local transactions.
+// It returns the number of transactions restored and dropped, the undecodable ones
+// being dropped as well
+func (p *TxPool) restoreTxs(raws [][]byte) (restored, dropped int) {
+	for _, raw := range raws {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			dropped++
+
+			continue
+		}
+
+		if _, err := p.submitLocalTx(tx); err != nil {
+			// mined or replaced since it was dumped
+			dropped++
+
+			continue
+		}
+
+		restored++
+	}
+
+	return restored, dropped
+}
+
+// ChunkTransactions groups the encoded transactions in chunks of at most MaxPoolChunkSize bytes, in order
+func ChunkTransactions(raws [][]byte) [][][]byte {
+	var (
+		chunks [][][]byte
+		chunk  [][]byte
+		size   int
+	)
+
+	for _, raw := range raws {
+		if len(chunk) > 0 && size+len(raw) > MaxPoolChunkSize {
+			chunks = append(chunks, chunk)
+			chunk, size = nil, 0
+		}
+
+		chunk = append(chunk, raw)
+		size += len(raw)
+	}
+
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
@@ -0,0 +1,95 @@
+package txpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/txpool/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxPool_DumpRestorePool(t *testing.T) {
+	t.Parallel()
+
+	txs := newJournalTestTxs(t)
+
+	// runs a pool until the transactions are promoted
+	runPool := func(add func(pool *TxPool)) *TxPool {
+		pool, err := newTestPool(defaultMockStore{
+			DefaultHeader: mockHeader,
+			BaseFee:       1,
+		})
+		assert.NoError(t, err)
+		pool.SetSigner(crypto.NewLondonSigner(100))
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+
+		pool.Start()
+		t.Cleanup(pool.Close)
+
+		add(pool)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancelFn()
+
+		assert.Len(t, waitForEvents(ctx, subscription, len(txs)), len(txs))
+
+		return pool
+	}
+
+	drained := runPool(func(pool *TxPool) {
+		for _, tx := range txs {
+			assert.NoError(t, pool.AddTx(tx.Copy()))
+		}
+	})
+
+	// dumped by account and nonce
+	dumped := drained.dumpTxs()
+	assert.Len(t, dumped, len(txs))
+
+	raws := make([][]byte, len(dumped))
+	for i, tx := range dumped {
+		assert.Equal(t, txs[i].Hash, tx.Hash)
+
+		raws[i] = tx.MarshalRLP()
+	}
+
+	restored := runPool(func(pool *TxPool) {
+		resp, err := pool.RestorePool(context.Background(), &proto.PoolChunk{
+			// the undecodable and known transactions are dropped
+			Txns: append(raws, []byte{0x01}, raws[0]),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(len(txs)), resp.Restored)
+		assert.Equal(t, uint64(2), resp.Dropped)
+	})
+
+	for _, tx := range txs {
+		_, ok := restored.GetPendingTx(tx.Hash)
+		assert.True(t, ok)
+	}
+
+	// restored as local transactions
+	assert.True(t, restored.IsLocal(txs[0].From))
+}
+
+func TestChunkTransactions(t *testing.T) {
+	t.Parallel()
+
+	half := make([]byte, MaxPoolChunkSize/2)
+	large := make([]byte, MaxPoolChunkSize+1)
+
+	chunks := ChunkTransactions([][]byte{half, half, half, large, {0x01}})
+
+	// a transaction larger than the chunk size takes a chunk of its own
+	assert.Equal(t, [][][]byte{
+		{half, half},
+		{half},
+		{large},
+		{{0x01}},
+	}, chunks)
+
+	assert.Empty(t, ChunkTransactions(nil))
+}
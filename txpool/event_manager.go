@@ -11,6 +11,35 @@ import (
 	"github.com/hashicorp/go-hclog"
 )
 
+// The reasons of the pool events. They are stable names subscribers can branch on,
+// the regular flow of the transactions through the pool has no reason.
+const (
+	// the transaction replaces a pending one of the same nonce
+	EventReasonReplacement = "REPLACEMENT"
+	// the transaction is replaced by a higher priced one of the same nonce
+	EventReasonPriceBump = "PRICE_BUMP"
+	// the transaction failed the execution with an unrecoverable error
+	EventReasonExecutionFailed = "EXECUTION_FAILED"
+	// the account of the transaction is dropped
+	EventReasonAccountDropped = "ACCOUNT_DROPPED"
+	// the transaction failed the execution with a recoverable error
+	EventReasonExecutionRetry = "EXECUTION_RETRY"
+	// the nonce of the account is corrected
+	EventReasonNonceMismatch = "NONCE_MISMATCH"
+	// a lower nonce transaction of the account left the pool
+	EventReasonNonceGap = "NONCE_GAP"
+	// the nonce of the transaction is already used by the chain
+	EventReasonNonceTooLow = "NONCE_TOO_LOW"
+	// the account exceeds its enqueued transactions or slots limits
+	EventReasonAccountLimit = "ACCOUNT_LIMIT"
+	// the transaction is enqueued for too long
+	EventReasonStale = "STALE"
+	// the transaction sits in the pool longer than its lifetime
+	EventReasonLifetime = "LIFETIME"
+	// the sender of the transaction is denied by the pool address policy
+	EventReasonDenied = "DENIED"
+)
+
 type eventManager struct {
 	subscriptions     map[subscriptionID]*eventSubscription
 	subscriptionsLock sync.RWMutex
@@ -86,7 +115,7 @@ func (em *eventManager) Close() {
 }
 
 // signalEvent is a helper method for alerting listeners of a new TxPool event
-func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types.Hash) {
+func (em *eventManager) signalEvent(eventType proto.EventType, reason string, txHashes ...types.Hash) {
 	if atomic.LoadInt64(&em.numSubscriptions) < 1 {
 		// No reason to lock the subscriptions map
 		// if no subscriptions exist
@@ -101,6 +130,7 @@ func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types
 			subscription.pushEvent(&proto.TxPoolEvent{
 				Type:   eventType,
 				TxHash: txHash.String(),
+				Reason: reason,
 			})
 		}
 	}
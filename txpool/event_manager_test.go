@@ -103,7 +103,7 @@ func TestEventManager_SignalEvent(t *testing.T) {
 
 	// Send the events
 	for _, mockEvent := range mockEvents {
-		em.signalEvent(mockEvent.Type, mockHash)
+		em.signalEvent(mockEvent.Type, "", mockHash)
 	}
 
 	// Make sure all valid events get processed
@@ -184,7 +184,7 @@ func TestEventManager_SignalEventOrder(t *testing.T) {
 
 	// Send the events
 	for _, mockEvent := range mockEvents {
-		em.signalEvent(mockEvent.Type, mockHash)
+		em.signalEvent(mockEvent.Type, "", mockHash)
 	}
 
 	// Make sure all valid events get processed
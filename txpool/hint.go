@@ -0,0 +1,34 @@
+package txpool
+
+import (
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// ExecutionHint is what the pool learnt about a transaction while validating it,
+// so that block building does not have to work it out again
+type ExecutionHint struct {
+	// Gas is the estimated gas the transaction uses. It is the intrinsic gas,
+	// which is the exact gas used by a plain transfer, and a lower bound otherwise.
+	Gas uint64
+	// Accounts are the accounts the transaction is known to touch
+	Accounts []types.Address
+}
+
+// newExecutionHint returns the hint of a validated transaction
+func newExecutionHint(tx *types.Transaction, intrinsicGas uint64) *ExecutionHint {
+	to := crypto.CreateAddress(tx.From, tx.Nonce)
+	if tx.To != nil {
+		to = *tx.To
+	}
+
+	return &ExecutionHint{
+		Gas:      intrinsicGas,
+		Accounts: []types.Address{tx.From, to},
+	}
+}
+
+// ExecutionHint returns the hint cached when the transaction entered the pool
+func (p *TxPool) ExecutionHint(hash types.Hash) (*ExecutionHint, bool) {
+	return p.index.getHint(hash)
+}
@@ -0,0 +1,285 @@
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/dogechain-lab/fastrlp"
+)
+
+// errNoActiveJournal is returned if a transaction is inserted while the journal
+// is not open for writing, such as during the replay on startup
+var errNoActiveJournal = errors.New("no active journal")
+
+// journal is an append-only log of the local transactions, RLP encoded one after
+// another as they are in a block, so that they survive node restarts
+type journal struct {
+	path string
+
+	lock   sync.Mutex
+	writer io.WriteCloser // output of the new transactions, nil if not open
+}
+
+// newJournal creates the journal of the file
+func newJournal(path string) *journal {
+	return &journal{
+		path: path,
+	}
+}
+
+// load parses the journal from disk, and passes every transaction to add.
+// It returns the number of transactions loaded and dropped, a missing journal
+// is not an error.
+func (j *journal) load(add func(tx *types.Transaction) error) (loaded, dropped int, err error) {
+	data, err := os.ReadFile(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	parser := &fastrlp.Parser{}
+
+	for len(data) > 0 {
+		size, err := rlpItemSize(data)
+		if err != nil {
+			// a partially written entry, the node crashed while appending it
+			return loaded, dropped, fmt.Errorf("corrupted journal entry %d, %w", loaded, err)
+		}
+
+		v, err := parser.Parse(data[:size])
+		if err != nil {
+			return loaded, dropped, fmt.Errorf("corrupted journal entry %d, %w", loaded, err)
+		}
+
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLPFrom(parser, v); err != nil {
+			return loaded, dropped, fmt.Errorf("corrupted journal entry %d, %w", loaded, err)
+		}
+
+		tx.ComputeHash()
+
+		loaded++
+
+		if err := add(tx); err != nil {
+			// mined or replaced since it was journaled
+			dropped++
+		}
+
+		data = data[size:]
+	}
+
+	return loaded, dropped, nil
+}
+
+// insert appends the transaction to the journal
+func (j *journal) insert(tx *types.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer == nil {
+		return errNoActiveJournal
+	}
+
+	_, err := j.writer.Write(types.MarshalRLPTo(tx.MarshalRLPWith, nil))
+
+	return err
+}
+
+// rotate regenerates the journal with the transactions of the local accounts,
+// dropping the mined and replaced ones, and opens it for writing
+func (j *journal) rotate(all map[types.Address][]*types.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return err
+		}
+
+		j.writer = nil
+	}
+
+	// write the live transactions into a temporary journal
+	replacement, err := os.OpenFile(j.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	var buf []byte
+
+	for _, txs := range all {
+		for _, tx := range txs {
+			buf = types.MarshalRLPTo(tx.MarshalRLPWith, buf)
+
+			if _, err := replacement.Write(buf); err != nil {
+				replacement.Close()
+
+				return err
+			}
+
+			buf = buf[:0]
+		}
+	}
+
+	if err := replacement.Close(); err != nil {
+		return err
+	}
+
+	// replace the live journal with the newly generated one
+	if err := os.Rename(j.path+".new", j.path); err != nil {
+		return err
+	}
+
+	sink, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	j.writer = sink
+
+	return nil
+}
+
+// close flushes the journal contents to disk and closes the file
+func (j *journal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	var err error
+
+	if j.writer != nil {
+		err = j.writer.Close()
+		j.writer = nil
+	}
+
+	return err
+}
+
+// SplitTransactions splits the transactions RLP encoded one after another,
+// as they are in the journal, into the encoding of every transaction
+func SplitTransactions(data []byte) ([][]byte, error) {
+	var raws [][]byte
+
+	for len(data) > 0 {
+		size, err := rlpItemSize(data)
+		if err != nil {
+			return nil, fmt.Errorf("corrupted transaction %d, %w", len(raws), err)
+		}
+
+		raws = append(raws, data[:size])
+		data = data[size:]
+	}
+
+	return raws, nil
+}
+
+// rlpItemSize returns the encoded size of the first RLP item of the data
+func rlpItemSize(data []byte) (int, error) {
+	var (
+		prefix  = data[0]
+		header  int
+		payload uint64
+	)
+
+	switch {
+	case prefix < 0x80:
+		return 1, nil
+	case prefix < 0xb8:
+		header, payload = 1, uint64(prefix-0x80)
+	case prefix < 0xc0:
+		header = 1 + int(prefix-0xb7)
+	case prefix < 0xf8:
+		header, payload = 1, uint64(prefix-0xc0)
+	default:
+		header = 1 + int(prefix-0xf7)
+	}
+
+	if len(data) < header {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	// long strings and lists encode the size of the payload after the prefix
+	if header > 1 {
+		for _, b := range data[1:header] {
+			payload = payload<<8 | uint64(b)
+		}
+	}
+
+	if payload > uint64(len(data)-header) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	return header + int(payload), nil
+}
+
+// localAccounts is the set of the accounts which submitted transactions
+// to the node, whose transactions are journaled
+type localAccounts struct {
+	lock     sync.RWMutex
+	accounts map[types.Address]struct{}
+}
+
+func newLocalAccounts() *localAccounts {
+	return &localAccounts{
+		accounts: make(map[types.Address]struct{}),
+	}
+}
+
+func (l *localAccounts) add(addr types.Address) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.accounts[addr] = struct{}{}
+}
+
+// contains returns whether the account is local
+func (l *localAccounts) contains(addr types.Address) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	_, ok := l.accounts[addr]
+
+	return ok
+}
+
+// list returns the local accounts
+func (l *localAccounts) list() []types.Address {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	addrs := make([]types.Address, 0, len(l.accounts))
+	for addr := range l.accounts {
+		addrs = append(addrs, addr)
+	}
+
+	return addrs
+}
+
+// retain drops the accounts which no longer have transactions in the pool,
+// and returns the transactions of the remaining ones
+func (l *localAccounts) retain(
+	txsOf func(types.Address) []*types.Transaction,
+) map[types.Address][]*types.Transaction {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	locals := make(map[types.Address][]*types.Transaction, len(l.accounts))
+
+	for addr := range l.accounts {
+		txs := txsOf(addr)
+		if len(txs) == 0 {
+			delete(l.accounts, addr)
+
+			continue
+		}
+
+		locals[addr] = txs
+	}
+
+	return locals
+}
@@ -0,0 +1,270 @@
+package txpool
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/helper/errcode"
+	"github.com/dogechain-lab/dogechain/helper/tests"
+	"github.com/dogechain-lab/dogechain/txpool/proto"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func newJournalTestTxs(t *testing.T) []*types.Transaction {
+	t.Helper()
+
+	signer := crypto.NewLondonSigner(100)
+	key, addr := tests.GenerateKeyAndAddr(t)
+
+	legacy := newTx(addr, 0, 1)
+
+	dynamic := newTx(addr, 1, 1)
+	dynamic.Type = types.DynamicFeeTx
+	dynamic.GasPrice = nil
+	dynamic.GasTipCap = big.NewInt(1)
+	dynamic.GasFeeCap = big.NewInt(int64(defaultPriceLimit))
+
+	txs := make([]*types.Transaction, 0, 2)
+
+	for _, tx := range []*types.Transaction{legacy, dynamic} {
+		signed, err := signer.SignTx(tx, key)
+		assert.NoError(t, err)
+
+		txs = append(txs, signed.ComputeHash())
+	}
+
+	return txs
+}
+
+func loadJournalHashes(t *testing.T, j *journal) ([]types.Hash, error) {
+	t.Helper()
+
+	hashes := []types.Hash{}
+
+	_, _, err := j.load(func(tx *types.Transaction) error {
+		hashes = append(hashes, tx.Hash)
+
+		return nil
+	})
+
+	return hashes, err
+}
+
+func TestJournal_InsertLoad(t *testing.T) {
+	t.Parallel()
+
+	txs := newJournalTestTxs(t)
+	j := newJournal(filepath.Join(t.TempDir(), DefaultJournal))
+
+	// a missing journal is empty
+	hashes, err := loadJournalHashes(t, j)
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
+
+	// not open for writing before the first rotation
+	assert.ErrorIs(t, j.insert(txs[0]), errNoActiveJournal)
+
+	assert.NoError(t, j.rotate(nil))
+
+	for _, tx := range txs {
+		assert.NoError(t, j.insert(tx))
+	}
+
+	assert.NoError(t, j.close())
+
+	hashes, err = loadJournalHashes(t, j)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{txs[0].Hash, txs[1].Hash}, hashes)
+}
+
+func TestJournal_Rotate(t *testing.T) {
+	t.Parallel()
+
+	txs := newJournalTestTxs(t)
+	j := newJournal(filepath.Join(t.TempDir(), DefaultJournal))
+
+	assert.NoError(t, j.rotate(nil))
+
+	for _, tx := range txs {
+		assert.NoError(t, j.insert(tx))
+	}
+
+	// the first transaction is mined
+	assert.NoError(t, j.rotate(map[types.Address][]*types.Transaction{
+		txs[1].From: {txs[1]},
+	}))
+	assert.NoError(t, j.close())
+
+	hashes, err := loadJournalHashes(t, j)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{txs[1].Hash}, hashes)
+}
+
+func TestJournal_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	txs := newJournalTestTxs(t)
+	j := newJournal(filepath.Join(t.TempDir(), DefaultJournal))
+
+	assert.NoError(t, j.rotate(nil))
+	assert.NoError(t, j.insert(txs[0]))
+	assert.NoError(t, j.close())
+
+	// the node crashed while appending the second transaction
+	raw := txs[1].MarshalRLP()
+
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600)
+	assert.NoError(t, err)
+	_, err = f.Write(raw[:len(raw)/2])
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	// the entries before the corrupted one are loaded
+	hashes, err := loadJournalHashes(t, j)
+	assert.Error(t, err)
+	assert.Equal(t, []types.Hash{txs[0].Hash}, hashes)
+}
+
+func TestTxPool_Journal(t *testing.T) {
+	t.Parallel()
+
+	txs := newJournalTestTxs(t)
+	path := filepath.Join(t.TempDir(), DefaultJournal)
+
+	setupPool := func() *TxPool {
+		pool, err := newTestPool(defaultMockStore{
+			DefaultHeader: mockHeader,
+			BaseFee:       1,
+		})
+		assert.NoError(t, err)
+
+		pool.SetSigner(crypto.NewLondonSigner(100))
+		pool.journal = newJournal(path)
+
+		return pool
+	}
+
+	// runs the pool until the transactions are promoted
+	runPool := func(pool *TxPool, add func(pool *TxPool)) {
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+
+		pool.Start()
+		defer pool.Close()
+
+		add(pool)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancelFn()
+
+		assert.Len(t, waitForEvents(ctx, subscription, len(txs)), len(txs))
+	}
+
+	runPool(setupPool(), func(pool *TxPool) {
+		for _, tx := range txs {
+			assert.NoError(t, pool.AddTx(tx.Copy()))
+		}
+	})
+
+	// the local transactions are replayed on restart
+	pool := setupPool()
+
+	runPool(pool, func(*TxPool) {})
+
+	for _, tx := range txs {
+		_, ok := pool.GetPendingTx(tx.Hash)
+		assert.True(t, ok)
+	}
+}
+
+func TestSplitTransactions(t *testing.T) {
+	t.Parallel()
+
+	txs := newJournalTestTxs(t)
+
+	var (
+		data     []byte
+		expected [][]byte
+	)
+
+	for _, tx := range txs {
+		raw := types.MarshalRLPTo(tx.MarshalRLPWith, nil)
+
+		data = append(data, raw...)
+		expected = append(expected, raw)
+	}
+
+	raws, err := SplitTransactions(data)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, raws)
+
+	// every transaction is decoded as added one by one
+	for i, raw := range raws {
+		tx := new(types.Transaction)
+		assert.NoError(t, tx.UnmarshalRLP(raw))
+		tx.ComputeHash()
+		assert.Equal(t, txs[i].Hash, tx.Hash)
+	}
+
+	// truncated
+	_, err = SplitTransactions(data[:len(data)-1])
+	assert.Error(t, err)
+}
+
+func TestTxPool_AddTxns(t *testing.T) {
+	t.Parallel()
+
+	txs := newJournalTestTxs(t)
+
+	pool, err := newTestPool(defaultMockStore{
+		DefaultHeader: mockHeader,
+		BaseFee:       1,
+	})
+	assert.NoError(t, err)
+	pool.SetSigner(crypto.NewLondonSigner(100))
+
+	subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+
+	pool.Start()
+	defer pool.Close()
+
+	newReq := func(raw []byte) *proto.AddTxnReq {
+		return &proto.AddTxnReq{Raw: &anypb.Any{Value: raw}}
+	}
+
+	resp, err := pool.AddTxns(context.Background(), &proto.AddTxnsReq{
+		Txns: []*proto.AddTxnReq{
+			newReq(txs[0].MarshalRLP()),
+			newReq(txs[0].MarshalRLP()),
+			newReq([]byte{0x01}),
+			newReq(txs[1].MarshalRLP()),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Results, 4)
+
+	// a rejected transaction does not prevent the following ones from being added
+	assert.Equal(t, &proto.AddTxnResult{TxHash: txs[0].Hash.String()}, resp.Results[0])
+	assert.Equal(t, ErrAlreadyKnown.Error(), resp.Results[1].Error)
+	assert.Equal(t, errcode.TxPoolAlreadyKnown.String(), resp.Results[1].ErrorReason)
+	assert.NotEmpty(t, resp.Results[2].Error)
+	assert.Empty(t, resp.Results[2].ErrorReason)
+	assert.Equal(t, &proto.AddTxnResult{TxHash: txs[1].Hash.String()}, resp.Results[3])
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFn()
+
+	assert.Len(t, waitForEvents(ctx, subscription, len(txs)), len(txs))
+
+	// too large batch
+	_, err = pool.AddTxns(context.Background(), &proto.AddTxnsReq{
+		Txns: make([]*proto.AddTxnReq, MaxAddTxnsBatch+1),
+	})
+	assert.Error(t, err)
+}
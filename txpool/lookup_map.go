@@ -10,6 +10,16 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+
+	// execution hints of the transactions, dropped along with them
+	hints map[types.Hash]*ExecutionHint
+}
+
+func newLookupMap() lookupMap {
+	return lookupMap{
+		all:   make(map[types.Hash]*types.Transaction),
+		hints: make(map[types.Hash]*ExecutionHint),
+	}
 }
 
 // add inserts the given transaction into the map. Returns false
@@ -34,6 +44,7 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.hints, tx.Hash)
 	}
 }
 
@@ -49,3 +60,24 @@ func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 
 	return tx, true
 }
+
+// setHint attaches the execution hint to the transaction,
+// if it is still in the map. [thread-safe]
+func (m *lookupMap) setHint(hash types.Hash, hint *ExecutionHint) {
+	m.Lock()
+	defer m.Unlock()
+
+	if _, exists := m.all[hash]; exists {
+		m.hints[hash] = hint
+	}
+}
+
+// getHint returns the execution hint of the transaction. [thread-safe]
+func (m *lookupMap) getHint(hash types.Hash) (*ExecutionHint, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	hint, ok := m.hints[hash]
+
+	return hint, ok
+}
@@ -13,6 +13,10 @@ type Metrics struct {
 	PendingTxs metrics.Gauge
 	// Enqueue transactions
 	EnqueueTxs metrics.Gauge
+	// Transactions expired in the pool
+	ExpiredTxs metrics.Counter
+	// Local pending transactions gossiped again
+	RebroadcastTxs metrics.Counter
 }
 
 func (m *Metrics) SetDefaultValue(v float64) {
@@ -41,13 +45,27 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 			Name:      "enqueued_transactions",
 			Help:      "Enqueued transactions in the pool",
 		}, labels).With(labelsWithValues...),
+		ExpiredTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "txpool",
+			Name:      "expired_transactions",
+			Help:      "Transactions expired in the pool",
+		}, labels).With(labelsWithValues...),
+		RebroadcastTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "txpool",
+			Name:      "rebroadcast_transactions",
+			Help:      "Local pending transactions gossiped again",
+		}, labels).With(labelsWithValues...),
 	}
 }
 
 // NilMetrics will return the non operational txpool metrics
 func NilMetrics() *Metrics {
 	return &Metrics{
-		PendingTxs: discard.NewGauge(),
-		EnqueueTxs: discard.NewGauge(),
+		PendingTxs:     discard.NewGauge(),
+		EnqueueTxs:     discard.NewGauge(),
+		ExpiredTxs:     discard.NewCounter(),
+		RebroadcastTxs: discard.NewCounter(),
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/dogechain-lab/dogechain/contracts/governance"
 	"github.com/dogechain-lab/dogechain/types"
 )
 
@@ -15,11 +16,13 @@ var mockHeader = &types.Header{
 
 type defaultMockStore struct {
 	DefaultHeader *types.Header
+	Governance    *governance.Params
+	BaseFee       uint64
 }
 
 func NewDefaultMockStore(header *types.Header) defaultMockStore {
 	return defaultMockStore{
-		header,
+		DefaultHeader: header,
 	}
 }
 
@@ -41,6 +44,18 @@ func (m defaultMockStore) GetBalance(types.Hash, types.Address) (*big.Int, error
 	return balance, nil
 }
 
+func (m defaultMockStore) GovernanceParams(*types.Header) (*governance.Params, error) {
+	if m.Governance == nil {
+		return &governance.Params{}, nil
+	}
+
+	return m.Governance, nil
+}
+
+func (m defaultMockStore) CalculateBaseFee(*types.Header) uint64 {
+	return m.BaseFee
+}
+
 type faultyMockStore struct {
 }
 
@@ -60,6 +75,14 @@ func (fms faultyMockStore) GetBalance(root types.Hash, addr types.Address) (*big
 	return nil, fmt.Errorf("unable to fetch account state")
 }
 
+func (fms faultyMockStore) GovernanceParams(*types.Header) (*governance.Params, error) {
+	return nil, fmt.Errorf("unable to fetch governance params")
+}
+
+func (fms faultyMockStore) CalculateBaseFee(*types.Header) uint64 {
+	return 0
+}
+
 type mockSigner struct {
 }
 
@@ -3,31 +3,96 @@ package txpool
 import (
 	"context"
 	"fmt"
+	"sort"
 
+	"github.com/dogechain-lab/dogechain/helper/errcode"
 	"github.com/dogechain-lab/dogechain/txpool/proto"
 	"github.com/dogechain-lab/dogechain/types"
+	"google.golang.org/grpc/peer"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
-// Status implements the GRPC status endpoint. Returns the number of transactions in the pool
+// Status implements the GRPC status endpoint. Returns the number of transactions in the pool,
+// and the usage of the accounts, the most slots taking first
 //
 // Length is deprecated. Use pendingLength, enqueuedLength instead.
 func (p *TxPool) Status(ctx context.Context, req *empty.Empty) (*proto.TxnPoolStatusResp, error) {
 	pendingLength := p.accounts.promoted()
 
 	resp := &proto.TxnPoolStatusResp{
-		Length:         pendingLength,
-		PendingLength:  pendingLength,
-		EnqueuedLength: p.accounts.enqueued(),
-		MaxSlots:       p.gauge.max,
-		CurrentSlots:   p.gauge.read(),
+		Length:             pendingLength,
+		PendingLength:      pendingLength,
+		EnqueuedLength:     p.accounts.enqueued(),
+		MaxSlots:           p.gauge.limit(),
+		CurrentSlots:       p.gauge.read(),
+		MaxAccountEnqueued: p.maxAccountEnqueued,
+		MaxAccountSlots:    p.maxAccountSlots,
 	}
 
+	for addr, usage := range p.accounts.usage() {
+		resp.Accounts = append(resp.Accounts, &proto.AccountStatus{
+			Address:        addr.String(),
+			PendingLength:  usage.promoted,
+			EnqueuedLength: usage.enqueued,
+			Slots:          usage.slots,
+		})
+	}
+
+	sort.Slice(resp.Accounts, func(i, j int) bool {
+		if resp.Accounts[i].Slots != resp.Accounts[j].Slots {
+			return resp.Accounts[i].Slots > resp.Accounts[j].Slots
+		}
+
+		return resp.Accounts[i].Address < resp.Accounts[j].Address
+	})
+
 	return resp, nil
 }
 
 // AddTxn adds a local transaction to the pool
 func (p *TxPool) AddTxn(ctx context.Context, raw *proto.AddTxnReq) (*proto.AddTxnResp, error) {
+	resp, err := p.addTxn(raw)
+	if err != nil {
+		return nil, errcode.ToGRPCError(err)
+	}
+
+	return resp, nil
+}
+
+// AddTxns adds a batch of local transactions to the pool, in order, as AddTxn does.
+// A rejected transaction does not prevent the following ones from being added,
+// its rejection is reported in its result.
+func (p *TxPool) AddTxns(ctx context.Context, req *proto.AddTxnsReq) (*proto.AddTxnsResp, error) {
+	if len(req.Txns) > MaxAddTxnsBatch {
+		return nil, fmt.Errorf("batch of %d transactions exceeds the limit of %d", len(req.Txns), MaxAddTxnsBatch)
+	}
+
+	resp := &proto.AddTxnsResp{
+		Results: make([]*proto.AddTxnResult, len(req.Txns)),
+	}
+
+	for i, raw := range req.Txns {
+		result := &proto.AddTxnResult{}
+
+		if added, err := p.addTxn(raw); err != nil {
+			result.Error = err.Error()
+
+			if code := errcode.GetCode(err); code != errcode.Unknown {
+				result.ErrorReason = code.String()
+			}
+		} else {
+			result.TxHash = added.TxHash
+			result.ReplacedTxHash = added.ReplacedTxHash
+		}
+
+		resp.Results[i] = result
+	}
+
+	return resp, nil
+}
+
+// addTxn decodes the transaction, and adds it to the pool
+func (p *TxPool) addTxn(raw *proto.AddTxnReq) (*proto.AddTxnResp, error) {
 	if raw.Raw == nil {
 		return nil, fmt.Errorf("transaction's field raw is empty")
 	}
@@ -46,13 +111,20 @@ func (p *TxPool) AddTxn(ctx context.Context, raw *proto.AddTxnReq) (*proto.AddTx
 		txn.From = from
 	}
 
-	if err := p.AddTx(txn); err != nil {
+	replaced, err := p.submitLocalTx(txn)
+	if err != nil {
 		return nil, err
 	}
 
-	return &proto.AddTxnResp{
+	resp := &proto.AddTxnResp{
 		TxHash: txn.Hash.String(),
-	}, nil
+	}
+
+	if replaced != nil {
+		resp.ReplacedTxHash = replaced.Hash.String()
+	}
+
+	return resp, nil
 }
 
 // Subscribe implements the operator endpoint. It subscribes to new events in the tx pool
@@ -86,3 +158,188 @@ func (p *TxPool) Subscribe(
 		}
 	}
 }
+
+// GetAddressPolicy implements the operator endpoint. It returns the addresses denied and allowed by the pool
+func (p *TxPool) GetAddressPolicy(ctx context.Context, req *empty.Empty) (*proto.AddressPolicyResp, error) {
+	return p.addressPolicyResp(), nil
+}
+
+// UpdateAddressPolicy implements the operator endpoint. It adds and removes addresses of the pool
+// denylist and allowlist, and evicts the transactions of the senders newly denied.
+// The updates are not persisted, the configured lists are loaded again on restart.
+func (p *TxPool) UpdateAddressPolicy(
+	ctx context.Context,
+	req *proto.UpdateAddressPolicyReq,
+) (*proto.AddressPolicyResp, error) {
+	lists := make([][]types.Address, 4)
+
+	for i, raw := range [][]string{req.Deny, req.Undeny, req.Allow, req.Unallow} {
+		addrs, err := parseAddresses(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		lists[i] = addrs
+	}
+
+	p.policy.update(lists[0], lists[1], lists[2], lists[3])
+
+	// the pool should not hold the transactions it would reject
+	p.evictAccounts(EventReasonDenied, lists[0]...)
+
+	p.logger.Info("address policy updated",
+		"deny", len(req.Deny),
+		"undeny", len(req.Undeny),
+		"allow", len(req.Allow),
+		"unallow", len(req.Unallow),
+	)
+
+	return p.addressPolicyResp(), nil
+}
+
+// GetAccountQueue implements the operator endpoint. It returns the nonces of the transactions of the account
+// in the pool, and the missing nonces blocking the promotion of its enqueued transactions, if any
+func (p *TxPool) GetAccountQueue(ctx context.Context, req *proto.AccountQueueReq) (*proto.AccountQueueResp, error) {
+	var addr types.Address
+	if err := addr.UnmarshalText([]byte(req.Address)); err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", req.Address, err)
+	}
+
+	resp := &proto.AccountQueueResp{
+		Address: addr.String(),
+	}
+
+	account := p.accounts.get(addr)
+	if account == nil {
+		resp.NextNonce = p.GetNonce(addr)
+
+		return resp, nil
+	}
+
+	nonces := account.nonces()
+
+	resp.NextNonce = nonces.next
+	resp.PendingNonces = nonces.promoted
+	resp.EnqueuedNonces = nonces.enqueued
+
+	if len(nonces.enqueued) > 0 && nonces.enqueued[0] > nonces.next {
+		resp.Gap = &proto.NonceGap{
+			From: nonces.next,
+			To:   nonces.enqueued[0] - 1,
+		}
+	}
+
+	return resp, nil
+}
+
+// GetPriceLimit implements the operator endpoint. It returns the price limit of the pool and what it derives from
+func (p *TxPool) GetPriceLimit(ctx context.Context, req *empty.Empty) (*proto.PriceLimitResp, error) {
+	return priceLimitResp(p.GetPriceLimitInfo()), nil
+}
+
+// SetPriceLimit implements the operator endpoint. It overrides the configured and scheduled price limits,
+// or clears the override. The change is logged along with the address of the caller, for auditing
+func (p *TxPool) SetPriceLimit(ctx context.Context, req *proto.SetPriceLimitReq) (*proto.PriceLimitResp, error) {
+	var (
+		before = p.GetPriceLimitInfo()
+		after  *PriceLimitInfo
+	)
+
+	if req.Clear {
+		after = p.ClearPriceLimitOverride()
+	} else {
+		after = p.SetPriceLimitOverride(req.PriceLimit)
+	}
+
+	caller := "unknown"
+	if from, ok := peer.FromContext(ctx); ok && from.Addr != nil {
+		caller = from.Addr.String()
+	}
+
+	p.logger.Info("price limit override updated",
+		"caller", caller,
+		"clear", req.Clear,
+		"override", req.PriceLimit,
+		"old", before.PriceLimit,
+		"new", after.PriceLimit,
+	)
+
+	return priceLimitResp(after), nil
+}
+
+// DumpPool implements the operator endpoint. It streams the transactions of the pool, pending and enqueued,
+// RLP encoded by account and nonce, in chunks of at most MaxPoolChunkSize bytes
+func (p *TxPool) DumpPool(req *empty.Empty, stream proto.TxnPoolOperator_DumpPoolServer) error {
+	txs := p.dumpTxs()
+
+	raws := make([][]byte, len(txs))
+	for i, tx := range txs {
+		raws[i] = tx.MarshalRLP()
+	}
+
+	for _, chunk := range ChunkTransactions(raws) {
+		if err := stream.Send(&proto.PoolChunk{Txns: chunk}); err != nil {
+			return err
+		}
+	}
+
+	p.logger.Info("pool dumped", "txs", len(txs))
+
+	return nil
+}
+
+// RestorePool implements the operator endpoint. It adds the transactions of a chunk of a pool dump
+// as local transactions, in order. The transactions rejected, such as the ones mined or replaced
+// since dumped, are dropped
+func (p *TxPool) RestorePool(ctx context.Context, req *proto.PoolChunk) (*proto.RestorePoolResp, error) {
+	restored, dropped := p.restoreTxs(req.Txns)
+
+	p.logger.Info("pool chunk restored", "restored", restored, "dropped", dropped)
+
+	return &proto.RestorePoolResp{
+		Restored: uint64(restored),
+		Dropped:  uint64(dropped),
+	}, nil
+}
+
+func priceLimitResp(info *PriceLimitInfo) *proto.PriceLimitResp {
+	return &proto.PriceLimitResp{
+		PriceLimit:  info.PriceLimit,
+		Configured:  info.Configured,
+		Scheduled:   info.Scheduled,
+		Governed:    info.Governed,
+		Override:    info.Override,
+		HasOverride: info.HasOverride,
+	}
+}
+
+func (p *TxPool) addressPolicyResp() *proto.AddressPolicyResp {
+	denyList, allowList := p.policy.lists()
+
+	resp := &proto.AddressPolicyResp{
+		DenyList:  make([]string, len(denyList)),
+		AllowList: make([]string, len(allowList)),
+	}
+
+	for i, addr := range denyList {
+		resp.DenyList[i] = addr.String()
+	}
+
+	for i, addr := range allowList {
+		resp.AllowList[i] = addr.String()
+	}
+
+	return resp
+}
+
+func parseAddresses(raw []string) ([]types.Address, error) {
+	addrs := make([]types.Address, len(raw))
+
+	for i, s := range raw {
+		if err := addrs[i].UnmarshalText([]byte(s)); err != nil {
+			return nil, fmt.Errorf("invalid address %s: %w", s, err)
+		}
+	}
+
+	return addrs, nil
+}
@@ -0,0 +1,88 @@
+package txpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/contracts/governance"
+	"github.com/dogechain-lab/dogechain/txpool/proto"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestTxPool_GetAccountQueue(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// unknown accounts expect the nonce of the state
+	resp, err := pool.GetAccountQueue(context.Background(), &proto.AccountQueueReq{Address: addr1.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), resp.NextNonce)
+	assert.Empty(t, resp.PendingNonces)
+	assert.Nil(t, resp.Gap)
+
+	// 0 and 1 are promoted, 5 and 4 wait for 2 and 3
+	acc := pool.createAccountOnce(addr1)
+	acc.promoted.push(newTx(addr1, 1, 1))
+	acc.promoted.push(newTx(addr1, 0, 1))
+	acc.enqueued.push(newTx(addr1, 5, 1))
+	acc.enqueued.push(newTx(addr1, 4, 1))
+	acc.setNonce(2)
+
+	resp, err = pool.GetAccountQueue(context.Background(), &proto.AccountQueueReq{Address: addr1.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, addr1.String(), resp.Address)
+	assert.Equal(t, uint64(2), resp.NextNonce)
+	assert.Equal(t, []uint64{0, 1}, resp.PendingNonces)
+	assert.Equal(t, []uint64{4, 5}, resp.EnqueuedNonces)
+	assert.Equal(t, uint64(2), resp.Gap.From)
+	assert.Equal(t, uint64(3), resp.Gap.To)
+
+	_, err = pool.GetAccountQueue(context.Background(), &proto.AccountQueueReq{Address: "0x1"})
+	assert.Error(t, err)
+}
+
+func TestTxPool_SetPriceLimit(t *testing.T) {
+	pool, err := newTestPool(defaultMockStore{
+		DefaultHeader: mockHeader,
+		Governance:    &governance.Params{MinGasPrice: defaultPriceLimit + 5},
+	})
+	assert.NoError(t, err)
+
+	pool.priceLimitSources.schedule = []*chain.PriceLimit{
+		{Block: chain.Fork(10), Limit: defaultPriceLimit + 20},
+	}
+
+	// the governed minimum gas price raises the configured price limit
+	pool.updateGovernanceParams(mockHeader)
+
+	resp, err := pool.GetPriceLimit(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultPriceLimit+5, resp.PriceLimit)
+	assert.Equal(t, uint64(defaultPriceLimit), resp.Configured)
+	assert.Equal(t, uint64(0), resp.Scheduled)
+	assert.False(t, resp.HasOverride)
+
+	// the scheduled price limit applies from the fork height
+	pool.updateGovernanceParams(&types.Header{Number: 9})
+	assert.Equal(t, defaultPriceLimit+20, pool.GetPriceLimitInfo().PriceLimit)
+
+	// the override replaces the scheduled price limit, not the governed one
+	resp, err = pool.SetPriceLimit(context.Background(), &proto.SetPriceLimitReq{PriceLimit: defaultPriceLimit + 100})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultPriceLimit+100, resp.PriceLimit)
+	assert.True(t, resp.HasOverride)
+	assert.Equal(t, defaultPriceLimit+100, pool.priceLimit)
+
+	resp, err = pool.SetPriceLimit(context.Background(), &proto.SetPriceLimitReq{PriceLimit: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultPriceLimit+5, resp.PriceLimit)
+
+	resp, err = pool.SetPriceLimit(context.Background(), &proto.SetPriceLimitReq{Clear: true})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultPriceLimit+20, resp.PriceLimit)
+	assert.False(t, resp.HasOverride)
+}
@@ -0,0 +1,95 @@
+package txpool
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// addressPolicy rejects the transactions from or to the denied addresses, and,
+// when the allowlist is not empty, the transactions of the senders not allowed
+type addressPolicy struct {
+	sync.RWMutex
+	denied  map[types.Address]struct{}
+	allowed map[types.Address]struct{}
+}
+
+func newAddressPolicy(denyList, allowList []types.Address) *addressPolicy {
+	policy := &addressPolicy{
+		denied:  make(map[types.Address]struct{}),
+		allowed: make(map[types.Address]struct{}),
+	}
+
+	policy.update(denyList, nil, allowList, nil)
+
+	return policy
+}
+
+// check returns the reason the transaction is rejected by the policy, if any. [thread-safe]
+func (ap *addressPolicy) check(from types.Address, to *types.Address) error {
+	ap.RLock()
+	defer ap.RUnlock()
+
+	if _, ok := ap.denied[from]; ok {
+		return ErrDeniedAddress
+	}
+
+	if to != nil {
+		if _, ok := ap.denied[*to]; ok {
+			return ErrDeniedAddress
+		}
+	}
+
+	if len(ap.allowed) > 0 {
+		if _, ok := ap.allowed[from]; !ok {
+			return ErrNotAllowedAddress
+		}
+	}
+
+	return nil
+}
+
+// update adds and removes addresses of the lists, additions first. [thread-safe]
+func (ap *addressPolicy) update(deny, undeny, allow, unallow []types.Address) {
+	ap.Lock()
+	defer ap.Unlock()
+
+	for _, addr := range deny {
+		ap.denied[addr] = struct{}{}
+	}
+
+	for _, addr := range allow {
+		ap.allowed[addr] = struct{}{}
+	}
+
+	for _, addr := range undeny {
+		delete(ap.denied, addr)
+	}
+
+	for _, addr := range unallow {
+		delete(ap.allowed, addr)
+	}
+}
+
+// lists returns the sorted addresses of the denylist and the allowlist. [thread-safe]
+func (ap *addressPolicy) lists() (denyList, allowList []types.Address) {
+	ap.RLock()
+	defer ap.RUnlock()
+
+	return sortedAddresses(ap.denied), sortedAddresses(ap.allowed)
+}
+
+func sortedAddresses(set map[types.Address]struct{}) []types.Address {
+	addrs := make([]types.Address, 0, len(set))
+	for addr := range set {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+
+	return addrs
+}
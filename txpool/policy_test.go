@@ -0,0 +1,139 @@
+package txpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dogechain-lab/dogechain/txpool/proto"
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestAddressPolicy_Check(t *testing.T) {
+	var (
+		denied  = types.StringToAddress("1")
+		allowed = types.StringToAddress("2")
+		other   = types.StringToAddress("3")
+	)
+
+	testCases := []struct {
+		name      string
+		denyList  []types.Address
+		allowList []types.Address
+		from      types.Address
+		to        *types.Address
+		err       error
+	}{
+		{"no policy", nil, nil, other, &other, nil},
+		{"denied sender", []types.Address{denied}, nil, denied, &other, ErrDeniedAddress},
+		{"denied recipient", []types.Address{denied}, nil, other, &denied, ErrDeniedAddress},
+		{"contract creation", []types.Address{denied}, nil, other, nil, nil},
+		{"allowed sender", nil, []types.Address{allowed}, allowed, &other, nil},
+		{"not allowed sender", nil, []types.Address{allowed}, other, &allowed, ErrNotAllowedAddress},
+		{"denied allowed sender", []types.Address{allowed}, []types.Address{allowed}, allowed, nil, ErrDeniedAddress},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			policy := newAddressPolicy(testCase.denyList, testCase.allowList)
+
+			assert.ErrorIs(t, policy.check(testCase.from, testCase.to), testCase.err)
+		})
+	}
+}
+
+func TestAddressPolicy_Update(t *testing.T) {
+	addr1, addr2 := types.StringToAddress("1"), types.StringToAddress("2")
+
+	policy := newAddressPolicy([]types.Address{addr2}, nil)
+
+	policy.update([]types.Address{addr1}, nil, []types.Address{addr2, addr1}, nil)
+
+	denyList, allowList := policy.lists()
+	assert.Equal(t, []types.Address{addr1, addr2}, denyList)
+	assert.Equal(t, []types.Address{addr1, addr2}, allowList)
+
+	policy.update(nil, []types.Address{addr1, addr2}, nil, []types.Address{addr2})
+
+	denyList, allowList = policy.lists()
+	assert.Empty(t, denyList)
+	assert.Equal(t, []types.Address{addr1}, allowList)
+}
+
+func TestTxPool_UpdateAddressPolicy(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.Start()
+	defer pool.Close()
+
+	promotedSubscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		assert.NoError(t, pool.addTx(local, newTx(addr1, nonce, 1)))
+	}
+
+	assert.NoError(t, pool.addTx(local, newTx(addr2, 0, 1)))
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancelFn()
+
+	assert.Len(t, waitForEvents(ctx, promotedSubscription, 3), 3)
+	pool.eventManager.cancelSubscription(promotedSubscription.subscriptionID)
+
+	droppedSubscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_DROPPED})
+
+	resp, err := pool.UpdateAddressPolicy(context.Background(), &proto.UpdateAddressPolicyReq{
+		Deny: []string{addr1.String()},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{addr1.String()}, resp.DenyList)
+	assert.Empty(t, resp.AllowList)
+
+	// the transactions of the denied sender are evicted
+	events := waitForEvents(ctx, droppedSubscription, 2)
+	assert.Len(t, events, 2)
+
+	for _, event := range events {
+		assert.Equal(t, EventReasonDenied, event.Reason)
+	}
+
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+	assert.Equal(t, uint64(1), pool.accounts.get(addr2).promoted.length())
+	assert.Equal(t, uint64(1), pool.gauge.read())
+
+	// and the new ones rejected
+	assert.ErrorIs(t, pool.addTx(local, newTx(addr1, 0, 1)), ErrDeniedAddress)
+
+	_, err = pool.UpdateAddressPolicy(context.Background(), &proto.UpdateAddressPolicyReq{
+		Undeny: []string{addr1.String()},
+		Allow:  []string{"not an address"},
+	})
+	assert.Error(t, err)
+
+	resp, err = pool.UpdateAddressPolicy(context.Background(), &proto.UpdateAddressPolicyReq{
+		Undeny: []string{addr1.String()},
+		Allow:  []string{addr1.String()},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.DenyList)
+	assert.Equal(t, []string{addr1.String()}, resp.AllowList)
+
+	promotedSubscription = pool.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+
+	assert.NoError(t, pool.addTx(local, newTx(addr1, 0, 1)))
+	assert.ErrorIs(t, pool.addTx(local, newTx(addr2, 1, 1)), ErrNotAllowedAddress)
+
+	assert.Len(t, waitForEvents(ctx, promotedSubscription, 1), 1)
+	pool.eventManager.cancelSubscription(promotedSubscription.subscriptionID)
+
+	resp, err = pool.GetAddressPolicy(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{addr1.String()}, resp.AllowList)
+}
@@ -0,0 +1,101 @@
+package txpool
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/dogechain-lab/dogechain/chain"
+)
+
+// priceLimitSources holds what the price limit of the pool derives from
+type priceLimitSources struct {
+	sync.Mutex
+
+	// configured is the price limit set on startup
+	configured uint64
+	// schedule is the price limits scheduled at fork heights in the genesis
+	schedule []*chain.PriceLimit
+	// override is the price limit set at runtime by the operator, replacing
+	// the configured and scheduled ones until cleared
+	override *uint64
+	// governed is the minimum gas price enacted by the governance
+	governed uint64
+	// pending is the number of the block the pool collects transactions for
+	pending uint64
+}
+
+// base returns the price limit of the node, before the governed minimum gas price is applied
+func (s *priceLimitSources) base() uint64 {
+	if s.override != nil {
+		return *s.override
+	}
+
+	limit := s.configured
+	if scheduled := chain.PriceLimitAt(s.schedule, s.pending); scheduled > limit {
+		limit = scheduled
+	}
+
+	return limit
+}
+
+// PriceLimitInfo is the price limit of the pool and what it derives from
+type PriceLimitInfo struct {
+	PriceLimit  uint64
+	Configured  uint64
+	Scheduled   uint64
+	Governed    uint64
+	Override    uint64
+	HasOverride bool
+}
+
+// updatePriceLimit applies the update to the sources of the price limit, and recomputes it.
+// The governed minimum gas price cannot be undercut, even by the operator
+func (p *TxPool) updatePriceLimit(update func(s *priceLimitSources)) *PriceLimitInfo {
+	s := &p.priceLimitSources
+
+	s.Lock()
+	defer s.Unlock()
+
+	update(s)
+
+	limit := s.base()
+	if s.governed > limit {
+		limit = s.governed
+	}
+
+	atomic.StoreUint64(&p.priceLimit, limit)
+
+	info := &PriceLimitInfo{
+		PriceLimit: limit,
+		Configured: s.configured,
+		Scheduled:  chain.PriceLimitAt(s.schedule, s.pending),
+		Governed:   s.governed,
+	}
+
+	if s.override != nil {
+		info.Override = *s.override
+		info.HasOverride = true
+	}
+
+	return info
+}
+
+// GetPriceLimitInfo returns the price limit of the pool and what it derives from
+func (p *TxPool) GetPriceLimitInfo() *PriceLimitInfo {
+	return p.updatePriceLimit(func(*priceLimitSources) {})
+}
+
+// SetPriceLimitOverride replaces the configured and scheduled price limits
+// by the given one, until cleared. The override is lost on restart
+func (p *TxPool) SetPriceLimitOverride(limit uint64) *PriceLimitInfo {
+	return p.updatePriceLimit(func(s *priceLimitSources) {
+		s.override = &limit
+	})
+}
+
+// ClearPriceLimitOverride restores the configured and scheduled price limits
+func (p *TxPool) ClearPriceLimitOverride() *PriceLimitInfo {
+	return p.updatePriceLimit(func(s *priceLimitSources) {
+		s.override = nil
+	})
+}
@@ -23,8 +23,24 @@ type TxnPoolOperatorClient interface {
 	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TxnPoolStatusResp, error)
 	// AddTxn adds a local transaction to the pool
 	AddTxn(ctx context.Context, in *AddTxnReq, opts ...grpc.CallOption) (*AddTxnResp, error)
+	// AddTxns adds a batch of local transactions to the pool, in order
+	AddTxns(ctx context.Context, in *AddTxnsReq, opts ...grpc.CallOption) (*AddTxnsResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribeClient, error)
+	// GetAddressPolicy returns the addresses denied and allowed by the pool
+	GetAddressPolicy(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AddressPolicyResp, error)
+	// UpdateAddressPolicy adds and removes addresses of the pool denylist and allowlist
+	UpdateAddressPolicy(ctx context.Context, in *UpdateAddressPolicyReq, opts ...grpc.CallOption) (*AddressPolicyResp, error)
+	// GetAccountQueue returns the nonces of the transactions of an account in the pool
+	GetAccountQueue(ctx context.Context, in *AccountQueueReq, opts ...grpc.CallOption) (*AccountQueueResp, error)
+	// GetPriceLimit returns the price limit of the pool and what it derives from
+	GetPriceLimit(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PriceLimitResp, error)
+	// SetPriceLimit overrides the configured and scheduled price limits of the pool, or clears the override
+	SetPriceLimit(ctx context.Context, in *SetPriceLimitReq, opts ...grpc.CallOption) (*PriceLimitResp, error)
+	// DumpPool streams the transactions of the pool, pending and enqueued, in chunks
+	DumpPool(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (TxnPoolOperator_DumpPoolClient, error)
+	// RestorePool adds the transactions of a chunk of a pool dump as local transactions
+	RestorePool(ctx context.Context, in *PoolChunk, opts ...grpc.CallOption) (*RestorePoolResp, error)
 }
 
 type txnPoolOperatorClient struct {
@@ -53,6 +69,15 @@ func (c *txnPoolOperatorClient) AddTxn(ctx context.Context, in *AddTxnReq, opts
 	return out, nil
 }
 
+func (c *txnPoolOperatorClient) AddTxns(ctx context.Context, in *AddTxnsReq, opts ...grpc.CallOption) (*AddTxnsResp, error) {
+	out := new(AddTxnsResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/AddTxns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *txnPoolOperatorClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribeClient, error) {
 	stream, err := c.cc.NewStream(ctx, &TxnPoolOperator_ServiceDesc.Streams[0], "/v1.TxnPoolOperator/Subscribe", opts...)
 	if err != nil {
@@ -85,6 +110,92 @@ func (x *txnPoolOperatorSubscribeClient) Recv() (*TxPoolEvent, error) {
 	return m, nil
 }
 
+func (c *txnPoolOperatorClient) GetAddressPolicy(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AddressPolicyResp, error) {
+	out := new(AddressPolicyResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/GetAddressPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) UpdateAddressPolicy(ctx context.Context, in *UpdateAddressPolicyReq, opts ...grpc.CallOption) (*AddressPolicyResp, error) {
+	out := new(AddressPolicyResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/UpdateAddressPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) GetAccountQueue(ctx context.Context, in *AccountQueueReq, opts ...grpc.CallOption) (*AccountQueueResp, error) {
+	out := new(AccountQueueResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/GetAccountQueue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) GetPriceLimit(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PriceLimitResp, error) {
+	out := new(PriceLimitResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/GetPriceLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) SetPriceLimit(ctx context.Context, in *SetPriceLimitReq, opts ...grpc.CallOption) (*PriceLimitResp, error) {
+	out := new(PriceLimitResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/SetPriceLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) DumpPool(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (TxnPoolOperator_DumpPoolClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TxnPoolOperator_ServiceDesc.Streams[1], "/v1.TxnPoolOperator/DumpPool", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &txnPoolOperatorDumpPoolClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TxnPoolOperator_DumpPoolClient interface {
+	Recv() (*PoolChunk, error)
+	grpc.ClientStream
+}
+
+type txnPoolOperatorDumpPoolClient struct {
+	grpc.ClientStream
+}
+
+func (x *txnPoolOperatorDumpPoolClient) Recv() (*PoolChunk, error) {
+	m := new(PoolChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *txnPoolOperatorClient) RestorePool(ctx context.Context, in *PoolChunk, opts ...grpc.CallOption) (*RestorePoolResp, error) {
+	out := new(RestorePoolResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/RestorePool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TxnPoolOperatorServer is the server API for TxnPoolOperator service.
 // All implementations must embed UnimplementedTxnPoolOperatorServer
 // for forward compatibility
@@ -93,8 +204,24 @@ type TxnPoolOperatorServer interface {
 	Status(context.Context, *emptypb.Empty) (*TxnPoolStatusResp, error)
 	// AddTxn adds a local transaction to the pool
 	AddTxn(context.Context, *AddTxnReq) (*AddTxnResp, error)
+	// AddTxns adds a batch of local transactions to the pool, in order
+	AddTxns(context.Context, *AddTxnsReq) (*AddTxnsResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error
+	// GetAddressPolicy returns the addresses denied and allowed by the pool
+	GetAddressPolicy(context.Context, *emptypb.Empty) (*AddressPolicyResp, error)
+	// UpdateAddressPolicy adds and removes addresses of the pool denylist and allowlist
+	UpdateAddressPolicy(context.Context, *UpdateAddressPolicyReq) (*AddressPolicyResp, error)
+	// GetAccountQueue returns the nonces of the transactions of an account in the pool
+	GetAccountQueue(context.Context, *AccountQueueReq) (*AccountQueueResp, error)
+	// GetPriceLimit returns the price limit of the pool and what it derives from
+	GetPriceLimit(context.Context, *emptypb.Empty) (*PriceLimitResp, error)
+	// SetPriceLimit overrides the configured and scheduled price limits of the pool, or clears the override
+	SetPriceLimit(context.Context, *SetPriceLimitReq) (*PriceLimitResp, error)
+	// DumpPool streams the transactions of the pool, pending and enqueued, in chunks
+	DumpPool(*emptypb.Empty, TxnPoolOperator_DumpPoolServer) error
+	// RestorePool adds the transactions of a chunk of a pool dump as local transactions
+	RestorePool(context.Context, *PoolChunk) (*RestorePoolResp, error)
 	mustEmbedUnimplementedTxnPoolOperatorServer()
 }
 
@@ -108,9 +235,33 @@ func (UnimplementedTxnPoolOperatorServer) Status(context.Context, *emptypb.Empty
 func (UnimplementedTxnPoolOperatorServer) AddTxn(context.Context, *AddTxnReq) (*AddTxnResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddTxn not implemented")
 }
+func (UnimplementedTxnPoolOperatorServer) AddTxns(context.Context, *AddTxnsReq) (*AddTxnsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTxns not implemented")
+}
 func (UnimplementedTxnPoolOperatorServer) Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedTxnPoolOperatorServer) GetAddressPolicy(context.Context, *emptypb.Empty) (*AddressPolicyResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressPolicy not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) UpdateAddressPolicy(context.Context, *UpdateAddressPolicyReq) (*AddressPolicyResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAddressPolicy not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) GetAccountQueue(context.Context, *AccountQueueReq) (*AccountQueueResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountQueue not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) GetPriceLimit(context.Context, *emptypb.Empty) (*PriceLimitResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceLimit not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) SetPriceLimit(context.Context, *SetPriceLimitReq) (*PriceLimitResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPriceLimit not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) DumpPool(*emptypb.Empty, TxnPoolOperator_DumpPoolServer) error {
+	return status.Errorf(codes.Unimplemented, "method DumpPool not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) RestorePool(context.Context, *PoolChunk) (*RestorePoolResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestorePool not implemented")
+}
 func (UnimplementedTxnPoolOperatorServer) mustEmbedUnimplementedTxnPoolOperatorServer() {}
 
 // UnsafeTxnPoolOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -160,6 +311,24 @@ func _TxnPoolOperator_AddTxn_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TxnPoolOperator_AddTxns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTxnsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).AddTxns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/AddTxns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).AddTxns(ctx, req.(*AddTxnsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TxnPoolOperator_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -181,6 +350,135 @@ func (x *txnPoolOperatorSubscribeServer) Send(m *TxPoolEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TxnPoolOperator_GetAddressPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).GetAddressPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/GetAddressPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).GetAddressPolicy(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_UpdateAddressPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAddressPolicyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).UpdateAddressPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/UpdateAddressPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).UpdateAddressPolicy(ctx, req.(*UpdateAddressPolicyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_GetAccountQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountQueueReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).GetAccountQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/GetAccountQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).GetAccountQueue(ctx, req.(*AccountQueueReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_GetPriceLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).GetPriceLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/GetPriceLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).GetPriceLimit(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_SetPriceLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPriceLimitReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).SetPriceLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/SetPriceLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).SetPriceLimit(ctx, req.(*SetPriceLimitReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_DumpPool_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TxnPoolOperatorServer).DumpPool(m, &txnPoolOperatorDumpPoolServer{stream})
+}
+
+type TxnPoolOperator_DumpPoolServer interface {
+	Send(*PoolChunk) error
+	grpc.ServerStream
+}
+
+type txnPoolOperatorDumpPoolServer struct {
+	grpc.ServerStream
+}
+
+func (x *txnPoolOperatorDumpPoolServer) Send(m *PoolChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TxnPoolOperator_RestorePool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolChunk)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).RestorePool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/RestorePool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).RestorePool(ctx, req.(*PoolChunk))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TxnPoolOperator_ServiceDesc is the grpc.ServiceDesc for TxnPoolOperator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -196,6 +494,34 @@ var TxnPoolOperator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddTxn",
 			Handler:    _TxnPoolOperator_AddTxn_Handler,
 		},
+		{
+			MethodName: "AddTxns",
+			Handler:    _TxnPoolOperator_AddTxns_Handler,
+		},
+		{
+			MethodName: "GetAddressPolicy",
+			Handler:    _TxnPoolOperator_GetAddressPolicy_Handler,
+		},
+		{
+			MethodName: "UpdateAddressPolicy",
+			Handler:    _TxnPoolOperator_UpdateAddressPolicy_Handler,
+		},
+		{
+			MethodName: "GetAccountQueue",
+			Handler:    _TxnPoolOperator_GetAccountQueue_Handler,
+		},
+		{
+			MethodName: "GetPriceLimit",
+			Handler:    _TxnPoolOperator_GetPriceLimit_Handler,
+		},
+		{
+			MethodName: "SetPriceLimit",
+			Handler:    _TxnPoolOperator_SetPriceLimit_Handler,
+		},
+		{
+			MethodName: "RestorePool",
+			Handler:    _TxnPoolOperator_RestorePool_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -203,6 +529,11 @@ var TxnPoolOperator_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TxnPoolOperator_Subscribe_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DumpPool",
+			Handler:       _TxnPoolOperator_DumpPool_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "txpool/proto/operator.proto",
 }
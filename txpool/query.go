@@ -27,7 +27,7 @@ func (p *TxPool) GetNonce(addr types.Address) uint64 {
 // GetCapacity returns the current number of slots
 // occupied in the pool as well as the max limit
 func (p *TxPool) GetCapacity() (uint64, uint64) {
-	return p.gauge.read(), p.gauge.max
+	return p.gauge.read(), p.gauge.limit()
 }
 
 // GetPendingTx returns the transaction by hash in the TxPool (pending txn) [Thread-safe]
@@ -40,6 +40,19 @@ func (p *TxPool) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
 	return tx, true
 }
 
+// GetReplacement returns the hash of the transaction which replaced the given one,
+// if it is among the latest replaced transactions [Thread-safe]
+func (p *TxPool) GetReplacement(txHash types.Hash) (types.Hash, bool) {
+	v, ok := p.replacements.Get(txHash)
+	if !ok {
+		return types.ZeroHash, false
+	}
+
+	replacement, ok := v.(types.Hash)
+
+	return replacement, ok
+}
+
 // GetTxs gets pending and queued transactions
 func (p *TxPool) GetTxs(inclQueued bool) (
 	allPromoted, allEnqueued map[types.Address][]*types.Transaction,
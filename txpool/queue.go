@@ -115,13 +115,13 @@ func (q *accountQueue) clearNonceTxs() {
 // transaction was accepted, and if yes, any previous transaction it replaced.
 //
 // not thread-safe, should be lock held.
-func (q *accountQueue) SameNonceTx(tx *types.Transaction) (replacable bool, old *types.Transaction) {
+func (q *accountQueue) SameNonceTx(tx *types.Transaction, priceBump uint64) (replacable bool, old *types.Transaction) {
 	old = q.GetTxByNonce(tx.Nonce)
 	if old == nil {
 		return false, nil
 	}
 	// If there's an older better transaction, abort
-	if !txPriceReplacable(tx, old) {
+	if !txPriceReplacable(tx, old, priceBump) {
 		return false, old
 	}
 
@@ -133,8 +133,8 @@ func (q *accountQueue) SameNonceTx(tx *types.Transaction) (replacable bool, old
 // it replaced.
 //
 // not thread-safe, should be lock held.
-func (q *accountQueue) Add(tx *types.Transaction) (bool, *types.Transaction) {
-	replacable, old := q.SameNonceTx(tx)
+func (q *accountQueue) Add(tx *types.Transaction, priceBump uint64) (bool, *types.Transaction) {
+	replacable, old := q.SameNonceTx(tx, priceBump)
 	if !replacable && old != nil {
 		// transaction replace underprice
 		return false, old
@@ -144,17 +144,17 @@ func (q *accountQueue) Add(tx *types.Transaction) (bool, *types.Transaction) {
 	if old == nil {
 		q.push(tx)
 	} else {
-		old = q.replaceTxByNewTx(tx)
+		old = q.replaceTxByNewTx(tx, priceBump)
 	}
 
 	return true, old
 }
 
-func (q *accountQueue) replaceTxByNewTx(newTx *types.Transaction) *types.Transaction {
+func (q *accountQueue) replaceTxByNewTx(newTx *types.Transaction, priceBump uint64) *types.Transaction {
 	var dropped *types.Transaction
 
 	for i, tx := range q.queue {
-		if tx.Nonce == newTx.Nonce && txPriceReplacable(newTx, tx) {
+		if tx.Nonce == newTx.Nonce && txPriceReplacable(newTx, tx, priceBump) {
 			dropped = tx
 			q.queue[i] = newTx
 			q.setNonceTx(newTx)
@@ -198,6 +198,56 @@ func (q *accountQueue) pop() *types.Transaction {
 	return transaction
 }
 
+// removeWhere removes the transactions matching the condition from the queue and returns them.
+func (q *accountQueue) removeWhere(match func(tx *types.Transaction) bool) (removed []*types.Transaction) {
+	kept := make(minNonceQueue, 0, len(q.queue))
+
+	for _, tx := range q.queue {
+		if !match(tx) {
+			kept = append(kept, tx)
+
+			continue
+		}
+
+		removed = append(removed, tx)
+		q.deleteNonceTx(tx.Nonce)
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+
+	q.queue = kept
+	heap.Init(&q.queue)
+
+	return removed
+}
+
+// popHighest removes the highest nonce transaction from the queue and returns it.
+func (q *accountQueue) popHighest() *types.Transaction {
+	if q.length() == 0 {
+		return nil
+	}
+
+	highest := 0
+
+	for i, tx := range q.queue {
+		if tx.Nonce > q.queue[highest].Nonce {
+			highest = i
+		}
+	}
+
+	transaction, ok := heap.Remove(&q.queue, highest).(*types.Transaction)
+	if !ok {
+		return nil
+	}
+
+	// remove it from cache
+	q.deleteNonceTx(transaction.Nonce)
+
+	return transaction
+}
+
 // length returns the number of transactions in the queue.
 func (q *accountQueue) length() uint64 {
 	return uint64(q.queue.Len())
@@ -227,7 +277,7 @@ func (q *minNonceQueue) Swap(i, j int) {
 func (q *minNonceQueue) Less(i, j int) bool {
 	// The higher gas price Tx comes first if the nonces are same
 	if (*q)[i].Nonce == (*q)[j].Nonce {
-		return (*q)[i].GasPrice.Cmp((*q)[j].GasPrice) > 0
+		return (*q)[i].GetGasFeeCap().Cmp((*q)[j].GetGasFeeCap()) > 0
 	}
 
 	return (*q)[i].Nonce < (*q)[j].Nonce
@@ -317,7 +367,7 @@ func (q *maxPriceQueue) Swap(i, j int) {
 }
 
 func (q *maxPriceQueue) Less(i, j int) bool {
-	return (*q)[i].GasPrice.Uint64() > (*q)[j].GasPrice.Uint64()
+	return (*q)[i].GetGasFeeCap().Cmp((*q)[j].GetGasFeeCap()) > 0
 }
 
 func (q *maxPriceQueue) Push(x interface{}) {
@@ -0,0 +1,102 @@
+package txpool
+
+import (
+	"sync"
+
+	"github.com/dogechain-lab/dogechain/types"
+)
+
+// maxRebroadcastBackoff bounds the doubling of the rebroadcast interval of a transaction
+const maxRebroadcastBackoff = 64
+
+// rebroadcastEntry is the rebroadcast schedule of a local pending transaction
+type rebroadcastEntry struct {
+	// next is the block from which the transaction is gossiped again
+	next uint64
+	// interval is the blocks to wait for after the next rebroadcast
+	interval uint64
+}
+
+// rebroadcaster schedules the local pending transactions remaining unmined to be gossiped
+// again, as peers restarting lose the ones they were gossiped once. The interval of a
+// transaction doubles with each rebroadcast, so the stuck ones do not flood the network
+type rebroadcaster struct {
+	sync.Mutex
+
+	blocks  uint64
+	entries map[types.Hash]*rebroadcastEntry
+}
+
+func newRebroadcaster(blocks uint64) *rebroadcaster {
+	return &rebroadcaster{
+		blocks:  blocks,
+		entries: make(map[types.Hash]*rebroadcastEntry),
+	}
+}
+
+// due returns the transactions to gossip again at the block, among the pending ones.
+// The transactions no longer pending are forgotten
+func (r *rebroadcaster) due(number uint64, pending []*types.Transaction) []*types.Transaction {
+	r.Lock()
+	defer r.Unlock()
+
+	var (
+		due     = make([]*types.Transaction, 0)
+		entries = make(map[types.Hash]*rebroadcastEntry, len(pending))
+	)
+
+	for _, tx := range pending {
+		entry, ok := r.entries[tx.Hash]
+		if !ok {
+			entry = &rebroadcastEntry{
+				next:     number + r.blocks,
+				interval: r.blocks,
+			}
+		} else if number >= entry.next {
+			due = append(due, tx)
+
+			if entry.interval < r.blocks*maxRebroadcastBackoff {
+				entry.interval *= 2
+			}
+
+			entry.next = number + entry.interval
+		}
+
+		entries[tx.Hash] = entry
+	}
+
+	r.entries = entries
+
+	return due
+}
+
+// rebroadcastLocals gossips again the local pending transactions due at the block
+func (p *TxPool) rebroadcastLocals(number uint64) {
+	if p.rebroadcast == nil || p.topic == nil {
+		return
+	}
+
+	pending := make([]*types.Transaction, 0)
+
+	for _, addr := range p.locals.list() {
+		account := p.accounts.get(addr)
+		if account == nil {
+			continue
+		}
+
+		account.promoted.lock(false)
+		pending = append(pending, account.promoted.Transactions()...)
+		account.promoted.unlock()
+	}
+
+	due := p.rebroadcast.due(number, pending)
+
+	for _, tx := range due {
+		p.publish(tx)
+	}
+
+	if len(due) > 0 {
+		p.metrics.RebroadcastTxs.Add(float64(len(due)))
+		p.logger.Debug("rebroadcast local pending transactions", "block", number, "transactions", len(due))
+	}
+}
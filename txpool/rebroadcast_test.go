@@ -0,0 +1,50 @@
+package txpool
+
+import (
+	"testing"
+
+	"github.com/dogechain-lab/dogechain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebroadcaster_Due(t *testing.T) {
+	var (
+		r   = newRebroadcaster(2)
+		tx1 = newTx(addr1, 0, 1).ComputeHash()
+		tx2 = newTx(addr1, 1, 1).ComputeHash()
+	)
+
+	// the transactions are scheduled when first seen pending
+	assert.Empty(t, r.due(10, []*types.Transaction{tx1}))
+	assert.Empty(t, r.due(11, []*types.Transaction{tx1, tx2}))
+
+	// the interval doubles with each rebroadcast
+	assert.Equal(t, []*types.Transaction{tx1}, r.due(12, []*types.Transaction{tx1, tx2}))
+	assert.Equal(t, []*types.Transaction{tx2}, r.due(13, []*types.Transaction{tx1, tx2}))
+	assert.Empty(t, r.due(15, []*types.Transaction{tx1, tx2}))
+	assert.Equal(t, []*types.Transaction{tx1}, r.due(16, []*types.Transaction{tx1, tx2}))
+	assert.Equal(t, uint64(8), r.entries[tx1.Hash].interval)
+
+	// the transactions no longer pending are forgotten
+	assert.Equal(t, []*types.Transaction{tx2}, r.due(17, []*types.Transaction{tx2}))
+	assert.NotContains(t, r.entries, tx1.Hash)
+
+	// pending again, the transaction starts over
+	assert.Empty(t, r.due(18, []*types.Transaction{tx1, tx2}))
+	assert.Equal(t, uint64(2), r.entries[tx1.Hash].interval)
+}
+
+func TestRebroadcaster_BoundedBackoff(t *testing.T) {
+	var (
+		r  = newRebroadcaster(1)
+		tx = newTx(addr1, 0, 1)
+	)
+
+	r.due(0, []*types.Transaction{tx})
+
+	for number := uint64(1); number < 1000; number++ {
+		r.due(number, []*types.Transaction{tx})
+	}
+
+	assert.Equal(t, uint64(maxRebroadcastBackoff), r.entries[tx.Hash].interval)
+}
@@ -23,6 +23,16 @@ func (g *slotGauge) read() uint64 {
 
 
 
+// limit returns the max limit of the gauge.
+func (g *slotGauge) limit() uint64 {
+	return atomic.LoadUint64(&g.max)
+}
+
+// setLimit sets the max limit of the gauge.
+func (g *slotGauge) setLimit(max uint64) {
+	atomic.StoreUint64(&g.max, max)
+}
+
 // increase increases the height of the gauge by the specified slots amount.
 func (g *slotGauge) increase(slots uint64) {
 	atomic.AddUint64(&g.height, slots)
@@ -35,7 +45,7 @@ func (g *slotGauge) decrease(slots uint64) {
 // highPressure checks if the gauge level
 // is higher than the 0.8*max threshold
 func (g *slotGauge) highPressure() bool {
-	return g.read() > (highPressureMark*g.max)/100
+	return g.read() > (highPressureMark*g.limit())/100
 }
 
 // slotsRequired calculates the number of slots required for given transaction(s).
@@ -4,10 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dogechain-lab/dogechain/blockchain"
 	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/contracts/governance"
+	"github.com/dogechain-lab/dogechain/helper/errcode"
 	"github.com/dogechain-lab/dogechain/network"
 	"github.com/dogechain-lab/dogechain/state"
 	"github.com/dogechain-lab/dogechain/txpool/proto"
@@ -15,36 +19,50 @@ import (
 	"github.com/go-kit/kit/metrics"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
 	"google.golang.org/grpc"
 )
 
 const (
-	txSlotSize  = 32 * 1024  // 32kB
-	txMaxSize   = 128 * 1024 //128Kb
-	topicNameV1 = "txpool/0.1"
+	txSlotSize                 = 32 * 1024 // 32kB
+	topicNameV1                = "txpool/0.1"
 	maxAccountDemotions uint64 = 10
 
 	// maximum allowed number of consecutive blocks that don't have the account's transaction
 	maxAccountSkips = uint64(10)
-	pruningCooldown = 5000 * time.Millisecond	
+	pruningCooldown = 5000 * time.Millisecond
+
+	// number of the latest replaced transactions whose replacement is remembered
+	replacementsCacheSize = 1024
+
+	// interval of sweeping the expired transactions
+	expirySweepInterval = time.Minute
+
+	// MaxAddTxnsBatch is the maximum number of transactions submitted in a batch
+	MaxAddTxnsBatch = 1024
 )
 
 // errors
 var (
-	ErrIntrinsicGas        = errors.New("intrinsic gas too low")
-	ErrBlockLimitExceeded  = errors.New("exceeds block gas limit")
-	ErrNegativeValue       = errors.New("negative value")
-	ErrExtractSignature    = errors.New("cannot extract signature")
-	ErrInvalidSender       = errors.New("invalid sender")
-	ErrTxPoolOverflow      = errors.New("txpool is full")
-	ErrUnderpriced         = errors.New("transaction underpriced")
-	ErrNonceTooLow         = errors.New("nonce too low")
-	ErrInsufficientFunds   = errors.New("insufficient funds for gas * price + value")
-	ErrInvalidAccountState = errors.New("invalid account state")
-	ErrAlreadyKnown        = errors.New("already known")
-	ErrOversizedData       = errors.New("oversized data")
-	ErrReplaceUnderpriced  = errors.New("replacement transaction underpriced")
-	ErrBlackList           = errors.New("address in blacklist")
+	ErrIntrinsicGas        = errcode.New(errcode.TxPoolIntrinsicGas, "intrinsic gas too low")
+	ErrBlockLimitExceeded  = errcode.New(errcode.TxPoolBlockLimitExceeded, "exceeds block gas limit")
+	ErrNegativeValue       = errcode.New(errcode.TxPoolNegativeValue, "negative value")
+	ErrExtractSignature    = errcode.New(errcode.TxPoolExtractSignature, "cannot extract signature")
+	ErrInvalidSender       = errcode.New(errcode.TxPoolInvalidSender, "invalid sender")
+	ErrTxPoolOverflow      = errcode.New(errcode.TxPoolOverflow, "txpool is full")
+	ErrUnderpriced         = errcode.New(errcode.TxPoolUnderpriced, "transaction underpriced")
+	ErrNonceTooLow         = errcode.New(errcode.TxPoolNonceTooLow, "nonce too low")
+	ErrInsufficientFunds   = errcode.New(errcode.TxPoolInsufficientFunds, "insufficient funds for gas * price + value")
+	ErrInvalidAccountState = errcode.New(errcode.TxPoolInvalidAccountState, "invalid account state")
+	ErrAlreadyKnown        = errcode.New(errcode.TxPoolAlreadyKnown, "already known")
+	ErrOversizedData       = errcode.New(errcode.TxPoolOversizedData, "oversized data")
+	ErrReplaceUnderpriced  = errcode.New(errcode.TxPoolReplaceUnderpriced, "replacement transaction underpriced")
+	ErrBlackList           = errcode.New(errcode.TxPoolBlackList, "address in blacklist")
+	ErrTxTypeNotSupported  = errcode.New(errcode.TxPoolTxTypeNotSupported, "transaction type not supported")
+	ErrTipAboveFeeCap      = errcode.New(errcode.TxPoolTipAboveFeeCap, "max priority fee per gas higher than max fee per gas")
+	ErrDeniedAddress       = errcode.New(errcode.TxPoolDeniedAddress, "address in denylist")
+	ErrNotAllowedAddress   = errcode.New(errcode.TxPoolNotAllowedAddress, "sender not in allowlist")
+	ErrNonceTooHigh        = errcode.New(errcode.TxPoolNonceTooHigh, "nonce too high")
 )
 
 // indicates origin of a transaction
@@ -75,6 +93,8 @@ type store interface {
 	GetNonce(root types.Hash, addr types.Address) uint64
 	GetBalance(root types.Hash, addr types.Address) (*big.Int, error)
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
+	GovernanceParams(header *types.Header) (*governance.Params, error)
+	CalculateBaseFee(parent *types.Header) uint64
 }
 
 type signer interface {
@@ -84,10 +104,34 @@ type signer interface {
 type Config struct {
 	PriceLimit            uint64
 	MaxSlots              uint64
+	MaxAccountEnqueued    uint64
+	MaxAccountSlots       uint64
 	Sealing               bool
 	PruneTickSeconds      uint64
 	PromoteOutdateSeconds uint64
 	BlackList             []types.Address
+	PriceBump             uint64
+	// TxLifetimeSeconds is how long a transaction may sit in the pool before it expires
+	TxLifetimeSeconds uint64
+	// Journal is the file the local transactions are journaled to, disabled if empty
+	Journal string
+	// DenyList is the addresses the transactions from or to are rejected
+	DenyList []types.Address
+	// AllowList is the only senders accepted, any sender is accepted if empty
+	AllowList []types.Address
+	// PriceLimits is the price limits scheduled at fork heights, raising the configured one
+	PriceLimits []*chain.PriceLimit
+	// NoLocals disables the exemptions of the local transactions, submitted through the
+	// endpoints of the node, from the price limit and the evictions
+	NoLocals bool
+	// MaxAccountFutureNonce is how far ahead of the account nonce of the state the nonce
+	// of a transaction may be, any nonce is accepted if zero
+	MaxAccountFutureNonce uint64
+	// MaxTxSize is the maximum encoded size in bytes of a transaction
+	MaxTxSize uint64
+	// RebroadcastBlocks is the blocks a local pending transaction stays unmined
+	// before it is gossiped again, disabled if zero
+	RebroadcastBlocks uint64
 }
 
 /* All requests are passed to the main loop
@@ -138,7 +182,7 @@ type promoteRequest struct {
 type TxPool struct {
 	logger hclog.Logger
 	signer signer
-	forks  chain.ForksInTime
+	forks  *chain.Forks
 	store  store
 
 	// map of all accounts registered by the pool
@@ -157,8 +201,25 @@ type TxPool struct {
 	// gauge for measuring pool capacity
 	gauge slotGauge
 
-	// priceLimit is a lower threshold for gas price
-	priceLimit uint64
+	// priceLimit is a lower threshold for gas price,
+	// derived from its sources, see updatePriceLimit
+	priceLimit        uint64
+	priceLimitSources priceLimitSources
+
+	// configured max slots, overridden by the governed parameters
+	configMaxSlots uint64
+
+	// limits of a single account, so that it does not monopolize the pool,
+	// the highest nonce enqueued transactions are evicted once exceeded
+	maxAccountEnqueued uint64
+	maxAccountSlots    uint64
+
+	// maximum encoded size of a transaction
+	maxTxSize uint64
+
+	// how far ahead of the account nonce of the state a transaction nonce may be,
+	// so that unreachable nonces cannot fill the enqueued queues. Disabled if zero
+	maxAccountFutureNonce uint64
 
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
@@ -188,14 +249,42 @@ type TxPool struct {
 	pruneTick              time.Duration
 	promoteOutdateDuration time.Duration
 
+	// ticker for sweeping the transactions sitting in the pool longer than their lifetime
+	expiryTicker *time.Ticker
+	txLifetime   time.Duration
+
 	// some very bad guys whose txs should never be included
 	blacklist map[types.Address]struct{}
+
+	// operator denylist and allowlist, modifiable at runtime
+	policy *addressPolicy
+
+	// minimum gas price bump (percentage) to replace a transaction of the same nonce
+	priceBump uint64
+
+	// the replacement transaction of the latest replaced transactions
+	replacements *lru.Cache
+
+	// journal of the local transactions to survive node restarts, nil if disabled
+	journal       *journal
+	journalTicker *time.Ticker
+	locals        *localAccounts
+
+	// noLocals disables the exemptions of the local accounts
+	noLocals bool
+
+	// rebroadcast schedules the local pending transactions to gossip again, nil if disabled
+	rebroadcast *rebroadcaster
+
+	// promoteHooks are notified of the promoted transactions
+	promoteHooks     []func(txs []*types.Transaction)
+	promoteHooksLock sync.RWMutex
 }
 
 // NewTxPool returns a new pool for processing incoming transactions.
 func NewTxPool(
 	logger hclog.Logger,
-	forks chain.ForksInTime,
+	forks *chain.Forks,
 	store store,
 	grpcServer *grpc.Server,
 	network *network.Server,
@@ -206,6 +295,10 @@ func NewTxPool(
 		pruneTickSeconds      = config.PruneTickSeconds
 		promoteOutdateSeconds = config.PromoteOutdateSeconds
 		maxSlot               = config.MaxSlots
+		maxAccountEnqueued    = config.MaxAccountEnqueued
+		maxAccountSlots       = config.MaxAccountSlots
+		txLifetimeSeconds     = config.TxLifetimeSeconds
+		maxTxSize             = config.MaxTxSize
 	)
 
 	if pruneTickSeconds == 0 {
@@ -220,6 +313,27 @@ func NewTxPool(
 		maxSlot = DefaultMaxSlots
 	}
 
+	if maxAccountEnqueued == 0 {
+		maxAccountEnqueued = DefaultMaxAccountEnqueued
+	}
+
+	if maxAccountSlots == 0 {
+		maxAccountSlots = DefaultMaxAccountSlots
+	}
+
+	if txLifetimeSeconds == 0 {
+		txLifetimeSeconds = DefaultTxLifetimeSeconds
+	}
+
+	if maxTxSize == 0 {
+		maxTxSize = DefaultMaxTxSize
+	}
+
+	replacements, err := lru.New(replacementsCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
 	pool := &TxPool{
 		logger:                 logger.Named("txpool"),
 		forks:                  forks,
@@ -227,21 +341,39 @@ func NewTxPool(
 		metrics:                metrics,
 		accounts:               newAccountsMap(),
 		executables:            newPricedQueue(),
-		index:                  lookupMap{all: make(map[types.Hash]*types.Transaction)},
+		index:                  newLookupMap(),
 		gauge:                  slotGauge{height: 0, max: maxSlot},
-		priceLimit:             config.PriceLimit,
+		configMaxSlots:         maxSlot,
+		maxAccountEnqueued:     maxAccountEnqueued,
+		maxAccountSlots:        maxAccountSlots,
+		maxTxSize:              maxTxSize,
+		maxAccountFutureNonce:  config.MaxAccountFutureNonce,
+		noLocals:               config.NoLocals,
 		pruneTick:              time.Second * time.Duration(pruneTickSeconds),
 		promoteOutdateDuration: time.Second * time.Duration(promoteOutdateSeconds),
+		txLifetime:             time.Second * time.Duration(txLifetimeSeconds),
+		priceBump:              config.PriceBump,
+		replacements:           replacements,
+		locals:                 newLocalAccounts(),
 
 		//	main loop channels
 		enqueueReqCh: make(chan enqueueRequest),
 		promoteReqCh: make(chan promoteRequest),
 		pruneCh:      make(chan struct{}),
-		shutdownCh:   make(chan struct{}),		
+		shutdownCh:   make(chan struct{}),
 	}
 
 	pool.SetSealing(config.Sealing) // sealing flag
 
+	if config.RebroadcastBlocks > 0 {
+		pool.rebroadcast = newRebroadcaster(config.RebroadcastBlocks)
+	}
+
+	pool.updatePriceLimit(func(s *priceLimitSources) {
+		s.configured = config.PriceLimit
+		s.schedule = config.PriceLimits
+	})
+
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
 
@@ -264,7 +396,9 @@ func NewTxPool(
 		proto.RegisterTxnPoolOperatorServer(grpcServer, pool)
 	}
 
-
+	if config.Journal != "" {
+		pool.journal = newJournal(config.Journal)
+	}
 
 	// blacklist
 	pool.blacklist = make(map[types.Address]struct{})
@@ -272,6 +406,8 @@ func NewTxPool(
 		pool.blacklist[addr] = struct{}{}
 	}
 
+	pool.policy = newAddressPolicy(config.DenyList, config.AllowList)
+
 	return pool, nil
 }
 
@@ -289,9 +425,22 @@ func (p *TxPool) getSealing() bool {
 // On each request received, the appropriate handler
 // is invoked in a separate goroutine.
 func (p *TxPool) Start() {
+	// the scheduled price limit is the one of the block following the head
+	if head := p.store.Header(); head != nil {
+		p.updatePriceLimit(func(s *priceLimitSources) {
+			s.pending = head.Number + 1
+		})
+	}
+
 	// set default value of txpool pending transactions gauge
 	p.metrics.PendingTxs.Set(0)
 
+	// prune stale accounts periodically
+	p.pruneAccountTicker = time.NewTicker(p.pruneTick)
+
+	// sweep expired transactions periodically
+	p.expiryTicker = time.NewTicker(expirySweepInterval)
+
 	//	run the handler for high gauge level pruning
 	go func() {
 		for {
@@ -326,14 +475,69 @@ func (p *TxPool) Start() {
 				if ok { // readable
 					go p.pruneStaleAccounts()
 				}
+			case _, ok := <-p.expiryTicker.C:
+				if ok {
+					go p.expireTxs()
+				}
+			}
+		}
+	}()
+
+	if p.journal != nil {
+		p.startJournal()
+	}
+}
+
+// startJournal replays the local transaction journal, and regenerates it
+// periodically. The main loop should be running to enqueue the replayed transactions.
+func (p *TxPool) startJournal() {
+	loaded, dropped, err := p.journal.load(func(tx *types.Transaction) error {
+		_, err := p.submitLocalTx(tx)
+
+		return err
+	})
+	if err != nil {
+		p.logger.Warn("failed to load transaction journal", "err", err)
+	}
+
+	p.logger.Info("loaded local transaction journal", "transactions", loaded, "dropped", dropped)
+
+	p.rotateJournal()
+
+	p.journalTicker = time.NewTicker(DefaultJournalRotateSeconds * time.Second)
+
+	go func() {
+		for {
+			select {
+			case <-p.shutdownCh:
+				return
+			case _, ok := <-p.journalTicker.C:
+				if ok {
+					p.rotateJournal()
+				}
 			}
 		}
 	}()
 }
 
+// rotateJournal regenerates the journal with the transactions
+// of the local accounts still in the pool
+func (p *TxPool) rotateJournal() {
+	locals := p.locals.retain(p.accounts.accountTxs)
+
+	if err := p.journal.rotate(locals); err != nil {
+		p.logger.Warn("failed to rotate transaction journal", "err", err)
+
+		return
+	}
+
+	p.logger.Debug("regenerated local transaction journal", "accounts", len(locals))
+}
+
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
 	p.pruneAccountTicker.Stop()
+	p.expiryTicker.Stop()
 	p.eventManager.Close()
 	// stop
 	p.shutdownCh <- struct{}{}
@@ -346,6 +550,16 @@ func (p *TxPool) Close() {
 	close(p.enqueueReqCh)
 	close(p.promoteReqCh)
 	close(p.shutdownCh)
+
+	if p.journal != nil {
+		if p.journalTicker != nil {
+			p.journalTicker.Stop()
+		}
+
+		if err := p.journal.close(); err != nil {
+			p.logger.Error("failed to close transaction journal", "err", err)
+		}
+	}
 }
 
 // SetSigner sets the signer the pool will use
@@ -357,27 +571,60 @@ func (p *TxPool) SetSigner(s signer) {
 // AddTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
 // and broadcasts it to the network (if enabled).
 func (p *TxPool) AddTx(tx *types.Transaction) error {
-	if err := p.addTx(local, tx); err != nil {
-		p.logger.Error("failed to add tx", "err", err)
+	_, err := p.submitLocalTx(tx)
 
-		return err
+	return err
+}
+
+// submitLocalTx adds a transaction submitted by a user, and journals it
+// if the journal is enabled. It returns the pending transaction of the same nonce it replaces, if any.
+func (p *TxPool) submitLocalTx(tx *types.Transaction) (*types.Transaction, error) {
+	replaced, err := p.addLocalTx(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	// broadcast the transaction only if a topic
-	// subscription is present
-	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
-		}
+	p.locals.add(tx.From)
 
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
+	if p.journal != nil {
+		if err := p.journal.insert(tx); err != nil && !errors.Is(err, errNoActiveJournal) {
+			p.logger.Warn("failed to journal local transaction", "hash", tx.Hash, "err", err)
 		}
 	}
 
-	return nil
+	return replaced, nil
+}
+
+// addLocalTx adds a new transaction sent from json-RPC/gRPC endpoints, and broadcasts it.
+// It returns the pending transaction of the same nonce it replaces, if any.
+func (p *TxPool) addLocalTx(tx *types.Transaction) (*types.Transaction, error) {
+	replaced, err := p.addTxWithReplacement(local, tx)
+	if err != nil {
+		p.logger.Error("failed to add tx", "err", err)
+
+		return nil, err
+	}
+
+	p.publish(tx)
+
+	return replaced, nil
+}
+
+// publish gossips the transaction, only if a topic subscription is present
+func (p *TxPool) publish(tx *types.Transaction) {
+	if p.topic == nil {
+		return
+	}
+
+	msg := &proto.Txn{
+		Raw: &any.Any{
+			Value: tx.MarshalRLP(),
+		},
+	}
+
+	if err := p.topic.Publish(msg); err != nil {
+		p.logger.Error("failed to topic tx", "err", err)
+	}
 }
 
 // Prepare generates all the transactions
@@ -397,13 +644,29 @@ func (p *TxPool) Prepare() {
 	}
 }
 
-// Pop returns the best-price selected
-// transaction ready for execution.
+// PopExecutable pops the best-price selected
+// transaction ready for execution from the executables queue.
+//
+// Popping the executables queue does not remove
+// the actual tx from the pool, the caller should
+// call RemoveExecuted, Demote or Drop on it later.
+func (p *TxPool) PopExecutable() *types.Transaction {
+	return p.executables.pop()
+}
+
+// Pop removes the given transaction, which is
+// the head of its account promoted queue, from the pool.
 func (p *TxPool) Pop(tx *types.Transaction) {
 	// fetch the associated account
 	account := p.accounts.get(tx.From)
 	account.promoted.lock(true)
 	defer account.promoted.unlock()
+
+	// the transaction might have expired meanwhile
+	if head := account.promoted.peek(); head == nil || head.Hash != tx.Hash {
+		return
+	}
+
 	// pop the top most promoted tx
 	account.promoted.pop()
 
@@ -431,6 +694,11 @@ func (p *TxPool) RemoveExecuted(tx *types.Transaction) {
 	account.promoted.lock(true)
 	defer account.promoted.unlock()
 
+	// the transaction might have expired meanwhile
+	if head := account.promoted.peek(); head == nil || head.Hash != tx.Hash {
+		return
+	}
+
 	// pop the top most promoted tx
 	account.promoted.pop()
 
@@ -476,13 +744,13 @@ func (p *TxPool) DemoteAllPromoted(tx *types.Transaction, correctNonce uint64) {
 	p.metrics.PendingTxs.Add(-1 * float64(len(txs)))
 	p.gauge.decrease(slotsRequired(txs...))
 	// signal events
-	p.eventManager.signalEvent(proto.EventType_DEMOTED, toHash(txs...)...)
+	p.eventManager.signalEvent(proto.EventType_DEMOTED, EventReasonNonceMismatch, toHash(txs...)...)
 
 	go func(txs []*types.Transaction) {
 		// retry enqueue, and broadcast
 		for _, tx := range txs {
 			//nolint:errcheck
-			p.AddTx(tx)
+			p.addLocalTx(tx)
 		}
 	}(txs)
 }
@@ -518,20 +786,21 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 	account.setNonce(nextNonce)
 
 	// drop promoted
-	dropped := account.promoted.Clear()
-	clearAccountQueue(dropped)
+	droppedPromoted := account.promoted.Clear()
+	clearAccountQueue(droppedPromoted)
 
 	// update metrics
-	p.metrics.PendingTxs.Add(float64(-1 * len(dropped)))
+	p.metrics.PendingTxs.Add(float64(-1 * len(droppedPromoted)))
 
 	// drop enqueued
-	dropped = account.enqueued.Clear()
-	clearAccountQueue(dropped)
+	droppedEnqueued := account.enqueued.Clear()
+	clearAccountQueue(droppedEnqueued)
 
 	// update metrics
-	p.metrics.EnqueueTxs.Add(float64(-1 * len(dropped)))
+	p.metrics.EnqueueTxs.Add(float64(-1 * len(droppedEnqueued)))
 
-	p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
+	p.eventManager.signalEvent(proto.EventType_DROPPED, EventReasonExecutionFailed, tx.Hash)
+	p.signalAccountDropped(tx, droppedPromoted, droppedEnqueued)
 	p.logger.Debug("dropped account txs",
 		"num", droppedCount,
 		"next_nonce", nextNonce,
@@ -539,6 +808,64 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 	)
 }
 
+// evictAccounts clears the transactions of the accounts, and reverts their next (expected)
+// nonces to the lowest promoted ones
+func (p *TxPool) evictAccounts(reason string, addrs ...types.Address) {
+	for _, addr := range addrs {
+		if account := p.accounts.get(addr); account != nil {
+			p.evictAccount(account, reason)
+		}
+	}
+}
+
+func (p *TxPool) evictAccount(account *account, reason string) {
+	account.promoted.lock(true)
+	account.enqueued.lock(true)
+
+	defer func() {
+		account.enqueued.unlock()
+		account.promoted.unlock()
+	}()
+
+	promoted := account.promoted.Clear()
+	enqueued := account.enqueued.Clear()
+
+	if len(promoted) > 0 {
+		account.setNonce(promoted[0].Nonce)
+	}
+
+	evicted := make([]*types.Transaction, 0, len(promoted)+len(enqueued))
+	evicted = append(evicted, promoted...)
+	evicted = append(evicted, enqueued...)
+
+	if len(evicted) == 0 {
+		return
+	}
+
+	p.index.remove(evicted...)
+	p.gauge.decrease(slotsRequired(evicted...))
+	p.metrics.PendingTxs.Add(-1 * float64(len(promoted)))
+	p.metrics.EnqueueTxs.Add(-1 * float64(len(enqueued)))
+	p.eventManager.signalEvent(proto.EventType_DROPPED, reason, toHash(evicted...)...)
+
+	p.logger.Debug("evicted account txs", "num", len(evicted), "reason", reason)
+}
+
+// signalAccountDropped signals the transactions dropped along with the failed one
+func (p *TxPool) signalAccountDropped(failed *types.Transaction, dropped ...[]*types.Transaction) {
+	hashes := make([]types.Hash, 0)
+
+	for _, txs := range dropped {
+		for _, tx := range txs {
+			if tx.Hash != failed.Hash {
+				hashes = append(hashes, tx.Hash)
+			}
+		}
+	}
+
+	p.eventManager.signalEvent(proto.EventType_DROPPED, EventReasonAccountDropped, hashes...)
+}
+
 // Demote excludes an account from being further processed during block building
 // due to a recoverable error. If an account has been demoted too many times (maxAccountDemotions),
 // it is Dropped instead.
@@ -559,7 +886,7 @@ func (p *TxPool) Demote(tx *types.Transaction) {
 
 	account.incrementDemotions()
 
-	p.eventManager.signalEvent(proto.EventType_DEMOTED, tx.Hash)
+	p.eventManager.signalEvent(proto.EventType_DEMOTED, EventReasonExecutionRetry, tx.Hash)
 }
 
 // ResetWithHeaders processes the transactions from the new
@@ -593,7 +920,15 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 	}
 
 	// Grab the latest state root now that the block has been inserted
-	stateRoot := p.store.Header().StateRoot
+	head := p.store.Header()
+	stateRoot := head.StateRoot
+
+	// pick up the governed parameters enacted in the latest block
+	p.updateGovernanceParams(head)
+
+	// gossip again the local transactions left unmined
+	p.rebroadcastLocals(head.Number)
+
 	stateNonces := make(map[types.Address]uint64)
 
 	// discover latest (next) nonces for all accounts
@@ -644,17 +979,55 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 	p.resetAccounts(stateNonces)
 }
 
+// updateGovernanceParams applies the governed parameters enacted in the state of the block
+func (p *TxPool) updateGovernanceParams(header *types.Header) {
+	params, err := p.store.GovernanceParams(header)
+	if err != nil {
+		p.logger.Error("could not read governance params", "block", header.Number, "err", err)
+
+		return
+	}
+
+	maxSlots := p.configMaxSlots
+	if params.TxPoolMaxSlots > 0 {
+		maxSlots = params.TxPoolMaxSlots
+	}
+
+	p.updatePriceLimit(func(s *priceLimitSources) {
+		s.governed = params.MinGasPrice
+		s.pending = header.Number + 1
+	})
+	p.gauge.setLimit(maxSlots)
+}
+
 // validateTx ensures the transaction conforms to specific
-// constraints before entering the pool.
-func (p *TxPool) validateTx(tx *types.Transaction) error {
+// constraints before entering the pool, and returns its execution hint.
+func (p *TxPool) validateTx(origin txOrigin, tx *types.Transaction) (*ExecutionHint, error) {
 	// Check the transaction size to overcome DOS Attacks
-	if uint64(len(tx.MarshalRLP())) > txMaxSize {
-		return ErrOversizedData
+	if size := uint64(len(tx.MarshalRLP())); size > p.maxTxSize {
+		return nil, fmt.Errorf("%w: size %d exceeds the limit of %d", ErrOversizedData, size, p.maxTxSize)
 	}
 
 	// Check if the transaction has a strictly positive value
 	if tx.Value.Sign() < 0 {
-		return ErrNegativeValue
+		return nil, ErrNegativeValue
+	}
+
+	// Access list transactions are only accepted once the berlin fork is enabled
+	if tx.Type == types.AccessListTx && !p.pendingForks().Berlin {
+		return nil, ErrTxTypeNotSupported
+	}
+
+	// Dynamic fee transactions are only accepted once the london fork is enabled,
+	// which is when the next block carries a base fee
+	if tx.IsDynamicFee() {
+		if p.store.CalculateBaseFee(p.store.Header()) == 0 {
+			return nil, ErrTxTypeNotSupported
+		}
+
+		if tx.GasTipCap.Cmp(tx.GasFeeCap) > 0 {
+			return nil, ErrTipAboveFeeCap
+		}
 	}
 
 	// Check if the transaction is signed properly
@@ -662,18 +1035,22 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	// Extract the sender
 	from, signerErr := p.signer.Sender(tx)
 	if signerErr != nil {
-		return ErrExtractSignature
+		return nil, ErrExtractSignature
 	}
 
 	if _, ok := p.blacklist[from]; ok {
-		return ErrBlackList
+		return nil, ErrBlackList
+	}
+
+	if err := p.policy.check(from, tx.To); err != nil {
+		return nil, err
 	}
 
 	// If the from field is set, check that
 	// it matches the signer
 	if tx.From != types.ZeroAddress &&
 		tx.From != from {
-		return ErrInvalidSender
+		return nil, ErrInvalidSender
 	}
 
 	// If no address was set, update it
@@ -681,49 +1058,91 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		tx.From = from
 	}
 
-	// Reject underpriced transactions
-	if tx.IsUnderpriced(p.priceLimit) {
-		return ErrUnderpriced
+	// Reject underpriced transactions, but the local ones
+	if !p.isExempt(origin, tx.From) && tx.IsUnderpriced(atomic.LoadUint64(&p.priceLimit)) {
+		return nil, ErrUnderpriced
 	}
 
 	// Grab the state root for the latest block
 	stateRoot := p.store.Header().StateRoot
 
 	// Check nonce ordering
-	if p.store.GetNonce(stateRoot, tx.From) > tx.Nonce {
-		return ErrNonceTooLow
+	stateNonce := p.store.GetNonce(stateRoot, tx.From)
+	if stateNonce > tx.Nonce {
+		return nil, ErrNonceTooLow
+	}
+
+	// Reject the nonces too far ahead to be reached soon
+	if p.maxAccountFutureNonce > 0 && tx.Nonce-stateNonce > p.maxAccountFutureNonce {
+		return nil, fmt.Errorf("%w: nonce %d is more than %d ahead of the account nonce %d",
+			ErrNonceTooHigh, tx.Nonce, p.maxAccountFutureNonce, stateNonce)
 	}
 
 	accountBalance, balanceErr := p.store.GetBalance(stateRoot, tx.From)
 	if balanceErr != nil {
-		return ErrInvalidAccountState
+		return nil, ErrInvalidAccountState
 	}
 
 	// Check if the sender has enough funds to execute the transaction
 	if accountBalance.Cmp(tx.Cost()) < 0 {
-		return ErrInsufficientFunds
+		return nil, ErrInsufficientFunds
 	}
 
 	// Make sure the transaction has more gas than the basic transaction fee
-	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul)
+	intrinsicGas, err := p.intrinsicGas(tx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if tx.Gas < intrinsicGas {
-		return ErrIntrinsicGas
+		return nil, fmt.Errorf("%w: have %d, want %d", ErrIntrinsicGas, tx.Gas, intrinsicGas)
 	}
 
 	// Grab the block gas limit for the latest block
 	latestBlockGasLimit := p.store.Header().GasLimit
 
 	if tx.Gas > latestBlockGasLimit {
-		return ErrBlockLimitExceeded
+		return nil, ErrBlockLimitExceeded
 	}
 
-	return nil
+	return newExecutionHint(tx, intrinsicGas), nil
+}
+
+// IsLocal returns whether the account is local, having submitted transactions
+// through the endpoints of the node. The transactions of a local account are exempted
+// from the price limit and the evictions, unless the local class is disabled
+func (p *TxPool) IsLocal(addr types.Address) bool {
+	return !p.noLocals && p.locals.contains(addr)
+}
+
+// isExempt returns whether the transaction of the origin and sender is exempted
+// from the price limit, the ones submitted locally being so before their sender is local
+func (p *TxPool) isExempt(origin txOrigin, from types.Address) bool {
+	if p.noLocals {
+		return false
+	}
+
+	return origin == local || p.locals.contains(from)
+}
+
+// pendingForks returns the forks enabled in the block the pool collects transactions for
+func (p *TxPool) pendingForks() chain.ForksInTime {
+	return p.forks.At(p.store.Header().Number + 1)
 }
 
+// intrinsicGas returns the gas the transaction is charged before its execution in the pending
+// block, as the executor computes it: the base cost, higher for contract creations once homestead
+// is enabled, plus the cost of the zero and non-zero calldata bytes and of the access list
+func (p *TxPool) intrinsicGas(tx *types.Transaction) (uint64, error) {
+	forks := p.pendingForks()
+
+	gas, err := state.TransactionGasCost(tx, forks.Homestead, forks.Istanbul)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrIntrinsicGas, err)
+	}
+
+	return gas, nil
+}
 
 func (p *TxPool) signalPruning() {
 	select {
@@ -733,10 +1152,15 @@ func (p *TxPool) signalPruning() {
 }
 
 func (p *TxPool) pruneAccountsWithNonceHoles() {
-	p.accounts.Range(
-		func(_, value interface{}) bool {
+	p.accounts.cmap.Range(
+		func(key, value interface{}) bool {
+			addr, _ := key.(types.Address)
 			account, _ := value.(*account)
 
+			if p.IsLocal(addr) {
+				return true
+			}
+
 			account.enqueued.lock(true)
 			defer account.enqueued.unlock()
 
@@ -750,7 +1174,7 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 				return true
 			}
 
-			removed := account.enqueued.clear()
+			removed := account.enqueued.Clear()
 
 			p.index.remove(removed...)
 			p.gauge.decrease(slotsRequired(removed...))
@@ -765,45 +1189,63 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 // successful, an account is created for this address
 // (only once) and an enqueueRequest is signaled.
 func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
+	_, err := p.addTxWithReplacement(origin, tx)
+
+	return err
+}
+
+// addTxWithReplacement adds the transaction as addTx does, and returns
+// the pending transaction of the same nonce it replaces, if any.
+func (p *TxPool) addTxWithReplacement(origin txOrigin, tx *types.Transaction) (*types.Transaction, error) {
 	p.logger.Debug("add tx",
 		"origin", origin.String(),
 		"hash", tx.Hash.String(),
 	)
 
 	// validate incoming tx
-	if err := p.validateTx(tx); err != nil {
-		return err
+	hint, err := p.validateTx(origin, tx)
+	if err != nil {
+		return nil, err
 	}
 
 	if p.gauge.highPressure() {
 		p.signalPruning()
-	}	
+	}
 
 	// check for overflow
-	if p.gauge.read()+slotsRequired(tx) > p.gauge.max {
-		return ErrTxPoolOverflow
+	if p.gauge.read()+slotsRequired(tx) > p.gauge.limit() {
+		return nil, ErrTxPoolOverflow
 	}
 
 	tx.ComputeHash()
 
+	// initialize account for this address once
+	p.createAccountOnce(tx.From)
+
+	// a pending transaction of the same nonce is only replaced
+	// if the gas price is bumped enough
+	replaced, err := p.accounts.get(tx.From).replacement(tx, p.priceBump)
+	if err != nil {
+		return nil, err
+	}
+
 	// add to index
 	if ok := p.index.add(tx); !ok {
-		return ErrAlreadyKnown
+		return nil, ErrAlreadyKnown
 	}
 
+	// keep what validation learnt for block building
+	p.index.setHint(tx.Hash, hint)
+
 	if tx.ReceivedTime.IsZero() {
 		tx.ReceivedTime = time.Now() // mark the tx received time
 	}
 
-	// initialize account for this address once
-	p.createAccountOnce(tx.From)
-
-
 	// send request [BLOCKING]
 	p.enqueueReqCh <- enqueueRequest{tx: tx}
-	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
+	p.eventManager.signalEvent(proto.EventType_ADDED, "", tx.Hash)
 
-	return nil
+	return replaced, nil
 }
 
 // handleEnqueueRequest attempts to enqueue the transaction
@@ -818,7 +1260,7 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 	account := p.accounts.get(addr)
 
 	// enqueue tx
-	replacedTx, err := account.enqueue(tx)
+	replacedTx, promoted, err := account.enqueue(tx, p.priceBump)
 	if err != nil {
 		p.logger.Error("enqueue request", "err", err)
 
@@ -840,10 +1282,24 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 
 		// remove tx index
 		p.index.remove(replacedTx)
+		p.replacements.Add(replacedTx.Hash, tx.Hash)
 		// gauge, metrics, event
 		p.gauge.decrease(slotsRequired(replacedTx))
+		p.eventManager.signalEvent(proto.EventType_REPLACED, EventReasonPriceBump, replacedTx.Hash)
+
+		if promoted {
+			// the replacement takes the place of the promoted transaction
+			p.gauge.increase(slotsRequired(tx))
+			p.eventManager.signalEvent(proto.EventType_PROMOTED, EventReasonReplacement, tx.Hash)
+			p.notifyPromoted([]*types.Transaction{tx})
+
+			// the replacement might be larger
+			p.enforceAccountLimits(addr, account)
+
+			return
+		}
+
 		p.metrics.EnqueueTxs.Add(-1)
-		p.eventManager.signalEvent(proto.EventType_REPLACED, replacedTx.Hash)
 	}
 
 	p.logger.Debug("enqueue request", "hash", tx.Hash.String())
@@ -851,7 +1307,14 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 	// state
 	p.gauge.increase(slotsRequired(tx))
 	// metrics and event
-	p.increaseQueueGauge([]*types.Transaction{tx}, p.metrics.EnqueueTxs, proto.EventType_ENQUEUED)
+	p.increaseQueueGauge([]*types.Transaction{tx}, p.metrics.EnqueueTxs, proto.EventType_ENQUEUED, "")
+
+	for _, evicted := range p.enforceAccountLimits(addr, account) {
+		if evicted == tx {
+			// nothing to promote
+			return
+		}
+	}
 
 	if tx.Nonce > account.getNonce() {
 		// don't signal promotion for
@@ -862,6 +1325,25 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 	p.promoteReqCh <- promoteRequest{account: addr} // BLOCKING
 }
 
+// enforceAccountLimits evicts the highest nonce enqueued transactions of the account
+// exceeding its limits, so that a single account does not monopolize the pool.
+// The local accounts are exempted.
+func (p *TxPool) enforceAccountLimits(addr types.Address, account *account) []*types.Transaction {
+	if p.IsLocal(addr) {
+		return nil
+	}
+
+	evicted := account.enforceLimits(p.maxAccountEnqueued, p.maxAccountSlots)
+	if len(evicted) == 0 {
+		return nil
+	}
+
+	p.logger.Debug("evicted account transactions exceeding the limits", "num", len(evicted))
+	p.pruneEnqueuedTxs(evicted, EventReasonAccountLimit)
+
+	return evicted
+}
+
 // handlePromoteRequest handles moving promotable transactions
 // of some account from enqueued to promoted. Can only be
 // invoked by handleEnqueueRequest or resetAccount.
@@ -874,54 +1356,122 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 	promoted, pruned := account.promote()
 	p.logger.Debug("promote request", "promoted", promoted, "addr", addr.String())
 
-	p.index.remove(pruned...)
-	p.gauge.decrease(slotsRequired(pruned...))
+	if len(pruned) > 0 {
+		p.pruneEnqueuedTxs(pruned, EventReasonNonceTooLow)
+	}
 
 	// update metrics
 	p.metrics.PendingTxs.Add(float64(len(promoted)))
-	p.eventManager.signalEvent(proto.EventType_PROMOTED, toHash(promoted...)...)
+	p.eventManager.signalEvent(proto.EventType_PROMOTED, "", toHash(promoted...)...)
+	p.notifyPromoted(promoted)
+}
+
+// AddPromoteHook registers the hook notified of the transactions promoted by the pool.
+// The hook is called from the pool loop, it must not block
+func (p *TxPool) AddPromoteHook(hook func(txs []*types.Transaction)) {
+	p.promoteHooksLock.Lock()
+	defer p.promoteHooksLock.Unlock()
+
+	p.promoteHooks = append(p.promoteHooks, hook)
+}
+
+// notifyPromoted notifies the promote hooks of the promoted transactions
+func (p *TxPool) notifyPromoted(promoted []*types.Transaction) {
+	if len(promoted) == 0 {
+		return
+	}
+
+	p.promoteHooksLock.RLock()
+	defer p.promoteHooksLock.RUnlock()
+
+	for _, hook := range p.promoteHooks {
+		hook(promoted)
+	}
 }
 
 // pruneStaleAccounts would find out all need-to-prune transactions,
 // remove them from txpool.
 func (p *TxPool) pruneStaleAccounts() {
-	pruned := p.accounts.pruneStaleEnqueuedTxs(p.promoteOutdateDuration)
+	pruned := p.accounts.pruneStaleEnqueuedTxs(p.promoteOutdateDuration, p.IsLocal)
 	if len(pruned) == 0 {
 		return
 	}
 
-	p.pruneEnqueuedTxs(pruned)
+	p.pruneEnqueuedTxs(pruned, EventReasonStale)
 	p.logger.Debug("pruned stale enqueued txs", "num", pruned)
 }
 
-func (p *TxPool) tranferQueueGauge(txs []*types.Transaction, src, dest metrics.Gauge, event proto.EventType) {
+// expireTxs drops the transactions sitting in the pool longer than their lifetime
+func (p *TxPool) expireTxs() {
+	expiredPromoted, expiredEnqueued, demoted := p.accounts.expireTxs(time.Now().Add(-p.txLifetime), p.IsLocal)
+
+	expired := make([]*types.Transaction, 0, len(expiredPromoted)+len(expiredEnqueued))
+	expired = append(expired, expiredPromoted...)
+	expired = append(expired, expiredEnqueued...)
+
+	if len(expired) == 0 {
+		return
+	}
+
+	p.index.remove(expired...)
+	// state
+	p.gauge.decrease(slotsRequired(expired...))
+	// metrics and event
+	p.metrics.PendingTxs.Add(-1 * float64(len(expiredPromoted)))
+	p.metrics.EnqueueTxs.Add(-1 * float64(len(expiredEnqueued)))
+	p.metrics.ExpiredTxs.Add(float64(len(expired)))
+	p.eventManager.signalEvent(proto.EventType_EXPIRED, EventReasonLifetime, toHash(expired...)...)
+
+	if len(demoted) > 0 {
+		p.tranferQueueGauge(demoted, p.metrics.PendingTxs, p.metrics.EnqueueTxs, proto.EventType_DEMOTED, EventReasonNonceGap)
+	}
+
+	p.logger.Debug("expired txs", "num", len(expired), "demoted", len(demoted))
+}
+
+func (p *TxPool) tranferQueueGauge(
+	txs []*types.Transaction,
+	src, dest metrics.Gauge,
+	event proto.EventType,
+	reason string,
+) {
 	// metrics switching
 	src.Add(-1 * float64(len(txs)))
 	dest.Add(float64(len(txs)))
 	// event
-	p.eventManager.signalEvent(event, toHash(txs...)...)
+	p.eventManager.signalEvent(event, reason, toHash(txs...)...)
 }
 
-func (p *TxPool) increaseQueueGauge(txs []*types.Transaction, destGauge metrics.Gauge, event proto.EventType) {
+func (p *TxPool) increaseQueueGauge(
+	txs []*types.Transaction,
+	destGauge metrics.Gauge,
+	event proto.EventType,
+	reason string,
+) {
 	// metrics
 	destGauge.Add(float64(len(txs)))
 	// event
-	p.eventManager.signalEvent(event, toHash(txs...)...)
+	p.eventManager.signalEvent(event, reason, toHash(txs...)...)
 }
 
-func (p *TxPool) decreaseQueueGauge(txs []*types.Transaction, destGauge metrics.Gauge, event proto.EventType) {
+func (p *TxPool) decreaseQueueGauge(
+	txs []*types.Transaction,
+	destGauge metrics.Gauge,
+	event proto.EventType,
+	reason string,
+) {
 	// metrics
 	destGauge.Add(-1 * float64(len(txs)))
 	// event
-	p.eventManager.signalEvent(event, toHash(txs...)...)
+	p.eventManager.signalEvent(event, reason, toHash(txs...)...)
 }
 
-func (p *TxPool) pruneEnqueuedTxs(pruned []*types.Transaction) {
+func (p *TxPool) pruneEnqueuedTxs(pruned []*types.Transaction, reason string) {
 	p.index.remove(pruned...)
 	// state
 	p.gauge.decrease(slotsRequired(pruned...))
 	// metrics and event
-	p.decreaseQueueGauge(pruned, p.metrics.EnqueueTxs, proto.EventType_PRUNED_ENQUEUED)
+	p.decreaseQueueGauge(pruned, p.metrics.EnqueueTxs, proto.EventType_PRUNED_ENQUEUED, reason)
 }
 
 // addGossipTx handles receiving transactions gossiped by the network.
@@ -974,13 +1524,12 @@ func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 
 	//	clear all accounts of stale txs
 	for addr, newNonce := range stateNonces {
-			account := p.accounts.get(addr)
+		account := p.accounts.get(addr)
 
-			if account == nil {
-				// no updates for this account
-				continue
-			}
-		
+		if account == nil {
+			// no updates for this account
+			continue
+		}
 
 		prunedPromoted, prunedEnqueued := account.reset(newNonce, p.promoteReqCh)
 
@@ -1000,12 +1549,12 @@ func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 	//	prune pool state
 	if len(allPrunedPromoted) > 0 {
 		cleanup(allPrunedPromoted)
-		p.decreaseQueueGauge(allPrunedPromoted, p.metrics.PendingTxs, proto.EventType_PRUNED_PROMOTED)
+		p.decreaseQueueGauge(allPrunedPromoted, p.metrics.PendingTxs, proto.EventType_PRUNED_PROMOTED, EventReasonNonceTooLow)
 	}
 
 	if len(allPrunedEnqueued) > 0 {
 		cleanup(allPrunedEnqueued)
-		p.decreaseQueueGauge(allPrunedEnqueued, p.metrics.EnqueueTxs, proto.EventType_PRUNED_ENQUEUED)
+		p.decreaseQueueGauge(allPrunedEnqueued, p.metrics.EnqueueTxs, proto.EventType_PRUNED_ENQUEUED, EventReasonNonceTooLow)
 	}
 }
 
@@ -1014,7 +1563,7 @@ func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 func (p *TxPool) createAccountOnce(newAddr types.Address) *account {
 	if p.accounts.exists(newAddr) {
 		return nil
-	}	
+	}
 	// fetch nonce from state
 	stateRoot := p.store.Header().StateRoot
 	stateNonce := p.store.GetNonce(stateRoot, newAddr)
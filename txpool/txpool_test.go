@@ -11,8 +11,11 @@ import (
 	"time"
 
 	"github.com/dogechain-lab/dogechain/chain"
+	"github.com/dogechain-lab/dogechain/contracts/governance"
 	"github.com/dogechain-lab/dogechain/crypto"
+	"github.com/dogechain-lab/dogechain/helper/errcode"
 	"github.com/dogechain-lab/dogechain/helper/tests"
+	"github.com/dogechain-lab/dogechain/state"
 	"github.com/dogechain-lab/dogechain/txpool/proto"
 	"github.com/dogechain-lab/dogechain/types"
 	"github.com/golang/protobuf/ptypes/any"
@@ -95,7 +98,7 @@ func newTestPoolWithSlots(maxSlots uint64, mockStore ...store) (*TxPool, error)
 
 	return NewTxPool(
 		hclog.NewNullLogger(),
-		forks.At(0),
+		forks,
 		storeToUse,
 		nil,
 		nil,
@@ -106,6 +109,7 @@ func newTestPoolWithSlots(maxSlots uint64, mockStore ...store) (*TxPool, error)
 			Sealing:               false,
 			PruneTickSeconds:      DefaultPruneTickSeconds,
 			PromoteOutdateSeconds: DefaultPromoteOutdateSeconds,
+			PriceBump:             DefaultPriceBump,
 		},
 	)
 }
@@ -208,11 +212,32 @@ func TestAddTxErrors(t *testing.T) {
 		tx = signTx(tx)
 
 		assert.ErrorIs(t,
-			pool.addTx(local, tx),
+			pool.addTx(gossip, tx),
 			ErrUnderpriced,
 		)
 	})
 
+	t.Run("local transactions exempted from the price limit", func(t *testing.T) {
+		pool := setupPool()
+		pool.priceLimit = 1000000
+
+		tx := signTx(newTx(defaultAddr, 0, 1))
+
+		_, err := pool.validateTx(local, tx)
+		assert.NoError(t, err)
+
+		// the exemption covers the other transactions of the local accounts
+		pool.locals.add(defaultAddr)
+
+		_, err = pool.validateTx(gossip, signTx(newTx(defaultAddr, 1, 1)))
+		assert.NoError(t, err)
+
+		pool.noLocals = true
+
+		_, err = pool.validateTx(local, tx)
+		assert.ErrorIs(t, err, ErrUnderpriced)
+	})
+
 	t.Run("ErrInvalidAccountState", func(t *testing.T) {
 		pool := setupPool()
 		pool.store = faultyMockStore{}
@@ -256,6 +281,29 @@ func TestAddTxErrors(t *testing.T) {
 		)
 	})
 
+	t.Run("ErrIntrinsicGas on calldata boundary", func(t *testing.T) {
+		pool := setupPool()
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Input = []byte{0x0, 0x1, 0x0, 0x2}
+
+		// must be the same value as the executor charges
+		pending := pool.pendingForks()
+		intrinsicGas, err := state.TransactionGasCost(tx, pending.Homestead, pending.Istanbul)
+		assert.NoError(t, err)
+
+		tx.Gas = intrinsicGas - 1
+
+		_, err = pool.validateTx(gossip, signTx(tx))
+		assert.ErrorIs(t, err, ErrIntrinsicGas)
+
+		tx.Gas = intrinsicGas
+
+		hint, err := pool.validateTx(gossip, signTx(tx))
+		assert.NoError(t, err)
+		assert.Equal(t, intrinsicGas, hint.Gas)
+	})
+
 	t.Run("ErrAlreadyKnown", func(t *testing.T) {
 		pool := setupPool()
 
@@ -296,6 +344,44 @@ func TestAddTxErrors(t *testing.T) {
 		)
 	})
 
+	t.Run("ErrOversizedData over the configured limit", func(t *testing.T) {
+		pool := setupPool()
+
+		tx := signTx(newTx(defaultAddr, 0, 1))
+		size := uint64(len(tx.MarshalRLP()))
+
+		pool.maxTxSize = size - 1
+
+		_, err := pool.validateTx(gossip, tx)
+		assert.ErrorIs(t, err, ErrOversizedData)
+		assert.Equal(t, errcode.TxPoolOversizedData, errcode.GetCode(err))
+
+		pool.maxTxSize = size
+
+		_, err = pool.validateTx(gossip, tx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ErrIntrinsicGas on contract creation", func(t *testing.T) {
+		pool := setupPool()
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.To = nil
+		tx.Input = []byte{0x0, 0x1}
+
+		// contract creations are charged more once homestead is enabled
+		tx.Gas = state.TxGasContractCreation + state.TxDataZeroGas + state.TxDataNonZeroGasEIP2028 - 1
+
+		_, err := pool.validateTx(gossip, signTx(tx))
+		assert.ErrorIs(t, err, ErrIntrinsicGas)
+		assert.Equal(t, errcode.TxPoolIntrinsicGas, errcode.GetCode(err))
+
+		tx.Gas++
+
+		_, err = pool.validateTx(gossip, signTx(tx))
+		assert.NoError(t, err)
+	})
+
 	t.Run("ErrNonceTooLow", func(t *testing.T) {
 		pool := setupPool()
 
@@ -310,6 +396,22 @@ func TestAddTxErrors(t *testing.T) {
 		)
 	})
 
+	t.Run("ErrNonceTooHigh", func(t *testing.T) {
+		pool := setupPool()
+		pool.maxAccountFutureNonce = 10
+
+		tx := newTx(defaultAddr, 10, 1)
+
+		_, err := pool.validateTx(gossip, signTx(tx))
+		assert.NoError(t, err)
+
+		tx.Nonce = 11
+
+		_, err = pool.validateTx(gossip, signTx(tx))
+		assert.ErrorIs(t, err, ErrNonceTooHigh)
+		assert.Equal(t, errcode.TxPoolNonceTooHigh, errcode.GetCode(err))
+	})
+
 	t.Run("ErrInsufficientFunds", func(t *testing.T) {
 		pool := setupPool()
 
@@ -886,16 +988,18 @@ func TestPromoteHandler(t *testing.T) {
 			promReq1 := handleEnqueueRequest(enqTx1)
 			promReq2 := handleEnqueueRequest(enqTx2)
 
+			// the pricier tx replaces the cheaper one of the same nonce when enqueued
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
-			assert.Equal(t, uint64(2), pool.accounts.get(addr1).enqueued.length())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+			assertTxExists(t, tx1, false)
 			assert.Equal(
 				t,
-				slotsRequired(tx1)+slotsRequired(tx2),
+				slotsRequired(tx2),
 				pool.gauge.read(),
 			)
 
-			// promote the second Tx and remove the first Tx
+			// promote the second Tx
 			pool.handlePromoteRequest(promReq1)
 
 			assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
@@ -1353,7 +1457,7 @@ func TestPop(t *testing.T) {
 
 	// pop the tx
 	pool.Prepare()
-	tx := pool.Pop()
+	tx := pool.PopExecutable()
 	pool.RemoveExecuted(tx)
 
 	assert.Equal(t, uint64(0), pool.gauge.read())
@@ -1379,7 +1483,7 @@ func TestDrop(t *testing.T) {
 
 	// pop the tx
 	pool.Prepare()
-	tx := pool.Pop()
+	tx := pool.PopExecutable()
 	pool.Drop(tx)
 
 	assert.Equal(t, uint64(0), pool.gauge.read())
@@ -1387,6 +1491,113 @@ func TestDrop(t *testing.T) {
 	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
 }
 
+func TestDrop_EventReasons(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.Start()
+	defer pool.Close()
+
+	promotedSubscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		assert.NoError(t, pool.addTx(local, newTx(addr1, nonce, 1)))
+	}
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancelFn()
+
+	assert.Len(t, waitForEvents(ctx, promotedSubscription, 3), 3)
+	pool.eventManager.cancelSubscription(promotedSubscription.subscriptionID)
+
+	droppedSubscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_DROPPED})
+
+	pool.Prepare()
+	tx := pool.PopExecutable()
+	pool.Drop(tx)
+
+	events := waitForEvents(ctx, droppedSubscription, 3)
+	assert.Len(t, events, 3)
+
+	// the failed transaction first, then the rest of the account
+	assert.Equal(t, tx.Hash.String(), events[0].TxHash)
+	assert.Equal(t, EventReasonExecutionFailed, events[0].Reason)
+
+	for _, event := range events[1:] {
+		assert.NotEqual(t, tx.Hash.String(), event.TxHash)
+		assert.Equal(t, EventReasonAccountDropped, event.Reason)
+	}
+}
+
+func TestExecutionHint(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	tx := newTx(addr1, 0, 1)
+
+	go func() {
+		err := pool.addTx(local, tx)
+		assert.NoError(t, err)
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	pending := pool.pendingForks()
+	intrinsicGas, err := state.TransactionGasCost(tx, pending.Homestead, pending.Istanbul)
+	assert.NoError(t, err)
+
+	hint, ok := pool.ExecutionHint(tx.Hash)
+	assert.True(t, ok)
+	assert.Equal(t, intrinsicGas, hint.Gas)
+	assert.Equal(t, []types.Address{addr1, crypto.CreateAddress(addr1, 0)}, hint.Accounts)
+
+	// the hint leaves the pool along with the transaction
+	pool.Prepare()
+	pool.Drop(pool.PopExecutable())
+
+	_, ok = pool.ExecutionHint(tx.Hash)
+	assert.False(t, ok)
+}
+
+func TestUpdateGovernanceParams(t *testing.T) {
+	tests := []struct {
+		name               string
+		params             *governance.Params
+		expectedPriceLimit uint64
+		expectedMaxSlots   uint64
+	}{
+		{
+			name:               "not governed",
+			params:             &governance.Params{},
+			expectedPriceLimit: defaultPriceLimit,
+			expectedMaxSlots:   defaultMaxSlots,
+		},
+		{
+			name:               "governed",
+			params:             &governance.Params{MinGasPrice: defaultPriceLimit + 10, TxPoolMaxSlots: 20},
+			expectedPriceLimit: defaultPriceLimit + 10,
+			expectedMaxSlots:   20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := newTestPool(defaultMockStore{
+				DefaultHeader: mockHeader,
+				Governance:    tt.params,
+			})
+			assert.NoError(t, err)
+
+			pool.updateGovernanceParams(mockHeader)
+
+			assert.Equal(t, tt.expectedPriceLimit, pool.priceLimit)
+			assert.Equal(t, tt.expectedMaxSlots, pool.gauge.limit())
+		})
+	}
+}
+
 func TestDrop_RecoverRightNonce(t *testing.T) {
 	pool, err := newTestPool()
 	assert.NoError(t, err)
@@ -1440,7 +1651,7 @@ func TestDrop_RecoverRightNonce(t *testing.T) {
 
 	// pop the tx
 	pool.Prepare()
-	tx := pool.Pop()
+	tx := pool.PopExecutable()
 	pool.Drop(tx)
 
 	assert.Equal(t, uint64(0), pool.gauge.read())
@@ -1659,6 +1870,9 @@ func TestAddTxns(t *testing.T) {
 			subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
 
 			addr := types.Address{0x1}
+			// the local accounts are exempted from the account limits
+			pool.locals.add(addr)
+
 			for nonce := uint64(0); nonce < test.numTxs; nonce++ {
 				err := pool.addTx(local, newTx(addr, nonce, slotSize))
 				assert.NoError(t, err)
@@ -2125,7 +2339,7 @@ func TestExecutablesOrder(t *testing.T) {
 
 			var successful []*types.Transaction
 			for {
-				tx := pool.Pop()
+				tx := pool.PopExecutable()
 				if tx == nil {
 					break
 				}
@@ -2338,7 +2552,7 @@ func TestDropAndRequeue(t *testing.T) {
 			func() {
 				pool.Prepare()
 				for {
-					tx := pool.Pop()
+					tx := pool.PopExecutable()
 					if tx == nil {
 						break
 					}
@@ -2382,19 +2596,19 @@ func TestDemote(t *testing.T) {
 		assert.Equal(t, uint64(1), pool.gauge.read())
 		assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
 		assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
-		assert.Equal(t, uint(0), pool.accounts.get(addr1).demotions)
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).demotions)
 		assert.Equal(t, uint64(0), pool.accounts.get(addr1).Demotions())
 
 		// call demote
 		pool.Prepare()
-		tx := pool.Peek()
+		tx := pool.PopExecutable()
 		pool.Demote(tx)
 		assert.Equal(t, uint64(1), pool.gauge.read())
 		assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
 		assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
 
 		// assert counter was incremented
-		assert.Equal(t, uint(1), pool.accounts.get(addr1).demotions)
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).demotions)
 		assert.Equal(t, uint64(1), pool.accounts.get(addr1).Demotions())
 	})
 
@@ -2418,7 +2632,7 @@ func TestDemote(t *testing.T) {
 		pool.accounts.get(addr1).demotions = maxAccountDemotions
 		// call demote
 		pool.Prepare()
-		tx := pool.Peek()
+		tx := pool.PopExecutable()
 		pool.Demote(tx)
 		// account was dropped
 		assert.Equal(t, uint64(0), pool.gauge.read())
@@ -2426,7 +2640,7 @@ func TestDemote(t *testing.T) {
 		assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
 
 		// demotions are reset to 0
-		assert.Equal(t, uint(0), pool.accounts.get(addr1).demotions)
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).demotions)
 		assert.Equal(t, uint64(0), pool.accounts.get(addr1).Demotions())
 	})
 }
@@ -2758,3 +2972,414 @@ func TestAddTx_ReplaceSameNonce(t *testing.T) {
 		})
 	}
 }
+
+func TestTxPriceReplacable(t *testing.T) {
+	t.Parallel()
+
+	oldTx := newPriceTx(addr1, big.NewInt(1000), 0, 1)
+
+	testCases := []struct {
+		name       string
+		price      int64
+		priceBump  uint64
+		replacable bool
+	}{
+		{"lower price", 999, 0, false},
+		{"same price", 1000, 0, false},
+		{"higher price without bump", 1001, 0, true},
+		{"price bumped too little", 1099, 10, false},
+		{"price bumped enough", 1100, 10, true},
+		{"price bumped more", 2000, 10, true},
+	}
+
+	for _, testCase := range testCases {
+		newTx := newPriceTx(addr1, big.NewInt(testCase.price), 0, 1)
+
+		assert.Equal(
+			t,
+			testCase.replacable,
+			txPriceReplacable(newTx, oldTx, testCase.priceBump),
+			testCase.name,
+		)
+	}
+}
+
+func TestAddTx_PriceBump(t *testing.T) {
+	var (
+		eoa  = new(eoa).create(t)
+		addr = eoa.Address
+		// price
+		price       = big.NewInt(1000)
+		lowBumped   = big.NewInt(1050)
+		highBumped  = big.NewInt(1100)
+		tx0         = eoa.signTx(newPriceTx(addr, price, 0, 1), signerEIP155)
+		tx0Low      = eoa.signTx(newPriceTx(addr, lowBumped, 0, 1), signerEIP155)
+		tx0High     = eoa.signTx(newPriceTx(addr, highBumped, 0, 1), signerEIP155)
+		expectedErr = ErrReplaceUnderpriced
+	)
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(signerEIP155)
+
+	pool.Start()
+	defer pool.Close()
+
+	subscription := pool.eventManager.subscribe(
+		[]proto.EventType{
+			proto.EventType_PROMOTED,
+			proto.EventType_REPLACED,
+		},
+	)
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancelFn()
+
+	// the first transaction is promoted
+	replaced, err := pool.addTxWithReplacement(local, tx0)
+	assert.NoError(t, err)
+	assert.Nil(t, replaced)
+	assert.Len(t, waitForEvents(ctx, subscription, 1), 1)
+
+	// the price is not bumped enough, rejected right away
+	replaced, err = pool.addTxWithReplacement(local, tx0Low)
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Nil(t, replaced)
+
+	// the price is bumped enough, the promoted transaction is replaced
+	replaced, err = pool.addTxWithReplacement(local, tx0High)
+	assert.NoError(t, err)
+	assert.Equal(t, tx0, replaced)
+	assert.Len(t, waitForEvents(ctx, subscription, 2), 2)
+
+	allPromoted, allEnqueued := pool.GetTxs(true)
+	assert.Equal(t, []*types.Transaction{tx0High}, allPromoted[addr])
+	assert.Empty(t, allEnqueued[addr])
+
+	_, ok := pool.GetPendingTx(tx0.Hash)
+	assert.False(t, ok)
+
+	replacement, ok := pool.GetReplacement(tx0.Hash)
+	assert.True(t, ok)
+	assert.Equal(t, tx0High.Hash, replacement)
+}
+
+func TestAddTx_DynamicFee(t *testing.T) {
+	t.Parallel()
+
+	poolSigner := crypto.NewLondonSigner(100)
+	key, addr := tests.GenerateKeyAndAddr(t)
+
+	newDynamicFeeTx := func(tipCap, feeCap int64) *types.Transaction {
+		tx := newTx(addr, 0, 1)
+		tx.Type = types.DynamicFeeTx
+		tx.GasPrice = nil
+		tx.GasTipCap = big.NewInt(tipCap)
+		tx.GasFeeCap = big.NewInt(feeCap)
+
+		signedTx, err := poolSigner.SignTx(tx, key)
+		assert.NoError(t, err)
+
+		return signedTx
+	}
+
+	setupPool := func(baseFee uint64) *TxPool {
+		pool, err := newTestPool(defaultMockStore{
+			DefaultHeader: mockHeader,
+			BaseFee:       baseFee,
+		})
+		assert.NoError(t, err)
+
+		pool.SetSigner(poolSigner)
+
+		return pool
+	}
+
+	t.Run("rejected before london", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(0)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, newDynamicFeeTx(1, int64(defaultPriceLimit))),
+			ErrTxTypeNotSupported,
+		)
+	})
+
+	t.Run("tip above fee cap", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(1)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, newDynamicFeeTx(int64(defaultPriceLimit)+1, int64(defaultPriceLimit))),
+			ErrTipAboveFeeCap,
+		)
+	})
+
+	t.Run("accepted after london", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(1)
+		tx := newDynamicFeeTx(1, int64(defaultPriceLimit))
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, tx))
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		<-pool.promoteReqCh
+
+		assert.Equal(t, uint64(1), pool.accounts.get(addr).enqueued.length())
+	})
+}
+
+func TestAddTx_AccessList(t *testing.T) {
+	t.Parallel()
+
+	poolSigner := crypto.NewBerlinSigner(100)
+	key, addr := tests.GenerateKeyAndAddr(t)
+
+	tx := newTx(addr, 0, 1)
+	tx.Type = types.AccessListTx
+	tx.AccessList = types.AccessList{
+		{Address: addr1, StorageKeys: []types.Hash{{0x1}}},
+	}
+
+	signedTx, err := poolSigner.SignTx(tx, key)
+	assert.NoError(t, err)
+
+	setupPool := func(berlin bool) *TxPool {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+
+		pool.SetSigner(poolSigner)
+
+		if berlin {
+			pool.forks = &chain.Forks{
+				Homestead: chain.NewFork(0),
+				Istanbul:  chain.NewFork(0),
+				Berlin:    chain.NewFork(0),
+			}
+		}
+
+		return pool
+	}
+
+	t.Run("rejected before berlin", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(false)
+
+		assert.ErrorIs(t, pool.addTx(local, signedTx), ErrTxTypeNotSupported)
+	})
+
+	t.Run("accepted after berlin", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(true)
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, signedTx))
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		<-pool.promoteReqCh
+
+		assert.Equal(t, uint64(1), pool.accounts.get(addr).enqueued.length())
+	})
+}
+
+func TestAddTx_AccountLimits(t *testing.T) {
+	addTxs := func(t *testing.T, pool *TxPool, txs ...*types.Transaction) {
+		t.Helper()
+
+		for _, tx := range txs {
+			go func(tx *types.Transaction) {
+				assert.NoError(t, pool.addTx(local, tx))
+			}(tx)
+
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		}
+	}
+
+	t.Run("evict the highest nonce enqueued transactions", func(t *testing.T) {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.maxAccountEnqueued = 2
+
+		tx3, tx4, tx5 := newTx(addr1, 3, 1), newTx(addr1, 4, 1), newTx(addr1, 5, 1)
+		addTxs(t, pool, tx3, tx5, tx4)
+
+		assert.Equal(t, uint64(2), pool.gauge.read())
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).enqueued.length())
+		assert.NotNil(t, pool.accounts.get(addr1).enqueued.GetTxByNonce(4))
+		assert.Nil(t, pool.accounts.get(addr1).enqueued.GetTxByNonce(5))
+
+		_, ok := pool.index.get(tx5.Hash)
+		assert.False(t, ok)
+	})
+
+	t.Run("evict the transactions exceeding the account slots", func(t *testing.T) {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.maxAccountSlots = 3
+
+		// the last one does not fit
+		addTxs(t, pool, newTx(addr1, 1, 1), newTx(addr1, 2, 2), newTx(addr1, 3, 1))
+
+		assert.Equal(t, uint64(3), pool.gauge.read())
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).enqueued.length())
+		assert.Nil(t, pool.accounts.get(addr1).enqueued.GetTxByNonce(3))
+
+		// other accounts are not limited by it
+		addTxs(t, pool, newTx(addr2, 1, 1))
+
+		resp, err := pool.Status(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(3), resp.MaxAccountSlots)
+		assert.Equal(t, []*proto.AccountStatus{
+			{Address: addr1.String(), EnqueuedLength: 2, Slots: 3},
+			{Address: addr2.String(), EnqueuedLength: 1, Slots: 1},
+		}, resp.Accounts)
+	})
+}
+
+func TestTxpool_ExpireTxs(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_EXPIRED})
+
+	expiredTime := time.Now().Add(-time.Second * DefaultTxLifetimeSeconds)
+
+	newReceivedTx := func(addr types.Address, nonce uint64, receivedTime time.Time) *types.Transaction {
+		tx := newTx(addr, nonce, 1)
+		tx.ReceivedTime = receivedTime
+
+		return tx
+	}
+
+	var (
+		tx0 = newReceivedTx(addr1, 0, time.Now())
+		tx1 = newReceivedTx(addr1, 1, expiredTime)
+		tx2 = newReceivedTx(addr1, 2, time.Now())
+		tx5 = newReceivedTx(addr1, 5, expiredTime)
+		tx3 = newReceivedTx(addr2, 3, time.Now())
+	)
+
+	// promote the first ones, the others stay enqueued
+	for _, tx := range []*types.Transaction{tx0, tx1, tx2} {
+		go func(tx *types.Transaction) {
+			assert.NoError(t, pool.addTx(local, tx))
+		}(tx)
+
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	for _, tx := range []*types.Transaction{tx5, tx3} {
+		go func(tx *types.Transaction) {
+			assert.NoError(t, pool.addTx(local, tx))
+		}(tx)
+
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	}
+
+	acc := pool.accounts.get(addr1)
+	assert.Equal(t, uint64(3), acc.promoted.length())
+	assert.Equal(t, uint64(5), pool.gauge.read())
+
+	//	pretend ticker ticks and triggers the sweep
+	pool.expireTxs()
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelFn()
+
+	events := waitForEvents(ctx, subscription, 2)
+	assert.ElementsMatch(t, []string{tx1.Hash.String(), tx5.Hash.String()}, []string{
+		events[0].TxHash,
+		events[1].TxHash,
+	})
+
+	// the promoted transaction following the expired one is demoted
+	assert.Equal(t, []*types.Transaction{tx0}, acc.promoted.Transactions())
+	assert.Equal(t, []*types.Transaction{tx2}, acc.enqueued.Transactions())
+	assert.Equal(t, uint64(1), acc.getNonce())
+
+	// other accounts are untouched
+	assert.Equal(t, uint64(1), pool.accounts.get(addr2).enqueued.length())
+
+	assert.Equal(t, uint64(3), pool.gauge.read())
+
+	for _, tx := range []*types.Transaction{tx1, tx5} {
+		_, ok := pool.index.get(tx.Hash)
+		assert.False(t, ok)
+	}
+}
+
+func TestTxpool_LocalsExemptedFromEvictions(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	expiredTime := time.Now().Add(-time.Second * DefaultTxLifetimeSeconds)
+
+	// a stale and expired transaction behind a nonce hole
+	tx := newTx(addr1, 5, 1)
+	tx.ReceivedTime = expiredTime
+
+	go func() {
+		assert.NoError(t, pool.addTx(local, tx))
+	}()
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	acc := pool.accounts.get(addr1)
+	acc.lastPromoted = expiredTime
+
+	pool.locals.add(addr1)
+
+	pool.expireTxs()
+	pool.pruneStaleAccounts()
+	pool.pruneAccountsWithNonceHoles()
+
+	assert.Equal(t, uint64(1), acc.enqueued.length())
+	assert.Equal(t, uint64(1), pool.gauge.read())
+
+	// without the exemptions, the transaction is evicted
+	pool.noLocals = true
+
+	pool.expireTxs()
+
+	assert.Equal(t, uint64(0), acc.enqueued.length())
+	assert.Equal(t, uint64(0), pool.gauge.read())
+}
+
+func TestPromoteHook(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	var notified []*types.Transaction
+
+	pool.AddPromoteHook(func(txs []*types.Transaction) {
+		notified = append(notified, txs...)
+	})
+
+	tx := newTx(addr1, 0, 1)
+
+	go func() {
+		err := pool.addTx(local, tx)
+		assert.NoError(t, err)
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	// tx enqueued -> promotion signaled
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	assert.Len(t, notified, 1)
+	assert.Equal(t, tx.Hash, notified[0].Hash)
+}
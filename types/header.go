@@ -28,6 +28,10 @@ type Header struct {
 	MixHash      Hash
 	Nonce        Nonce
 	Hash         Hash
+
+	// BaseFee is the base fee per gas of the block, which is only set from the
+	// london fork on. It never falls to zero once set, so zero means no base fee.
+	BaseFee uint64
 }
 
 func (h *Header) Equal(hh *Header) bool {
@@ -94,6 +98,7 @@ func (h *Header) Copy() *Header {
 		MixHash:      h.MixHash,
 		Nonce:        h.Nonce,
 		Hash:         h.Hash,
+		BaseFee:      h.BaseFee,
 	}
 
 	newHeader.ExtraData = make([]byte, len(h.ExtraData))
@@ -153,6 +158,17 @@ func (b *Block) Size() uint64 {
 	return *sizeVal
 }
 
+// BodySize returns the encoded size of the block transactions
+func (b *Block) BodySize() uint64 {
+	var size uint64
+
+	for _, tx := range b.Transactions {
+		size += tx.Size()
+	}
+
+	return size
+}
+
 func (b *Block) String() string {
 	str := fmt.Sprintf(`Block(#%v):`, b.Number())
 
@@ -110,20 +110,34 @@ func CreateBloom(receipts []*Receipt) (b Bloom) {
 }
 
 func (b *Bloom) setEncode(hasher *keccak.Keccak, h []byte) {
+	for _, bit := range bloomBitLocations(hasher, h) {
+		// Find where the bit maps in the [0..255] byte array
+		byteLocation := 256 - 1 - bit/8
+		bitLocation := bit % 8
+		b[byteLocation] = b[byteLocation] | (1 << bitLocation)
+	}
+}
+
+// BloomBitLocations returns the global locations of the bits the data sets in a bloom filter
+func BloomBitLocations(data []byte) [3]uint {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	return bloomBitLocations(hasher, data)
+}
+
+func bloomBitLocations(hasher *keccak.Keccak, data []byte) (bits [3]uint) {
 	hasher.Reset()
 	//nolint
-	hasher.Write(h[:])
+	hasher.Write(data[:])
 	buf := hasher.Read()
 
 	for i := 0; i < 6; i += 2 {
 		// Find the global bit location
-		bit := (uint(buf[i+1]) + (uint(buf[i]) << 8)) & 2047
-
-		// Find where the bit maps in the [0..255] byte array
-		byteLocation := 256 - 1 - bit/8
-		bitLocation := bit % 8
-		b[byteLocation] = b[byteLocation] | (1 << bitLocation)
+		bits[i/2] = (uint(buf[i+1]) + (uint(buf[i]) << 8)) & 2047
 	}
+
+	return bits
 }
 
 // IsLogInBloom checks if the log has a possible presence in the bloom filter
@@ -129,3 +129,99 @@ func TestRLPUnmarshal_Header_ComputeHash(t *testing.T) {
 	assert.NoError(t, h2.UnmarshalRLP(data))
 	assert.Equal(t, h.Hash, h2.Hash)
 }
+
+func TestRLPMarshall_And_Unmarshall_DynamicFeeTransaction(t *testing.T) {
+	addrTo := StringToAddress("11")
+	txn := &Transaction{
+		Type:      DynamicFeeTx,
+		ChainID:   big.NewInt(2000),
+		Nonce:     1,
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(11),
+		Gas:       11,
+		To:        &addrTo,
+		Value:     big.NewInt(1),
+		Input:     []byte{1, 2},
+		AccessList: AccessList{
+			{Address: addrTo, StorageKeys: []Hash{StringToHash("1")}},
+		},
+		V: big.NewInt(1),
+		S: big.NewInt(26),
+		R: big.NewInt(27),
+	}
+	txn.ComputeHash()
+
+	// the envelope starts with the transaction type
+	marshaledRlp := txn.MarshalRLP()
+	assert.Equal(t, byte(DynamicFeeTx), marshaledRlp[0])
+
+	unmarshalledTxn := new(Transaction)
+	if err := unmarshalledTxn.UnmarshalRLP(marshaledRlp); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, txn.Hash, unmarshalledTxn.Hash)
+	assert.Equal(t, txn.AccessList, unmarshalledTxn.AccessList)
+	assert.Equal(t, marshaledRlp, unmarshalledTxn.MarshalRLP())
+
+	// typed transactions are embedded in block bodies as byte strings
+	body := &Body{Transactions: []*Transaction{txn}}
+	unmarshalledBody := new(Body)
+
+	if err := unmarshalledBody.UnmarshalRLP(body.MarshalRLPTo(nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, unmarshalledBody.Transactions, 1)
+	assert.Equal(t, txn.Hash, unmarshalledBody.Transactions[0].Hash)
+	assert.Equal(t, DynamicFeeTx, unmarshalledBody.Transactions[0].Type)
+}
+
+func TestRLPMarshall_And_Unmarshall_AccessListTransaction(t *testing.T) {
+	addrTo := StringToAddress("11")
+	txn := &Transaction{
+		Type:     AccessListTx,
+		ChainID:  big.NewInt(2000),
+		Nonce:    1,
+		GasPrice: big.NewInt(11),
+		Gas:      11,
+		To:       &addrTo,
+		Value:    big.NewInt(1),
+		Input:    []byte{1, 2},
+		AccessList: AccessList{
+			{Address: addrTo, StorageKeys: []Hash{StringToHash("1"), StringToHash("2")}},
+			{Address: StringToAddress("12"), StorageKeys: []Hash{}},
+		},
+		V: big.NewInt(0),
+		S: big.NewInt(26),
+		R: big.NewInt(27),
+	}
+	txn.ComputeHash()
+
+	marshaledRlp := txn.MarshalRLP()
+	assert.Equal(t, byte(AccessListTx), marshaledRlp[0])
+
+	unmarshalledTxn := new(Transaction)
+	if err := unmarshalledTxn.UnmarshalRLP(marshaledRlp); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, txn.Hash, unmarshalledTxn.Hash)
+	assert.Equal(t, AccessListTx, unmarshalledTxn.Type)
+	assert.Equal(t, txn.GasPrice, unmarshalledTxn.GasPrice)
+	assert.Equal(t, txn.GasPrice, unmarshalledTxn.GetGasFeeCap())
+	assert.Len(t, unmarshalledTxn.AccessList, 2)
+	assert.Equal(t, txn.AccessList[0], unmarshalledTxn.AccessList[0])
+	assert.Equal(t, marshaledRlp, unmarshalledTxn.MarshalRLP())
+}
+
+func TestRLPUnmarshal_Header_BaseFee(t *testing.T) {
+	header := &Header{Number: 10, BaseFee: 875000000}
+
+	unmarshalledHeader := new(Header)
+	if err := unmarshalledHeader.UnmarshalRLP(header.MarshalRLP()); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, header.BaseFee, unmarshalledHeader.BaseFee)
+}
@@ -1,12 +1,18 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
+	"github.com/dogechain-lab/dogechain/helper/keccak"
 	"github.com/dogechain-lab/fastrlp"
 )
 
+var (
+	ErrTxTypeNotSupported = errors.New("transaction type not supported")
+)
+
 type RLPUnmarshaler interface {
 	UnmarshalRLP(input []byte) error
 }
@@ -167,6 +173,14 @@ func (h *Header) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	h.SetNonce(nonce)
 
+	// baseFee, only part of the london headers
+	h.BaseFee = 0
+	if len(elems) > 15 {
+		if h.BaseFee, err = elems[15].GetUint64(); err != nil {
+			return err
+		}
+	}
+
 	// compute the hash after the decoding
 	h.ComputeHash()
 
@@ -292,12 +306,28 @@ func (l *Log) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 	return nil
 }
 
+// UnmarshalRLP unmarshals a Transaction from its canonical encoding,
+// either a legacy RLP list or a typed transaction envelope (EIP-2718)
 func (t *Transaction) UnmarshalRLP(input []byte) error {
+	if len(input) > 0 && input[0] <= maxTxType {
+		return t.unmarshalTyped(input)
+	}
+
 	return UnmarshalRlp(t.UnmarshalRLPFrom, input)
 }
 
 // UnmarshalRLP unmarshals a Transaction in RLP format
 func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	if v.Type() == fastrlp.TypeBytes {
+		// typed transactions are embedded in a block as a byte string of their envelope
+		envelope, err := v.Bytes()
+		if err != nil {
+			return err
+		}
+
+		return t.unmarshalTyped(envelope)
+	}
+
 	elems, err := v.GetElems()
 	if err != nil {
 		return err
@@ -310,6 +340,8 @@ func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 
 	p.Hash(t.Hash[:0], v)
 
+	t.Type = LegacyTx
+
 	// nonce
 	if t.Nonce, err = elems[0].GetUint64(); err != nil {
 		return err
@@ -361,3 +393,233 @@ func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 
 	return nil
 }
+
+// maxTxType is the highest first byte of a typed transaction envelope,
+// the first byte of a legacy transaction is always an RLP list prefix
+const maxTxType = 0x7f
+
+// unmarshalTyped unmarshals a typed transaction envelope, the type byte followed by the RLP payload
+func (t *Transaction) unmarshalTyped(envelope []byte) error {
+	if len(envelope) == 0 {
+		return fmt.Errorf("empty typed transaction")
+	}
+
+	switch txType := TxType(envelope[0]); txType {
+	case AccessListTx:
+		if err := UnmarshalRlp(t.unmarshalAccessListRLPFrom, envelope[1:]); err != nil {
+			return err
+		}
+	case DynamicFeeTx:
+		if err := UnmarshalRlp(t.unmarshalDynamicFeeRLPFrom, envelope[1:]); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: %d", ErrTxTypeNotSupported, txType)
+	}
+
+	// the hash of a typed transaction covers its envelope
+	keccak.Keccak256(t.Hash[:0], envelope)
+
+	return nil
+}
+
+// unmarshalAccessListRLPFrom unmarshals the payload of an access list transaction
+func (t *Transaction) unmarshalAccessListRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 11 {
+		return fmt.Errorf("incorrect number of elements to decode access list transaction, expected 11 but found %d",
+			len(elems))
+	}
+
+	t.Type = AccessListTx
+	t.GasTipCap = nil
+	t.GasFeeCap = nil
+
+	// chainID
+	t.ChainID = new(big.Int)
+	if err := elems[0].GetBigInt(t.ChainID); err != nil {
+		return err
+	}
+	// nonce
+	if t.Nonce, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+	// gasPrice
+	t.GasPrice = new(big.Int)
+	if err := elems[2].GetBigInt(t.GasPrice); err != nil {
+		return err
+	}
+	// gas
+	if t.Gas, err = elems[3].GetUint64(); err != nil {
+		return err
+	}
+	// to
+	if vv, _ := elems[4].Bytes(); len(vv) == 20 {
+		// address
+		addr := BytesToAddress(vv)
+		t.To = &addr
+	} else {
+		// reset To
+		t.To = nil
+	}
+	// value
+	t.Value = new(big.Int)
+	if err := elems[5].GetBigInt(t.Value); err != nil {
+		return err
+	}
+	// input
+	if t.Input, err = elems[6].GetBytes(t.Input[:0]); err != nil {
+		return err
+	}
+	// accessList
+	t.AccessList = nil
+	if err := t.AccessList.unmarshalRLPFrom(p, elems[7]); err != nil {
+		return err
+	}
+
+	// V
+	t.V = new(big.Int)
+	if err = elems[8].GetBigInt(t.V); err != nil {
+		return err
+	}
+	// R
+	t.R = new(big.Int)
+	if err = elems[9].GetBigInt(t.R); err != nil {
+		return err
+	}
+	// S
+	t.S = new(big.Int)
+	if err = elems[10].GetBigInt(t.S); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// unmarshalDynamicFeeRLPFrom unmarshals the payload of a dynamic fee transaction
+func (t *Transaction) unmarshalDynamicFeeRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 12 {
+		return fmt.Errorf("incorrect number of elements to decode dynamic fee transaction, expected 12 but found %d",
+			len(elems))
+	}
+
+	t.Type = DynamicFeeTx
+	t.GasPrice = nil
+
+	// chainID
+	t.ChainID = new(big.Int)
+	if err := elems[0].GetBigInt(t.ChainID); err != nil {
+		return err
+	}
+	// nonce
+	if t.Nonce, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+	// gasTipCap
+	t.GasTipCap = new(big.Int)
+	if err := elems[2].GetBigInt(t.GasTipCap); err != nil {
+		return err
+	}
+	// gasFeeCap
+	t.GasFeeCap = new(big.Int)
+	if err := elems[3].GetBigInt(t.GasFeeCap); err != nil {
+		return err
+	}
+	// gas
+	if t.Gas, err = elems[4].GetUint64(); err != nil {
+		return err
+	}
+	// to
+	if vv, _ := elems[5].Bytes(); len(vv) == 20 {
+		// address
+		addr := BytesToAddress(vv)
+		t.To = &addr
+	} else {
+		// reset To
+		t.To = nil
+	}
+	// value
+	t.Value = new(big.Int)
+	if err := elems[6].GetBigInt(t.Value); err != nil {
+		return err
+	}
+	// input
+	if t.Input, err = elems[7].GetBytes(t.Input[:0]); err != nil {
+		return err
+	}
+	// accessList
+	t.AccessList = nil
+	if err := t.AccessList.unmarshalRLPFrom(p, elems[8]); err != nil {
+		return err
+	}
+
+	// V
+	t.V = new(big.Int)
+	if err = elems[9].GetBigInt(t.V); err != nil {
+		return err
+	}
+	// R
+	t.R = new(big.Int)
+	if err = elems[10].GetBigInt(t.R); err != nil {
+		return err
+	}
+	// S
+	t.S = new(big.Int)
+	if err = elems[11].GetBigInt(t.S); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (al *AccessList) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range elems {
+		tupleElems, err := elem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(tupleElems) != 2 {
+			return fmt.Errorf("incorrect number of elements to decode access tuple, expected 2 but found %d",
+				len(tupleElems))
+		}
+
+		tuple := AccessTuple{}
+
+		if err := tupleElems[0].GetAddr(tuple.Address[:]); err != nil {
+			return err
+		}
+
+		keyElems, err := tupleElems[1].GetElems()
+		if err != nil {
+			return err
+		}
+
+		for _, keyElem := range keyElems {
+			var key Hash
+			if err := keyElem.GetHash(key[:]); err != nil {
+				return err
+			}
+
+			tuple.StorageKeys = append(tuple.StorageKeys, key)
+		}
+
+		*al = append(*al, tuple)
+	}
+
+	return nil
+}
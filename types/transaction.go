@@ -9,6 +9,43 @@ import (
 	"github.com/dogechain-lab/dogechain/helper/keccak"
 )
 
+// TxType is the type of the transaction envelope (EIP-2718)
+type TxType byte
+
+const (
+	// LegacyTx is the plain RLP list transaction
+	LegacyTx TxType = 0x0
+	// AccessListTx is the EIP-2930 transaction carrying an access list, paying a gas price
+	AccessListTx TxType = 0x1
+	// DynamicFeeTx is the EIP-1559 transaction paying a base fee and a tip
+	DynamicFeeTx TxType = 0x2
+)
+
+// AccessTuple is an address and the storage keys the transaction plans to access
+type AccessTuple struct {
+	Address     Address
+	StorageKeys []Hash
+}
+
+// AccessList is the list of addresses and storage keys the transaction plans to access
+type AccessList []AccessTuple
+
+// Copy returns a deep copy
+func (al AccessList) Copy() AccessList {
+	if al == nil {
+		return nil
+	}
+
+	cp := make(AccessList, len(al))
+
+	for i, tuple := range al {
+		cp[i].Address = tuple.Address
+		cp[i].StorageKeys = append([]Hash(nil), tuple.StorageKeys...)
+	}
+
+	return cp
+}
+
 type Transaction struct {
 	Nonce    uint64
 	GasPrice *big.Int
@@ -22,6 +59,15 @@ type Transaction struct {
 	Hash     Hash
 	From     Address
 
+	// Type is the envelope type, the following fields are only set for the typed
+	// transactions. The fee caps are only set for the dynamic fee transactions,
+	// which have no GasPrice
+	Type       TxType
+	ChainID    *big.Int
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	AccessList AccessList
+
 	// Cache
 	size atomic.Value
 
@@ -33,8 +79,63 @@ func (t *Transaction) IsContractCreation() bool {
 	return t.To == nil
 }
 
+// IsDynamicFee returns whether the transaction is an EIP-1559 transaction
+func (t *Transaction) IsDynamicFee() bool {
+	return t.Type == DynamicFeeTx
+}
+
+// GetGasFeeCap returns the maximum price per gas the transaction pays,
+// which is the gas price of a legacy transaction
+func (t *Transaction) GetGasFeeCap() *big.Int {
+	if t.IsDynamicFee() {
+		return t.GasFeeCap
+	}
+
+	return t.GasPrice
+}
+
+// GetGasTipCap returns the maximum tip per gas the transaction pays on top of the base fee,
+// which is the gas price of a legacy transaction
+func (t *Transaction) GetGasTipCap() *big.Int {
+	if t.IsDynamicFee() {
+		return t.GasTipCap
+	}
+
+	return t.GasPrice
+}
+
+// EffectiveGasPrice returns the price per gas the transaction pays in a block of the base fee,
+// which is the tip cap on top of the base fee, capped by the fee cap
+func (t *Transaction) EffectiveGasPrice(baseFee uint64) *big.Int {
+	if !t.IsDynamicFee() {
+		return new(big.Int).Set(t.GasPrice)
+	}
+
+	price := new(big.Int).Add(t.GasTipCap, new(big.Int).SetUint64(baseFee))
+	if price.Cmp(t.GasFeeCap) > 0 {
+		price.Set(t.GasFeeCap)
+	}
+
+	return price
+}
+
+// EffectiveTip returns the price per gas the block proposer earns from the transaction
+// in a block of the base fee. It is negative if the fee cap is lower than the base fee.
+func (t *Transaction) EffectiveTip(baseFee uint64) *big.Int {
+	tip := t.EffectiveGasPrice(baseFee)
+
+	return tip.Sub(tip, new(big.Int).SetUint64(baseFee))
+}
+
 // ComputeHash computes the hash of the transaction
 func (t *Transaction) ComputeHash() *Transaction {
+	if t.Type != LegacyTx {
+		// the hash of a typed transaction covers its envelope
+		keccak.Keccak256(t.Hash[:0], t.MarshalRLP())
+
+		return t
+	}
+
 	ar := marshalArenaPool.Get()
 	hash := keccak.DefaultKeccakPool.Get()
 
@@ -56,9 +157,9 @@ func (t *Transaction) Copy() *Transaction {
 		From:  t.From,
 	}
 
-	tt.GasPrice = new(big.Int)
+	// the dynamic fee transactions have no gas price
 	if t.GasPrice != nil {
-		tt.GasPrice.Set(t.GasPrice)
+		tt.GasPrice = new(big.Int).Set(t.GasPrice)
 	}
 
 	if t.To != nil {
@@ -88,14 +189,30 @@ func (t *Transaction) Copy() *Transaction {
 		tt.S = new(big.Int).SetBits(t.S.Bits())
 	}
 
+	tt.Type = t.Type
+
+	if t.ChainID != nil {
+		tt.ChainID = new(big.Int).Set(t.ChainID)
+	}
+
+	if t.GasTipCap != nil {
+		tt.GasTipCap = new(big.Int).Set(t.GasTipCap)
+	}
+
+	if t.GasFeeCap != nil {
+		tt.GasFeeCap = new(big.Int).Set(t.GasFeeCap)
+	}
+
+	tt.AccessList = t.AccessList.Copy()
+
 	tt.ReceivedTime = t.ReceivedTime
 
 	return tt
 }
 
-// Cost returns gas * gasPrice + value
+// Cost returns gas * gasFeeCap + value, the most the transaction could cost
 func (t *Transaction) Cost() *big.Int {
-	total := new(big.Int).Mul(t.GasPrice, new(big.Int).SetUint64(t.Gas))
+	total := new(big.Int).Mul(t.GetGasFeeCap(), new(big.Int).SetUint64(t.Gas))
 	total.Add(total, t.Value)
 
 	return total
@@ -121,43 +238,48 @@ func (t *Transaction) ExceedsBlockGasLimit(blockGasLimit uint64) bool {
 	return t.Gas > blockGasLimit
 }
 
+// IsUnderpriced returns whether the maximum price per gas is lower than the price limit
 func (t *Transaction) IsUnderpriced(priceLimit uint64) bool {
-	return t.GasPrice.Cmp(big.NewInt(0).SetUint64(priceLimit)) < 0
+	return t.GetGasFeeCap().Cmp(big.NewInt(0).SetUint64(priceLimit)) < 0
 }
 
 // TxByPriceAndTime implements both the sort and the heap interface, making it useful
 // for all at once sorting as well as individually adding and removing elements.
-type TxByPriceAndTime []*Transaction
+// Transactions are sorted by the tip they pay on top of the base fee.
+type TxByPriceAndTime struct {
+	txs     []*Transaction
+	baseFee uint64
+}
 
 func (s TxByPriceAndTime) Len() int {
-	return len(s)
+	return len(s.txs)
 }
 
 func (s TxByPriceAndTime) Less(i, j int) bool {
-	// If the prices are equal, use the time the transaction was first seen for deterministic sorting
-	cmp := s[i].GasPrice.Cmp(s[j].GasPrice)
+	// If the tips are equal, use the time the transaction was first seen for deterministic sorting
+	cmp := s.txs[i].EffectiveTip(s.baseFee).Cmp(s.txs[j].EffectiveTip(s.baseFee))
 	if cmp == 0 {
-		return s[i].ReceivedTime.Before(s[j].ReceivedTime)
+		return s.txs[i].ReceivedTime.Before(s.txs[j].ReceivedTime)
 	}
 
 	return cmp > 0
 }
 
 func (s TxByPriceAndTime) Swap(i, j int) {
-	s[i], s[j] = s[j], s[i]
+	s.txs[i], s.txs[j] = s.txs[j], s.txs[i]
 }
 
 func (s *TxByPriceAndTime) Push(x interface{}) {
 	if v, ok := x.(*Transaction); ok {
-		*s = append(*s, v)
+		s.txs = append(s.txs, v)
 	}
 }
 
 func (s *TxByPriceAndTime) Pop() interface{} {
-	old := *s
+	old := s.txs
 	n := len(old)
 	x := old[n-1]
-	*s = old[0 : n-1]
+	s.txs = old[0 : n-1]
 
 	return x
 }
@@ -180,16 +302,23 @@ type TransactionsByPriceAndNonce struct {
 }
 
 // NewTransactionsByPriceAndNonce creates a transaction set that can retrieve
-// price sorted transactions in a nonce-honouring way.
+// price sorted transactions in a nonce-honouring way. The price is the tip
+// paid on top of the base fee, which is zero before the london fork.
 //
 // Note, the input map is reowned so the caller should not interact any more with
 // if after providing it to the constructor.
-func NewTransactionsByPriceAndNonce(txs map[Address][]*Transaction) *TransactionsByPriceAndNonce {
+func NewTransactionsByPriceAndNonce(
+	txs map[Address][]*Transaction,
+	baseFee uint64,
+) *TransactionsByPriceAndNonce {
 	// Initialize a price and received time based heap with the head transactions
-	heads := make(TxByPriceAndTime, 0, len(txs))
+	heads := TxByPriceAndTime{
+		txs:     make([]*Transaction, 0, len(txs)),
+		baseFee: baseFee,
+	}
 
 	for from, accTxs := range txs {
-		heads = append(heads, accTxs[0])
+		heads.txs = append(heads.txs, accTxs[0])
 		txs[from] = accTxs[1:]
 	}
 
@@ -204,18 +333,18 @@ func NewTransactionsByPriceAndNonce(txs map[Address][]*Transaction) *Transaction
 
 // Peek returns the next transaction by price.
 func (t *TransactionsByPriceAndNonce) Peek() *Transaction {
-	if len(t.heads) == 0 {
+	if len(t.heads.txs) == 0 {
 		return nil
 	}
 
-	return t.heads[0]
+	return t.heads.txs[0]
 }
 
 // Shift replaces the current best head with the next one from the same account.
 func (t *TransactionsByPriceAndNonce) Shift() {
-	account := t.heads[0].From
+	account := t.heads.txs[0].From
 	if txs, ok := t.txs[account]; ok && len(txs) > 0 {
-		t.heads[0], t.txs[account] = txs[0], txs[1:]
+		t.heads.txs[0], t.txs[account] = txs[0], txs[1:]
 		heap.Fix(&t.heads, 0)
 
 		return
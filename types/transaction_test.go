@@ -27,6 +27,31 @@ func TestTransactionCopy(t *testing.T) {
 	}
 }
 
+func TestTransactionCopy_DynamicFee(t *testing.T) {
+	addrTo := StringToAddress("11")
+	txn := &Transaction{
+		Type:      DynamicFeeTx,
+		ChainID:   big.NewInt(100),
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(20),
+		Gas:       11,
+		To:        &addrTo,
+		Value:     big.NewInt(1),
+		V:         big.NewInt(1),
+		S:         big.NewInt(26),
+		R:         big.NewInt(27),
+	}
+	newTxn := txn.Copy()
+
+	if newTxn.GasPrice != nil {
+		t.Fatal("[ERROR] Copied dynamic fee transaction has a gas price")
+	}
+
+	if !reflect.DeepEqual(txn, newTxn) {
+		t.Fatal("[ERROR] Copied transaction not equal base transaction")
+	}
+}
+
 // Tests that if multiple transactions have the same price, the ones seen earlier
 // are prioritized to avoid network spam attacks aiming for a specific ordering.
 func TestTransactionTimeSort(t *testing.T) {
@@ -56,7 +81,7 @@ func TestTransactionTimeSort(t *testing.T) {
 		groups[addr] = append(groups[addr], tx)
 	}
 	// Sort the transactions and cross check the nonce ordering
-	txset := NewTransactionsByPriceAndNonce(groups)
+	txset := NewTransactionsByPriceAndNonce(groups, 0)
 
 	txs := []*Transaction{}
 